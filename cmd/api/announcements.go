@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"educnet/internal/usecase"
+)
+
+// ! announcementDeliveryInterval délai maximal entre publication programmée et envoi des emails
+const announcementDeliveryInterval = time.Minute
+
+// ! runAnnouncementDelivery diffuse les annonces arrivées à publication, jusqu'à l'annulation de ctx
+func runAnnouncementDelivery(ctx context.Context, announcementUC usecase.AnnouncementUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		delivered, err := announcementUC.DeliverScheduled(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "announcement delivery failed", "error", err)
+		} else if delivered > 0 {
+			slog.InfoContext(ctx, "announcements delivered", "count", delivered)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
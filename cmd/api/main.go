@@ -1,31 +1,69 @@
 package main
 
 import (
-	"log"
+	"context"
+	"errors"
+	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"educnet/internal/auth"
 	"educnet/internal/config"
 	"educnet/internal/db"
+	"educnet/internal/logging"
+	"educnet/internal/mailer"
+	"educnet/internal/metrics"
 	"educnet/internal/middleware"
+	"educnet/internal/migrate"
 	"educnet/internal/repository"
 	"educnet/internal/routes"
+	"educnet/internal/usecase"
+	"educnet/migrations"
 )
 
 func main() {
 	//! 1. Load configuration
 	cfg, err := config.Load()
 	if err != nil {
-		log.Fatal("Failed to load config:", err)
+		fatal("Failed to load config", err)
+	}
+
+	//! 1b. Logs JSON structurés (niveau LOG_LEVEL)
+	if err := logging.Setup(os.Stderr, cfg.Log.Level); err != nil {
+		fatal("Failed to configure logging", err)
 	}
 
 	//! 2. Connect to database
 	database, err := db.Connect(cfg.DSN())
 	if err != nil {
-		log.Fatal("Failed to connect to database:", err)
+		fatal("Failed to connect to database", err)
+	}
+
+	//! 2b. `api migrate up|down|status|baseline` : gestion du schéma puis sortie
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		err := runMigrate(context.Background(), database, os.Args[2:])
+		db.Close(database)
+		if err != nil {
+			fatal("Migration failed", err)
+		}
+		return
+	}
+
+	//! 2c. Migrations au démarrage (optionnel, verrou advisory si plusieurs instances).
+	//! Le migrator sert aussi à la sonde de readiness (schéma à jour).
+	migrator, err := migrate.New(database, migrations.FS)
+	if err != nil {
+		fatal("Failed to load migrations", err)
+	}
+	if cfg.Database.AutoMigrate {
+		applied, err := migrator.Up(context.Background())
+		if err != nil {
+			fatal("Failed to run migrations", err)
+		}
+		slog.Info("migrations up to date", "applied", len(applied))
 	}
-	defer db.Close(database)
-	log.Println("✅ Database connected successfully")
 
 	//! 3. Initialize JWT service
 	jwtService := auth.NewJWTService(
@@ -33,7 +71,7 @@ func main() {
 		cfg.JWT.AccessTokenTTL,
 		cfg.JWT.RefreshTokenTTL,
 	)
-	log.Printf("🔑 JWT configured (TTL: %dh)", cfg.JWT.AccessTokenTTL)
+	slog.Info("jwt configured", "access_ttl_hours", cfg.JWT.AccessTokenTTL)
 
 	//! 4. Initialize repositories
 	schoolRepo := repository.NewSchoolRepository(database)
@@ -43,11 +81,47 @@ func main() {
 	teacherSubjectRepo := repository.NewTeacherSubjectRepository(database)
 	studentClassRepo := repository.NewStudentClassRepository(database)
 	messageRepository := repository.NewMessageRepository(database)
-	//! 5. Setup router (all routes configured in routes package)
+	auditLogRepo := repository.NewAuditLogRepository(database)
+	invitationRepo := repository.NewInvitationRepository(database)
+	statsRepo := repository.NewStatsRepository(database)
+	gradeRepo := repository.NewGradeRepository(database)
+	attendanceRepo := repository.NewAttendanceRepository(database)
+	privacyRepo := repository.NewPrivacyRepository(database)
+	quizRepo := repository.NewQuizRepository(database)
+	resourceRepo := repository.NewResourceRepository(database)
+	calendarRepo := repository.NewCalendarRepository(database)
+	announcementRepo := repository.NewAnnouncementRepository(database)
+	roomRepo := repository.NewRoomRepository(database)
+	profileRepo := repository.NewProfileRepository(database)
+	studentNumberRepo := repository.NewStudentNumberRepository(database)
+	disciplineRepo := repository.NewDisciplineRepository(database)
+	rankingRepo := repository.NewRankingRepository(database)
+	mailService := mailer.New(cfg.SMTP)
+
+	//! 5. Bootstrap platform super-admin (optional, accès plateforme : hors de toute école)
+	if cfg.SuperAdmin.Email != "" && cfg.SuperAdmin.Password != "" {
+		superAdminUC := usecase.NewSuperAdminUseCase(userRepo, schoolRepo, auditLogRepo, jwtService)
+		err := db.InPlatformTx(context.Background(), database, func(ctx context.Context) error {
+			return superAdminUC.EnsureSuperAdmin(
+				ctx,
+				cfg.SuperAdmin.Email,
+				cfg.SuperAdmin.Password,
+				cfg.SuperAdmin.FirstName,
+				cfg.SuperAdmin.LastName,
+			)
+		})
+		if err != nil {
+			fatal("Failed to bootstrap super-admin", err)
+		}
+		slog.Info("super-admin ready", "email", cfg.SuperAdmin.Email)
+	}
+
+	//! 6. Setup router (all routes configured in routes package)
 	router := routes.NewRouter(
 		database,
 		jwtService,
 		cfg.JWT.Secret,
+		cfg.Server.FrontendURL,
 		schoolRepo,
 		userRepo,
 		subjectRepo,
@@ -55,16 +129,53 @@ func main() {
 		teacherSubjectRepo,
 		studentClassRepo,
 		messageRepository,
+		auditLogRepo,
+		invitationRepo,
+		statsRepo,
+		gradeRepo,
+		attendanceRepo,
+		privacyRepo,
+		quizRepo,
+		resourceRepo,
+		calendarRepo,
+		announcementRepo,
+		roomRepo,
+		profileRepo,
+		studentNumberRepo,
+		disciplineRepo,
+		rankingRepo,
+		mailService,
+		migrator,
+		metrics.NewRegistry(),
 	)
 
 	handler := middleware.CORS(router)
 
-	//! 6. Start server
-	addr := ":" + cfg.Server.Port
-	log.Printf("🚀 Server starting on http://localhost%s (env: %s)", addr, cfg.Server.Env)
-	log.Printf("📍 Health: http://localhost%s/api/health", addr)
+	//! 6b. Purge RGPD des messages selon la politique de rétention de chaque école
+	privacyUC := usecase.NewPrivacyUseCase(database, userRepo, schoolRepo, studentClassRepo, teacherSubjectRepo,
+		messageRepository, gradeRepo, attendanceRepo, privacyRepo, auditLogRepo, profileRepo, disciplineRepo)
+	//! SIGINT / SIGTERM annule ctx : arrêt des tâches de fond puis du serveur
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go runRetentionPurge(ctx, privacyUC, retentionPurgeInterval)
 
-	if err := http.ListenAndServe(addr, handler); err != nil {
-		log.Fatal("Failed to start server:", err)
+	//! 6c. Diffusion des annonces programmées
+	announcementUC := usecase.NewAnnouncementUseCase(database, userRepo, schoolRepo, classRepo, studentClassRepo,
+		announcementRepo, mailService, cfg.Server.FrontendURL)
+	go runAnnouncementDelivery(ctx, announcementUC, announcementDeliveryInterval)
+
+	//! 7. Start server
+	srv := newServer(cfg.Server, handler)
+	slog.Info("server starting", "addr", srv.Addr, "env", cfg.Server.Env)
+
+	if err := serve(ctx, srv, database, cfg.Server.ShutdownTimeout); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		fatal("Server stopped with error", err)
 	}
 }
+
+// ! fatal log l'erreur puis quitte (remplace log.Fatal, sortie JSON)
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"educnet/internal/migrate"
+	"educnet/migrations"
+)
+
+const migrateUsage = `usage: api migrate <command>
+
+  up              applique les migrations en attente
+  down [N]        annule les N dernières migrations (défaut 1)
+  status          liste les migrations et leur état
+  baseline <V>    marque les migrations <= V comme appliquées sans les exécuter
+                  (base créée à la main avant le runner)`
+
+// ! runMigrate sous-commande `api migrate ...`
+func runMigrate(ctx context.Context, database *sql.DB, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%s", migrateUsage)
+	}
+
+	migrator, err := migrate.New(database, migrations.FS)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d migration(s) applied\n", len(applied))
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+				return fmt.Errorf("invalid step count %q", args[1])
+			}
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d migration(s) reverted\n", len(reverted))
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+		for _, s := range statuses {
+			state, appliedAt := "pending", ""
+			if s.Applied {
+				state, appliedAt = "applied", s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			if s.ChecksumMismatch {
+				state = "MODIFIED"
+			}
+			fmt.Fprintf(w, "%03d\t%s\t%s\t%s\n", s.Version, s.Name, state, appliedAt)
+		}
+		return w.Flush()
+
+	case "baseline":
+		if len(args) < 2 {
+			return fmt.Errorf("%s", migrateUsage)
+		}
+		version, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid version %q", args[1])
+		}
+		marked, err := migrator.Baseline(ctx, version)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d migration(s) marked as applied\n", len(marked))
+
+	default:
+		return fmt.Errorf("%s", migrateUsage)
+	}
+	return nil
+}
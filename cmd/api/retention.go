@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"educnet/internal/usecase"
+)
+
+// ! retentionPurgeInterval fréquence de la purge des messages expirés
+const retentionPurgeInterval = 24 * time.Hour
+
+// ! runRetentionPurge purge au démarrage puis à chaque intervalle, jusqu'à l'annulation de ctx
+func runRetentionPurge(ctx context.Context, privacyUC usecase.PrivacyUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := privacyUC.PurgeExpiredMessages(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "retention purge failed", "error", err)
+		} else if purged > 0 {
+			slog.InfoContext(ctx, "retention purge", "messages_deleted", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"educnet/internal/config"
+	"educnet/internal/db"
+	"educnet/internal/websocket"
+)
+
+// ! newServer http.Server avec timeouts (évite les connexions lentes qui monopolisent le serveur)
+func newServer(cfg config.ServerConfig, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              ":" + cfg.Port,
+		Handler:           handler,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		ReadTimeout:       cfg.ReadTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+		ErrorLog:          slog.NewLogLogger(slog.Default().Handler(), slog.LevelWarn),
+	}
+}
+
+// ! serve écoute jusqu'à l'annulation de ctx (SIGINT / SIGTERM) puis arrête proprement
+func serve(ctx context.Context, srv *http.Server, database *sql.DB, timeout time.Duration) error {
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		db.Close(database)
+		return err
+	case <-ctx.Done():
+	}
+
+	slog.Info("shutdown started", "timeout", timeout.String())
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return shutdown(shutdownCtx, srv, database)
+}
+
+// ! shutdown ordre : plus de nouvelles connexions et requêtes en cours, WebSockets
+// ! (trame 1012), pool SQL. Tout est borné par la deadline de ctx.
+func shutdown(ctx context.Context, srv *http.Server, database *sql.DB) error {
+	var errs []error
+
+	//! 1. Plus de nouvelles connexions (ni nouveaux WebSockets), attente des requêtes
+	//! en cours ; les connexions WebSocket détournées ne sont pas attendues ici
+	if err := srv.Shutdown(ctx); err != nil {
+		slog.Warn("http requests not drained", "error", err)
+		errs = append(errs, err)
+		srv.Close()
+	}
+
+	//! 2. Les clients reçoivent "server restarting" et se reconnectent ailleurs
+	if err := websocket.Shutdown(ctx); err != nil {
+		slog.Warn("websocket rooms not drained", "error", err)
+		errs = append(errs, err)
+	}
+
+	//! 3. sql.DB.Close attend les connexions rendues au pool
+	closed := make(chan error, 1)
+	go func() { closed <- db.Close(database) }()
+	select {
+	case err := <-closed:
+		if err != nil {
+			errs = append(errs, err)
+		}
+	case <-ctx.Done():
+		slog.Warn("database pool not drained", "error", ctx.Err())
+		errs = append(errs, ctx.Err())
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+	slog.Info("shutdown complete")
+	return nil
+}
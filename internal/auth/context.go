@@ -0,0 +1,22 @@
+package auth
+
+import "context"
+
+type contextKey string
+
+const impersonatorContextKey contextKey = "impersonator_id"
+
+// ! WithImpersonator marque la requête comme faite par un superadmin à la place de
+// ! l'utilisateur du token (posé par JWTAuth, lu par l'audit)
+func WithImpersonator(ctx context.Context, impersonatorID int) context.Context {
+	if impersonatorID <= 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, impersonatorContextKey, impersonatorID)
+}
+
+// ! ImpersonatorFromContext ID du superadmin impersonant, 0 hors impersonation
+func ImpersonatorFromContext(ctx context.Context) int {
+	id, _ := ctx.Value(impersonatorContextKey).(int)
+	return id
+}
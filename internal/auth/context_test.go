@@ -0,0 +1,19 @@
+package auth
+
+import (
+	"context"
+	"testing"
+)
+
+func TestImpersonatorContext(t *testing.T) {
+	ctx := context.Background()
+	if got := ImpersonatorFromContext(ctx); got != 0 {
+		t.Errorf("ImpersonatorFromContext() without impersonation = %d, want 0", got)
+	}
+	if got := ImpersonatorFromContext(WithImpersonator(ctx, 0)); got != 0 {
+		t.Errorf("ImpersonatorFromContext() with zero ID = %d, want 0", got)
+	}
+	if got := ImpersonatorFromContext(WithImpersonator(ctx, 9)); got != 9 {
+		t.Errorf("ImpersonatorFromContext() = %d, want 9", got)
+	}
+}
@@ -3,7 +3,6 @@ package auth
 import (
 	"errors"
 	"time"
-	"log"
 
 	"github.com/golang-jwt/jwt/v5"
 )
@@ -18,6 +17,22 @@ type JWTClaims struct {
 	Email    string `json:"email"`
 	Role     string `json:"role"`
 	SchoolID int    `json:"school_id"`
+	//! ImpersonatorID est renseigné quand un superadmin agit à la place de l'utilisateur
+	ImpersonatorID int `json:"impersonator_id,omitempty"`
+	//! PasswordChangeRequired token restreint : seul le changement de mot de passe est autorisé
+	PasswordChangeRequired bool `json:"password_change_required,omitempty"`
+	jwt.RegisteredClaims
+}
+
+//! invitationAudience distingue les tokens d'invitation des access tokens
+const invitationAudience = "invitation"
+
+//! InvitationClaims token signé envoyé dans le lien d'invitation
+type InvitationClaims struct {
+	InvitationID int    `json:"invitation_id"`
+	SchoolID     int    `json:"school_id"`
+	Email        string `json:"email"`
+	Role         string `json:"role"`
 	jwt.RegisteredClaims
 }
 
@@ -53,6 +68,46 @@ func (s *JWTService) GenerateAccessToken(userID int, email, role string, schoolI
 	return token.SignedString([]byte(s.secretKey))
 }
 
+//! GeneratePasswordChangeToken génère l'access token restreint d'un utilisateur qui
+//! doit changer son mot de passe (must_change_password) : refusé partout ailleurs
+func (s *JWTService) GeneratePasswordChangeToken(userID int, email, role string, schoolID int) (string, error) {
+	claims := JWTClaims{
+		UserID:                 userID,
+		Email:                  email,
+		Role:                   role,
+		SchoolID:               schoolID,
+		PasswordChangeRequired: true,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.accessTokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			NotBefore: jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(s.secretKey))
+}
+
+//! GenerateImpersonationToken génère un access token court pour un superadmin
+//! agissant en tant qu'admin d'école (impersonator_id conservé dans les claims)
+func (s *JWTService) GenerateImpersonationToken(userID int, email, role string, schoolID, impersonatorID int, ttl time.Duration) (string, error) {
+	claims := JWTClaims{
+		UserID:         userID,
+		Email:          email,
+		Role:           role,
+		SchoolID:       schoolID,
+		ImpersonatorID: impersonatorID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			NotBefore: jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(s.secretKey))
+}
+
 //! GenerateRefreshToken génère un refresh token JWT
 func (s *JWTService) GenerateRefreshToken(userID int, email string) (string, error) {
 	claims := jwt.RegisteredClaims{
@@ -67,35 +122,119 @@ func (s *JWTService) GenerateRefreshToken(userID int, email string) (string, err
 
 //! ValidateToken valide et parse un token JWT
 func (s *JWTService) ValidateToken(tokenString string) (*JWTClaims, error) {
-	log.Printf("[JWT] Validating token with secret: %s...", s.secretKey[:10]) // DEBUG
-	
 	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
 		// Vérifier la méthode de signature
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			log.Printf("[JWT] Invalid signing method: %v", token.Method) // DEBUG
 			return nil, ErrInvalidToken
 		}
 		return []byte(s.secretKey), nil
 	})
 
 	if err != nil {
-		log.Printf("[JWT] Parse error: %v", err) // DEBUG
 		return nil, err
 	}
 
 	claims, ok := token.Claims.(*JWTClaims)
 	if !ok || !token.Valid {
-		log.Printf("[JWT] Invalid claims or token not valid") // DEBUG
+		return nil, ErrInvalidToken
+	}
+
+	//! Un token d'invitation (audience) n'est pas un access token
+	if len(claims.Audience) > 0 {
 		return nil, ErrInvalidToken
 	}
 
 	// Vérifier l'expiration
 	if claims.ExpiresAt.Time.Before(time.Now()) {
-		log.Printf("[JWT] Token expired at: %v", claims.ExpiresAt.Time) // DEBUG
 		return nil, ErrExpiredToken
 	}
 
-	log.Printf("[JWT] Token valid! User ID: %d, Email: %s", claims.UserID, claims.Email) // DEBUG
 	return claims, nil
 }
 
+
+//! GenerateInvitationToken génère le token signé d'une invitation (expire avec elle)
+func (s *JWTService) GenerateInvitationToken(invitationID, schoolID int, email, role string, expiresAt time.Time) (string, error) {
+	claims := InvitationClaims{
+		InvitationID: invitationID,
+		SchoolID:     schoolID,
+		Email:        email,
+		Role:         role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Audience:  jwt.ClaimStrings{invitationAudience},
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(s.secretKey))
+}
+
+//! ValidateInvitationToken valide signature, audience et expiration d'un token d'invitation
+func (s *JWTService) ValidateInvitationToken(tokenString string) (*InvitationClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &InvitationClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, ErrInvalidToken
+		}
+		return []byte(s.secretKey), nil
+	}, jwt.WithAudience(invitationAudience))
+	if errors.Is(err, jwt.ErrTokenExpired) {
+		return nil, ErrExpiredToken
+	}
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	claims, ok := token.Claims.(*InvitationClaims)
+	if !ok || !token.Valid || claims.InvitationID <= 0 {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
+
+//! calendarAudience jeton du flux iCalendar personnel (sans expiration, révocable par version)
+const calendarAudience = "calendar"
+
+//! CalendarClaims token signé inclus dans l'URL d'abonnement .ics
+type CalendarClaims struct {
+	UserID   int `json:"user_id"`
+	SchoolID int `json:"school_id"`
+	Version  int `json:"version"`
+	jwt.RegisteredClaims
+}
+
+//! GenerateCalendarToken génère le token du flux .ics d'un utilisateur
+func (s *JWTService) GenerateCalendarToken(userID, schoolID, version int) (string, error) {
+	claims := CalendarClaims{
+		UserID:   userID,
+		SchoolID: schoolID,
+		Version:  version,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Audience: jwt.ClaimStrings{calendarAudience},
+			IssuedAt: jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(s.secretKey))
+}
+
+//! ValidateCalendarToken valide signature et audience ; la version est vérifiée par l'appelant
+func (s *JWTService) ValidateCalendarToken(tokenString string) (*CalendarClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &CalendarClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, ErrInvalidToken
+		}
+		return []byte(s.secretKey), nil
+	}, jwt.WithAudience(calendarAudience))
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	claims, ok := token.Claims.(*CalendarClaims)
+	if !ok || !token.Valid || claims.UserID <= 0 {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
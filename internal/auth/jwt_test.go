@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJWTService_InvitationToken(t *testing.T) {
+	service := NewJWTService("test-secret-key-1234", 1, 1)
+
+	token, err := service.GenerateInvitationToken(7, 3, "prof@test.mg", "teacher", time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("GenerateInvitationToken() error = %v", err)
+	}
+
+	claims, err := service.ValidateInvitationToken(token)
+	if err != nil {
+		t.Fatalf("ValidateInvitationToken() error = %v", err)
+	}
+	if claims.InvitationID != 7 || claims.SchoolID != 3 || claims.Email != "prof@test.mg" || claims.Role != "teacher" {
+		t.Errorf("ValidateInvitationToken() claims = %+v", claims)
+	}
+
+	//! Un token d'invitation ne doit pas servir d'access token (et inversement)
+	if _, err := service.ValidateToken(token); err == nil {
+		t.Error("ValidateToken() accepted an invitation token")
+	}
+	access, _ := service.GenerateAccessToken(1, "admin@test.mg", "admin", 3)
+	if _, err := service.ValidateInvitationToken(access); err != ErrInvalidToken {
+		t.Errorf("ValidateInvitationToken() access token error = %v, want %v", err, ErrInvalidToken)
+	}
+
+	//! Signature d'une autre clé
+	other := NewJWTService("another-secret-key-99", 1, 1)
+	if _, err := other.ValidateInvitationToken(token); err != ErrInvalidToken {
+		t.Errorf("ValidateInvitationToken() foreign signature error = %v, want %v", err, ErrInvalidToken)
+	}
+}
+
+func TestJWTService_InvitationTokenExpired(t *testing.T) {
+	service := NewJWTService("test-secret-key-1234", 1, 1)
+
+	token, _ := service.GenerateInvitationToken(7, 3, "prof@test.mg", "teacher", time.Now().Add(-time.Minute))
+	if _, err := service.ValidateInvitationToken(token); err != ErrExpiredToken {
+		t.Errorf("ValidateInvitationToken() expired error = %v, want %v", err, ErrExpiredToken)
+	}
+}
+
+func TestJWTService_CalendarToken(t *testing.T) {
+	service := NewJWTService("test-secret-key-1234", 1, 1)
+
+	token, err := service.GenerateCalendarToken(12, 3, 2)
+	if err != nil {
+		t.Fatalf("GenerateCalendarToken() error = %v", err)
+	}
+	claims, err := service.ValidateCalendarToken(token)
+	if err != nil {
+		t.Fatalf("ValidateCalendarToken() error = %v", err)
+	}
+	if claims.UserID != 12 || claims.SchoolID != 3 || claims.Version != 2 {
+		t.Errorf("ValidateCalendarToken() claims = %+v", claims)
+	}
+
+	//! Ni access token, ni token d'invitation
+	if _, err := service.ValidateToken(token); err == nil {
+		t.Error("ValidateToken() accepted a calendar token")
+	}
+	invitation, _ := service.GenerateInvitationToken(7, 3, "prof@test.mg", "teacher", time.Now().Add(time.Hour))
+	if _, err := service.ValidateCalendarToken(invitation); err != ErrInvalidToken {
+		t.Errorf("ValidateCalendarToken() invitation token error = %v, want %v", err, ErrInvalidToken)
+	}
+}
+
+func TestJWTService_PasswordChangeToken(t *testing.T) {
+	service := NewJWTService("test-secret-key-1234", 1, 1)
+
+	token, err := service.GeneratePasswordChangeToken(5, "eleve@test.mg", "student", 3)
+	if err != nil {
+		t.Fatalf("GeneratePasswordChangeToken() error = %v", err)
+	}
+	claims, err := service.ValidateToken(token)
+	if err != nil {
+		t.Fatalf("ValidateToken() error = %v", err)
+	}
+	if !claims.PasswordChangeRequired || claims.UserID != 5 || claims.SchoolID != 3 {
+		t.Errorf("ValidateToken() claims = %+v, want restricted token", claims)
+	}
+
+	access, _ := service.GenerateAccessToken(5, "eleve@test.mg", "student", 3)
+	if claims, err := service.ValidateToken(access); err != nil || claims.PasswordChangeRequired {
+		t.Errorf("ValidateToken() access token = %+v, %v; want unrestricted", claims, err)
+	}
+}
@@ -0,0 +1,64 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// ! TTL cache mémoire clé/valeur avec expiration (ex: statistiques par école)
+type TTL[K comparable, V any] struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[K]entry[V]
+}
+
+type entry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// ! NewTTL crée un cache dont les entrées expirent après ttl
+func NewTTL[K comparable, V any](ttl time.Duration) *TTL[K, V] {
+	return &TTL[K, V]{ttl: ttl, now: time.Now, entries: map[K]entry[V]{}}
+}
+
+// ! Get retourne la valeur si elle existe et n'a pas expiré
+func (c *TTL[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok || !c.now().Before(e.expiresAt) {
+		delete(c.entries, key)
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+// ! Set enregistre la valeur et purge au passage les entrées expirées
+func (c *TTL[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for k, e := range c.entries {
+		if !now.Before(e.expiresAt) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = entry[V]{value: value, expiresAt: now.Add(c.ttl)}
+}
+
+// ! DeleteFunc supprime les entrées dont la clé vérifie match (ex: toutes celles d'une école)
+func (c *TTL[K, V]) DeleteFunc(match func(key K) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for k := range c.entries {
+		if match(k) {
+			delete(c.entries, k)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTTL_Expiration(t *testing.T) {
+	now := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	c := NewTTL[string, int](time.Minute)
+	c.now = func() time.Time { return now }
+
+	c.Set("a", 1)
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Errorf("Get(a) = %v, %v; want 1, true", v, ok)
+	}
+
+	now = now.Add(time.Minute)
+	if _, ok := c.Get("a"); ok {
+		t.Error("Get(a) after TTL = found, want expired")
+	}
+	if _, ok := c.Get("missing"); ok {
+		t.Error("Get(missing) = found")
+	}
+}
+
+func TestTTL_DeleteFunc(t *testing.T) {
+	c := NewTTL[int, string](time.Hour)
+	c.Set(1, "school 1")
+	c.Set(2, "school 2")
+
+	c.DeleteFunc(func(key int) bool { return key == 1 })
+
+	if _, ok := c.Get(1); ok {
+		t.Error("Get(1) after DeleteFunc = found")
+	}
+	if _, ok := c.Get(2); !ok {
+		t.Error("Get(2) after DeleteFunc = missing")
+	}
+}
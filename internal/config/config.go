@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -11,6 +12,9 @@ type Config struct {
 	Database DatabaseConfig
 	Server   ServerConfig
 	JWT JWTConfig
+	SuperAdmin SuperAdminConfig
+	SMTP SMTPConfig
+	Log LogConfig
 }
 
 type DatabaseConfig struct {
@@ -20,11 +24,22 @@ type DatabaseConfig struct {
 	Password string
 	DBName   string
 	SSLMode  string
+	//! AutoMigrate applique les migrations embarquées au démarrage
+	AutoMigrate bool
 }
 
 type ServerConfig struct {
 	Port string
 	Env  string
+	//! FrontendURL base des liens envoyés par email (invitations)
+	FrontendURL string
+	//! Timeouts du http.Server (les WebSockets détournées n'y sont pas soumises)
+	ReadHeaderTimeout time.Duration
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	//! ShutdownTimeout délai max pour drainer requêtes, WebSockets et pool SQL (SIGTERM)
+	ShutdownTimeout time.Duration
 }
 
 type JWTConfig struct {
@@ -34,6 +49,28 @@ type JWTConfig struct {
 
 }
 
+//! SuperAdminConfig compte super-admin créé au démarrage s'il n'existe pas
+type SuperAdminConfig struct {
+	Email     string
+	Password  string
+	FirstName string
+	LastName  string
+}
+
+//! SMTPConfig serveur d'envoi d'emails (vide = emails désactivés, loggés)
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+//! LogConfig logs JSON sur stderr ; Level: debug | info | warn | error
+type LogConfig struct {
+	Level string
+}
+
 //! Load charge la configuration depuis .env
 func Load() (*Config, error) {
 	_ = godotenv.Load()
@@ -42,20 +79,43 @@ func Load() (*Config, error) {
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
 			Port:     getEnv("DB_PORT", "5432"),
-			User:     getEnv("DB_USER", "postgres"),
+			User:     getEnv("DB_USER", "educnet_app"),
 			Password: getEnv("DB_PASSWORD", ""),
 			DBName:   getEnv("DB_NAME", "educnet"),
 			SSLMode:  getEnv("DB_SSLMODE", "disable"),
+			AutoMigrate: getEnv("DB_AUTO_MIGRATE", "false") == "true",
 		},
 		Server: ServerConfig{
 			Port: getEnv("PORT", "8080"),
 			Env:  getEnv("ENV", "development"),
+			FrontendURL: getEnv("FRONTEND_URL", "http://localhost:3000"),
+			ReadHeaderTimeout: getDurationEnv("SERVER_READ_HEADER_TIMEOUT", 5*time.Second),
+			ReadTimeout:       getDurationEnv("SERVER_READ_TIMEOUT", 30*time.Second),
+			WriteTimeout:      getDurationEnv("SERVER_WRITE_TIMEOUT", 120*time.Second),
+			IdleTimeout:       getDurationEnv("SERVER_IDLE_TIMEOUT", 120*time.Second),
+			ShutdownTimeout:   getDurationEnv("SERVER_SHUTDOWN_TIMEOUT", 20*time.Second),
 		},
 		JWT: JWTConfig{
 			Secret: getEnv("JWT_SECRET", "supersecretkey"),
 			AccessTokenTTL:   24,  // 24 heures
 			RefreshTokenTTL:  30,  // 30 jours
 		},
+		SuperAdmin: SuperAdminConfig{
+			Email:     getEnv("SUPERADMIN_EMAIL", ""),
+			Password:  getEnv("SUPERADMIN_PASSWORD", ""),
+			FirstName: getEnv("SUPERADMIN_FIRST_NAME", "Super"),
+			LastName:  getEnv("SUPERADMIN_LAST_NAME", "Admin"),
+		},
+		SMTP: SMTPConfig{
+			Host:     getEnv("SMTP_HOST", ""),
+			Port:     getEnv("SMTP_PORT", "587"),
+			Username: getEnv("SMTP_USERNAME", ""),
+			Password: getEnv("SMTP_PASSWORD", ""),
+			From:     getEnv("SMTP_FROM", "no-reply@educnet.mg"),
+		},
+		Log: LogConfig{
+			Level: getEnv("LOG_LEVEL", "info"),
+		},
 	}
 
 	return cfg, nil
@@ -82,4 +142,12 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+//! getDurationEnv durée au format Go (30s, 2m) ; fallback si absente ou invalide
+func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	if value, err := time.ParseDuration(os.Getenv(key)); err == nil && value > 0 {
+		return value
+	}
+	return defaultValue
+}
+
 
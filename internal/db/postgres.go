@@ -3,7 +3,7 @@ package db
 import (
 	"database/sql"
 	"fmt"
-	"log"
+	"log/slog"
 
 	_ "github.com/lib/pq"
 )
@@ -22,7 +22,14 @@ func Connect(dsn string) (*sql.DB, error) {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	log.Println("Database connected successfully")
+	slog.Info("database connected")
+
+	//! Un rôle SUPERUSER ou BYPASSRLS ignore l'isolation entre écoles (policies RLS)
+	var bypassRLS bool
+	err = db.QueryRow(`SELECT rolsuper OR rolbypassrls FROM pg_roles WHERE rolname = current_user`).Scan(&bypassRLS)
+	if err == nil && bypassRLS {
+		slog.Warn("database role bypasses row-level security, connect with a non-superuser application role")
+	}
 
 	return db, nil
 }
@@ -30,7 +37,7 @@ func Connect(dsn string) (*sql.DB, error) {
 //! Close ferme proprement la connexion
 func Close(db *sql.DB) error {
 	if db != nil {
-		log.Println("Closing database connection")
+		slog.Info("closing database connection")
 		return db.Close()
 	}
 
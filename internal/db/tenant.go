@@ -0,0 +1,184 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+)
+
+// ! Executor est implémenté par *sql.DB et *sql.Tx
+type Executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type txContextKey struct{}
+
+// ! txState transaction du context + callbacks à exécuter après commit
+type txState struct {
+	tx          *sql.Tx
+	afterCommit []func()
+}
+
+// ! WithTx attache une transaction au context (utilisée par tous les repositories)
+func WithTx(ctx context.Context, tx *sql.Tx) context.Context {
+	return context.WithValue(ctx, txContextKey{}, &txState{tx: tx})
+}
+
+// ! TxFromContext récupère la transaction de la requête si elle existe
+func TxFromContext(ctx context.Context) (*sql.Tx, bool) {
+	state, ok := ctx.Value(txContextKey{}).(*txState)
+	if !ok {
+		return nil, false
+	}
+	return state.tx, true
+}
+
+// ! AfterCommit exécute fn une fois la transaction du context validée
+// ! (immédiatement s'il n'y a pas de transaction). Ex: emails, cache.
+func AfterCommit(ctx context.Context, fn func()) {
+	state, ok := ctx.Value(txContextKey{}).(*txState)
+	if !ok {
+		fn()
+		return
+	}
+	state.afterCommit = append(state.afterCommit, fn)
+}
+
+// ! Commit valide la transaction du context puis exécute les callbacks AfterCommit
+func Commit(ctx context.Context) error {
+	state, ok := ctx.Value(txContextKey{}).(*txState)
+	if !ok {
+		return nil
+	}
+	if err := state.tx.Commit(); err != nil {
+		return err
+	}
+	for _, fn := range state.afterCommit {
+		fn()
+	}
+	return nil
+}
+
+// ! Conn retourne la transaction du context (scope tenant / RLS) ou le pool par défaut
+func Conn(ctx context.Context, pool *sql.DB) Executor {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx
+	}
+	return pool
+}
+
+// ! BeginTenantTx ouvre une transaction où app.school_id est fixé (SET LOCAL)
+// ! Les policies RLS filtrent alors toutes les tables scopées par école
+func BeginTenantTx(ctx context.Context, pool *sql.DB, schoolID int) (*sql.Tx, error) {
+	tx, err := pool.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tenant tx: %w", err)
+	}
+
+	//! set_config(..., true) == SET LOCAL, mais accepte un paramètre lié
+	if _, err := tx.ExecContext(ctx, `SELECT set_config('app.school_id', $1, true)`, strconv.Itoa(schoolID)); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("set tenant school_id: %w", err)
+	}
+	return tx, nil
+}
+
+// ! BeginPlatformTx ouvre une transaction plateforme : app.bypass_rls = on (SET LOCAL).
+// ! Les policies RLS y laissent voir toutes les écoles ; réservé au superadmin et aux
+// ! chemins cross-tenant (login, inscription, tâches de fond)
+func BeginPlatformTx(ctx context.Context, pool *sql.DB) (*sql.Tx, error) {
+	tx, err := pool.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin platform tx: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `SELECT set_config('app.bypass_rls', 'on', true)`); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("set platform bypass_rls: %w", err)
+	}
+	return tx, nil
+}
+
+// ! InTenantTx exécute fn dans une transaction scopée sur l'école (commit si fn réussit)
+func InTenantTx(ctx context.Context, pool *sql.DB, schoolID int, fn func(ctx context.Context) error) error {
+	if _, ok := TxFromContext(ctx); ok {
+		return fn(ctx)
+	}
+	if schoolID <= 0 {
+		return RunInTx(ctx, pool, fn) //! pas d'école : la RLS ne laisse voir aucune ligne scopée
+	}
+	return inScopedTx(ctx, fn, func() (*sql.Tx, error) {
+		return BeginTenantTx(ctx, pool, schoolID)
+	})
+}
+
+// ! InPlatformTx exécute fn dans une transaction plateforme (commit si fn réussit).
+// ! Dans une transaction existante, fn garde le scope de celle-ci.
+func InPlatformTx(ctx context.Context, pool *sql.DB, fn func(ctx context.Context) error) error {
+	if _, ok := TxFromContext(ctx); ok {
+		return fn(ctx)
+	}
+	return inScopedTx(ctx, fn, func() (*sql.Tx, error) {
+		return BeginPlatformTx(ctx, pool)
+	})
+}
+
+func inScopedTx(ctx context.Context, fn func(ctx context.Context) error, begin func() (*sql.Tx, error)) error {
+	tx, err := begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	txCtx := WithTx(ctx, tx)
+	if err := fn(txCtx); err != nil {
+		return err
+	}
+	return Commit(txCtx)
+}
+
+// ! RunInTx exécute fn dans une transaction. Si la requête en a déjà une
+// ! (scope tenant), fn tourne dans un SAVEPOINT : en cas d'erreur seules ses
+// ! écritures sont annulées, le commit se fera en fin de requête.
+func RunInTx(ctx context.Context, pool *sql.DB, fn func(ctx context.Context) error) error {
+	if tx, ok := TxFromContext(ctx); ok {
+		return inSavepoint(ctx, tx, fn)
+	}
+
+	tx, err := pool.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	txCtx := WithTx(ctx, tx)
+	if err := fn(txCtx); err != nil {
+		return err
+	}
+	return Commit(txCtx)
+}
+
+var savepointSeq atomic.Uint64
+
+func inSavepoint(ctx context.Context, tx *sql.Tx, fn func(ctx context.Context) error) error {
+	name := fmt.Sprintf("sp_%d", savepointSeq.Add(1))
+	if _, err := tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return fmt.Errorf("savepoint: %w", err)
+	}
+
+	if err := fn(ctx); err != nil {
+		if _, rbErr := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); rbErr != nil {
+			return fmt.Errorf("%w (rollback to savepoint: %v)", err, rbErr)
+		}
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name); err != nil {
+		return fmt.Errorf("release savepoint: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,233 @@
+package domain
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ! Cibles d'une annonce
+const (
+	AnnounceSchool  = "school"  //! toute l'école
+	AnnounceRole    = "role"    //! un rôle (TargetRole)
+	AnnounceClasses = "classes" //! élèves des classes ClassIDs
+	AnnounceLevels  = "levels"  //! élèves des classes des niveaux Levels
+	AnnounceParents = "parents" //! parents
+)
+
+const (
+	// ! AnnouncementMaxAttachments nombre maximal de pièces jointes par annonce
+	AnnouncementMaxAttachments = 5
+	// ! AnnouncementMaxFileSize taille maximale d'une pièce jointe (10 Mo)
+	AnnouncementMaxFileSize = 10 << 20
+)
+
+var announcementRoles = map[string]bool{
+	RoleAdmin: true, RoleTeacher: true, RoleStudent: true, RoleParent: true,
+}
+
+// ! Announcement annonce publiée par l'administration, visible de PublishAt à ExpiresAt.
+// ! NotifiedAt est renseigné quand les destinataires ont été prévenus par email.
+type Announcement struct {
+	ID          int        `json:"id"`
+	SchoolID    int        `json:"school_id"`
+	AuthorID    int        `json:"author_id"`
+	Title       string     `json:"title"`
+	Body        string     `json:"body"`
+	Target      string     `json:"target"`
+	TargetRole  string     `json:"target_role,omitempty"`
+	ClassIDs    []int      `json:"class_ids,omitempty"`
+	Levels      []string   `json:"levels,omitempty"`
+	PublishAt   time.Time  `json:"publish_at"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	RequiresAck bool       `json:"requires_ack"`
+	NotifiedAt  *time.Time `json:"notified_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// ! NewAnnouncement publishAt zéro = publication immédiate ; les listes inutiles à la cible sont vidées
+func NewAnnouncement(schoolID, authorID int, title, body, target, targetRole string, classIDs []int, levels []string, publishAt time.Time, expiresAt *time.Time, requiresAck bool) (*Announcement, error) {
+	title = strings.TrimSpace(title)
+	if title == "" || len(title) > 255 {
+		return nil, ErrAnnouncementTitleRequired
+	}
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, ErrAnnouncementBodyRequired
+	}
+
+	a := &Announcement{
+		SchoolID:    schoolID,
+		AuthorID:    authorID,
+		Title:       title,
+		Body:        body,
+		Target:      target,
+		RequiresAck: requiresAck,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	switch target {
+	case AnnounceSchool, AnnounceParents:
+	case AnnounceRole:
+		if !announcementRoles[targetRole] {
+			return nil, ErrAnnouncementInvalidTarget
+		}
+		a.TargetRole = targetRole
+	case AnnounceClasses:
+		a.ClassIDs = uniqueIDs(classIDs)
+		if len(a.ClassIDs) == 0 {
+			return nil, ErrAnnouncementInvalidTarget
+		}
+	case AnnounceLevels:
+		for _, level := range levels {
+			if level = strings.TrimSpace(level); level != "" && !containsString(a.Levels, level) {
+				a.Levels = append(a.Levels, level)
+			}
+		}
+		if len(a.Levels) == 0 {
+			return nil, ErrAnnouncementInvalidTarget
+		}
+	default:
+		return nil, ErrAnnouncementInvalidTarget
+	}
+
+	a.PublishAt = publishAt
+	if a.PublishAt.IsZero() {
+		a.PublishAt = a.CreatedAt
+	}
+	if expiresAt != nil && !expiresAt.After(a.PublishAt) {
+		return nil, ErrAnnouncementInvalidDates
+	}
+	a.ExpiresAt = expiresAt
+	return a, nil
+}
+
+// ! IsActive publiée et non expirée
+func (a *Announcement) IsActive(now time.Time) bool {
+	if now.Before(a.PublishAt) {
+		return false
+	}
+	return a.ExpiresAt == nil || now.Before(*a.ExpiresAt)
+}
+
+// ! Targets vrai si l'utilisateur fait partie des destinataires.
+// ! Les cibles classes / niveaux visent les élèves inscrits (classes = inscriptions actives).
+func (a *Announcement) Targets(role string, classes []*Class) bool {
+	switch a.Target {
+	case AnnounceSchool:
+		return announcementRoles[role]
+	case AnnounceRole:
+		return role == a.TargetRole
+	case AnnounceParents:
+		return role == RoleParent
+	case AnnounceClasses:
+		if role != RoleStudent {
+			return false
+		}
+		for _, class := range classes {
+			if containsInt(a.ClassIDs, class.ID) {
+				return true
+			}
+		}
+	case AnnounceLevels:
+		if role != RoleStudent {
+			return false
+		}
+		for _, class := range classes {
+			if containsString(a.Levels, class.Level) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ! AnnouncementAttachment pièce jointe, servie après contrôle d'accès
+type AnnouncementAttachment struct {
+	ID             int       `json:"id"`
+	AnnouncementID int       `json:"announcement_id"`
+	FileName       string    `json:"file_name"`
+	ContentType    string    `json:"content_type"`
+	Size           int64     `json:"size"`
+	StoragePath    string    `json:"-"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// ! NewAnnouncementAttachment mêmes types de fichiers que la bibliothèque de cours
+func NewAnnouncementAttachment(announcementID int, fileName, contentType string, size int64) (*AnnouncementAttachment, error) {
+	if size <= 0 {
+		return nil, ErrAnnouncementFileRequired
+	}
+	if size > AnnouncementMaxFileSize {
+		return nil, ErrAnnouncementFileTooLarge
+	}
+
+	fileName = sanitizeFileName(fileName)
+	if !resourceExtensions[strings.ToLower(filepath.Ext(fileName))] {
+		return nil, ErrAnnouncementFileType
+	}
+	if strings.TrimSpace(contentType) == "" {
+		contentType = "application/octet-stream"
+	}
+
+	return &AnnouncementAttachment{
+		AnnouncementID: announcementID,
+		FileName:       fileName,
+		ContentType:    contentType,
+		Size:           size,
+		CreatedAt:      time.Now(),
+	}, nil
+}
+
+// ! Ext extension du fichier (minuscules), utilisée pour le nom de stockage
+func (f *AnnouncementAttachment) Ext() string {
+	return strings.ToLower(filepath.Ext(f.FileName))
+}
+
+// ! AnnouncementReceipt lecture (et accusé de réception) d'une annonce par un utilisateur
+type AnnouncementReceipt struct {
+	AnnouncementID int        `json:"announcement_id"`
+	UserID         int        `json:"user_id"`
+	ReadAt         time.Time  `json:"read_at"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+}
+
+// ! AnnouncementRecipient destinataire et état de lecture (rapport de suivi)
+type AnnouncementRecipient struct {
+	UserID         int
+	FirstName      string
+	LastName       string
+	Email          string
+	Role           string
+	ReadAt         *time.Time
+	AcknowledgedAt *time.Time
+}
+
+func uniqueIDs(ids []int) []int {
+	var unique []int
+	for _, id := range ids {
+		if id > 0 && !containsInt(unique, id) {
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewAnnouncement(t *testing.T) {
+	publish := time.Date(2026, 9, 1, 8, 0, 0, 0, time.UTC)
+	before := publish.Add(-time.Hour)
+	after := publish.Add(24 * time.Hour)
+
+	tests := []struct {
+		name       string
+		title      string
+		body       string
+		target     string
+		targetRole string
+		classIDs   []int
+		levels     []string
+		expiresAt  *time.Time
+		wantErr    error
+	}{
+		{"Whole school", "Rentrée", "Bienvenue", AnnounceSchool, "", nil, nil, nil, nil},
+		{"Teachers", "Conseil", "Salle des profs", AnnounceRole, RoleTeacher, nil, nil, &after, nil},
+		{"Classes", "Sortie", "Car à 8h", AnnounceClasses, "", []int{3, 3, 4}, nil, nil, nil},
+		{"Levels", "Examen", "Brevet blanc", AnnounceLevels, "", nil, []string{" 3ème ", ""}, nil, nil},
+		{"Parents", "Réunion", "Jeudi 18h", AnnounceParents, "", nil, nil, nil, nil},
+		{"Empty title", " ", "Corps", AnnounceSchool, "", nil, nil, nil, ErrAnnouncementTitleRequired},
+		{"Empty body", "Titre", "", AnnounceSchool, "", nil, nil, nil, ErrAnnouncementBodyRequired},
+		{"Unknown target", "Titre", "Corps", "everyone", "", nil, nil, nil, ErrAnnouncementInvalidTarget},
+		{"Superadmin role", "Titre", "Corps", AnnounceRole, RoleSuperAdmin, nil, nil, nil, ErrAnnouncementInvalidTarget},
+		{"No class", "Titre", "Corps", AnnounceClasses, "", []int{0}, nil, nil, ErrAnnouncementInvalidTarget},
+		{"No level", "Titre", "Corps", AnnounceLevels, "", nil, []string{" "}, nil, ErrAnnouncementInvalidTarget},
+		{"Expires before publish", "Titre", "Corps", AnnounceSchool, "", nil, nil, &before, ErrAnnouncementInvalidDates},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := NewAnnouncement(1, 2, tt.title, tt.body, tt.target, tt.targetRole, tt.classIDs, tt.levels, publish, tt.expiresAt, false)
+			if err != tt.wantErr {
+				t.Fatalf("NewAnnouncement() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if tt.target == AnnounceClasses && len(a.ClassIDs) != 2 {
+				t.Errorf("ClassIDs = %v, want duplicates removed", a.ClassIDs)
+			}
+			if tt.target == AnnounceLevels && (len(a.Levels) != 1 || a.Levels[0] != "3ème") {
+				t.Errorf("Levels = %q, want [3ème]", a.Levels)
+			}
+		})
+	}
+}
+
+func TestNewAnnouncement_PublishNow(t *testing.T) {
+	a, err := NewAnnouncement(1, 2, "Info", "Corps", AnnounceSchool, "", []int{1}, nil, time.Time{}, nil, true)
+	if err != nil {
+		t.Fatalf("NewAnnouncement() error = %v", err)
+	}
+	if a.PublishAt.IsZero() || !a.IsActive(time.Now()) {
+		t.Errorf("PublishAt = %v, want immediate publication", a.PublishAt)
+	}
+	if a.ClassIDs != nil {
+		t.Errorf("ClassIDs = %v, want nil for a school-wide announcement", a.ClassIDs)
+	}
+}
+
+func TestAnnouncement_IsActive(t *testing.T) {
+	publish := time.Date(2026, 9, 1, 8, 0, 0, 0, time.UTC)
+	expires := publish.Add(48 * time.Hour)
+	a := &Announcement{PublishAt: publish, ExpiresAt: &expires}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want bool
+	}{
+		{"Scheduled", publish.Add(-time.Minute), false},
+		{"Published", publish, true},
+		{"Expired", expires, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := a.IsActive(tt.now); got != tt.want {
+				t.Errorf("IsActive() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnnouncement_Targets(t *testing.T) {
+	sixA := []*Class{{ID: 1, Level: "6ème"}}
+	thirdB := []*Class{{ID: 7, Level: "3ème"}}
+
+	tests := []struct {
+		name         string
+		announcement *Announcement
+		role         string
+		classes      []*Class
+		want         bool
+	}{
+		{"School reaches parents", &Announcement{Target: AnnounceSchool}, RoleParent, nil, true},
+		{"School excludes superadmin", &Announcement{Target: AnnounceSchool}, RoleSuperAdmin, nil, false},
+		{"Teachers only", &Announcement{Target: AnnounceRole, TargetRole: RoleTeacher}, RoleTeacher, nil, true},
+		{"Teachers only, student", &Announcement{Target: AnnounceRole, TargetRole: RoleTeacher}, RoleStudent, sixA, false},
+		{"Parents", &Announcement{Target: AnnounceParents}, RoleAdmin, nil, false},
+		{"Own class", &Announcement{Target: AnnounceClasses, ClassIDs: []int{1, 2}}, RoleStudent, sixA, true},
+		{"Other class", &Announcement{Target: AnnounceClasses, ClassIDs: []int{1, 2}}, RoleStudent, thirdB, false},
+		{"Class target, teacher", &Announcement{Target: AnnounceClasses, ClassIDs: []int{1}}, RoleTeacher, sixA, false},
+		{"Level", &Announcement{Target: AnnounceLevels, Levels: []string{"3ème"}}, RoleStudent, thirdB, true},
+		{"Other level", &Announcement{Target: AnnounceLevels, Levels: []string{"3ème"}}, RoleStudent, sixA, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.announcement.Targets(tt.role, tt.classes); got != tt.want {
+				t.Errorf("Targets(%s) = %v, want %v", tt.role, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewAnnouncementAttachment(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileName string
+		size     int64
+		wantErr  error
+	}{
+		{"PDF", "circulaire.pdf", 1024, nil},
+		{"Empty", "circulaire.pdf", 0, ErrAnnouncementFileRequired},
+		{"Too large", "video.mp4", AnnouncementMaxFileSize + 1, ErrAnnouncementFileTooLarge},
+		{"Executable", "setup.exe", 1024, ErrAnnouncementFileType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAnnouncementAttachment(1, tt.fileName, "", tt.size)
+			if err != tt.wantErr {
+				t.Errorf("NewAnnouncementAttachment() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
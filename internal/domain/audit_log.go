@@ -0,0 +1,53 @@
+package domain
+
+import "time"
+
+// ! Audit actions
+const (
+	AuditActionSchoolSuspended     = "school.suspended"
+	AuditActionSchoolReactivated   = "school.reactivated"
+	AuditActionImpersonation       = "school.impersonation"
+	AuditActionOwnershipTransfer   = "school.ownership_transfer"
+	AuditActionSuperAdminBootstrap = "superadmin.bootstrap"
+	AuditActionUserErased          = "user.erased"
+	AuditActionRetentionUpdated    = "retention.updated"
+	AuditActionMessagesPurged      = "retention.messages_purged"
+)
+
+// ! AuditLog trace une action sensible effectuée sur la plateforme
+type AuditLog struct {
+	ID           int64  `json:"id"`
+	ActorUserID  *int   `json:"actor_user_id,omitempty"`
+	Action       string `json:"action"`
+	SchoolID     *int   `json:"school_id,omitempty"`
+	TargetUserID *int   `json:"target_user_id,omitempty"`
+	//! ImpersonatorID superadmin ayant agi à la place de ActorUserID (impersonation)
+	ImpersonatorID *int      `json:"impersonator_id,omitempty"`
+	Details        string    `json:"details,omitempty"`
+	IPAddress      string    `json:"ip_address,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// ! NewAuditLog crée une entrée d'audit
+func NewAuditLog(actorUserID int, action string, schoolID, targetUserID int, details, ipAddress string) (*AuditLog, error) {
+	if action == "" {
+		return nil, ErrAuditActionRequired
+	}
+
+	log := &AuditLog{
+		Action:    action,
+		Details:   details,
+		IPAddress: ipAddress,
+		CreatedAt: time.Now(),
+	}
+	if actorUserID > 0 {
+		log.ActorUserID = &actorUserID
+	}
+	if schoolID > 0 {
+		log.SchoolID = &schoolID
+	}
+	if targetUserID > 0 {
+		log.TargetUserID = &targetUserID
+	}
+	return log, nil
+}
@@ -0,0 +1,26 @@
+package domain
+
+import "testing"
+
+func TestNewAuditLog(t *testing.T) {
+	log, err := NewAuditLog(1, AuditActionSchoolSuspended, 5, 0, "unpaid invoice", "127.0.0.1")
+	if err != nil {
+		t.Fatalf("NewAuditLog() unexpected error = %v", err)
+	}
+
+	if log.ActorUserID == nil || *log.ActorUserID != 1 {
+		t.Errorf("ActorUserID = %v, want 1", log.ActorUserID)
+	}
+	if log.SchoolID == nil || *log.SchoolID != 5 {
+		t.Errorf("SchoolID = %v, want 5", log.SchoolID)
+	}
+	if log.TargetUserID != nil {
+		t.Errorf("TargetUserID = %v, want nil", *log.TargetUserID)
+	}
+}
+
+func TestNewAuditLog_ActionRequired(t *testing.T) {
+	if _, err := NewAuditLog(1, "", 5, 0, "", ""); err != ErrAuditActionRequired {
+		t.Errorf("NewAuditLog() error = %v, want %v", err, ErrAuditActionRequired)
+	}
+}
@@ -0,0 +1,378 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ! Catégories d'événements du calendrier
+const (
+	EventHoliday = "holiday"
+	EventExam    = "exam"
+	EventMeeting = "meeting"
+	EventLesson  = "lesson" //! emploi du temps : cours récurrent d'une classe
+	EventOther   = "other"
+)
+
+// ! Public d'un événement (le personnel voit tout)
+const (
+	AudienceAll      = "all"
+	AudienceStaff    = "staff"
+	AudienceStudents = "students"
+	AudienceParents  = "parents"
+)
+
+const (
+	// ! EventMaxDuration durée maximale d'une occurrence (vacances incluses)
+	EventMaxDuration = 120 * 24 * time.Hour
+	// ! EventMaxOccurrences borne de l'expansion d'une récurrence sur une période
+	EventMaxOccurrences = 1000
+)
+
+var eventCategories = map[string]bool{
+	EventHoliday: true, EventExam: true, EventMeeting: true, EventLesson: true, EventOther: true,
+}
+
+var eventAudiences = map[string]bool{
+	AudienceAll: true, AudienceStaff: true, AudienceStudents: true, AudienceParents: true,
+}
+
+// ! IsEventCategory catégorie connue (filtre des listes)
+func IsEventCategory(category string) bool {
+	return eventCategories[category]
+}
+
+// ! Event événement de l'école, ou d'une classe si ClassID est renseigné.
+// ! Dates en UTC ; pour AllDay, EndsAt est exclusif (minuit du lendemain du dernier jour).
+type Event struct {
+	ID          int       `json:"id"`
+	SchoolID    int       `json:"school_id"`
+	ClassID     *int      `json:"class_id,omitempty"`
+	RoomID      *int      `json:"room_id,omitempty"` //! salle occupée (emploi du temps)
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Location    string    `json:"location"`
+	Category    string    `json:"category"`
+	Audience    string    `json:"audience"`
+	StartsAt    time.Time `json:"starts_at"`
+	EndsAt      time.Time `json:"ends_at"`
+	AllDay      bool      `json:"all_day"`
+	RRule       string    `json:"rrule,omitempty"`
+	CreatedBy   int       `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func NewEvent(schoolID int, classID *int, title, description, location, category, audience string, startsAt, endsAt time.Time, allDay bool, rrule string, createdBy int) (*Event, error) {
+	title = strings.TrimSpace(title)
+	if title == "" || len(title) > 255 {
+		return nil, ErrEventTitleRequired
+	}
+	if category == "" {
+		category = EventOther
+	}
+	if !eventCategories[category] {
+		return nil, ErrEventInvalidCategory
+	}
+	if audience == "" {
+		audience = AudienceAll
+	}
+	if !eventAudiences[audience] {
+		return nil, ErrEventInvalidAudience
+	}
+
+	startsAt, endsAt = startsAt.UTC(), endsAt.UTC()
+	if allDay {
+		startsAt = startsAt.Truncate(24 * time.Hour)
+		endsAt = endsAt.Truncate(24 * time.Hour)
+	}
+	if !endsAt.After(startsAt) || endsAt.Sub(startsAt) > EventMaxDuration {
+		return nil, ErrEventInvalidDates
+	}
+
+	rrule = strings.TrimSpace(strings.TrimPrefix(strings.ToUpper(rrule), "RRULE:"))
+	if rrule != "" {
+		rec, err := ParseRecurrence(rrule)
+		if err != nil {
+			return nil, err
+		}
+		rrule = rec.Format(allDay)
+	}
+
+	return &Event{
+		SchoolID:    schoolID,
+		ClassID:     classID,
+		Title:       title,
+		Description: strings.TrimSpace(description),
+		Location:    strings.TrimSpace(location),
+		Category:    category,
+		Audience:    audience,
+		StartsAt:    startsAt,
+		EndsAt:      endsAt,
+		AllDay:      allDay,
+		RRule:       rrule,
+		CreatedBy:   createdBy,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}, nil
+}
+
+// ! VisibleTo le personnel voit tout ; élèves et parents selon le public,
+// ! les événements de classe uniquement pour les élèves inscrits
+func (e *Event) VisibleTo(role string, classIDs []int) bool {
+	switch role {
+	case RoleAdmin, RoleTeacher:
+		return true
+	case RoleStudent:
+		if e.Audience != AudienceAll && e.Audience != AudienceStudents {
+			return false
+		}
+		if e.ClassID == nil {
+			return true
+		}
+		for _, id := range classIDs {
+			if id == *e.ClassID {
+				return true
+			}
+		}
+		return false
+	case RoleParent:
+		return e.ClassID == nil && (e.Audience == AudienceAll || e.Audience == AudienceParents)
+	}
+	return false
+}
+
+// ! Occurrences débuts des occurrences qui chevauchent [from, to)
+func (e *Event) Occurrences(from, to time.Time) []time.Time {
+	duration := e.EndsAt.Sub(e.StartsAt)
+	overlaps := func(start time.Time) bool {
+		return start.Before(to) && start.Add(duration).After(from)
+	}
+
+	if e.RRule == "" {
+		if overlaps(e.StartsAt) {
+			return []time.Time{e.StartsAt}
+		}
+		return nil
+	}
+
+	rec, err := ParseRecurrence(e.RRule)
+	if err != nil {
+		return nil
+	}
+	var starts []time.Time
+	rec.each(e.StartsAt, func(start time.Time) bool {
+		if !start.Before(to) {
+			return false
+		}
+		if overlaps(start) {
+			starts = append(starts, start)
+		}
+		return len(starts) < EventMaxOccurrences
+	})
+	return starts
+}
+
+// ! ==================== RECURRENCE (RRULE) ====================
+
+const (
+	FreqDaily   = "DAILY"
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+	FreqYearly  = "YEARLY"
+)
+
+var rruleWeekdays = map[string]time.Weekday{
+	"MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday, "TH": time.Thursday,
+	"FR": time.Friday, "SA": time.Saturday, "SU": time.Sunday,
+}
+
+// ! Recurrence sous-ensemble de RFC 5545 : FREQ, INTERVAL, COUNT, UNTIL, BYDAY (hebdomadaire)
+type Recurrence struct {
+	Freq     string
+	Interval int
+	Count    int
+	Until    time.Time
+	ByDay    []time.Weekday
+}
+
+// ! ParseRecurrence ex: FREQ=WEEKLY;BYDAY=MO,TH;UNTIL=20260630T000000Z
+func ParseRecurrence(rule string) (*Recurrence, error) {
+	rec := &Recurrence{Interval: 1}
+	for _, part := range strings.Split(strings.ToUpper(strings.TrimSpace(rule)), ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, ErrEventInvalidRecurrence
+		}
+
+		var err error
+		switch key {
+		case "FREQ":
+			if value != FreqDaily && value != FreqWeekly && value != FreqMonthly && value != FreqYearly {
+				return nil, ErrEventInvalidRecurrence
+			}
+			rec.Freq = value
+		case "INTERVAL":
+			rec.Interval, err = strconv.Atoi(value)
+			if err != nil || rec.Interval < 1 || rec.Interval > 365 {
+				return nil, ErrEventInvalidRecurrence
+			}
+		case "COUNT":
+			rec.Count, err = strconv.Atoi(value)
+			if err != nil || rec.Count < 1 || rec.Count > EventMaxOccurrences {
+				return nil, ErrEventInvalidRecurrence
+			}
+		case "UNTIL":
+			if rec.Until, err = parseRRuleTime(value); err != nil {
+				return nil, ErrEventInvalidRecurrence
+			}
+		case "BYDAY":
+			for _, code := range strings.Split(value, ",") {
+				day, ok := rruleWeekdays[code]
+				if !ok {
+					return nil, ErrEventInvalidRecurrence
+				}
+				rec.ByDay = append(rec.ByDay, day)
+			}
+		case "WKST":
+			if value != "MO" {
+				return nil, ErrEventInvalidRecurrence
+			}
+		default:
+			return nil, ErrEventInvalidRecurrence
+		}
+	}
+
+	if rec.Freq == "" || (rec.Count > 0 && !rec.Until.IsZero()) {
+		return nil, ErrEventInvalidRecurrence
+	}
+	if len(rec.ByDay) > 0 && rec.Freq != FreqWeekly {
+		return nil, ErrEventInvalidRecurrence
+	}
+	return rec, nil
+}
+
+var rruleDayCodes = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// ! Format forme canonique ; UNTIL suit le type de DTSTART (date si allDay, sinon UTC)
+func (r *Recurrence) Format(allDay bool) string {
+	parts := []string{"FREQ=" + r.Freq}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		if allDay {
+			parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102"))
+		} else {
+			parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+		}
+	}
+	if len(r.ByDay) > 0 {
+		codes := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			codes[i] = rruleDayCodes[day]
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	return strings.Join(parts, ";")
+}
+
+func parseRRuleTime(value string) (time.Time, error) {
+	if len(value) == len("20060102") {
+		t, err := time.Parse("20060102", value)
+		return t.Add(24*time.Hour - time.Second), err //! UNTIL date : jour inclus
+	}
+	return time.Parse("20060102T150405Z", value)
+}
+
+// ! each appelle fn pour chaque occurrence (dans l'ordre) tant que fn renvoie vrai.
+// ! Les dates inexistantes (31 du mois, 29 février) sont ignorées comme dans RFC 5545.
+func (r *Recurrence) each(start time.Time, fn func(time.Time) bool) {
+	count := 0
+	emit := func(t time.Time) bool {
+		if t.Before(start) {
+			return true
+		}
+		if !r.Until.IsZero() && t.After(r.Until) {
+			return false
+		}
+		count++
+		if !fn(t) {
+			return false
+		}
+		return r.Count == 0 || count < r.Count
+	}
+
+	//! Garde-fou : au plus ~30 ans de périodes quotidiennes
+	for period := 0; period < 11000; period++ {
+		n := period * r.Interval
+		switch r.Freq {
+		case FreqDaily:
+			if !emit(start.AddDate(0, 0, n)) {
+				return
+			}
+		case FreqWeekly:
+			if len(r.ByDay) == 0 {
+				if !emit(start.AddDate(0, 0, 7*n)) {
+					return
+				}
+				continue
+			}
+			//! Semaine commençant le lundi, jours dans l'ordre de la semaine
+			monday := start.AddDate(0, 0, -((int(start.Weekday())+6)%7)+7*n)
+			for offset := 0; offset < 7; offset++ {
+				day := monday.AddDate(0, 0, offset)
+				if weekdayIn(day.Weekday(), r.ByDay) && !emit(day) {
+					return
+				}
+			}
+		case FreqMonthly:
+			t := time.Date(start.Year(), start.Month()+time.Month(n), start.Day(),
+				start.Hour(), start.Minute(), start.Second(), 0, start.Location())
+			if t.Day() == start.Day() && !emit(t) {
+				return
+			}
+		case FreqYearly:
+			t := time.Date(start.Year()+n, start.Month(), start.Day(),
+				start.Hour(), start.Minute(), start.Second(), 0, start.Location())
+			if t.Month() == start.Month() && !emit(t) {
+				return
+			}
+		}
+	}
+}
+
+func weekdayIn(day time.Weekday, days []time.Weekday) bool {
+	for _, d := range days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// ! CalendarFeed jeton du flux iCalendar personnel ; incrémenter Version révoque l'ancien lien
+type CalendarFeed struct {
+	UserID    int       `json:"user_id"`
+	SchoolID  int       `json:"school_id"`
+	Version   int       `json:"version"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ! EventFilter événements de l'école chevauchant [From, To). Les événements de classe
+// ! sont tous inclus si ClassIDs est nil et ClassEventsBy nul, sinon seulement ceux des
+// ! classes ClassIDs ou créés par ClassEventsBy (emploi du temps d'un enseignant).
+type EventFilter struct {
+	SchoolID      int
+	From          time.Time
+	To            time.Time
+	ClassIDs      []int
+	ClassEventsBy int
+	ClassID       int //! uniquement cette classe (et les événements de l'école)
+	Category      string
+	WithRoom      bool //! uniquement les événements qui occupent une salle
+}
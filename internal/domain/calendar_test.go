@@ -0,0 +1,167 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func utc(year int, month time.Month, day, hour, min int) time.Time {
+	return time.Date(year, month, day, hour, min, 0, 0, time.UTC)
+}
+
+func TestNewEvent(t *testing.T) {
+	start := utc(2026, 9, 7, 8, 0)
+
+	tests := []struct {
+		name     string
+		title    string
+		category string
+		audience string
+		end      time.Time
+		rrule    string
+		wantErr  error
+	}{
+		{"Meeting", "Réunion parents", EventMeeting, AudienceParents, start.Add(2 * time.Hour), "", nil},
+		{"Defaults", "Sortie", "", "", start.Add(time.Hour), "", nil},
+		{"Weekly lesson", "Maths", EventLesson, AudienceStudents, start.Add(time.Hour), "RRULE:FREQ=WEEKLY;BYDAY=MO,TH", nil},
+		{"Empty title", " ", EventOther, AudienceAll, start.Add(time.Hour), "", ErrEventTitleRequired},
+		{"Unknown category", "Fête", "party", AudienceAll, start.Add(time.Hour), "", ErrEventInvalidCategory},
+		{"Unknown audience", "Fête", EventOther, "teachers", start.Add(time.Hour), "", ErrEventInvalidAudience},
+		{"Ends before start", "Fête", EventOther, AudienceAll, start, "", ErrEventInvalidDates},
+		{"Too long", "Fête", EventOther, AudienceAll, start.Add(EventMaxDuration + time.Hour), "", ErrEventInvalidDates},
+		{"Bad rule", "Maths", EventLesson, AudienceAll, start.Add(time.Hour), "FREQ=HOURLY", ErrEventInvalidRecurrence},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := NewEvent(1, nil, tt.title, "", "", tt.category, tt.audience, start, tt.end, false, tt.rrule, 2)
+			if err != tt.wantErr {
+				t.Fatalf("NewEvent() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (event.Category == "" || event.Audience == "") {
+				t.Errorf("NewEvent() category/audience not defaulted: %+v", event)
+			}
+		})
+	}
+}
+
+func TestNewEvent_AllDay(t *testing.T) {
+	event, err := NewEvent(1, nil, "Vacances", "", "", EventHoliday, AudienceAll,
+		utc(2026, 10, 24, 15, 30), utc(2026, 11, 2, 0, 0), true, "", 2)
+	if err != nil {
+		t.Fatalf("NewEvent() error = %v", err)
+	}
+	if !event.StartsAt.Equal(utc(2026, 10, 24, 0, 0)) {
+		t.Errorf("StartsAt = %v, want midnight", event.StartsAt)
+	}
+}
+
+func TestParseRecurrence(t *testing.T) {
+	tests := []struct {
+		rule    string
+		wantErr bool
+	}{
+		{"FREQ=DAILY", false},
+		{"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;UNTIL=20261231", false},
+		{"freq=monthly;count=10", false},
+		{"FREQ=YEARLY;UNTIL=20300101T000000Z", false},
+		{"INTERVAL=2", true},
+		{"FREQ=WEEKLY;BYDAY=XX", true},
+		{"FREQ=MONTHLY;BYDAY=MO", true},
+		{"FREQ=DAILY;COUNT=3;UNTIL=20261231", true},
+		{"FREQ=DAILY;INTERVAL=0", true},
+		{"FREQ=DAILY;BYMONTHDAY=1", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			_, err := ParseRecurrence(tt.rule)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseRecurrence(%q) error = %v, wantErr %v", tt.rule, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRecurrence_Format(t *testing.T) {
+	rec, _ := ParseRecurrence("byday=mo,th;freq=weekly;until=20261231;interval=1")
+	if got := rec.Format(false); got != "FREQ=WEEKLY;UNTIL=20261231T235959Z;BYDAY=MO,TH" {
+		t.Errorf("Format(false) = %q", got)
+	}
+	if got := rec.Format(true); got != "FREQ=WEEKLY;UNTIL=20261231;BYDAY=MO,TH" {
+		t.Errorf("Format(true) = %q", got)
+	}
+}
+
+func TestEvent_Occurrences(t *testing.T) {
+	//! Lundi 7 septembre 2026, 8h-9h
+	start := utc(2026, 9, 7, 8, 0)
+	event := func(rrule string) *Event {
+		return &Event{StartsAt: start, EndsAt: start.Add(time.Hour), RRule: rrule}
+	}
+
+	tests := []struct {
+		name  string
+		event *Event
+		from  time.Time
+		to    time.Time
+		want  []time.Time
+	}{
+		{"Single in range", event(""), utc(2026, 9, 7, 8, 30), utc(2026, 9, 8, 0, 0), []time.Time{start}},
+		{"Single out of range", event(""), utc(2026, 9, 8, 0, 0), utc(2026, 9, 9, 0, 0), nil},
+		{"Weekly by day", event("FREQ=WEEKLY;BYDAY=MO,TH"), utc(2026, 9, 8, 0, 0), utc(2026, 9, 15, 0, 0),
+			[]time.Time{utc(2026, 9, 10, 8, 0), utc(2026, 9, 14, 8, 0)}},
+		{"Count includes past occurrences", event("FREQ=DAILY;COUNT=3"), utc(2026, 9, 8, 0, 0), utc(2026, 9, 30, 0, 0),
+			[]time.Time{utc(2026, 9, 8, 8, 0), utc(2026, 9, 9, 8, 0)}},
+		{"Every other week until", event("FREQ=WEEKLY;INTERVAL=2;UNTIL=20261005"), start, utc(2026, 12, 1, 0, 0),
+			[]time.Time{start, utc(2026, 9, 21, 8, 0), utc(2026, 10, 5, 8, 0)}},
+		{"Monthly skips short months", &Event{StartsAt: utc(2027, 1, 31, 8, 0), EndsAt: utc(2027, 1, 31, 9, 0), RRule: "FREQ=MONTHLY"},
+			utc(2027, 1, 1, 0, 0), utc(2027, 5, 1, 0, 0), []time.Time{utc(2027, 1, 31, 8, 0), utc(2027, 3, 31, 8, 0)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.event.Occurrences(tt.from, tt.to)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Occurrences() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("Occurrences()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestEvent_VisibleTo(t *testing.T) {
+	classID := 4
+	schoolWide := &Event{Audience: AudienceAll}
+	staffOnly := &Event{Audience: AudienceStaff}
+	forParents := &Event{Audience: AudienceParents}
+	classEvent := &Event{Audience: AudienceAll, ClassID: &classID}
+
+	tests := []struct {
+		name    string
+		event   *Event
+		role    string
+		classes []int
+		want    bool
+	}{
+		{"Teacher sees staff events", staffOnly, RoleTeacher, nil, true},
+		{"Admin sees class events", classEvent, RoleAdmin, nil, true},
+		{"Student school-wide", schoolWide, RoleStudent, nil, true},
+		{"Student staff-only", staffOnly, RoleStudent, nil, false},
+		{"Student parents-only", forParents, RoleStudent, nil, false},
+		{"Student own class", classEvent, RoleStudent, []int{1, 4}, true},
+		{"Student other class", classEvent, RoleStudent, []int{1}, false},
+		{"Parent meeting", forParents, RoleParent, nil, true},
+		{"Parent class event", classEvent, RoleParent, nil, false},
+		{"Superadmin", schoolWide, RoleSuperAdmin, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.event.VisibleTo(tt.role, tt.classes); got != tt.want {
+				t.Errorf("VisibleTo(%s) = %v, want %v", tt.role, got, tt.want)
+			}
+		})
+	}
+}
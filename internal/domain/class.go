@@ -63,3 +63,19 @@ func (c *Class) Archive() {
 func (c *Class) IsActive() bool {
 	return c.Status == ClassStatusActive
 }
+
+// ! HasSeat vrai si la classe peut accueillir un élève de plus (capacité <= 0 : illimitée)
+func (c *Class) HasSeat(enrolled int) bool {
+	return c.Capacity <= 0 || enrolled < c.Capacity
+}
+
+// ! WaitlistEntry élève en attente d'une place dans une classe complète
+type WaitlistEntry struct {
+	ID           int       `json:"id"`
+	ClassID      int       `json:"class_id"`
+	StudentID    int       `json:"student_id"`
+	StudentName  string    `json:"student_name"`
+	StudentEmail string    `json:"student_email"`
+	Position     int       `json:"position"`
+	CreatedAt    time.Time `json:"created_at"`
+}
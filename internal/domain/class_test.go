@@ -96,3 +96,27 @@ func TestClass_IsActive(t *testing.T) {
 		t.Error("Archived class should not be active")
 	}
 }
+
+func TestClass_HasSeat(t *testing.T) {
+	tests := []struct {
+		name     string
+		capacity int
+		enrolled int
+		want     bool
+	}{
+		{"Empty class", 30, 0, true},
+		{"Last seat", 30, 29, true},
+		{"Full class", 30, 30, false},
+		{"Overfilled class", 30, 31, false},
+		{"Unlimited capacity", 0, 500, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cls := &Class{Capacity: tt.capacity}
+			if got := cls.HasSeat(tt.enrolled); got != tt.want {
+				t.Errorf("HasSeat(%d) = %v, want %v", tt.enrolled, got, tt.want)
+			}
+		})
+	}
+}
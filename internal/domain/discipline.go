@@ -0,0 +1,358 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ! Gravité d'un incident, de la moins à la plus grave
+const (
+	SeverityMinor    = "minor"
+	SeverityModerate = "moderate"
+	SeveritySerious  = "serious"
+	SeverityCritical = "critical"
+)
+
+// ! Statuts d'un incident : open -> escalated (direction) -> resolved | dismissed
+const (
+	IncidentOpen      = "open"
+	IncidentEscalated = "escalated"
+	IncidentResolved  = "resolved"
+	IncidentDismissed = "dismissed"
+)
+
+// ! Types de sanctions ; l'exclusion (temporaire) est réservée à l'administration
+const (
+	SanctionWarning   = "warning"
+	SanctionDetention = "detention"
+	SanctionExclusion = "exclusion"
+)
+
+// ! Visibilité des incidents pour l'élève et pour ses responsables
+const (
+	DisciplineVisibilityNone       = "none"       //! rien n'est communiqué
+	DisciplineVisibilitySanctioned = "sanctioned" //! incidents ayant donné lieu à une sanction
+	DisciplineVisibilityAll        = "all"        //! tous les incidents non confidentiels
+)
+
+const (
+	// ! ExclusionMaxDays au-delà, la décision relève du conseil de discipline (hors application)
+	ExclusionMaxDays = 15
+	// ! IncidentMaxDescription longueur maximale de la description (caractères)
+	IncidentMaxDescription = 2000
+)
+
+var severityRanks = map[string]int{
+	SeverityMinor: 1, SeverityModerate: 2, SeveritySerious: 3, SeverityCritical: 4,
+}
+
+var sanctionTypes = map[string]bool{
+	SanctionWarning: true, SanctionDetention: true, SanctionExclusion: true,
+}
+
+var disciplineVisibilities = map[string]bool{
+	DisciplineVisibilityNone: true, DisciplineVisibilitySanctioned: true, DisciplineVisibilityAll: true,
+}
+
+// ! DisciplineSettings réglages de l'école : visibilité et seuils d'escalade automatique
+type DisciplineSettings struct {
+	SchoolID          int       `json:"school_id"`
+	StudentVisibility string    `json:"student_visibility"`
+	ParentVisibility  string    `json:"parent_visibility"`  //! responsables légaux, notifiés par email
+	EscalateSeverity  string    `json:"escalate_severity"`  //! escalade dès cette gravité
+	EscalateThreshold int       `json:"escalate_threshold"` //! ou dès N incidents sur la fenêtre (0 = jamais)
+	EscalateWindow    int       `json:"escalate_window_days"`
+	UpdatedBy         int       `json:"updated_by,omitempty"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// ! DefaultDisciplineSettings réglages d'une école qui n'a rien configuré
+func DefaultDisciplineSettings(schoolID int) *DisciplineSettings {
+	return &DisciplineSettings{
+		SchoolID:          schoolID,
+		StudentVisibility: DisciplineVisibilitySanctioned,
+		ParentVisibility:  DisciplineVisibilitySanctioned,
+		EscalateSeverity:  SeveritySerious,
+		EscalateThreshold: 3,
+		EscalateWindow:    30,
+	}
+}
+
+func (s *DisciplineSettings) Validate() error {
+	if !disciplineVisibilities[s.StudentVisibility] || !disciplineVisibilities[s.ParentVisibility] {
+		return ErrDisciplineInvalidVisibility
+	}
+	if severityRanks[s.EscalateSeverity] == 0 {
+		return ErrIncidentInvalidSeverity
+	}
+	if s.EscalateThreshold < 0 || s.EscalateThreshold > 50 || s.EscalateWindow < 1 || s.EscalateWindow > 365 {
+		return ErrDisciplineInvalidThreshold
+	}
+	return nil
+}
+
+// ! RequiresEscalation recentCount : incidents de l'élève sur la fenêtre, nouvel incident compris
+func (s *DisciplineSettings) RequiresEscalation(severity string, recentCount int) bool {
+	if severityRanks[severity] >= severityRanks[s.EscalateSeverity] {
+		return true
+	}
+	return s.EscalateThreshold > 0 && recentCount >= s.EscalateThreshold
+}
+
+// ! Incident signalement d'un élève dans le cadre d'une classe.
+// ! Confidential : jamais communiqué à l'élève ni à ses responsables.
+type Incident struct {
+	ID               int         `json:"id"`
+	SchoolID         int         `json:"school_id"`
+	StudentID        int         `json:"student_id"`
+	ClassID          int         `json:"class_id"`
+	ReportedBy       int         `json:"reported_by"`
+	OccurredAt       time.Time   `json:"occurred_at"`
+	Location         string      `json:"location"`
+	Description      string      `json:"description"`
+	Severity         string      `json:"severity"`
+	Status           string      `json:"status"`
+	Confidential     bool        `json:"confidential"`
+	EscalatedBy      *int        `json:"escalated_by,omitempty"` //! nil + EscalatedAt : escalade automatique
+	EscalatedAt      *time.Time  `json:"escalated_at,omitempty"`
+	EscalationReason string      `json:"escalation_reason,omitempty"`
+	ResolvedBy       *int        `json:"resolved_by,omitempty"`
+	ResolvedAt       *time.Time  `json:"resolved_at,omitempty"`
+	Resolution       string      `json:"resolution,omitempty"`
+	Sanctions        []*Sanction `json:"sanctions"`
+	CreatedAt        time.Time   `json:"created_at"`
+	UpdatedAt        time.Time   `json:"updated_at"`
+
+	//! Lecture seule (jointures)
+	StudentName  string `json:"student_name,omitempty"`
+	ClassName    string `json:"class_name,omitempty"`
+	ReporterName string `json:"reporter_name,omitempty"`
+}
+
+func NewIncident(schoolID, studentID, classID, reportedBy int, occurredAt time.Time, location, description, severity string, confidential bool, now time.Time) (*Incident, error) {
+	description = strings.TrimSpace(description)
+	location = strings.TrimSpace(location)
+	if description == "" || utf8.RuneCountInString(description) > IncidentMaxDescription {
+		return nil, ErrIncidentDescriptionRequired
+	}
+	if utf8.RuneCountInString(location) > 200 {
+		return nil, ErrIncidentLocationTooLong
+	}
+	if severityRanks[severity] == 0 {
+		return nil, ErrIncidentInvalidSeverity
+	}
+	if occurredAt.IsZero() || occurredAt.After(now) || occurredAt.Before(now.AddDate(-1, 0, 0)) {
+		return nil, ErrIncidentInvalidDate
+	}
+	return &Incident{
+		SchoolID:     schoolID,
+		StudentID:    studentID,
+		ClassID:      classID,
+		ReportedBy:   reportedBy,
+		OccurredAt:   occurredAt.UTC(),
+		Location:     location,
+		Description:  description,
+		Severity:     severity,
+		Status:       IncidentOpen,
+		Confidential: confidential,
+		Sanctions:    []*Sanction{},
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}, nil
+}
+
+// ! IsClosed résolu ou classé sans suite
+func (i *Incident) IsClosed() bool {
+	return i.Status == IncidentResolved || i.Status == IncidentDismissed
+}
+
+// ! Escalate transmet l'incident à la direction ; by nil = escalade automatique
+func (i *Incident) Escalate(by *int, reason string, now time.Time) error {
+	if i.Status != IncidentOpen {
+		return ErrIncidentNotOpen
+	}
+	i.Status = IncidentEscalated
+	i.EscalatedBy = by
+	i.EscalatedAt = &now
+	i.EscalationReason = strings.TrimSpace(reason)
+	i.UpdatedAt = now
+	return nil
+}
+
+// ! Close clôture l'incident (résolu, ou classé sans suite si dismiss)
+func (i *Incident) Close(by int, resolution string, dismiss bool, now time.Time) error {
+	if i.IsClosed() {
+		return ErrIncidentClosed
+	}
+	i.Status = IncidentResolved
+	if dismiss {
+		i.Status = IncidentDismissed
+	}
+	i.ResolvedBy = &by
+	i.ResolvedAt = &now
+	i.Resolution = strings.TrimSpace(resolution)
+	i.UpdatedAt = now
+	return nil
+}
+
+// ! VisibleWith visibilité de l'incident pour l'élève ou ses responsables selon le réglage
+func (i *Incident) VisibleWith(visibility string) bool {
+	if i.Confidential || i.Status == IncidentDismissed {
+		return false
+	}
+	switch visibility {
+	case DisciplineVisibilityAll:
+		return true
+	case DisciplineVisibilitySanctioned:
+		return len(i.Sanctions) > 0
+	}
+	return false
+}
+
+// ! Sanction décision prise suite à un incident ; StartsOn/EndsOn : jour de retenue
+// ! ou période d'exclusion (bornes incluses), absents pour un avertissement
+type Sanction struct {
+	ID         int        `json:"id"`
+	SchoolID   int        `json:"school_id"`
+	IncidentID int        `json:"incident_id"`
+	StudentID  int        `json:"student_id"`
+	Type       string     `json:"type"`
+	StartsOn   *time.Time `json:"starts_on,omitempty"`
+	EndsOn     *time.Time `json:"ends_on,omitempty"`
+	Notes      string     `json:"notes"`
+	IssuedBy   int        `json:"issued_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// ! SanctionRequiresAdmin les enseignants donnent avertissements et retenues
+func SanctionRequiresAdmin(sanctionType string) bool {
+	return sanctionType == SanctionExclusion
+}
+
+func NewSanction(incident *Incident, sanctionType string, startsOn, endsOn *time.Time, notes string, issuedBy int, now time.Time) (*Sanction, error) {
+	if incident.Status == IncidentDismissed {
+		return nil, ErrIncidentClosed
+	}
+	if !sanctionTypes[sanctionType] {
+		return nil, ErrSanctionInvalidType
+	}
+	notes = strings.TrimSpace(notes)
+	if utf8.RuneCountInString(notes) > 1000 {
+		return nil, ErrSanctionNotesTooLong
+	}
+
+	switch sanctionType {
+	case SanctionWarning:
+		startsOn, endsOn = nil, nil
+	case SanctionDetention:
+		if startsOn == nil || (endsOn != nil && !endsOn.Equal(*startsOn)) {
+			return nil, ErrSanctionInvalidDates
+		}
+		endsOn = startsOn
+	case SanctionExclusion:
+		if startsOn == nil || endsOn == nil || endsOn.Before(*startsOn) ||
+			endsOn.Sub(*startsOn) >= ExclusionMaxDays*24*time.Hour {
+			return nil, ErrSanctionInvalidDates
+		}
+	}
+	return &Sanction{
+		SchoolID:   incident.SchoolID,
+		IncidentID: incident.ID,
+		StudentID:  incident.StudentID,
+		Type:       sanctionType,
+		StartsOn:   startsOn,
+		EndsOn:     endsOn,
+		Notes:      notes,
+		IssuedBy:   issuedBy,
+		CreatedAt:  now,
+	}, nil
+}
+
+// ! Days durée d'une retenue ou d'une exclusion en jours (bornes incluses), 0 pour un avertissement
+func (s *Sanction) Days() int {
+	if s.StartsOn == nil || s.EndsOn == nil {
+		return 0
+	}
+	return int(s.EndsOn.Sub(*s.StartsOn).Hours()/24) + 1
+}
+
+// ! IncidentFilter recherche d'incidents ; OccurredAt dans [From, To) si renseignés
+type IncidentFilter struct {
+	SchoolID   int
+	StudentID  int
+	ClassID    int
+	ReportedBy int
+	Status     string
+	Severity   string
+	From       time.Time
+	To         time.Time
+}
+
+// ! DisciplineSummary synthèse d'un ensemble d'incidents (historique d'un élève, classe)
+type DisciplineSummary struct {
+	Incidents     int                    `json:"incidents"`
+	BySeverity    map[string]int         `json:"by_severity"`
+	ByStatus      map[string]int         `json:"by_status"`
+	Sanctions     map[string]int         `json:"sanctions"`
+	ExclusionDays int                    `json:"exclusion_days"`
+	Students      []StudentIncidentCount `json:"students,omitempty"` //! les plus concernés d'abord
+}
+
+type StudentIncidentCount struct {
+	StudentID   int       `json:"student_id"`
+	StudentName string    `json:"student_name"`
+	Incidents   int       `json:"incidents"`
+	Sanctions   int       `json:"sanctions"`
+	LastAt      time.Time `json:"last_incident_at"`
+}
+
+// ! SummarizeIncidents les incidents classés sans suite sont comptés par statut seulement
+func SummarizeIncidents(incidents []*Incident) *DisciplineSummary {
+	summary := &DisciplineSummary{
+		BySeverity: map[string]int{},
+		ByStatus:   map[string]int{},
+		Sanctions:  map[string]int{},
+		Students:   []StudentIncidentCount{},
+	}
+	perStudent := map[int]*StudentIncidentCount{}
+	for _, incident := range incidents {
+		summary.ByStatus[incident.Status]++
+		if incident.Status == IncidentDismissed {
+			continue
+		}
+		summary.Incidents++
+		summary.BySeverity[incident.Severity]++
+
+		count, ok := perStudent[incident.StudentID]
+		if !ok {
+			count = &StudentIncidentCount{StudentID: incident.StudentID, StudentName: incident.StudentName}
+			perStudent[incident.StudentID] = count
+		}
+		count.Incidents++
+		count.Sanctions += len(incident.Sanctions)
+		if incident.OccurredAt.After(count.LastAt) {
+			count.LastAt = incident.OccurredAt
+		}
+
+		for _, sanction := range incident.Sanctions {
+			summary.Sanctions[sanction.Type]++
+			if sanction.Type == SanctionExclusion {
+				summary.ExclusionDays += sanction.Days()
+			}
+		}
+	}
+
+	for _, count := range perStudent {
+		summary.Students = append(summary.Students, *count)
+	}
+	sort.Slice(summary.Students, func(a, b int) bool {
+		sa, sb := summary.Students[a], summary.Students[b]
+		if sa.Incidents != sb.Incidents {
+			return sa.Incidents > sb.Incidents
+		}
+		return sa.StudentID < sb.StudentID
+	})
+	return summary
+}
@@ -0,0 +1,202 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewIncident(t *testing.T) {
+	now := time.Date(2026, 10, 5, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		occurredAt  time.Time
+		description string
+		location    string
+		severity    string
+		wantErr     error
+	}{
+		{"Valid", now.Add(-time.Hour), " Bavardages répétés ", "Salle 12", SeverityMinor, nil},
+		{"Empty description", now.Add(-time.Hour), " ", "", SeverityMinor, ErrIncidentDescriptionRequired},
+		{"Description too long", now.Add(-time.Hour), strings.Repeat("é", IncidentMaxDescription+1), "", SeverityMinor, ErrIncidentDescriptionRequired},
+		{"Location too long", now.Add(-time.Hour), "Retard", strings.Repeat("x", 201), SeverityMinor, ErrIncidentLocationTooLong},
+		{"Unknown severity", now.Add(-time.Hour), "Retard", "", "huge", ErrIncidentInvalidSeverity},
+		{"In the future", now.Add(time.Hour), "Retard", "", SeverityMinor, ErrIncidentInvalidDate},
+		{"Too old", now.AddDate(-2, 0, 0), "Retard", "", SeverityMinor, ErrIncidentInvalidDate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			incident, err := NewIncident(1, 2, 3, 4, tt.occurredAt, tt.location, tt.description, tt.severity, false, now)
+			if err != tt.wantErr {
+				t.Fatalf("NewIncident() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (incident.Status != IncidentOpen || incident.Description != "Bavardages répétés") {
+				t.Errorf("NewIncident() = %+v", incident)
+			}
+		})
+	}
+}
+
+func TestIncident_Workflow(t *testing.T) {
+	now := time.Date(2026, 10, 5, 10, 0, 0, 0, time.UTC)
+	adminID := 9
+	incident, _ := NewIncident(1, 2, 3, 4, now.Add(-time.Hour), "", "Bagarre", SeveritySerious, false, now)
+
+	if err := incident.Escalate(nil, "Gravité", now); err != nil || incident.Status != IncidentEscalated || incident.EscalatedBy != nil {
+		t.Fatalf("Escalate() = %+v, err = %v", incident, err)
+	}
+	if err := incident.Escalate(&adminID, "", now); err != ErrIncidentNotOpen {
+		t.Errorf("Escalate() twice error = %v, want %v", err, ErrIncidentNotOpen)
+	}
+	if err := incident.Close(adminID, " Exclusion prononcée ", false, now); err != nil || incident.Status != IncidentResolved {
+		t.Fatalf("Close() status = %s, err = %v", incident.Status, err)
+	}
+	if err := incident.Close(adminID, "", true, now); err != ErrIncidentClosed {
+		t.Errorf("Close() twice error = %v, want %v", err, ErrIncidentClosed)
+	}
+
+	dismissed, _ := NewIncident(1, 2, 3, 4, now.Add(-time.Hour), "", "Malentendu", SeverityMinor, false, now)
+	if err := dismissed.Close(adminID, "", true, now); err != nil || dismissed.Status != IncidentDismissed {
+		t.Fatalf("Close(dismiss) status = %s, err = %v", dismissed.Status, err)
+	}
+	if _, err := NewSanction(dismissed, SanctionWarning, nil, nil, "", adminID, now); err != ErrIncidentClosed {
+		t.Errorf("NewSanction() on dismissed incident error = %v, want %v", err, ErrIncidentClosed)
+	}
+}
+
+func TestDisciplineSettings_RequiresEscalation(t *testing.T) {
+	settings := DefaultDisciplineSettings(1)
+	if err := settings.Validate(); err != nil {
+		t.Fatalf("default settings Validate() = %v", err)
+	}
+
+	if !settings.RequiresEscalation(SeverityCritical, 1) {
+		t.Error("critical incident should be escalated")
+	}
+	if settings.RequiresEscalation(SeverityMinor, 2) {
+		t.Error("second minor incident should not be escalated")
+	}
+	if !settings.RequiresEscalation(SeverityMinor, 3) {
+		t.Error("third incident in the window should be escalated")
+	}
+
+	settings.EscalateThreshold = 0
+	if settings.RequiresEscalation(SeverityModerate, 10) {
+		t.Error("threshold 0 should disable escalation by count")
+	}
+
+	settings.StudentVisibility = "parents"
+	if err := settings.Validate(); err != ErrDisciplineInvalidVisibility {
+		t.Errorf("Validate() error = %v, want %v", err, ErrDisciplineInvalidVisibility)
+	}
+}
+
+func TestIncident_VisibleWith(t *testing.T) {
+	now := time.Date(2026, 10, 5, 10, 0, 0, 0, time.UTC)
+	plain, _ := NewIncident(1, 2, 3, 4, now.Add(-time.Hour), "", "Retard", SeverityMinor, false, now)
+	sanctioned, _ := NewIncident(1, 2, 3, 4, now.Add(-time.Hour), "", "Insolence", SeverityModerate, false, now)
+	warning, _ := NewSanction(sanctioned, SanctionWarning, nil, nil, "", 4, now)
+	sanctioned.Sanctions = append(sanctioned.Sanctions, warning)
+	confidential, _ := NewIncident(1, 2, 3, 4, now.Add(-time.Hour), "", "Situation familiale", SeverityModerate, true, now)
+	confidential.Sanctions = append(confidential.Sanctions, warning)
+
+	tests := []struct {
+		name       string
+		incident   *Incident
+		visibility string
+		want       bool
+	}{
+		{"All shows plain", plain, DisciplineVisibilityAll, true},
+		{"Sanctioned hides plain", plain, DisciplineVisibilitySanctioned, false},
+		{"Sanctioned shows sanctioned", sanctioned, DisciplineVisibilitySanctioned, true},
+		{"None hides everything", sanctioned, DisciplineVisibilityNone, false},
+		{"Confidential never shown", confidential, DisciplineVisibilityAll, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.incident.VisibleWith(tt.visibility); got != tt.want {
+				t.Errorf("VisibleWith(%s) = %v, want %v", tt.visibility, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewSanction(t *testing.T) {
+	now := time.Date(2026, 10, 5, 10, 0, 0, 0, time.UTC)
+	incident, _ := NewIncident(1, 2, 3, 4, now.Add(-time.Hour), "", "Bagarre", SeveritySerious, false, now)
+	day := time.Date(2026, 10, 7, 0, 0, 0, 0, time.UTC)
+	nextDay := day.AddDate(0, 0, 1)
+	lastDay := day.AddDate(0, 0, ExclusionMaxDays-1)
+	tooLate := day.AddDate(0, 0, ExclusionMaxDays)
+
+	tests := []struct {
+		name     string
+		kind     string
+		startsOn *time.Time
+		endsOn   *time.Time
+		wantDays int
+		wantErr  error
+	}{
+		{"Warning ignores dates", SanctionWarning, &day, &nextDay, 0, nil},
+		{"Detention", SanctionDetention, &day, nil, 1, nil},
+		{"Detention without day", SanctionDetention, nil, nil, 0, ErrSanctionInvalidDates},
+		{"Detention over two days", SanctionDetention, &day, &nextDay, 0, ErrSanctionInvalidDates},
+		{"Exclusion", SanctionExclusion, &day, &nextDay, 2, nil},
+		{"Longest exclusion", SanctionExclusion, &day, &lastDay, ExclusionMaxDays, nil},
+		{"Exclusion too long", SanctionExclusion, &day, &tooLate, 0, ErrSanctionInvalidDates},
+		{"Exclusion reversed", SanctionExclusion, &nextDay, &day, 0, ErrSanctionInvalidDates},
+		{"Unknown type", "expulsion", nil, nil, 0, ErrSanctionInvalidType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sanction, err := NewSanction(incident, tt.kind, tt.startsOn, tt.endsOn, "", 9, now)
+			if err != tt.wantErr {
+				t.Fatalf("NewSanction() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (sanction.Days() != tt.wantDays || sanction.StudentID != 2) {
+				t.Errorf("NewSanction() = %+v, Days() = %d, want %d", sanction, sanction.Days(), tt.wantDays)
+			}
+		})
+	}
+
+	if !SanctionRequiresAdmin(SanctionExclusion) || SanctionRequiresAdmin(SanctionDetention) {
+		t.Error("only exclusion should require the administration")
+	}
+}
+
+func TestSummarizeIncidents(t *testing.T) {
+	now := time.Date(2026, 10, 5, 10, 0, 0, 0, time.UTC)
+	day := time.Date(2026, 10, 7, 0, 0, 0, 0, time.UTC)
+	end := day.AddDate(0, 0, 2)
+
+	newIncident := func(studentID int, severity string, at time.Time) *Incident {
+		i, _ := NewIncident(1, studentID, 3, 4, at, "", "Incident", severity, false, now)
+		i.StudentName = map[int]string{1: "Alice", 2: "Bob"}[studentID]
+		return i
+	}
+	a1 := newIncident(1, SeverityMinor, now.Add(-48*time.Hour))
+	a2 := newIncident(1, SeveritySerious, now.Add(-time.Hour))
+	exclusion, _ := NewSanction(a2, SanctionExclusion, &day, &end, "", 9, now)
+	a2.Sanctions = append(a2.Sanctions, exclusion)
+	b1 := newIncident(2, SeverityMinor, now.Add(-time.Hour))
+	dismissed := newIncident(2, SeverityMinor, now.Add(-time.Hour))
+	dismissed.Close(9, "", true, now)
+
+	summary := SummarizeIncidents([]*Incident{b1, a1, a2, dismissed})
+	if summary.Incidents != 3 || summary.BySeverity[SeverityMinor] != 2 || summary.BySeverity[SeveritySerious] != 1 {
+		t.Errorf("counts = %d, %v", summary.Incidents, summary.BySeverity)
+	}
+	if summary.ByStatus[IncidentDismissed] != 1 || summary.ByStatus[IncidentOpen] != 3 {
+		t.Errorf("ByStatus = %v", summary.ByStatus)
+	}
+	if summary.Sanctions[SanctionExclusion] != 1 || summary.ExclusionDays != 3 {
+		t.Errorf("Sanctions = %v, ExclusionDays = %d", summary.Sanctions, summary.ExclusionDays)
+	}
+	if len(summary.Students) != 2 || summary.Students[0].StudentName != "Alice" || summary.Students[0].Incidents != 2 ||
+		!summary.Students[0].LastAt.Equal(a2.OccurredAt) || summary.Students[1].Incidents != 1 {
+		t.Errorf("Students = %+v", summary.Students)
+	}
+}
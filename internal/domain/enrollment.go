@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ! MaxEndReasonLength taille de student_classes.end_reason
+const MaxEndReasonLength = 255
+
+// ! Enrollment inscription d'un élève dans une classe (active ou fermée)
+type Enrollment struct {
+	ID           int        `json:"id"`
+	StudentID    int        `json:"student_id"`
+	ClassID      int        `json:"class_id"`
+	ClassName    string     `json:"class_name"`
+	AcademicYear string     `json:"academic_year"`
+	EnrolledAt   time.Time  `json:"enrolled_at"`
+	EndedAt      *time.Time `json:"ended_at,omitempty"`
+	EndReason    string     `json:"end_reason,omitempty"`
+	IsActive     bool       `json:"is_active"`
+}
+
+// ! NormalizeEndReason motif de fin d'inscription (transfert, départ...), optionnel
+func NormalizeEndReason(reason string) (string, error) {
+	reason = strings.TrimSpace(reason)
+	if utf8.RuneCountInString(reason) > MaxEndReasonLength {
+		return "", ErrEndReasonTooLong
+	}
+	return reason, nil
+}
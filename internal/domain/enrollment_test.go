@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeEndReason(t *testing.T) {
+	tests := []struct {
+		name    string
+		reason  string
+		want    string
+		wantErr error
+	}{
+		{"Empty reason", "", "", nil},
+		{"Trimmed", "  Changement de filière  ", "Changement de filière", nil},
+		{"Max length (runes)", strings.Repeat("é", MaxEndReasonLength), strings.Repeat("é", MaxEndReasonLength), nil},
+		{"Too long", strings.Repeat("a", MaxEndReasonLength+1), "", ErrEndReasonTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeEndReason(tt.reason)
+			if err != tt.wantErr {
+				t.Fatalf("NormalizeEndReason() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeEndReason() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
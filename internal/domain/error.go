@@ -1,6 +1,10 @@
 package domain
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 // ! DomainError représente une erreur métier
 type DomainError struct {
@@ -19,12 +23,28 @@ func NewError(code, message string) *DomainError {
 	}
 }
 
+// ! IsNotFound ressource absente : NOT_FOUND ou code en *_NOT_FOUND (erreur éventuellement enveloppée)
+func IsNotFound(err error) bool {
+	var domainErr *DomainError
+	if !errors.As(err, &domainErr) {
+		return false
+	}
+	return domainErr.Code == ErrNotFound.Code || strings.HasSuffix(domainErr.Code, "_"+ErrNotFound.Code)
+}
+
 // ! SCHOOL ERRORS
 var (
-	ErrSchoolNameRequired  = NewError("SCHOOL_NAME_REQUIRED", "School name is required")
-	ErrSchoolSlugRequired  = NewError("SCHOOL_SLUG_REQUIRED", "School slug is required")
-	ErrSchoolNotFound      = NewError("SCHOOL_NOT_FOUND", "School not found")
-	ErrSchoolAlreadyExists = NewError("SCHOOL_ALREADY_EXISTS", "School with this name already exists")
+	ErrSchoolNameRequired     = NewError("SCHOOL_NAME_REQUIRED", "School name is required")
+	ErrSchoolSlugRequired     = NewError("SCHOOL_SLUG_REQUIRED", "School slug is required")
+	ErrSchoolNotFound         = NewError("SCHOOL_NOT_FOUND", "School not found")
+	ErrSchoolAlreadyExists    = NewError("SCHOOL_ALREADY_EXISTS", "School with this name already exists")
+	ErrSchoolSuspended        = NewError("SCHOOL_SUSPENDED", "School is suspended")
+	ErrSchoolAlreadySuspended = NewError("SCHOOL_ALREADY_SUSPENDED", "School is already suspended")
+	ErrSchoolNotSuspended     = NewError("SCHOOL_NOT_SUSPENDED", "School is not suspended")
+	ErrSchoolHasNoAdmin       = NewError("SCHOOL_HAS_NO_ADMIN", "School has no admin user")
+
+	ErrInvalidRegistrationMode = NewError("INVALID_REGISTRATION_MODE", "Registration mode must be 'open', 'invite_only' or 'open_with_approval'")
+	ErrSelfRegistrationClosed  = NewError("SELF_REGISTRATION_CLOSED", "This school only accepts registrations by invitation")
 )
 
 // ! COMMON ERRORS
@@ -53,6 +73,7 @@ var (
 	ErrClassYearRequired  = NewError("CLASS_YEAR_REQUIRED", "Academic year is required")
 	ErrClassInvalidID     = NewError("CLASS_INVALID_ID", "Invalid school ID")
 	ErrClassNotFound      = NewError("CLASS_NOT_FOUND", "Class not found")
+	ErrClassFull          = NewError("CLASS_FULL", "Class has reached its capacity")
 )
 
 // ! SUBJECT ERRORS
@@ -65,12 +86,231 @@ var (
 
 // ! USER ERRORS
 var (
-	ErrUserNotFound = NewError("USER_NOT_FOUND", "User not found")
+	ErrUserNotFound         = NewError("USER_NOT_FOUND", "User not found")
+	ErrUserNotApproved      = NewError("USER_NOT_APPROVED", "User is not approved")
+	ErrUserNotPending       = NewError("USER_NOT_PENDING", "User is not pending approval")
+	ErrUserSuspended        = NewError("USER_SUSPENDED", "User account is suspended")
+	ErrUserAlreadySuspended = NewError("USER_ALREADY_SUSPENDED", "User is already suspended")
+	ErrUserNotDeactivated   = NewError("USER_NOT_DEACTIVATED", "User is not suspended or deactivated")
+	ErrUserNotStudent       = NewError("USER_NOT_STUDENT", "User is not a student")
+	ErrUserNotTeacher       = NewError("USER_NOT_TEACHER", "User is not a teacher")
+	ErrCannotModifySelf     = NewError("CANNOT_MODIFY_SELF", "Admins cannot change their own account status")
+)
+
+// ! IMPORT ERRORS
+var (
+	ErrImportEmpty             = NewError("IMPORT_EMPTY", "Import file has no data rows")
+	ErrImportUnsupportedFormat = NewError("IMPORT_UNSUPPORTED_FORMAT", "Only CSV and XLSX files are supported")
+	ErrImportMissingColumn     = NewError("IMPORT_MISSING_COLUMN", "Mapped column not found in file header")
+	ErrImportInvalidRole       = NewError("IMPORT_INVALID_ROLE", "Only teachers and students can be imported")
+	ErrImportInvalidDelivery   = NewError("IMPORT_INVALID_DELIVERY", "Password delivery must be 'email' or 'sheet'")
+)
+
+// ! EXPORT ERRORS
+var (
+	ErrExportUnsupportedFormat = NewError("EXPORT_UNSUPPORTED_FORMAT", "Export format must be 'csv' or 'xlsx'")
+)
+
+// ! PRIVACY ERRORS
+var (
+	ErrUserAlreadyErased    = NewError("USER_ALREADY_ERASED", "User account has already been erased")
+	ErrErasureNotAllowed    = NewError("ERASURE_NOT_ALLOWED", "Administrator accounts cannot be erased")
+	ErrRetentionInvalidDays = NewError("RETENTION_INVALID_DAYS", "Message retention must be between 30 and 3650 days")
+)
+
+// ! INVITATION ERRORS
+var (
+	ErrInvitationNotFound        = NewError("INVITATION_NOT_FOUND", "Invitation not found")
+	ErrInvitationInvalidRole     = NewError("INVITATION_INVALID_ROLE", "Only teachers and students can be invited")
+	ErrInvitationClassRequired   = NewError("INVITATION_CLASS_REQUIRED", "A class is required to invite a student")
+	ErrInvitationSubjectRequired = NewError("INVITATION_SUBJECT_REQUIRED", "At least one subject is required to invite a teacher")
+	ErrInvitationExpired         = NewError("INVITATION_EXPIRED", "Invitation has expired")
+	ErrInvitationAlreadyAccepted = NewError("INVITATION_ALREADY_ACCEPTED", "Invitation has already been accepted")
+	ErrInvitationRevoked         = NewError("INVITATION_REVOKED", "Invitation has been revoked")
+	ErrInvitationInvalidToken    = NewError("INVITATION_INVALID_TOKEN", "Invalid invitation token")
+)
+
+// ! STATS ERRORS
+var (
+	ErrStatsInvalidGranularity = NewError("STATS_INVALID_GRANULARITY", "Granularity must be 'day', 'week' or 'month'")
+	ErrStatsInvalidRange       = NewError("STATS_INVALID_RANGE", "'from' must be before 'to'")
+	ErrStatsRangeTooLarge      = NewError("STATS_RANGE_TOO_LARGE", "Date range cannot exceed one year")
+)
+
+// ! GRADE / REPORT CARD ERRORS
+var (
+	ErrTermInvalidID           = NewError("TERM_INVALID_ID", "Invalid school ID")
+	ErrTermNameRequired        = NewError("TERM_NAME_REQUIRED", "Term name is required")
+	ErrTermInvalidDates        = NewError("TERM_INVALID_DATES", "Term end date must be on or after its start date")
+	ErrTermNotFound            = NewError("TERM_NOT_FOUND", "Term not found")
+	ErrTermAlreadyExists       = NewError("TERM_ALREADY_EXISTS", "A term with this name already exists for this academic year")
+	ErrTermClassYearMismatch   = NewError("TERM_CLASS_YEAR_MISMATCH", "Term and class belong to different academic years")
+	ErrGradeInvalidScore       = NewError("GRADE_INVALID_SCORE", "Score must be between 0 and the maximum score")
+	ErrGradeInvalidCoefficient = NewError("GRADE_INVALID_COEFFICIENT", "Coefficient must be positive")
+	ErrGradeNotFound           = NewError("GRADE_NOT_FOUND", "Grade not found")
+	ErrReportCommentRequired   = NewError("REPORT_COMMENT_REQUIRED", "Comment is required")
+	ErrAttendanceInvalidStatus = NewError("ATTENDANCE_INVALID_STATUS", "Attendance status must be 'absent' or 'late'")
+	ErrAttendanceDateRequired  = NewError("ATTENDANCE_DATE_REQUIRED", "Attendance date is required")
+)
+
+// ! QUIZ ERRORS
+var (
+	ErrQuestionNotFound       = NewError("QUESTION_NOT_FOUND", "Question not found")
+	ErrQuestionPromptRequired = NewError("QUESTION_PROMPT_REQUIRED", "Question prompt is required")
+	ErrQuestionInvalidType    = NewError("QUESTION_INVALID_TYPE", "Question type must be 'single_choice', 'multiple_choice', 'true_false', 'numeric' or 'short_text'")
+	ErrQuestionInvalidChoices = NewError("QUESTION_INVALID_CHOICES", "Choice questions need at least two non-empty choices")
+	ErrQuestionInvalidAnswer  = NewError("QUESTION_INVALID_ANSWER", "Answer key does not match the question type")
+	ErrQuestionInvalidPoints  = NewError("QUESTION_INVALID_POINTS", "Question points must be positive")
+	ErrQuestionInUse          = NewError("QUESTION_IN_USE", "Question is used by a quiz and cannot be changed")
+	ErrQuizNotFound           = NewError("QUIZ_NOT_FOUND", "Quiz not found")
+	ErrQuizTitleRequired      = NewError("QUIZ_TITLE_REQUIRED", "Quiz title is required")
+	ErrQuizInvalidDuration    = NewError("QUIZ_INVALID_DURATION", "Quiz duration must be between 1 and 240 minutes")
+	ErrQuizInvalidWindow      = NewError("QUIZ_INVALID_WINDOW", "Quiz close date must be after its open date")
+	ErrQuizInvalidAttempts    = NewError("QUIZ_INVALID_ATTEMPTS", "Quiz attempts must be between 1 and 10")
+	ErrQuizQuestionsRequired  = NewError("QUIZ_QUESTIONS_REQUIRED", "A quiz needs at least one question from its subject bank")
+	ErrQuizAlreadyOpened      = NewError("QUIZ_ALREADY_OPENED", "Quiz has already opened and cannot be changed")
+	ErrQuizNotOpen            = NewError("QUIZ_NOT_OPEN", "Quiz is not open")
+	ErrQuizNoAttemptsLeft     = NewError("QUIZ_NO_ATTEMPTS_LEFT", "No attempts left for this quiz")
+	ErrQuizAttemptNotFound    = NewError("QUIZ_ATTEMPT_NOT_FOUND", "Quiz attempt not found")
+	ErrQuizAttemptSubmitted   = NewError("QUIZ_ATTEMPT_SUBMITTED", "Quiz attempt has already been submitted")
+)
+
+// ! RESOURCE ERRORS
+var (
+	ErrResourceNotFound           = NewError("RESOURCE_NOT_FOUND", "Resource not found")
+	ErrResourceTitleRequired      = NewError("RESOURCE_TITLE_REQUIRED", "Resource title is required")
+	ErrResourceTargetRequired     = NewError("RESOURCE_TARGET_REQUIRED", "A resource must target a class or a level")
+	ErrResourceInvalidKind        = NewError("RESOURCE_INVALID_KIND", "Resource kind must be 'file' or 'link'")
+	ErrResourceInvalidURL         = NewError("RESOURCE_INVALID_URL", "Link must be an http(s) URL")
+	ErrResourceInvalidTags        = NewError("RESOURCE_INVALID_TAGS", "At most 10 tags of 30 characters are allowed")
+	ErrResourceFileRequired       = NewError("RESOURCE_FILE_REQUIRED", "A non-empty file is required")
+	ErrResourceFileTooLarge       = NewError("RESOURCE_FILE_TOO_LARGE", "File is too large (max 20MB)")
+	ErrResourceFileType           = NewError("RESOURCE_FILE_TYPE", "File type is not allowed")
+	ErrResourceNotFile            = NewError("RESOURCE_NOT_FILE", "Only file resources have versions")
+	ErrResourceVersionNotFound    = NewError("RESOURCE_VERSION_NOT_FOUND", "Resource version not found")
+	ErrResourceFolderNotFound     = NewError("RESOURCE_FOLDER_NOT_FOUND", "Resource folder not found")
+	ErrResourceFolderNameRequired = NewError("RESOURCE_FOLDER_NAME_REQUIRED", "Folder name is required (100 characters max)")
+	ErrResourceFolderExists       = NewError("RESOURCE_FOLDER_EXISTS", "A folder with this name already exists here")
+	ErrResourceFolderNotEmpty     = NewError("RESOURCE_FOLDER_NOT_EMPTY", "Folder is not empty")
+)
+
+// ! CALENDAR ERRORS
+var (
+	ErrEventNotFound          = NewError("EVENT_NOT_FOUND", "Event not found")
+	ErrEventTitleRequired     = NewError("EVENT_TITLE_REQUIRED", "Event title is required (255 characters max)")
+	ErrEventInvalidCategory   = NewError("EVENT_INVALID_CATEGORY", "Event category must be holiday, exam, meeting, lesson or other")
+	ErrEventInvalidAudience   = NewError("EVENT_INVALID_AUDIENCE", "Event audience must be all, staff, students or parents")
+	ErrEventInvalidDates      = NewError("EVENT_INVALID_DATES", "Event must end after it starts and last at most 120 days")
+	ErrEventInvalidRecurrence = NewError("EVENT_INVALID_RECURRENCE", "Unsupported recurrence rule (FREQ, INTERVAL, COUNT or UNTIL, BYDAY for weekly)")
+	ErrEventSchoolWide        = NewError("EVENT_SCHOOL_WIDE", "Only administrators can manage school-wide events")
+	ErrCalendarFeedNotFound   = NewError("CALENDAR_FEED_NOT_FOUND", "Calendar feed not found")
+)
+
+// ! ANNOUNCEMENT ERRORS
+var (
+	ErrAnnouncementNotFound           = NewError("ANNOUNCEMENT_NOT_FOUND", "Announcement not found")
+	ErrAnnouncementTitleRequired      = NewError("ANNOUNCEMENT_TITLE_REQUIRED", "Announcement title is required (255 characters max)")
+	ErrAnnouncementBodyRequired       = NewError("ANNOUNCEMENT_BODY_REQUIRED", "Announcement body is required")
+	ErrAnnouncementInvalidTarget      = NewError("ANNOUNCEMENT_INVALID_TARGET", "Target must be school, parents, a role, classes or levels")
+	ErrAnnouncementInvalidDates       = NewError("ANNOUNCEMENT_INVALID_DATES", "Announcement must expire after it is published")
+	ErrAnnouncementAckNotRequired     = NewError("ANNOUNCEMENT_ACK_NOT_REQUIRED", "This announcement does not require an acknowledgement")
+	ErrAnnouncementAttachmentNotFound = NewError("ANNOUNCEMENT_ATTACHMENT_NOT_FOUND", "Attachment not found")
+	ErrAnnouncementTooManyFiles       = NewError("ANNOUNCEMENT_TOO_MANY_FILES", "At most 5 attachments are allowed")
+	ErrAnnouncementFileRequired       = NewError("ANNOUNCEMENT_FILE_REQUIRED", "A non-empty file is required")
+	ErrAnnouncementFileTooLarge       = NewError("ANNOUNCEMENT_FILE_TOO_LARGE", "File is too large (max 10MB)")
+	ErrAnnouncementFileType           = NewError("ANNOUNCEMENT_FILE_TYPE", "File type is not allowed")
+)
+
+// ! ROOM & BOOKING ERRORS
+var (
+	ErrRoomNotFound          = NewError("ROOM_NOT_FOUND", "Room not found")
+	ErrRoomNameRequired      = NewError("ROOM_NAME_REQUIRED", "Room name is required (100 characters max)")
+	ErrRoomInvalidType       = NewError("ROOM_INVALID_TYPE", "Room type must be classroom, lab, gym, library, computer, auditorium or other")
+	ErrRoomInvalidCapacity   = NewError("ROOM_INVALID_CAPACITY", "Room capacity cannot be negative")
+	ErrRoomInvalidEquipment  = NewError("ROOM_INVALID_EQUIPMENT", "At most 30 equipment items of 100 characters are allowed")
+	ErrRoomExists            = NewError("ROOM_EXISTS", "A room with this name already exists")
+	ErrEquipmentNotFound     = NewError("EQUIPMENT_NOT_FOUND", "Equipment not found")
+	ErrEquipmentNameRequired = NewError("EQUIPMENT_NAME_REQUIRED", "Equipment name is required (100 characters max)")
+	ErrEquipmentExists       = NewError("EQUIPMENT_EXISTS", "Equipment with this name already exists")
+	ErrBookingNotFound       = NewError("BOOKING_NOT_FOUND", "Booking not found")
+	ErrBookingTargetRequired = NewError("BOOKING_TARGET_REQUIRED", "A booking must target exactly one room or one equipment")
+	ErrBookingInvalidDates   = NewError("BOOKING_INVALID_DATES", "Booking must be in the future, end after it starts and last at most 12 hours")
+	ErrBookingUnavailable    = NewError("BOOKING_UNAVAILABLE", "This room or equipment is not available for booking")
+	ErrBookingConflict       = NewError("BOOKING_CONFLICT", "Already booked for this time slot")
+	ErrBookingNotPending     = NewError("BOOKING_NOT_PENDING", "Only pending bookings can be reviewed")
+	ErrBookingNotCancellable = NewError("BOOKING_NOT_CANCELLABLE", "This booking can no longer be cancelled")
+)
+
+// ! PROFILE ERRORS
+var (
+	ErrProfileNotExtended          = NewError("PROFILE_NOT_EXTENDED", "Only students and teachers have an extended profile")
+	ErrProfileFieldReadOnly        = NewError("PROFILE_FIELD_READ_ONLY", "One of these profile fields does not apply to this user or cannot be modified by you")
+	ErrProfileInvalidDate          = NewError("PROFILE_INVALID_DATE", "Profile dates must use the YYYY-MM-DD format and be plausible")
+	ErrProfileFieldTooLong         = NewError("PROFILE_FIELD_TOO_LONG", "Address is limited to 500 characters, medical notes to 2000 and document titles to 255")
+	ErrStudentNumberInvalid        = NewError("STUDENT_NUMBER_INVALID", "Student number is limited to 50 letters, digits, dashes, dots or slashes")
+	ErrStudentNumberTaken          = NewError("STUDENT_NUMBER_TAKEN", "This student number is already used in the school")
+	ErrGuardianNameRequired        = NewError("GUARDIAN_NAME_REQUIRED", "Guardian name is required (200 characters max)")
+	ErrGuardianRelationshipTooLong = NewError("GUARDIAN_RELATIONSHIP_TOO_LONG", "Guardian relationship is limited to 50 characters")
+	ErrGuardianContactRequired     = NewError("GUARDIAN_CONTACT_REQUIRED", "A guardian needs a phone number or a valid email")
+	ErrTooManyGuardians            = NewError("TOO_MANY_GUARDIANS", "A student can have at most 5 guardians or emergency contacts")
+	ErrQualificationsInvalid       = NewError("QUALIFICATIONS_INVALID", "At most 20 qualifications of 200 characters are allowed")
+	ErrProfileDocumentNotFound     = NewError("PROFILE_DOCUMENT_NOT_FOUND", "Document not found")
+	ErrProfileDocumentRequired     = NewError("PROFILE_DOCUMENT_REQUIRED", "A non-empty file is required")
+	ErrProfileDocumentTooLarge     = NewError("PROFILE_DOCUMENT_TOO_LARGE", "Document exceeds the maximum size (10MB)")
+	ErrProfileDocumentType         = NewError("PROFILE_DOCUMENT_TYPE", "Document must be a PDF, an image or a text document (pdf, png, jpg, doc, docx, odt)")
+	ErrProfileDocumentKind         = NewError("PROFILE_DOCUMENT_KIND", "Document kind must be certificate, medical, identity, diploma, cv or other")
+	ErrProfileTooManyDocuments     = NewError("PROFILE_TOO_MANY_DOCUMENTS", "A profile can hold at most 20 documents")
+)
+
+// ! STUDENT NUMBER ERRORS
+var (
+	ErrStudentNumberFormatInvalid = NewError("STUDENT_NUMBER_FORMAT_INVALID", "Student number format must contain {SEQ:n} once and only {YEAR}, {YY}, {LEVEL}, letters, digits, dashes, dots or slashes (50 characters max once generated)")
+	ErrStudentNumberNotFound      = NewError("STUDENT_NUMBER_NOT_FOUND", "No student with this number in the school")
+	ErrStudentNumberUnavailable   = NewError("STUDENT_NUMBER_UNAVAILABLE", "Could not generate a free student number, check numbers entered manually")
+)
+
+// ! DISCIPLINE ERRORS
+var (
+	ErrIncidentNotFound            = NewError("INCIDENT_NOT_FOUND", "Incident not found")
+	ErrIncidentDescriptionRequired = NewError("INCIDENT_DESCRIPTION_REQUIRED", "Incident description is required (2000 characters max)")
+	ErrIncidentLocationTooLong     = NewError("INCIDENT_LOCATION_TOO_LONG", "Incident location is limited to 200 characters")
+	ErrIncidentInvalidSeverity     = NewError("INCIDENT_INVALID_SEVERITY", "Severity must be minor, moderate, serious or critical")
+	ErrIncidentInvalidDate         = NewError("INCIDENT_INVALID_DATE", "Incident date must be an RFC3339 timestamp within the last year and not in the future")
+	ErrIncidentNotOpen             = NewError("INCIDENT_NOT_OPEN", "Only open incidents can be escalated")
+	ErrIncidentClosed              = NewError("INCIDENT_CLOSED", "This incident is already closed")
+	ErrSanctionNotFound            = NewError("SANCTION_NOT_FOUND", "Sanction not found")
+	ErrSanctionInvalidType         = NewError("SANCTION_INVALID_TYPE", "Sanction type must be warning, detention or exclusion")
+	ErrSanctionInvalidDates        = NewError("SANCTION_INVALID_DATES", "Detention needs a day; exclusion needs a start and end day (15 days max)")
+	ErrSanctionNotesTooLong        = NewError("SANCTION_NOTES_TOO_LONG", "Sanction notes are limited to 1000 characters")
+	ErrSanctionAdminOnly           = NewError("SANCTION_ADMIN_ONLY", "Only the administration can pronounce an exclusion")
+	ErrDisciplineInvalidVisibility = NewError("DISCIPLINE_INVALID_VISIBILITY", "Visibility must be none, sanctioned or all")
+	ErrDisciplineInvalidThreshold  = NewError("DISCIPLINE_INVALID_THRESHOLD", "Escalation threshold must be between 0 and 50 over a window of 1 to 365 days")
+)
+
+// ! GRADEBOOK ERRORS
+var (
+	ErrEvaluationNotFound      = NewError("EVALUATION_NOT_FOUND", "Evaluation not found")
+	ErrEvaluationLabelRequired = NewError("EVALUATION_LABEL_REQUIRED", "Evaluation label is required (max 255 characters)")
+	ErrEvaluationExists        = NewError("EVALUATION_EXISTS", "An evaluation with this label already exists for this class, subject and term")
+	ErrEvaluationScoreAboveMax = NewError("EVALUATION_SCORE_ABOVE_MAX", "Some recorded scores exceed the new maximum score")
+	ErrGradebookInvalidCell    = NewError("GRADEBOOK_INVALID_CELL", "Invalid gradebook cell")
+	ErrGradebookDuplicateCell  = NewError("GRADEBOOK_DUPLICATE_CELL", "The same cell appears twice in the batch")
+	ErrGradebookTooManyChanges = NewError("GRADEBOOK_TOO_MANY_CHANGES", "Too many cells in a single batch")
+	ErrGradebookConflict       = NewError("GRADEBOOK_CONFLICT", "Some cells were modified by someone else; reload the gradebook")
+	ErrGradebookUnknownColumn  = NewError("GRADEBOOK_UNKNOWN_COLUMN", "Column does not match any evaluation of the gradebook")
+	ErrGradeInGradebook        = NewError("GRADE_IN_GRADEBOOK", "This grade belongs to a gradebook evaluation; edit it from the gradebook")
+)
+
+// ! AUDIT ERRORS
+var (
+	ErrAuditActionRequired = NewError("AUDIT_ACTION_REQUIRED", "Audit action is required")
 )
 
 // ! STUDENT-CLASS ERRORS
 var (
-	ErrStudentClassNotFound = NewError("STUDENT_CLASS_NOT_FOUND", "Student-Class association not found")
+	ErrStudentClassNotFound  = NewError("STUDENT_CLASS_NOT_FOUND", "Student-Class association not found")
+	ErrWaitlistEntryNotFound = NewError("WAITLIST_ENTRY_NOT_FOUND", "Student is not on this class waitlist")
+	ErrEndReasonTooLong      = NewError("END_REASON_TOO_LONG", "Enrollment end reason is too long (255 characters max)")
 )
 
 // ! TEACHER-SUBJECT ERRORS
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestIsNotFound(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"Generic", ErrNotFound, true},
+		{"Entity", ErrUserNotFound, true},
+		{"Association", ErrStudentClassNotFound, true},
+		{"Wrapped", fmt.Errorf("load: %w", ErrTermNotFound), true},
+		{"Validation", ErrGradeInvalidScore, false},
+		{"Not a domain error", errors.New("boom"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsNotFound(tt.err); got != tt.want {
+				t.Errorf("IsNotFound(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// ! DefaultMaxScore barème par défaut (notes sur 20)
+const DefaultMaxScore = 20.0
+
+const (
+	AttendanceAbsent  = "absent"
+	AttendanceLate    = "late"
+	AttendancePresent = "present" //! jamais stocké : efface l'absence / le retard du jour
+)
+
+// ! Term période de notation (trimestre, semestre) d'une année scolaire
+type Term struct {
+	ID           int       `json:"id"`
+	SchoolID     int       `json:"school_id"`
+	AcademicYear string    `json:"academic_year"`
+	Name         string    `json:"name"`
+	Position     int       `json:"position"`
+	StartDate    time.Time `json:"start_date"`
+	EndDate      time.Time `json:"end_date"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func NewTerm(schoolID int, academicYear, name string, position int, start, end time.Time) (*Term, error) {
+	name = strings.TrimSpace(name)
+	if schoolID <= 0 {
+		return nil, ErrTermInvalidID
+	}
+	if name == "" {
+		return nil, ErrTermNameRequired
+	}
+	if strings.TrimSpace(academicYear) == "" {
+		return nil, ErrClassYearRequired
+	}
+	if start.IsZero() || end.IsZero() || end.Before(start) {
+		return nil, ErrTermInvalidDates
+	}
+	if position <= 0 {
+		position = 1
+	}
+
+	return &Term{
+		SchoolID:     schoolID,
+		AcademicYear: strings.TrimSpace(academicYear),
+		Name:         name,
+		Position:     position,
+		StartDate:    start,
+		EndDate:      end,
+		CreatedAt:    time.Now(),
+	}, nil
+}
+
+// ! Contains vrai si date tombe dans la période (bornes incluses, au jour près)
+func (t *Term) Contains(date time.Time) bool {
+	day := date.Format("2006-01-02")
+	return day >= t.StartDate.Format("2006-01-02") && day <= t.EndDate.Format("2006-01-02")
+}
+
+// ! Grade une note d'évaluation (score / max_score, pondérée par coefficient)
+type Grade struct {
+	ID          int       `json:"id"`
+	SchoolID    int       `json:"school_id"`
+	StudentID   int       `json:"student_id"`
+	SubjectID   int       `json:"subject_id"`
+	ClassID     int       `json:"class_id"`
+	TermID      int       `json:"term_id"`
+	TeacherID   int       `json:"teacher_id"`
+	Label       string    `json:"label"`
+	Score       float64   `json:"score"`
+	MaxScore    float64   `json:"max_score"`
+	Coefficient float64   `json:"coefficient"`
+	GradedOn    time.Time `json:"graded_on"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	//! Carnet de notes : évaluation (colonne) et version de la cellule (verrou optimiste)
+	EvaluationID *int `json:"evaluation_id,omitempty"`
+	Version      int  `json:"version"`
+}
+
+// ! NewGrade maxScore et coefficient à 0 => valeurs par défaut (/20, coef 1)
+func NewGrade(schoolID, studentID, subjectID, classID, termID, teacherID int, label string, score, maxScore, coefficient float64, gradedOn time.Time) (*Grade, error) {
+	if maxScore == 0 {
+		maxScore = DefaultMaxScore
+	}
+	if coefficient == 0 {
+		coefficient = 1
+	}
+	if maxScore < 0 || score < 0 || score > maxScore {
+		return nil, ErrGradeInvalidScore
+	}
+	if coefficient < 0 {
+		return nil, ErrGradeInvalidCoefficient
+	}
+	if gradedOn.IsZero() {
+		gradedOn = time.Now()
+	}
+
+	return &Grade{
+		SchoolID:    schoolID,
+		StudentID:   studentID,
+		SubjectID:   subjectID,
+		ClassID:     classID,
+		TermID:      termID,
+		TeacherID:   teacherID,
+		Label:       strings.TrimSpace(label),
+		Score:       score,
+		MaxScore:    maxScore,
+		Coefficient: coefficient,
+		GradedOn:    gradedOn,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}, nil
+}
+
+// ! OutOf20 note ramenée sur 20
+func (g *Grade) OutOf20() float64 {
+	return g.Score / g.MaxScore * DefaultMaxScore
+}
+
+// ! ReportComment appréciation du bulletin : par matière, ou générale si SubjectID nil
+type ReportComment struct {
+	ID        int       `json:"id"`
+	SchoolID  int       `json:"school_id"`
+	StudentID int       `json:"student_id"`
+	TermID    int       `json:"term_id"`
+	SubjectID *int      `json:"subject_id,omitempty"`
+	AuthorID  int       `json:"author_id"`
+	Comment   string    `json:"comment"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ! AttendanceRecord absence ou retard d'un élève (un enregistrement par jour)
+type AttendanceRecord struct {
+	ID         int       `json:"id"`
+	SchoolID   int       `json:"school_id"`
+	StudentID  int       `json:"student_id"`
+	ClassID    int       `json:"class_id"`
+	RecordedBy int       `json:"recorded_by"`
+	Date       time.Time `json:"date"`
+	Status     string    `json:"status"`
+	Justified  bool      `json:"justified"`
+	Note       string    `json:"note,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func NewAttendanceRecord(schoolID, studentID, classID, recordedBy int, date time.Time, status string, justified bool, note string) (*AttendanceRecord, error) {
+	if status != AttendanceAbsent && status != AttendanceLate {
+		return nil, ErrAttendanceInvalidStatus
+	}
+	if date.IsZero() {
+		return nil, ErrAttendanceDateRequired
+	}
+
+	return &AttendanceRecord{
+		SchoolID:   schoolID,
+		StudentID:  studentID,
+		ClassID:    classID,
+		RecordedBy: recordedBy,
+		Date:       date,
+		Status:     status,
+		Justified:  justified,
+		Note:       strings.TrimSpace(note),
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+// ! AttendanceSummary absences / retards d'un élève sur une période
+type AttendanceSummary struct {
+	Absences          int `json:"absences"`
+	JustifiedAbsences int `json:"justified_absences"`
+	Lates             int `json:"lates"`
+}
@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewTerm(t *testing.T) {
+	start := time.Date(2025, 9, 15, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 12, 19, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		school  int
+		year    string
+		term    string
+		start   time.Time
+		end     time.Time
+		wantErr error
+	}{
+		{"Valid term", 1, "2025-2026", "Trimestre 1", start, end, nil},
+		{"Invalid school", 0, "2025-2026", "Trimestre 1", start, end, ErrTermInvalidID},
+		{"Empty name", 1, "2025-2026", "  ", start, end, ErrTermNameRequired},
+		{"Empty year", 1, "", "Trimestre 1", start, end, ErrClassYearRequired},
+		{"End before start", 1, "2025-2026", "Trimestre 1", end, start, ErrTermInvalidDates},
+		{"Single day", 1, "2025-2026", "Examens", start, start, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			term, err := NewTerm(tt.school, tt.year, tt.term, 0, tt.start, tt.end)
+			if err != tt.wantErr {
+				t.Fatalf("NewTerm() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && term.Position != 1 {
+				t.Errorf("Position = %d, want default 1", term.Position)
+			}
+		})
+	}
+}
+
+func TestTerm_Contains(t *testing.T) {
+	term, _ := NewTerm(1, "2025-2026", "T1",
+		1, time.Date(2025, 9, 15, 0, 0, 0, 0, time.UTC), time.Date(2025, 12, 19, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		date time.Time
+		want bool
+	}{
+		{time.Date(2025, 9, 14, 23, 0, 0, 0, time.UTC), false},
+		{time.Date(2025, 9, 15, 8, 0, 0, 0, time.UTC), true},
+		{time.Date(2025, 12, 19, 17, 30, 0, 0, time.UTC), true},
+		{time.Date(2025, 12, 20, 0, 0, 0, 0, time.UTC), false},
+	}
+	for _, tt := range tests {
+		if got := term.Contains(tt.date); got != tt.want {
+			t.Errorf("Contains(%s) = %v, want %v", tt.date, got, tt.want)
+		}
+	}
+}
+
+func TestNewGrade(t *testing.T) {
+	tests := []struct {
+		name        string
+		score       float64
+		maxScore    float64
+		coefficient float64
+		wantErr     error
+		wantOutOf20 float64
+	}{
+		{"Default scale", 15, 0, 0, nil, 15},
+		{"Out of 10", 7, 10, 2, nil, 14},
+		{"Perfect score", 40, 40, 1, nil, 20},
+		{"Negative score", -1, 20, 1, ErrGradeInvalidScore, 0},
+		{"Above max", 21, 20, 1, ErrGradeInvalidScore, 0},
+		{"Negative coefficient", 10, 20, -1, ErrGradeInvalidCoefficient, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grade, err := NewGrade(1, 2, 3, 4, 5, 6, " DS 1 ", tt.score, tt.maxScore, tt.coefficient, time.Time{})
+			if err != tt.wantErr {
+				t.Fatalf("NewGrade() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := grade.OutOf20(); got != tt.wantOutOf20 {
+				t.Errorf("OutOf20() = %v, want %v", got, tt.wantOutOf20)
+			}
+			if grade.Label != "DS 1" || grade.Coefficient <= 0 || grade.GradedOn.IsZero() {
+				t.Errorf("NewGrade() = %+v, want trimmed label, positive coefficient and date", grade)
+			}
+		})
+	}
+}
+
+func TestNewAttendanceRecord(t *testing.T) {
+	day := time.Date(2025, 10, 6, 0, 0, 0, 0, time.UTC)
+
+	if _, err := NewAttendanceRecord(1, 2, 3, 4, day, AttendanceAbsent, false, ""); err != nil {
+		t.Errorf("absent record error = %v", err)
+	}
+	if _, err := NewAttendanceRecord(1, 2, 3, 4, day, AttendanceLate, true, "Bus"); err != nil {
+		t.Errorf("late record error = %v", err)
+	}
+	if _, err := NewAttendanceRecord(1, 2, 3, 4, day, "present", false, ""); err != ErrAttendanceInvalidStatus {
+		t.Errorf("present record error = %v, want ErrAttendanceInvalidStatus", err)
+	}
+	if _, err := NewAttendanceRecord(1, 2, 3, 4, time.Time{}, AttendanceAbsent, false, ""); err != ErrAttendanceDateRequired {
+		t.Errorf("undated record error = %v, want ErrAttendanceDateRequired", err)
+	}
+}
@@ -0,0 +1,253 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// ! GradebookMaxChanges cellules par enregistrement (PUT ou import)
+	GradebookMaxChanges = 2000
+	// ! EvaluationMaxScore / EvaluationMaxCoefficient bornes des colonnes NUMERIC(5,2) / NUMERIC(4,2)
+	EvaluationMaxScore       = 999.99
+	EvaluationMaxCoefficient = 99.99
+)
+
+// ! Evaluation colonne du carnet de notes (devoir, interrogation) d'une classe dans une
+// ! matière pour une période ; ses notes héritent du libellé, du barème et du coefficient
+type Evaluation struct {
+	ID          int       `json:"id"`
+	SchoolID    int       `json:"school_id"`
+	ClassID     int       `json:"class_id"`
+	SubjectID   int       `json:"subject_id"`
+	TermID      int       `json:"term_id"`
+	CreatedBy   int       `json:"created_by"`
+	Label       string    `json:"label"`
+	MaxScore    float64   `json:"max_score"`
+	Coefficient float64   `json:"coefficient"`
+	GradedOn    time.Time `json:"graded_on"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ! NewEvaluation maxScore et coefficient à 0 => valeurs par défaut (/20, coef 1)
+func NewEvaluation(schoolID, classID, subjectID, termID, createdBy int, label string, maxScore, coefficient float64, gradedOn time.Time) (*Evaluation, error) {
+	label = strings.TrimSpace(label)
+	if label == "" || utf8.RuneCountInString(label) > 255 {
+		return nil, ErrEvaluationLabelRequired
+	}
+	if maxScore == 0 {
+		maxScore = DefaultMaxScore
+	}
+	if coefficient == 0 {
+		coefficient = 1
+	}
+	if maxScore < 0 || maxScore > EvaluationMaxScore {
+		return nil, ErrGradeInvalidScore
+	}
+	if coefficient < 0 || coefficient > EvaluationMaxCoefficient {
+		return nil, ErrGradeInvalidCoefficient
+	}
+	if gradedOn.IsZero() {
+		gradedOn = time.Now()
+	}
+	now := time.Now()
+	return &Evaluation{
+		SchoolID:    schoolID,
+		ClassID:     classID,
+		SubjectID:   subjectID,
+		TermID:      termID,
+		CreatedBy:   createdBy,
+		Label:       label,
+		MaxScore:    maxScore,
+		Coefficient: coefficient,
+		GradedOn:    gradedOn,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// ! NewGrade note d'un élève pour cette évaluation (teacherID : auteur de la saisie)
+func (e *Evaluation) NewGrade(studentID, teacherID int, score float64) (*Grade, error) {
+	grade, err := NewGrade(e.SchoolID, studentID, e.SubjectID, e.ClassID, e.TermID, teacherID,
+		e.Label, score, e.MaxScore, e.Coefficient, e.GradedOn)
+	if err != nil {
+		return nil, err
+	}
+	grade.EvaluationID = &e.ID
+	return grade, nil
+}
+
+// ! GradebookKey identifie une cellule (élève × évaluation)
+type GradebookKey struct {
+	StudentID    int
+	EvaluationID int
+}
+
+// ! GradebookCell note d'une cellule ; Version 0 = cellule vide
+type GradebookCell struct {
+	EvaluationID int      `json:"evaluation_id"`
+	GradeID      int      `json:"grade_id,omitempty"`
+	Score        *float64 `json:"score"`
+	Version      int      `json:"version"`
+}
+
+// ! GradebookRow ligne d'un élève ; Cells dans l'ordre des évaluations
+type GradebookRow struct {
+	StudentID   int             `json:"student_id"`
+	StudentName string          `json:"student_name"`
+	Cells       []GradebookCell `json:"cells"`
+	Average     *float64        `json:"average,omitempty"` //! /20 pondérée, nil si aucune note
+}
+
+// ! Gradebook matrice élèves × évaluations d'une classe, d'une matière et d'une période
+type Gradebook struct {
+	Evaluations []*Evaluation  `json:"evaluations"`
+	Rows        []GradebookRow `json:"rows"`
+}
+
+// ! BuildGradebook évaluations par date puis ID, élèves dans l'ordre du roster ;
+// ! les notes hors évaluation ou d'élèves absents du roster sont ignorées
+func BuildGradebook(evaluations []*Evaluation, students []*User, grades []*Grade) *Gradebook {
+	sorted := append([]*Evaluation(nil), evaluations...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if !sorted[i].GradedOn.Equal(sorted[j].GradedOn) {
+			return sorted[i].GradedOn.Before(sorted[j].GradedOn)
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	cells := make(map[GradebookKey]*Grade, len(grades))
+	for _, g := range grades {
+		if g.EvaluationID != nil {
+			cells[GradebookKey{StudentID: g.StudentID, EvaluationID: *g.EvaluationID}] = g
+		}
+	}
+
+	book := &Gradebook{Evaluations: sorted, Rows: make([]GradebookRow, 0, len(students))}
+	for _, student := range students {
+		row := GradebookRow{
+			StudentID:   student.ID,
+			StudentName: student.GetFullName(),
+			Cells:       make([]GradebookCell, len(sorted)),
+		}
+		var scored []*Grade
+		for i, e := range sorted {
+			row.Cells[i] = GradebookCell{EvaluationID: e.ID}
+			if g, ok := cells[GradebookKey{StudentID: student.ID, EvaluationID: e.ID}]; ok {
+				score := g.Score
+				row.Cells[i].GradeID = g.ID
+				row.Cells[i].Score = &score
+				row.Cells[i].Version = g.Version
+				scored = append(scored, g)
+			}
+		}
+		if avg, ok := SubjectAverage(scored); ok {
+			avg = float64(roundAverage(avg)) / 100
+			row.Average = &avg
+		}
+		book.Rows = append(book.Rows, row)
+	}
+	return book
+}
+
+// ! GradebookChange saisie d'une cellule : Score nil efface la note ; Version : version lue
+// ! par le client (0 = cellule vide), contrôlée si CheckVersion
+type GradebookChange struct {
+	StudentID    int
+	EvaluationID int
+	Score        *float64
+	Version      int
+	CheckVersion bool
+}
+
+// ! GradebookConflict cellule modifiée entre-temps (état actuel en base)
+type GradebookConflict struct {
+	StudentID    int      `json:"student_id"`
+	EvaluationID int      `json:"evaluation_id"`
+	Score        *float64 `json:"score"`
+	Version      int      `json:"version"`
+}
+
+// ! GradebookPlan écritures à appliquer (cellules inchangées omises)
+type GradebookPlan struct {
+	Create []*Grade
+	Update []*Grade
+	Delete []*Grade
+}
+
+func (p *GradebookPlan) Len() int {
+	return len(p.Create) + len(p.Update) + len(p.Delete)
+}
+
+// ! PlanGradebook valide un lot de saisies contre les évaluations, le roster (élèves inscrits)
+// ! et les notes actuelles. Erreur de validation : ErrGradebookInvalidCell précisant la cellule.
+// ! Les conflits de version sont tous retournés ; le lot ne doit alors pas être appliqué.
+func PlanGradebook(evaluations map[int]*Evaluation, roster map[int]bool, current map[GradebookKey]*Grade,
+	changes []GradebookChange, teacherID int) (*GradebookPlan, []GradebookConflict, error) {
+	if len(changes) > GradebookMaxChanges {
+		return nil, nil, ErrGradebookTooManyChanges
+	}
+
+	plan := &GradebookPlan{}
+	var conflicts []GradebookConflict
+	seen := make(map[GradebookKey]bool, len(changes))
+	for _, c := range changes {
+		key := GradebookKey{StudentID: c.StudentID, EvaluationID: c.EvaluationID}
+		invalid := func(reason *DomainError) error {
+			return fmt.Errorf("%w: student %d, evaluation %d: %s", ErrGradebookInvalidCell, c.StudentID, c.EvaluationID, reason.Message)
+		}
+		evaluation, ok := evaluations[c.EvaluationID]
+		if !ok {
+			return nil, nil, invalid(ErrEvaluationNotFound)
+		}
+		if !roster[c.StudentID] {
+			return nil, nil, invalid(ErrStudentClassNotFound)
+		}
+		if seen[key] {
+			return nil, nil, invalid(ErrGradebookDuplicateCell)
+		}
+		seen[key] = true
+		if c.Score != nil && (math.IsNaN(*c.Score) || *c.Score < 0 || *c.Score > evaluation.MaxScore) {
+			return nil, nil, invalid(ErrGradeInvalidScore)
+		}
+
+		existing := current[key]
+		version := 0
+		if existing != nil {
+			version = existing.Version
+		}
+		if c.CheckVersion && c.Version != version {
+			conflict := GradebookConflict{StudentID: c.StudentID, EvaluationID: c.EvaluationID, Version: version}
+			if existing != nil {
+				score := existing.Score
+				conflict.Score = &score
+			}
+			conflicts = append(conflicts, conflict)
+			continue
+		}
+
+		switch {
+		case existing == nil && c.Score == nil:
+			//! cellule vide laissée vide
+		case existing == nil:
+			grade, err := evaluation.NewGrade(c.StudentID, teacherID, *c.Score)
+			if err != nil {
+				return nil, nil, invalid(ErrGradeInvalidScore)
+			}
+			plan.Create = append(plan.Create, grade)
+		case c.Score == nil:
+			plan.Delete = append(plan.Delete, existing)
+		case *c.Score != existing.Score:
+			updated := *existing
+			updated.Score = *c.Score
+			updated.TeacherID = teacherID
+			plan.Update = append(plan.Update, &updated)
+		}
+	}
+	return plan, conflicts, nil
+}
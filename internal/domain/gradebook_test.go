@@ -0,0 +1,175 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func gradebookFixture(t *testing.T) (*Evaluation, *Evaluation) {
+	t.Helper()
+	first, err := NewEvaluation(1, 10, 20, 30, 5, "Devoir 1", 0, 0, time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("NewEvaluation() error = %v", err)
+	}
+	first.ID = 100
+	second, err := NewEvaluation(1, 10, 20, 30, 5, "Interrogation", 10, 2, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("NewEvaluation() error = %v", err)
+	}
+	second.ID = 101
+	return first, second
+}
+
+func TestNewEvaluation(t *testing.T) {
+	tests := []struct {
+		name        string
+		label       string
+		maxScore    float64
+		coefficient float64
+		wantErr     error
+	}{
+		{"Defaults", "Devoir 1", 0, 0, nil},
+		{"Custom", "Examen", 40, 3, nil},
+		{"Empty label", "  ", 20, 1, ErrEvaluationLabelRequired},
+		{"Negative max", "Devoir", -1, 1, ErrGradeInvalidScore},
+		{"Max too large", "Devoir", 1000, 1, ErrGradeInvalidScore},
+		{"Negative coefficient", "Devoir", 20, -1, ErrGradeInvalidCoefficient},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := NewEvaluation(1, 10, 20, 30, 5, tt.label, tt.maxScore, tt.coefficient, time.Time{})
+			if err != tt.wantErr {
+				t.Fatalf("NewEvaluation() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (e.MaxScore <= 0 || e.Coefficient <= 0 || e.GradedOn.IsZero()) {
+				t.Errorf("NewEvaluation() = %+v, want defaults applied", e)
+			}
+		})
+	}
+}
+
+func TestBuildGradebook(t *testing.T) {
+	first, second := gradebookFixture(t)
+	students := []*User{
+		{ID: 1, FirstName: "Aina", LastName: "Rakoto"},
+		{ID: 2, FirstName: "Bako", LastName: "Rabe"},
+	}
+	g1, _ := first.NewGrade(1, 5, 12)
+	g1.ID, g1.Version = 500, 3
+	g2, _ := second.NewGrade(1, 5, 5)
+	g2.ID, g2.Version = 501, 1
+	free, _ := NewGrade(1, 1, 20, 10, 30, 5, "Hors carnet", 20, 20, 1, time.Time{})
+
+	book := BuildGradebook([]*Evaluation{first, second}, students, []*Grade{g1, g2, free})
+
+	if len(book.Evaluations) != 2 || book.Evaluations[0].ID != second.ID {
+		t.Fatalf("Evaluations not sorted by date: %+v", book.Evaluations)
+	}
+	if len(book.Rows) != 2 {
+		t.Fatalf("Rows = %d, want 2", len(book.Rows))
+	}
+	row := book.Rows[0]
+	if row.Cells[0].Score == nil || *row.Cells[0].Score != 5 || row.Cells[1].Version != 3 || row.Cells[1].GradeID != 500 {
+		t.Errorf("Row cells = %+v", row.Cells)
+	}
+	//! (5/10*20*2 + 12/20*20*1) / 3 = 10.67
+	if row.Average == nil || *row.Average != 10.67 {
+		t.Errorf("Average = %v, want 10.67", row.Average)
+	}
+	empty := book.Rows[1]
+	if empty.Average != nil || empty.Cells[0].Score != nil || empty.Cells[0].Version != 0 {
+		t.Errorf("Empty row = %+v", empty)
+	}
+}
+
+func TestPlanGradebook(t *testing.T) {
+	first, second := gradebookFixture(t)
+	evaluations := map[int]*Evaluation{first.ID: first, second.ID: second}
+	roster := map[int]bool{1: true, 2: true}
+	existing, _ := first.NewGrade(1, 5, 12)
+	existing.ID, existing.Version = 500, 2
+	current := map[GradebookKey]*Grade{{StudentID: 1, EvaluationID: first.ID}: existing}
+	score := func(v float64) *float64 { return &v }
+
+	t.Run("Create and update, empty cell left empty", func(t *testing.T) {
+		plan, conflicts, err := PlanGradebook(evaluations, roster, current, []GradebookChange{
+			{StudentID: 2, EvaluationID: first.ID, Score: score(15), CheckVersion: true},
+			{StudentID: 1, EvaluationID: first.ID, Score: score(14), Version: 2, CheckVersion: true},
+			{StudentID: 2, EvaluationID: second.ID, Score: nil, CheckVersion: true},
+		}, 7)
+		if err != nil || len(conflicts) != 0 {
+			t.Fatalf("PlanGradebook() = %v, %v", conflicts, err)
+		}
+		if len(plan.Create) != 1 || len(plan.Update) != 1 || len(plan.Delete) != 0 {
+			t.Fatalf("plan = %+v", plan)
+		}
+		if created := plan.Create[0]; *created.EvaluationID != first.ID || created.MaxScore != 20 || created.TeacherID != 7 {
+			t.Errorf("created grade = %+v", created)
+		}
+		if updated := plan.Update[0]; updated.Score != 14 || existing.Score != 12 {
+			t.Errorf("updated grade = %+v, existing must be untouched", updated)
+		}
+	})
+
+	t.Run("Delete and unchanged", func(t *testing.T) {
+		plan, _, err := PlanGradebook(evaluations, roster, current, []GradebookChange{
+			{StudentID: 1, EvaluationID: first.ID, Score: score(12), Version: 2, CheckVersion: true},
+		}, 7)
+		if err != nil || plan.Len() != 0 {
+			t.Fatalf("unchanged cell planned: %+v, %v", plan, err)
+		}
+		plan, _, err = PlanGradebook(evaluations, roster, current, []GradebookChange{
+			{StudentID: 1, EvaluationID: first.ID, Version: 2, CheckVersion: true},
+		}, 7)
+		if err != nil || len(plan.Delete) != 1 {
+			t.Fatalf("delete not planned: %+v, %v", plan, err)
+		}
+	})
+
+	t.Run("Conflicts", func(t *testing.T) {
+		_, conflicts, err := PlanGradebook(evaluations, roster, current, []GradebookChange{
+			{StudentID: 1, EvaluationID: first.ID, Score: score(14), Version: 1, CheckVersion: true},
+			{StudentID: 1, EvaluationID: second.ID, Score: score(4), Version: 3, CheckVersion: true},
+		}, 7)
+		if err != nil || len(conflicts) != 2 {
+			t.Fatalf("PlanGradebook() conflicts = %+v, %v", conflicts, err)
+		}
+		if conflicts[0].Version != 2 || *conflicts[0].Score != 12 || conflicts[1].Score != nil {
+			t.Errorf("conflicts = %+v", conflicts)
+		}
+
+		plan, conflicts, err := PlanGradebook(evaluations, roster, current, []GradebookChange{
+			{StudentID: 1, EvaluationID: first.ID, Score: score(14)},
+		}, 7)
+		if err != nil || len(conflicts) != 0 || len(plan.Update) != 1 {
+			t.Errorf("unchecked change: %+v, %+v, %v", plan, conflicts, err)
+		}
+	})
+
+	invalid := []struct {
+		name   string
+		change GradebookChange
+	}{
+		{"Unknown evaluation", GradebookChange{StudentID: 1, EvaluationID: 999, Score: score(1)}},
+		{"Not enrolled", GradebookChange{StudentID: 3, EvaluationID: first.ID, Score: score(1)}},
+		{"Above max", GradebookChange{StudentID: 1, EvaluationID: second.ID, Score: score(11)}},
+		{"Negative", GradebookChange{StudentID: 1, EvaluationID: second.ID, Score: score(-1)}},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := PlanGradebook(evaluations, roster, current, []GradebookChange{tt.change}, 7)
+			if !errors.Is(err, ErrGradebookInvalidCell) {
+				t.Errorf("PlanGradebook() error = %v, want ErrGradebookInvalidCell", err)
+			}
+		})
+	}
+
+	_, _, err := PlanGradebook(evaluations, roster, current, []GradebookChange{
+		{StudentID: 2, EvaluationID: first.ID, Score: score(1)},
+		{StudentID: 2, EvaluationID: first.ID, Score: score(2)},
+	}, 7)
+	if !errors.Is(err, ErrGradebookInvalidCell) {
+		t.Errorf("duplicate cell error = %v, want ErrGradebookInvalidCell", err)
+	}
+}
@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"net/mail"
+	"strings"
+	"time"
+)
+
+// ! Invitation status (calculé, non stocké)
+const (
+	InvitationStatusPending  = "pending"
+	InvitationStatusAccepted = "accepted"
+	InvitationStatusRevoked  = "revoked"
+	InvitationStatusExpired  = "expired"
+)
+
+// ! Invitation invitation nominative d'un enseignant ou d'un élève par l'admin
+// ! Classe (élève) ou matières (enseignant) sont pré-remplies à l'acceptation
+type Invitation struct {
+	ID             int        `json:"id"`
+	SchoolID       int        `json:"school_id"`
+	Email          string     `json:"email"`
+	Role           string     `json:"role"`
+	ClassID        *int       `json:"class_id,omitempty"`
+	SubjectIDs     []int      `json:"subject_ids,omitempty"`
+	InvitedBy      int        `json:"invited_by"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	AcceptedAt     *time.Time `json:"accepted_at,omitempty"`
+	AcceptedUserID *int       `json:"accepted_user_id,omitempty"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// ! NewInvitation crée une invitation valable ttl
+func NewInvitation(schoolID, invitedBy int, email, role string, classID int, subjectIDs []int, ttl time.Duration) (*Invitation, error) {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return nil, ErrEmailRequired
+	}
+	if _, err := mail.ParseAddress(email); err != nil {
+		return nil, ErrEmailInvalid
+	}
+
+	inv := &Invitation{
+		SchoolID:  schoolID,
+		Email:     email,
+		Role:      role,
+		InvitedBy: invitedBy,
+		ExpiresAt: time.Now().Add(ttl),
+		CreatedAt: time.Now(),
+	}
+
+	switch role {
+	case RoleStudent:
+		if classID <= 0 {
+			return nil, ErrInvitationClassRequired
+		}
+		inv.ClassID = &classID
+	case RoleTeacher:
+		if len(subjectIDs) == 0 {
+			return nil, ErrInvitationSubjectRequired
+		}
+		inv.SubjectIDs = subjectIDs
+	default:
+		return nil, ErrInvitationInvalidRole
+	}
+	return inv, nil
+}
+
+// ! IsExpired vérifie si l'invitation a dépassé sa date d'expiration
+func (i *Invitation) IsExpired() bool {
+	return time.Now().After(i.ExpiresAt)
+}
+
+// ! Status état courant de l'invitation
+func (i *Invitation) Status() string {
+	switch {
+	case i.AcceptedAt != nil:
+		return InvitationStatusAccepted
+	case i.RevokedAt != nil:
+		return InvitationStatusRevoked
+	case i.IsExpired():
+		return InvitationStatusExpired
+	default:
+		return InvitationStatusPending
+	}
+}
+
+// ! CanBeAccepted vérifie que l'invitation est encore utilisable
+func (i *Invitation) CanBeAccepted() error {
+	switch i.Status() {
+	case InvitationStatusAccepted:
+		return ErrInvitationAlreadyAccepted
+	case InvitationStatusRevoked:
+		return ErrInvitationRevoked
+	case InvitationStatusExpired:
+		return ErrInvitationExpired
+	}
+	return nil
+}
+
+// ! Accept marque l'invitation comme utilisée par userID
+func (i *Invitation) Accept(userID int) error {
+	if err := i.CanBeAccepted(); err != nil {
+		return err
+	}
+	now := time.Now()
+	i.AcceptedAt = &now
+	i.AcceptedUserID = &userID
+	return nil
+}
+
+// ! Revoke annule une invitation non encore acceptée
+func (i *Invitation) Revoke() error {
+	if i.AcceptedAt != nil {
+		return ErrInvitationAlreadyAccepted
+	}
+	if i.RevokedAt != nil {
+		return ErrInvitationRevoked
+	}
+	now := time.Now()
+	i.RevokedAt = &now
+	return nil
+}
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewInvitation(t *testing.T) {
+	tests := []struct {
+		name        string
+		email       string
+		role        string
+		classID     int
+		subjectIDs  []int
+		expectedErr error
+	}{
+		{"Valid student", "eleve@test.mg", RoleStudent, 3, nil, nil},
+		{"Valid teacher", "prof@test.mg", RoleTeacher, 0, []int{1, 2}, nil},
+		{"Empty email", "", RoleStudent, 3, nil, ErrEmailRequired},
+		{"Invalid email", "eleve", RoleStudent, 3, nil, ErrEmailInvalid},
+		{"Student without class", "eleve@test.mg", RoleStudent, 0, nil, ErrInvitationClassRequired},
+		{"Teacher without subjects", "prof@test.mg", RoleTeacher, 0, nil, ErrInvitationSubjectRequired},
+		{"Admin role", "admin@test.mg", RoleAdmin, 0, nil, ErrInvitationInvalidRole},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inv, err := NewInvitation(1, 10, tt.email, tt.role, tt.classID, tt.subjectIDs, 24*time.Hour)
+			if err != tt.expectedErr {
+				t.Fatalf("NewInvitation() error = %v, want %v", err, tt.expectedErr)
+			}
+			if err != nil {
+				return
+			}
+			if inv.Status() != InvitationStatusPending {
+				t.Errorf("NewInvitation() Status = %v, want pending", inv.Status())
+			}
+			if tt.role == RoleStudent && (inv.ClassID == nil || *inv.ClassID != tt.classID) {
+				t.Errorf("NewInvitation() ClassID = %v, want %d", inv.ClassID, tt.classID)
+			}
+		})
+	}
+}
+
+func TestInvitation_Accept(t *testing.T) {
+	inv, _ := NewInvitation(1, 10, "eleve@test.mg", RoleStudent, 3, nil, time.Hour)
+
+	if err := inv.Accept(42); err != nil {
+		t.Fatalf("Accept() error = %v", err)
+	}
+	if inv.Status() != InvitationStatusAccepted || *inv.AcceptedUserID != 42 {
+		t.Errorf("After Accept() Status = %v, AcceptedUserID = %v", inv.Status(), inv.AcceptedUserID)
+	}
+
+	//! Usage unique
+	if err := inv.Accept(43); err != ErrInvitationAlreadyAccepted {
+		t.Errorf("Accept() twice error = %v, want %v", err, ErrInvitationAlreadyAccepted)
+	}
+	if err := inv.Revoke(); err != ErrInvitationAlreadyAccepted {
+		t.Errorf("Revoke() accepted error = %v, want %v", err, ErrInvitationAlreadyAccepted)
+	}
+}
+
+func TestInvitation_ExpiredAndRevoked(t *testing.T) {
+	expired, _ := NewInvitation(1, 10, "eleve@test.mg", RoleStudent, 3, nil, -time.Minute)
+	if err := expired.Accept(42); err != ErrInvitationExpired {
+		t.Errorf("Accept() expired error = %v, want %v", err, ErrInvitationExpired)
+	}
+
+	revoked, _ := NewInvitation(1, 10, "prof@test.mg", RoleTeacher, 0, []int{1}, time.Hour)
+	if err := revoked.Revoke(); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+	if err := revoked.Accept(42); err != ErrInvitationRevoked {
+		t.Errorf("Accept() revoked error = %v, want %v", err, ErrInvitationRevoked)
+	}
+	if err := revoked.Revoke(); err != ErrInvitationRevoked {
+		t.Errorf("Revoke() twice error = %v, want %v", err, ErrInvitationRevoked)
+	}
+}
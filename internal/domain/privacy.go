@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ! ErasedEmailDomain domaine réservé (RFC 2606) des comptes effacés : aucun envoi possible
+const ErasedEmailDomain = "erased.invalid"
+
+// ! Bornes de la durée de conservation des messages (jours)
+const (
+	MinMessageRetentionDays = 30
+	MaxMessageRetentionDays = 3650
+)
+
+// ! Erase anonymise le compte (droit à l'effacement) : l'ID est conservé pour que
+// ! messages, notes et historique restent cohérents, sans donnée identifiante
+func (u *User) Erase() error {
+	if u.IsErased() {
+		return ErrUserAlreadyErased
+	}
+	if u.IsAdmin() || u.IsSuperAdmin() {
+		return ErrErasureNotAllowed
+	}
+
+	u.Email = fmt.Sprintf("erased-%d@%s", u.ID, ErasedEmailDomain)
+	u.FirstName = "Utilisateur"
+	u.LastName = "supprimé"
+	u.Phone = ""
+	u.AvatarURL = ""
+	u.PasswordHash = "" //! aucun mot de passe ne correspond : connexion impossible
+	u.MustChangePassword = false
+	u.Status = UserStatusInactive
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+// ! IsErased vrai si le compte a été anonymisé
+func (u *User) IsErased() bool {
+	return strings.HasSuffix(u.Email, "@"+ErasedEmailDomain)
+}
+
+// ! RetentionPolicy durées de conservation d'une école (nil = conservation illimitée)
+type RetentionPolicy struct {
+	SchoolID             int       `json:"school_id"`
+	MessageRetentionDays *int      `json:"message_retention_days"`
+	UpdatedBy            int       `json:"updated_by,omitempty"`
+	UpdatedAt            time.Time `json:"updated_at"`
+}
+
+func NewRetentionPolicy(schoolID int, messageRetentionDays *int, updatedBy int) (*RetentionPolicy, error) {
+	if days := messageRetentionDays; days != nil && (*days < MinMessageRetentionDays || *days > MaxMessageRetentionDays) {
+		return nil, ErrRetentionInvalidDays
+	}
+	return &RetentionPolicy{
+		SchoolID:             schoolID,
+		MessageRetentionDays: messageRetentionDays,
+		UpdatedBy:            updatedBy,
+		UpdatedAt:            time.Now(),
+	}, nil
+}
+
+// ! MessageCutoff messages antérieurs à cette date à purger (false si conservation illimitée)
+func (p *RetentionPolicy) MessageCutoff(now time.Time) (time.Time, bool) {
+	if p.MessageRetentionDays == nil {
+		return time.Time{}, false
+	}
+	return now.AddDate(0, 0, -*p.MessageRetentionDays), true
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUser_Erase(t *testing.T) {
+	user := &User{
+		ID: 42, SchoolID: 1, Email: "rado@test.mg", PasswordHash: "hash",
+		FirstName: "Rado", LastName: "Rakoto", Phone: "0340000000",
+		Role: RoleStudent, AvatarURL: "/uploads/avatars/avatar_42_1.jpg", Status: UserStatusApproved,
+	}
+
+	if err := user.Erase(); err != nil {
+		t.Fatalf("Erase() error = %v", err)
+	}
+	if user.Email != "erased-42@erased.invalid" || !user.IsErased() {
+		t.Errorf("Erase() Email = %q", user.Email)
+	}
+	if user.FirstName == "Rado" || user.LastName == "Rakoto" || user.Phone != "" || user.AvatarURL != "" {
+		t.Errorf("Erase() kept personal data: %+v", user)
+	}
+	if user.VerifyPassword("") || user.Status != UserStatusInactive {
+		t.Errorf("Erase() account still usable: status = %q", user.Status)
+	}
+	if user.ID != 42 || user.SchoolID != 1 || user.Role != RoleStudent {
+		t.Errorf("Erase() changed identity fields: %+v", user)
+	}
+
+	if err := user.Erase(); err != ErrUserAlreadyErased {
+		t.Errorf("Erase() twice error = %v, want %v", err, ErrUserAlreadyErased)
+	}
+}
+
+func TestUser_EraseAdminNotAllowed(t *testing.T) {
+	admin := &User{ID: 1, Email: "admin@test.mg", Role: RoleAdmin}
+	if err := admin.Erase(); err != ErrErasureNotAllowed {
+		t.Errorf("Erase() error = %v, want %v", err, ErrErasureNotAllowed)
+	}
+}
+
+func TestNewRetentionPolicy(t *testing.T) {
+	days := func(n int) *int { return &n }
+
+	tests := []struct {
+		name    string
+		days    *int
+		wantErr error
+	}{
+		{"Keep forever", nil, nil},
+		{"Minimum", days(MinMessageRetentionDays), nil},
+		{"Maximum", days(MaxMessageRetentionDays), nil},
+		{"Too short", days(7), ErrRetentionInvalidDays},
+		{"Too long", days(MaxMessageRetentionDays + 1), ErrRetentionInvalidDays},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewRetentionPolicy(1, tt.days, 2)
+			if err != tt.wantErr {
+				t.Errorf("NewRetentionPolicy() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRetentionPolicy_MessageCutoff(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	forever := &RetentionPolicy{SchoolID: 1}
+	if _, ok := forever.MessageCutoff(now); ok {
+		t.Error("MessageCutoff() ok = true for unlimited retention")
+	}
+
+	days := 90
+	policy := &RetentionPolicy{SchoolID: 1, MessageRetentionDays: &days}
+	cutoff, ok := policy.MessageCutoff(now)
+	if !ok || !cutoff.Equal(time.Date(2026, 7, 21, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("MessageCutoff() = %v, %v", cutoff, ok)
+	}
+}
@@ -0,0 +1,320 @@
+package domain
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ! Champs du profil étendu, soumis à des droits de lecture / écriture par champ
+const (
+	FieldDateOfBirth    = "date_of_birth"
+	FieldAddress        = "address"
+	FieldStudentNumber  = "student_number"
+	FieldMedicalNotes   = "medical_notes"
+	FieldGuardians      = "guardians"
+	FieldDocuments      = "documents"
+	FieldQualifications = "qualifications"
+	FieldHireDate       = "hire_date"
+)
+
+// ! Relation entre le lecteur et le titulaire du profil
+const (
+	ViewerSelf    = "self"
+	ViewerAdmin   = "admin"
+	ViewerTeacher = "teacher" //! enseignant de l'école consultant un élève
+)
+
+// ! Types de documents du profil
+const (
+	DocumentCertificate = "certificate"
+	DocumentMedical     = "medical"
+	DocumentIdentity    = "identity"
+	DocumentDiploma     = "diploma"
+	DocumentCV          = "cv"
+	DocumentOther       = "other"
+)
+
+const (
+	// ! ProfileMaxGuardians responsables légaux et contacts d'urgence par élève
+	ProfileMaxGuardians = 5
+	// ! ProfileMaxDocuments documents téléversés par profil
+	ProfileMaxDocuments = 20
+	// ! ProfileMaxFileSize taille maximale d'un document (10 Mo)
+	ProfileMaxFileSize = 10 << 20
+)
+
+var profileReadable = map[string]map[string]bool{
+	ViewerAdmin: {
+		FieldDateOfBirth: true, FieldAddress: true, FieldStudentNumber: true, FieldMedicalNotes: true,
+		FieldGuardians: true, FieldDocuments: true, FieldQualifications: true, FieldHireDate: true,
+	},
+	ViewerSelf: {
+		FieldDateOfBirth: true, FieldAddress: true, FieldStudentNumber: true, FieldMedicalNotes: true,
+		FieldGuardians: true, FieldDocuments: true, FieldQualifications: true, FieldHireDate: true,
+	},
+	//! Un enseignant voit de quoi identifier l'élève et qui prévenir, pas l'adresse ni le médical
+	ViewerTeacher: {FieldDateOfBirth: true, FieldStudentNumber: true, FieldGuardians: true},
+}
+
+var profileWritable = map[string]map[string]bool{
+	ViewerAdmin: {
+		FieldDateOfBirth: true, FieldAddress: true, FieldStudentNumber: true, FieldMedicalNotes: true,
+		FieldGuardians: true, FieldDocuments: true, FieldQualifications: true, FieldHireDate: true,
+	},
+	//! L'état civil, le matricule, le médical et la date d'embauche restent du ressort de l'administration
+	ViewerSelf: {FieldAddress: true, FieldGuardians: true, FieldDocuments: true, FieldQualifications: true},
+}
+
+// ! profileFields champs du profil étendu selon le rôle du titulaire
+var profileFields = map[string]map[string]bool{
+	RoleStudent: {
+		FieldDateOfBirth: true, FieldAddress: true, FieldStudentNumber: true,
+		FieldMedicalNotes: true, FieldGuardians: true, FieldDocuments: true,
+	},
+	RoleTeacher: {FieldQualifications: true, FieldHireDate: true, FieldDocuments: true},
+}
+
+// ! HasExtendedProfile seuls les élèves et les enseignants ont un profil étendu
+func HasExtendedProfile(role string) bool {
+	return profileFields[role] != nil
+}
+
+// ! ProfileHasField le champ existe pour ce rôle
+func ProfileHasField(role, field string) bool {
+	return profileFields[role][field]
+}
+
+// ! CanReadProfileField droit de lecture d'un champ pour une relation lecteur / titulaire
+func CanReadProfileField(viewer, field string) bool {
+	return profileReadable[viewer][field]
+}
+
+// ! CanWriteProfileField droit de modification d'un champ
+func CanWriteProfileField(viewer, field string) bool {
+	return profileWritable[viewer][field]
+}
+
+// ! ProfileViewerFor relation de actor à target : soi-même, admin de l'école, ou enseignant
+// ! consultant un élève de l'école. ErrNotFound hors de l'école, ErrForbidden sinon.
+func ProfileViewerFor(actor, target *User) (string, error) {
+	if actor.ID == target.ID {
+		return ViewerSelf, nil
+	}
+	if actor.SchoolID == 0 || actor.SchoolID != target.SchoolID {
+		return "", ErrNotFound
+	}
+	switch {
+	case actor.IsAdmin():
+		return ViewerAdmin, nil
+	case actor.IsTeacher() && target.IsStudent():
+		return ViewerTeacher, nil
+	}
+	return "", ErrForbidden
+}
+
+var studentNumberPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9./-]{0,49}$`)
+
+// ! StudentProfile profil étendu d'un élève ; StudentNumber est le matricule (unique dans l'école)
+type StudentProfile struct {
+	UserID        int         `json:"user_id"`
+	SchoolID      int         `json:"school_id"`
+	DateOfBirth   *time.Time  `json:"date_of_birth,omitempty"`
+	Address       string      `json:"address"`
+	StudentNumber string      `json:"student_number"`
+	MedicalNotes  string      `json:"medical_notes"`
+	Guardians     []*Guardian `json:"guardians"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
+
+// ! Validate normalise les champs texte et vérifie l'ensemble du profil
+func (p *StudentProfile) Validate(now time.Time) error {
+	p.Address = strings.TrimSpace(p.Address)
+	p.MedicalNotes = strings.TrimSpace(p.MedicalNotes)
+	p.StudentNumber = strings.TrimSpace(p.StudentNumber)
+
+	if err := validateProfileDate(p.DateOfBirth, now); err != nil {
+		return err
+	}
+	if len(p.Address) > 500 || len(p.MedicalNotes) > 2000 {
+		return ErrProfileFieldTooLong
+	}
+	if p.StudentNumber != "" && !studentNumberPattern.MatchString(p.StudentNumber) {
+		return ErrStudentNumberInvalid
+	}
+	if len(p.Guardians) > ProfileMaxGuardians {
+		return ErrTooManyGuardians
+	}
+	return nil
+}
+
+// ! Redact retire les champs que le lecteur n'a pas le droit de voir
+func (p *StudentProfile) Redact(viewer string) {
+	if !CanReadProfileField(viewer, FieldDateOfBirth) {
+		p.DateOfBirth = nil
+	}
+	if !CanReadProfileField(viewer, FieldAddress) {
+		p.Address = ""
+	}
+	if !CanReadProfileField(viewer, FieldStudentNumber) {
+		p.StudentNumber = ""
+	}
+	if !CanReadProfileField(viewer, FieldMedicalNotes) {
+		p.MedicalNotes = ""
+	}
+	if !CanReadProfileField(viewer, FieldGuardians) {
+		p.Guardians = nil
+	}
+}
+
+// ! Guardian responsable légal et/ou contact d'urgence d'un élève (sans compte utilisateur)
+type Guardian struct {
+	ID                 int    `json:"id"`
+	FullName           string `json:"full_name"`
+	Relationship       string `json:"relationship"`
+	Phone              string `json:"phone"`
+	Email              string `json:"email"`
+	IsLegalGuardian    bool   `json:"is_legal_guardian"`
+	IsEmergencyContact bool   `json:"is_emergency_contact"`
+}
+
+func NewGuardian(fullName, relationship, phone, email string, isLegalGuardian, isEmergencyContact bool) (*Guardian, error) {
+	fullName = strings.TrimSpace(fullName)
+	if fullName == "" || len(fullName) > 200 {
+		return nil, ErrGuardianNameRequired
+	}
+	phone, email = strings.TrimSpace(phone), strings.TrimSpace(email)
+	if len(phone) > 30 || (email != "" && !isValidEmail(email)) || (phone == "" && email == "") {
+		return nil, ErrGuardianContactRequired
+	}
+	relationship = strings.TrimSpace(relationship)
+	if len([]rune(relationship)) > 50 {
+		return nil, ErrGuardianRelationshipTooLong
+	}
+	return &Guardian{
+		FullName:           fullName,
+		Relationship:       relationship,
+		Phone:              phone,
+		Email:              strings.ToLower(email),
+		IsLegalGuardian:    isLegalGuardian,
+		IsEmergencyContact: isEmergencyContact,
+	}, nil
+}
+
+// ! TeacherProfile profil étendu d'un enseignant ; le CV est un document de type cv
+type TeacherProfile struct {
+	UserID         int        `json:"user_id"`
+	SchoolID       int        `json:"school_id"`
+	Qualifications []string   `json:"qualifications"`
+	HireDate       *time.Time `json:"hire_date,omitempty"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// ! Validate dédoublonne les diplômes / qualifications et vérifie la date d'embauche
+func (p *TeacherProfile) Validate(now time.Time) error {
+	qualifications := []string{}
+	seen := map[string]bool{}
+	for _, q := range p.Qualifications {
+		q = strings.TrimSpace(q)
+		key := strings.ToLower(q)
+		if q == "" || seen[key] {
+			continue
+		}
+		if len(q) > 200 {
+			return ErrQualificationsInvalid
+		}
+		seen[key] = true
+		qualifications = append(qualifications, q)
+	}
+	if len(qualifications) > 20 {
+		return ErrQualificationsInvalid
+	}
+	p.Qualifications = qualifications
+
+	//! Une embauche peut être programmée, dans la limite d'un an
+	return validateProfileDate(p.HireDate, now.AddDate(1, 0, 0))
+}
+
+// ! validateProfileDate date renseignée entre 1900 et max
+func validateProfileDate(date *time.Time, max time.Time) error {
+	if date == nil {
+		return nil
+	}
+	if date.Year() < 1900 || date.After(max) {
+		return ErrProfileInvalidDate
+	}
+	return nil
+}
+
+var documentKinds = map[string]bool{
+	DocumentCertificate: true, DocumentMedical: true, DocumentIdentity: true,
+	DocumentDiploma: true, DocumentCV: true, DocumentOther: true,
+}
+
+var profileDocumentExtensions = map[string]bool{
+	".pdf": true, ".png": true, ".jpg": true, ".jpeg": true, ".doc": true, ".docx": true, ".odt": true,
+}
+
+// ! ProfileDocument pièce du dossier (certificat, CV...), servie après contrôle d'accès
+type ProfileDocument struct {
+	ID          int       `json:"id"`
+	SchoolID    int       `json:"school_id"`
+	UserID      int       `json:"user_id"`
+	Kind        string    `json:"kind"`
+	Title       string    `json:"title"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	StoragePath string    `json:"-"`
+	UploadedBy  int       `json:"uploaded_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ! NewProfileDocument title vide : nom du fichier
+func NewProfileDocument(schoolID, userID int, kind, title, fileName, contentType string, size int64, uploadedBy int) (*ProfileDocument, error) {
+	if kind == "" {
+		kind = DocumentOther
+	}
+	if !documentKinds[kind] {
+		return nil, ErrProfileDocumentKind
+	}
+	if size <= 0 {
+		return nil, ErrProfileDocumentRequired
+	}
+	if size > ProfileMaxFileSize {
+		return nil, ErrProfileDocumentTooLarge
+	}
+
+	fileName = sanitizeFileName(fileName)
+	if !profileDocumentExtensions[strings.ToLower(filepath.Ext(fileName))] {
+		return nil, ErrProfileDocumentType
+	}
+	if strings.TrimSpace(contentType) == "" {
+		contentType = "application/octet-stream"
+	}
+	title = strings.TrimSpace(title)
+	if title == "" {
+		title = fileName
+	}
+	if len([]rune(title)) > 255 {
+		return nil, ErrProfileFieldTooLong
+	}
+
+	return &ProfileDocument{
+		SchoolID:    schoolID,
+		UserID:      userID,
+		Kind:        kind,
+		Title:       title,
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        size,
+		UploadedBy:  uploadedBy,
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+// ! Ext extension du fichier (minuscules), utilisée pour le nom de stockage
+func (d *ProfileDocument) Ext() string {
+	return strings.ToLower(filepath.Ext(d.FileName))
+}
@@ -0,0 +1,221 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProfileViewerFor(t *testing.T) {
+	admin := &User{ID: 1, SchoolID: 1, Role: RoleAdmin}
+	teacher := &User{ID: 2, SchoolID: 1, Role: RoleTeacher}
+	student := &User{ID: 3, SchoolID: 1, Role: RoleStudent}
+	otherStudent := &User{ID: 4, SchoolID: 1, Role: RoleStudent}
+	otherSchool := &User{ID: 5, SchoolID: 2, Role: RoleAdmin}
+
+	tests := []struct {
+		name    string
+		actor   *User
+		target  *User
+		want    string
+		wantErr error
+	}{
+		{"Self", student, student, ViewerSelf, nil},
+		{"Admin", admin, teacher, ViewerAdmin, nil},
+		{"Teacher on student", teacher, student, ViewerTeacher, nil},
+		{"Teacher on teacher", teacher, &User{ID: 6, SchoolID: 1, Role: RoleTeacher}, "", ErrForbidden},
+		{"Student on student", student, otherStudent, "", ErrForbidden},
+		{"Other school", otherSchool, student, "", ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ProfileViewerFor(tt.actor, tt.target)
+			if err != tt.wantErr || got != tt.want {
+				t.Errorf("ProfileViewerFor() = %q, %v, want %q, %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProfileFieldPermissions(t *testing.T) {
+	tests := []struct {
+		viewer    string
+		field     string
+		wantRead  bool
+		wantWrite bool
+	}{
+		{ViewerAdmin, FieldMedicalNotes, true, true},
+		{ViewerSelf, FieldMedicalNotes, true, false},
+		{ViewerSelf, FieldAddress, true, true},
+		{ViewerSelf, FieldStudentNumber, true, false},
+		{ViewerSelf, FieldHireDate, true, false},
+		{ViewerSelf, FieldQualifications, true, true},
+		{ViewerTeacher, FieldGuardians, true, false},
+		{ViewerTeacher, FieldMedicalNotes, false, false},
+		{ViewerTeacher, FieldAddress, false, false},
+		{ViewerTeacher, FieldDocuments, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.viewer+"/"+tt.field, func(t *testing.T) {
+			if got := CanReadProfileField(tt.viewer, tt.field); got != tt.wantRead {
+				t.Errorf("CanReadProfileField() = %v, want %v", got, tt.wantRead)
+			}
+			if got := CanWriteProfileField(tt.viewer, tt.field); got != tt.wantWrite {
+				t.Errorf("CanWriteProfileField() = %v, want %v", got, tt.wantWrite)
+			}
+		})
+	}
+}
+
+func TestProfileHasField(t *testing.T) {
+	if !HasExtendedProfile(RoleStudent) || !HasExtendedProfile(RoleTeacher) || HasExtendedProfile(RoleParent) {
+		t.Error("HasExtendedProfile() should be true for students and teachers only")
+	}
+	if !ProfileHasField(RoleStudent, FieldGuardians) || ProfileHasField(RoleStudent, FieldHireDate) {
+		t.Error("ProfileHasField(student) mismatch")
+	}
+	if !ProfileHasField(RoleTeacher, FieldQualifications) || ProfileHasField(RoleTeacher, FieldMedicalNotes) {
+		t.Error("ProfileHasField(teacher) mismatch")
+	}
+}
+
+func TestStudentProfile_Validate(t *testing.T) {
+	now := time.Date(2026, 9, 7, 0, 0, 0, 0, time.UTC)
+	born := time.Date(2012, 3, 14, 0, 0, 0, 0, time.UTC)
+	future := now.AddDate(0, 0, 1)
+	tooOld := time.Date(1899, 12, 31, 0, 0, 0, 0, time.UTC)
+	guardian := &Guardian{FullName: "Rakoto"}
+
+	tests := []struct {
+		name    string
+		profile StudentProfile
+		wantErr error
+	}{
+		{"Valid", StudentProfile{DateOfBirth: &born, Address: " Lot II ", StudentNumber: "2026-6E-0001"}, nil},
+		{"Empty", StudentProfile{}, nil},
+		{"Born in the future", StudentProfile{DateOfBirth: &future}, ErrProfileInvalidDate},
+		{"Born before 1900", StudentProfile{DateOfBirth: &tooOld}, ErrProfileInvalidDate},
+		{"Address too long", StudentProfile{Address: strings.Repeat("a", 501)}, ErrProfileFieldTooLong},
+		{"Medical notes too long", StudentProfile{MedicalNotes: strings.Repeat("a", 2001)}, ErrProfileFieldTooLong},
+		{"Invalid number", StudentProfile{StudentNumber: "N° 12"}, ErrStudentNumberInvalid},
+		{"Too many guardians", StudentProfile{Guardians: []*Guardian{guardian, guardian, guardian, guardian, guardian, guardian}}, ErrTooManyGuardians},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := tt.profile
+			if err := profile.Validate(now); err != tt.wantErr {
+				t.Errorf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStudentProfile_Redact(t *testing.T) {
+	born := time.Date(2012, 3, 14, 0, 0, 0, 0, time.UTC)
+	profile := &StudentProfile{
+		DateOfBirth:   &born,
+		Address:       "Lot II",
+		StudentNumber: "2026-0001",
+		MedicalNotes:  "Asthme",
+		Guardians:     []*Guardian{{FullName: "Rakoto"}},
+	}
+
+	profile.Redact(ViewerTeacher)
+	if profile.Address != "" || profile.MedicalNotes != "" {
+		t.Errorf("Redact(teacher) kept address %q / medical notes %q", profile.Address, profile.MedicalNotes)
+	}
+	if profile.DateOfBirth == nil || profile.StudentNumber == "" || len(profile.Guardians) != 1 {
+		t.Errorf("Redact(teacher) removed readable fields: %+v", profile)
+	}
+}
+
+func TestNewGuardian(t *testing.T) {
+	tests := []struct {
+		name     string
+		fullName string
+		relation string
+		phone    string
+		email    string
+		wantErr  error
+	}{
+		{"Phone only", "Rakoto Jean", "Père", "+261 34 00 000 00", "", nil},
+		{"Email only", "Rasoa", "Mère", "", "Rasoa@Mail.mg", nil},
+		{"No name", " ", "Père", "0340000000", "", ErrGuardianNameRequired},
+		{"No contact", "Rakoto", "Père", "", "", ErrGuardianContactRequired},
+		{"Invalid email", "Rakoto", "Père", "", "rakoto@", ErrGuardianContactRequired},
+		{"Relationship too long", "Rakoto", strings.Repeat("é", 51), "0340000000", "", ErrGuardianRelationshipTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewGuardian(tt.fullName, tt.relation, tt.phone, tt.email, true, true)
+			if err != tt.wantErr {
+				t.Fatalf("NewGuardian() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && tt.email != "" && g.Email != strings.ToLower(tt.email) {
+				t.Errorf("Email = %q, want lower-cased", g.Email)
+			}
+		})
+	}
+}
+
+func TestTeacherProfile_Validate(t *testing.T) {
+	now := time.Date(2026, 9, 7, 0, 0, 0, 0, time.UTC)
+	nextMonth := now.AddDate(0, 1, 0)
+	inTwoYears := now.AddDate(2, 0, 0)
+
+	profile := &TeacherProfile{Qualifications: []string{" CAPES Mathématiques ", "capes mathématiques", "", "Master 2"}, HireDate: &nextMonth}
+	if err := profile.Validate(now); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(profile.Qualifications) != 2 {
+		t.Errorf("Qualifications = %q, want duplicates and blanks removed", profile.Qualifications)
+	}
+
+	if err := (&TeacherProfile{HireDate: &inTwoYears}).Validate(now); err != ErrProfileInvalidDate {
+		t.Errorf("Validate() hire date in two years error = %v, want %v", err, ErrProfileInvalidDate)
+	}
+	if err := (&TeacherProfile{Qualifications: []string{strings.Repeat("x", 201)}}).Validate(now); err != ErrQualificationsInvalid {
+		t.Errorf("Validate() long qualification error = %v, want %v", err, ErrQualificationsInvalid)
+	}
+}
+
+func TestNewProfileDocument(t *testing.T) {
+	tests := []struct {
+		name      string
+		kind      string
+		title     string
+		fileName  string
+		size      int64
+		wantTitle string
+		wantErr   error
+	}{
+		{"Certificate", DocumentCertificate, "Certificat de scolarité", "certif.pdf", 1024, "Certificat de scolarité", nil},
+		{"Default kind and title", "", "", "../../cv.DOCX", 1024, "cv.DOCX", nil},
+		{"Unknown kind", "passport", "", "id.pdf", 1024, "", ErrProfileDocumentKind},
+		{"Empty file", DocumentCV, "", "cv.pdf", 0, "", ErrProfileDocumentRequired},
+		{"Too large", DocumentCV, "", "cv.pdf", ProfileMaxFileSize + 1, "", ErrProfileDocumentTooLarge},
+		{"Executable", DocumentOther, "", "setup.exe", 1024, "", ErrProfileDocumentType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := NewProfileDocument(1, 2, tt.kind, tt.title, tt.fileName, "", tt.size, 2)
+			if err != tt.wantErr {
+				t.Fatalf("NewProfileDocument() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if doc.Title != tt.wantTitle {
+				t.Errorf("Title = %q, want %q", doc.Title, tt.wantTitle)
+			}
+			if doc.ContentType != "application/octet-stream" {
+				t.Errorf("ContentType = %q, want default", doc.ContentType)
+			}
+		})
+	}
+}
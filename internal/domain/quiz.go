@@ -0,0 +1,332 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	QuestionSingleChoice   = "single_choice"
+	QuestionMultipleChoice = "multiple_choice"
+	QuestionTrueFalse      = "true_false"
+	QuestionNumeric        = "numeric"
+	QuestionShortText      = "short_text"
+)
+
+const (
+	// ! QuizMaxDuration durée maximale d'une tentative (minutes)
+	QuizMaxDuration = 240
+	// ! QuizMaxAttempts nombre maximal de tentatives autorisées par élève
+	QuizMaxAttempts = 10
+	// ! QuizSubmitGrace tolérance réseau après la deadline d'une tentative
+	QuizSubmitGrace = 30 * time.Second
+)
+
+// ! AnswerKey réponse attendue. Seul le champ correspondant au type est renseigné.
+// ! Ne doit jamais être renvoyée à un élève avant la clôture du quiz.
+type AnswerKey struct {
+	Choices   []int    `json:"choices,omitempty"` //! index dans Question.Choices
+	Bool      *bool    `json:"bool,omitempty"`
+	Number    *float64 `json:"number,omitempty"`
+	Tolerance float64  `json:"tolerance,omitempty"`
+	Texts     []string `json:"texts,omitempty"` //! réponses acceptées (casse / accents ignorés)
+}
+
+// ! Question élément de la banque de questions d'une matière
+type Question struct {
+	ID        int       `json:"id"`
+	SchoolID  int       `json:"school_id"`
+	SubjectID int       `json:"subject_id"`
+	AuthorID  int       `json:"author_id"`
+	Type      string    `json:"type"`
+	Prompt    string    `json:"prompt"`
+	Choices   []string  `json:"choices"`
+	Answer    AnswerKey `json:"answer_key"`
+	Points    float64   `json:"points"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ! NewQuestion points à 0 => 1 point ; la clé est normalisée selon le type
+func NewQuestion(schoolID, subjectID, authorID int, questionType, prompt string, choices []string, answer AnswerKey, points float64) (*Question, error) {
+	prompt = strings.TrimSpace(prompt)
+	if prompt == "" {
+		return nil, ErrQuestionPromptRequired
+	}
+	if points == 0 {
+		points = 1
+	}
+	if points < 0 {
+		return nil, ErrQuestionInvalidPoints
+	}
+
+	key, cleanChoices, err := normalizeAnswerKey(questionType, choices, answer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Question{
+		SchoolID:  schoolID,
+		SubjectID: subjectID,
+		AuthorID:  authorID,
+		Type:      questionType,
+		Prompt:    prompt,
+		Choices:   cleanChoices,
+		Answer:    key,
+		Points:    points,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+// ! normalizeAnswerKey ne garde que les champs utiles au type
+func normalizeAnswerKey(questionType string, choices []string, answer AnswerKey) (AnswerKey, []string, error) {
+	switch questionType {
+	case QuestionSingleChoice, QuestionMultipleChoice:
+		clean := make([]string, 0, len(choices))
+		for _, c := range choices {
+			if c = strings.TrimSpace(c); c == "" {
+				return AnswerKey{}, nil, ErrQuestionInvalidChoices
+			}
+			clean = append(clean, c)
+		}
+		if len(clean) < 2 {
+			return AnswerKey{}, nil, ErrQuestionInvalidChoices
+		}
+		correct := uniqueSorted(answer.Choices)
+		if len(correct) == 0 || (questionType == QuestionSingleChoice && len(correct) != 1) {
+			return AnswerKey{}, nil, ErrQuestionInvalidAnswer
+		}
+		for _, i := range correct {
+			if i < 0 || i >= len(clean) {
+				return AnswerKey{}, nil, ErrQuestionInvalidAnswer
+			}
+		}
+		return AnswerKey{Choices: correct}, clean, nil
+
+	case QuestionTrueFalse:
+		if answer.Bool == nil {
+			return AnswerKey{}, nil, ErrQuestionInvalidAnswer
+		}
+		return AnswerKey{Bool: answer.Bool}, []string{}, nil
+
+	case QuestionNumeric:
+		if answer.Number == nil || math.IsNaN(*answer.Number) || math.IsInf(*answer.Number, 0) || answer.Tolerance < 0 {
+			return AnswerKey{}, nil, ErrQuestionInvalidAnswer
+		}
+		return AnswerKey{Number: answer.Number, Tolerance: answer.Tolerance}, []string{}, nil
+
+	case QuestionShortText:
+		texts := make([]string, 0, len(answer.Texts))
+		for _, t := range answer.Texts {
+			if t = strings.TrimSpace(t); t != "" {
+				texts = append(texts, t)
+			}
+		}
+		if len(texts) == 0 {
+			return AnswerKey{}, nil, ErrQuestionInvalidAnswer
+		}
+		return AnswerKey{Texts: texts}, []string{}, nil
+	}
+	return AnswerKey{}, nil, ErrQuestionInvalidType
+}
+
+// ! Answer réponse d'un élève à une question (champ selon le type)
+type Answer struct {
+	QuestionID int      `json:"question_id"`
+	Choices    []int    `json:"choices,omitempty"`
+	Bool       *bool    `json:"bool,omitempty"`
+	Number     *float64 `json:"number,omitempty"`
+	Text       string   `json:"text,omitempty"`
+}
+
+// ! IsCorrect choix multiple : tout ou rien (exactement les bonnes propositions)
+func (q *Question) IsCorrect(answer Answer) bool {
+	switch q.Type {
+	case QuestionSingleChoice, QuestionMultipleChoice:
+		given := uniqueSorted(answer.Choices)
+		if len(given) != len(q.Answer.Choices) {
+			return false
+		}
+		for i := range given {
+			if given[i] != q.Answer.Choices[i] {
+				return false
+			}
+		}
+		return true
+	case QuestionTrueFalse:
+		return answer.Bool != nil && q.Answer.Bool != nil && *answer.Bool == *q.Answer.Bool
+	case QuestionNumeric:
+		return answer.Number != nil && q.Answer.Number != nil &&
+			math.Abs(*answer.Number-*q.Answer.Number) <= q.Answer.Tolerance+1e-9
+	case QuestionShortText:
+		given := normalizeText(answer.Text)
+		if given == "" {
+			return false
+		}
+		for _, accepted := range q.Answer.Texts {
+			if normalizeText(accepted) == given {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ! Quiz évaluation en ligne d'une classe, ouverte entre OpensAt et ClosesAt
+type Quiz struct {
+	ID              int       `json:"id"`
+	SchoolID        int       `json:"school_id"`
+	SubjectID       int       `json:"subject_id"`
+	ClassID         int       `json:"class_id"`
+	TermID          int       `json:"term_id"`
+	TeacherID       int       `json:"teacher_id"`
+	Title           string    `json:"title"`
+	Description     string    `json:"description"`
+	DurationMinutes int       `json:"duration_minutes"`
+	OpensAt         time.Time `json:"opens_at"`
+	ClosesAt        time.Time `json:"closes_at"`
+	MaxAttempts     int       `json:"max_attempts"`
+	Coefficient     float64   `json:"coefficient"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// ! NewQuiz maxAttempts et coefficient à 0 => 1
+func NewQuiz(schoolID, subjectID, classID, termID, teacherID int, title, description string, durationMinutes int, opensAt, closesAt time.Time, maxAttempts int, coefficient float64) (*Quiz, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return nil, ErrQuizTitleRequired
+	}
+	if durationMinutes <= 0 || durationMinutes > QuizMaxDuration {
+		return nil, ErrQuizInvalidDuration
+	}
+	if opensAt.IsZero() || closesAt.IsZero() || !closesAt.After(opensAt) {
+		return nil, ErrQuizInvalidWindow
+	}
+	if maxAttempts == 0 {
+		maxAttempts = 1
+	}
+	if maxAttempts < 0 || maxAttempts > QuizMaxAttempts {
+		return nil, ErrQuizInvalidAttempts
+	}
+	if coefficient == 0 {
+		coefficient = 1
+	}
+	if coefficient < 0 {
+		return nil, ErrGradeInvalidCoefficient
+	}
+
+	return &Quiz{
+		SchoolID:        schoolID,
+		SubjectID:       subjectID,
+		ClassID:         classID,
+		TermID:          termID,
+		TeacherID:       teacherID,
+		Title:           title,
+		Description:     strings.TrimSpace(description),
+		DurationMinutes: durationMinutes,
+		OpensAt:         opensAt,
+		ClosesAt:        closesAt,
+		MaxAttempts:     maxAttempts,
+		Coefficient:     coefficient,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}, nil
+}
+
+// ! IsOpen vrai pendant la fenêtre [OpensAt, ClosesAt[
+func (q *Quiz) IsOpen(now time.Time) bool {
+	return !now.Before(q.OpensAt) && now.Before(q.ClosesAt)
+}
+
+// ! IsClosed vrai après la clôture : corrigés visibles par les élèves
+func (q *Quiz) IsClosed(now time.Time) bool {
+	return !now.Before(q.ClosesAt)
+}
+
+// ! Deadline fin d'une tentative démarrée à startedAt (jamais après la clôture)
+func (q *Quiz) Deadline(startedAt time.Time) time.Time {
+	deadline := startedAt.Add(time.Duration(q.DurationMinutes) * time.Minute)
+	if deadline.After(q.ClosesAt) {
+		return q.ClosesAt
+	}
+	return deadline
+}
+
+// ! QuizAttempt tentative d'un élève (SubmittedAt nil = en cours)
+type QuizAttempt struct {
+	ID          int        `json:"id"`
+	SchoolID    int        `json:"school_id"`
+	QuizID      int        `json:"quiz_id"`
+	StudentID   int        `json:"student_id"`
+	Number      int        `json:"number"`
+	StartedAt   time.Time  `json:"started_at"`
+	DeadlineAt  time.Time  `json:"deadline_at"`
+	SubmittedAt *time.Time `json:"submitted_at,omitempty"`
+	Answers     []Answer   `json:"answers"`
+	Score       float64    `json:"score"`
+	MaxScore    float64    `json:"max_score"`
+}
+
+func (a *QuizAttempt) IsSubmitted() bool {
+	return a.SubmittedAt != nil
+}
+
+// ! Expired temps écoulé (tolérance QuizSubmitGrace incluse)
+func (a *QuizAttempt) Expired(now time.Time) bool {
+	return now.After(a.DeadlineAt.Add(QuizSubmitGrace))
+}
+
+// ! Grade corrige la tentative : seules les réponses aux questions du quiz sont
+// ! conservées (une par question). Une tentative expirée vaut 0.
+func (a *QuizAttempt) Grade(questions []*Question, answers []Answer, now time.Time) {
+	byQuestion := make(map[int]Answer, len(answers))
+	for _, answer := range answers {
+		byQuestion[answer.QuestionID] = answer
+	}
+
+	a.Answers = []Answer{}
+	a.Score, a.MaxScore = 0, 0
+	expired := a.Expired(now)
+	for _, q := range questions {
+		a.MaxScore += q.Points
+		answer, ok := byQuestion[q.ID]
+		if !ok || expired {
+			continue
+		}
+		a.Answers = append(a.Answers, answer)
+		if q.IsCorrect(answer) {
+			a.Score += q.Points
+		}
+	}
+	a.SubmittedAt = &now
+}
+
+// ! uniqueSorted copie triée sans doublons
+func uniqueSorted(values []int) []int {
+	out := append([]int(nil), values...)
+	sort.Ints(out)
+	n := 0
+	for i, v := range out {
+		if i == 0 || v != out[n-1] {
+			out[n] = v
+			n++
+		}
+	}
+	return out[:n]
+}
+
+// ! normalizeText minuscules, sans accents, espaces réduits
+func normalizeText(s string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	s, _, _ = transform.String(t, strings.ToLower(s))
+	return strings.Join(strings.Fields(s), " ")
+}
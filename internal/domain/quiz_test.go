@@ -0,0 +1,183 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func boolPtr(b bool) *bool               { return &b }
+func floatPtr(f float64) *float64        { return &f }
+func choiceKey(choices ...int) AnswerKey { return AnswerKey{Choices: choices} }
+
+func TestNewQuestion(t *testing.T) {
+	abc := []string{"A", "B", "C"}
+
+	tests := []struct {
+		name    string
+		qtype   string
+		prompt  string
+		choices []string
+		key     AnswerKey
+		points  float64
+		wantErr error
+	}{
+		{"Single choice", QuestionSingleChoice, "2+2 ?", abc, choiceKey(1), 0, nil},
+		{"Single choice two answers", QuestionSingleChoice, "2+2 ?", abc, choiceKey(0, 1), 1, ErrQuestionInvalidAnswer},
+		{"Single choice out of range", QuestionSingleChoice, "2+2 ?", abc, choiceKey(3), 1, ErrQuestionInvalidAnswer},
+		{"One choice only", QuestionSingleChoice, "2+2 ?", []string{"4"}, choiceKey(0), 1, ErrQuestionInvalidChoices},
+		{"Blank choice", QuestionMultipleChoice, "Primes", []string{"2", " "}, choiceKey(0), 1, ErrQuestionInvalidChoices},
+		{"Multiple choice", QuestionMultipleChoice, "Primes", abc, choiceKey(0, 2, 2), 2, nil},
+		{"Multiple choice no answer", QuestionMultipleChoice, "Primes", abc, AnswerKey{}, 2, ErrQuestionInvalidAnswer},
+		{"True/false", QuestionTrueFalse, "Sky is blue", nil, AnswerKey{Bool: boolPtr(true)}, 1, nil},
+		{"True/false missing key", QuestionTrueFalse, "Sky is blue", nil, AnswerKey{}, 1, ErrQuestionInvalidAnswer},
+		{"Numeric", QuestionNumeric, "Pi", nil, AnswerKey{Number: floatPtr(3.14), Tolerance: 0.01}, 1, nil},
+		{"Numeric negative tolerance", QuestionNumeric, "Pi", nil, AnswerKey{Number: floatPtr(3.14), Tolerance: -1}, 1, ErrQuestionInvalidAnswer},
+		{"Short text", QuestionShortText, "Capitale ?", nil, AnswerKey{Texts: []string{" Antananarivo ", ""}}, 1, nil},
+		{"Short text empty", QuestionShortText, "Capitale ?", nil, AnswerKey{Texts: []string{"  "}}, 1, ErrQuestionInvalidAnswer},
+		{"Unknown type", "essay", "Explain", nil, AnswerKey{}, 1, ErrQuestionInvalidType},
+		{"Empty prompt", QuestionTrueFalse, "  ", nil, AnswerKey{Bool: boolPtr(true)}, 1, ErrQuestionPromptRequired},
+		{"Negative points", QuestionTrueFalse, "Sky is blue", nil, AnswerKey{Bool: boolPtr(true)}, -1, ErrQuestionInvalidPoints},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := NewQuestion(1, 2, 3, tt.qtype, tt.prompt, tt.choices, tt.key, tt.points)
+			if err != tt.wantErr {
+				t.Fatalf("NewQuestion() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && q.Points <= 0 {
+				t.Errorf("Points = %v, want positive", q.Points)
+			}
+		})
+	}
+}
+
+func TestNewQuestion_NormalizesKey(t *testing.T) {
+	q, err := NewQuestion(1, 2, 3, QuestionMultipleChoice, "Primes", []string{"2", "4", "5"},
+		AnswerKey{Choices: []int{2, 0, 2}, Bool: boolPtr(true), Texts: []string{"x"}}, 1)
+	if err != nil {
+		t.Fatalf("NewQuestion() error = %v", err)
+	}
+	if len(q.Answer.Choices) != 2 || q.Answer.Choices[0] != 0 || q.Answer.Choices[1] != 2 {
+		t.Errorf("Choices = %v, want [0 2]", q.Answer.Choices)
+	}
+	if q.Answer.Bool != nil || q.Answer.Texts != nil {
+		t.Errorf("unrelated key fields kept: %+v", q.Answer)
+	}
+}
+
+func TestQuestion_IsCorrect(t *testing.T) {
+	single, _ := NewQuestion(1, 1, 1, QuestionSingleChoice, "q", []string{"a", "b"}, choiceKey(1), 1)
+	multi, _ := NewQuestion(1, 1, 1, QuestionMultipleChoice, "q", []string{"a", "b", "c"}, choiceKey(0, 2), 1)
+	tf, _ := NewQuestion(1, 1, 1, QuestionTrueFalse, "q", nil, AnswerKey{Bool: boolPtr(false)}, 1)
+	num, _ := NewQuestion(1, 1, 1, QuestionNumeric, "q", nil, AnswerKey{Number: floatPtr(9.81), Tolerance: 0.05}, 1)
+	text, _ := NewQuestion(1, 1, 1, QuestionShortText, "q", nil, AnswerKey{Texts: []string{"Élève assidu"}}, 1)
+
+	tests := []struct {
+		name   string
+		q      *Question
+		answer Answer
+		want   bool
+	}{
+		{"Single right", single, Answer{Choices: []int{1}}, true},
+		{"Single wrong", single, Answer{Choices: []int{0}}, false},
+		{"Single both", single, Answer{Choices: []int{0, 1}}, false},
+		{"Multi exact", multi, Answer{Choices: []int{2, 0}}, true},
+		{"Multi partial", multi, Answer{Choices: []int{0}}, false},
+		{"Multi extra", multi, Answer{Choices: []int{0, 1, 2}}, false},
+		{"True/false right", tf, Answer{Bool: boolPtr(false)}, true},
+		{"True/false missing", tf, Answer{}, false},
+		{"Numeric within tolerance", num, Answer{Number: floatPtr(9.8)}, true},
+		{"Numeric outside tolerance", num, Answer{Number: floatPtr(9.7)}, false},
+		{"Text case and accents", text, Answer{Text: "  eleve   ASSIDU "}, true},
+		{"Text wrong", text, Answer{Text: "absent"}, false},
+		{"Text empty", text, Answer{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.q.IsCorrect(tt.answer); got != tt.want {
+				t.Errorf("IsCorrect() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewQuiz(t *testing.T) {
+	opens := time.Date(2025, 10, 6, 8, 0, 0, 0, time.UTC)
+	closes := opens.Add(48 * time.Hour)
+
+	tests := []struct {
+		name     string
+		title    string
+		duration int
+		opens    time.Time
+		closes   time.Time
+		attempts int
+		wantErr  error
+	}{
+		{"Valid quiz", "Chapitre 1", 30, opens, closes, 0, nil},
+		{"Empty title", " ", 30, opens, closes, 1, ErrQuizTitleRequired},
+		{"No duration", "Chapitre 1", 0, opens, closes, 1, ErrQuizInvalidDuration},
+		{"Too long", "Chapitre 1", QuizMaxDuration + 1, opens, closes, 1, ErrQuizInvalidDuration},
+		{"Closes before opens", "Chapitre 1", 30, closes, opens, 1, ErrQuizInvalidWindow},
+		{"Too many attempts", "Chapitre 1", 30, opens, closes, QuizMaxAttempts + 1, ErrQuizInvalidAttempts},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quiz, err := NewQuiz(1, 2, 3, 4, 5, tt.title, "", tt.duration, tt.opens, tt.closes, tt.attempts, 0)
+			if err != tt.wantErr {
+				t.Fatalf("NewQuiz() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (quiz.MaxAttempts != 1 || quiz.Coefficient != 1) {
+				t.Errorf("defaults = %d attempts, coef %v, want 1 / 1", quiz.MaxAttempts, quiz.Coefficient)
+			}
+		})
+	}
+}
+
+func TestQuiz_Window(t *testing.T) {
+	opens := time.Date(2025, 10, 6, 8, 0, 0, 0, time.UTC)
+	quiz, _ := NewQuiz(1, 1, 1, 1, 1, "Quiz", "", 30, opens, opens.Add(time.Hour), 1, 1)
+
+	if quiz.IsOpen(opens.Add(-time.Second)) || !quiz.IsOpen(opens) || quiz.IsOpen(quiz.ClosesAt) {
+		t.Error("IsOpen() window boundaries are wrong")
+	}
+	if quiz.IsClosed(opens) || !quiz.IsClosed(quiz.ClosesAt) {
+		t.Error("IsClosed() boundaries are wrong")
+	}
+	if got := quiz.Deadline(opens); !got.Equal(opens.Add(30 * time.Minute)) {
+		t.Errorf("Deadline() = %v, want start + duration", got)
+	}
+	if got := quiz.Deadline(opens.Add(50 * time.Minute)); !got.Equal(quiz.ClosesAt) {
+		t.Errorf("Deadline() = %v, want capped at close", got)
+	}
+}
+
+func TestQuizAttempt_Grade(t *testing.T) {
+	q1, _ := NewQuestion(1, 1, 1, QuestionTrueFalse, "q1", nil, AnswerKey{Bool: boolPtr(true)}, 2)
+	q2, _ := NewQuestion(1, 1, 1, QuestionNumeric, "q2", nil, AnswerKey{Number: floatPtr(4)}, 3)
+	q1.ID, q2.ID = 1, 2
+	questions := []*Question{q1, q2}
+
+	start := time.Date(2025, 10, 6, 8, 0, 0, 0, time.UTC)
+	answers := []Answer{
+		{QuestionID: 1, Bool: boolPtr(true)},
+		{QuestionID: 2, Number: floatPtr(5)},
+		{QuestionID: 99, Text: "ignored"},
+	}
+
+	attempt := &QuizAttempt{StartedAt: start, DeadlineAt: start.Add(10 * time.Minute)}
+	attempt.Grade(questions, answers, start.Add(10*time.Minute+QuizSubmitGrace))
+	if attempt.Score != 2 || attempt.MaxScore != 5 {
+		t.Errorf("Score = %v/%v, want 2/5", attempt.Score, attempt.MaxScore)
+	}
+	if len(attempt.Answers) != 2 || !attempt.IsSubmitted() {
+		t.Errorf("Answers = %d, submitted = %v; want 2, true", len(attempt.Answers), attempt.IsSubmitted())
+	}
+
+	late := &QuizAttempt{StartedAt: start, DeadlineAt: start.Add(10 * time.Minute)}
+	late.Grade(questions, answers, start.Add(11*time.Minute))
+	if late.Score != 0 || late.MaxScore != 5 || len(late.Answers) != 0 {
+		t.Errorf("expired attempt = %v/%v with %d answers, want 0/5 and none", late.Score, late.MaxScore, len(late.Answers))
+	}
+}
@@ -0,0 +1,246 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	// ! RankingHistogramBins tranches de l'histogramme /20 (largeur 2 points)
+	RankingHistogramBins = 10
+)
+
+// ! RankingSettings réglages de l'école : visibilité du rang pour les élèves
+type RankingSettings struct {
+	SchoolID           int       `json:"school_id"`
+	StudentRankVisible bool      `json:"student_rank_visible"` //! l'élève voit son rang (moyennes toujours visibles)
+	UpdatedBy          int       `json:"updated_by,omitempty"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// ! DefaultRankingSettings réglages d'une école qui n'a rien configuré (rang masqué)
+func DefaultRankingSettings(schoolID int) *RankingSettings {
+	return &RankingSettings{SchoolID: schoolID}
+}
+
+// ! GradeSetVersion empreinte des notes d'une classe pour une période : toute création,
+// ! modification ou suppression la change (nombre, plus grand ID, somme des versions)
+type GradeSetVersion struct {
+	Count      int
+	MaxID      int
+	VersionSum int64
+	UpdatedAt  int64 //! plus récent updated_at (UnixNano)
+}
+
+// ! HistogramBin nombre de moyennes dans [From, To[ (la dernière tranche inclut 20)
+type HistogramBin struct {
+	From  float64 `json:"from"`
+	To    float64 `json:"to"`
+	Count int     `json:"count"`
+}
+
+// ! ClassStatistics repères de la classe sur les moyennes /20 (nil si aucun élève noté)
+type ClassStatistics struct {
+	Count     int            `json:"count"` //! élèves notés
+	Min       *float64       `json:"min,omitempty"`
+	Max       *float64       `json:"max,omitempty"`
+	Mean      *float64       `json:"mean,omitempty"`
+	Median    *float64       `json:"median,omitempty"`
+	Histogram []HistogramBin `json:"histogram"`
+}
+
+// ! RankingEntry moyenne et rang d'un élève ; Rank 0 = non classé (aucune note)
+type RankingEntry struct {
+	StudentID   int      `json:"student_id"`
+	StudentName string   `json:"student_name"`
+	Average     *float64 `json:"average,omitempty"`
+	Rank        int      `json:"rank,omitempty"`
+}
+
+// ! RankingTable classement d'une matière (SubjectID 0 = classement général)
+type RankingTable struct {
+	SubjectID   int             `json:"subject_id,omitempty"`
+	SubjectName string          `json:"subject_name,omitempty"`
+	Statistics  ClassStatistics `json:"statistics"`
+	Entries     []RankingEntry  `json:"entries"` //! par rang puis nom, non classés à la fin
+}
+
+// ! ClassRanking classements d'une classe pour une période (même règle que les bulletins)
+type ClassRanking struct {
+	ClassID    int            `json:"class_id"`
+	TermID     int            `json:"term_id"`
+	ClassSize  int            `json:"class_size"`
+	Overall    RankingTable   `json:"overall"`
+	Subjects   []RankingTable `json:"subjects"` //! matières notées, par ordre alphabétique
+	ComputedAt time.Time      `json:"computed_at"`
+}
+
+// ! BuildClassRanking moyennes pondérées par matière, moyenne générale = moyenne simple des
+// ! moyennes par matière, ex-aequo au même rang ; les notes d'élèves hors roster sont ignorées
+func BuildClassRanking(classID, termID int, students []*User, subjects []*Subject, grades []*Grade, now time.Time) *ClassRanking {
+	enrolled := make(map[int]bool, len(students))
+	for _, s := range students {
+		enrolled[s.ID] = true
+	}
+	bySubject := map[int]map[int][]*Grade{}
+	for _, g := range grades {
+		if !enrolled[g.StudentID] {
+			continue
+		}
+		if bySubject[g.SubjectID] == nil {
+			bySubject[g.SubjectID] = map[int][]*Grade{}
+		}
+		bySubject[g.SubjectID][g.StudentID] = append(bySubject[g.SubjectID][g.StudentID], g)
+	}
+
+	graded := make([]*Subject, 0, len(subjects))
+	for _, s := range subjects {
+		if len(bySubject[s.ID]) > 0 {
+			graded = append(graded, s)
+		}
+	}
+	sort.Slice(graded, func(i, j int) bool { return graded[i].Name < graded[j].Name })
+
+	ranking := &ClassRanking{
+		ClassID:    classID,
+		TermID:     termID,
+		ClassSize:  len(students),
+		Subjects:   make([]RankingTable, 0, len(graded)),
+		ComputedAt: now,
+	}
+	studentAverages := map[int]map[int]float64{}
+	for _, s := range graded {
+		averages := map[int]float64{}
+		for student, grades := range bySubject[s.ID] {
+			if avg, ok := SubjectAverage(grades); ok {
+				averages[student] = avg
+			}
+		}
+		studentAverages[s.ID] = averages
+		table := rankingTable(students, averages)
+		table.SubjectID, table.SubjectName = s.ID, s.Name
+		ranking.Subjects = append(ranking.Subjects, table)
+	}
+	ranking.Overall = rankingTable(students, generalAverages(students, graded, studentAverages))
+	return ranking
+}
+
+// ! rankingTable entrées classées et statistiques à partir des moyennes par élève
+func rankingTable(students []*User, averages map[int]float64) RankingTable {
+	ranks := RankAverages(averages)
+	table := RankingTable{Entries: make([]RankingEntry, 0, len(students))}
+	values := make([]float64, 0, len(averages))
+	for _, student := range students {
+		entry := RankingEntry{StudentID: student.ID, StudentName: student.GetFullName(), Rank: ranks[student.ID]}
+		if avg, ok := averages[student.ID]; ok {
+			rounded := float64(roundAverage(avg)) / 100
+			entry.Average = &rounded
+			values = append(values, avg)
+		}
+		table.Entries = append(table.Entries, entry)
+	}
+	sort.SliceStable(table.Entries, func(i, j int) bool {
+		a, b := table.Entries[i], table.Entries[j]
+		if (a.Rank == 0) != (b.Rank == 0) {
+			return b.Rank == 0
+		}
+		if a.Rank != b.Rank {
+			return a.Rank < b.Rank
+		}
+		return a.StudentName < b.StudentName
+	})
+	table.Statistics = NewClassStatistics(values)
+	return table
+}
+
+// ! NewClassStatistics min, max, moyenne, médiane (arrondis au centième) et histogramme
+func NewClassStatistics(values []float64) ClassStatistics {
+	stats := ClassStatistics{Count: len(values), Histogram: make([]HistogramBin, RankingHistogramBins)}
+	width := DefaultMaxScore / RankingHistogramBins
+	for i := range stats.Histogram {
+		stats.Histogram[i] = HistogramBin{From: float64(i) * width, To: float64(i+1) * width}
+	}
+	if len(values) == 0 {
+		return stats
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	for _, v := range sorted {
+		bin := int(v / width)
+		if bin >= RankingHistogramBins {
+			bin = RankingHistogramBins - 1
+		}
+		if bin < 0 {
+			bin = 0
+		}
+		stats.Histogram[bin].Count++
+	}
+
+	mean, min, max := summarize(sorted)
+	median := sorted[len(sorted)/2]
+	if len(sorted)%2 == 0 {
+		median = (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+	}
+	stats.Min, stats.Max, stats.Mean, stats.Median = roundedPtr(*min), roundedPtr(*max), roundedPtr(*mean), roundedPtr(median)
+	return stats
+}
+
+// ! StudentRank position de l'élève dans un classement (Rank 0 : non classé ou masqué)
+type StudentRank struct {
+	SubjectID    int      `json:"subject_id,omitempty"`
+	SubjectName  string   `json:"subject_name,omitempty"`
+	Average      *float64 `json:"average,omitempty"`
+	ClassAverage *float64 `json:"class_average,omitempty"`
+	Rank         int      `json:"rank,omitempty"`
+	Ranked       int      `json:"ranked,omitempty"` //! élèves classés
+}
+
+// ! StudentRanking vue élève du classement de sa classe
+type StudentRanking struct {
+	StudentID   int           `json:"student_id"`
+	ClassID     int           `json:"class_id"`
+	TermID      int           `json:"term_id"`
+	ClassSize   int           `json:"class_size"`
+	RankVisible bool          `json:"rank_visible"`
+	Overall     StudentRank   `json:"overall"`
+	Subjects    []StudentRank `json:"subjects"`
+}
+
+// ! ForStudent extrait la ligne de l'élève ; rangs omis si withRank est faux
+func (c *ClassRanking) ForStudent(studentID int, withRank bool) *StudentRanking {
+	view := &StudentRanking{
+		StudentID:   studentID,
+		ClassID:     c.ClassID,
+		TermID:      c.TermID,
+		ClassSize:   c.ClassSize,
+		RankVisible: withRank,
+		Overall:     c.Overall.studentRank(studentID, withRank),
+		Subjects:    make([]StudentRank, 0, len(c.Subjects)),
+	}
+	for _, table := range c.Subjects {
+		view.Subjects = append(view.Subjects, table.studentRank(studentID, withRank))
+	}
+	return view
+}
+
+func (t *RankingTable) studentRank(studentID int, withRank bool) StudentRank {
+	rank := StudentRank{SubjectID: t.SubjectID, SubjectName: t.SubjectName, ClassAverage: t.Statistics.Mean}
+	for _, entry := range t.Entries {
+		if entry.StudentID == studentID {
+			rank.Average = entry.Average
+			if withRank {
+				rank.Rank = entry.Rank
+			}
+		}
+	}
+	if withRank {
+		rank.Ranked = t.Statistics.Count
+	}
+	return rank
+}
+
+func roundedPtr(v float64) *float64 {
+	rounded := float64(roundAverage(v)) / 100
+	return &rounded
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewClassStatistics(t *testing.T) {
+	empty := NewClassStatistics(nil)
+	if empty.Count != 0 || empty.Mean != nil || empty.Median != nil || len(empty.Histogram) != RankingHistogramBins {
+		t.Errorf("NewClassStatistics(nil) = %+v", empty)
+	}
+
+	stats := NewClassStatistics([]float64{12, 8, 20, 15.5})
+	if stats.Count != 4 || *stats.Min != 8 || *stats.Max != 20 || *stats.Mean != 13.88 || *stats.Median != 13.75 {
+		t.Errorf("NewClassStatistics() = count %d min %v max %v mean %v median %v",
+			stats.Count, *stats.Min, *stats.Max, *stats.Mean, *stats.Median)
+	}
+	//! 8 -> [8,10[, 12 -> [12,14[, 15.5 -> [14,16[, 20 -> dernière tranche
+	want := map[int]int{4: 1, 6: 1, 7: 1, 9: 1}
+	for i, bin := range stats.Histogram {
+		if bin.Count != want[i] {
+			t.Errorf("Histogram[%d] = %+v, want count %d", i, bin, want[i])
+		}
+	}
+
+	odd := NewClassStatistics([]float64{14, 9, 11})
+	if *odd.Median != 11 {
+		t.Errorf("Median = %v, want 11", *odd.Median)
+	}
+}
+
+func TestBuildClassRanking(t *testing.T) {
+	maths := &Subject{ID: 10, Name: "Mathématiques"}
+	french := &Subject{ID: 20, Name: "Français"}
+	music := &Subject{ID: 30, Name: "Musique"} //! aucune note : absente du classement
+	alice := &User{ID: 1, FirstName: "Alice"}
+	bob := &User{ID: 2, FirstName: "Bob"}
+	carol := &User{ID: 3, FirstName: "Carol"}
+	dave := &User{ID: 4, FirstName: "Dave"} //! aucune note : non classé
+
+	grades := []*Grade{
+		{StudentID: 1, SubjectID: 10, Score: 16, MaxScore: 20, Coefficient: 1},
+		{StudentID: 1, SubjectID: 20, Score: 12, MaxScore: 20, Coefficient: 1},
+		{StudentID: 2, SubjectID: 10, Score: 9, MaxScore: 10, Coefficient: 3}, //! 18/20 coef 3
+		{StudentID: 2, SubjectID: 10, Score: 6, MaxScore: 20, Coefficient: 1}, //! (54 + 6) / 4 = 15
+		{StudentID: 2, SubjectID: 20, Score: 13, MaxScore: 20, Coefficient: 1},
+		{StudentID: 3, SubjectID: 10, Score: 15, MaxScore: 20, Coefficient: 1},
+		{StudentID: 99, SubjectID: 10, Score: 20, MaxScore: 20, Coefficient: 1}, //! hors roster
+	}
+	ranking := BuildClassRanking(5, 6, []*User{alice, bob, carol, dave}, []*Subject{maths, french, music}, grades, time.Now())
+
+	if ranking.ClassSize != 4 || len(ranking.Subjects) != 2 || ranking.Subjects[0].SubjectID != french.ID {
+		t.Fatalf("BuildClassRanking() subjects = %+v", ranking.Subjects)
+	}
+
+	//! Mathématiques : Alice 16, Bob 15, Carol 15 (ex-aequo), Dave non classé
+	mathTable := ranking.Subjects[1]
+	wantMath := []struct {
+		id, rank int
+	}{{1, 1}, {2, 2}, {3, 2}, {4, 0}}
+	for i, want := range wantMath {
+		entry := mathTable.Entries[i]
+		if entry.StudentID != want.id || entry.Rank != want.rank {
+			t.Errorf("maths entry %d = %+v, want student %d rank %d", i, entry, want.id, want.rank)
+		}
+	}
+	if mathTable.Statistics.Count != 3 || *mathTable.Statistics.Max != 16 || *mathTable.Statistics.Median != 15 {
+		t.Errorf("maths statistics = %+v", mathTable.Statistics)
+	}
+
+	//! Général : Alice (16+12)/2 = 14, Bob (15+13)/2 = 14, Carol 15
+	overall := ranking.Overall
+	if overall.Entries[0].StudentID != 3 || overall.Entries[0].Rank != 1 {
+		t.Errorf("overall first = %+v, want Carol rank 1", overall.Entries[0])
+	}
+	if overall.Entries[1].Rank != 2 || overall.Entries[2].Rank != 2 || overall.Entries[3].Rank != 0 {
+		t.Errorf("overall entries = %+v, want tie at rank 2", overall.Entries)
+	}
+
+	view := ranking.ForStudent(bob.ID, true)
+	if view.Overall.Rank != 2 || view.Overall.Ranked != 3 || *view.Overall.Average != 14 || len(view.Subjects) != 2 {
+		t.Errorf("ForStudent() = %+v", view)
+	}
+	hidden := ranking.ForStudent(bob.ID, false)
+	if hidden.Overall.Rank != 0 || hidden.Overall.Ranked != 0 || hidden.Subjects[1].Rank != 0 || hidden.Overall.Average == nil {
+		t.Errorf("ForStudent() without rank = %+v", hidden)
+	}
+}
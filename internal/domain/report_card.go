@@ -0,0 +1,236 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// ! ReportCard bulletin d'un élève pour une période
+type ReportCard struct {
+	School         *School           `json:"school"`
+	Student        *User             `json:"student"`
+	StudentNumber  string            `json:"student_number,omitempty"`
+	Class          *Class            `json:"class"`
+	Term           *Term             `json:"term"`
+	Subjects       []SubjectResult   `json:"subjects"`
+	GeneralAverage *float64          `json:"general_average,omitempty"`
+	ClassAverage   *float64          `json:"class_average,omitempty"`
+	Rank           int               `json:"rank,omitempty"` //! 0 = non classé (aucune note)
+	ClassSize      int               `json:"class_size"`
+	Attendance     AttendanceSummary `json:"attendance"`
+	Comment        string            `json:"comment,omitempty"`
+	GeneratedAt    time.Time         `json:"generated_at"`
+}
+
+// ! SubjectResult ligne du bulletin : moyenne de l'élève et repères de la classe
+type SubjectResult struct {
+	SubjectID    int      `json:"subject_id"`
+	SubjectName  string   `json:"subject_name"`
+	TeacherName  string   `json:"teacher_name,omitempty"`
+	Average      *float64 `json:"average,omitempty"`
+	ClassAverage *float64 `json:"class_average,omitempty"`
+	Min          *float64 `json:"min,omitempty"`
+	Max          *float64 `json:"max,omitempty"`
+	Comment      string   `json:"comment,omitempty"`
+}
+
+// ! ReportCardInput données d'une classe pour une période (chargées par le usecase)
+type ReportCardInput struct {
+	School         *School
+	Class          *Class
+	Term           *Term
+	Students       []*User
+	Subjects       []*Subject
+	Grades         []*Grade
+	Comments       []*ReportComment
+	Attendance     map[int]AttendanceSummary //! par élève
+	TeacherNames   map[int]string            //! par enseignant
+	StudentNumbers map[int]string            //! matricules par élève
+	GeneratedAt    time.Time
+}
+
+// ! SubjectAverage moyenne /20 pondérée par les coefficients (false si aucune note)
+func SubjectAverage(grades []*Grade) (float64, bool) {
+	var total, weights float64
+	for _, g := range grades {
+		total += g.OutOf20() * g.Coefficient
+		weights += g.Coefficient
+	}
+	if weights == 0 {
+		return 0, false
+	}
+	return total / weights, true
+}
+
+// ! RankAverages classement par moyenne décroissante, ex-aequo au même rang (1, 2, 2, 4)
+func RankAverages(averages map[int]float64) map[int]int {
+	ids := make([]int, 0, len(averages))
+	for id := range averages {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if averages[ids[i]] != averages[ids[j]] {
+			return averages[ids[i]] > averages[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+
+	ranks := make(map[int]int, len(ids))
+	for i, id := range ids {
+		if i > 0 && roundAverage(averages[id]) == roundAverage(averages[ids[i-1]]) {
+			ranks[id] = ranks[ids[i-1]]
+			continue
+		}
+		ranks[id] = i + 1
+	}
+	return ranks
+}
+
+// ! BuildReportCards calcule les bulletins de toute la classe (le rang en dépend)
+// ! Moyenne générale = moyenne simple des moyennes par matière
+func BuildReportCards(in ReportCardInput) []*ReportCard {
+	//! 1. Notes par élève et par matière
+	bySubject := map[int]map[int][]*Grade{}
+	latestTeacher := map[int]*Grade{}
+	for _, g := range in.Grades {
+		if bySubject[g.SubjectID] == nil {
+			bySubject[g.SubjectID] = map[int][]*Grade{}
+		}
+		bySubject[g.SubjectID][g.StudentID] = append(bySubject[g.SubjectID][g.StudentID], g)
+		if g.TeacherID > 0 {
+			if last, ok := latestTeacher[g.SubjectID]; !ok || g.GradedOn.After(last.GradedOn) {
+				latestTeacher[g.SubjectID] = g
+			}
+		}
+	}
+
+	subjectComments := map[int]map[int]string{}
+	generalComments := map[int]string{}
+	for _, c := range in.Comments {
+		if c.SubjectID == nil {
+			generalComments[c.StudentID] = c.Comment
+			continue
+		}
+		if subjectComments[*c.SubjectID] == nil {
+			subjectComments[*c.SubjectID] = map[int]string{}
+		}
+		subjectComments[*c.SubjectID][c.StudentID] = c.Comment
+	}
+
+	//! 2. Matières du bulletin : celles notées ou commentées, par ordre alphabétique
+	subjects := make([]*Subject, 0, len(in.Subjects))
+	for _, s := range in.Subjects {
+		if len(bySubject[s.ID]) > 0 || len(subjectComments[s.ID]) > 0 {
+			subjects = append(subjects, s)
+		}
+	}
+	sort.Slice(subjects, func(i, j int) bool { return subjects[i].Name < subjects[j].Name })
+
+	//! 3. Moyennes par matière (élève + classe)
+	studentAverages := map[int]map[int]float64{} //! subject -> student -> moyenne
+	results := make([]SubjectResult, len(subjects))
+	for i, s := range subjects {
+		studentAverages[s.ID] = map[int]float64{}
+		result := SubjectResult{SubjectID: s.ID, SubjectName: s.Name}
+		if g, ok := latestTeacher[s.ID]; ok {
+			result.TeacherName = in.TeacherNames[g.TeacherID]
+		}
+
+		var values []float64
+		for _, student := range in.Students {
+			if avg, ok := SubjectAverage(bySubject[s.ID][student.ID]); ok {
+				studentAverages[s.ID][student.ID] = avg
+				values = append(values, avg)
+			}
+		}
+		result.ClassAverage, result.Min, result.Max = summarize(values)
+		results[i] = result
+	}
+
+	//! 4. Moyennes générales et rang
+	generals := generalAverages(in.Students, subjects, studentAverages)
+	ranks := RankAverages(generals)
+
+	generalValues := make([]float64, 0, len(generals))
+	for _, avg := range generals {
+		generalValues = append(generalValues, avg)
+	}
+	classAverage, _, _ := summarize(generalValues)
+
+	//! 5. Un bulletin par élève
+	cards := make([]*ReportCard, 0, len(in.Students))
+	for _, student := range in.Students {
+		card := &ReportCard{
+			School:        in.School,
+			Student:       student,
+			StudentNumber: in.StudentNumbers[student.ID],
+			Class:         in.Class,
+			Term:          in.Term,
+			Subjects:      make([]SubjectResult, len(results)),
+			ClassAverage:  classAverage,
+			Rank:          ranks[student.ID],
+			ClassSize:     len(in.Students),
+			Attendance:    in.Attendance[student.ID],
+			Comment:       generalComments[student.ID],
+			GeneratedAt:   in.GeneratedAt,
+		}
+		if avg, ok := generals[student.ID]; ok {
+			card.GeneralAverage = &avg
+		}
+		for i, result := range results {
+			if avg, ok := studentAverages[result.SubjectID][student.ID]; ok {
+				result.Average = &avg
+			}
+			result.Comment = subjectComments[result.SubjectID][student.ID]
+			card.Subjects[i] = result
+		}
+		cards = append(cards, card)
+	}
+	return cards
+}
+
+// ! generalAverages moyenne générale = moyenne simple des moyennes par matière (élèves notés)
+func generalAverages(students []*User, subjects []*Subject, studentAverages map[int]map[int]float64) map[int]float64 {
+	generals := map[int]float64{}
+	for _, student := range students {
+		var sum float64
+		var count int
+		for _, s := range subjects {
+			if avg, ok := studentAverages[s.ID][student.ID]; ok {
+				sum += avg
+				count++
+			}
+		}
+		if count > 0 {
+			generals[student.ID] = sum / float64(count)
+		}
+	}
+	return generals
+}
+
+// ! summarize moyenne, min et max (nil si aucune valeur)
+func summarize(values []float64) (avg, min, max *float64) {
+	if len(values) == 0 {
+		return nil, nil, nil
+	}
+	lo, hi, sum := values[0], values[0], 0.0
+	for _, v := range values {
+		sum += v
+		if v < lo {
+			lo = v
+		}
+		if v > hi {
+			hi = v
+		}
+	}
+	mean := sum / float64(len(values))
+	return &mean, &lo, &hi
+}
+
+// ! roundAverage arrondi au centième (moyennes affichées à 2 décimales)
+func roundAverage(v float64) int64 {
+	if v < 0 {
+		return int64(v*100 - 0.5)
+	}
+	return int64(v*100 + 0.5)
+}
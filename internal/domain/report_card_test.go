@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSubjectAverage(t *testing.T) {
+	if _, ok := SubjectAverage(nil); ok {
+		t.Error("SubjectAverage(nil) should report no grade")
+	}
+
+	grades := []*Grade{
+		{Score: 12, MaxScore: 20, Coefficient: 1},
+		{Score: 9, MaxScore: 10, Coefficient: 2}, //! 18/20
+	}
+	avg, ok := SubjectAverage(grades)
+	if !ok || math.Abs(avg-16) > 1e-9 {
+		t.Errorf("SubjectAverage() = %v, %v; want 16", avg, ok)
+	}
+}
+
+func TestRankAverages(t *testing.T) {
+	ranks := RankAverages(map[int]float64{
+		1: 12.5,
+		2: 15,
+		3: 12.5,
+		4: 9,
+		5: 12.499, //! arrondi 12.50 : ex-aequo
+	})
+
+	want := map[int]int{2: 1, 1: 2, 3: 2, 5: 2, 4: 5}
+	for id, rank := range want {
+		if ranks[id] != rank {
+			t.Errorf("rank[%d] = %d, want %d", id, ranks[id], rank)
+		}
+	}
+}
+
+func TestBuildReportCards(t *testing.T) {
+	math_ := &Subject{ID: 10, Name: "Mathématiques"}
+	french := &Subject{ID: 20, Name: "Français"}
+	music := &Subject{ID: 30, Name: "Musique"} //! ni note ni appréciation : absente du bulletin
+	alice := &User{ID: 1, FirstName: "Alice"}
+	bob := &User{ID: 2, FirstName: "Bob"}
+	carol := &User{ID: 3, FirstName: "Carol"} //! aucune note : non classée
+
+	day := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	subjectID := math_.ID
+	in := ReportCardInput{
+		Students: []*User{alice, bob, carol},
+		Subjects: []*Subject{math_, french, music},
+		Grades: []*Grade{
+			{StudentID: 1, SubjectID: 10, TeacherID: 7, Score: 16, MaxScore: 20, Coefficient: 1, GradedOn: day},
+			{StudentID: 1, SubjectID: 20, TeacherID: 8, Score: 12, MaxScore: 20, Coefficient: 1, GradedOn: day},
+			{StudentID: 2, SubjectID: 10, TeacherID: 9, Score: 8, MaxScore: 20, Coefficient: 1, GradedOn: day.AddDate(0, 0, 7)},
+			{StudentID: 2, SubjectID: 20, TeacherID: 8, Score: 14, MaxScore: 20, Coefficient: 1, GradedOn: day},
+		},
+		Comments: []*ReportComment{
+			{StudentID: 1, SubjectID: &subjectID, Comment: "Très bon trimestre"},
+			{StudentID: 2, Comment: "Doit se ressaisir"},
+		},
+		Attendance:     map[int]AttendanceSummary{2: {Absences: 3, JustifiedAbsences: 1}},
+		TeacherNames:   map[int]string{7: "M. Rakoto", 8: "Mme Rabe", 9: "M. Randria"},
+		StudentNumbers: map[int]string{1: "2025-6EME-0001"},
+	}
+
+	cards := BuildReportCards(in)
+	if len(cards) != 3 {
+		t.Fatalf("BuildReportCards() returned %d cards, want 3", len(cards))
+	}
+	a, b, c := cards[0], cards[1], cards[2]
+
+	//! Matières triées, Musique exclue
+	if len(a.Subjects) != 2 || a.Subjects[0].SubjectName != "Français" || a.Subjects[1].SubjectName != "Mathématiques" {
+		t.Fatalf("subjects = %+v, want Français then Mathématiques", a.Subjects)
+	}
+	maths := a.Subjects[1]
+	if *maths.Average != 16 || *maths.ClassAverage != 12 || *maths.Min != 8 || *maths.Max != 16 {
+		t.Errorf("maths result = %+v", maths)
+	}
+	if maths.TeacherName != "M. Randria" {
+		t.Errorf("TeacherName = %q, want teacher of the latest grade", maths.TeacherName)
+	}
+	if maths.Comment != "Très bon trimestre" {
+		t.Errorf("Comment = %q", maths.Comment)
+	}
+
+	//! Alice 14, Bob 11 ; Carol sans moyenne
+	if *a.GeneralAverage != 14 || a.Rank != 1 || *b.GeneralAverage != 11 || b.Rank != 2 {
+		t.Errorf("averages/ranks = %v/%d, %v/%d", *a.GeneralAverage, a.Rank, *b.GeneralAverage, b.Rank)
+	}
+	if c.GeneralAverage != nil || c.Rank != 0 || c.Subjects[0].Average != nil {
+		t.Errorf("ungraded student card = %+v, want no average and no rank", c)
+	}
+	if *a.ClassAverage != 12.5 || a.ClassSize != 3 {
+		t.Errorf("ClassAverage = %v, ClassSize = %d", *a.ClassAverage, a.ClassSize)
+	}
+	if b.Comment != "Doit se ressaisir" || b.Attendance.Absences != 3 {
+		t.Errorf("bob card = %+v", b)
+	}
+	if a.StudentNumber != "2025-6EME-0001" || b.StudentNumber != "" {
+		t.Errorf("StudentNumber = %q, %q, want only Alice's", a.StudentNumber, b.StudentNumber)
+	}
+}
@@ -0,0 +1,240 @@
+package domain
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	ResourceFile = "file"
+	ResourceLink = "link"
+)
+
+const (
+	// ! ResourceMaxFileSize taille maximale d'un fichier de cours (20 Mo)
+	ResourceMaxFileSize = 20 << 20
+	// ! ResourceMaxTags nombre maximal de tags par ressource
+	ResourceMaxTags = 10
+)
+
+// ! resourceExtensions documents de cours acceptés (pas d'exécutables)
+var resourceExtensions = map[string]bool{
+	".pdf": true, ".txt": true, ".odt": true, ".odp": true, ".ods": true,
+	".doc": true, ".docx": true, ".ppt": true, ".pptx": true, ".xls": true, ".xlsx": true,
+	".png": true, ".jpg": true, ".jpeg": true, ".zip": true, ".mp3": true, ".mp4": true,
+}
+
+// ! ResourceFolder dossier de la bibliothèque d'une matière (ParentID nil = racine)
+type ResourceFolder struct {
+	ID        int       `json:"id"`
+	SchoolID  int       `json:"school_id"`
+	SubjectID int       `json:"subject_id"`
+	ParentID  *int      `json:"parent_id,omitempty"`
+	Name      string    `json:"name"`
+	CreatedBy int       `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewResourceFolder(schoolID, subjectID int, parentID *int, name string, createdBy int) (*ResourceFolder, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > 100 {
+		return nil, ErrResourceFolderNameRequired
+	}
+
+	return &ResourceFolder{
+		SchoolID:  schoolID,
+		SubjectID: subjectID,
+		ParentID:  parentID,
+		Name:      name,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// ! Resource support de cours d'une matière, destiné à une classe ou à un niveau
+type Resource struct {
+	ID             int       `json:"id"`
+	SchoolID       int       `json:"school_id"`
+	SubjectID      int       `json:"subject_id"`
+	ClassID        *int      `json:"class_id,omitempty"`
+	Level          string    `json:"level"`
+	Chapter        string    `json:"chapter"`
+	FolderID       *int      `json:"folder_id,omitempty"`
+	Title          string    `json:"title"`
+	Description    string    `json:"description"`
+	Kind           string    `json:"kind"`
+	URL            string    `json:"url,omitempty"` //! liens uniquement
+	Tags           []string  `json:"tags"`
+	CurrentVersion int       `json:"current_version"`
+	DownloadCount  int       `json:"download_count"`
+	CreatedBy      int       `json:"created_by"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// ! NewResource une ressource vise une classe (classID) ou tout un niveau (level)
+func NewResource(schoolID, subjectID int, classID *int, level, chapter string, folderID *int, title, description, kind, link string, tags []string, createdBy int) (*Resource, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return nil, ErrResourceTitleRequired
+	}
+	level = strings.TrimSpace(level)
+	if classID == nil && level == "" {
+		return nil, ErrResourceTargetRequired
+	}
+
+	link = strings.TrimSpace(link)
+	switch kind {
+	case ResourceFile:
+		link = ""
+	case ResourceLink:
+		u, err := url.Parse(link)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, ErrResourceInvalidURL
+		}
+	default:
+		return nil, ErrResourceInvalidKind
+	}
+
+	normalized, err := NormalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Resource{
+		SchoolID:    schoolID,
+		SubjectID:   subjectID,
+		ClassID:     classID,
+		Level:       level,
+		Chapter:     strings.TrimSpace(chapter),
+		FolderID:    folderID,
+		Title:       title,
+		Description: strings.TrimSpace(description),
+		Kind:        kind,
+		URL:         link,
+		Tags:        normalized,
+		CreatedBy:   createdBy,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}, nil
+}
+
+// ! VisibleTo vrai si la ressource vise l'une des classes (ou leur niveau)
+func (r *Resource) VisibleTo(classes []*Class) bool {
+	for _, class := range classes {
+		if class.SchoolID != r.SchoolID {
+			continue
+		}
+		if r.ClassID != nil {
+			if *r.ClassID == class.ID {
+				return true
+			}
+			continue
+		}
+		if strings.EqualFold(r.Level, class.Level) {
+			return true
+		}
+	}
+	return false
+}
+
+// ! NormalizeTags minuscules, sans doublons ni vides, 30 caractères max
+func NormalizeTags(tags []string) ([]string, error) {
+	normalized := []string{}
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		if len([]rune(tag)) > 30 {
+			return nil, ErrResourceInvalidTags
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	if len(normalized) > ResourceMaxTags {
+		return nil, ErrResourceInvalidTags
+	}
+	return normalized, nil
+}
+
+// ! ResourceVersion fichier d'une ressource ; la plus récente est Resource.CurrentVersion
+type ResourceVersion struct {
+	ID          int       `json:"id"`
+	ResourceID  int       `json:"resource_id"`
+	Version     int       `json:"version"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	StoragePath string    `json:"-"`
+	UploadedBy  int       `json:"uploaded_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ! NewResourceVersion le nom de fichier est nettoyé (chemin, guillemets, caractères de contrôle)
+func NewResourceVersion(resourceID, version int, fileName, contentType string, size int64, uploadedBy int) (*ResourceVersion, error) {
+	if size <= 0 {
+		return nil, ErrResourceFileRequired
+	}
+	if size > ResourceMaxFileSize {
+		return nil, ErrResourceFileTooLarge
+	}
+
+	fileName = sanitizeFileName(fileName)
+	if !resourceExtensions[strings.ToLower(filepath.Ext(fileName))] {
+		return nil, ErrResourceFileType
+	}
+	if strings.TrimSpace(contentType) == "" {
+		contentType = "application/octet-stream"
+	}
+
+	return &ResourceVersion{
+		ResourceID:  resourceID,
+		Version:     version,
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        size,
+		UploadedBy:  uploadedBy,
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+// ! Ext extension du fichier (minuscules), utilisée pour le nom de stockage
+func (v *ResourceVersion) Ext() string {
+	return strings.ToLower(filepath.Ext(v.FileName))
+}
+
+func sanitizeFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, `\`, "/"))
+	name = strings.Map(func(r rune) rune {
+		if r == '"' || unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name)
+	if len(name) > 255 {
+		ext := filepath.Ext(name)
+		name = name[:255-len(ext)] + ext
+	}
+	return strings.TrimSpace(name)
+}
+
+// ! ResourceFilter critères de recherche ; SubjectIDs / ClassIDs / Levels restreignent
+// ! aux ressources accessibles (matières enseignées, classes de l'élève)
+type ResourceFilter struct {
+	SchoolID   int
+	SubjectIDs []int
+	ClassIDs   []int
+	Levels     []string
+	SubjectID  int
+	ClassID    int
+	Level      string
+	Chapter    string
+	FolderID   int
+	Tag        string
+	Query      string
+}
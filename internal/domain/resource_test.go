@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewResource(t *testing.T) {
+	classID := 3
+
+	tests := []struct {
+		name    string
+		classID *int
+		level   string
+		title   string
+		kind    string
+		url     string
+		tags    []string
+		wantErr error
+	}{
+		{"File for a class", &classID, "", "Cours 1", ResourceFile, "", nil, nil},
+		{"Link for a level", nil, "6ème", "Vidéo", ResourceLink, "https://example.org/video", []string{"video"}, nil},
+		{"No target", nil, " ", "Cours 1", ResourceFile, "", nil, ErrResourceTargetRequired},
+		{"Empty title", &classID, "", "  ", ResourceFile, "", nil, ErrResourceTitleRequired},
+		{"Unknown kind", &classID, "", "Cours 1", "video", "", nil, ErrResourceInvalidKind},
+		{"Link without scheme", &classID, "", "Lien", ResourceLink, "example.org", nil, ErrResourceInvalidURL},
+		{"Javascript link", &classID, "", "Lien", ResourceLink, "javascript:alert(1)", nil, ErrResourceInvalidURL},
+		{"Tag too long", &classID, "", "Cours 1", ResourceFile, "", []string{strings.Repeat("x", 31)}, ErrResourceInvalidTags},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewResource(1, 2, tt.classID, tt.level, "Chapitre 1", nil, tt.title, "", tt.kind, tt.url, tt.tags, 5)
+			if err != tt.wantErr {
+				t.Fatalf("NewResource() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNormalizeTags(t *testing.T) {
+	tags, err := NormalizeTags([]string{" Algèbre ", "algèbre", "", "TD"})
+	if err != nil {
+		t.Fatalf("NormalizeTags() error = %v", err)
+	}
+	if len(tags) != 2 || tags[0] != "algèbre" || tags[1] != "td" {
+		t.Errorf("NormalizeTags() = %v, want [algèbre td]", tags)
+	}
+
+	many := make([]string, ResourceMaxTags+1)
+	for i := range many {
+		many[i] = strings.Repeat("t", i+1)
+	}
+	if _, err := NormalizeTags(many); err != ErrResourceInvalidTags {
+		t.Errorf("NormalizeTags(%d tags) error = %v, want %v", len(many), err, ErrResourceInvalidTags)
+	}
+}
+
+func TestResource_VisibleTo(t *testing.T) {
+	classID := 7
+	forClass := &Resource{SchoolID: 1, ClassID: &classID, Level: "6ème"}
+	forLevel := &Resource{SchoolID: 1, Level: "6ème"}
+
+	sixthA := &Class{ID: 7, SchoolID: 1, Level: "6ème"}
+	sixthB := &Class{ID: 8, SchoolID: 1, Level: "6ÈME"}
+	otherSchool := &Class{ID: 7, SchoolID: 2, Level: "6ème"}
+
+	tests := []struct {
+		name     string
+		resource *Resource
+		classes  []*Class
+		want     bool
+	}{
+		{"Own class", forClass, []*Class{sixthA}, true},
+		{"Same level other class", forClass, []*Class{sixthB}, false},
+		{"Level match", forLevel, []*Class{sixthB}, true},
+		{"Other school", forLevel, []*Class{otherSchool}, false},
+		{"No class", forLevel, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.resource.VisibleTo(tt.classes); got != tt.want {
+				t.Errorf("VisibleTo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewResourceVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileName string
+		size     int64
+		wantName string
+		wantErr  error
+	}{
+		{"PDF", "cours.pdf", 1024, "cours.pdf", nil},
+		{"Path stripped", `..\..\etc/"notes".PDF`, 10, "notes.PDF", nil},
+		{"Empty file", "cours.pdf", 0, "", ErrResourceFileRequired},
+		{"Too large", "cours.pdf", ResourceMaxFileSize + 1, "", ErrResourceFileTooLarge},
+		{"Executable", "setup.exe", 10, "", ErrResourceFileType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := NewResourceVersion(1, 1, tt.fileName, "", tt.size, 2)
+			if err != tt.wantErr {
+				t.Fatalf("NewResourceVersion() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil {
+				if v.FileName != tt.wantName {
+					t.Errorf("FileName = %q, want %q", v.FileName, tt.wantName)
+				}
+				if v.ContentType != "application/octet-stream" {
+					t.Errorf("ContentType = %q, want default", v.ContentType)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// ! Types de salles
+const (
+	RoomClassroom  = "classroom"
+	RoomLab        = "lab"
+	RoomGym        = "gym"
+	RoomLibrary    = "library"
+	RoomComputer   = "computer"
+	RoomAuditorium = "auditorium"
+	RoomOther      = "other"
+)
+
+// ! Statuts d'une réservation ; pending et approved bloquent le créneau
+const (
+	BookingPending   = "pending"
+	BookingApproved  = "approved"
+	BookingRejected  = "rejected"
+	BookingCancelled = "cancelled"
+)
+
+const (
+	// ! BookingMaxDuration durée maximale d'une réservation
+	BookingMaxDuration = 12 * time.Hour
+	// ! RoomMaxEquipment nombre maximal d'éléments dans la liste d'équipement d'une salle
+	RoomMaxEquipment = 30
+)
+
+var roomTypes = map[string]bool{
+	RoomClassroom: true, RoomLab: true, RoomGym: true, RoomLibrary: true,
+	RoomComputer: true, RoomAuditorium: true, RoomOther: true,
+}
+
+// ! specialRoomTypes salles soumises par défaut à validation de l'admin
+var specialRoomTypes = map[string]bool{
+	RoomLab: true, RoomGym: true, RoomComputer: true, RoomAuditorium: true,
+}
+
+// ! Room salle de l'école ; Equipment décrit l'équipement fixe (vidéoprojecteur, paillasses...)
+type Room struct {
+	ID               int       `json:"id"`
+	SchoolID         int       `json:"school_id"`
+	Name             string    `json:"name"`
+	Type             string    `json:"type"`
+	Capacity         int       `json:"capacity"`
+	Equipment        []string  `json:"equipment"`
+	RequiresApproval bool      `json:"requires_approval"`
+	IsActive         bool      `json:"is_active"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// ! NewRoom requiresApproval nil : valeur par défaut selon le type (salles spécialisées)
+func NewRoom(schoolID int, name, roomType string, capacity int, equipment []string, requiresApproval *bool) (*Room, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > 100 {
+		return nil, ErrRoomNameRequired
+	}
+	if roomType == "" {
+		roomType = RoomClassroom
+	}
+	if !roomTypes[roomType] {
+		return nil, ErrRoomInvalidType
+	}
+	if capacity < 0 {
+		return nil, ErrRoomInvalidCapacity
+	}
+	items, err := normalizeEquipment(equipment)
+	if err != nil {
+		return nil, err
+	}
+
+	approval := specialRoomTypes[roomType]
+	if requiresApproval != nil {
+		approval = *requiresApproval
+	}
+	return &Room{
+		SchoolID:         schoolID,
+		Name:             name,
+		Type:             roomType,
+		Capacity:         capacity,
+		Equipment:        items,
+		RequiresApproval: approval,
+		IsActive:         true,
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}, nil
+}
+
+// ! HasEquipment vrai si la salle dispose de tous les équipements demandés (insensible à la casse)
+func (r *Room) HasEquipment(items []string) bool {
+	for _, item := range items {
+		found := false
+		for _, have := range r.Equipment {
+			if strings.EqualFold(have, strings.TrimSpace(item)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func normalizeEquipment(items []string) ([]string, error) {
+	normalized := []string{}
+	seen := map[string]bool{}
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		key := strings.ToLower(item)
+		if item == "" || seen[key] {
+			continue
+		}
+		if len(item) > 100 {
+			return nil, ErrRoomInvalidEquipment
+		}
+		seen[key] = true
+		normalized = append(normalized, item)
+	}
+	if len(normalized) > RoomMaxEquipment {
+		return nil, ErrRoomInvalidEquipment
+	}
+	return normalized, nil
+}
+
+// ! Equipment matériel réservable (un enregistrement par unité : "Vidéoprojecteur 2")
+type Equipment struct {
+	ID               int       `json:"id"`
+	SchoolID         int       `json:"school_id"`
+	Name             string    `json:"name"`
+	Description      string    `json:"description"`
+	RequiresApproval bool      `json:"requires_approval"`
+	IsActive         bool      `json:"is_active"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+func NewEquipment(schoolID int, name, description string, requiresApproval bool) (*Equipment, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > 100 {
+		return nil, ErrEquipmentNameRequired
+	}
+	return &Equipment{
+		SchoolID:         schoolID,
+		Name:             name,
+		Description:      strings.TrimSpace(description),
+		RequiresApproval: requiresApproval,
+		IsActive:         true,
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}, nil
+}
+
+// ! Booking réservation d'une salle ou d'un équipement (exactement l'un des deux).
+// ! Les chevauchements sont interdits par une contrainte d'exclusion (pending + approved).
+type Booking struct {
+	ID          int        `json:"id"`
+	SchoolID    int        `json:"school_id"`
+	RoomID      *int       `json:"room_id,omitempty"`
+	EquipmentID *int       `json:"equipment_id,omitempty"`
+	UserID      int        `json:"user_id"`
+	Purpose     string     `json:"purpose"`
+	StartsAt    time.Time  `json:"starts_at"`
+	EndsAt      time.Time  `json:"ends_at"`
+	Status      string     `json:"status"`
+	ReviewedBy  *int       `json:"reviewed_by,omitempty"`
+	ReviewedAt  *time.Time `json:"reviewed_at,omitempty"`
+	ReviewNote  string     `json:"review_note,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// ! NewBooking en attente si la ressource exige une validation, sinon approuvée d'office
+func NewBooking(schoolID int, roomID, equipmentID *int, userID int, purpose string, startsAt, endsAt time.Time, requiresApproval bool, now time.Time) (*Booking, error) {
+	if (roomID == nil) == (equipmentID == nil) {
+		return nil, ErrBookingTargetRequired
+	}
+	startsAt, endsAt = startsAt.UTC(), endsAt.UTC()
+	if !endsAt.After(startsAt) || endsAt.Sub(startsAt) > BookingMaxDuration || startsAt.Before(now) {
+		return nil, ErrBookingInvalidDates
+	}
+
+	status := BookingApproved
+	if requiresApproval {
+		status = BookingPending
+	}
+	return &Booking{
+		SchoolID:    schoolID,
+		RoomID:      roomID,
+		EquipmentID: equipmentID,
+		UserID:      userID,
+		Purpose:     strings.TrimSpace(purpose),
+		StartsAt:    startsAt,
+		EndsAt:      endsAt,
+		Status:      status,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// ! Review décision de l'admin sur une réservation en attente
+func (b *Booking) Review(approve bool, reviewerID int, note string, now time.Time) error {
+	if b.Status != BookingPending {
+		return ErrBookingNotPending
+	}
+	b.Status = BookingRejected
+	if approve {
+		b.Status = BookingApproved
+	}
+	b.ReviewedBy = &reviewerID
+	b.ReviewedAt = &now
+	b.ReviewNote = strings.TrimSpace(note)
+	return nil
+}
+
+// ! Cancel libère le créneau ; impossible une fois la réservation terminée
+func (b *Booking) Cancel(now time.Time) error {
+	if b.Status != BookingPending && b.Status != BookingApproved {
+		return ErrBookingNotCancellable
+	}
+	if !now.Before(b.EndsAt) {
+		return ErrBookingNotCancellable
+	}
+	b.Status = BookingCancelled
+	return nil
+}
+
+// ! RoomFilter recherche de salles ; Equipment : tous les éléments doivent être présents
+type RoomFilter struct {
+	SchoolID        int
+	Type            string
+	MinCapacity     int
+	Equipment       []string
+	IncludeInactive bool
+}
+
+// ! BookingFilter bookings chevauchant [From, To) si renseignés
+type BookingFilter struct {
+	SchoolID    int
+	UserID      int
+	RoomID      int
+	EquipmentID int
+	Status      string
+	From        time.Time
+	To          time.Time
+}
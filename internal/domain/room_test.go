@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewRoom(t *testing.T) {
+	no := false
+
+	tests := []struct {
+		name         string
+		roomName     string
+		roomType     string
+		capacity     int
+		equipment    []string
+		approval     *bool
+		wantApproval bool
+		wantErr      error
+	}{
+		{"Classroom", "Salle 12", "", 35, []string{"Tableau", " tableau ", "Vidéoprojecteur"}, nil, false, nil},
+		{"Lab needs approval", "Labo SVT", RoomLab, 24, []string{"Paillasses"}, nil, true, nil},
+		{"Gym override", "Gymnase", RoomGym, 60, nil, &no, false, nil},
+		{"Empty name", " ", RoomClassroom, 30, nil, nil, false, ErrRoomNameRequired},
+		{"Unknown type", "Salle", "kitchen", 30, nil, nil, false, ErrRoomInvalidType},
+		{"Negative capacity", "Salle", RoomClassroom, -1, nil, nil, false, ErrRoomInvalidCapacity},
+		{"Item too long", "Salle", RoomClassroom, 30, []string{strings.Repeat("x", 101)}, nil, false, ErrRoomInvalidEquipment},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			room, err := NewRoom(1, tt.roomName, tt.roomType, tt.capacity, tt.equipment, tt.approval)
+			if err != tt.wantErr {
+				t.Fatalf("NewRoom() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if room.RequiresApproval != tt.wantApproval {
+				t.Errorf("RequiresApproval = %v, want %v", room.RequiresApproval, tt.wantApproval)
+			}
+			if tt.name == "Classroom" && len(room.Equipment) != 2 {
+				t.Errorf("Equipment = %q, want duplicates removed", room.Equipment)
+			}
+		})
+	}
+}
+
+func TestRoom_HasEquipment(t *testing.T) {
+	room := &Room{Equipment: []string{"Vidéoprojecteur", "Tableau blanc"}}
+
+	if !room.HasEquipment([]string{"vidéoprojecteur"}) {
+		t.Error("HasEquipment(vidéoprojecteur) = false, want true")
+	}
+	if !room.HasEquipment(nil) {
+		t.Error("HasEquipment(nil) = false, want true")
+	}
+	if room.HasEquipment([]string{"Tableau blanc", "Paillasses"}) {
+		t.Error("HasEquipment(Paillasses) = true, want false")
+	}
+}
+
+func TestNewBooking(t *testing.T) {
+	now := time.Date(2026, 9, 7, 7, 0, 0, 0, time.UTC)
+	start := now.Add(time.Hour)
+	roomID, equipmentID := 1, 2
+
+	tests := []struct {
+		name        string
+		roomID      *int
+		equipmentID *int
+		start       time.Time
+		end         time.Time
+		approval    bool
+		wantStatus  string
+		wantErr     error
+	}{
+		{"Room", &roomID, nil, start, start.Add(2 * time.Hour), false, BookingApproved, nil},
+		{"Special room", &roomID, nil, start, start.Add(time.Hour), true, BookingPending, nil},
+		{"Equipment", nil, &equipmentID, start, start.Add(time.Hour), false, BookingApproved, nil},
+		{"No target", nil, nil, start, start.Add(time.Hour), false, "", ErrBookingTargetRequired},
+		{"Both targets", &roomID, &equipmentID, start, start.Add(time.Hour), false, "", ErrBookingTargetRequired},
+		{"Ends before start", &roomID, nil, start, start, false, "", ErrBookingInvalidDates},
+		{"Too long", &roomID, nil, start, start.Add(BookingMaxDuration + time.Minute), false, "", ErrBookingInvalidDates},
+		{"In the past", &roomID, nil, now.Add(-time.Hour), now.Add(time.Hour), false, "", ErrBookingInvalidDates},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			booking, err := NewBooking(1, tt.roomID, tt.equipmentID, 3, " Cours ", tt.start, tt.end, tt.approval, now)
+			if err != tt.wantErr {
+				t.Fatalf("NewBooking() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && booking.Status != tt.wantStatus {
+				t.Errorf("Status = %s, want %s", booking.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestBooking_ReviewAndCancel(t *testing.T) {
+	now := time.Date(2026, 9, 7, 7, 0, 0, 0, time.UTC)
+	roomID := 1
+	newPending := func() *Booking {
+		b, _ := NewBooking(1, &roomID, nil, 3, "TP", now.Add(time.Hour), now.Add(2*time.Hour), true, now)
+		return b
+	}
+
+	approved := newPending()
+	if err := approved.Review(true, 9, " OK ", now); err != nil || approved.Status != BookingApproved || approved.ReviewNote != "OK" {
+		t.Fatalf("Review(approve) = %+v, err = %v", approved, err)
+	}
+	if err := approved.Review(false, 9, "", now); err != ErrBookingNotPending {
+		t.Errorf("Review() twice error = %v, want %v", err, ErrBookingNotPending)
+	}
+
+	rejected := newPending()
+	if err := rejected.Review(false, 9, "Salle indisponible", now); err != nil || rejected.Status != BookingRejected {
+		t.Fatalf("Review(reject) status = %s, err = %v", rejected.Status, err)
+	}
+	if err := rejected.Cancel(now); err != ErrBookingNotCancellable {
+		t.Errorf("Cancel() rejected error = %v, want %v", err, ErrBookingNotCancellable)
+	}
+
+	if err := approved.Cancel(now.Add(3 * time.Hour)); err != ErrBookingNotCancellable {
+		t.Errorf("Cancel() after end error = %v, want %v", err, ErrBookingNotCancellable)
+	}
+	if err := approved.Cancel(now); err != nil || approved.Status != BookingCancelled {
+		t.Errorf("Cancel() status = %s, err = %v", approved.Status, err)
+	}
+}
@@ -7,23 +7,32 @@ import (
 
 // ! School représente l'entité métier École
 type School struct {
-	ID          int       `json:"id"`
-	Name        string    `json:"name"`
-	Slug        string    `json:"slug"`
-	Address     string    `json:"address"`
-	Phone       string    `json:"phone"`
-	Email       string    `json:"email"`
-	LogoURL     string    `json:"logo_url"`
-	AdminUserID *int      `json:"admin_user_id"`
-	Status      string    `json:"status"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID               int       `json:"id"`
+	Name             string    `json:"name"`
+	Slug             string    `json:"slug"`
+	Address          string    `json:"address"`
+	Phone            string    `json:"phone"`
+	Email            string    `json:"email"`
+	LogoURL          string    `json:"logo_url"`
+	AdminUserID      *int      `json:"admin_user_id"`
+	Status           string    `json:"status"`
+	RegistrationMode string    `json:"registration_mode"` //! open | invite_only | open_with_approval
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
 }
 
 // ! School status constants
 const (
-	SchoolStatusActive   = "active"
-	SchoolStatusInactive = "inactive"
+	SchoolStatusActive    = "active"
+	SchoolStatusInactive  = "inactive"
+	SchoolStatusSuspended = "suspended"
+)
+
+// ! Registration mode constants (inscription libre des enseignants / élèves)
+const (
+	RegistrationModeOpen       = "open"               //! comptes approuvés directement
+	RegistrationModeInviteOnly = "invite_only"        //! uniquement via invitation
+	RegistrationModeApproval   = "open_with_approval" //! comptes en attente de validation admin
 )
 
 // ! NewSchool crée une nouvelle école avec validation
@@ -49,6 +58,8 @@ func NewSchool(name, slug, address, email, phone string) (*School, error) {
 		Phone:   phone,
 		Email:   email,
 		Status:  SchoolStatusActive,
+
+		RegistrationMode: RegistrationModeApproval,
 	}, nil
 }
 
@@ -61,3 +72,67 @@ func (s *School) SetAdmin(adminID int) {
 func (s *School) IsActive() bool {
 	return s.Status == SchoolStatusActive
 }
+
+// ! Suspend bloque l'école (ses utilisateurs ne peuvent plus se connecter)
+func (s *School) Suspend() error {
+	if s.IsSuspended() {
+		return ErrSchoolAlreadySuspended
+	}
+	s.Status = SchoolStatusSuspended
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+// ! Reactivate réactive une école suspendue
+func (s *School) Reactivate() error {
+	if !s.IsSuspended() {
+		return ErrSchoolNotSuspended
+	}
+	s.Status = SchoolStatusActive
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+// ! IsSuspended vérifie si l'école est suspendue
+func (s *School) IsSuspended() bool {
+	return s.Status == SchoolStatusSuspended
+}
+
+// ! SetRegistrationMode change la politique d'inscription libre
+func (s *School) SetRegistrationMode(mode string) error {
+	switch mode {
+	case RegistrationModeOpen, RegistrationModeInviteOnly, RegistrationModeApproval:
+	default:
+		return ErrInvalidRegistrationMode
+	}
+	s.RegistrationMode = mode
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+// ! AllowsSelfRegistration vrai si enseignants / élèves peuvent s'inscrire sans invitation
+func (s *School) AllowsSelfRegistration() bool {
+	return s.RegistrationMode != RegistrationModeInviteOnly
+}
+
+// ! SelfRegistrationStatus statut initial d'un compte créé par inscription libre
+func (s *School) SelfRegistrationStatus() string {
+	if s.RegistrationMode == RegistrationModeOpen {
+		return UserStatusApproved
+	}
+	return UserStatusPending
+}
+
+// ! SchoolStats statistiques d'usage d'une école (console super-admin)
+type SchoolStats struct {
+	School        *School    `json:"school"`
+	TotalUsers    int        `json:"total_users"`
+	TotalAdmins   int        `json:"total_admins"`
+	TotalTeachers int        `json:"total_teachers"`
+	TotalStudents int        `json:"total_students"`
+	PendingUsers  int        `json:"pending_users"`
+	TotalClasses  int        `json:"total_classes"`
+	TotalSubjects int        `json:"total_subjects"`
+	TotalMessages int        `json:"total_messages"`
+	LastActivity  *time.Time `json:"last_activity,omitempty"`
+}
@@ -100,3 +100,59 @@ func TestSchool_IsActive(t *testing.T) {
 		t.Error("IsActive() = true, want false for inactive school")
 	}
 }
+
+func TestSchool_SuspendAndReactivate(t *testing.T) {
+	school, _ := NewSchool("Test", "test", "", "test@test.mg", "")
+
+	if err := school.Reactivate(); err != ErrSchoolNotSuspended {
+		t.Errorf("Reactivate() on active school error = %v, want %v", err, ErrSchoolNotSuspended)
+	}
+
+	if err := school.Suspend(); err != nil {
+		t.Fatalf("Suspend() unexpected error = %v", err)
+	}
+	if !school.IsSuspended() || school.IsActive() {
+		t.Errorf("After Suspend() Status = %v, want suspended", school.Status)
+	}
+
+	if err := school.Suspend(); err != ErrSchoolAlreadySuspended {
+		t.Errorf("Suspend() twice error = %v, want %v", err, ErrSchoolAlreadySuspended)
+	}
+
+	if err := school.Reactivate(); err != nil {
+		t.Fatalf("Reactivate() unexpected error = %v", err)
+	}
+	if !school.IsActive() {
+		t.Errorf("After Reactivate() Status = %v, want active", school.Status)
+	}
+}
+
+func TestSchool_RegistrationMode(t *testing.T) {
+	school, _ := NewSchool("Test", "test", "", "test@test.mg", "")
+
+	//! Défaut : inscription libre soumise à validation
+	if school.RegistrationMode != RegistrationModeApproval {
+		t.Errorf("NewSchool() RegistrationMode = %v, want %v", school.RegistrationMode, RegistrationModeApproval)
+	}
+	if !school.AllowsSelfRegistration() || school.SelfRegistrationStatus() != UserStatusPending {
+		t.Errorf("open_with_approval: allows = %v, status = %v", school.AllowsSelfRegistration(), school.SelfRegistrationStatus())
+	}
+
+	if err := school.SetRegistrationMode(RegistrationModeOpen); err != nil {
+		t.Fatalf("SetRegistrationMode(open) error = %v", err)
+	}
+	if school.SelfRegistrationStatus() != UserStatusApproved {
+		t.Errorf("open: SelfRegistrationStatus() = %v, want approved", school.SelfRegistrationStatus())
+	}
+
+	if err := school.SetRegistrationMode(RegistrationModeInviteOnly); err != nil {
+		t.Fatalf("SetRegistrationMode(invite_only) error = %v", err)
+	}
+	if school.AllowsSelfRegistration() {
+		t.Error("invite_only: AllowsSelfRegistration() = true, want false")
+	}
+
+	if err := school.SetRegistrationMode("closed"); err != ErrInvalidRegistrationMode {
+		t.Errorf("SetRegistrationMode(closed) error = %v, want %v", err, ErrInvalidRegistrationMode)
+	}
+}
@@ -0,0 +1,110 @@
+package domain
+
+import "time"
+
+// ! Granularités des séries temporelles (date_trunc PostgreSQL)
+const (
+	GranularityDay   = "day"
+	GranularityWeek  = "week"
+	GranularityMonth = "month"
+)
+
+const (
+	DefaultStatsRange = 30 * 24 * time.Hour
+	MaxStatsRange     = 366 * 24 * time.Hour
+)
+
+// ! StatsPeriod intervalle [From, To) et pas d'agrégation des statistiques
+type StatsPeriod struct {
+	From        time.Time `json:"from"`
+	To          time.Time `json:"to"`
+	Granularity string    `json:"granularity"`
+}
+
+// ! NewStatsPeriod valide l'intervalle (dates incluses, défaut 30 derniers jours)
+func NewStatsPeriod(from, to time.Time, granularity string, now time.Time) (StatsPeriod, error) {
+	if granularity == "" {
+		granularity = GranularityDay
+	}
+	switch granularity {
+	case GranularityDay, GranularityWeek, GranularityMonth:
+	default:
+		return StatsPeriod{}, ErrStatsInvalidGranularity
+	}
+
+	today := truncateDay(now)
+	if to.IsZero() {
+		to = today
+	}
+	if from.IsZero() {
+		from = truncateDay(to).Add(-DefaultStatsRange)
+	}
+
+	from, to = truncateDay(from), truncateDay(to).AddDate(0, 0, 1) //! borne haute exclusive
+	if !from.Before(to) {
+		return StatsPeriod{}, ErrStatsInvalidRange
+	}
+	if to.Sub(from) > MaxStatsRange {
+		return StatsPeriod{}, ErrStatsRangeTooLarge
+	}
+	return StatsPeriod{From: from, To: to, Granularity: granularity}, nil
+}
+
+func truncateDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// ! UserCounts répartition des utilisateurs d'une école (dashboard)
+type UserCounts struct {
+	Total    int `json:"total"`
+	Admins   int `json:"admins"`
+	Teachers int `json:"teachers"`
+	Students int `json:"students"`
+	Pending  int `json:"pending"`
+	Approved int `json:"approved"`
+	Rejected int `json:"rejected"`
+}
+
+// ! RegistrationPoint inscriptions sur une période (bucket)
+type RegistrationPoint struct {
+	Period   time.Time `json:"period"`
+	Teachers int       `json:"teachers"`
+	Students int       `json:"students"`
+	Total    int       `json:"total"`
+}
+
+// ! ApprovalLatency délai entre inscription et validation par l'admin (heures)
+type ApprovalLatency struct {
+	Approved     int     `json:"approved"`
+	AverageHours float64 `json:"average_hours"`
+	MedianHours  float64 `json:"median_hours"`
+	P90Hours     float64 `json:"p90_hours"`
+	MaxHours     float64 `json:"max_hours"`
+}
+
+// ! ClassFill remplissage d'une classe par rapport à sa capacité
+type ClassFill struct {
+	ClassID   int     `json:"class_id"`
+	ClassName string  `json:"class_name"`
+	Capacity  int     `json:"capacity"`
+	Enrolled  int     `json:"enrolled"` //! élèves approuvés
+	Pending   int     `json:"pending"`  //! inscriptions en attente
+	FillRate  float64 `json:"fill_rate"`
+}
+
+// ! SubjectTeachers nombre d'enseignants approuvés par matière
+type SubjectTeachers struct {
+	SubjectID   int    `json:"subject_id"`
+	SubjectName string `json:"subject_name"`
+	SubjectCode string `json:"subject_code"`
+	Teachers    int    `json:"teachers"`
+}
+
+// ! ClassChatActivity messages envoyés dans une classe un jour donné
+type ClassChatActivity struct {
+	ClassID   int       `json:"class_id"`
+	ClassName string    `json:"class_name"`
+	Day       time.Time `json:"day"`
+	Messages  int       `json:"messages"`
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewStatsPeriod(t *testing.T) {
+	now := time.Date(2026, 10, 19, 15, 30, 0, 0, time.UTC)
+	day := func(m time.Month, d int) time.Time { return time.Date(2026, m, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name        string
+		from, to    time.Time
+		granularity string
+		wantFrom    time.Time
+		wantTo      time.Time
+		expectedErr error
+	}{
+		{"Defaults to last 30 days", time.Time{}, time.Time{}, "", day(9, 19), day(10, 20), nil},
+		{"Explicit range, inclusive end", day(9, 1), day(9, 30), GranularityWeek, day(9, 1), day(10, 1), nil},
+		{"Single day", day(10, 1), day(10, 1), GranularityDay, day(10, 1), day(10, 2), nil},
+		{"Invalid granularity", time.Time{}, time.Time{}, "hour", time.Time{}, time.Time{}, ErrStatsInvalidGranularity},
+		{"Reversed range", day(10, 5), day(10, 1), GranularityDay, time.Time{}, time.Time{}, ErrStatsInvalidRange},
+		{"Range too large", day(1, 1).AddDate(-1, 0, 0), day(10, 1), GranularityMonth, time.Time{}, time.Time{}, ErrStatsRangeTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			period, err := NewStatsPeriod(tt.from, tt.to, tt.granularity, now)
+			if err != tt.expectedErr {
+				t.Fatalf("NewStatsPeriod() error = %v, want %v", err, tt.expectedErr)
+			}
+			if err != nil {
+				return
+			}
+			if !period.From.Equal(tt.wantFrom) || !period.To.Equal(tt.wantTo) {
+				t.Errorf("NewStatsPeriod() = [%v, %v), want [%v, %v)", period.From, period.To, tt.wantFrom, tt.wantTo)
+			}
+			if period.Granularity == "" {
+				t.Error("NewStatsPeriod() Granularity is empty")
+			}
+		})
+	}
+}
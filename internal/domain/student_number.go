@@ -0,0 +1,163 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ! Jetons du format de matricule : {YEAR} 2026, {YY} 26, {LEVEL} niveau de la classe,
+// ! {SEQ:n} compteur sur n chiffres (obligatoire, une seule fois ; {SEQ} = {SEQ:4})
+const (
+	DefaultStudentNumberFormat = "{YEAR}-{SEQ:5}"
+
+	// ! StudentNumberMaxFormat longueur maximale du format saisi par l'admin
+	StudentNumberMaxFormat = 60
+	// ! studentNumberLevelLen le niveau est tronqué pour tenir dans 50 caractères
+	studentNumberLevelLen = 10
+	// ! studentNumberNoLevel niveau utilisé quand l'élève n'a pas (encore) de classe
+	studentNumberNoLevel = "NA"
+	// ! seqPlaceholder marque la place du compteur dans la portée (clé du compteur)
+	seqPlaceholder = "{SEQ}"
+)
+
+// ! StudentNumberFormat format de matricule choisi par l'école
+type StudentNumberFormat struct {
+	SchoolID  int       `json:"school_id"`
+	Format    string    `json:"format"`
+	UpdatedBy int       `json:"updated_by,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ! StudentNumberTemplate format analysé
+type StudentNumberTemplate struct {
+	parts []numberPart
+	width int
+}
+
+type numberPart struct {
+	token   string //! "" pour un littéral
+	literal string
+}
+
+// ! ParseStudentNumberFormat valide le format ; les littéraux sont limités à lettres, chiffres, - . /
+// ! et le matricule le plus long possible doit tenir dans 50 caractères
+func ParseStudentNumberFormat(format string) (*StudentNumberTemplate, error) {
+	format = strings.TrimSpace(format)
+	if format == "" || len(format) > StudentNumberMaxFormat {
+		return nil, ErrStudentNumberFormatInvalid
+	}
+
+	t := &StudentNumberTemplate{}
+	seqCount := 0
+	for rest := format; rest != ""; {
+		if !strings.HasPrefix(rest, "{") {
+			end := strings.IndexByte(rest, '{')
+			if end < 0 {
+				end = len(rest)
+			}
+			t.parts = append(t.parts, numberPart{literal: rest[:end]})
+			rest = rest[end:]
+			continue
+		}
+
+		end := strings.IndexByte(rest, '}')
+		if end < 0 {
+			return nil, ErrStudentNumberFormatInvalid
+		}
+		token := rest[1:end]
+		rest = rest[end+1:]
+		switch {
+		case token == "YEAR" || token == "YY" || token == "LEVEL":
+			t.parts = append(t.parts, numberPart{token: token})
+		case token == "SEQ" || strings.HasPrefix(token, "SEQ:"):
+			width := 4
+			if token != "SEQ" {
+				n, err := strconv.Atoi(strings.TrimPrefix(token, "SEQ:"))
+				if err != nil || n < 1 || n > 9 {
+					return nil, ErrStudentNumberFormatInvalid
+				}
+				width = n
+			}
+			seqCount++
+			t.width = width
+			t.parts = append(t.parts, numberPart{token: "SEQ"})
+		default:
+			return nil, ErrStudentNumberFormatInvalid
+		}
+	}
+	if seqCount != 1 {
+		return nil, ErrStudentNumberFormatInvalid
+	}
+
+	//! Pire cas : niveau le plus long, compteur à pleine largeur
+	longest := t.Number(t.Scope(9999, strings.Repeat("X", studentNumberLevelLen)), 1)
+	if !studentNumberPattern.MatchString(longest) {
+		return nil, ErrStudentNumberFormatInvalid
+	}
+	return t, nil
+}
+
+// ! Scope matricule sans le compteur : chaque portée (année, niveau...) a son propre compteur
+func (t *StudentNumberTemplate) Scope(year int, level string) string {
+	var b strings.Builder
+	for _, part := range t.parts {
+		switch part.token {
+		case "":
+			b.WriteString(part.literal)
+		case "YEAR":
+			fmt.Fprintf(&b, "%04d", year)
+		case "YY":
+			fmt.Fprintf(&b, "%02d", year%100)
+		case "LEVEL":
+			b.WriteString(NormalizeStudentNumberLevel(level))
+		case "SEQ":
+			b.WriteString(seqPlaceholder)
+		}
+	}
+	return b.String()
+}
+
+// ! Number matricule final ; un compteur plus long que la largeur n'est pas tronqué
+func (t *StudentNumberTemplate) Number(scope string, seq int) string {
+	return strings.Replace(scope, seqPlaceholder, fmt.Sprintf("%0*d", t.width, seq), 1)
+}
+
+// ! Example aperçu affiché à l'admin (premier matricule d'une classe de 6e)
+func (t *StudentNumberTemplate) Example(year int) string {
+	return t.Number(t.Scope(year, "6e"), 1)
+}
+
+var levelAccents = strings.NewReplacer(
+	"À", "A", "Â", "A", "Ä", "A", "Ç", "C", "É", "E", "È", "E", "Ê", "E", "Ë", "E",
+	"Î", "I", "Ï", "I", "Ô", "O", "Ö", "O", "Ù", "U", "Û", "U", "Ü", "U",
+)
+
+// ! NormalizeStudentNumberLevel "6ème" -> "6EME" : majuscules sans accents, lettres et chiffres seulement
+func NormalizeStudentNumberLevel(level string) string {
+	level = levelAccents.Replace(strings.ToUpper(level))
+	var b strings.Builder
+	for _, r := range level {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+		if b.Len() == studentNumberLevelLen {
+			break
+		}
+	}
+	if b.Len() == 0 {
+		return studentNumberNoLevel
+	}
+	return b.String()
+}
+
+// ! StudentNumberYear année de rentrée de la classe ("2026-2027" -> 2026), sinon année en cours
+func StudentNumberYear(academicYear string, now time.Time) int {
+	if len(academicYear) >= 4 {
+		if year, err := strconv.Atoi(academicYear[:4]); err == nil {
+			return year
+		}
+	}
+	return now.Year()
+}
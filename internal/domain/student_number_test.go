@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseStudentNumberFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		year    int
+		level   string
+		seq     int
+		want    string
+		wantErr error
+	}{
+		{"Year level seq", "{YEAR}-{LEVEL}-{SEQ:4}", 2026, "6ème", 7, "2026-6EME-0007", nil},
+		{"Short year", "MAT/{YY}/{SEQ:3}", 2026, "", 12, "MAT/26/012", nil},
+		{"Default width", "{SEQ}", 2026, "", 3, "0003", nil},
+		{"Overflow not truncated", "{YEAR}{SEQ:2}", 2026, "", 123, "2026123", nil},
+		{"No class", "{LEVEL}-{SEQ:4}", 2026, "", 1, "NA-0001", nil},
+		{"Default format", DefaultStudentNumberFormat, 2026, "", 42, "2026-00042", nil},
+		{"Missing seq", "{YEAR}-{LEVEL}", 0, "", 0, "", ErrStudentNumberFormatInvalid},
+		{"Two seq", "{SEQ:2}{SEQ:2}", 0, "", 0, "", ErrStudentNumberFormatInvalid},
+		{"Unknown token", "{MONTH}-{SEQ:4}", 0, "", 0, "", ErrStudentNumberFormatInvalid},
+		{"Bad width", "{SEQ:0}", 0, "", 0, "", ErrStudentNumberFormatInvalid},
+		{"Unclosed token", "{YEAR-{SEQ:4}", 0, "", 0, "", ErrStudentNumberFormatInvalid},
+		{"Forbidden character", "{YEAR} {SEQ:4}", 0, "", 0, "", ErrStudentNumberFormatInvalid},
+		{"Leading dash", "-{SEQ:4}", 0, "", 0, "", ErrStudentNumberFormatInvalid},
+		{"Too long once generated", "SCHOOL-OF-EXCELLENCE-{YEAR}-{LEVEL}-{LEVEL}-{SEQ:9}", 0, "", 0, "", ErrStudentNumberFormatInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := ParseStudentNumberFormat(tt.format)
+			if err != tt.wantErr {
+				t.Fatalf("ParseStudentNumberFormat(%q) error = %v, want %v", tt.format, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := tmpl.Number(tmpl.Scope(tt.year, tt.level), tt.seq); got != tt.want {
+				t.Errorf("Number() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStudentNumberTemplate_Scope(t *testing.T) {
+	tmpl, err := ParseStudentNumberFormat("{YEAR}-{LEVEL}-{SEQ:4}")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//! Un compteur par année et par niveau
+	if a, b := tmpl.Scope(2026, "6e"), tmpl.Scope(2026, "5e"); a == b {
+		t.Errorf("Scope() levels share a counter: %q", a)
+	}
+	if a, b := tmpl.Scope(2026, "6e"), tmpl.Scope(2027, "6e"); a == b {
+		t.Errorf("Scope() years share a counter: %q", a)
+	}
+	if got := tmpl.Example(2026); got != "2026-6E-0001" {
+		t.Errorf("Example() = %q, want 2026-6E-0001", got)
+	}
+}
+
+func TestNormalizeStudentNumberLevel(t *testing.T) {
+	tests := map[string]string{
+		"6ème":             "6EME",
+		"Terminale C":      "TERMINALEC",
+		"Première S":       "PREMIERES",
+		"Seconde générale": "SECONDEGEN",
+		" ":                "NA",
+	}
+	for level, want := range tests {
+		if got := NormalizeStudentNumberLevel(level); got != want {
+			t.Errorf("NormalizeStudentNumberLevel(%q) = %q, want %q", level, got, want)
+		}
+	}
+}
+
+func TestStudentNumberYear(t *testing.T) {
+	now := time.Date(2027, 3, 1, 0, 0, 0, 0, time.UTC)
+	if got := StudentNumberYear("2026-2027", now); got != 2026 {
+		t.Errorf("StudentNumberYear(2026-2027) = %d, want 2026", got)
+	}
+	if got := StudentNumberYear("", now); got != 2027 {
+		t.Errorf("StudentNumberYear(\"\") = %d, want 2027", got)
+	}
+}
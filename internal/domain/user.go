@@ -10,26 +10,29 @@ import (
 
 // ! User représente l'entité métier Utilisateur
 type User struct {
-	ID           int       `json:"id"`
-	SchoolID     int       `json:"school_id"`
-	Email        string    `json:"email"`
-	PasswordHash string    `json:"password_hash"`
-	FirstName    string    `json:"first_name"`
-	LastName     string    `json:"last_name"`
-	Phone        string    `json:"phone"`
-	Role         string    `json:"role"` //! admin, teacher, student, parent
-	AvatarURL    string    `json:"avatar_url"`
-	Status       string    `json:"status"` //! pending, approved, rejected, inactive
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID           int    `json:"id"`
+	SchoolID     int    `json:"school_id"`
+	Email        string `json:"email"`
+	PasswordHash string `json:"password_hash"`
+	FirstName    string `json:"first_name"`
+	LastName     string `json:"last_name"`
+	Phone        string `json:"phone"`
+	Role         string `json:"role"` //! superadmin, admin, teacher, student, parent
+	AvatarURL    string `json:"avatar_url"`
+	Status       string `json:"status"` //! pending, approved, rejected, inactive, suspended
+	//! MustChangePassword vrai après une réinitialisation par l'admin
+	MustChangePassword bool      `json:"must_change_password"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
 }
 
 // ! Role constants
 const (
-	RoleAdmin   = "admin"
-	RoleTeacher = "teacher"
-	RoleStudent = "student"
-	RoleParent  = "parent"
+	RoleSuperAdmin = "superadmin"
+	RoleAdmin      = "admin"
+	RoleTeacher    = "teacher"
+	RoleStudent    = "student"
+	RoleParent     = "parent"
 )
 
 // ! User status constants
@@ -41,25 +44,34 @@ const (
 	UserStatusSuspended = "suspended"
 )
 
-// ! NewUser crée un nouvel utilisateur avec validation
-func NewUser(schoolID int, email, password, firstName, lastName, phone, role string) (*User, error) {
+// ! ValidateUserInput applique les règles de NewUser sans hasher le mot de passe
+// ! (utile pour valider un import en masse avant de créer les comptes)
+func ValidateUserInput(email, password, firstName, role string) error {
 	if email == "" {
-		return nil, ErrEmailRequired
+		return ErrEmailRequired
 	}
 	if !isValidEmail(email) {
-		return nil, ErrEmailInvalid
+		return ErrEmailInvalid
 	}
 
 	if password == "" || len(password) < 6 {
-		return nil, ErrPasswordTooShort
+		return ErrPasswordTooShort
 	}
 
 	if firstName == "" {
-		return nil, ErrNameRequired
+		return ErrNameRequired
 	}
 
 	if !isValidRole(role) {
-		return nil, ErrInvalidRole
+		return ErrInvalidRole
+	}
+	return nil
+}
+
+// ! NewUser crée un nouvel utilisateur avec validation
+func NewUser(schoolID int, email, password, firstName, lastName, phone, role string) (*User, error) {
+	if err := ValidateUserInput(email, password, firstName, role); err != nil {
+		return nil, err
 	}
 
 	hashedPassword, err := hashPassword(password)
@@ -90,6 +102,17 @@ func NewAdminUser(schoolID int, email, password, firstName, lastName, phone stri
 	return user, nil
 }
 
+// ! NewSuperAdminUser crée un super-admin plateforme (hors de toute école)
+func NewSuperAdminUser(email, password, firstName, lastName string) (*User, error) {
+	user, err := NewUser(0, email, password, firstName, lastName, "", RoleSuperAdmin)
+	if err != nil {
+		return nil, err
+	}
+	user.Status = UserStatusApproved
+	user.UpdatedAt = time.Now()
+	return user, nil
+}
+
 // ! VerifyPassword vérifie le mot de passe
 func (u *User) VerifyPassword(password string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password))
@@ -120,6 +143,30 @@ func (u *User) Deactivate() {
 	u.UpdatedAt = time.Now()
 }
 
+// ! Reactivate réactive un compte suspendu ou désactivé
+func (u *User) Reactivate() error {
+	if u.Status != UserStatusSuspended && u.Status != UserStatusInactive {
+		return ErrUserNotDeactivated
+	}
+	u.Status = UserStatusApproved
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+// ! ResetPassword définit un mot de passe temporaire à changer à la prochaine connexion
+func (u *User) ResetPassword(temporaryPassword string) error {
+	if err := u.SetPassword(temporaryPassword); err != nil {
+		return err
+	}
+	u.MustChangePassword = true
+	return nil
+}
+
+// ! IsSuspended vérifie si le compte est suspendu
+func (u *User) IsSuspended() bool {
+	return u.Status == UserStatusSuspended
+}
+
 // ! IsApproved vérifie si l'utilisateur est approuvé
 func (u *User) IsApproved() bool {
 	return u.Status == UserStatusApproved
@@ -140,6 +187,17 @@ func (u *User) IsAdmin() bool {
 	return u.Role == RoleAdmin
 }
 
+// ! IsSuperAdmin vérifie si l'utilisateur est super-admin plateforme
+func (u *User) IsSuperAdmin() bool {
+	return u.Role == RoleSuperAdmin
+}
+
+// ! PromoteToAdmin donne le rôle admin (transfert de propriété d'école)
+func (u *User) PromoteToAdmin() {
+	u.Role = RoleAdmin
+	u.UpdatedAt = time.Now()
+}
+
 // ! IsTeacher checks if user is a teacher
 func (u *User) IsTeacher() bool {
 	return u.Role == RoleTeacher
@@ -166,10 +224,11 @@ func isValidEmail(email string) bool {
 
 func isValidRole(role string) bool {
 	validRoles := map[string]bool{
-		"admin":   true,
-		"teacher": true,
-		"student": true,
-		"parent":  true,
+		"superadmin": true,
+		"admin":      true,
+		"teacher":    true,
+		"student":    true,
+		"parent":     true,
 	}
 	return validRoles[role]
 }
@@ -191,6 +250,7 @@ func (u *User) SetPassword(password string) error {
 	}
 
 	u.PasswordHash = string(hashedPassword)
+	u.MustChangePassword = false
 	u.UpdatedAt = time.Now()
 
 	return nil
@@ -307,3 +307,99 @@ func TestUserRoleChecks(t *testing.T) {
 		t.Error("Expected student.IsTeacher() to return false")
 	}
 }
+
+func TestNewSuperAdminUser(t *testing.T) {
+	superAdmin, err := NewSuperAdminUser("root@educnet.mg", "password123", "Root", "Admin")
+	if err != nil {
+		t.Fatalf("NewSuperAdminUser() unexpected error = %v", err)
+	}
+
+	if !superAdmin.IsSuperAdmin() {
+		t.Errorf("NewSuperAdminUser() Role = %v, want superadmin", superAdmin.Role)
+	}
+	if superAdmin.SchoolID != 0 {
+		t.Errorf("NewSuperAdminUser() SchoolID = %v, want 0", superAdmin.SchoolID)
+	}
+	if !superAdmin.IsApproved() {
+		t.Errorf("NewSuperAdminUser() Status = %v, want approved", superAdmin.Status)
+	}
+	if superAdmin.IsAdmin() {
+		t.Error("IsAdmin() = true, want false for superadmin")
+	}
+}
+
+func TestUser_PromoteToAdmin(t *testing.T) {
+	teacher, _ := NewUser(1, "teacher@test.mg", "password123", "Teacher", "User", "", RoleTeacher)
+
+	teacher.PromoteToAdmin()
+	if !teacher.IsAdmin() {
+		t.Errorf("After PromoteToAdmin() Role = %v, want admin", teacher.Role)
+	}
+}
+
+func TestValidateUserInput(t *testing.T) {
+	tests := []struct {
+		name      string
+		email     string
+		password  string
+		firstName string
+		role      string
+		want      error
+	}{
+		{"Valid student", "eleve@test.mg", "password123", "Rado", RoleStudent, nil},
+		{"Invalid email", "eleve", "password123", "Rado", RoleStudent, ErrEmailInvalid},
+		{"Short password", "eleve@test.mg", "abc", "Rado", RoleStudent, ErrPasswordTooShort},
+		{"Missing first name", "eleve@test.mg", "password123", "", RoleStudent, ErrNameRequired},
+		{"Unknown role", "eleve@test.mg", "password123", "Rado", "janitor", ErrInvalidRole},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateUserInput(tt.email, tt.password, tt.firstName, tt.role); err != tt.want {
+				t.Errorf("ValidateUserInput() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestUser_Reactivate(t *testing.T) {
+	user, _ := NewUser(1, "eleve@test.mg", "password123", "Eleve", "Test", "", RoleStudent)
+
+	if err := user.Reactivate(); err != ErrUserNotDeactivated {
+		t.Errorf("Reactivate() on pending user error = %v, want %v", err, ErrUserNotDeactivated)
+	}
+
+	user.Approve()
+	user.Suspend()
+	if !user.IsSuspended() {
+		t.Fatalf("After Suspend() Status = %v, want suspended", user.Status)
+	}
+
+	if err := user.Reactivate(); err != nil {
+		t.Fatalf("Reactivate() error = %v", err)
+	}
+	if !user.IsApproved() {
+		t.Errorf("After Reactivate() Status = %v, want approved", user.Status)
+	}
+}
+
+func TestUser_ResetPassword(t *testing.T) {
+	user, _ := NewUser(1, "prof@test.mg", "password123", "Prof", "Test", "", RoleTeacher)
+
+	if err := user.ResetPassword("Tmp4pass9x"); err != nil {
+		t.Fatalf("ResetPassword() error = %v", err)
+	}
+	if !user.MustChangePassword {
+		t.Error("ResetPassword() MustChangePassword = false, want true")
+	}
+	if !user.VerifyPassword("Tmp4pass9x") {
+		t.Error("ResetPassword() temporary password does not verify")
+	}
+
+	if err := user.SetPassword("newpassword123"); err != nil {
+		t.Fatalf("SetPassword() error = %v", err)
+	}
+	if user.MustChangePassword {
+		t.Error("SetPassword() MustChangePassword = true, want false")
+	}
+}
@@ -0,0 +1,130 @@
+// Package export écrit des tableaux en CSV ou XLSX au fil de l'eau : chaque
+// ligne est envoyée au writer dès qu'elle est produite, sans charger
+// l'ensemble des données en mémoire.
+package export
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"strings"
+
+	"educnet/internal/domain"
+)
+
+// ! Format de fichier d'export
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+// ! ParseFormat "csv" (défaut) ou "xlsx"
+func ParseFormat(value string) (Format, error) {
+	switch Format(strings.ToLower(strings.TrimSpace(value))) {
+	case "", FormatCSV:
+		return FormatCSV, nil
+	case FormatXLSX:
+		return FormatXLSX, nil
+	default:
+		return "", domain.ErrExportUnsupportedFormat
+	}
+}
+
+func (f Format) ContentType() string {
+	if f == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+func (f Format) Extension() string {
+	return "." + string(f)
+}
+
+// ! Table export prêt à écrire : en-têtes (snake_case, identiques en CSV et XLSX)
+// ! et producteur de lignes. Rows appelle emit pour chaque ligne, dans l'ordre.
+type Table struct {
+	Name    string //! nom de fichier sans extension, aussi nom de la feuille XLSX
+	Columns []string
+	Rows    func(ctx context.Context, emit func(row []string) error) error
+}
+
+// ! Writer écrit les lignes d'un export ; Close termine le fichier
+type Writer interface {
+	Write(row []string) error
+	Close() error
+}
+
+// ! NewWriter écrit l'en-tête puis retourne un writer pour les lignes
+func NewWriter(w io.Writer, format Format, sheetName string, columns []string) (Writer, error) {
+	var ew Writer
+	switch format {
+	case FormatCSV:
+		ew = newCSVWriter(w)
+	case FormatXLSX:
+		xw, err := newXLSXWriter(w, sheetName)
+		if err != nil {
+			return nil, err
+		}
+		ew = xw
+	default:
+		return nil, domain.ErrExportUnsupportedFormat
+	}
+
+	if err := ew.Write(columns); err != nil {
+		return nil, err
+	}
+	return ew, nil
+}
+
+// ! WriteTable écrit la table complète (en-tête, lignes, fin de fichier)
+func WriteTable(ctx context.Context, w io.Writer, format Format, table *Table) error {
+	ew, err := NewWriter(w, format, table.Name, table.Columns)
+	if err != nil {
+		return err
+	}
+	if err := table.Rows(ctx, ew.Write); err != nil {
+		return err
+	}
+	return ew.Close()
+}
+
+// ! ==================== CSV ====================
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+// ! newCSVWriter BOM UTF-8 pour qu'Excel détecte l'encodage (accents)
+func newCSVWriter(w io.Writer) *csvWriter {
+	io.WriteString(w, "\xef\xbb\xbf")
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (c *csvWriter) Write(row []string) error {
+	safe := make([]string, len(row))
+	for i, value := range row {
+		safe[i] = neutralizeFormula(value)
+	}
+	return c.w.Write(safe)
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// ! neutralizeFormula une cellule commençant par = + - @ serait évaluée par le tableur
+// ! (injection de formule) : on la préfixe d'une apostrophe
+func neutralizeFormula(value string) string {
+	if value == "" {
+		return value
+	}
+	switch value[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return "'" + value
+	}
+	return value
+}
@@ -0,0 +1,109 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"educnet/internal/domain"
+	"educnet/internal/importer"
+)
+
+func testTable() *Table {
+	return &Table{
+		Name:    "users",
+		Columns: []string{"id", "last_name", "first_name", "note"},
+		Rows: func(ctx context.Context, emit func(row []string) error) error {
+			rows := [][]string{
+				{"1", "Rakoto", "Hériniaina", ""},
+				{"2", "Rabe", "Soa", "=HYPERLINK(\"x\")"},
+			}
+			for _, row := range rows {
+				if err := emit(row); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Format
+		wantErr error
+	}{
+		{"", FormatCSV, nil},
+		{"CSV", FormatCSV, nil},
+		{"xlsx", FormatXLSX, nil},
+		{"pdf", "", domain.ErrExportUnsupportedFormat},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseFormat(tt.input)
+		if got != tt.want || err != tt.wantErr {
+			t.Errorf("ParseFormat(%q) = %q, %v, want %q, %v", tt.input, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestWriteTable_CSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteTable(context.Background(), &buf, FormatCSV, testTable()); err != nil {
+		t.Fatalf("WriteTable() error = %v", err)
+	}
+
+	out := buf.String()
+	if !strings.HasPrefix(out, "\xef\xbb\xbfid,last_name,first_name,note\n") {
+		t.Errorf("WriteTable() header = %q", out)
+	}
+	if !strings.Contains(out, "1,Rakoto,Hériniaina,\n") {
+		t.Errorf("WriteTable() missing first row in %q", out)
+	}
+	if !strings.Contains(out, `"'=HYPERLINK(""x"")"`) {
+		t.Errorf("WriteTable() formula not neutralized in %q", out)
+	}
+}
+
+func TestWriteTable_XLSXRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteTable(context.Background(), &buf, FormatXLSX, testTable()); err != nil {
+		t.Fatalf("WriteTable() error = %v", err)
+	}
+
+	rows, err := importer.ReadRows("users.xlsx", &buf)
+	if err != nil {
+		t.Fatalf("ReadRows() error = %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("ReadRows() = %d rows, want 3", len(rows))
+	}
+	if strings.Join(rows[0], ",") != "id,last_name,first_name,note" {
+		t.Errorf("header = %v", rows[0])
+	}
+	if rows[1][2] != "Hériniaina" || rows[2][3] != `=HYPERLINK("x")` {
+		t.Errorf("rows = %v", rows[1:])
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	for index, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"} {
+		if got := columnName(index); got != want {
+			t.Errorf("columnName(%d) = %q, want %q", index, got, want)
+		}
+	}
+}
+
+func TestSanitizeSheetName(t *testing.T) {
+	if got := sanitizeSheetName("roster/6ème A [2025]"); got != "roster-6ème A -2025-" {
+		t.Errorf("sanitizeSheetName() = %q", got)
+	}
+	if got := sanitizeSheetName(strings.Repeat("a", 40)); len(got) != 31 {
+		t.Errorf("sanitizeSheetName() length = %d, want 31", len(got))
+	}
+	if got := sanitizeSheetName(" "); got != "Export" {
+		t.Errorf("sanitizeSheetName() = %q, want Export", got)
+	}
+}
@@ -329,6 +329,7 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"strconv"
@@ -358,6 +359,20 @@ import (
 	func (h *AdminHandler) UpdateClass(w http.ResponseWriter, r *http.Request)
 	func (h *AdminHandler) UpdateSubject(w http.ResponseWriter, r *http.Request)
 
+	func (h *AdminHandler) GetUserByID(w http.ResponseWriter, r *http.Request)
+	func (h *AdminHandler) SuspendUser(w http.ResponseWriter, r *http.Request)
+	func (h *AdminHandler) ReactivateUser(w http.ResponseWriter, r *http.Request)
+	func (h *AdminHandler) DeactivateUser(w http.ResponseWriter, r *http.Request)
+	func (h *AdminHandler) ResetUserPassword(w http.ResponseWriter, r *http.Request)
+	func (h *AdminHandler) ChangeStudentClass(w http.ResponseWriter, r *http.Request)
+	func (h *AdminHandler) UpdateTeacherSubjects(w http.ResponseWriter, r *http.Request)
+	func (h *AdminHandler) BulkApproveUsers(w http.ResponseWriter, r *http.Request)
+	func (h *AdminHandler) BulkRejectUsers(w http.ResponseWriter, r *http.Request)
+	func (h *AdminHandler) GetStudentNumberFormat(w http.ResponseWriter, r *http.Request)
+	func (h *AdminHandler) UpdateStudentNumberFormat(w http.ResponseWriter, r *http.Request)
+	func (h *AdminHandler) AssignStudentNumber(w http.ResponseWriter, r *http.Request)
+	func (h *AdminHandler) FindByStudentNumber(w http.ResponseWriter, r *http.Request)
+
 */
 
 type AdminHandler struct {
@@ -376,7 +391,7 @@ func (h *AdminHandler) GetPendingUsers(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp, err := h.adminUC.GetPendingUsers(claims.UserID)
+	resp, err := h.adminUC.GetPendingUsers(r.Context(), claims.UserID)
 	if err != nil {
 		utils.HandleUseCaseError(w, err)
 		return
@@ -400,7 +415,7 @@ func (h *AdminHandler) ApproveUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.adminUC.ApproveUser(claims.UserID, userID); err != nil {
+	if err := h.adminUC.ApproveUser(r.Context(), claims.UserID, userID); err != nil {
 		utils.HandleUseCaseError(w, err) // 403/404/500
 		return
 	}
@@ -431,7 +446,7 @@ func (h *AdminHandler) RejectUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.adminUC.RejectUser(claims.UserID, userID, req.Reason); err != nil {
+	if err := h.adminUC.RejectUser(r.Context(), claims.UserID, userID, req.Reason); err != nil {
 		utils.HandleUseCaseError(w, err)
 		return
 	}
@@ -439,7 +454,7 @@ func (h *AdminHandler) RejectUser(w http.ResponseWriter, r *http.Request) {
 	utils.OK(w, "User rejected successfully", nil)
 }
 
-// GET /api/admin/users?role=student&status=pending
+// GET /api/admin/users?role=student&status=pending&student_number=2026-
 func (h *AdminHandler) GetAllUsers(w http.ResponseWriter, r *http.Request) {
 	claims, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
@@ -448,11 +463,12 @@ func (h *AdminHandler) GetAllUsers(w http.ResponseWriter, r *http.Request) {
 	}
 
 	filters := map[string]string{
-		"role":   r.URL.Query().Get("role"),
-		"status": r.URL.Query().Get("status"),
+		"role":           r.URL.Query().Get("role"),
+		"status":         r.URL.Query().Get("status"),
+		"student_number": r.URL.Query().Get("student_number"),
 	}
 
-	resp, err := h.adminUC.GetAllUsers(claims.UserID, filters)
+	resp, err := h.adminUC.GetAllUsers(r.Context(), claims.UserID, filters)
 	if err != nil {
 		utils.HandleUseCaseError(w, err)
 		return
@@ -477,7 +493,7 @@ func (h *AdminHandler) CreateSubject(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp, err := h.adminUC.CreateSubject(claims.UserID, &req)
+	resp, err := h.adminUC.CreateSubject(r.Context(), claims.UserID, &req)
 	if err != nil {
 		utils.HandleUseCaseError(w, err)
 		return
@@ -507,7 +523,7 @@ func (h *AdminHandler) UpdateSubject(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp, err := h.adminUC.UpdateSubject(claims.UserID, subjectID, &req)
+	resp, err := h.adminUC.UpdateSubject(r.Context(), claims.UserID, subjectID, &req)
 	if err != nil {
 		utils.HandleUseCaseError(w, err)
 		return
@@ -531,7 +547,7 @@ func (h *AdminHandler) DeleteSubject(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.adminUC.DeleteSubject(claims.UserID, subjectID); err != nil {
+	if err := h.adminUC.DeleteSubject(r.Context(), claims.UserID, subjectID); err != nil {
 		utils.HandleUseCaseError(w, err)
 		return
 	}
@@ -547,7 +563,7 @@ func (h *AdminHandler) GetAllSubjects(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	subjects, err := h.adminUC.GetAllSubjects(claims.SchoolID)
+	subjects, err := h.adminUC.GetAllSubjects(r.Context(), claims.SchoolID)
 	if err != nil {
 		utils.HandleUseCaseError(w, err)
 		return
@@ -566,7 +582,7 @@ func (h *AdminHandler) GetAllClasses(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	classes, err := h.adminUC.GetAllClasses(claims.SchoolID)
+	classes, err := h.adminUC.GetAllClasses(r.Context(), claims.SchoolID)
 	if err != nil {
 		utils.HandleUseCaseError(w, err)
 		return
@@ -589,7 +605,7 @@ func (h *AdminHandler) CreateClass(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp, err := h.adminUC.CreateClass(claims.UserID, &req)
+	resp, err := h.adminUC.CreateClass(r.Context(), claims.UserID, &req)
 	if err != nil {
 		utils.HandleUseCaseError(w, err)
 		return
@@ -619,7 +635,7 @@ func (h *AdminHandler) UpdateClass(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp, err := h.adminUC.UpdateClass(claims.UserID, classID, &req)
+	resp, err := h.adminUC.UpdateClass(r.Context(), claims.UserID, classID, &req)
 	if err != nil {
 		utils.HandleUseCaseError(w, err)
 		return
@@ -643,7 +659,7 @@ func (h *AdminHandler) DeleteClass(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.adminUC.DeleteClass(claims.UserID, classID); err != nil {
+	if err := h.adminUC.DeleteClass(r.Context(), claims.UserID, classID); err != nil {
 		utils.HandleUseCaseError(w, err)
 		return
 	}
@@ -651,6 +667,57 @@ func (h *AdminHandler) DeleteClass(w http.ResponseWriter, r *http.Request) {
 	utils.OK(w, "Class deleted successfully", nil)
 }
 
+// GET /api/admin/classes/{id}/waitlist
+func (h *AdminHandler) GetClassWaitlist(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	classID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid class ID")
+		return
+	}
+
+	waitlist, err := h.adminUC.GetClassWaitlist(r.Context(), claims.UserID, classID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Waitlist retrieved", waitlist)
+}
+
+// DELETE /api/admin/classes/{id}/waitlist/{studentId}
+func (h *AdminHandler) RemoveFromWaitlist(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	vars := mux.Vars(r)
+	classID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid class ID")
+		return
+	}
+	studentID, err := strconv.Atoi(vars["studentId"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid student ID")
+		return
+	}
+
+	if err := h.adminUC.RemoveFromWaitlist(r.Context(), claims.UserID, classID, studentID); err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Student removed from waitlist", nil)
+}
+
 // GET /api/admin/dashboard
 func (h *AdminHandler) GetDashboard(w http.ResponseWriter, r *http.Request) {
 	claims, ok := middleware.GetUserFromContext(r.Context())
@@ -659,7 +726,7 @@ func (h *AdminHandler) GetDashboard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	dashboard, err := h.adminUC.GetDashboard(claims.UserID)
+	dashboard, err := h.adminUC.GetDashboard(r.Context(), claims.UserID)
 	if err != nil {
 		utils.HandleUseCaseError(w, err)
 		return
@@ -667,3 +734,304 @@ func (h *AdminHandler) GetDashboard(w http.ResponseWriter, r *http.Request) {
 
 	utils.OK(w, "Dashboard retrieved", dashboard)
 }
+
+// ! ========== USER LIFECYCLE ==========
+
+// GET /api/admin/users/{id}
+func (h *AdminHandler) GetUserByID(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	userID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid user ID")
+		return
+	}
+
+	user, err := h.adminUC.GetUserByID(r.Context(), claims.UserID, userID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "User retrieved", user)
+}
+
+// POST /api/admin/users/{id}/suspend
+func (h *AdminHandler) SuspendUser(w http.ResponseWriter, r *http.Request) {
+	h.userStatusAction(w, r, h.adminUC.SuspendUser, "User suspended successfully")
+}
+
+// POST /api/admin/users/{id}/reactivate
+func (h *AdminHandler) ReactivateUser(w http.ResponseWriter, r *http.Request) {
+	h.userStatusAction(w, r, h.adminUC.ReactivateUser, "User reactivated successfully")
+}
+
+// POST /api/admin/users/{id}/deactivate
+func (h *AdminHandler) DeactivateUser(w http.ResponseWriter, r *http.Request) {
+	h.userStatusAction(w, r, h.adminUC.DeactivateUser, "User deactivated successfully")
+}
+
+// POST /api/admin/users/{id}/reset-password
+func (h *AdminHandler) ResetUserPassword(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	userID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid user ID")
+		return
+	}
+
+	var req dto.ResetPasswordRequest
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.BadRequest(w, "Invalid request body")
+			return
+		}
+	}
+
+	resp, err := h.adminUC.ResetUserPassword(r.Context(), claims.UserID, userID, &req)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Password reset successfully", resp)
+}
+
+// PUT /api/admin/users/{id}/class | POST /api/admin/users/{id}/transfer
+func (h *AdminHandler) ChangeStudentClass(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	userID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid user ID")
+		return
+	}
+
+	var req dto.ChangeStudentClassRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	user, err := h.adminUC.ChangeStudentClass(r.Context(), claims.UserID, userID, &req)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Student transferred successfully", user)
+}
+
+// GET /api/admin/users/{id}/enrollments
+func (h *AdminHandler) GetStudentEnrollments(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	userID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid user ID")
+		return
+	}
+
+	history, err := h.adminUC.GetStudentEnrollments(r.Context(), claims.UserID, userID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Enrollment history retrieved", history)
+}
+
+// PUT /api/admin/users/{id}/subjects
+func (h *AdminHandler) UpdateTeacherSubjects(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	userID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid user ID")
+		return
+	}
+
+	var req dto.UpdateTeacherSubjectsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	user, err := h.adminUC.UpdateTeacherSubjects(r.Context(), claims.UserID, userID, &req)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Teacher subjects updated successfully", user)
+}
+
+// ! ========== BULK ACTIONS ==========
+
+// POST /api/admin/users/bulk-approve
+func (h *AdminHandler) BulkApproveUsers(w http.ResponseWriter, r *http.Request) {
+	h.bulkAction(w, r, h.adminUC.BulkApproveUsers, "Bulk approval processed")
+}
+
+// POST /api/admin/users/bulk-reject
+func (h *AdminHandler) BulkRejectUsers(w http.ResponseWriter, r *http.Request) {
+	h.bulkAction(w, r, h.adminUC.BulkRejectUsers, "Bulk rejection processed")
+}
+
+// ========== STUDENT NUMBERS ==========
+
+// GET /api/admin/school/student-numbers
+func (h *AdminHandler) GetStudentNumberFormat(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	format, err := h.adminUC.GetStudentNumberFormat(r.Context(), claims.UserID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Student number format retrieved", format)
+}
+
+// PUT /api/admin/school/student-numbers
+func (h *AdminHandler) UpdateStudentNumberFormat(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	var req dto.StudentNumberFormatRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	format, err := h.adminUC.UpdateStudentNumberFormat(r.Context(), claims.UserID, &req)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Student number format updated successfully", format)
+}
+
+// POST /api/admin/users/{id}/student-number
+func (h *AdminHandler) AssignStudentNumber(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	studentID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid user ID")
+		return
+	}
+
+	user, err := h.adminUC.AssignStudentNumber(r.Context(), claims.UserID, studentID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Student number assigned", user)
+}
+
+// GET /api/admin/students/by-number/{number}
+func (h *AdminHandler) FindByStudentNumber(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	user, err := h.adminUC.FindByStudentNumber(r.Context(), claims.UserID, mux.Vars(r)["number"])
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "User retrieved", user)
+}
+
+// ! userStatusAction handler commun suspend / reactivate / deactivate
+func (h *AdminHandler) userStatusAction(
+	w http.ResponseWriter,
+	r *http.Request,
+	action func(ctx context.Context, adminUserID, targetUserID int) error,
+	message string,
+) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	userID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid user ID")
+		return
+	}
+
+	if err := action(r.Context(), claims.UserID, userID); err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, message, nil)
+}
+
+// ! bulkAction handler commun des actions en masse (résultat par utilisateur)
+func (h *AdminHandler) bulkAction(
+	w http.ResponseWriter,
+	r *http.Request,
+	action func(ctx context.Context, adminUserID int, req *dto.BulkUserActionRequest) (*dto.BulkUserActionResponse, error),
+	message string,
+) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	var req dto.BulkUserActionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	resp, err := action(r.Context(), claims.UserID, &req)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, message, resp)
+}
@@ -0,0 +1,298 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"educnet/internal/domain"
+	"educnet/internal/handler/dto"
+	"educnet/internal/middleware"
+	"educnet/internal/usecase"
+	"educnet/internal/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// ! maxAnnouncementForm pièce jointe + champs du formulaire
+const maxAnnouncementForm = domain.AnnouncementMaxFileSize + 1<<20
+
+// ! AnnouncementHandler tableau d'annonces (gestion admin, lecture par les destinataires)
+type AnnouncementHandler struct {
+	announcementUC usecase.AnnouncementUseCase
+}
+
+func NewAnnouncementHandler(announcementUC usecase.AnnouncementUseCase) *AnnouncementHandler {
+	return &AnnouncementHandler{announcementUC: announcementUC}
+}
+
+// ! POST /api/admin/announcements
+func (h *AnnouncementHandler) CreateAnnouncement(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	var req dto.AnnouncementRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	announcement, err := h.announcementUC.CreateAnnouncement(r.Context(), claims.UserID, &req)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.Created(w, "Announcement created successfully", announcement)
+}
+
+// ! GET /api/admin/announcements
+func (h *AnnouncementHandler) ListAnnouncements(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	announcements, err := h.announcementUC.ListAnnouncements(r.Context(), claims.UserID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Announcements retrieved", announcements)
+}
+
+// ! PUT /api/admin/announcements/{id}
+func (h *AnnouncementHandler) UpdateAnnouncement(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	announcementID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid announcement ID")
+		return
+	}
+
+	var req dto.AnnouncementRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	announcement, err := h.announcementUC.UpdateAnnouncement(r.Context(), claims.UserID, announcementID, &req)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Announcement updated successfully", announcement)
+}
+
+// ! DELETE /api/admin/announcements/{id}
+func (h *AnnouncementHandler) DeleteAnnouncement(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	announcementID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid announcement ID")
+		return
+	}
+
+	if err := h.announcementUC.DeleteAnnouncement(r.Context(), claims.UserID, announcementID); err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Announcement deleted successfully", nil)
+}
+
+// ! GET /api/admin/announcements/{id}/report (qui n'a pas lu / pas accusé réception)
+func (h *AnnouncementHandler) GetReport(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	announcementID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid announcement ID")
+		return
+	}
+
+	report, err := h.announcementUC.GetReport(r.Context(), claims.UserID, announcementID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Announcement report retrieved", report)
+}
+
+// ! POST /api/admin/announcements/{id}/attachments (multipart : file)
+func (h *AnnouncementHandler) AddAttachment(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	announcementID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid announcement ID")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxAnnouncementForm)
+	if err := r.ParseMultipartForm(maxAnnouncementForm); err != nil {
+		utils.BadRequest(w, "Invalid form (max 10MB)")
+		return
+	}
+	file, closeFile, err := formResourceFile(r)
+	if err != nil {
+		utils.BadRequest(w, "Invalid file")
+		return
+	}
+	defer closeFile()
+
+	announcement, err := h.announcementUC.AddAttachment(r.Context(), claims.UserID, announcementID, file)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.Created(w, "Attachment added successfully", announcement)
+}
+
+// ! DELETE /api/admin/announcements/{id}/attachments/{attachmentId}
+func (h *AnnouncementHandler) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	announcementID, attachmentID, ok := attachmentIDs(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.announcementUC.DeleteAttachment(r.Context(), claims.UserID, announcementID, attachmentID); err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Attachment deleted successfully", nil)
+}
+
+// ! GET /api/announcements (annonces actives qui me ciblent)
+func (h *AnnouncementHandler) ListMyAnnouncements(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	announcements, err := h.announcementUC.ListMyAnnouncements(r.Context(), claims.UserID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Announcements retrieved", announcements)
+}
+
+// ! GET /api/announcements/{id} (marque l'annonce comme lue)
+func (h *AnnouncementHandler) GetAnnouncement(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	announcementID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid announcement ID")
+		return
+	}
+
+	announcement, err := h.announcementUC.GetAnnouncement(r.Context(), claims.UserID, announcementID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Announcement retrieved", announcement)
+}
+
+// ! POST /api/announcements/{id}/acknowledge
+func (h *AnnouncementHandler) Acknowledge(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	announcementID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid announcement ID")
+		return
+	}
+
+	announcement, err := h.announcementUC.Acknowledge(r.Context(), claims.UserID, announcementID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Announcement acknowledged", announcement)
+}
+
+// ! GET /api/announcements/{id}/attachments/{attachmentId}
+func (h *AnnouncementHandler) DownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	announcementID, attachmentID, ok := attachmentIDs(w, r)
+	if !ok {
+		return
+	}
+
+	file, err := h.announcementUC.DownloadAttachment(r.Context(), claims.UserID, announcementID, attachmentID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	writeFile(w, file)
+}
+
+// ! attachmentIDs lit {id} et {attachmentId} ; répond 400 si invalides
+func attachmentIDs(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	vars := mux.Vars(r)
+	announcementID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid announcement ID")
+		return 0, 0, false
+	}
+	attachmentID, err := strconv.Atoi(vars["attachmentId"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid attachment ID")
+		return 0, 0, false
+	}
+	return announcementID, attachmentID, true
+}
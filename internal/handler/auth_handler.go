@@ -35,7 +35,7 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	}
 
 	//! Login
-	resp, err := h.authUC.Login(&req)
+	resp, err := h.authUC.Login(r.Context(), &req)
 	if err != nil {
 		utils.Unauthorized(w, err.Error())
 		return
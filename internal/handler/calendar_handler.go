@@ -0,0 +1,226 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"educnet/internal/handler/dto"
+	"educnet/internal/middleware"
+	"educnet/internal/usecase"
+	"educnet/internal/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// ! CalendarHandler événements de l'école et flux iCalendar personnels
+type CalendarHandler struct {
+	calendarUC usecase.CalendarUseCase
+}
+
+func NewCalendarHandler(calendarUC usecase.CalendarUseCase) *CalendarHandler {
+	return &CalendarHandler{calendarUC: calendarUC}
+}
+
+// ! POST /api/calendar/events
+func (h *CalendarHandler) CreateEvent(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	var req dto.EventRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	event, err := h.calendarUC.CreateEvent(r.Context(), claims.UserID, &req)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.Created(w, "Event created successfully", event)
+}
+
+// ! GET /api/calendar/events?from=2026-09-01&to=2026-10-01&class_id=1&category=exam
+func (h *CalendarHandler) ListEvents(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	values := r.URL.Query()
+	query := dto.EventQuery{From: values.Get("from"), To: values.Get("to"), Category: values.Get("category")}
+	classID, err := formInt(values.Get("class_id"), "class_id")
+	if err != nil {
+		utils.BadRequest(w, err.Error())
+		return
+	}
+	query.ClassID = classID
+
+	occurrences, err := h.calendarUC.ListOccurrences(r.Context(), claims.UserID, query)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Events retrieved", occurrences)
+}
+
+// ! GET /api/calendar/events/{id}
+func (h *CalendarHandler) GetEvent(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	eventID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid event ID")
+		return
+	}
+
+	event, err := h.calendarUC.GetEvent(r.Context(), claims.UserID, eventID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Event retrieved", event)
+}
+
+// ! PUT /api/calendar/events/{id}
+func (h *CalendarHandler) UpdateEvent(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	eventID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid event ID")
+		return
+	}
+
+	var req dto.EventRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	event, err := h.calendarUC.UpdateEvent(r.Context(), claims.UserID, eventID, &req)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Event updated successfully", event)
+}
+
+// ! DELETE /api/calendar/events/{id}
+func (h *CalendarHandler) DeleteEvent(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	eventID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid event ID")
+		return
+	}
+
+	if err := h.calendarUC.DeleteEvent(r.Context(), claims.UserID, eventID); err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Event deleted successfully", nil)
+}
+
+// ! GET /api/calendar/feed (URL d'abonnement, créée à la première demande)
+func (h *CalendarHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	feed, err := h.calendarUC.GetFeed(r.Context(), claims.UserID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	feed.URL = feedURL(r, feed.Token)
+	utils.OK(w, "Calendar feed retrieved", feed)
+}
+
+// ! POST /api/calendar/feed/rotate (invalide l'URL précédente)
+func (h *CalendarHandler) RotateFeed(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	feed, err := h.calendarUC.RotateFeed(r.Context(), claims.UserID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	feed.URL = feedURL(r, feed.Token)
+	utils.OK(w, "Calendar feed rotated", feed)
+}
+
+// ! DELETE /api/calendar/feed
+func (h *CalendarHandler) RevokeFeed(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	if err := h.calendarUC.RevokeFeed(r.Context(), claims.UserID); err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Calendar feed revoked", nil)
+}
+
+// ! GET /api/calendar/feed/{token}.ics (public : le token signé fait office d'authentification)
+func (h *CalendarHandler) Feed(w http.ResponseWriter, r *http.Request) {
+	file, err := h.calendarUC.Feed(r.Context(), mux.Vars(r)["token"])
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "private, max-age=900")
+	writeFile(w, file)
+}
+
+// ! feedURL URL absolue du flux, déduite de la requête (proxy compris)
+func feedURL(r *http.Request, token string) string {
+	scheme := r.Header.Get("X-Forwarded-Proto")
+	if scheme == "" {
+		scheme = "http"
+		if r.TLS != nil {
+			scheme = "https"
+		}
+	}
+	host := r.Header.Get("X-Forwarded-Host")
+	if host == "" {
+		host = r.Host
+	}
+	return scheme + "://" + host + "/api/calendar/feed/" + token + ".ics"
+}
@@ -2,11 +2,15 @@ package handler
 
 import (
 	"context"
+	"database/sql"
+	"educnet/internal/db"
+	"educnet/internal/domain"
+	"educnet/internal/logging"
 	"educnet/internal/middleware"
 	"educnet/internal/usecase"
 	"educnet/internal/utils"
 	ws "educnet/internal/websocket"
-	"log"
+	"log/slog"
 	"net/http"
 	"strconv"
 	"time"
@@ -17,10 +21,11 @@ import (
 
 type ChatHandler struct {
 	uc usecase.MessageUseCase
+	db *sql.DB //! le WS n'a pas de transaction de requête : une tx tenant par opération
 }
 
-func NewChatHandler(uc usecase.MessageUseCase) *ChatHandler {
-	return &ChatHandler{uc}
+func NewChatHandler(uc usecase.MessageUseCase, database *sql.DB) *ChatHandler {
+	return &ChatHandler{uc: uc, db: database}
 }
 
 var upgrader = websocket.Upgrader{
@@ -28,31 +33,40 @@ var upgrader = websocket.Upgrader{
 }
 
 func (h *ChatHandler) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
-	log.Println("🔥 WS Handler START - classId parsing...")
-
 	classIDStr := mux.Vars(r)["classId"]
 	classID, err := strconv.Atoi(classIDStr)
 	if err != nil {
 		utils.BadRequest(w, "Invalid class id type")
 		return
 	}
-	log.Printf("✅ WS classID parsed: %d", classID)
 
 	claims, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
 		utils.Unauthorized(w, "Unauthorized user")
 		return
 	}
-	log.Printf("✅ WS UserID: %d (%s)", claims.UserID, claims.Email)
+
+	//! Context de session : garde request_id / user_id pour les logs, sans
+	//! l'annulation liée à la requête HTTP
+	ctx := context.WithoutCancel(r.Context())
+	logging.AddAttrs(ctx, slog.Int("class_id", classID))
 
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
-		log.Println("WebSocket upgrade:", err)
+		slog.WarnContext(ctx, "websocket upgrade failed", "error", err)
 		return
 	}
 	defer conn.Close()
 
-	canAccess, err := h.uc.CanAccessClass(r.Context(), claims.UserID, classID)
+	//! Connexion détournée : les timeouts du http.Server (lecture / écriture)
+	//! ne doivent pas couper une session de chat longue
+	conn.NetConn().SetDeadline(time.Time{})
+
+	var canAccess bool
+	err = db.InTenantTx(ctx, h.db, claims.SchoolID, func(ctx context.Context) error {
+		canAccess, err = h.uc.CanAccessClass(ctx, claims.UserID, classID)
+		return err
+	})
 	if err != nil {
 		utils.HandleUseCaseError(w, err)
 		return
@@ -62,16 +76,24 @@ func (h *ChatHandler) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	room := ws.NewRoom(classID)
 	client := &ws.Client{
 		ID:   claims.UserID,
 		Conn: conn,
 		Send: make(chan ws.Message, 256),
+		Ctx:  ctx,
 	}
 
-	room.RegisterClient(client)
+	//! Arrêt du serveur en cours : le client a reçu la trame de redémarrage
+	room := ws.Join(classID, client)
+	if room == nil {
+		return
+	}
 
-	messages, err := h.uc.GetClassMessages(r.Context(), classID, 50)
+	var messages []domain.Message
+	err = db.InTenantTx(ctx, h.db, claims.SchoolID, func(ctx context.Context) error {
+		messages, err = h.uc.GetClassMessages(ctx, classID, 50)
+		return err
+	})
 	if err == nil {
 		for _, msg := range messages {
 			roomMsg := ws.Message{
@@ -86,12 +108,14 @@ func (h *ChatHandler) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	go h.writePump(conn, client.Send)
+	slog.InfoContext(ctx, "websocket session started")
+	go h.writePump(ctx, conn, client.Send)
 
-	h.readPump(room, conn, client, claims.UserID, classID)
+	h.readPump(ctx, room, conn, client, claims.UserID, claims.SchoolID, classID)
+	slog.InfoContext(ctx, "websocket session ended")
 }
 
-func (h *ChatHandler) writePump(conn *websocket.Conn, send chan ws.Message) {
+func (h *ChatHandler) writePump(ctx context.Context, conn *websocket.Conn, send chan ws.Message) {
 	ticker := time.NewTicker(90 * time.Second)
 	defer ticker.Stop()
 	defer conn.Close()
@@ -105,7 +129,7 @@ func (h *ChatHandler) writePump(conn *websocket.Conn, send chan ws.Message) {
 			}
 
 			if err := conn.WriteJSON(message); err != nil {
-				log.Println("Write error:", err)
+				slog.DebugContext(ctx, "websocket write failed", "error", err)
 				return
 			}
 
@@ -117,7 +141,7 @@ func (h *ChatHandler) writePump(conn *websocket.Conn, send chan ws.Message) {
 	}
 }
 
-func (h *ChatHandler) readPump(room *ws.Room, conn *websocket.Conn, client *ws.Client, userID, classID int) {
+func (h *ChatHandler) readPump(ctx context.Context, room *ws.Room, conn *websocket.Conn, client *ws.Client, userID, schoolID, classID int) {
 	defer func() {
 		room.UnregisterClient(client)
 		conn.Close()
@@ -129,7 +153,9 @@ func (h *ChatHandler) readPump(room *ws.Room, conn *websocket.Conn, client *ws.C
 		}
 
 		if err := conn.ReadJSON(&msg); err != nil {
-			log.Println("Read error:", err)
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				slog.WarnContext(ctx, "websocket read failed", "error", err)
+			}
 			break
 		}
 
@@ -137,7 +163,12 @@ func (h *ChatHandler) readPump(room *ws.Room, conn *websocket.Conn, client *ws.C
 			continue
 		}
 
-		createdMsg, err := h.uc.SendMessage(context.Background(), userID, classID, msg.Content)
+		var createdMsg domain.Message
+		err := db.InTenantTx(ctx, h.db, schoolID, func(ctx context.Context) error {
+			var err error
+			createdMsg, err = h.uc.SendMessage(ctx, userID, classID, msg.Content)
+			return err
+		})
 		if err != nil {
 			conn.WriteJSON(map[string]string{"error": err.Error()})
 			continue
@@ -147,6 +178,6 @@ func (h *ChatHandler) readPump(room *ws.Room, conn *websocket.Conn, client *ws.C
 			Type:    "message",
 			Content: createdMsg,
 		}
-		room.Broadcast <- roomMsg
+		room.Publish(roomMsg)
 	}
 }
@@ -29,7 +29,7 @@ func (h *ClassHandler) GetClassesBySchoolID(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	out, err := h.classUseCase.GetAllBySchoolID(id)
+	out, err := h.classUseCase.GetAllBySchoolID(r.Context(), id)
 	if err != nil {
 		utils.HandleUseCaseError(w, err)
 		return
@@ -0,0 +1,339 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"educnet/internal/handler/dto"
+	"educnet/internal/middleware"
+	"educnet/internal/usecase"
+	"educnet/internal/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// ! DisciplineHandler incidents disciplinaires, sanctions et réglages de visibilité
+type DisciplineHandler struct {
+	disciplineUC usecase.DisciplineUseCase
+}
+
+func NewDisciplineHandler(disciplineUC usecase.DisciplineUseCase) *DisciplineHandler {
+	return &DisciplineHandler{disciplineUC: disciplineUC}
+}
+
+// ! POST /api/teacher/incidents, /api/admin/incidents
+func (h *DisciplineHandler) ReportIncident(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	var req dto.IncidentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	incident, err := h.disciplineUC.ReportIncident(r.Context(), claims.UserID, &req)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.Created(w, "Incident reported successfully", incident)
+}
+
+// ! GET /api/teacher/incidents
+func (h *DisciplineHandler) ListMyIncidents(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	incidents, err := h.disciplineUC.ListMyIncidents(r.Context(), claims.UserID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Incidents retrieved", incidents)
+}
+
+// ! POST /api/teacher/incidents/{id}/escalate, /api/admin/incidents/{id}/escalate
+func (h *DisciplineHandler) EscalateIncident(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	incidentID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid incident ID")
+		return
+	}
+
+	var req dto.EscalateIncidentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	incident, err := h.disciplineUC.EscalateIncident(r.Context(), claims.UserID, incidentID, &req)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Incident escalated successfully", incident)
+}
+
+// ! POST /api/teacher/incidents/{id}/sanctions, /api/admin/incidents/{id}/sanctions
+func (h *DisciplineHandler) AddSanction(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	incidentID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid incident ID")
+		return
+	}
+
+	var req dto.SanctionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	incident, err := h.disciplineUC.AddSanction(r.Context(), claims.UserID, incidentID, &req)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.Created(w, "Sanction issued successfully", incident)
+}
+
+// ! GET /api/admin/incidents?student_id=&class_id=&status=&severity=&from=&to=
+func (h *DisciplineHandler) ListIncidents(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	values := r.URL.Query()
+	query := dto.IncidentQuery{
+		Status:   values.Get("status"),
+		Severity: values.Get("severity"),
+		From:     values.Get("from"),
+		To:       values.Get("to"),
+	}
+	var err error
+	if query.StudentID, err = formInt(values.Get("student_id"), "student_id"); err != nil {
+		utils.BadRequest(w, err.Error())
+		return
+	}
+	if query.ClassID, err = formInt(values.Get("class_id"), "class_id"); err != nil {
+		utils.BadRequest(w, err.Error())
+		return
+	}
+
+	incidents, err := h.disciplineUC.ListIncidents(r.Context(), claims.UserID, query)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Incidents retrieved", incidents)
+}
+
+// ! GET /api/admin/incidents/{id}
+func (h *DisciplineHandler) GetIncident(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	incidentID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid incident ID")
+		return
+	}
+
+	incident, err := h.disciplineUC.GetIncident(r.Context(), claims.UserID, incidentID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Incident retrieved", incident)
+}
+
+// ! POST /api/admin/incidents/{id}/close
+func (h *DisciplineHandler) CloseIncident(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	incidentID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid incident ID")
+		return
+	}
+
+	var req dto.CloseIncidentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	incident, err := h.disciplineUC.CloseIncident(r.Context(), claims.UserID, incidentID, &req)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Incident closed successfully", incident)
+}
+
+// ! DELETE /api/admin/incidents/{id}/sanctions/{sanctionId}
+func (h *DisciplineHandler) DeleteSanction(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	vars := mux.Vars(r)
+	incidentID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid incident ID")
+		return
+	}
+	sanctionID, err := strconv.Atoi(vars["sanctionId"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid sanction ID")
+		return
+	}
+
+	if err := h.disciplineUC.DeleteSanction(r.Context(), claims.UserID, incidentID, sanctionID); err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Sanction deleted successfully", nil)
+}
+
+// ! GET /api/admin/students/{id}/incidents
+func (h *DisciplineHandler) GetStudentHistory(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	studentID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid student ID")
+		return
+	}
+
+	history, err := h.disciplineUC.GetStudentHistory(r.Context(), claims.UserID, studentID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Discipline history retrieved", history)
+}
+
+// ! GET /api/admin/classes/{id}/discipline-summary?from=&to=
+func (h *DisciplineHandler) GetClassSummary(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	classID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid class ID")
+		return
+	}
+
+	values := r.URL.Query()
+	summary, err := h.disciplineUC.GetClassSummary(r.Context(), claims.UserID, classID, values.Get("from"), values.Get("to"))
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Discipline summary retrieved", summary)
+}
+
+// ! GET /api/admin/school/discipline
+func (h *DisciplineHandler) GetSettings(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	settings, err := h.disciplineUC.GetSettings(r.Context(), claims.UserID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Discipline settings retrieved", settings)
+}
+
+// ! PUT /api/admin/school/discipline
+func (h *DisciplineHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	var req dto.DisciplineSettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	settings, err := h.disciplineUC.UpdateSettings(r.Context(), claims.UserID, &req)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Discipline settings updated successfully", settings)
+}
+
+// ! GET /api/student/incidents
+func (h *DisciplineHandler) ListStudentIncidents(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	incidents, err := h.disciplineUC.ListStudentIncidents(r.Context(), claims.UserID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Incidents retrieved", incidents)
+}
@@ -1,6 +1,9 @@
 package dto
 
-import "educnet/internal/domain"
+import (
+	"educnet/internal/domain"
+	"time"
+)
 
 type PendingUsersResponse struct {
 	Users []PendingUserInfo `json:"users"`
@@ -34,13 +37,14 @@ type UserListResponse struct {
 }
 
 type UserListInfo struct {
-	ID        int    `json:"id"`
-	Email     string `json:"email"`
-	FullName  string `json:"full_name"`
-	Role      string `json:"role"`
-	Status    string `json:"status"`
-	Phone     string `json:"phone,omitempty"`
-	CreatedAt string `json:"created_at"`
+	ID            int    `json:"id"`
+	Email         string `json:"email"`
+	FullName      string `json:"full_name"`
+	Role          string `json:"role"`
+	Status        string `json:"status"`
+	Phone         string `json:"phone,omitempty"`
+	StudentNumber string `json:"student_number,omitempty"` //! élèves : matricule
+	CreatedAt     string `json:"created_at"`
 }
 
 //! ========== SUBJECTS ==========
@@ -122,6 +126,62 @@ func ClassResponsesFromDomain(classes []*domain.Class) []ClassResponse {
 	return responses
 }
 
+type EnrollmentResponse struct {
+	ClassID      int    `json:"class_id"`
+	ClassName    string `json:"class_name"`
+	AcademicYear string `json:"academic_year"`
+	EnrolledAt   string `json:"enrolled_at"`
+	EndedAt      string `json:"ended_at,omitempty"`
+	EndReason    string `json:"end_reason,omitempty"`
+	IsActive     bool   `json:"is_active"`
+}
+
+type EnrollmentHistoryResponse struct {
+	StudentID   int                  `json:"student_id"`
+	FullName    string               `json:"full_name"`
+	Enrollments []EnrollmentResponse `json:"enrollments"`
+}
+
+func EnrollmentResponsesFromDomain(enrollments []*domain.Enrollment) []EnrollmentResponse {
+	responses := make([]EnrollmentResponse, len(enrollments))
+	for i, e := range enrollments {
+		responses[i] = EnrollmentResponse{
+			ClassID:      e.ClassID,
+			ClassName:    e.ClassName,
+			AcademicYear: e.AcademicYear,
+			EnrolledAt:   e.EnrolledAt.Format("2006-01-02 15:04:05"),
+			EndReason:    e.EndReason,
+			IsActive:     e.IsActive,
+		}
+		if e.EndedAt != nil {
+			responses[i].EndedAt = e.EndedAt.Format("2006-01-02 15:04:05")
+		}
+	}
+	return responses
+}
+
+type WaitlistEntryResponse struct {
+	StudentID    int    `json:"student_id"`
+	StudentName  string `json:"student_name"`
+	StudentEmail string `json:"student_email"`
+	Position     int    `json:"position"`
+	WaitingSince string `json:"waiting_since"`
+}
+
+func WaitlistEntryResponsesFromDomain(entries []*domain.WaitlistEntry) []WaitlistEntryResponse {
+	responses := make([]WaitlistEntryResponse, len(entries))
+	for i, entry := range entries {
+		responses[i] = WaitlistEntryResponse{
+			StudentID:    entry.StudentID,
+			StudentName:  entry.StudentName,
+			StudentEmail: entry.StudentEmail,
+			Position:     entry.Position,
+			WaitingSince: entry.CreatedAt.Format("2006-01-02 15:04:05"),
+		}
+	}
+	return responses
+}
+
 // ! DashboardResponse statistiques du dashboard admin
 type DashboardResponse struct {
 	Stats          DashboardStats       `json:"stats"`
@@ -146,3 +206,80 @@ type RecentActivityInfo struct {
 	Message   string `json:"message"`
 	Timestamp string `json:"timestamp"`
 }
+
+// ! ========== USER LIFECYCLE ==========
+
+// ! UserDetailResponse détail d'un utilisateur (admin)
+type UserDetailResponse struct {
+	UserListInfo
+	SchoolID           int               `json:"school_id"`
+	AvatarURL          string            `json:"avatar_url,omitempty"`
+	MustChangePassword bool              `json:"must_change_password"`
+	UpdatedAt          string            `json:"updated_at"`
+	Subjects           []SubjectResponse `json:"subjects,omitempty"`
+	Classes            []ClassResponse   `json:"classes,omitempty"`
+}
+
+// ! StudentNumberFormatRequest jetons : {YEAR}, {YY}, {LEVEL}, {SEQ:n}
+type StudentNumberFormatRequest struct {
+	Format string `json:"format"`
+}
+
+type StudentNumberFormatResponse struct {
+	Format    string `json:"format"`
+	Example   string `json:"example"` //! premier matricule d'une classe de 6e cette année
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+type ResetPasswordRequest struct {
+	SendEmail bool `json:"send_email"` //! sinon le mot de passe temporaire est retourné
+}
+
+type ResetPasswordResponse struct {
+	UserID            int    `json:"user_id"`
+	Email             string `json:"email"`
+	TemporaryPassword string `json:"temporary_password,omitempty"`
+	EmailSent         bool   `json:"email_sent"`
+}
+
+type ChangeStudentClassRequest struct {
+	ClassID int    `json:"class_id"`
+	Reason  string `json:"reason,omitempty"` //! motif de fin de l'inscription actuelle
+}
+
+type UpdateTeacherSubjectsRequest struct {
+	SubjectIDs []int `json:"subject_ids"`
+}
+
+// ! ========== BULK ACTIONS ==========
+
+type BulkUserActionRequest struct {
+	UserIDs []int  `json:"user_ids"`
+	Reason  string `json:"reason,omitempty"` //! reject uniquement
+}
+
+type BulkUserActionResult struct {
+	UserID  int    `json:"user_id"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+type BulkUserActionResponse struct {
+	Results   []BulkUserActionResult `json:"results"`
+	Succeeded int                    `json:"succeeded"`
+	Failed    int                    `json:"failed"`
+}
+
+// ! ========== ANALYTICS ==========
+
+// ! StatsResponse statistiques agrégées de l'école sur une période
+type StatsResponse struct {
+	Period             domain.StatsPeriod         `json:"period"`
+	Users              domain.UserCounts          `json:"users"`
+	Registrations      []domain.RegistrationPoint `json:"registrations"`
+	ApprovalLatency    domain.ApprovalLatency     `json:"approval_latency"`
+	ClassFill          []domain.ClassFill         `json:"class_fill"`
+	TeachersPerSubject []domain.SubjectTeachers   `json:"teachers_per_subject"`
+	ChatActivity       []domain.ClassChatActivity `json:"chat_activity"`
+	GeneratedAt        time.Time                  `json:"generated_at"`
+}
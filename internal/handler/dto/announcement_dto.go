@@ -0,0 +1,115 @@
+package dto
+
+import (
+	"educnet/internal/domain"
+	"time"
+)
+
+// ! AnnouncementRequest target : school | role (target_role) | classes (class_ids) | levels | parents.
+// ! publish_at / expires_at RFC 3339 ; publish_at absent = publication immédiate
+type AnnouncementRequest struct {
+	Title       string   `json:"title"`
+	Body        string   `json:"body"`
+	Target      string   `json:"target"`
+	TargetRole  string   `json:"target_role"`
+	ClassIDs    []int    `json:"class_ids"`
+	Levels      []string `json:"levels"`
+	PublishAt   string   `json:"publish_at"`
+	ExpiresAt   string   `json:"expires_at"`
+	RequiresAck bool     `json:"requires_ack"`
+}
+
+type AnnouncementAttachmentResponse struct {
+	ID          int       `json:"id"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ! AnnouncementResponse ReadAt / AcknowledgedAt : état pour l'utilisateur courant (destinataire)
+type AnnouncementResponse struct {
+	ID             int                              `json:"id"`
+	AuthorID       int                              `json:"author_id"`
+	Title          string                           `json:"title"`
+	Body           string                           `json:"body"`
+	Target         string                           `json:"target"`
+	TargetRole     string                           `json:"target_role,omitempty"`
+	ClassIDs       []int                            `json:"class_ids,omitempty"`
+	Levels         []string                         `json:"levels,omitempty"`
+	PublishAt      time.Time                        `json:"publish_at"`
+	ExpiresAt      *time.Time                       `json:"expires_at,omitempty"`
+	RequiresAck    bool                             `json:"requires_ack"`
+	NotifiedAt     *time.Time                       `json:"notified_at,omitempty"`
+	Attachments    []AnnouncementAttachmentResponse `json:"attachments,omitempty"`
+	ReadAt         *time.Time                       `json:"read_at,omitempty"`
+	AcknowledgedAt *time.Time                       `json:"acknowledged_at,omitempty"`
+	CreatedAt      time.Time                        `json:"created_at"`
+	UpdatedAt      time.Time                        `json:"updated_at"`
+}
+
+func AnnouncementResponseFromDomain(a *domain.Announcement) AnnouncementResponse {
+	return AnnouncementResponse{
+		ID:          a.ID,
+		AuthorID:    a.AuthorID,
+		Title:       a.Title,
+		Body:        a.Body,
+		Target:      a.Target,
+		TargetRole:  a.TargetRole,
+		ClassIDs:    a.ClassIDs,
+		Levels:      a.Levels,
+		PublishAt:   a.PublishAt,
+		ExpiresAt:   a.ExpiresAt,
+		RequiresAck: a.RequiresAck,
+		NotifiedAt:  a.NotifiedAt,
+		CreatedAt:   a.CreatedAt,
+		UpdatedAt:   a.UpdatedAt,
+	}
+}
+
+func AnnouncementAttachmentResponsesFromDomain(files []*domain.AnnouncementAttachment) []AnnouncementAttachmentResponse {
+	responses := make([]AnnouncementAttachmentResponse, len(files))
+	for i, f := range files {
+		responses[i] = AnnouncementAttachmentResponse{
+			ID:          f.ID,
+			FileName:    f.FileName,
+			ContentType: f.ContentType,
+			Size:        f.Size,
+			CreatedAt:   f.CreatedAt,
+		}
+	}
+	return responses
+}
+
+type AnnouncementRecipientResponse struct {
+	UserID         int        `json:"user_id"`
+	FirstName      string     `json:"first_name"`
+	LastName       string     `json:"last_name"`
+	Email          string     `json:"email"`
+	Role           string     `json:"role"`
+	ReadAt         *time.Time `json:"read_at,omitempty"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+}
+
+func AnnouncementRecipientResponseFromDomain(r *domain.AnnouncementRecipient) AnnouncementRecipientResponse {
+	return AnnouncementRecipientResponse{
+		UserID:         r.UserID,
+		FirstName:      r.FirstName,
+		LastName:       r.LastName,
+		Email:          r.Email,
+		Role:           r.Role,
+		ReadAt:         r.ReadAt,
+		AcknowledgedAt: r.AcknowledgedAt,
+	}
+}
+
+// ! AnnouncementReportResponse suivi de lecture : destinataires n'ayant pas lu
+// ! et, si un accusé de réception est demandé, ceux qui ne l'ont pas donné
+type AnnouncementReportResponse struct {
+	AnnouncementID int                             `json:"announcement_id"`
+	Recipients     int                             `json:"recipients"`
+	Read           int                             `json:"read"`
+	Acknowledged   int                             `json:"acknowledged"`
+	Unread         []AnnouncementRecipientResponse `json:"unread"`
+	Unacknowledged []AnnouncementRecipientResponse `json:"unacknowledged,omitempty"`
+}
@@ -21,4 +21,6 @@ type UserInfo struct {
 	Status    string `json:"status"`
 	SchoolID  int    `json:"school_id"`
 	AvatarURL string `json:"avatar_url,omitempty"`
+
+	MustChangePassword bool `json:"must_change_password,omitempty"` //! le client doit forcer le changement
 }
@@ -0,0 +1,90 @@
+package dto
+
+import (
+	"educnet/internal/domain"
+	"time"
+)
+
+// ! EventRequest dates RFC 3339 (2026-09-07T08:00:00Z), ou YYYY-MM-DD si all_day
+// ! (ends_at = dernier jour inclus). class_id absent => événement de toute l'école (admin).
+// ! room_id : salle occupée (cours de l'emploi du temps), exclue des disponibilités
+// ! rrule : FREQ=DAILY|WEEKLY|MONTHLY|YEARLY ; INTERVAL ; COUNT ou UNTIL ; BYDAY (hebdomadaire)
+type EventRequest struct {
+	ClassID     *int   `json:"class_id"`
+	RoomID      *int   `json:"room_id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Location    string `json:"location"`
+	Category    string `json:"category"`
+	Audience    string `json:"audience"`
+	StartsAt    string `json:"starts_at"`
+	EndsAt      string `json:"ends_at"`
+	AllDay      bool   `json:"all_day"`
+	RRule       string `json:"rrule"`
+}
+
+// ! EventQuery période [from, to) au format YYYY-MM-DD (défaut : 30 jours à partir d'aujourd'hui)
+type EventQuery struct {
+	From     string
+	To       string
+	ClassID  int
+	Category string
+}
+
+type EventResponse struct {
+	ID          int       `json:"id"`
+	ClassID     *int      `json:"class_id,omitempty"`
+	RoomID      *int      `json:"room_id,omitempty"`
+	Title       string    `json:"title"`
+	Description string    `json:"description,omitempty"`
+	Location    string    `json:"location,omitempty"`
+	Category    string    `json:"category"`
+	Audience    string    `json:"audience"`
+	StartsAt    time.Time `json:"starts_at"`
+	EndsAt      time.Time `json:"ends_at"`
+	AllDay      bool      `json:"all_day"`
+	RRule       string    `json:"rrule,omitempty"`
+	CreatedBy   int       `json:"created_by"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func EventResponseFromDomain(e *domain.Event) EventResponse {
+	return EventResponse{
+		ID:          e.ID,
+		ClassID:     e.ClassID,
+		RoomID:      e.RoomID,
+		Title:       e.Title,
+		Description: e.Description,
+		Location:    e.Location,
+		Category:    e.Category,
+		Audience:    e.Audience,
+		StartsAt:    e.StartsAt,
+		EndsAt:      e.EndsAt,
+		AllDay:      e.AllDay,
+		RRule:       e.RRule,
+		CreatedBy:   e.CreatedBy,
+		UpdatedAt:   e.UpdatedAt,
+	}
+}
+
+// ! OccurrenceResponse une occurrence d'événement dans la période demandée
+// ! (les événements récurrents apparaissent une fois par occurrence)
+type OccurrenceResponse struct {
+	EventID   int       `json:"event_id"`
+	ClassID   *int      `json:"class_id,omitempty"`
+	RoomID    *int      `json:"room_id,omitempty"`
+	Title     string    `json:"title"`
+	Location  string    `json:"location,omitempty"`
+	Category  string    `json:"category"`
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	AllDay    bool      `json:"all_day"`
+	Recurring bool      `json:"recurring"`
+}
+
+// ! CalendarFeedResponse URL d'abonnement .ics (à garder secrète : elle donne accès au calendrier)
+type CalendarFeedResponse struct {
+	Token   string `json:"-"`
+	URL     string `json:"url"`
+	Version int    `json:"version"`
+}
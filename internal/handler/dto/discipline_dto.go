@@ -0,0 +1,175 @@
+package dto
+
+import (
+	"educnet/internal/domain"
+	"time"
+)
+
+// ! IncidentRequest occurred_at en RFC 3339 (2026-10-19T10:15:00Z) ; confidential : jamais
+// ! communiqué à l'élève ni à ses responsables
+type IncidentRequest struct {
+	StudentID    int    `json:"student_id"`
+	ClassID      int    `json:"class_id"`
+	OccurredAt   string `json:"occurred_at"`
+	Location     string `json:"location"`
+	Description  string `json:"description"`
+	Severity     string `json:"severity"`
+	Confidential bool   `json:"confidential"`
+}
+
+type EscalateIncidentRequest struct {
+	Reason string `json:"reason"`
+}
+
+// ! CloseIncidentRequest dismiss : classé sans suite (exclu des synthèses)
+type CloseIncidentRequest struct {
+	Resolution string `json:"resolution"`
+	Dismiss    bool   `json:"dismiss"`
+}
+
+// ! SanctionRequest dates YYYY-MM-DD : jour de la retenue (starts_on) ou période d'exclusion
+type SanctionRequest struct {
+	Type     string `json:"type"`
+	StartsOn string `json:"starts_on"`
+	EndsOn   string `json:"ends_on"`
+	Notes    string `json:"notes"`
+}
+
+// ! IncidentQuery filtres de la liste admin ; from/to au format YYYY-MM-DD (to inclus)
+type IncidentQuery struct {
+	StudentID int
+	ClassID   int
+	Status    string
+	Severity  string
+	From      string
+	To        string
+}
+
+type DisciplineSettingsRequest struct {
+	StudentVisibility string `json:"student_visibility"`
+	ParentVisibility  string `json:"parent_visibility"`
+	EscalateSeverity  string `json:"escalate_severity"`
+	EscalateThreshold int    `json:"escalate_threshold"`
+	EscalateWindow    int    `json:"escalate_window_days"`
+}
+
+type SanctionResponse struct {
+	ID        int        `json:"id"`
+	Type      string     `json:"type"`
+	StartsOn  *time.Time `json:"starts_on,omitempty"`
+	EndsOn    *time.Time `json:"ends_on,omitempty"`
+	Days      int        `json:"days,omitempty"`
+	Notes     string     `json:"notes,omitempty"`
+	IssuedBy  int        `json:"issued_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func SanctionResponseFromDomain(s *domain.Sanction) SanctionResponse {
+	return SanctionResponse{
+		ID:        s.ID,
+		Type:      s.Type,
+		StartsOn:  s.StartsOn,
+		EndsOn:    s.EndsOn,
+		Days:      s.Days(),
+		Notes:     s.Notes,
+		IssuedBy:  s.IssuedBy,
+		CreatedAt: s.CreatedAt,
+	}
+}
+
+type IncidentResponse struct {
+	ID               int                `json:"id"`
+	StudentID        int                `json:"student_id"`
+	StudentName      string             `json:"student_name,omitempty"`
+	ClassID          int                `json:"class_id"`
+	ClassName        string             `json:"class_name,omitempty"`
+	ReportedBy       int                `json:"reported_by,omitempty"`
+	ReporterName     string             `json:"reporter_name,omitempty"`
+	OccurredAt       time.Time          `json:"occurred_at"`
+	Location         string             `json:"location,omitempty"`
+	Description      string             `json:"description"`
+	Severity         string             `json:"severity"`
+	Status           string             `json:"status"`
+	Confidential     bool               `json:"confidential"`
+	AutoEscalated    bool               `json:"auto_escalated,omitempty"`
+	EscalatedBy      *int               `json:"escalated_by,omitempty"`
+	EscalatedAt      *time.Time         `json:"escalated_at,omitempty"`
+	EscalationReason string             `json:"escalation_reason,omitempty"`
+	ResolvedBy       *int               `json:"resolved_by,omitempty"`
+	ResolvedAt       *time.Time         `json:"resolved_at,omitempty"`
+	Resolution       string             `json:"resolution,omitempty"`
+	Sanctions        []SanctionResponse `json:"sanctions"`
+	CreatedAt        time.Time          `json:"created_at"`
+}
+
+func IncidentResponseFromDomain(i *domain.Incident) IncidentResponse {
+	sanctions := make([]SanctionResponse, len(i.Sanctions))
+	for k, s := range i.Sanctions {
+		sanctions[k] = SanctionResponseFromDomain(s)
+	}
+	return IncidentResponse{
+		ID:               i.ID,
+		StudentID:        i.StudentID,
+		StudentName:      i.StudentName,
+		ClassID:          i.ClassID,
+		ClassName:        i.ClassName,
+		ReportedBy:       i.ReportedBy,
+		ReporterName:     i.ReporterName,
+		OccurredAt:       i.OccurredAt,
+		Location:         i.Location,
+		Description:      i.Description,
+		Severity:         i.Severity,
+		Status:           i.Status,
+		Confidential:     i.Confidential,
+		AutoEscalated:    i.EscalatedAt != nil && i.EscalatedBy == nil,
+		EscalatedBy:      i.EscalatedBy,
+		EscalatedAt:      i.EscalatedAt,
+		EscalationReason: i.EscalationReason,
+		ResolvedBy:       i.ResolvedBy,
+		ResolvedAt:       i.ResolvedAt,
+		Resolution:       i.Resolution,
+		Sanctions:        sanctions,
+		CreatedAt:        i.CreatedAt,
+	}
+}
+
+func IncidentResponsesFromDomain(incidents []*domain.Incident) []IncidentResponse {
+	responses := make([]IncidentResponse, len(incidents))
+	for k, i := range incidents {
+		responses[k] = IncidentResponseFromDomain(i)
+	}
+	return responses
+}
+
+// ! StudentIncidentResponsesFromDomain vue élève : ni auteur, ni échanges internes
+// ! (motif d'escalade), ni notes des sanctions
+func StudentIncidentResponsesFromDomain(incidents []*domain.Incident) []IncidentResponse {
+	responses := IncidentResponsesFromDomain(incidents)
+	for k := range responses {
+		r := &responses[k]
+		r.ReportedBy, r.ReporterName = 0, ""
+		r.AutoEscalated, r.EscalatedBy, r.EscalationReason = false, nil, ""
+		r.ResolvedBy = nil
+		for s := range r.Sanctions {
+			r.Sanctions[s].Notes, r.Sanctions[s].IssuedBy = "", 0
+		}
+	}
+	return responses
+}
+
+// ! StudentDisciplineResponse historique disciplinaire d'un élève (admin)
+type StudentDisciplineResponse struct {
+	StudentID   int                       `json:"student_id"`
+	StudentName string                    `json:"student_name"`
+	Summary     *domain.DisciplineSummary `json:"summary"`
+	Incidents   []IncidentResponse        `json:"incidents"`
+}
+
+// ! ClassDisciplineSummaryResponse synthèse d'une classe sur [from, to]
+type ClassDisciplineSummaryResponse struct {
+	ClassID   int                       `json:"class_id"`
+	ClassName string                    `json:"class_name"`
+	From      string                    `json:"from,omitempty"`
+	To        string                    `json:"to,omitempty"`
+	Summary   *domain.DisciplineSummary `json:"summary"`
+}
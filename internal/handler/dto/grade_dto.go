@@ -0,0 +1,152 @@
+package dto
+
+import "educnet/internal/domain"
+
+// ! Dates au format YYYY-MM-DD
+
+type CreateTermRequest struct {
+	AcademicYear string `json:"academic_year"`
+	Name         string `json:"name"`
+	Position     int    `json:"position"`
+	StartDate    string `json:"start_date"`
+	EndDate      string `json:"end_date"`
+}
+
+type TermResponse struct {
+	ID           int    `json:"id"`
+	AcademicYear string `json:"academic_year"`
+	Name         string `json:"name"`
+	Position     int    `json:"position"`
+	StartDate    string `json:"start_date"`
+	EndDate      string `json:"end_date"`
+}
+
+func TermResponseFromDomain(term *domain.Term) TermResponse {
+	return TermResponse{
+		ID:           term.ID,
+		AcademicYear: term.AcademicYear,
+		Name:         term.Name,
+		Position:     term.Position,
+		StartDate:    term.StartDate.Format("2006-01-02"),
+		EndDate:      term.EndDate.Format("2006-01-02"),
+	}
+}
+
+func TermResponsesFromDomain(terms []*domain.Term) []TermResponse {
+	responses := make([]TermResponse, len(terms))
+	for i, term := range terms {
+		responses[i] = TermResponseFromDomain(term)
+	}
+	return responses
+}
+
+// ! CreateGradeRequest max_score / coefficient à 0 => /20, coef 1
+type CreateGradeRequest struct {
+	StudentID   int     `json:"student_id"`
+	ClassID     int     `json:"class_id"`
+	SubjectID   int     `json:"subject_id"`
+	TermID      int     `json:"term_id"`
+	Label       string  `json:"label"`
+	Score       float64 `json:"score"`
+	MaxScore    float64 `json:"max_score"`
+	Coefficient float64 `json:"coefficient"`
+	GradedOn    string  `json:"graded_on"`
+}
+
+type UpdateGradeRequest struct {
+	Label       string  `json:"label"`
+	Score       float64 `json:"score"`
+	MaxScore    float64 `json:"max_score"`
+	Coefficient float64 `json:"coefficient"`
+	GradedOn    string  `json:"graded_on"`
+}
+
+type GradeResponse struct {
+	ID          int     `json:"id"`
+	StudentID   int     `json:"student_id"`
+	ClassID     int     `json:"class_id"`
+	SubjectID   int     `json:"subject_id"`
+	TermID      int     `json:"term_id"`
+	TeacherID   int     `json:"teacher_id"`
+	Label       string  `json:"label"`
+	Score       float64 `json:"score"`
+	MaxScore    float64 `json:"max_score"`
+	Coefficient float64 `json:"coefficient"`
+	GradedOn    string  `json:"graded_on"`
+
+	EvaluationID *int `json:"evaluation_id,omitempty"` //! note saisie au carnet de notes
+}
+
+func GradeResponseFromDomain(grade *domain.Grade) GradeResponse {
+	return GradeResponse{
+		ID:          grade.ID,
+		StudentID:   grade.StudentID,
+		ClassID:     grade.ClassID,
+		SubjectID:   grade.SubjectID,
+		TermID:      grade.TermID,
+		TeacherID:   grade.TeacherID,
+		Label:       grade.Label,
+		Score:       grade.Score,
+		MaxScore:    grade.MaxScore,
+		Coefficient: grade.Coefficient,
+		GradedOn:    grade.GradedOn.Format("2006-01-02"),
+
+		EvaluationID: grade.EvaluationID,
+	}
+}
+
+func GradeResponsesFromDomain(grades []*domain.Grade) []GradeResponse {
+	responses := make([]GradeResponse, len(grades))
+	for i, grade := range grades {
+		responses[i] = GradeResponseFromDomain(grade)
+	}
+	return responses
+}
+
+// ! ReportCommentRequest subject_id absent = appréciation générale (admin)
+type ReportCommentRequest struct {
+	StudentID int    `json:"student_id"`
+	TermID    int    `json:"term_id"`
+	SubjectID *int   `json:"subject_id,omitempty"`
+	Comment   string `json:"comment"`
+}
+
+// ! RecordAttendanceRequest appel d'une classe pour un jour ("present" efface l'absence / le retard)
+type RecordAttendanceRequest struct {
+	ClassID int               `json:"class_id"`
+	Date    string            `json:"date"`
+	Records []AttendanceEntry `json:"records"`
+}
+
+type AttendanceEntry struct {
+	StudentID int    `json:"student_id"`
+	Status    string `json:"status"`
+	Justified bool   `json:"justified"`
+	Note      string `json:"note,omitempty"`
+}
+
+type AttendanceResponse struct {
+	ClassID int               `json:"class_id"`
+	Date    string            `json:"date"`
+	Records []AttendanceEntry `json:"records"` //! absents et retards uniquement
+}
+
+func AttendanceResponseFromDomain(classID int, date string, records []*domain.AttendanceRecord) *AttendanceResponse {
+	entries := make([]AttendanceEntry, len(records))
+	for i, rec := range records {
+		entries[i] = AttendanceEntry{
+			StudentID: rec.StudentID,
+			Status:    rec.Status,
+			Justified: rec.Justified,
+			Note:      rec.Note,
+		}
+	}
+	return &AttendanceResponse{ClassID: classID, Date: date, Records: entries}
+}
+
+// ! FileResponse document binaire généré (bulletin PDF, archive ZIP)
+type FileResponse struct {
+	FileName    string
+	ContentType string
+	Data        []byte
+}
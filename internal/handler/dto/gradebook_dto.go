@@ -0,0 +1,129 @@
+package dto
+
+import "educnet/internal/domain"
+
+// ! EvaluationRequest class_id / subject_id / term_id ignorés en modification ;
+// ! max_score / coefficient à 0 => /20, coef 1 ; graded_on YYYY-MM-DD (défaut : aujourd'hui)
+type EvaluationRequest struct {
+	ClassID     int     `json:"class_id"`
+	SubjectID   int     `json:"subject_id"`
+	TermID      int     `json:"term_id"`
+	Label       string  `json:"label"`
+	MaxScore    float64 `json:"max_score"`
+	Coefficient float64 `json:"coefficient"`
+	GradedOn    string  `json:"graded_on"`
+}
+
+type EvaluationResponse struct {
+	ID          int     `json:"id"`
+	ClassID     int     `json:"class_id"`
+	SubjectID   int     `json:"subject_id"`
+	TermID      int     `json:"term_id"`
+	Label       string  `json:"label"`
+	MaxScore    float64 `json:"max_score"`
+	Coefficient float64 `json:"coefficient"`
+	GradedOn    string  `json:"graded_on"`
+	CreatedBy   int     `json:"created_by,omitempty"`
+}
+
+func EvaluationResponseFromDomain(e *domain.Evaluation) EvaluationResponse {
+	return EvaluationResponse{
+		ID:          e.ID,
+		ClassID:     e.ClassID,
+		SubjectID:   e.SubjectID,
+		TermID:      e.TermID,
+		Label:       e.Label,
+		MaxScore:    e.MaxScore,
+		Coefficient: e.Coefficient,
+		GradedOn:    e.GradedOn.Format("2006-01-02"),
+		CreatedBy:   e.CreatedBy,
+	}
+}
+
+// ! GradebookQuery classe, matière et période du carnet (paramètres de requête)
+type GradebookQuery struct {
+	ClassID   int
+	SubjectID int
+	TermID    int
+}
+
+// ! GradebookResponse matrice élèves × évaluations ; rows[].cells dans l'ordre de evaluations
+type GradebookResponse struct {
+	ClassID     int                   `json:"class_id"`
+	SubjectID   int                   `json:"subject_id"`
+	TermID      int                   `json:"term_id"`
+	Evaluations []EvaluationResponse  `json:"evaluations"`
+	Rows        []domain.GradebookRow `json:"rows"`
+}
+
+func GradebookResponseFromDomain(query GradebookQuery, book *domain.Gradebook) *GradebookResponse {
+	evaluations := make([]EvaluationResponse, len(book.Evaluations))
+	for i, e := range book.Evaluations {
+		evaluations[i] = EvaluationResponseFromDomain(e)
+	}
+	return &GradebookResponse{
+		ClassID:     query.ClassID,
+		SubjectID:   query.SubjectID,
+		TermID:      query.TermID,
+		Evaluations: evaluations,
+		Rows:        book.Rows,
+	}
+}
+
+// ! GradebookCellRequest score null efface la note ; version : celle lue dans le carnet
+// ! (0 pour une cellule vide)
+type GradebookCellRequest struct {
+	StudentID    int      `json:"student_id"`
+	EvaluationID int      `json:"evaluation_id"`
+	Score        *float64 `json:"score"`
+	Version      int      `json:"version"`
+}
+
+// ! GradebookSaveRequest lot de cellules appliqué en tout ou rien
+type GradebookSaveRequest struct {
+	ClassID   int                    `json:"class_id"`
+	SubjectID int                    `json:"subject_id"`
+	TermID    int                    `json:"term_id"`
+	Cells     []GradebookCellRequest `json:"cells"`
+}
+
+// ! GradebookSaveResponse Saved false : rien n'a été écrit, Conflicts liste les cellules
+// ! modifiées entre-temps (état actuel) ; Gradebook : carnet à jour dans les deux cas
+type GradebookSaveResponse struct {
+	Saved     bool                       `json:"saved"`
+	Created   int                        `json:"created"`
+	Updated   int                        `json:"updated"`
+	Deleted   int                        `json:"deleted"`
+	Conflicts []domain.GradebookConflict `json:"conflicts,omitempty"`
+	Gradebook *GradebookResponse         `json:"gradebook"`
+}
+
+// ! GradebookImportRequest options d'import (champs du formulaire multipart)
+type GradebookImportRequest struct {
+	ClassID   int  `json:"class_id"`
+	SubjectID int  `json:"subject_id"`
+	TermID    int  `json:"term_id"`
+	DryRun    bool `json:"dry_run"`
+}
+
+// ! GradebookImportRow résultat de validation d'une ligne
+type GradebookImportRow struct {
+	Line        int      `json:"line"`
+	StudentID   int      `json:"student_id,omitempty"`
+	StudentName string   `json:"student_name,omitempty"`
+	Scores      int      `json:"scores"` //! cellules renseignées
+	Errors      []string `json:"errors,omitempty"`
+}
+
+// ! GradebookImportReport rapport de dry-run ou d'import
+type GradebookImportReport struct {
+	DryRun      bool                 `json:"dry_run"`
+	Committed   bool                 `json:"committed"`
+	Evaluations []string             `json:"evaluations"` //! colonnes reconnues
+	TotalRows   int                  `json:"total_rows"`
+	ValidRows   int                  `json:"valid_rows"`
+	InvalidRows int                  `json:"invalid_rows"`
+	Created     int                  `json:"created"`
+	Updated     int                  `json:"updated"`
+	Rows        []GradebookImportRow `json:"rows"`
+}
@@ -0,0 +1,50 @@
+package dto
+
+// ! ========== BULK USER IMPORT ==========
+
+// ! Password delivery modes
+const (
+	PasswordDeliveryEmail = "email" //! identifiants envoyés par email après commit
+	PasswordDeliverySheet = "sheet" //! identifiants retournés pour une fiche imprimable
+)
+
+// ! ImportUsersRequest options d'import (champs du formulaire multipart)
+type ImportUsersRequest struct {
+	Role             string            `json:"role"`              //! rôle par défaut: teacher | student
+	Mapping          map[string]string `json:"mapping,omitempty"` //! champ → en-tête de colonne
+	DryRun           bool              `json:"dry_run"`
+	PreApprove       bool              `json:"pre_approve"`
+	PasswordDelivery string            `json:"password_delivery"`
+}
+
+// ! ImportRowResult résultat de validation d'une ligne
+type ImportRowResult struct {
+	Line     int      `json:"line"`
+	Email    string   `json:"email"`
+	FullName string   `json:"full_name"`
+	Role     string   `json:"role"`
+	Class    string   `json:"class,omitempty"`
+	Subjects []string `json:"subjects,omitempty"`
+	Errors   []string `json:"errors,omitempty"`
+}
+
+// ! ImportCredential identifiants initiaux (fiche imprimable)
+type ImportCredential struct {
+	Email    string `json:"email"`
+	FullName string `json:"full_name"`
+	Role     string `json:"role"`
+	Class    string `json:"class,omitempty"`
+	Password string `json:"password"`
+}
+
+// ! ImportReport rapport de dry-run ou d'import
+type ImportReport struct {
+	DryRun      bool               `json:"dry_run"`
+	Committed   bool               `json:"committed"`
+	TotalRows   int                `json:"total_rows"`
+	ValidRows   int                `json:"valid_rows"`
+	InvalidRows int                `json:"invalid_rows"`
+	Created     int                `json:"created"`
+	Rows        []ImportRowResult  `json:"rows"`
+	Credentials []ImportCredential `json:"credentials,omitempty"`
+}
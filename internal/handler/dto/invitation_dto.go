@@ -0,0 +1,87 @@
+package dto
+
+import (
+	"educnet/internal/domain"
+	"time"
+)
+
+// ! ========== INVITATIONS (admin) ==========
+
+type CreateInvitationRequest struct {
+	Email         string `json:"email"`
+	Role          string `json:"role"`                  //! teacher | student
+	ClassID       int    `json:"class_id,omitempty"`    //! requis pour un élève
+	SubjectIDs    []int  `json:"subject_ids,omitempty"` //! requis pour un enseignant
+	ExpiresInDays int    `json:"expires_in_days"`       //! défaut 7, max 30
+	SendEmail     bool   `json:"send_email"`            //! sinon le lien est seulement retourné
+}
+
+type InvitationResponse struct {
+	ID             int        `json:"id"`
+	Email          string     `json:"email"`
+	Role           string     `json:"role"`
+	Status         string     `json:"status"`
+	ClassID        *int       `json:"class_id,omitempty"`
+	SubjectIDs     []int      `json:"subject_ids,omitempty"`
+	InvitedBy      int        `json:"invited_by"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	AcceptedAt     *time.Time `json:"accepted_at,omitempty"`
+	AcceptedUserID *int       `json:"accepted_user_id,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+
+	//! Renseignés uniquement à la création
+	InviteURL string `json:"invite_url,omitempty"`
+	Token     string `json:"token,omitempty"`
+	EmailSent bool   `json:"email_sent,omitempty"`
+}
+
+func InvitationResponseFromDomain(inv *domain.Invitation) *InvitationResponse {
+	return &InvitationResponse{
+		ID:             inv.ID,
+		Email:          inv.Email,
+		Role:           inv.Role,
+		Status:         inv.Status(),
+		ClassID:        inv.ClassID,
+		SubjectIDs:     inv.SubjectIDs,
+		InvitedBy:      inv.InvitedBy,
+		ExpiresAt:      inv.ExpiresAt,
+		AcceptedAt:     inv.AcceptedAt,
+		AcceptedUserID: inv.AcceptedUserID,
+		CreatedAt:      inv.CreatedAt,
+	}
+}
+
+type UpdateRegistrationModeRequest struct {
+	Mode string `json:"registration_mode"` //! open | invite_only | open_with_approval
+}
+
+// ! ========== INVITATIONS (public) ==========
+
+// ! InvitationPreviewResponse infos affichées sur la page d'acceptation
+type InvitationPreviewResponse struct {
+	SchoolName string    `json:"school_name"`
+	Email      string    `json:"email"`
+	Role       string    `json:"role"`
+	ClassName  string    `json:"class_name,omitempty"`
+	Subjects   []string  `json:"subjects,omitempty"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+type AcceptInvitationRequest struct {
+	Token     string `json:"token"`
+	Password  string `json:"password"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Phone     string `json:"phone"`
+}
+
+type AcceptInvitationResponse struct {
+	UserID     int    `json:"user_id"`
+	Email      string `json:"email"`
+	FullName   string `json:"full_name"`
+	SchoolID   int    `json:"school_id"`
+	Role       string `json:"role"`
+	Status     string `json:"status"`
+	Waitlisted bool   `json:"waitlisted,omitempty"`
+	Message    string `json:"message"`
+}
@@ -0,0 +1,61 @@
+package dto
+
+import "educnet/internal/domain"
+
+// ! RetentionPolicyRequest message_retention_days null = conservation illimitée
+type RetentionPolicyRequest struct {
+	MessageRetentionDays *int `json:"message_retention_days"`
+}
+
+type RetentionPolicyResponse struct {
+	SchoolID             int    `json:"school_id"`
+	MessageRetentionDays *int   `json:"message_retention_days"`
+	UpdatedAt            string `json:"updated_at,omitempty"`
+}
+
+func RetentionPolicyResponseFromDomain(policy *domain.RetentionPolicy) *RetentionPolicyResponse {
+	resp := &RetentionPolicyResponse{
+		SchoolID:             policy.SchoolID,
+		MessageRetentionDays: policy.MessageRetentionDays,
+	}
+	if !policy.UpdatedAt.IsZero() {
+		resp.UpdatedAt = policy.UpdatedAt.Format("2006-01-02 15:04:05")
+	}
+	return resp
+}
+
+// ! PersonalDataExport contenu de data.json dans l'archive d'export RGPD
+type PersonalDataExport struct {
+	GeneratedAt string               `json:"generated_at"`
+	Profile     ProfileResponse      `json:"profile"`
+	School      *PersonalDataSchool  `json:"school,omitempty"`
+	Enrollments []EnrollmentResponse `json:"enrollments"`
+	Subjects    []SubjectInfo        `json:"subjects"`
+	Messages    []PersonalMessage    `json:"messages"`
+	Grades      []GradeResponse      `json:"grades"`
+	Attendance  []PersonalAttendance `json:"attendance"`
+	Files       []string             `json:"files"` //! chemins dans l'archive
+}
+
+type PersonalDataSchool struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type PersonalMessage struct {
+	ID          int64  `json:"id"`
+	ClassID     int    `json:"class_id"`
+	ClassName   string `json:"class_name"`
+	MessageType string `json:"message_type"`
+	Content     string `json:"content"`
+	FileURL     string `json:"file_url,omitempty"`
+	CreatedAt   string `json:"created_at"`
+}
+
+type PersonalAttendance struct {
+	Date      string `json:"date"`
+	ClassID   int    `json:"class_id"`
+	Status    string `json:"status"`
+	Justified bool   `json:"justified"`
+	Note      string `json:"note,omitempty"`
+}
@@ -1,6 +1,9 @@
 package dto
 
-import "educnet/internal/domain"
+import (
+	"educnet/internal/domain"
+	"time"
+)
 
 // UpdateProfileRequest pour modifier le profil
 type UpdateProfileRequest struct {
@@ -30,6 +33,101 @@ type ProfileResponse struct {
 	SchoolID  int    `json:"school_id"`
 	AvatarURL string `json:"avatar_url,omitempty"`
 	CreatedAt string `json:"created_at"`
+
+	//! Profil étendu, limité aux champs que le lecteur peut voir
+	StudentProfile *StudentProfileResponse   `json:"student_profile,omitempty"`
+	TeacherProfile *TeacherProfileResponse   `json:"teacher_profile,omitempty"`
+	Documents      []ProfileDocumentResponse `json:"documents,omitempty"`
+}
+
+// ! ExtendedProfileRequest champs absents (null) inchangés ; dates YYYY-MM-DD ("" pour effacer).
+// ! Élève : date_of_birth, address, student_number, medical_notes, guardians (liste complète).
+// ! Enseignant : qualifications, hire_date. Un champ non modifiable par l'appelant => 403
+type ExtendedProfileRequest struct {
+	DateOfBirth    *string            `json:"date_of_birth"`
+	Address        *string            `json:"address"`
+	StudentNumber  *string            `json:"student_number"`
+	MedicalNotes   *string            `json:"medical_notes"`
+	Guardians      *[]GuardianRequest `json:"guardians"`
+	Qualifications *[]string          `json:"qualifications"`
+	HireDate       *string            `json:"hire_date"`
+}
+
+type GuardianRequest struct {
+	FullName           string `json:"full_name"`
+	Relationship       string `json:"relationship"`
+	Phone              string `json:"phone"`
+	Email              string `json:"email"`
+	IsLegalGuardian    bool   `json:"is_legal_guardian"`
+	IsEmergencyContact bool   `json:"is_emergency_contact"`
+}
+
+type StudentProfileResponse struct {
+	DateOfBirth   string             `json:"date_of_birth,omitempty"`
+	Address       string             `json:"address,omitempty"`
+	StudentNumber string             `json:"student_number,omitempty"`
+	MedicalNotes  string             `json:"medical_notes,omitempty"`
+	Guardians     []*domain.Guardian `json:"guardians,omitempty"`
+}
+
+func StudentProfileResponseFromDomain(p *domain.StudentProfile) *StudentProfileResponse {
+	return &StudentProfileResponse{
+		DateOfBirth:   formatDay(p.DateOfBirth),
+		Address:       p.Address,
+		StudentNumber: p.StudentNumber,
+		MedicalNotes:  p.MedicalNotes,
+		Guardians:     p.Guardians,
+	}
+}
+
+type TeacherProfileResponse struct {
+	Qualifications []string `json:"qualifications"`
+	HireDate       string   `json:"hire_date,omitempty"`
+}
+
+func TeacherProfileResponseFromDomain(p *domain.TeacherProfile) *TeacherProfileResponse {
+	return &TeacherProfileResponse{Qualifications: p.Qualifications, HireDate: formatDay(p.HireDate)}
+}
+
+// ! ProfileDocumentRequest champs du formulaire multipart (avec file)
+type ProfileDocumentRequest struct {
+	Kind  string
+	Title string
+}
+
+type ProfileDocumentResponse struct {
+	ID          int       `json:"id"`
+	Kind        string    `json:"kind"`
+	Title       string    `json:"title"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	UploadedBy  int       `json:"uploaded_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func ProfileDocumentResponsesFromDomain(docs []*domain.ProfileDocument) []ProfileDocumentResponse {
+	responses := make([]ProfileDocumentResponse, len(docs))
+	for i, doc := range docs {
+		responses[i] = ProfileDocumentResponse{
+			ID:          doc.ID,
+			Kind:        doc.Kind,
+			Title:       doc.Title,
+			FileName:    doc.FileName,
+			ContentType: doc.ContentType,
+			Size:        doc.Size,
+			UploadedBy:  doc.UploadedBy,
+			CreatedAt:   doc.CreatedAt,
+		}
+	}
+	return responses
+}
+
+func formatDay(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("2006-01-02")
 }
 
 // TeacherSubjectsResponse liste des matières d'un enseignant
@@ -0,0 +1,201 @@
+package dto
+
+import (
+	"educnet/internal/domain"
+	"time"
+)
+
+// ! Dates des quiz au format RFC 3339 (2025-10-06T08:00:00Z)
+
+// ! QuestionRequest answer_key selon le type :
+// ! choices (index) pour single/multiple_choice, bool, number + tolerance, texts (réponses acceptées)
+type QuestionRequest struct {
+	SubjectID int              `json:"subject_id"`
+	Type      string           `json:"type"`
+	Prompt    string           `json:"prompt"`
+	Choices   []string         `json:"choices"`
+	AnswerKey domain.AnswerKey `json:"answer_key"`
+	Points    float64          `json:"points"`
+}
+
+// ! QuestionResponse vue enseignant (avec la clé de correction)
+type QuestionResponse struct {
+	ID        int              `json:"id"`
+	SubjectID int              `json:"subject_id"`
+	AuthorID  int              `json:"author_id"`
+	Type      string           `json:"type"`
+	Prompt    string           `json:"prompt"`
+	Choices   []string         `json:"choices"`
+	AnswerKey domain.AnswerKey `json:"answer_key"`
+	Points    float64          `json:"points"`
+}
+
+func QuestionResponseFromDomain(q *domain.Question) QuestionResponse {
+	return QuestionResponse{
+		ID:        q.ID,
+		SubjectID: q.SubjectID,
+		AuthorID:  q.AuthorID,
+		Type:      q.Type,
+		Prompt:    q.Prompt,
+		Choices:   q.Choices,
+		AnswerKey: q.Answer,
+		Points:    q.Points,
+	}
+}
+
+func QuestionResponsesFromDomain(questions []*domain.Question) []QuestionResponse {
+	responses := make([]QuestionResponse, len(questions))
+	for i, q := range questions {
+		responses[i] = QuestionResponseFromDomain(q)
+	}
+	return responses
+}
+
+// ! QuizQuestionView vue élève : jamais de clé de correction
+type QuizQuestionView struct {
+	ID      int      `json:"id"`
+	Type    string   `json:"type"`
+	Prompt  string   `json:"prompt"`
+	Choices []string `json:"choices"`
+	Points  float64  `json:"points"`
+}
+
+func QuizQuestionViewsFromDomain(questions []*domain.Question) []QuizQuestionView {
+	views := make([]QuizQuestionView, len(questions))
+	for i, q := range questions {
+		views[i] = QuizQuestionView{ID: q.ID, Type: q.Type, Prompt: q.Prompt, Choices: q.Choices, Points: q.Points}
+	}
+	return views
+}
+
+// ! CreateQuizRequest max_attempts / coefficient à 0 => 1 ; question_ids dans l'ordre du quiz
+type CreateQuizRequest struct {
+	SubjectID       int     `json:"subject_id"`
+	ClassID         int     `json:"class_id"`
+	TermID          int     `json:"term_id"`
+	Title           string  `json:"title"`
+	Description     string  `json:"description"`
+	DurationMinutes int     `json:"duration_minutes"`
+	OpensAt         string  `json:"opens_at"`
+	ClosesAt        string  `json:"closes_at"`
+	MaxAttempts     int     `json:"max_attempts"`
+	Coefficient     float64 `json:"coefficient"`
+	QuestionIDs     []int   `json:"question_ids"`
+}
+
+type QuizResponse struct {
+	ID              int                `json:"id"`
+	SubjectID       int                `json:"subject_id"`
+	ClassID         int                `json:"class_id"`
+	TermID          int                `json:"term_id"`
+	TeacherID       int                `json:"teacher_id"`
+	Title           string             `json:"title"`
+	Description     string             `json:"description"`
+	DurationMinutes int                `json:"duration_minutes"`
+	OpensAt         time.Time          `json:"opens_at"`
+	ClosesAt        time.Time          `json:"closes_at"`
+	MaxAttempts     int                `json:"max_attempts"`
+	Coefficient     float64            `json:"coefficient"`
+	Status          string             `json:"status"` //! upcoming | open | closed
+	Questions       []QuestionResponse `json:"questions,omitempty"`
+}
+
+func QuizResponseFromDomain(quiz *domain.Quiz, now time.Time) QuizResponse {
+	return QuizResponse{
+		ID:              quiz.ID,
+		SubjectID:       quiz.SubjectID,
+		ClassID:         quiz.ClassID,
+		TermID:          quiz.TermID,
+		TeacherID:       quiz.TeacherID,
+		Title:           quiz.Title,
+		Description:     quiz.Description,
+		DurationMinutes: quiz.DurationMinutes,
+		OpensAt:         quiz.OpensAt,
+		ClosesAt:        quiz.ClosesAt,
+		MaxAttempts:     quiz.MaxAttempts,
+		Coefficient:     quiz.Coefficient,
+		Status:          QuizStatus(quiz, now),
+	}
+}
+
+func QuizResponsesFromDomain(quizzes []*domain.Quiz, now time.Time) []QuizResponse {
+	responses := make([]QuizResponse, len(quizzes))
+	for i, quiz := range quizzes {
+		responses[i] = QuizResponseFromDomain(quiz, now)
+	}
+	return responses
+}
+
+func QuizStatus(quiz *domain.Quiz, now time.Time) string {
+	switch {
+	case quiz.IsClosed(now):
+		return "closed"
+	case quiz.IsOpen(now):
+		return "open"
+	}
+	return "upcoming"
+}
+
+// ! StudentQuizResponse quiz vu par l'élève ; Corrections uniquement après la clôture
+type StudentQuizResponse struct {
+	QuizResponse
+	AttemptsUsed int                `json:"attempts_used"`
+	BestScore    *float64           `json:"best_score,omitempty"`
+	MaxScore     float64            `json:"max_score,omitempty"`
+	Attempts     []AttemptResponse  `json:"attempts,omitempty"`
+	Corrections  []QuestionResponse `json:"corrections,omitempty"`
+}
+
+// ! AttemptResponse Questions renseignées au démarrage / à la reprise,
+// ! Answers uniquement pour l'enseignant ou après la clôture
+type AttemptResponse struct {
+	ID               int                `json:"id"`
+	QuizID           int                `json:"quiz_id"`
+	StudentID        int                `json:"student_id"`
+	Number           int                `json:"number"`
+	StartedAt        time.Time          `json:"started_at"`
+	DeadlineAt       time.Time          `json:"deadline_at"`
+	RemainingSeconds int                `json:"remaining_seconds"`
+	SubmittedAt      *time.Time         `json:"submitted_at,omitempty"`
+	Score            *float64           `json:"score,omitempty"`
+	MaxScore         float64            `json:"max_score"`
+	Expired          bool               `json:"expired,omitempty"` //! rendue hors délai : 0
+	Questions        []QuizQuestionView `json:"questions,omitempty"`
+	Answers          []domain.Answer    `json:"answers,omitempty"`
+}
+
+func AttemptResponseFromDomain(a *domain.QuizAttempt, now time.Time, withAnswers bool) AttemptResponse {
+	response := AttemptResponse{
+		ID:          a.ID,
+		QuizID:      a.QuizID,
+		StudentID:   a.StudentID,
+		Number:      a.Number,
+		StartedAt:   a.StartedAt,
+		DeadlineAt:  a.DeadlineAt,
+		SubmittedAt: a.SubmittedAt,
+		MaxScore:    a.MaxScore,
+	}
+	if a.IsSubmitted() {
+		score := a.Score
+		response.Score = &score
+		response.Expired = a.SubmittedAt.After(a.DeadlineAt.Add(domain.QuizSubmitGrace))
+	} else if remaining := a.DeadlineAt.Sub(now); remaining > 0 {
+		response.RemainingSeconds = int(remaining.Seconds())
+	}
+	if withAnswers {
+		response.Answers = a.Answers
+	}
+	return response
+}
+
+func AttemptResponsesFromDomain(attempts []*domain.QuizAttempt, now time.Time, withAnswers bool) []AttemptResponse {
+	responses := make([]AttemptResponse, len(attempts))
+	for i, a := range attempts {
+		responses[i] = AttemptResponseFromDomain(a, now, withAnswers)
+	}
+	return responses
+}
+
+type SubmitAttemptRequest struct {
+	Answers []domain.Answer `json:"answers"`
+}
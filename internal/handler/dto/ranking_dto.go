@@ -0,0 +1,22 @@
+package dto
+
+import "educnet/internal/domain"
+
+// ! RankingSettingsRequest réglage de l'école (admin)
+type RankingSettingsRequest struct {
+	StudentRankVisible bool `json:"student_rank_visible"`
+}
+
+// ! ClassRankingResponse classements et statistiques d'une classe (enseignants et admins)
+type ClassRankingResponse struct {
+	ClassName string `json:"class_name"`
+	TermName  string `json:"term_name"`
+	*domain.ClassRanking
+}
+
+// ! StudentRankingResponse moyennes de l'élève, rangs selon le réglage de l'école
+type StudentRankingResponse struct {
+	ClassName string `json:"class_name"`
+	TermName  string `json:"term_name"`
+	*domain.StudentRanking
+}
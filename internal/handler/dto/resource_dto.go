@@ -0,0 +1,151 @@
+package dto
+
+import (
+	"educnet/internal/domain"
+	"io"
+	"time"
+)
+
+// ! CreateFolderRequest parent_id absent => dossier racine de la matière
+type CreateFolderRequest struct {
+	SubjectID int    `json:"subject_id"`
+	ParentID  *int   `json:"parent_id"`
+	Name      string `json:"name"`
+}
+
+type ResourceFolderResponse struct {
+	ID        int       `json:"id"`
+	SubjectID int       `json:"subject_id"`
+	ParentID  *int      `json:"parent_id,omitempty"`
+	Name      string    `json:"name"`
+	CreatedBy int       `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func ResourceFolderResponsesFromDomain(folders []*domain.ResourceFolder) []ResourceFolderResponse {
+	responses := make([]ResourceFolderResponse, len(folders))
+	for i, f := range folders {
+		responses[i] = ResourceFolderResponse{
+			ID:        f.ID,
+			SubjectID: f.SubjectID,
+			ParentID:  f.ParentID,
+			Name:      f.Name,
+			CreatedBy: f.CreatedBy,
+			CreatedAt: f.CreatedAt,
+		}
+	}
+	return responses
+}
+
+// ! ResourceRequest création (multipart, champ "file" pour kind=file, tags séparés par des virgules)
+// ! ou modification (JSON, subject_id et kind ignorés). class_id ou level obligatoire.
+type ResourceRequest struct {
+	SubjectID   int      `json:"subject_id"`
+	ClassID     *int     `json:"class_id"`
+	Level       string   `json:"level"`
+	Chapter     string   `json:"chapter"`
+	FolderID    *int     `json:"folder_id"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Kind        string   `json:"kind"`
+	URL         string   `json:"url"`
+	Tags        []string `json:"tags"`
+}
+
+// ! ResourceQuery filtres de la liste (query string), tous optionnels
+type ResourceQuery struct {
+	SubjectID int
+	ClassID   int
+	Level     string
+	Chapter   string
+	FolderID  int
+	Tag       string
+	Query     string
+}
+
+// ! UploadedFile fichier reçu en multipart, lu une seule fois par le usecase
+type UploadedFile struct {
+	FileName    string
+	ContentType string
+	Size        int64
+	Content     io.Reader
+}
+
+type ResourceVersionResponse struct {
+	Version     int       `json:"version"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	UploadedBy  int       `json:"uploaded_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func ResourceVersionResponsesFromDomain(versions []*domain.ResourceVersion) []ResourceVersionResponse {
+	responses := make([]ResourceVersionResponse, len(versions))
+	for i, v := range versions {
+		responses[i] = ResourceVersionResponse{
+			Version:     v.Version,
+			FileName:    v.FileName,
+			ContentType: v.ContentType,
+			Size:        v.Size,
+			UploadedBy:  v.UploadedBy,
+			CreatedAt:   v.CreatedAt,
+		}
+	}
+	return responses
+}
+
+type ResourceResponse struct {
+	ID             int                      `json:"id"`
+	SubjectID      int                      `json:"subject_id"`
+	ClassID        *int                     `json:"class_id,omitempty"`
+	Level          string                   `json:"level,omitempty"`
+	Chapter        string                   `json:"chapter,omitempty"`
+	FolderID       *int                     `json:"folder_id,omitempty"`
+	Title          string                   `json:"title"`
+	Description    string                   `json:"description,omitempty"`
+	Kind           string                   `json:"kind"`
+	URL            string                   `json:"url,omitempty"`
+	Tags           []string                 `json:"tags"`
+	CurrentVersion int                      `json:"current_version,omitempty"`
+	DownloadCount  int                      `json:"download_count"`
+	CreatedBy      int                      `json:"created_by"`
+	CreatedAt      time.Time                `json:"created_at"`
+	UpdatedAt      time.Time                `json:"updated_at"`
+	File           *ResourceVersionResponse `json:"file,omitempty"` //! version courante (création / remplacement)
+}
+
+func ResourceResponseFromDomain(r *domain.Resource) ResourceResponse {
+	return ResourceResponse{
+		ID:             r.ID,
+		SubjectID:      r.SubjectID,
+		ClassID:        r.ClassID,
+		Level:          r.Level,
+		Chapter:        r.Chapter,
+		FolderID:       r.FolderID,
+		Title:          r.Title,
+		Description:    r.Description,
+		Kind:           r.Kind,
+		URL:            r.URL,
+		Tags:           r.Tags,
+		CurrentVersion: r.CurrentVersion,
+		DownloadCount:  r.DownloadCount,
+		CreatedBy:      r.CreatedBy,
+		CreatedAt:      r.CreatedAt,
+		UpdatedAt:      r.UpdatedAt,
+	}
+}
+
+func ResourceResponsesFromDomain(resources []*domain.Resource) []ResourceResponse {
+	responses := make([]ResourceResponse, len(resources))
+	for i, r := range resources {
+		responses[i] = ResourceResponseFromDomain(r)
+	}
+	return responses
+}
+
+// ! ResourceDownload soit un lien externe (redirection), soit le contenu du fichier
+type ResourceDownload struct {
+	URL  string
+	File *FileResponse
+}
@@ -0,0 +1,139 @@
+package dto
+
+import (
+	"educnet/internal/domain"
+	"time"
+)
+
+// ! RoomRequest requires_approval absent : salles spécialisées (labo, gymnase...) soumises à validation.
+// ! is_active absent : inchangé (actif à la création)
+type RoomRequest struct {
+	Name             string   `json:"name"`
+	Type             string   `json:"type"`
+	Capacity         int      `json:"capacity"`
+	Equipment        []string `json:"equipment"`
+	RequiresApproval *bool    `json:"requires_approval"`
+	IsActive         *bool    `json:"is_active"`
+}
+
+type EquipmentRequest struct {
+	Name             string `json:"name"`
+	Description      string `json:"description"`
+	RequiresApproval bool   `json:"requires_approval"`
+	IsActive         *bool  `json:"is_active"`
+}
+
+// ! BookingRequest room_id OU equipment_id ; dates RFC 3339 (2026-09-07T08:00:00Z)
+type BookingRequest struct {
+	RoomID      *int   `json:"room_id"`
+	EquipmentID *int   `json:"equipment_id"`
+	Purpose     string `json:"purpose"`
+	StartsAt    string `json:"starts_at"`
+	EndsAt      string `json:"ends_at"`
+}
+
+type BookingReviewRequest struct {
+	Approve bool   `json:"approve"`
+	Note    string `json:"note"`
+}
+
+// ! BookingQuery filtres de la liste admin ; from/to au format YYYY-MM-DD
+type BookingQuery struct {
+	Status string
+	RoomID int
+	From   string
+	To     string
+}
+
+// ! AvailabilityQuery créneau [from, to) en RFC 3339 ; equipment : éléments requis dans la salle
+type AvailabilityQuery struct {
+	From        string
+	To          string
+	Type        string
+	MinCapacity int
+	Equipment   []string
+}
+
+type RoomResponse struct {
+	ID               int       `json:"id"`
+	Name             string    `json:"name"`
+	Type             string    `json:"type"`
+	Capacity         int       `json:"capacity"`
+	Equipment        []string  `json:"equipment"`
+	RequiresApproval bool      `json:"requires_approval"`
+	IsActive         bool      `json:"is_active"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+func RoomResponseFromDomain(r *domain.Room) RoomResponse {
+	return RoomResponse{
+		ID:               r.ID,
+		Name:             r.Name,
+		Type:             r.Type,
+		Capacity:         r.Capacity,
+		Equipment:        r.Equipment,
+		RequiresApproval: r.RequiresApproval,
+		IsActive:         r.IsActive,
+		UpdatedAt:        r.UpdatedAt,
+	}
+}
+
+type EquipmentResponse struct {
+	ID               int       `json:"id"`
+	Name             string    `json:"name"`
+	Description      string    `json:"description,omitempty"`
+	RequiresApproval bool      `json:"requires_approval"`
+	IsActive         bool      `json:"is_active"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+func EquipmentResponseFromDomain(e *domain.Equipment) EquipmentResponse {
+	return EquipmentResponse{
+		ID:               e.ID,
+		Name:             e.Name,
+		Description:      e.Description,
+		RequiresApproval: e.RequiresApproval,
+		IsActive:         e.IsActive,
+		UpdatedAt:        e.UpdatedAt,
+	}
+}
+
+type BookingResponse struct {
+	ID          int        `json:"id"`
+	RoomID      *int       `json:"room_id,omitempty"`
+	EquipmentID *int       `json:"equipment_id,omitempty"`
+	UserID      int        `json:"user_id"`
+	Purpose     string     `json:"purpose,omitempty"`
+	StartsAt    time.Time  `json:"starts_at"`
+	EndsAt      time.Time  `json:"ends_at"`
+	Status      string     `json:"status"`
+	ReviewedBy  *int       `json:"reviewed_by,omitempty"`
+	ReviewedAt  *time.Time `json:"reviewed_at,omitempty"`
+	ReviewNote  string     `json:"review_note,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+func BookingResponseFromDomain(b *domain.Booking) BookingResponse {
+	return BookingResponse{
+		ID:          b.ID,
+		RoomID:      b.RoomID,
+		EquipmentID: b.EquipmentID,
+		UserID:      b.UserID,
+		Purpose:     b.Purpose,
+		StartsAt:    b.StartsAt,
+		EndsAt:      b.EndsAt,
+		Status:      b.Status,
+		ReviewedBy:  b.ReviewedBy,
+		ReviewedAt:  b.ReviewedAt,
+		ReviewNote:  b.ReviewNote,
+		CreatedAt:   b.CreatedAt,
+	}
+}
+
+// ! AvailabilityResponse salles et équipements libres sur tout le créneau
+type AvailabilityResponse struct {
+	From      time.Time           `json:"from"`
+	To        time.Time           `json:"to"`
+	Rooms     []RoomResponse      `json:"rooms"`
+	Equipment []EquipmentResponse `json:"equipment"`
+}
@@ -32,6 +32,8 @@ type SchoolDTO struct {
 	Status      string    `json:"status"`
 	AdminUserID *int      `json:"admin_user_id,omitempty"`
 	CreatedAt   time.Time `json:"created_at"`
+
+	RegistrationMode string `json:"registration_mode"`
 }
 
 func SchoolDTOFromDomain(school *domain.School) *SchoolDTO {
@@ -44,6 +46,8 @@ func SchoolDTOFromDomain(school *domain.School) *SchoolDTO {
 		Status:      school.Status,
 		AdminUserID: school.AdminUserID,
 		CreatedAt:   school.CreatedAt,
+
+		RegistrationMode: school.RegistrationMode,
 	}
 }
 
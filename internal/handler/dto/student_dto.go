@@ -11,11 +11,12 @@ type StudentRegistrationRequest struct {
 }
 
 type StudentRegistrationResponse struct {
-	UserID    int    `json:"user_id"`
-	Email     string `json:"email"`
-	FullName  string `json:"full_name"`
-	SchoolID  int    `json:"school_id"`
-	Status    string `json:"status"`
-	ClassName string `json:"class_name"`
-	Message   string `json:"message"`
+	UserID     int    `json:"user_id"`
+	Email      string `json:"email"`
+	FullName   string `json:"full_name"`
+	SchoolID   int    `json:"school_id"`
+	Status     string `json:"status"`
+	ClassName  string `json:"class_name"`
+	Waitlisted bool   `json:"waitlisted,omitempty"`
+	Message    string `json:"message"`
 }
@@ -0,0 +1,80 @@
+package dto
+
+import (
+	"educnet/internal/domain"
+	"time"
+)
+
+// ! SchoolStatsResponse école + statistiques d'usage (console super-admin)
+type SchoolStatsResponse struct {
+	School        SchoolDTO  `json:"school"`
+	Email         string     `json:"email"`
+	TotalUsers    int        `json:"total_users"`
+	TotalAdmins   int        `json:"total_admins"`
+	TotalTeachers int        `json:"total_teachers"`
+	TotalStudents int        `json:"total_students"`
+	PendingUsers  int        `json:"pending_users"`
+	TotalClasses  int        `json:"total_classes"`
+	TotalSubjects int        `json:"total_subjects"`
+	TotalMessages int        `json:"total_messages"`
+	LastActivity  *time.Time `json:"last_activity,omitempty"`
+}
+
+func SchoolStatsResponseFromDomain(stats *domain.SchoolStats) SchoolStatsResponse {
+	return SchoolStatsResponse{
+		School:        *SchoolDTOFromDomain(stats.School),
+		Email:         stats.School.Email,
+		TotalUsers:    stats.TotalUsers,
+		TotalAdmins:   stats.TotalAdmins,
+		TotalTeachers: stats.TotalTeachers,
+		TotalStudents: stats.TotalStudents,
+		PendingUsers:  stats.PendingUsers,
+		TotalClasses:  stats.TotalClasses,
+		TotalSubjects: stats.TotalSubjects,
+		TotalMessages: stats.TotalMessages,
+		LastActivity:  stats.LastActivity,
+	}
+}
+
+// ! SchoolDetailResponse détail d'une école avec son admin et son audit
+type SchoolDetailResponse struct {
+	SchoolStatsResponse
+	Admin     *UserDTO           `json:"admin,omitempty"`
+	AuditLogs []*domain.AuditLog `json:"audit_logs"`
+}
+
+type SuspendSchoolRequest struct {
+	Reason string `json:"reason"`
+}
+
+type ImpersonateRequest struct {
+	Reason string `json:"reason"`
+}
+
+// ! ImpersonationResponse token court pour agir en tant qu'admin d'école
+type ImpersonationResponse struct {
+	AccessToken    string   `json:"access_token"`
+	TokenType      string   `json:"token_type"`
+	ExpiresIn      int      `json:"expires_in"` //! en secondes
+	User           UserInfo `json:"user"`
+	ImpersonatorID int      `json:"impersonator_id"`
+}
+
+type TransferOwnershipRequest struct {
+	NewAdminUserID int    `json:"new_admin_user_id"`
+	Reason         string `json:"reason,omitempty"`
+}
+
+func UserDTOFromDomain(user *domain.User) *UserDTO {
+	return &UserDTO{
+		ID:        user.ID,
+		SchoolID:  user.SchoolID,
+		Email:     user.Email,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Phone:     user.Phone,
+		Role:      user.Role,
+		Status:    user.Status,
+		CreatedAt: user.CreatedAt,
+	}
+}
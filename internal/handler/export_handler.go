@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"educnet/internal/export"
+	"educnet/internal/middleware"
+	"educnet/internal/usecase"
+	"educnet/internal/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// ! ExportHandler exports CSV / XLSX (?format=csv|xlsx, CSV par défaut)
+type ExportHandler struct {
+	exportUC usecase.ExportUseCase
+}
+
+func NewExportHandler(exportUC usecase.ExportUseCase) *ExportHandler {
+	return &ExportHandler{exportUC: exportUC}
+}
+
+// ! GET /api/admin/exports/users?format=xlsx&role=student&status=approved&student_number=2026-
+func (h *ExportHandler) ExportUsers(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	filters := map[string]string{
+		"role":           r.URL.Query().Get("role"),
+		"status":         r.URL.Query().Get("status"),
+		"student_number": r.URL.Query().Get("student_number"),
+	}
+
+	table, err := h.exportUC.Users(r.Context(), claims.UserID, filters)
+	writeExport(w, r, table, err)
+}
+
+// ! GET /api/admin/exports/classes/{id}/roster
+func (h *ExportHandler) ExportClassRoster(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	classID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid class ID")
+		return
+	}
+
+	table, err := h.exportUC.ClassRoster(r.Context(), claims.UserID, classID)
+	writeExport(w, r, table, err)
+}
+
+// ! GET /api/admin/exports/teacher-subjects
+func (h *ExportHandler) ExportTeacherSubjects(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	table, err := h.exportUC.TeacherSubjects(r.Context(), claims.UserID)
+	writeExport(w, r, table, err)
+}
+
+// ! GET /api/admin/exports/classes/{id}/messages
+func (h *ExportHandler) ExportClassMessages(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	classID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid class ID")
+		return
+	}
+
+	table, err := h.exportUC.ClassMessages(r.Context(), claims.UserID, classID)
+	writeExport(w, r, table, err)
+}
+
+// ! writeExport erreurs de préparation en JSON ; une fois l'en-tête envoyé, les lignes
+// ! sont streamées (une erreur en cours de route ne peut plus qu'interrompre le fichier)
+func writeExport(w http.ResponseWriter, r *http.Request, table *export.Table, err error) {
+	format, formatErr := export.ParseFormat(r.URL.Query().Get("format"))
+	if formatErr != nil {
+		utils.HandleUseCaseError(w, formatErr)
+		return
+	}
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	w = middleware.StreamingWriter(w)
+	filename := fmt.Sprintf("%s-%s%s", table.Name, time.Now().Format("20060102"), format.Extension())
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	w.WriteHeader(http.StatusOK)
+
+	if err := export.WriteTable(r.Context(), w, format, table); err != nil {
+		slog.ErrorContext(r.Context(), "export interrupted", "file", filename, "error", err)
+	}
+}
@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"educnet/internal/handler/dto"
+	"educnet/internal/middleware"
+	"educnet/internal/usecase"
+	"educnet/internal/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// ! GradeHandler périodes, notes, appréciations et absences
+type GradeHandler struct {
+	gradeUC usecase.GradeUseCase
+}
+
+func NewGradeHandler(gradeUC usecase.GradeUseCase) *GradeHandler {
+	return &GradeHandler{gradeUC: gradeUC}
+}
+
+// ! POST /api/admin/terms
+func (h *GradeHandler) CreateTerm(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	var req dto.CreateTermRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	term, err := h.gradeUC.CreateTerm(r.Context(), claims.UserID, &req)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.Created(w, "Term created successfully", term)
+}
+
+// ! GET /api/{admin|teacher|student}/terms?academic_year=2025-2026
+func (h *GradeHandler) ListTerms(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	terms, err := h.gradeUC.ListTerms(r.Context(), claims.UserID, r.URL.Query().Get("academic_year"))
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Terms retrieved", terms)
+}
+
+// ! POST /api/teacher/grades
+func (h *GradeHandler) RecordGrade(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	var req dto.CreateGradeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	grade, err := h.gradeUC.RecordGrade(r.Context(), claims.UserID, &req)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.Created(w, "Grade recorded successfully", grade)
+}
+
+// ! GET /api/teacher/grades?class_id=1&term_id=2
+func (h *GradeHandler) ListGrades(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	classID, err := strconv.Atoi(r.URL.Query().Get("class_id"))
+	if err != nil {
+		utils.BadRequest(w, "Invalid class_id")
+		return
+	}
+	termID, err := strconv.Atoi(r.URL.Query().Get("term_id"))
+	if err != nil {
+		utils.BadRequest(w, "Invalid term_id")
+		return
+	}
+
+	grades, err := h.gradeUC.ListGrades(r.Context(), claims.UserID, classID, termID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Grades retrieved", grades)
+}
+
+// ! PUT /api/teacher/grades/{id}
+func (h *GradeHandler) UpdateGrade(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	gradeID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid grade ID")
+		return
+	}
+
+	var req dto.UpdateGradeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	grade, err := h.gradeUC.UpdateGrade(r.Context(), claims.UserID, gradeID, &req)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Grade updated successfully", grade)
+}
+
+// ! DELETE /api/teacher/grades/{id}
+func (h *GradeHandler) DeleteGrade(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	gradeID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid grade ID")
+		return
+	}
+
+	if err := h.gradeUC.DeleteGrade(r.Context(), claims.UserID, gradeID); err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Grade deleted successfully", nil)
+}
+
+// ! PUT /api/{admin|teacher}/report-comments
+func (h *GradeHandler) SetReportComment(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	var req dto.ReportCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if err := h.gradeUC.SetReportComment(r.Context(), claims.UserID, &req); err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Report comment saved", nil)
+}
+
+// ! POST /api/teacher/attendance
+func (h *GradeHandler) RecordAttendance(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	var req dto.RecordAttendanceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	attendance, err := h.gradeUC.RecordAttendance(r.Context(), claims.UserID, &req)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Attendance recorded", attendance)
+}
@@ -0,0 +1,269 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"educnet/internal/domain"
+	"educnet/internal/handler/dto"
+	"educnet/internal/importer"
+	"educnet/internal/middleware"
+	"educnet/internal/usecase"
+	"educnet/internal/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// ! GradebookHandler carnet de notes des enseignants (évaluations, saisie en lot, export / import)
+type GradebookHandler struct {
+	gradebookUC usecase.GradebookUseCase
+}
+
+func NewGradebookHandler(gradebookUC usecase.GradebookUseCase) *GradebookHandler {
+	return &GradebookHandler{gradebookUC: gradebookUC}
+}
+
+// ! POST /api/teacher/evaluations
+func (h *GradebookHandler) CreateEvaluation(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	var req dto.EvaluationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	evaluation, err := h.gradebookUC.CreateEvaluation(r.Context(), claims.UserID, &req)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.Created(w, "Evaluation created successfully", evaluation)
+}
+
+// ! PUT /api/teacher/evaluations/{id}
+func (h *GradebookHandler) UpdateEvaluation(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	evaluationID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid evaluation ID")
+		return
+	}
+
+	var req dto.EvaluationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	evaluation, err := h.gradebookUC.UpdateEvaluation(r.Context(), claims.UserID, evaluationID, &req)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Evaluation updated successfully", evaluation)
+}
+
+// ! DELETE /api/teacher/evaluations/{id} (supprime aussi les notes de l'évaluation)
+func (h *GradebookHandler) DeleteEvaluation(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	evaluationID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid evaluation ID")
+		return
+	}
+
+	if err := h.gradebookUC.DeleteEvaluation(r.Context(), claims.UserID, evaluationID); err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Evaluation deleted successfully", nil)
+}
+
+// ! GET /api/teacher/gradebook?class_id=1&subject_id=2&term_id=3
+func (h *GradebookHandler) GetGradebook(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	query, err := gradebookQuery(r)
+	if err != nil {
+		utils.BadRequest(w, err.Error())
+		return
+	}
+
+	book, err := h.gradebookUC.GetGradebook(r.Context(), claims.UserID, query)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Gradebook retrieved", book)
+}
+
+// ! PUT /api/teacher/gradebook
+// ! {"class_id":1,"subject_id":2,"term_id":3,"cells":[{"student_id":4,"evaluation_id":5,"score":12.5,"version":1}]}
+// ! 409 si une cellule a été modifiée entre-temps : rien n'est écrit, data liste les conflits
+func (h *GradebookHandler) SaveGradebook(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	var req dto.GradebookSaveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	result, err := h.gradebookUC.SaveGradebook(r.Context(), claims.UserID, &req)
+	if err != nil {
+		if errors.Is(err, domain.ErrGradebookInvalidCell) {
+			utils.BadRequest(w, err.Error())
+			return
+		}
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	if !result.Saved {
+		utils.JSON(w, http.StatusConflict, utils.Response{
+			Success: false,
+			Error:   domain.ErrGradebookConflict.Message,
+			Data:    result,
+		})
+		return
+	}
+
+	utils.OK(w, "Gradebook saved successfully", result)
+}
+
+// ! GET /api/teacher/gradebook/export?class_id=1&subject_id=2&term_id=3&format=xlsx
+func (h *GradebookHandler) ExportGradebook(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	query, err := gradebookQuery(r)
+	if err != nil {
+		utils.BadRequest(w, err.Error())
+		return
+	}
+
+	table, err := h.gradebookUC.ExportGradebook(r.Context(), claims.UserID, query)
+	writeExport(w, r, table, err)
+}
+
+// ! POST /api/teacher/gradebook/import (multipart)
+// ! file: .csv | .xlsx au format de l'export
+// ! class_id, subject_id, term_id
+// ! dry_run: true (défaut) = rapport de validation seulement
+func (h *GradebookHandler) ImportGradebook(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		utils.BadRequest(w, "File too large (max 10MB)")
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		utils.BadRequest(w, "No file uploaded")
+		return
+	}
+	defer file.Close()
+
+	query, err := gradebookQuery(r)
+	if err != nil {
+		utils.BadRequest(w, err.Error())
+		return
+	}
+	req := dto.GradebookImportRequest{
+		ClassID:   query.ClassID,
+		SubjectID: query.SubjectID,
+		TermID:    query.TermID,
+		DryRun:    formBool(r, "dry_run", true),
+	}
+
+	rows, err := importer.ReadRows(header.Filename, file)
+	if err != nil {
+		if errors.Is(err, domain.ErrImportUnsupportedFormat) {
+			utils.HandleUseCaseError(w, err)
+			return
+		}
+		utils.BadRequest(w, "Unreadable file: "+err.Error())
+		return
+	}
+
+	report, err := h.gradebookUC.ImportGradebook(r.Context(), claims.UserID, rows, &req)
+	if err != nil {
+		if errors.Is(err, domain.ErrImportMissingColumn) || errors.Is(err, domain.ErrGradebookUnknownColumn) {
+			utils.BadRequest(w, err.Error())
+			return
+		}
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	switch {
+	case report.Committed:
+		utils.OK(w, "Gradebook imported successfully", report)
+	case !report.DryRun:
+		//! Rien n'a été écrit : le rapport indique les lignes à corriger
+		utils.JSON(w, http.StatusUnprocessableEntity, utils.Response{
+			Success: false,
+			Error:   "Import contains invalid rows, nothing was imported",
+			Data:    report,
+		})
+	default:
+		utils.OK(w, "Import validated (dry run)", report)
+	}
+}
+
+// ! gradebookQuery class_id, subject_id et term_id (query string ou formulaire)
+func gradebookQuery(r *http.Request) (dto.GradebookQuery, error) {
+	var query dto.GradebookQuery
+	for _, field := range []struct {
+		name  string
+		value *int
+	}{
+		{"class_id", &query.ClassID},
+		{"subject_id", &query.SubjectID},
+		{"term_id", &query.TermID},
+	} {
+		value, err := strconv.Atoi(r.FormValue(field.name))
+		if err != nil {
+			return query, errors.New("Invalid " + field.name)
+		}
+		*field.value = value
+	}
+	return query, nil
+}
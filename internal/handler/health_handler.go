@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"educnet/internal/migrate"
+	"educnet/internal/utils"
+)
+
+// ! readinessTimeout délai max des vérifications de readiness
+const readinessTimeout = 2 * time.Second
+
+// ! HealthHandler sondes liveness (processus vivant) / readiness (prêt à servir)
+type HealthHandler struct {
+	db       *sql.DB
+	migrator *migrate.Migrator
+}
+
+func NewHealthHandler(db *sql.DB, migrator *migrate.Migrator) *HealthHandler {
+	return &HealthHandler{db: db, migrator: migrator}
+}
+
+// ! GET /api/health/live : aucune dépendance vérifiée (un redémarrage ne réparerait pas la base)
+func (h *HealthHandler) Live(w http.ResponseWriter, r *http.Request) {
+	utils.OK(w, "Server is alive", map[string]string{"status": "alive"})
+}
+
+// ! GET /api/health/ready : base joignable et schéma à jour, 503 sinon
+func (h *HealthHandler) Ready(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+	defer cancel()
+
+	checks := map[string]string{"database": "ok", "migrations": "ok"}
+	ready := true
+
+	if err := h.db.PingContext(ctx); err != nil {
+		slog.WarnContext(ctx, "readiness: database ping failed", "error", err)
+		checks["database"] = "unreachable"
+		ready = false
+	}
+
+	if ready {
+		pending, err := h.migrator.Pending(ctx)
+		switch {
+		case err != nil:
+			slog.WarnContext(ctx, "readiness: migration check failed", "error", err)
+			checks["migrations"] = "unknown"
+			ready = false
+		case len(pending) > 0:
+			checks["migrations"] = "pending"
+			ready = false
+		}
+	}
+
+	if !ready {
+		utils.JSON(w, http.StatusServiceUnavailable, utils.Response{
+			Success: false,
+			Error:   "Service not ready",
+			Data:    checks,
+		})
+		return
+	}
+	utils.OK(w, "Service is ready", checks)
+}
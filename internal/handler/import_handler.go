@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"educnet/internal/domain"
+	"educnet/internal/handler/dto"
+	"educnet/internal/importer"
+	"educnet/internal/middleware"
+	"educnet/internal/usecase"
+	"educnet/internal/utils"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+// ! ImportHandler import en masse des utilisateurs d'une école (admin)
+type ImportHandler struct {
+	importUC usecase.UserImportUseCase
+}
+
+func NewImportHandler(importUC usecase.UserImportUseCase) *ImportHandler {
+	return &ImportHandler{importUC: importUC}
+}
+
+// ! POST /api/admin/users/import (multipart)
+// ! file: .csv | .xlsx
+// ! role: teacher | student (défaut student) — surchargeable par une colonne "role"
+// ! mapping: JSON {"email":"Courriel","first_name":"Prénom",...}
+// ! dry_run: true (défaut) = rapport de validation seulement
+// ! pre_approve: comptes créés directement "approved"
+// ! password_delivery: sheet (défaut) | email
+func (h *ImportHandler) ImportUsers(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		utils.BadRequest(w, "File too large (max 10MB)")
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		utils.BadRequest(w, "No file uploaded")
+		return
+	}
+	defer file.Close()
+
+	req := dto.ImportUsersRequest{
+		Role:             r.FormValue("role"),
+		DryRun:           formBool(r, "dry_run", true),
+		PreApprove:       formBool(r, "pre_approve", false),
+		PasswordDelivery: r.FormValue("password_delivery"),
+	}
+	if mapping := r.FormValue("mapping"); mapping != "" {
+		if err := json.Unmarshal([]byte(mapping), &req.Mapping); err != nil {
+			utils.BadRequest(w, "Invalid mapping (expected JSON object)")
+			return
+		}
+	}
+
+	rows, err := importer.ReadRows(header.Filename, file)
+	if err != nil {
+		if errors.Is(err, domain.ErrImportUnsupportedFormat) {
+			utils.HandleUseCaseError(w, err)
+			return
+		}
+		utils.BadRequest(w, "Unreadable file: "+err.Error())
+		return
+	}
+
+	report, err := h.importUC.ImportUsers(r.Context(), claims.UserID, rows, &req)
+	if err != nil {
+		if errors.Is(err, domain.ErrImportMissingColumn) {
+			utils.BadRequest(w, err.Error())
+			return
+		}
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	switch {
+	case report.Committed:
+		utils.Created(w, "Users imported successfully", report)
+	case !report.DryRun:
+		//! Rien n'a été créé : le rapport indique les lignes à corriger
+		utils.JSON(w, http.StatusUnprocessableEntity, utils.Response{
+			Success: false,
+			Error:   "Import contains invalid rows, nothing was imported",
+			Data:    report,
+		})
+	default:
+		utils.OK(w, "Import validated (dry run)", report)
+	}
+}
+
+// ! formBool lit un booléen de formulaire avec valeur par défaut
+func formBool(r *http.Request, key string, fallback bool) bool {
+	value, err := strconv.ParseBool(r.FormValue(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
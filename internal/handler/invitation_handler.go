@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"educnet/internal/handler/dto"
+	"educnet/internal/middleware"
+	"educnet/internal/usecase"
+	"educnet/internal/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// ! InvitationHandler invitations (admin) + acceptation (public)
+type InvitationHandler struct {
+	invitationUC usecase.InvitationUseCase
+}
+
+func NewInvitationHandler(invitationUC usecase.InvitationUseCase) *InvitationHandler {
+	return &InvitationHandler{invitationUC: invitationUC}
+}
+
+// ! ========== ADMIN ==========
+
+// POST /api/admin/invitations
+func (h *InvitationHandler) CreateInvitation(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	var req dto.CreateInvitationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	invitation, err := h.invitationUC.CreateInvitation(r.Context(), claims.UserID, &req)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.Created(w, "Invitation created successfully", invitation)
+}
+
+// GET /api/admin/invitations
+func (h *InvitationHandler) GetInvitations(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	invitations, err := h.invitationUC.ListInvitations(r.Context(), claims.UserID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Invitations retrieved", invitations)
+}
+
+// DELETE /api/admin/invitations/{id}
+func (h *InvitationHandler) RevokeInvitation(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	invitationID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid invitation ID")
+		return
+	}
+
+	if err := h.invitationUC.RevokeInvitation(r.Context(), claims.UserID, invitationID); err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Invitation revoked successfully", nil)
+}
+
+// PUT /api/admin/school/registration-mode
+func (h *InvitationHandler) UpdateRegistrationMode(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	var req dto.UpdateRegistrationModeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	school, err := h.invitationUC.UpdateRegistrationMode(r.Context(), claims.UserID, &req)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Registration mode updated successfully", school)
+}
+
+// ! ========== PUBLIC ==========
+
+// GET /api/invitations?token=...
+func (h *InvitationHandler) GetInvitation(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		utils.BadRequest(w, "token is required")
+		return
+	}
+
+	invitation, err := h.invitationUC.GetInvitation(r.Context(), token)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Invitation retrieved", invitation)
+}
+
+// POST /api/invitations/accept
+func (h *InvitationHandler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	var req dto.AcceptInvitationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+	if req.Token == "" {
+		utils.BadRequest(w, "token is required")
+		return
+	}
+
+	resp, err := h.invitationUC.AcceptInvitation(r.Context(), &req)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.Created(w, "Account created successfully", resp)
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"educnet/internal/handler/dto"
+	"educnet/internal/middleware"
+	"educnet/internal/usecase"
+	"educnet/internal/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// ! PrivacyHandler RGPD : export des données, effacement de compte, rétention
+type PrivacyHandler struct {
+	privacyUC usecase.PrivacyUseCase
+}
+
+func NewPrivacyHandler(privacyUC usecase.PrivacyUseCase) *PrivacyHandler {
+	return &PrivacyHandler{privacyUC: privacyUC}
+}
+
+// ! GET /api/me/data-export
+func (h *PrivacyHandler) ExportMyData(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	file, err := h.privacyUC.ExportMyData(r.Context(), claims.UserID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	writeFile(w, file)
+}
+
+// ! POST /api/admin/users/{id}/erase
+func (h *PrivacyHandler) EraseUser(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	userID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid user ID")
+		return
+	}
+
+	if err := h.privacyUC.EraseUser(r.Context(), claims.UserID, userID, r.RemoteAddr); err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "User erased successfully", nil)
+}
+
+// ! GET /api/admin/school/retention
+func (h *PrivacyHandler) GetRetentionPolicy(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	policy, err := h.privacyUC.GetRetentionPolicy(r.Context(), claims.UserID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Retention policy retrieved successfully", policy)
+}
+
+// ! PUT /api/admin/school/retention
+func (h *PrivacyHandler) UpdateRetentionPolicy(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	var req dto.RetentionPolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	policy, err := h.privacyUC.UpdateRetentionPolicy(r.Context(), claims.UserID, &req, r.RemoteAddr)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Retention policy updated successfully", policy)
+}
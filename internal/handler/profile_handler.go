@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"educnet/internal/domain"
 	"educnet/internal/handler/dto"
 	"educnet/internal/middleware"
 	"educnet/internal/usecase"
@@ -11,8 +12,11 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
+
+	"github.com/gorilla/mux"
 )
 
 type ProfileHandler struct {
@@ -31,7 +35,7 @@ func (h *ProfileHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	profile, err := h.profileUC.GetProfile(claims.UserID)
+	profile, err := h.profileUC.GetProfile(r.Context(), claims.UserID)
 	if err != nil {
 		utils.HandleUseCaseError(w, err)
 		return
@@ -54,7 +58,7 @@ func (h *ProfileHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	profile, err := h.profileUC.UpdateProfile(claims.UserID, &req)
+	profile, err := h.profileUC.UpdateProfile(r.Context(), claims.UserID, &req)
 	if err != nil {
 		utils.BadRequest(w, err.Error())
 		return
@@ -77,11 +81,16 @@ func (h *ProfileHandler) ChangePassword(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	if err := h.profileUC.ChangePassword(claims.UserID, &req); err != nil {
+	if err := h.profileUC.ChangePassword(r.Context(), claims.UserID, &req); err != nil {
 		utils.HandleUseCaseError(w, err)
 		return
 	}
 
+	//! Token restreint : une nouvelle connexion délivre un access token complet
+	if claims.PasswordChangeRequired {
+		utils.OK(w, "Password changed successfully. Please log in again.", nil)
+		return
+	}
 	utils.OK(w, "Password changed successfully", nil)
 }
 
@@ -139,7 +148,7 @@ func (h *ProfileHandler) UploadAvatar(w http.ResponseWriter, r *http.Request) {
 
 	//! Update database
 	avatarURL := fmt.Sprintf("/uploads/avatars/%s", filename)
-	if err := h.profileUC.UpdateAvatar(claims.UserID, avatarURL); err != nil {
+	if err := h.profileUC.UpdateAvatar(r.Context(), claims.UserID, avatarURL); err != nil {
 		utils.InternalServerError(w, "Failed to update avatar")
 		return
 	}
@@ -156,13 +165,13 @@ func (h *ProfileHandler) GetSchool(w http.ResponseWriter, r *http.Request) {
 	}
 
 	//! Get user to get school_id
-	user, err := h.profileUC.GetProfile(claims.UserID)
+	user, err := h.profileUC.GetProfile(r.Context(), claims.UserID)
 	if err != nil {
 		utils.NotFound(w, "User not found")
 		return
 	}
 
-	school, err := h.profileUC.GetSchool(user.ID, user.SchoolID)
+	school, err := h.profileUC.GetSchool(r.Context(), user.ID, user.SchoolID)
 	if err != nil {
 		utils.NotFound(w, "School not found")
 		return
@@ -186,7 +195,7 @@ func (h *ProfileHandler) UpdateSchool(w http.ResponseWriter, r *http.Request) {
 	}
 
 	//! Get user to get school_id
-	user, err := h.profileUC.GetProfile(claims.UserID)
+	user, err := h.profileUC.GetProfile(r.Context(), claims.UserID)
 	if err != nil {
 		utils.NotFound(w, "User not found")
 		return
@@ -198,7 +207,7 @@ func (h *ProfileHandler) UpdateSchool(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	school, err := h.profileUC.UpdateSchool(user.ID, user.SchoolID, &req)
+	school, err := h.profileUC.UpdateSchool(r.Context(), user.ID, user.SchoolID, &req)
 	if err != nil {
 		utils.BadRequest(w, err.Error())
 		return
@@ -222,7 +231,7 @@ func (h *ProfileHandler) UploadSchoolLogo(w http.ResponseWriter, r *http.Request
 	}
 
 	// Get user to get school_id
-	user, err := h.profileUC.GetProfile(claims.UserID)
+	user, err := h.profileUC.GetProfile(r.Context(), claims.UserID)
 	if err != nil {
 		utils.NotFound(w, "User not found")
 		return
@@ -274,7 +283,7 @@ func (h *ProfileHandler) UploadSchoolLogo(w http.ResponseWriter, r *http.Request
 
 	// Update database
 	logoURL := fmt.Sprintf("/uploads/logos/%s", filename)
-	if err := h.profileUC.UpdateSchoolLogo(user.ID, user.SchoolID, logoURL); err != nil {
+	if err := h.profileUC.UpdateSchoolLogo(r.Context(), user.ID, user.SchoolID, logoURL); err != nil {
 		utils.InternalServerError(w, "Failed to update logo")
 		return
 	}
@@ -290,7 +299,7 @@ func (h *ProfileHandler) GetMySubjects(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	subjects, err := h.profileUC.GetTeacherSubjects(claims.UserID)
+	subjects, err := h.profileUC.GetTeacherSubjects(r.Context(), claims.UserID)
 	if err != nil {
 		utils.BadRequest(w, err.Error())
 		return
@@ -307,7 +316,7 @@ func (h *ProfileHandler) GetMyClass(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	class, err := h.profileUC.GetStudentClasses(claims.UserID)
+	class, err := h.profileUC.GetStudentClasses(r.Context(), claims.UserID)
 	if err != nil {
 		utils.BadRequest(w, err.Error())
 		return
@@ -315,3 +324,165 @@ func (h *ProfileHandler) GetMyClass(w http.ResponseWriter, r *http.Request) {
 
 	utils.OK(w, "Class retrieved", class)
 }
+
+// ! ==================== EXTENDED PROFILE ====================
+// ! Routes /api/me/... (titulaire), /api/admin/users/{id}/... et /api/teacher/students/{id}/profile :
+// ! sans {id}, le titulaire est l'appelant ; les droits par champ sont appliqués par le usecase
+
+// ! maxProfileDocumentForm document + champs du formulaire
+const maxProfileDocumentForm = domain.ProfileMaxFileSize + 1<<20
+
+// ! GET /api/admin/users/{id}/profile, GET /api/teacher/students/{id}/profile
+func (h *ProfileHandler) GetUserProfile(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	userID, ok := profileOwnerID(w, r, claims.UserID)
+	if !ok {
+		return
+	}
+
+	profile, err := h.profileUC.GetUserProfile(r.Context(), claims.UserID, userID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Profile retrieved", profile)
+}
+
+// ! PUT /api/me/details, PUT /api/admin/users/{id}/profile
+func (h *ProfileHandler) UpdateExtendedProfile(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	userID, ok := profileOwnerID(w, r, claims.UserID)
+	if !ok {
+		return
+	}
+
+	var req dto.ExtendedProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	profile, err := h.profileUC.UpdateExtendedProfile(r.Context(), claims.UserID, userID, &req)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Profile updated successfully", profile)
+}
+
+// ! POST /api/me/documents, POST /api/admin/users/{id}/documents (multipart : file, kind, title)
+func (h *ProfileHandler) UploadDocument(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	userID, ok := profileOwnerID(w, r, claims.UserID)
+	if !ok {
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxProfileDocumentForm)
+	if err := r.ParseMultipartForm(maxProfileDocumentForm); err != nil {
+		utils.BadRequest(w, "Invalid form (max 10MB)")
+		return
+	}
+	file, closeFile, err := formResourceFile(r)
+	if err != nil {
+		utils.BadRequest(w, "Invalid file")
+		return
+	}
+	defer closeFile()
+
+	req := dto.ProfileDocumentRequest{Kind: r.FormValue("kind"), Title: r.FormValue("title")}
+	document, err := h.profileUC.UploadDocument(r.Context(), claims.UserID, userID, req, file)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.Created(w, "Document uploaded successfully", document)
+}
+
+// ! GET /api/me/documents/{documentId}, GET /api/admin/users/{id}/documents/{documentId}
+func (h *ProfileHandler) DownloadDocument(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	userID, documentID, ok := profileDocumentIDs(w, r, claims.UserID)
+	if !ok {
+		return
+	}
+
+	file, err := h.profileUC.DownloadDocument(r.Context(), claims.UserID, userID, documentID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	writeFile(w, file)
+}
+
+// ! DELETE /api/me/documents/{documentId}, DELETE /api/admin/users/{id}/documents/{documentId}
+func (h *ProfileHandler) DeleteDocument(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	userID, documentID, ok := profileDocumentIDs(w, r, claims.UserID)
+	if !ok {
+		return
+	}
+
+	if err := h.profileUC.DeleteDocument(r.Context(), claims.UserID, userID, documentID); err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Document deleted successfully", nil)
+}
+
+// ! profileOwnerID {id} de la route, ou l'appelant sous /api/me ; répond 400 si invalide
+func profileOwnerID(w http.ResponseWriter, r *http.Request, callerID int) (int, bool) {
+	value, ok := mux.Vars(r)["id"]
+	if !ok {
+		return callerID, true
+	}
+	userID, err := strconv.Atoi(value)
+	if err != nil {
+		utils.BadRequest(w, "Invalid user ID")
+		return 0, false
+	}
+	return userID, true
+}
+
+func profileDocumentIDs(w http.ResponseWriter, r *http.Request, callerID int) (int, int, bool) {
+	userID, ok := profileOwnerID(w, r, callerID)
+	if !ok {
+		return 0, 0, false
+	}
+	documentID, err := strconv.Atoi(mux.Vars(r)["documentId"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid document ID")
+		return 0, 0, false
+	}
+	return userID, documentID, true
+}
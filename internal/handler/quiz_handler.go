@@ -0,0 +1,326 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"educnet/internal/handler/dto"
+	"educnet/internal/middleware"
+	"educnet/internal/usecase"
+	"educnet/internal/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// ! QuizHandler banque de questions, quiz et tentatives
+type QuizHandler struct {
+	quizUC usecase.QuizUseCase
+}
+
+func NewQuizHandler(quizUC usecase.QuizUseCase) *QuizHandler {
+	return &QuizHandler{quizUC: quizUC}
+}
+
+// ! POST /api/teacher/questions
+func (h *QuizHandler) CreateQuestion(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	var req dto.QuestionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	question, err := h.quizUC.CreateQuestion(r.Context(), claims.UserID, &req)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.Created(w, "Question created successfully", question)
+}
+
+// ! GET /api/teacher/questions?subject_id=1
+func (h *QuizHandler) ListQuestions(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	subjectID, err := strconv.Atoi(r.URL.Query().Get("subject_id"))
+	if err != nil {
+		utils.BadRequest(w, "Invalid subject_id")
+		return
+	}
+
+	questions, err := h.quizUC.ListQuestions(r.Context(), claims.UserID, subjectID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Questions retrieved", questions)
+}
+
+// ! PUT /api/teacher/questions/{id}
+func (h *QuizHandler) UpdateQuestion(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	questionID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid question ID")
+		return
+	}
+
+	var req dto.QuestionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	question, err := h.quizUC.UpdateQuestion(r.Context(), claims.UserID, questionID, &req)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Question updated successfully", question)
+}
+
+// ! DELETE /api/teacher/questions/{id}
+func (h *QuizHandler) DeleteQuestion(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	questionID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid question ID")
+		return
+	}
+
+	if err := h.quizUC.DeleteQuestion(r.Context(), claims.UserID, questionID); err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Question deleted successfully", nil)
+}
+
+// ! POST /api/teacher/quizzes
+func (h *QuizHandler) CreateQuiz(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	var req dto.CreateQuizRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	quiz, err := h.quizUC.CreateQuiz(r.Context(), claims.UserID, &req)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.Created(w, "Quiz created successfully", quiz)
+}
+
+// ! GET /api/teacher/quizzes?class_id=1
+func (h *QuizHandler) ListQuizzes(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	classID, err := strconv.Atoi(r.URL.Query().Get("class_id"))
+	if err != nil {
+		utils.BadRequest(w, "Invalid class_id")
+		return
+	}
+
+	quizzes, err := h.quizUC.ListQuizzes(r.Context(), claims.UserID, classID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Quizzes retrieved", quizzes)
+}
+
+// ! GET /api/teacher/quizzes/{id}
+func (h *QuizHandler) GetQuiz(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	quizID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid quiz ID")
+		return
+	}
+
+	quiz, err := h.quizUC.GetQuiz(r.Context(), claims.UserID, quizID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Quiz retrieved", quiz)
+}
+
+// ! DELETE /api/teacher/quizzes/{id}
+func (h *QuizHandler) DeleteQuiz(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	quizID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid quiz ID")
+		return
+	}
+
+	if err := h.quizUC.DeleteQuiz(r.Context(), claims.UserID, quizID); err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Quiz deleted successfully", nil)
+}
+
+// ! GET /api/teacher/quizzes/{id}/attempts
+func (h *QuizHandler) ListAttempts(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	quizID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid quiz ID")
+		return
+	}
+
+	attempts, err := h.quizUC.ListAttempts(r.Context(), claims.UserID, quizID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Quiz attempts retrieved", attempts)
+}
+
+// ! GET /api/student/quizzes
+func (h *QuizHandler) ListMyQuizzes(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	quizzes, err := h.quizUC.ListMyQuizzes(r.Context(), claims.UserID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Quizzes retrieved", quizzes)
+}
+
+// ! GET /api/student/quizzes/{id}
+func (h *QuizHandler) GetMyQuiz(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	quizID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid quiz ID")
+		return
+	}
+
+	quiz, err := h.quizUC.GetMyQuiz(r.Context(), claims.UserID, quizID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Quiz retrieved", quiz)
+}
+
+// ! POST /api/student/quizzes/{id}/attempts
+func (h *QuizHandler) StartAttempt(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	quizID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid quiz ID")
+		return
+	}
+
+	attempt, err := h.quizUC.StartAttempt(r.Context(), claims.UserID, quizID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.Created(w, "Quiz attempt started", attempt)
+}
+
+// ! POST /api/student/quiz-attempts/{id}/submit
+func (h *QuizHandler) SubmitAttempt(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	attemptID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid attempt ID")
+		return
+	}
+
+	var req dto.SubmitAttemptRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	attempt, err := h.quizUC.SubmitAttempt(r.Context(), claims.UserID, attemptID, &req)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Quiz attempt submitted", attempt)
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"educnet/internal/handler/dto"
+	"educnet/internal/middleware"
+	"educnet/internal/usecase"
+	"educnet/internal/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// ! RankingHandler classements et statistiques de classe par période
+type RankingHandler struct {
+	rankingUC usecase.RankingUseCase
+}
+
+func NewRankingHandler(rankingUC usecase.RankingUseCase) *RankingHandler {
+	return &RankingHandler{rankingUC: rankingUC}
+}
+
+// ! GET /api/{admin|teacher}/classes/{id}/ranking?term_id=1
+func (h *RankingHandler) ClassRanking(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	classID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid class ID")
+		return
+	}
+	termID, err := strconv.Atoi(r.URL.Query().Get("term_id"))
+	if err != nil {
+		utils.BadRequest(w, "Invalid term_id")
+		return
+	}
+
+	ranking, err := h.rankingUC.ClassRanking(r.Context(), claims.UserID, classID, termID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Class ranking retrieved", ranking)
+}
+
+// ! GET /api/student/ranking?term_id=1
+func (h *RankingHandler) MyRanking(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	termID, err := strconv.Atoi(r.URL.Query().Get("term_id"))
+	if err != nil {
+		utils.BadRequest(w, "Invalid term_id")
+		return
+	}
+
+	ranking, err := h.rankingUC.StudentRanking(r.Context(), claims.UserID, termID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Ranking retrieved", ranking)
+}
+
+// ! GET /api/admin/school/ranking
+func (h *RankingHandler) GetSettings(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	settings, err := h.rankingUC.GetSettings(r.Context(), claims.UserID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Ranking settings retrieved", settings)
+}
+
+// ! PUT /api/admin/school/ranking
+func (h *RankingHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	var req dto.RankingSettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	settings, err := h.rankingUC.UpdateSettings(r.Context(), claims.UserID, &req)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Ranking settings updated successfully", settings)
+}
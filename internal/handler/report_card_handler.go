@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"educnet/internal/handler/dto"
+	"educnet/internal/middleware"
+	"educnet/internal/usecase"
+	"educnet/internal/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// ! ReportCardHandler téléchargement des bulletins (PDF, ZIP par classe)
+type ReportCardHandler struct {
+	reportCardUC usecase.ReportCardUseCase
+}
+
+func NewReportCardHandler(reportCardUC usecase.ReportCardUseCase) *ReportCardHandler {
+	return &ReportCardHandler{reportCardUC: reportCardUC}
+}
+
+// ! GET /api/admin/report-cards/students/{id}?term_id=1
+func (h *ReportCardHandler) StudentReportCard(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	studentID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid student ID")
+		return
+	}
+	termID, err := strconv.Atoi(r.URL.Query().Get("term_id"))
+	if err != nil {
+		utils.BadRequest(w, "Invalid term_id")
+		return
+	}
+
+	file, err := h.reportCardUC.StudentReportCard(r.Context(), claims.UserID, studentID, termID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	writeFile(w, file)
+}
+
+// ! GET /api/student/report-card?term_id=1
+func (h *ReportCardHandler) MyReportCard(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	termID, err := strconv.Atoi(r.URL.Query().Get("term_id"))
+	if err != nil {
+		utils.BadRequest(w, "Invalid term_id")
+		return
+	}
+
+	file, err := h.reportCardUC.StudentReportCard(r.Context(), claims.UserID, claims.UserID, termID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	writeFile(w, file)
+}
+
+// ! GET /api/admin/report-cards/classes/{id}?term_id=1 (archive ZIP)
+func (h *ReportCardHandler) ClassReportCards(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	classID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid class ID")
+		return
+	}
+	termID, err := strconv.Atoi(r.URL.Query().Get("term_id"))
+	if err != nil {
+		utils.BadRequest(w, "Invalid term_id")
+		return
+	}
+
+	file, err := h.reportCardUC.ClassReportCards(r.Context(), claims.UserID, classID, termID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	writeFile(w, file)
+}
+
+// ! writeFile réponse binaire en pièce jointe
+func writeFile(w http.ResponseWriter, file *dto.FileResponse) {
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, file.FileName))
+	w.Header().Set("Content-Length", strconv.Itoa(len(file.Data)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(file.Data)
+}
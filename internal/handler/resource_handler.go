@@ -0,0 +1,430 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"educnet/internal/domain"
+	"educnet/internal/handler/dto"
+	"educnet/internal/middleware"
+	"educnet/internal/usecase"
+	"educnet/internal/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// ! ResourceHandler bibliothèque de cours (dossiers, ressources, versions, téléchargements)
+type ResourceHandler struct {
+	resourceUC usecase.ResourceUseCase
+}
+
+func NewResourceHandler(resourceUC usecase.ResourceUseCase) *ResourceHandler {
+	return &ResourceHandler{resourceUC: resourceUC}
+}
+
+// ! maxResourceForm fichier + champs du formulaire
+const maxResourceForm = domain.ResourceMaxFileSize + 1<<20
+
+// ! POST /api/teacher/resource-folders
+func (h *ResourceHandler) CreateFolder(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	var req dto.CreateFolderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	folder, err := h.resourceUC.CreateFolder(r.Context(), claims.UserID, &req)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.Created(w, "Folder created successfully", folder)
+}
+
+// ! GET /api/teacher/resource-folders?subject_id=1 (idem /api/student)
+func (h *ResourceHandler) ListFolders(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	subjectID, err := strconv.Atoi(r.URL.Query().Get("subject_id"))
+	if err != nil {
+		utils.BadRequest(w, "Invalid subject_id")
+		return
+	}
+
+	folders, err := h.resourceUC.ListFolders(r.Context(), claims.UserID, subjectID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Folders retrieved", folders)
+}
+
+// ! DELETE /api/teacher/resource-folders/{id}
+func (h *ResourceHandler) DeleteFolder(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	folderID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid folder ID")
+		return
+	}
+
+	if err := h.resourceUC.DeleteFolder(r.Context(), claims.UserID, folderID); err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Folder deleted successfully", nil)
+}
+
+// ! POST /api/teacher/resources (multipart/form-data)
+// ! Champs : subject_id, class_id | level, chapter, folder_id, title, description,
+// ! kind (file | link, déduit si absent), url, tags (séparés par des virgules), file
+func (h *ResourceHandler) CreateResource(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxResourceForm)
+	if err := r.ParseMultipartForm(maxResourceForm); err != nil {
+		utils.BadRequest(w, "Invalid form (max 20MB)")
+		return
+	}
+
+	req, err := resourceRequestFromForm(r)
+	if err != nil {
+		utils.BadRequest(w, err.Error())
+		return
+	}
+
+	file, closeFile, err := formResourceFile(r)
+	if err != nil {
+		utils.BadRequest(w, "Invalid file")
+		return
+	}
+	defer closeFile()
+	if req.Kind == "" {
+		req.Kind = domain.ResourceLink
+		if file != nil {
+			req.Kind = domain.ResourceFile
+		}
+	}
+
+	resource, err := h.resourceUC.CreateResource(r.Context(), claims.UserID, req, file)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.Created(w, "Resource created successfully", resource)
+}
+
+// ! GET /api/teacher/resources?subject_id=&class_id=&level=&chapter=&folder_id=&tag=&q=
+func (h *ResourceHandler) ListResources(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	query, err := resourceQueryFromURL(r)
+	if err != nil {
+		utils.BadRequest(w, err.Error())
+		return
+	}
+
+	resources, err := h.resourceUC.ListResources(r.Context(), claims.UserID, query)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Resources retrieved", resources)
+}
+
+// ! PUT /api/teacher/resources/{id}
+func (h *ResourceHandler) UpdateResource(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	resourceID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid resource ID")
+		return
+	}
+
+	var req dto.ResourceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	resource, err := h.resourceUC.UpdateResource(r.Context(), claims.UserID, resourceID, &req)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Resource updated successfully", resource)
+}
+
+// ! DELETE /api/teacher/resources/{id}
+func (h *ResourceHandler) DeleteResource(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	resourceID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid resource ID")
+		return
+	}
+
+	if err := h.resourceUC.DeleteResource(r.Context(), claims.UserID, resourceID); err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Resource deleted successfully", nil)
+}
+
+// ! POST /api/teacher/resources/{id}/versions (multipart/form-data, champ "file")
+func (h *ResourceHandler) AddVersion(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	resourceID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid resource ID")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxResourceForm)
+	if err := r.ParseMultipartForm(maxResourceForm); err != nil {
+		utils.BadRequest(w, "Invalid form (max 20MB)")
+		return
+	}
+	file, closeFile, err := formResourceFile(r)
+	if err != nil {
+		utils.BadRequest(w, "Invalid file")
+		return
+	}
+	defer closeFile()
+
+	resource, err := h.resourceUC.AddVersion(r.Context(), claims.UserID, resourceID, file)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.Created(w, "Resource file replaced successfully", resource)
+}
+
+// ! GET /api/teacher/resources/{id}/versions
+func (h *ResourceHandler) ListVersions(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	resourceID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid resource ID")
+		return
+	}
+
+	versions, err := h.resourceUC.ListVersions(r.Context(), claims.UserID, resourceID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Resource versions retrieved", versions)
+}
+
+// ! GET /api/student/resources?subject_id=&class_id=&level=&chapter=&folder_id=&tag=&q=
+func (h *ResourceHandler) ListMyResources(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	query, err := resourceQueryFromURL(r)
+	if err != nil {
+		utils.BadRequest(w, err.Error())
+		return
+	}
+
+	resources, err := h.resourceUC.ListMyResources(r.Context(), claims.UserID, query)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Resources retrieved", resources)
+}
+
+// ! GET /api/{teacher|student}/resources/{id}/download?version=2
+// ! Lien : redirection ; fichier : pièce jointe (version courante par défaut)
+func (h *ResourceHandler) Download(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	resourceID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid resource ID")
+		return
+	}
+	version := 0
+	if value := r.URL.Query().Get("version"); value != "" {
+		if version, err = strconv.Atoi(value); err != nil || version < 1 {
+			utils.BadRequest(w, "Invalid version")
+			return
+		}
+	}
+
+	download, err := h.resourceUC.Download(r.Context(), claims.UserID, resourceID, version)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	if download.File == nil {
+		http.Redirect(w, r, download.URL, http.StatusFound)
+		return
+	}
+	writeFile(w, download.File)
+}
+
+// ! ==================== HELPERS ====================
+
+type formError string
+
+func (e formError) Error() string { return string(e) }
+
+// ! formInt entier optionnel d'un formulaire ou d'une query string (0 si absent)
+func formInt(value, field string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, formError("Invalid " + field)
+	}
+	return n, nil
+}
+
+// ! formID ID optionnel (nil si absent)
+func formID(value, field string) (*int, error) {
+	n, err := formInt(value, field)
+	if err != nil || n == 0 {
+		return nil, err
+	}
+	return &n, nil
+}
+
+func resourceRequestFromForm(r *http.Request) (*dto.ResourceRequest, error) {
+	subjectID, err := formInt(r.FormValue("subject_id"), "subject_id")
+	if err != nil {
+		return nil, err
+	}
+	classID, err := formID(r.FormValue("class_id"), "class_id")
+	if err != nil {
+		return nil, err
+	}
+	folderID, err := formID(r.FormValue("folder_id"), "folder_id")
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []string
+	if value := r.FormValue("tags"); value != "" {
+		tags = strings.Split(value, ",")
+	}
+
+	return &dto.ResourceRequest{
+		SubjectID:   subjectID,
+		ClassID:     classID,
+		Level:       r.FormValue("level"),
+		Chapter:     r.FormValue("chapter"),
+		FolderID:    folderID,
+		Title:       r.FormValue("title"),
+		Description: r.FormValue("description"),
+		Kind:        r.FormValue("kind"),
+		URL:         r.FormValue("url"),
+		Tags:        tags,
+	}, nil
+}
+
+// ! formResourceFile champ "file" du formulaire (nil si absent) ; closeFile toujours appelable
+func formResourceFile(r *http.Request) (*dto.UploadedFile, func(), error) {
+	file, header, err := r.FormFile("file")
+	if err == http.ErrMissingFile {
+		return nil, func() {}, nil
+	}
+	if err != nil {
+		return nil, func() {}, err
+	}
+
+	return &dto.UploadedFile{
+		FileName:    header.Filename,
+		ContentType: header.Header.Get("Content-Type"),
+		Size:        header.Size,
+		Content:     file,
+	}, func() { file.Close() }, nil
+}
+
+func resourceQueryFromURL(r *http.Request) (dto.ResourceQuery, error) {
+	values := r.URL.Query()
+	query := dto.ResourceQuery{
+		Level:   values.Get("level"),
+		Chapter: values.Get("chapter"),
+		Tag:     values.Get("tag"),
+		Query:   strings.TrimSpace(values.Get("q")),
+	}
+
+	var err error
+	if query.SubjectID, err = formInt(values.Get("subject_id"), "subject_id"); err != nil {
+		return query, err
+	}
+	if query.ClassID, err = formInt(values.Get("class_id"), "class_id"); err != nil {
+		return query, err
+	}
+	if query.FolderID, err = formInt(values.Get("folder_id"), "folder_id"); err != nil {
+		return query, err
+	}
+	return query, nil
+}
@@ -0,0 +1,357 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"educnet/internal/handler/dto"
+	"educnet/internal/middleware"
+	"educnet/internal/usecase"
+	"educnet/internal/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// ! RoomHandler salles, équipements et réservations
+type RoomHandler struct {
+	roomUC usecase.RoomUseCase
+}
+
+func NewRoomHandler(roomUC usecase.RoomUseCase) *RoomHandler {
+	return &RoomHandler{roomUC: roomUC}
+}
+
+// ! POST /api/admin/rooms
+func (h *RoomHandler) CreateRoom(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	var req dto.RoomRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	room, err := h.roomUC.CreateRoom(r.Context(), claims.UserID, &req)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.Created(w, "Room created successfully", room)
+}
+
+// ! PUT /api/admin/rooms/{id}
+func (h *RoomHandler) UpdateRoom(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	roomID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid room ID")
+		return
+	}
+
+	var req dto.RoomRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	room, err := h.roomUC.UpdateRoom(r.Context(), claims.UserID, roomID, &req)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Room updated successfully", room)
+}
+
+// ! DELETE /api/admin/rooms/{id}
+func (h *RoomHandler) DeleteRoom(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	roomID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid room ID")
+		return
+	}
+
+	if err := h.roomUC.DeleteRoom(r.Context(), claims.UserID, roomID); err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Room deleted successfully", nil)
+}
+
+// ! POST /api/admin/equipment
+func (h *RoomHandler) CreateEquipment(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	var req dto.EquipmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	equipment, err := h.roomUC.CreateEquipment(r.Context(), claims.UserID, &req)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.Created(w, "Equipment created successfully", equipment)
+}
+
+// ! PUT /api/admin/equipment/{id}
+func (h *RoomHandler) UpdateEquipment(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	equipmentID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid equipment ID")
+		return
+	}
+
+	var req dto.EquipmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	equipment, err := h.roomUC.UpdateEquipment(r.Context(), claims.UserID, equipmentID, &req)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Equipment updated successfully", equipment)
+}
+
+// ! DELETE /api/admin/equipment/{id}
+func (h *RoomHandler) DeleteEquipment(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	equipmentID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid equipment ID")
+		return
+	}
+
+	if err := h.roomUC.DeleteEquipment(r.Context(), claims.UserID, equipmentID); err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Equipment deleted successfully", nil)
+}
+
+// ! GET /api/admin/bookings?status=pending&room_id=&from=YYYY-MM-DD&to=YYYY-MM-DD
+func (h *RoomHandler) ListBookings(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	values := r.URL.Query()
+	query := dto.BookingQuery{Status: values.Get("status"), From: values.Get("from"), To: values.Get("to")}
+	roomID, err := formInt(values.Get("room_id"), "room_id")
+	if err != nil {
+		utils.BadRequest(w, err.Error())
+		return
+	}
+	query.RoomID = roomID
+
+	bookings, err := h.roomUC.ListBookings(r.Context(), claims.UserID, query)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Bookings retrieved", bookings)
+}
+
+// ! POST /api/admin/bookings/{id}/review
+func (h *RoomHandler) ReviewBooking(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	bookingID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid booking ID")
+		return
+	}
+
+	var req dto.BookingReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	booking, err := h.roomUC.ReviewBooking(r.Context(), claims.UserID, bookingID, &req)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Booking reviewed successfully", booking)
+}
+
+// ! GET /api/rooms
+func (h *RoomHandler) ListRooms(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	rooms, err := h.roomUC.ListRooms(r.Context(), claims.UserID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Rooms retrieved", rooms)
+}
+
+// ! GET /api/rooms/equipment
+func (h *RoomHandler) ListEquipment(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	equipment, err := h.roomUC.ListEquipment(r.Context(), claims.UserID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Equipment retrieved", equipment)
+}
+
+// ! GET /api/rooms/availability?from=RFC3339&to=RFC3339&type=lab&min_capacity=30&equipment=Vidéoprojecteur,Tableau
+func (h *RoomHandler) SearchAvailability(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	values := r.URL.Query()
+	query := dto.AvailabilityQuery{From: values.Get("from"), To: values.Get("to"), Type: values.Get("type")}
+	minCapacity, err := formInt(values.Get("min_capacity"), "min_capacity")
+	if err != nil {
+		utils.BadRequest(w, err.Error())
+		return
+	}
+	query.MinCapacity = minCapacity
+	for _, value := range values["equipment"] {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				query.Equipment = append(query.Equipment, item)
+			}
+		}
+	}
+
+	availability, err := h.roomUC.SearchAvailability(r.Context(), claims.UserID, query)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Availability retrieved", availability)
+}
+
+// ! POST /api/rooms/bookings
+func (h *RoomHandler) CreateBooking(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	var req dto.BookingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	booking, err := h.roomUC.CreateBooking(r.Context(), claims.UserID, &req)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.Created(w, "Booking created successfully", booking)
+}
+
+// ! GET /api/rooms/bookings (mes réservations à venir)
+func (h *RoomHandler) ListMyBookings(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	bookings, err := h.roomUC.ListMyBookings(r.Context(), claims.UserID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Bookings retrieved", bookings)
+}
+
+// ! POST /api/rooms/bookings/{id}/cancel
+func (h *RoomHandler) CancelBooking(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	bookingID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid booking ID")
+		return
+	}
+
+	booking, err := h.roomUC.CancelBooking(r.Context(), claims.UserID, bookingID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Booking cancelled successfully", booking)
+}
@@ -2,7 +2,6 @@ package handler
 
 import (
 	"encoding/json"
-	"fmt"
 	"net/http"
 
 	"educnet/internal/usecase"
@@ -28,9 +27,7 @@ func (h *SchoolHandler) CreateSchool(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	fmt.Println("SCHOOL: ", input)
-
-	output, err := h.schoolUseCase.CreateSchool(input)
+	output, err := h.schoolUseCase.CreateSchool(r.Context(), input)
 	if err != nil {
 		utils.HandleUseCaseError(w, err)
 		return
@@ -40,7 +37,7 @@ func (h *SchoolHandler) CreateSchool(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *SchoolHandler) GetAllSchool(w http.ResponseWriter, r *http.Request) {
-	out, err := h.schoolUseCase.GetAllSchool()
+	out, err := h.schoolUseCase.GetAllSchool(r.Context())
 	if err != nil {
 		utils.HandleUseCaseError(w, err)
 		return
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"educnet/internal/middleware"
+	"educnet/internal/usecase"
+	"educnet/internal/utils"
+)
+
+// ! StatsHandler statistiques agrégées de l'école (admin)
+type StatsHandler struct {
+	statsUC usecase.StatsUseCase
+}
+
+func NewStatsHandler(statsUC usecase.StatsUseCase) *StatsHandler {
+	return &StatsHandler{statsUC: statsUC}
+}
+
+// ! GET /api/admin/stats?from=2026-09-01&to=2026-09-30&granularity=day|week|month
+// ! Défaut : 30 derniers jours, granularité jour
+func (h *StatsHandler) GetStats(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	query := r.URL.Query()
+	from, err := parseDateParam(query.Get("from"))
+	if err != nil {
+		utils.BadRequest(w, "Invalid 'from' date (expected YYYY-MM-DD)")
+		return
+	}
+	to, err := parseDateParam(query.Get("to"))
+	if err != nil {
+		utils.BadRequest(w, "Invalid 'to' date (expected YYYY-MM-DD)")
+		return
+	}
+
+	stats, err := h.statsUC.GetStats(r.Context(), claims.UserID, from, to, query.Get("granularity"))
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Statistics retrieved", stats)
+}
+
+// ! parseDateParam date YYYY-MM-DD optionnelle (zéro si absente)
+func parseDateParam(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
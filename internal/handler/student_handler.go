@@ -84,7 +84,7 @@ func (h *StudentHandler) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp, err := h.studentUC.RegisterStudent(&req)
+	resp, err := h.studentUC.RegisterStudent(r.Context(), &req)
 	if err != nil {
 		utils.HandleUseCaseError(w, err) // 400/409/422/500 auto
 		return
@@ -100,7 +100,7 @@ func (h *StudentHandler) GetMyClasses(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	classes, err := h.studentUC.GetStudentClasses(claims.UserID)
+	classes, err := h.studentUC.GetStudentClasses(r.Context(), claims.UserID)
 	if err != nil {
 		utils.HandleUseCaseError(w, err)
 		return
@@ -29,7 +29,7 @@ func (h *SubjectHandler) GetSubjectsBySchoolID(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	out, err := h.subjectUseCase.GetAllBySchoolID(id)
+	out, err := h.subjectUseCase.GetAllBySchoolID(r.Context(), id)
 	if err != nil {
 		utils.HandleUseCaseError(w, err)
 		return
@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"educnet/internal/handler/dto"
+	"educnet/internal/middleware"
+	"educnet/internal/usecase"
+	"educnet/internal/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// ! SuperAdminHandler console plateforme (gestion de toutes les écoles)
+type SuperAdminHandler struct {
+	superAdminUC usecase.SuperAdminUseCase
+}
+
+func NewSuperAdminHandler(superAdminUC usecase.SuperAdminUseCase) *SuperAdminHandler {
+	return &SuperAdminHandler{superAdminUC: superAdminUC}
+}
+
+// GET /api/superadmin/schools
+func (h *SuperAdminHandler) ListSchools(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	schools, err := h.superAdminUC.ListSchools(r.Context(), claims.UserID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Schools retrieved", schools)
+}
+
+// GET /api/superadmin/schools/{id}
+func (h *SuperAdminHandler) GetSchool(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	schoolID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid school ID")
+		return
+	}
+
+	school, err := h.superAdminUC.GetSchool(r.Context(), claims.UserID, schoolID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "School retrieved", school)
+}
+
+// POST /api/superadmin/schools/{id}/suspend
+func (h *SuperAdminHandler) SuspendSchool(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	schoolID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid school ID")
+		return
+	}
+
+	var req dto.SuspendSchoolRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	school, err := h.superAdminUC.SuspendSchool(r.Context(), claims.UserID, schoolID, &req, r.RemoteAddr)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "School suspended successfully", school)
+}
+
+// POST /api/superadmin/schools/{id}/reactivate
+func (h *SuperAdminHandler) ReactivateSchool(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	schoolID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid school ID")
+		return
+	}
+
+	school, err := h.superAdminUC.ReactivateSchool(r.Context(), claims.UserID, schoolID, r.RemoteAddr)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "School reactivated successfully", school)
+}
+
+// POST /api/superadmin/schools/{id}/impersonate
+func (h *SuperAdminHandler) Impersonate(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	schoolID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid school ID")
+		return
+	}
+
+	var req dto.ImpersonateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+	if req.Reason == "" {
+		utils.BadRequest(w, "reason is required")
+		return
+	}
+
+	resp, err := h.superAdminUC.ImpersonateSchoolAdmin(r.Context(), claims.UserID, schoolID, &req, r.RemoteAddr)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Impersonation token issued", resp)
+}
+
+// POST /api/superadmin/schools/{id}/transfer-ownership
+func (h *SuperAdminHandler) TransferOwnership(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	schoolID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.BadRequest(w, "Invalid school ID")
+		return
+	}
+
+	var req dto.TransferOwnershipRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.BadRequest(w, "Invalid request body")
+		return
+	}
+	if req.NewAdminUserID <= 0 {
+		utils.BadRequest(w, "new_admin_user_id is required")
+		return
+	}
+
+	school, err := h.superAdminUC.TransferOwnership(r.Context(), claims.UserID, schoolID, &req, r.RemoteAddr)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "School ownership transferred successfully", school)
+}
+
+// GET /api/superadmin/audit-logs
+func (h *SuperAdminHandler) GetAuditLogs(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	logs, err := h.superAdminUC.GetAuditLogs(r.Context(), claims.UserID)
+	if err != nil {
+		utils.HandleUseCaseError(w, err)
+		return
+	}
+
+	utils.OK(w, "Audit logs retrieved", logs)
+}
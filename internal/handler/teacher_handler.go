@@ -84,7 +84,7 @@ func (h *TeacherHandler) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp, err := h.teacherUC.RegisterTeacher(&req)
+	resp, err := h.teacherUC.RegisterTeacher(r.Context(), &req)
 	if err != nil {
 		utils.HandleUseCaseError(w, err) // 400/409/422/500 auto
 		return
@@ -100,7 +100,7 @@ func (h *TeacherHandler) GetMySubjects(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	subjects, err := h.teacherUC.GetTeacherSubjects(claims.UserID)
+	subjects, err := h.teacherUC.GetTeacherSubjects(r.Context(), claims.UserID)
 	if err != nil {
 		utils.HandleUseCaseError(w, err)
 		return
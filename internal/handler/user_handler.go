@@ -25,7 +25,7 @@ func (h *UserHandler) GetMe(w http.ResponseWriter, r *http.Request) {
 	}
 
 	//! Get full user info from database
-	user, err := h.userRepo.FindByID(claims.UserID)
+	user, err := h.userRepo.FindByID(r.Context(), claims.UserID)
 	if err != nil {
 		utils.NotFound(w, "User not found")
 		return
@@ -0,0 +1,125 @@
+// Package ical écrit des calendriers iCalendar (RFC 5545) : un VCALENDAR
+// contenant des VEVENT, avec l'échappement des textes et le repliement des
+// lignes à 75 octets exigés par la norme.
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	ContentType = "text/calendar; charset=utf-8"
+
+	dateFormat     = "20060102"
+	dateTimeFormat = "20060102T150405Z"
+	maxLineOctets  = 75
+)
+
+// ! Event un VEVENT ; Start / End en UTC (End exclusif pour AllDay)
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	Categories  []string
+	URL         string
+	Start       time.Time
+	End         time.Time
+	AllDay      bool
+	RRule       string //! sans le préfixe "RRULE:"
+	Updated     time.Time
+}
+
+// ! Calendar en-têtes du VCALENDAR (Name = X-WR-CALNAME affiché par les applications)
+type Calendar struct {
+	ProdID string
+	Name   string
+	Events []Event
+}
+
+// ! Write écrit le calendrier ; les lignes sont terminées par CRLF
+func (c *Calendar) Write(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	line := func(name, value string) {
+		writeFolded(bw, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", escapeText(c.ProdID))
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	if c.Name != "" {
+		line("X-WR-CALNAME", escapeText(c.Name))
+	}
+
+	stamp := time.Now().UTC().Format(dateTimeFormat)
+	for _, e := range c.Events {
+		line("BEGIN", "VEVENT")
+		line("UID", escapeText(e.UID))
+		if e.Updated.IsZero() {
+			line("DTSTAMP", stamp)
+		} else {
+			line("DTSTAMP", e.Updated.UTC().Format(dateTimeFormat))
+		}
+		if e.AllDay {
+			line("DTSTART;VALUE=DATE", e.Start.UTC().Format(dateFormat))
+			line("DTEND;VALUE=DATE", e.End.UTC().Format(dateFormat))
+		} else {
+			line("DTSTART", e.Start.UTC().Format(dateTimeFormat))
+			line("DTEND", e.End.UTC().Format(dateTimeFormat))
+		}
+		if e.RRule != "" {
+			line("RRULE", e.RRule)
+		}
+		line("SUMMARY", escapeText(e.Summary))
+		if e.Description != "" {
+			line("DESCRIPTION", escapeText(e.Description))
+		}
+		if e.Location != "" {
+			line("LOCATION", escapeText(e.Location))
+		}
+		if len(e.Categories) > 0 {
+			categories := make([]string, len(e.Categories))
+			for i, category := range e.Categories {
+				categories[i] = escapeText(category)
+			}
+			line("CATEGORIES", strings.Join(categories, ","))
+		}
+		if e.URL != "" {
+			line("URL", e.URL)
+		}
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+
+	return bw.Flush()
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// ! escapeText échappe une valeur TEXT (antislash, ; , et retours à la ligne)
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+// ! writeFolded replie la ligne tous les 75 octets sans couper un caractère UTF-8
+func writeFolded(w *bufio.Writer, s string) {
+	limit := maxLineOctets
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		w.WriteString(s[:cut])
+		w.WriteString("\r\n ")
+		s = s[cut:]
+		limit = maxLineOctets - 1 //! l'espace de continuation compte
+	}
+	w.WriteString(s)
+	w.WriteString("\r\n")
+}
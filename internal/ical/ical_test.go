@@ -0,0 +1,92 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCalendar_Write(t *testing.T) {
+	start := time.Date(2026, 9, 7, 8, 0, 0, 0, time.UTC)
+	cal := &Calendar{
+		ProdID: "-//EducNet//Calendar//FR",
+		Name:   "Lycée, calendrier",
+		Events: []Event{
+			{
+				UID:         "event-1@educnet",
+				Summary:     "Maths; chapitre 1",
+				Description: "Ligne 1\nLigne 2",
+				Start:       start,
+				End:         start.Add(time.Hour),
+				RRule:       "FREQ=WEEKLY;BYDAY=MO",
+				Categories:  []string{"lesson"},
+			},
+			{
+				UID:     "event-2@educnet",
+				Summary: "Vacances",
+				Start:   time.Date(2026, 10, 24, 0, 0, 0, 0, time.UTC),
+				End:     time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC),
+				AllDay:  true,
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := cal.Write(&buf); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"X-WR-CALNAME:Lycée\\, calendrier\r\n",
+		"DTSTART:20260907T080000Z\r\n",
+		"RRULE:FREQ=WEEKLY;BYDAY=MO\r\n",
+		"SUMMARY:Maths\\; chapitre 1\r\n",
+		"DESCRIPTION:Ligne 1\\nLigne 2\r\n",
+		"DTSTART;VALUE=DATE:20261024\r\n",
+		"DTEND;VALUE=DATE:20261102\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "BEGIN:VEVENT") != 2 {
+		t.Errorf("want 2 VEVENT, got:\n%s", out)
+	}
+}
+
+func TestWriteFolded(t *testing.T) {
+	var buf bytes.Buffer
+	cal := &Calendar{ProdID: "x", Events: []Event{{
+		UID:     "1",
+		Summary: strings.Repeat("é", 100), //! 200 octets
+		Start:   time.Now(),
+		End:     time.Now().Add(time.Hour),
+	}}}
+	if err := cal.Write(&buf); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	var summary strings.Builder
+	inSummary := false
+	for _, line := range strings.Split(buf.String(), "\r\n") {
+		if len(line) > maxLineOctets {
+			t.Errorf("line of %d octets: %q", len(line), line)
+		}
+		switch {
+		case strings.HasPrefix(line, "SUMMARY:"):
+			inSummary = true
+			summary.WriteString(strings.TrimPrefix(line, "SUMMARY:"))
+		case inSummary && strings.HasPrefix(line, " "):
+			summary.WriteString(line[1:])
+		default:
+			inSummary = false
+		}
+	}
+	if summary.String() != strings.Repeat("é", 100) {
+		t.Errorf("unfolded summary = %q", summary.String())
+	}
+}
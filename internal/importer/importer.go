@@ -0,0 +1,113 @@
+package importer
+
+import (
+	"bytes"
+	"educnet/internal/domain"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// ! Record une ligne du fichier, indexée par champ (après mapping des colonnes)
+type Record struct {
+	Line   int //! numéro de ligne dans le fichier (header = 1)
+	Values map[string]string
+}
+
+// ! Get retourne la valeur d'un champ (vide si absent)
+func (r Record) Get(field string) string {
+	return r.Values[field]
+}
+
+// ! ReadRows lit un fichier CSV ou XLSX (première feuille) en lignes de cellules
+func ReadRows(filename string, r io.Reader) ([][]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read import file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return readCSV(data)
+	case ".xlsx":
+		return readXLSX(data)
+	default:
+		return nil, domain.ErrImportUnsupportedFormat
+	}
+}
+
+// ! readCSV accepte ',' ou ';' (export Excel FR) et ignore le BOM UTF-8
+func readCSV(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	if firstLine, _, _ := bytes.Cut(data, []byte("\n")); bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		reader.Comma = ';'
+	}
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("parse csv: %w", err)
+	}
+	return rows, nil
+}
+
+// ! MapRecords applique le mapping champ → en-tête de colonne
+// ! Un champ absent du mapping est cherché sous son propre nom (insensible à la casse)
+// ! Les lignes entièrement vides sont ignorées
+func MapRecords(rows [][]string, mapping map[string]string, fields []string, required []string) ([]Record, error) {
+	if len(rows) < 2 {
+		return nil, domain.ErrImportEmpty
+	}
+
+	header := map[string]int{}
+	for i, name := range rows[0] {
+		header[normalizeHeader(name)] = i
+	}
+
+	columns := map[string]int{}
+	for _, field := range fields {
+		column := field
+		if mapped, ok := mapping[field]; ok && mapped != "" {
+			column = mapped
+		}
+		if idx, ok := header[normalizeHeader(column)]; ok {
+			columns[field] = idx
+		}
+	}
+	for _, field := range required {
+		if _, ok := columns[field]; !ok {
+			return nil, fmt.Errorf("%w: %s", domain.ErrImportMissingColumn, field)
+		}
+	}
+
+	var records []Record
+	for i, row := range rows[1:] {
+		record := Record{Line: i + 2, Values: map[string]string{}}
+		empty := true
+		for field, idx := range columns {
+			if idx < len(row) {
+				value := strings.TrimSpace(row[idx])
+				record.Values[field] = value
+				if value != "" {
+					empty = false
+				}
+			}
+		}
+		if !empty {
+			records = append(records, record)
+		}
+	}
+	if len(records) == 0 {
+		return nil, domain.ErrImportEmpty
+	}
+	return records, nil
+}
+
+func normalizeHeader(name string) string {
+	return strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+}
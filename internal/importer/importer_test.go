@@ -0,0 +1,101 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"educnet/internal/domain"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReadRows_CSV(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"Comma separated", "email,first_name\nrado@test.mg,Rado\n"},
+		{"Semicolon separated with BOM", "\xef\xbb\xbfemail;first_name\nrado@test.mg;Rado\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := ReadRows("eleves.csv", strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("ReadRows() error = %v", err)
+			}
+			if len(rows) != 2 || rows[1][0] != "rado@test.mg" || rows[1][1] != "Rado" {
+				t.Errorf("ReadRows() = %v", rows)
+			}
+		})
+	}
+}
+
+func TestReadRows_UnsupportedFormat(t *testing.T) {
+	_, err := ReadRows("eleves.pdf", strings.NewReader("x"))
+	if err != domain.ErrImportUnsupportedFormat {
+		t.Errorf("ReadRows() error = %v, want %v", err, domain.ErrImportUnsupportedFormat)
+	}
+}
+
+func TestReadRows_XLSX(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	files := map[string]string{
+		"xl/sharedStrings.xml": `<sst><si><t>email</t></si><si><t>first_name</t></si><si><r><t>Ra</t></r><r><t>do</t></r></si></sst>`,
+		"xl/worksheets/sheet1.xml": `<worksheet><sheetData>
+			<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c></row>
+			<row r="2"><c r="A2" t="inlineStr"><is><t>rado@test.mg</t></is></c><c r="C2"><v>42</v></c><c r="B2" t="s"><v>2</v></c></row>
+		</sheetData></worksheet>`,
+	}
+	for name, content := range files {
+		w, _ := zw.Create(name)
+		w.Write([]byte(content))
+	}
+	zw.Close()
+
+	rows, err := ReadRows("eleves.xlsx", &buf)
+	if err != nil {
+		t.Fatalf("ReadRows() error = %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("ReadRows() rows = %d, want 2", len(rows))
+	}
+	if got := strings.Join(rows[1], "|"); got != "rado@test.mg|Rado|42" {
+		t.Errorf("ReadRows() second row = %q", got)
+	}
+}
+
+func TestMapRecords(t *testing.T) {
+	rows := [][]string{
+		{"Courriel", "Prénom", "Classe"},
+		{"rado@test.mg", "Rado", "6ème A"},
+		{"", "", ""},
+		{"soa@test.mg", "Soa"},
+	}
+	fields := []string{"email", "first_name", "class"}
+
+	records, err := MapRecords(rows, map[string]string{"email": "courriel", "first_name": "Prénom", "class": "Classe"}, fields, []string{"email"})
+	if err != nil {
+		t.Fatalf("MapRecords() error = %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("MapRecords() records = %d, want 2 (empty row skipped)", len(records))
+	}
+	if records[0].Line != 2 || records[0].Get("class") != "6ème A" {
+		t.Errorf("MapRecords() first record = %+v", records[0])
+	}
+	if records[1].Line != 4 || records[1].Get("class") != "" {
+		t.Errorf("MapRecords() short row = %+v", records[1])
+	}
+
+	_, err = MapRecords(rows, nil, fields, []string{"email"})
+	if !errors.Is(err, domain.ErrImportMissingColumn) {
+		t.Errorf("MapRecords() without mapping error = %v, want %v", err, domain.ErrImportMissingColumn)
+	}
+
+	_, err = MapRecords(rows[:1], nil, fields, nil)
+	if err != domain.ErrImportEmpty {
+		t.Errorf("MapRecords() header only error = %v, want %v", err, domain.ErrImportEmpty)
+	}
+}
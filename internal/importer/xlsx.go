@@ -0,0 +1,149 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ! Sous-ensemble SpreadsheetML nécessaire pour lire des valeurs de cellules
+
+type xlsxSharedStrings struct {
+	Items []xlsxStringItem `xml:"si"`
+}
+
+type xlsxStringItem struct {
+	Text string        `xml:"t"`
+	Runs []xlsxRichRun `xml:"r"`
+}
+
+type xlsxRichRun struct {
+	Text string `xml:"t"`
+}
+
+func (si xlsxStringItem) value() string {
+	if len(si.Runs) == 0 {
+		return si.Text
+	}
+	var b strings.Builder
+	for _, run := range si.Runs {
+		b.WriteString(run.Text)
+	}
+	return b.String()
+}
+
+type xlsxWorksheet struct {
+	Rows []xlsxRow `xml:"sheetData>row"`
+}
+
+type xlsxRow struct {
+	Cells []xlsxCell `xml:"c"`
+}
+
+type xlsxCell struct {
+	Ref       string         `xml:"r,attr"`
+	Type      string         `xml:"t,attr"`
+	Value     string         `xml:"v"`
+	InlineStr xlsxStringItem `xml:"is"`
+}
+
+// ! readXLSX lit la première feuille d'un classeur .xlsx
+func readXLSX(data []byte) ([][]string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("open xlsx: %w", err)
+	}
+
+	files := map[string]*zip.File{}
+	var sheets []string
+	for _, f := range archive.File {
+		files[f.Name] = f
+		if path.Dir(f.Name) == "xl/worksheets" && strings.HasSuffix(f.Name, ".xml") {
+			sheets = append(sheets, f.Name)
+		}
+	}
+	if len(sheets) == 0 {
+		return nil, fmt.Errorf("open xlsx: no worksheet found")
+	}
+	sort.Strings(sheets)
+	sheetName := sheets[0]
+	if _, ok := files["xl/worksheets/sheet1.xml"]; ok {
+		sheetName = "xl/worksheets/sheet1.xml"
+	}
+
+	var shared xlsxSharedStrings
+	if f, ok := files["xl/sharedStrings.xml"]; ok {
+		if err := decodeZipXML(f, &shared); err != nil {
+			return nil, fmt.Errorf("read shared strings: %w", err)
+		}
+	}
+
+	var sheet xlsxWorksheet
+	if err := decodeZipXML(files[sheetName], &sheet); err != nil {
+		return nil, fmt.Errorf("read worksheet: %w", err)
+	}
+
+	rows := make([][]string, 0, len(sheet.Rows))
+	for _, row := range sheet.Rows {
+		var cells []string
+		for i, cell := range row.Cells {
+			col := columnIndex(cell.Ref)
+			if col < 0 {
+				col = i
+			}
+			for len(cells) <= col {
+				cells = append(cells, "")
+			}
+			cells[col] = cellValue(cell, shared)
+		}
+		rows = append(rows, cells)
+	}
+	return rows, nil
+}
+
+func cellValue(cell xlsxCell, shared xlsxSharedStrings) string {
+	switch cell.Type {
+	case "s":
+		idx, err := strconv.Atoi(cell.Value)
+		if err != nil || idx < 0 || idx >= len(shared.Items) {
+			return ""
+		}
+		return shared.Items[idx].value()
+	case "inlineStr":
+		return cell.InlineStr.value()
+	default:
+		return cell.Value
+	}
+}
+
+// ! columnIndex "B12" → 1 (-1 si référence absente)
+func columnIndex(ref string) int {
+	col := 0
+	n := 0
+	for _, c := range ref {
+		if c < 'A' || c > 'Z' {
+			break
+		}
+		col = col*26 + int(c-'A'+1)
+		n++
+	}
+	if n == 0 {
+		return -1
+	}
+	return col - 1
+}
+
+func decodeZipXML(f *zip.File, v any) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	return xml.NewDecoder(io.LimitReader(rc, 64<<20)).Decode(v)
+}
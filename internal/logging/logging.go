@@ -0,0 +1,152 @@
+// Package logging configure log/slog en JSON : niveau configurable, champs de
+// requête (request_id, user_id, school_id...) ajoutés depuis le context et
+// masquage des valeurs sensibles (mots de passe, tokens, en-têtes d'auth).
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// ! Redacted valeur loggée à la place d'un secret
+const Redacted = "[REDACTED]"
+
+// ! secretKeys fragments de clés dont la valeur n'est jamais loggée
+var secretKeys = []string{"password", "passwd", "secret", "token", "authorization", "cookie", "api_key", "apikey"}
+
+// ! ParseLevel debug | info | warn | error (vide = info)
+func ParseLevel(level string) (slog.Level, error) {
+	var l slog.Level
+	if strings.TrimSpace(level) == "" {
+		return slog.LevelInfo, nil
+	}
+	if err := l.UnmarshalText([]byte(strings.TrimSpace(level))); err != nil {
+		return slog.LevelInfo, fmt.Errorf("invalid log level %q", level)
+	}
+	return l, nil
+}
+
+// ! New logger JSON sur w : champs du context + masquage des secrets
+func New(w io.Writer, level slog.Level) *slog.Logger {
+	handler := slog.NewJSONHandler(w, &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: redactAttr,
+	})
+	return slog.New(&contextHandler{Handler: handler})
+}
+
+// ! Setup installe le logger par défaut (slog et package log standard)
+func Setup(w io.Writer, level string) error {
+	l, err := ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(New(w, l))
+	return nil
+}
+
+// ! IsSecretKey vrai si la valeur associée à key doit être masquée
+func IsSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, fragment := range secretKeys {
+		if strings.Contains(key, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// ! RedactQuery query string avec les paramètres sensibles masqués (?token=...)
+func RedactQuery(query url.Values) string {
+	if len(query) == 0 {
+		return ""
+	}
+	redacted := make(url.Values, len(query))
+	for key, values := range query {
+		if IsSecretKey(key) {
+			redacted[key] = []string{Redacted}
+			continue
+		}
+		redacted[key] = values
+	}
+	return redacted.Encode()
+}
+
+func redactAttr(groups []string, a slog.Attr) slog.Attr {
+	if a.Value.Kind() != slog.KindGroup && IsSecretKey(a.Key) {
+		return slog.String(a.Key, Redacted)
+	}
+	return a
+}
+
+// ! ==================== CONTEXT ====================
+
+type requestKey struct{}
+
+// ! requestInfo champs partagés par tous les logs d'une requête. Mutable : JWTAuth
+// ! y ajoute l'utilisateur, visible ensuite par le log d'accès des middlewares englobants.
+type requestInfo struct {
+	id    string
+	mu    sync.Mutex
+	attrs []slog.Attr
+}
+
+// ! WithRequestID context de requête : tous les logs *Context porteront request_id
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestKey{}, &requestInfo{
+		id:    requestID,
+		attrs: []slog.Attr{slog.String("request_id", requestID)},
+	})
+}
+
+// ! RequestID identifiant de la requête du context ("" hors requête)
+func RequestID(ctx context.Context) string {
+	if info, ok := ctx.Value(requestKey{}).(*requestInfo); ok {
+		return info.id
+	}
+	return ""
+}
+
+// ! AddAttrs ajoute des champs aux logs de la requête (sans effet hors requête)
+func AddAttrs(ctx context.Context, attrs ...slog.Attr) {
+	info, ok := ctx.Value(requestKey{}).(*requestInfo)
+	if !ok {
+		return
+	}
+	info.mu.Lock()
+	info.attrs = append(info.attrs, attrs...)
+	info.mu.Unlock()
+}
+
+func (i *requestInfo) snapshot() []slog.Attr {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return append([]slog.Attr(nil), i.attrs...)
+}
+
+// ! contextHandler ajoute les champs de requête du context à chaque enregistrement
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if ctx != nil {
+		if info, ok := ctx.Value(requestKey{}).(*requestInfo); ok {
+			r.AddAttrs(info.snapshot()...)
+		}
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,94 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func decode(t *testing.T, buf *bytes.Buffer) map[string]any {
+	t.Helper()
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("invalid JSON log %q: %v", buf.String(), err)
+	}
+	return entry
+}
+
+func TestNew_RequestFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, slog.LevelInfo)
+
+	ctx := WithRequestID(context.Background(), "req-1")
+	AddAttrs(ctx, slog.Int("user_id", 7), slog.Int("school_id", 3))
+	logger.InfoContext(ctx, "hello")
+
+	entry := decode(t, &buf)
+	if entry["request_id"] != "req-1" || entry["user_id"] != float64(7) || entry["school_id"] != float64(3) {
+		t.Errorf("log entry = %v", entry)
+	}
+	if RequestID(ctx) != "req-1" || RequestID(context.Background()) != "" {
+		t.Errorf("RequestID() mismatch")
+	}
+}
+
+func TestNew_RedactsSecrets(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, slog.LevelInfo)
+
+	logger.Info("login", "email", "a@b.mg", "password", "hunter2", "Authorization", "Bearer abc", "refresh_token", "xyz")
+
+	out := buf.String()
+	for _, secret := range []string{"hunter2", "Bearer abc", "xyz"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("log leaks %q: %s", secret, out)
+		}
+	}
+	if entry := decode(t, &buf); entry["email"] != "a@b.mg" || entry["password"] != Redacted {
+		t.Errorf("log entry = %v", entry)
+	}
+}
+
+func TestNew_Level(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, slog.LevelWarn)
+	logger.Info("ignored")
+	if buf.Len() != 0 {
+		t.Errorf("info logged at warn level: %s", buf.String())
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    slog.Level
+		wantErr bool
+	}{
+		{"", slog.LevelInfo, false},
+		{"debug", slog.LevelDebug, false},
+		{"WARN", slog.LevelWarn, false},
+		{"error", slog.LevelError, false},
+		{"verbose", slog.LevelInfo, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseLevel(tt.in)
+		if got != tt.want || (err != nil) != tt.wantErr {
+			t.Errorf("ParseLevel(%q) = %v, %v", tt.in, got, err)
+		}
+	}
+}
+
+func TestRedactQuery(t *testing.T) {
+	q := url.Values{"token": {"secret"}, "class_id": {"4"}}
+	got := RedactQuery(q)
+	if strings.Contains(got, "secret") || !strings.Contains(got, "class_id=4") {
+		t.Errorf("RedactQuery() = %q", got)
+	}
+	if RedactQuery(nil) != "" {
+		t.Errorf("RedactQuery(nil) should be empty")
+	}
+}
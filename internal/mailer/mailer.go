@@ -0,0 +1,57 @@
+package mailer
+
+import (
+	"educnet/internal/config"
+	"fmt"
+	"log/slog"
+	"net/smtp"
+	"strings"
+)
+
+// ! Mailer envoi d'emails transactionnels (identifiants, invitations...)
+type Mailer interface {
+	Send(to, subject, body string) error
+}
+
+// ! New retourne un mailer SMTP si SMTP_HOST est configuré, sinon un mailer qui logge
+func New(cfg config.SMTPConfig) Mailer {
+	if cfg.Host == "" {
+		return &logMailer{}
+	}
+	return &smtpMailer{cfg: cfg}
+}
+
+type smtpMailer struct {
+	cfg config.SMTPConfig
+}
+
+func (m *smtpMailer) Send(to, subject, body string) error {
+	var auth smtp.Auth
+	if m.cfg.Username != "" {
+		auth = smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)
+	}
+
+	msg := strings.Join([]string{
+		"From: " + m.cfg.From,
+		"To: " + to,
+		"Subject: " + subject,
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		body,
+	}, "\r\n")
+
+	addr := m.cfg.Host + ":" + m.cfg.Port
+	if err := smtp.SendMail(addr, auth, m.cfg.From, []string{to}, []byte(msg)); err != nil {
+		return fmt.Errorf("send mail to %s: %w", to, err)
+	}
+	return nil
+}
+
+// ! logMailer développement : aucun envoi réel, le contenu n'est pas loggé (secrets)
+type logMailer struct{}
+
+func (m *logMailer) Send(to, subject, body string) error {
+	slog.Info("mail disabled, not sent", "to", to, "subject", subject)
+	return nil
+}
@@ -0,0 +1,254 @@
+// Package metrics expose des métriques au format texte Prometheus (v0.0.4) sans
+// dépendance externe : compteurs et histogrammes à labels, jauges calculées à la
+// lecture (pool SQL, WebSocket).
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ! DefaultBuckets latences HTTP en secondes (5 ms à 10 s)
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// ! ContentType format d'exposition texte Prometheus
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+type collector interface {
+	write(w io.Writer)
+}
+
+// ! Registry ensemble des métriques exposées par Handler
+type Registry struct {
+	mu         sync.Mutex
+	names      map[string]bool
+	collectors []collector
+}
+
+func NewRegistry() *Registry {
+	return &Registry{names: make(map[string]bool)}
+}
+
+// ! register panique sur un nom dupliqué (erreur de programmation, détectée au démarrage)
+func (r *Registry) register(name string, c collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.names[name] {
+		panic("metrics: duplicate metric " + name)
+	}
+	r.names[name] = true
+	r.collectors = append(r.collectors, c)
+}
+
+// ! WriteTo écrit toutes les métriques au format texte
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	collectors := append([]collector(nil), r.collectors...)
+	r.mu.Unlock()
+
+	var buf bytes.Buffer
+	for _, c := range collectors {
+		c.write(&buf)
+	}
+	return buf.WriteTo(w)
+}
+
+// ! Handler GET /metrics
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", ContentType)
+		r.WriteTo(w)
+	})
+}
+
+// ! ==================== FUNC METRICS ====================
+
+// ! funcMetric valeur calculée à chaque lecture
+type funcMetric struct {
+	name, help, kind string
+	fn               func() float64
+}
+
+func (f *funcMetric) write(w io.Writer) {
+	writeHeader(w, f.name, f.help, f.kind)
+	fmt.Fprintf(w, "%s %s\n", f.name, formatFloat(f.fn()))
+}
+
+// ! NewGaugeFunc jauge lue via fn (connexions ouvertes, clients connectés...)
+func (r *Registry) NewGaugeFunc(name, help string, fn func() float64) {
+	r.register(name, &funcMetric{name: name, help: help, kind: "gauge", fn: fn})
+}
+
+// ! NewCounterFunc compteur maintenu ailleurs (doit être croissant)
+func (r *Registry) NewCounterFunc(name, help string, fn func() float64) {
+	r.register(name, &funcMetric{name: name, help: help, kind: "counter", fn: fn})
+}
+
+// ! ==================== COUNTER ====================
+
+// ! CounterVec compteur par combinaison de labels
+type CounterVec struct {
+	name, help string
+	labels     []string
+	mu         sync.Mutex
+	series     map[string]*counterSeries
+}
+
+type counterSeries struct {
+	values []string
+	value  float64
+}
+
+func (r *Registry) NewCounterVec(name, help string, labels ...string) *CounterVec {
+	c := &CounterVec{name: name, help: help, labels: labels, series: make(map[string]*counterSeries)}
+	r.register(name, c)
+	return c
+}
+
+// ! Add v >= 0 ; labelValues dans l'ordre des labels déclarés
+func (c *CounterVec) Add(v float64, labelValues ...string) {
+	if v < 0 {
+		panic("metrics: counter cannot decrease")
+	}
+	key := seriesKey(c.labels, labelValues)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s, ok := c.series[key]
+	if !ok {
+		s = &counterSeries{values: labelValues}
+		c.series[key] = s
+	}
+	s.value += v
+}
+
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+func (c *CounterVec) write(w io.Writer) {
+	writeHeader(w, c.name, c.help, "counter")
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range sortedKeys(c.series) {
+		s := c.series[key]
+		fmt.Fprintf(w, "%s%s %s\n", c.name, formatLabels(c.labels, s.values, "", ""), formatFloat(s.value))
+	}
+}
+
+// ! ==================== HISTOGRAM ====================
+
+// ! HistogramVec distribution (buckets cumulés, somme, nombre) par combinaison de labels
+type HistogramVec struct {
+	name, help string
+	labels     []string
+	buckets    []float64
+	mu         sync.Mutex
+	series     map[string]*histogramSeries
+}
+
+type histogramSeries struct {
+	values []string
+	counts []uint64 //! par bucket, non cumulés
+	sum    float64
+	count  uint64
+}
+
+// ! NewHistogramVec buckets triés croissants (+Inf implicite)
+func (r *Registry) NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	sorted := append([]float64(nil), buckets...)
+	sort.Float64s(sorted)
+	h := &HistogramVec{name: name, help: help, labels: labels, buckets: sorted, series: make(map[string]*histogramSeries)}
+	r.register(name, h)
+	return h
+}
+
+func (h *HistogramVec) Observe(v float64, labelValues ...string) {
+	key := seriesKey(h.labels, labelValues)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	s, ok := h.series[key]
+	if !ok {
+		s = &histogramSeries{values: labelValues, counts: make([]uint64, len(h.buckets))}
+		h.series[key] = s
+	}
+	if i := sort.SearchFloat64s(h.buckets, v); i < len(h.buckets) {
+		s.counts[i]++
+	}
+	s.sum += v
+	s.count++
+}
+
+func (h *HistogramVec) write(w io.Writer) {
+	writeHeader(w, h.name, h.help, "histogram")
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, key := range sortedKeys(h.series) {
+		s := h.series[key]
+		var cumulative uint64
+		for i, upper := range h.buckets {
+			cumulative += s.counts[i]
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, formatLabels(h.labels, s.values, "le", formatFloat(upper)), cumulative)
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, formatLabels(h.labels, s.values, "le", "+Inf"), s.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, formatLabels(h.labels, s.values, "", ""), formatFloat(s.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, formatLabels(h.labels, s.values, "", ""), s.count)
+	}
+}
+
+// ! ==================== FORMAT ====================
+
+func writeHeader(w io.Writer, name, help, kind string) {
+	help = strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help)
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func seriesKey(labels, values []string) string {
+	if len(values) != len(labels) {
+		panic(fmt.Sprintf("metrics: expected %d label values, got %d", len(labels), len(values)))
+	}
+	return strings.Join(values, "\xff")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ! formatLabels {a="x",b="y"} ; extraName/extraValue pour le label le des buckets
+func formatLabels(names, values []string, extraName, extraValue string) string {
+	if len(names) == 0 && extraName == "" {
+		return ""
+	}
+	escape := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	parts := make([]string, 0, len(names)+1)
+	for i, name := range names {
+		parts = append(parts, fmt.Sprintf(`%s="%s"`, name, escape.Replace(values[i])))
+	}
+	if extraName != "" {
+		parts = append(parts, fmt.Sprintf(`%s="%s"`, extraName, extraValue))
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRegistry_Exposition(t *testing.T) {
+	r := NewRegistry()
+	requests := r.NewCounterVec("test_requests_total", "Requests.", "method", "path")
+	latency := r.NewHistogramVec("test_latency_seconds", "Latency.", []float64{0.1, 1}, "route")
+	r.NewGaugeFunc("test_connections", "Open connections.", func() float64 { return 3 })
+
+	requests.Inc("GET", `/a"b`)
+	requests.Add(2, "GET", `/a"b`)
+	latency.Observe(0.05, "/x")
+	latency.Observe(0.5, "/x")
+	latency.Observe(5, "/x")
+
+	rec := httptest.NewRecorder()
+	r.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	out := rec.Body.String()
+
+	if ct := rec.Header().Get("Content-Type"); ct != ContentType {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	for _, want := range []string{
+		"# TYPE test_requests_total counter",
+		`test_requests_total{method="GET",path="/a\"b"} 3`,
+		"# TYPE test_latency_seconds histogram",
+		`test_latency_seconds_bucket{route="/x",le="0.1"} 1`,
+		`test_latency_seconds_bucket{route="/x",le="1"} 2`,
+		`test_latency_seconds_bucket{route="/x",le="+Inf"} 3`,
+		`test_latency_seconds_sum{route="/x"} 5.55`,
+		`test_latency_seconds_count{route="/x"} 3`,
+		"# TYPE test_connections gauge",
+		"test_connections 3",
+	} {
+		if !strings.Contains(out, want+"\n") {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRegistry_DuplicatePanics(t *testing.T) {
+	r := NewRegistry()
+	r.NewCounterVec("dup_total", "First.")
+	defer func() {
+		if recover() == nil {
+			t.Error("duplicate registration should panic")
+		}
+	}()
+	r.NewGaugeFunc("dup_total", "Second.", func() float64 { return 0 })
+}
+
+func TestCounterVec_LabelCountPanics(t *testing.T) {
+	c := NewRegistry().NewCounterVec("labels_total", "Labels.", "a", "b")
+	defer func() {
+		if recover() == nil {
+			t.Error("wrong label count should panic")
+		}
+	}()
+	c.Inc("only-one")
+}
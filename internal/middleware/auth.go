@@ -3,27 +3,31 @@ package middleware
 import (
 	"context"
 	"educnet/internal/auth"
+	"educnet/internal/logging"
 	"educnet/internal/utils"
-	"log"
+	"fmt"
+	"log/slog"
 	"net/http"
 	"strings"
-	"fmt"
+
+	"github.com/gorilla/mux"
 )
 
 type contextKey string
 
 const UserContextKey contextKey = "user"
 
+// ChangePasswordRoute nom de la seule route accessible avec un token restreint
+// (must_change_password) ; à poser sur la route avec mux.Route.Name
+const ChangePasswordRoute = "change-password"
+
 // JWTAuth middleware pour protéger les routes
 func JWTAuth(jwtService *auth.JWTService) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Get token from Authorization header
+			// Get token from Authorization header (jamais loggé)
 			authHeader := r.Header.Get("Authorization")
-			log.Printf("[AUTH] Authorization header: %s", authHeader) // DEBUG
-			
 			if authHeader == "" {
-				log.Println("[AUTH] Missing authorization header") // DEBUG
 				utils.Unauthorized(w, "Missing authorization header")
 				return
 			}
@@ -31,31 +35,49 @@ func JWTAuth(jwtService *auth.JWTService) func(http.Handler) http.Handler {
 			// Check Bearer prefix
 			parts := strings.Split(authHeader, " ")
 			if len(parts) != 2 || parts[0] != "Bearer" {
-				log.Printf("[AUTH] Invalid format. Parts: %v", parts) // DEBUG
 				utils.Unauthorized(w, "Invalid authorization header format")
 				return
 			}
 
-			tokenString := parts[1]
-			log.Printf("[AUTH] Token: %s...", tokenString[:20]) // DEBUG (premiers 20 chars)
-
 			// Validate token
-			claims, err := jwtService.ValidateToken(tokenString)
+			claims, err := jwtService.ValidateToken(parts[1])
 			if err != nil {
-				log.Printf("[AUTH] Token validation error: %v", err) // DEBUG
+				slog.DebugContext(r.Context(), "token rejected", "error", err)
 				utils.Unauthorized(w, "Invalid or expired token")
 				return
 			}
 
-			log.Printf("[AUTH] Token valid for user: %s (ID: %d)", claims.Email, claims.UserID) // DEBUG
+			//! Mot de passe à changer : toutes les routes sauf le changement de mot de passe
+			if claims.PasswordChangeRequired && !isChangePasswordRoute(r) {
+				utils.Forbidden(w, "Password change required")
+				return
+			}
+
+			//! Champs utilisateur sur tous les logs de la requête (dont le log d'accès)
+			logging.AddAttrs(r.Context(),
+				slog.Int("user_id", claims.UserID),
+				slog.Int("school_id", claims.SchoolID),
+				slog.String("role", claims.Role),
+			)
 
 			// Add claims to context
 			ctx := context.WithValue(r.Context(), UserContextKey, claims)
+
+			//! Impersonation : superadmin réel visible dans les logs et l'audit
+			if claims.ImpersonatorID > 0 {
+				logging.AddAttrs(ctx, slog.Int("impersonator_id", claims.ImpersonatorID))
+				ctx = auth.WithImpersonator(ctx, claims.ImpersonatorID)
+			}
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
 
+func isChangePasswordRoute(r *http.Request) bool {
+	route := mux.CurrentRoute(r)
+	return route != nil && route.GetName() == ChangePasswordRoute
+}
+
 // GetUserFromContext récupère les claims du context
 func GetUserFromContext(ctx context.Context) (*auth.JWTClaims, bool) {
 	claims, ok := ctx.Value(UserContextKey).(*auth.JWTClaims)
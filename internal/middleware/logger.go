@@ -1,13 +1,102 @@
 package middleware
 
 import (
+	"bufio"
+	"errors"
+	"log/slog"
+	"net"
 	"net/http"
-	"log"
+	"time"
+
+	"educnet/internal/logging"
+
+	"github.com/gorilla/mux"
 )
 
+// Logger log d'accès JSON (une ligne par requête, après la réponse). À placer
+// après RequestID ; les champs utilisateur sont ajoutés par JWTAuth. Le chemin
+// loggé est le template de route mux : les secrets portés par l'URL (token du
+// flux calendrier) n'atteignent pas les logs.
 func Logger(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		log.Printf("📨 %s %s", r.Method, r.URL.Path)
-		next.ServeHTTP(w, r)
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		level := slog.LevelInfo
+		switch {
+		case rec.status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case rec.status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", r.Method),
+			slog.String("path", logPath(r)),
+			slog.Int("status", rec.status),
+			slog.Int("bytes", rec.bytes),
+			slog.Int64("duration_ms", time.Since(start).Milliseconds()),
+			slog.String("remote_addr", r.RemoteAddr),
+			slog.String("user_agent", r.UserAgent()),
+		}
+		if query := logging.RedactQuery(r.URL.Query()); query != "" {
+			attrs = append(attrs, slog.String("query", query))
+		}
+		slog.LogAttrs(r.Context(), level, "http request", attrs...)
 	})
-}
\ No newline at end of file
+}
+
+// logPath template de la route matchée, chemin brut sinon (404 sans route)
+func logPath(r *http.Request) string {
+	if mux.CurrentRoute(r) == nil {
+		return r.URL.Path
+	}
+	return routeTemplate(r)
+}
+
+// statusRecorder mémorise status et taille ; expose Hijack (WebSocket) et Flush (exports)
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	bytes       int
+	wroteHeader bool
+}
+
+func (s *statusRecorder) WriteHeader(status int) {
+	if !s.wroteHeader {
+		s.status = status
+		s.wroteHeader = true
+	}
+	s.ResponseWriter.WriteHeader(status)
+}
+
+func (s *statusRecorder) Write(p []byte) (int, error) {
+	s.wroteHeader = true
+	n, err := s.ResponseWriter.Write(p)
+	s.bytes += n
+	return n, err
+}
+
+func (s *statusRecorder) Flush() {
+	if f, ok := s.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (s *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := s.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+	conn, rw, err := h.Hijack()
+	if err == nil {
+		s.status = http.StatusSwitchingProtocols
+		s.wroteHeader = true
+	}
+	return conn, rw, err
+}
+
+func (s *statusRecorder) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
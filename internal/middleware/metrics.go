@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"educnet/internal/metrics"
+
+	"github.com/gorilla/mux"
+)
+
+// Metrics mesure la latence des requêtes par route (template mux, ex:
+// /api/admin/users/{id}) pour garder une cardinalité bornée. Les connexions
+// WebSocket (101) sont suivies par les jauges du package websocket.
+func Metrics(durations *metrics.HistogramVec) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+
+			if rec.status == http.StatusSwitchingProtocols {
+				return
+			}
+			durations.Observe(time.Since(start).Seconds(), r.Method, routeTemplate(r), strconv.Itoa(rec.status))
+		})
+	}
+}
+
+func routeTemplate(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if tpl, err := route.GetPathTemplate(); err == nil {
+			return tpl
+		}
+	}
+	return "unmatched"
+}
@@ -0,0 +1,140 @@
+package middleware
+
+import (
+	"bytes"
+	"database/sql"
+	"educnet/internal/db"
+	"educnet/internal/utils"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// TenantScope ouvre une transaction par requête avec app.school_id = school du JWT.
+// Les policies RLS (migrations 006, 024) rendent alors impossible toute lecture/écriture
+// cross-école, même si un usecase oublie de vérifier le SchoolID.
+// À placer APRÈS JWTAuth. Le superadmin obtient une transaction plateforme
+// (app.bypass_rls) ; sans école ni superadmin, la RLS ne laisse voir aucune ligne.
+func TenantScope(database *sql.DB) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := GetUserFromContext(r.Context())
+			switch {
+			case ok && claims.Role == "superadmin":
+				serveInTx(w, r, next, func() (*sql.Tx, error) {
+					return db.BeginPlatformTx(r.Context(), database)
+				})
+			case ok && claims.SchoolID > 0:
+				serveInTx(w, r, next, func() (*sql.Tx, error) {
+					return db.BeginTenantTx(r.Context(), database, claims.SchoolID)
+				})
+			default:
+				next.ServeHTTP(w, r)
+			}
+		})
+	}
+}
+
+// PlatformScope transaction plateforme (app.bypass_rls) pour les routes publiques
+// qui cherchent au-delà d'une école : login par email, inscription par slug, annuaire.
+func PlatformScope(database *sql.DB) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			serveInTx(w, r, next, func() (*sql.Tx, error) {
+				return db.BeginPlatformTx(r.Context(), database)
+			})
+		})
+	}
+}
+
+// SchoolScope transaction scopée sur l'école désignée par la variable de route param
+// (routes publiques d'une école, ex: /classes/{schoolId}).
+func SchoolScope(database *sql.DB, param string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			schoolID, err := strconv.Atoi(mux.Vars(r)[param])
+			if err != nil || schoolID <= 0 {
+				utils.BadRequest(w, "Invalid school ID")
+				return
+			}
+			serveInTx(w, r, next, func() (*sql.Tx, error) {
+				return db.BeginTenantTx(r.Context(), database, schoolID)
+			})
+		})
+	}
+}
+
+// serveInTx exécute next dans la transaction ouverte par begin, validée si la
+// réponse est un succès.
+func serveInTx(w http.ResponseWriter, r *http.Request, next http.Handler, begin func() (*sql.Tx, error)) {
+	tx, err := begin()
+	if err != nil {
+		slog.ErrorContext(r.Context(), "begin request transaction", "error", err)
+		utils.InternalServerError(w, "Database unavailable")
+		return
+	}
+	defer tx.Rollback()
+
+	//! La réponse est bufferisée : elle n'est envoyée qu'une fois le commit réussi
+	rec := &bufferedResponse{w: w, header: make(http.Header), status: http.StatusOK}
+	ctx := db.WithTx(r.Context(), tx)
+	next.ServeHTTP(rec, r.WithContext(ctx))
+
+	//! Réponse déjà envoyée en direct (lecture seule) : rien à valider
+	if rec.streaming {
+		return
+	}
+
+	if rec.status < http.StatusBadRequest {
+		if err := db.Commit(ctx); err != nil {
+			slog.ErrorContext(r.Context(), "commit request transaction", "error", err)
+			utils.InternalServerError(w, "Failed to save changes")
+			return
+		}
+	}
+	rec.flush(w)
+}
+
+// StreamingWriter désactive la bufferisation de TenantScope pour une réponse
+// volumineuse (exports) : les écritures partent directement au client et la
+// transaction, toujours ouverte pour les lectures, n'est pas validée.
+// Réservé aux handlers en lecture seule.
+func StreamingWriter(w http.ResponseWriter) http.ResponseWriter {
+	rec, ok := w.(*bufferedResponse)
+	if !ok {
+		return w
+	}
+	rec.streaming = true
+	for key, values := range rec.header {
+		rec.w.Header()[key] = values
+	}
+	//! Un export volumineux peut dépasser le WriteTimeout du serveur
+	http.NewResponseController(rec.w).SetWriteDeadline(time.Time{})
+	return rec.w
+}
+
+// bufferedResponse capture status, headers et body du handler
+type bufferedResponse struct {
+	w         http.ResponseWriter
+	header    http.Header
+	status    int
+	body      bytes.Buffer
+	streaming bool
+}
+
+func (b *bufferedResponse) Header() http.Header { return b.header }
+
+func (b *bufferedResponse) WriteHeader(status int) { b.status = status }
+
+func (b *bufferedResponse) Write(p []byte) (int, error) { return b.body.Write(p) }
+
+func (b *bufferedResponse) flush(w http.ResponseWriter) {
+	for key, values := range b.header {
+		w.Header()[key] = values
+	}
+	w.WriteHeader(b.status)
+	w.Write(b.body.Bytes())
+}
@@ -0,0 +1,355 @@
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// ! DefaultTable table de suivi des migrations appliquées
+const DefaultTable = "schema_migrations"
+
+// ! lockID clé pg_advisory_lock partagée par toutes les instances (démarrages concurrents)
+const lockID int64 = 0x6564_7563_6e65_74 // "educnet"
+
+var (
+	ErrChecksumMismatch = errors.New("migration modified after being applied")
+	ErrNoDownMigration  = errors.New("migration has no down file")
+	ErrUnknownVersion   = errors.New("applied migration not found in migration files")
+)
+
+var fileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// ! Migration une version du schéma (fichiers NNN_nom.up.sql / NNN_nom.down.sql)
+type Migration struct {
+	Version  int
+	Name     string
+	Up       string
+	Down     string
+	Checksum string //! sha256 du fichier up
+}
+
+// ! Status état d'une migration pour `migrate status`
+type Status struct {
+	Version          int
+	Name             string
+	Applied          bool
+	AppliedAt        *time.Time
+	ChecksumMismatch bool
+}
+
+// ! Migrator applique / annule les migrations et les trace dans Table
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+	Table      string
+}
+
+// ! New charge les migrations de fsys (fichiers *.up.sql / *.down.sql à la racine)
+func New(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations, Table: DefaultTable}, nil
+}
+
+// ! Load lit et valide les fichiers de migration, triés par version
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("read migrations: %w", err)
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+
+		version, _ := strconv.Atoi(match[1])
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", entry.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %03d: conflicting names %q and %q", version, m.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			sum := sha256.Sum256(content)
+			m.Up = string(content)
+			m.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %03d_%s: missing up file", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// ! Up applique toutes les migrations en attente (une transaction par migration)
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var done []Migration
+	err := m.locked(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := m.verify(applied); err != nil {
+			return err
+		}
+
+		for _, mig := range m.migrations {
+			if _, ok := applied[mig.Version]; ok {
+				continue
+			}
+			if err := m.run(ctx, conn, mig, mig.Up, true); err != nil {
+				return err
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// ! Down annule les `steps` dernières migrations appliquées
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var done []Migration
+	err := m.locked(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+			mig := m.migrations[i]
+			if _, ok := applied[mig.Version]; !ok {
+				continue
+			}
+			if mig.Down == "" {
+				return fmt.Errorf("%03d_%s: %w", mig.Version, mig.Name, ErrNoDownMigration)
+			}
+			if err := m.run(ctx, conn, mig, mig.Down, false); err != nil {
+				return err
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// ! Status état de chaque migration connue (appliquée, date, checksum modifié)
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+	err := m.locked(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, mig := range m.migrations {
+			status := Status{Version: mig.Version, Name: mig.Name}
+			if rec, ok := applied[mig.Version]; ok {
+				appliedAt := rec.appliedAt
+				status.Applied = true
+				status.AppliedAt = &appliedAt
+				status.ChecksumMismatch = rec.checksum != mig.Checksum
+			}
+			statuses = append(statuses, status)
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+// ! Pending migrations connues non appliquées. Sans verrou ni création de la table
+// ! de suivi (sonde de readiness) : erreur si la base n'a jamais été migrée.
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("migration connection: %w", err)
+	}
+	defer conn.Close()
+
+	applied, err := m.applied(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, mig := range m.migrations {
+		if _, ok := applied[mig.Version]; !ok {
+			pending = append(pending, mig)
+		}
+	}
+	return pending, nil
+}
+
+// ! Baseline marque comme appliquées les migrations <= version sans les exécuter
+// ! (bases créées à la main avec psql avant l'introduction du runner)
+func (m *Migrator) Baseline(ctx context.Context, version int) ([]Migration, error) {
+	var done []Migration
+	err := m.locked(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, mig := range m.migrations {
+			if mig.Version > version {
+				break
+			}
+			if _, ok := applied[mig.Version]; ok {
+				continue
+			}
+			if _, err := conn.ExecContext(ctx,
+				`INSERT INTO `+m.Table+` (version, name, checksum) VALUES ($1, $2, $3)`,
+				mig.Version, mig.Name, mig.Checksum); err != nil {
+				return fmt.Errorf("baseline %03d_%s: %w", mig.Version, mig.Name, err)
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// ! ==================== HELPERS ====================
+
+type appliedMigration struct {
+	checksum  string
+	appliedAt time.Time
+}
+
+// ! locked exécute fn sur une connexion dédiée qui détient le verrou advisory
+func (m *Migrator) locked(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("migration connection: %w", err)
+	}
+	defer conn.Close()
+
+	//! Bloque tant qu'une autre instance migre
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, lockID); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, lockID); err != nil {
+			slog.Error("release migration lock", "error", err)
+		}
+	}()
+
+	if _, err := conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS `+m.Table+` (
+        version INTEGER PRIMARY KEY,
+        name VARCHAR(255) NOT NULL,
+        checksum CHAR(64) NOT NULL,
+        applied_at TIMESTAMP NOT NULL DEFAULT NOW()
+    )`); err != nil {
+		return fmt.Errorf("create %s: %w", m.Table, err)
+	}
+
+	return fn(conn)
+}
+
+func (m *Migrator) applied(ctx context.Context, conn *sql.Conn) (map[int]appliedMigration, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT version, checksum, applied_at FROM `+m.Table)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", m.Table, err)
+	}
+	defer rows.Close()
+
+	applied := map[int]appliedMigration{}
+	for rows.Next() {
+		var version int
+		var rec appliedMigration
+		if err := rows.Scan(&version, &rec.checksum, &rec.appliedAt); err != nil {
+			return nil, fmt.Errorf("scan %s: %w", m.Table, err)
+		}
+		applied[version] = rec
+	}
+	return applied, rows.Err()
+}
+
+// ! verify refuse de migrer si une migration appliquée a été modifiée ou supprimée
+func (m *Migrator) verify(applied map[int]appliedMigration) error {
+	known := make(map[int]Migration, len(m.migrations))
+	for _, mig := range m.migrations {
+		known[mig.Version] = mig
+	}
+
+	for version, rec := range applied {
+		mig, ok := known[version]
+		if !ok {
+			return fmt.Errorf("version %03d: %w", version, ErrUnknownVersion)
+		}
+		if rec.checksum != mig.Checksum {
+			return fmt.Errorf("%03d_%s: %w", mig.Version, mig.Name, ErrChecksumMismatch)
+		}
+	}
+	return nil
+}
+
+// ! run exécute le SQL et met à jour la table de suivi dans la même transaction
+func (m *Migrator) run(ctx context.Context, conn *sql.Conn, mig Migration, script string, up bool) error {
+	direction := "down"
+	if up {
+		direction = "up"
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin %03d_%s: %w", mig.Version, mig.Name, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return fmt.Errorf("%s %03d_%s: %w", direction, mig.Version, mig.Name, err)
+	}
+
+	if up {
+		_, err = tx.ExecContext(ctx,
+			`INSERT INTO `+m.Table+` (version, name, checksum) VALUES ($1, $2, $3)`,
+			mig.Version, mig.Name, mig.Checksum)
+	} else {
+		_, err = tx.ExecContext(ctx, `DELETE FROM `+m.Table+` WHERE version = $1`, mig.Version)
+	}
+	if err != nil {
+		return fmt.Errorf("record %03d_%s: %w", mig.Version, mig.Name, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit %03d_%s: %w", mig.Version, mig.Name, err)
+	}
+	slog.Info("migration applied", "direction", direction, "version", mig.Version, "name", mig.Name)
+	return nil
+}
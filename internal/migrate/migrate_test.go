@@ -0,0 +1,135 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"educnet/internal/testutil"
+	"educnet/migrations"
+)
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"002_add_items.up.sql":   {Data: []byte("CREATE TABLE items (id INT);")},
+		"002_add_items.down.sql": {Data: []byte("DROP TABLE items;")},
+		"001_init.up.sql":        {Data: []byte("CREATE TABLE base (id INT);")},
+		"README.md":              {Data: []byte("ignored")},
+	}
+
+	got, err := Load(fsys)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("Load() = %d migrations, want 2", len(got))
+	}
+	if got[0].Version != 1 || got[1].Version != 2 || got[1].Name != "add_items" {
+		t.Errorf("Load() order = %+v", got)
+	}
+	if got[0].Down != "" || got[1].Down == "" {
+		t.Errorf("Load() down files = %q, %q", got[0].Down, got[1].Down)
+	}
+	if len(got[0].Checksum) != 64 || got[0].Checksum == got[1].Checksum {
+		t.Errorf("Load() checksums = %q, %q", got[0].Checksum, got[1].Checksum)
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+	}{
+		{"Down without up", fstest.MapFS{
+			"001_init.down.sql": {Data: []byte("DROP TABLE base;")},
+		}},
+		{"Conflicting names", fstest.MapFS{
+			"001_init.up.sql":  {Data: []byte("SELECT 1;")},
+			"001_other.up.sql": {Data: []byte("SELECT 2;")},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Load(tt.fsys); err == nil {
+				t.Error("Load() expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestLoad_EmbeddedMigrations(t *testing.T) {
+	got, err := Load(migrations.FS)
+	if err != nil {
+		t.Fatalf("Load(migrations.FS) error = %v", err)
+	}
+	if len(got) == 0 {
+		t.Fatal("Load(migrations.FS) returned no migrations")
+	}
+	for _, m := range got {
+		if m.Down == "" {
+			t.Errorf("migration %03d_%s has no down file", m.Version, m.Name)
+		}
+	}
+}
+
+func TestMigrator_UpDown(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+
+	fsys := fstest.MapFS{
+		"001_items.up.sql":   {Data: []byte("CREATE TABLE migrate_test_items (id INT);")},
+		"001_items.down.sql": {Data: []byte("DROP TABLE migrate_test_items;")},
+		"002_tags.up.sql":    {Data: []byte("CREATE TABLE migrate_test_tags (id INT);")},
+		"002_tags.down.sql":  {Data: []byte("DROP TABLE migrate_test_tags;")},
+	}
+	m, err := New(db, fsys)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	m.Table = "schema_migrations_test"
+	t.Cleanup(func() {
+		db.Exec("DROP TABLE IF EXISTS migrate_test_tags, migrate_test_items, schema_migrations_test")
+	})
+
+	applied, err := m.Up(ctx)
+	if err != nil || len(applied) != 2 {
+		t.Fatalf("Up() = %d migrations, err = %v", len(applied), err)
+	}
+
+	//! Idempotent
+	if applied, err := m.Up(ctx); err != nil || len(applied) != 0 {
+		t.Errorf("Up() again = %d migrations, err = %v", len(applied), err)
+	}
+
+	reverted, err := m.Down(ctx, 1)
+	if err != nil || len(reverted) != 1 || reverted[0].Version != 2 {
+		t.Fatalf("Down(1) = %+v, err = %v", reverted, err)
+	}
+
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if !statuses[0].Applied || statuses[1].Applied {
+		t.Errorf("Status() = %+v, want only 001 applied", statuses)
+	}
+
+	pending, err := m.Pending(ctx)
+	if err != nil || len(pending) != 1 || pending[0].Version != 2 {
+		t.Errorf("Pending() = %+v, err = %v, want [002]", pending, err)
+	}
+
+	//! Fichier appliqué modifié : refus de migrer
+	fsys["001_items.up.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE migrate_test_items (id BIGINT);")}
+	changed, _ := New(db, fsys)
+	changed.Table = m.Table
+	if _, err := changed.Up(ctx); !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("Up() modified migration error = %v, want %v", err, ErrChecksumMismatch)
+	}
+}
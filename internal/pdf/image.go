@@ -0,0 +1,75 @@
+package pdf
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/jpeg" //! décodeurs pour image.DecodeConfig / image.Decode
+	_ "image/png"
+)
+
+var ErrUnsupportedImage = errors.New("pdf: unsupported image format (JPEG or PNG only)")
+
+// ! Image XObject partagé par toutes les pages du document
+type Image struct {
+	name       string
+	width      int
+	height     int
+	colorSpace string
+	filter     string
+	data       []byte
+}
+
+// ! Size dimensions en pixels
+func (img *Image) Size() (int, int) {
+	return img.width, img.height
+}
+
+// ! AddImage ajoute une image JPEG (embarquée telle quelle) ou PNG
+// ! (recompressée en RGB, transparence aplatie sur fond blanc)
+func (d *Document) AddImage(data []byte) (*Image, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+
+	img := &Image{name: fmt.Sprintf("Im%d", len(d.images)+1), width: cfg.Width, height: cfg.Height}
+	switch {
+	case format == "jpeg" && cfg.ColorModel == color.GrayModel:
+		img.colorSpace, img.filter, img.data = "DeviceGray", "DCTDecode", data
+	case format == "jpeg" && cfg.ColorModel != color.CMYKModel:
+		img.colorSpace, img.filter, img.data = "DeviceRGB", "DCTDecode", data
+	case format == "jpeg" || format == "png":
+		//! CMYK (Adobe) ou PNG : conversion en RGB brut
+		decoded, _, err := image.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, ErrUnsupportedImage
+		}
+		compressed, err := deflate(rgb(decoded))
+		if err != nil {
+			return nil, err
+		}
+		img.colorSpace, img.filter, img.data = "DeviceRGB", "FlateDecode", compressed
+	default:
+		return nil, ErrUnsupportedImage
+	}
+
+	d.images = append(d.images, img)
+	return img, nil
+}
+
+// ! rgb pixels RGB 8 bits, alpha composé sur fond blanc
+func rgb(src image.Image) []byte {
+	bounds := src.Bounds()
+	out := make([]byte, 0, bounds.Dx()*bounds.Dy()*3)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r, g, b, a := src.At(x, y).RGBA()
+			white := 0xffff - a
+			out = append(out, byte((r+white)>>8), byte((g+white)>>8), byte((b+white)>>8))
+		}
+	}
+	return out
+}
@@ -0,0 +1,107 @@
+package pdf
+
+import (
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// ! Largeurs (1/1000 em) des caractères ASCII 32..126, métriques AFM Adobe
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+var helveticaBoldWidths = [95]int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+}
+
+// ! TextWidth largeur de s en points. Les lettres accentuées prennent la largeur
+// ! de leur lettre de base (vrai pour Helvetica), le reste une largeur moyenne.
+func TextWidth(s string, font Font, size float64) float64 {
+	widths := &helveticaWidths
+	if font == HelveticaBold {
+		widths = &helveticaBoldWidths
+	}
+
+	total := 0
+	for _, r := range s {
+		base := r
+		if r > '~' {
+			if decomposed := []rune(norm.NFD.String(string(r))); len(decomposed) > 0 {
+				base = decomposed[0]
+			}
+		}
+		if base >= ' ' && base <= '~' {
+			total += widths[base-' ']
+		} else {
+			total += 556
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// ! WrapText découpe s en lignes de largeur <= maxWidth (mots trop longs coupés)
+func WrapText(s string, font Font, size, maxWidth float64) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(s, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if TextWidth(candidate, font, size) <= maxWidth {
+				line = candidate
+				continue
+			}
+			if line != "" {
+				lines = append(lines, line)
+			}
+			//! Mot plus large que la colonne : coupé caractère par caractère
+			for TextWidth(word, font, size) > maxWidth {
+				cut := fitRunes(word, font, size, maxWidth)
+				lines = append(lines, word[:cut])
+				word = word[cut:]
+			}
+			line = word
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// ! Truncate raccourcit s avec "..." pour tenir dans maxWidth
+func Truncate(s string, font Font, size, maxWidth float64) string {
+	if TextWidth(s, font, size) <= maxWidth {
+		return s
+	}
+	ellipsis := "..."
+	available := maxWidth - TextWidth(ellipsis, font, size)
+	if available <= 0 {
+		return ""
+	}
+	return strings.TrimRight(s[:fitRunes(s, font, size, available)], " ") + ellipsis
+}
+
+// ! fitRunes nombre d'octets de s (au moins une rune) qui tiennent dans maxWidth
+func fitRunes(s string, font Font, size, maxWidth float64) int {
+	end := 0
+	for i, r := range s {
+		next := i + len(string(r))
+		if end > 0 && TextWidth(s[:next], font, size) > maxWidth {
+			break
+		}
+		end = next
+	}
+	return end
+}
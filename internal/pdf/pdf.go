@@ -0,0 +1,258 @@
+// Package pdf écrit des documents PDF simples sans dépendance externe :
+// texte en Helvetica (encodage WinAnsi, accents français), traits,
+// rectangles et images JPEG/PNG. Origine des coordonnées en haut à gauche,
+// unités en points (1/72 pouce).
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// ! Format A4 portrait en points
+const (
+	PageWidth  = 595.28
+	PageHeight = 841.89
+)
+
+// ! Font polices standard (les 14 polices de base n'ont pas besoin d'être embarquées)
+type Font int
+
+const (
+	Helvetica Font = iota
+	HelveticaBold
+)
+
+func (f Font) resource() string {
+	if f == HelveticaBold {
+		return "F2"
+	}
+	return "F1"
+}
+
+// ! Document PDF en mémoire
+type Document struct {
+	Title   string
+	Author  string
+	pages   []*Page
+	images  []*Image
+	created time.Time
+}
+
+// ! Page une page A4 ; les opérations sont écrites dans son flux de contenu
+type Page struct {
+	content bytes.Buffer
+}
+
+// ! New document vide
+func New(title string) *Document {
+	return &Document{Title: title, created: time.Now()}
+}
+
+// ! AddPage ajoute une page A4 et la retourne
+func (d *Document) AddPage() *Page {
+	p := &Page{}
+	d.pages = append(d.pages, p)
+	return p
+}
+
+// ! PageCount nombre de pages
+func (d *Document) PageCount() int {
+	return len(d.pages)
+}
+
+// ! Text écrit s avec la ligne de base à (x, y)
+func (p *Page) Text(x, y float64, font Font, size float64, s string) {
+	fmt.Fprintf(&p.content, "BT /%s %s Tf %s %s Td (%s) Tj ET\n",
+		font.resource(), num(size), num(x), num(PageHeight-y), escape(encode(s)))
+}
+
+// ! TextRight écrit s aligné à droite sur x
+func (p *Page) TextRight(x, y float64, font Font, size float64, s string) {
+	p.Text(x-TextWidth(s, font, size), y, font, size, s)
+}
+
+// ! TextCenter écrit s centré sur x
+func (p *Page) TextCenter(x, y float64, font Font, size float64, s string) {
+	p.Text(x-TextWidth(s, font, size)/2, y, font, size, s)
+}
+
+// ! SetGray couleur de trait et de remplissage (0 noir, 1 blanc)
+func (p *Page) SetGray(gray float64) {
+	fmt.Fprintf(&p.content, "%s g %s G\n", num(gray), num(gray))
+}
+
+// ! Line trait de (x1, y1) à (x2, y2)
+func (p *Page) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(&p.content, "%s w %s %s m %s %s l S\n",
+		num(width), num(x1), num(PageHeight-y1), num(x2), num(PageHeight-y2))
+}
+
+// ! Rect rectangle dont le coin supérieur gauche est (x, y)
+func (p *Page) Rect(x, y, w, h, lineWidth float64, fill bool) {
+	op := "S"
+	if fill {
+		op = "f"
+	}
+	fmt.Fprintf(&p.content, "%s w %s %s %s %s re %s\n",
+		num(lineWidth), num(x), num(PageHeight-y-h), num(w), num(h), op)
+}
+
+// ! DrawImage place img dans le rectangle (x, y, w, h)
+func (p *Page) DrawImage(img *Image, x, y, w, h float64) {
+	fmt.Fprintf(&p.content, "q %s 0 0 %s %s %s cm /%s Do Q\n",
+		num(w), num(h), num(x), num(PageHeight-y-h), img.name)
+}
+
+// ! Bytes sérialise le document
+func (d *Document) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := d.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ! WriteTo sérialise le document (objets, table xref, trailer)
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+
+	out := &countingWriter{w: w}
+	var offsets []int64
+	object := func(body func()) {
+		offsets = append(offsets, out.n)
+		fmt.Fprintf(out, "%d 0 obj\n", len(offsets))
+		body()
+		fmt.Fprint(out, "\nendobj\n")
+	}
+	stream := func(dict string, data []byte) {
+		fmt.Fprintf(out, "<< %s /Length %d >>\nstream\n", dict, len(data))
+		out.Write(data)
+		fmt.Fprint(out, "\nendstream")
+	}
+
+	fmt.Fprint(out, "%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	//! Numérotation : 1 catalogue, 2 arbre des pages, 3-4 polices, 5 infos,
+	//! puis images, puis (page, contenu) pour chaque page
+	const firstImageObj = 6
+	firstPageObj := firstImageObj + len(d.images)
+
+	object(func() { fmt.Fprint(out, "<< /Type /Catalog /Pages 2 0 R >>") })
+	object(func() {
+		kids := make([]string, len(d.pages))
+		for i := range d.pages {
+			kids[i] = fmt.Sprintf("%d 0 R", firstPageObj+2*i)
+		}
+		fmt.Fprintf(out, "<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages))
+	})
+	object(func() {
+		fmt.Fprint(out, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	})
+	object(func() {
+		fmt.Fprint(out, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	})
+	object(func() {
+		fmt.Fprintf(out, "<< /Title (%s) /Author (%s) /Producer (educnet) /CreationDate (D:%s) >>",
+			escape(encode(d.Title)), escape(encode(d.Author)), d.created.UTC().Format("20060102150405Z"))
+	})
+
+	xobjects := make([]string, len(d.images))
+	for i, img := range d.images {
+		xobjects[i] = fmt.Sprintf("/%s %d 0 R", img.name, firstImageObj+i)
+		object(func() {
+			stream(fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /%s /BitsPerComponent 8 /Filter /%s",
+				img.width, img.height, img.colorSpace, img.filter), img.data)
+		})
+	}
+	resources := fmt.Sprintf("<< /Font << /F1 3 0 R /F2 4 0 R >> /XObject << %s >> >>", strings.Join(xobjects, " "))
+
+	for i, page := range d.pages {
+		contentObj := firstPageObj + 2*i + 1
+		object(func() {
+			fmt.Fprintf(out, "<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources %s /Contents %d 0 R >>",
+				num(PageWidth), num(PageHeight), resources, contentObj)
+		})
+
+		compressed, err := deflate(page.content.Bytes())
+		if err != nil {
+			return out.n, err
+		}
+		object(func() { stream("/Filter /FlateDecode", compressed) })
+	}
+
+	xref := out.n
+	fmt.Fprintf(out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(out, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.n, out.err
+}
+
+// ! ==================== HELPERS ====================
+
+// ! countingWriter mémorise la position courante (offsets de la table xref)
+type countingWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	c.err = err
+	return n, err
+}
+
+// ! encode UTF-8 -> WinAnsi (caractères non représentables remplacés par '?')
+func encode(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if c, ok := charmap.Windows1252.EncodeRune(r); ok {
+			b.WriteByte(c)
+		} else {
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+// ! escape échappe une chaîne littérale PDF
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`, "\r", `\r`, "\n", `\n`).Replace(s)
+}
+
+// ! num nombre compact (2 décimales max)
+func num(v float64) string {
+	s := fmt.Sprintf("%.2f", v)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "-0" || s == "" {
+		return "0"
+	}
+	return s
+}
+
+func deflate(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,151 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDocument_Structure(t *testing.T) {
+	doc := New("Bulletin")
+	doc.AddPage().Text(50, 50, Helvetica, 12, "Élève (test) \\ é")
+	doc.AddPage().Line(10, 10, 100, 10, 1)
+
+	data, err := doc.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes() error = %v", err)
+	}
+	if !bytes.HasPrefix(data, []byte("%PDF-1.4")) || !bytes.HasSuffix(data, []byte("%%EOF\n")) {
+		t.Fatal("document should start with the PDF header and end with the EOF marker")
+	}
+	if !bytes.Contains(data, []byte("/Count 2")) {
+		t.Error("page tree should count 2 pages")
+	}
+
+	//! Chaque entrée xref pointe sur "N 0 obj"
+	startxref := regexp.MustCompile(`startxref\n(\d+)`).FindSubmatch(data)
+	if startxref == nil {
+		t.Fatal("missing startxref")
+	}
+	xrefOffset, _ := strconv.Atoi(string(startxref[1]))
+	if !bytes.HasPrefix(data[xrefOffset:], []byte("xref\n")) {
+		t.Fatalf("startxref %d does not point to the xref table", xrefOffset)
+	}
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(data[xrefOffset:], -1)
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		want := strconv.Itoa(i+1) + " 0 obj"
+		if !bytes.HasPrefix(data[offset:], []byte(want)) {
+			t.Errorf("xref entry %d points to %q, want %q", i+1, data[offset:offset+10], want)
+		}
+	}
+
+	//! Texte encodé en WinAnsi et échappé dans le premier flux de contenu
+	content := firstContentStream(t, data)
+	if !strings.Contains(content, "(\xc9l\xe8ve \\(test\\) \\\\ \xe9) Tj") {
+		t.Errorf("content stream = %q, want WinAnsi-escaped text", content)
+	}
+}
+
+func TestDocument_Images(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	src.Set(0, 0, color.RGBA{R: 255, A: 255})
+
+	var jpg, pngData bytes.Buffer
+	if err := jpeg.Encode(&jpg, src, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := png.Encode(&pngData, src); err != nil {
+		t.Fatal(err)
+	}
+
+	doc := New("Images")
+	page := doc.AddPage()
+	for _, data := range [][]byte{jpg.Bytes(), pngData.Bytes()} {
+		img, err := doc.AddImage(data)
+		if err != nil {
+			t.Fatalf("AddImage() error = %v", err)
+		}
+		if w, h := img.Size(); w != 4 || h != 2 {
+			t.Errorf("Size() = %dx%d, want 4x2", w, h)
+		}
+		page.DrawImage(img, 10, 10, 40, 20)
+	}
+	if _, err := doc.AddImage([]byte("<svg/>")); err != ErrUnsupportedImage {
+		t.Errorf("AddImage(svg) error = %v, want ErrUnsupportedImage", err)
+	}
+
+	data, err := doc.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes() error = %v", err)
+	}
+	for _, want := range []string{"/Filter /DCTDecode", "/Filter /FlateDecode", "/Im1 6 0 R /Im2 7 0 R"} {
+		if !bytes.Contains(data, []byte(want)) {
+			t.Errorf("document should contain %q", want)
+		}
+	}
+}
+
+func TestTextWidth(t *testing.T) {
+	if got := TextWidth("AV", Helvetica, 10); got != 13.34 {
+		t.Errorf("TextWidth(AV) = %v, want 13.34", got)
+	}
+	if TextWidth("é", Helvetica, 10) != TextWidth("e", Helvetica, 10) {
+		t.Error("accented letters should use their base letter width")
+	}
+	if TextWidth("Moyenne", HelveticaBold, 10) <= TextWidth("Moyenne", Helvetica, 10) {
+		t.Error("bold text should be wider")
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	lines := WrapText("Bon trimestre, continuez ainsi", Helvetica, 10, 80)
+	if len(lines) < 2 {
+		t.Fatalf("WrapText() = %q, want several lines", lines)
+	}
+	for _, line := range lines {
+		if TextWidth(line, Helvetica, 10) > 80 {
+			t.Errorf("line %q is wider than 80pt", line)
+		}
+	}
+	if strings.Join(lines, " ") != "Bon trimestre, continuez ainsi" {
+		t.Errorf("WrapText() lost words: %q", lines)
+	}
+
+	long := WrapText(strings.Repeat("é", 40), Helvetica, 10, 50)
+	for _, line := range long {
+		if TextWidth(line, Helvetica, 10) > 50 {
+			t.Errorf("long word line %q is wider than 50pt", line)
+		}
+	}
+
+	if got := Truncate("Mathématiques appliquées", Helvetica, 10, 60); !strings.HasSuffix(got, "...") ||
+		TextWidth(got, Helvetica, 10) > 60 {
+		t.Errorf("Truncate() = %q", got)
+	}
+}
+
+func firstContentStream(t *testing.T, data []byte) string {
+	t.Helper()
+	marker := []byte("/Filter /FlateDecode /Length ")
+	i := bytes.Index(data, marker)
+	if i < 0 {
+		t.Fatal("no content stream")
+	}
+	start := bytes.Index(data[i:], []byte("stream\n")) + i + len("stream\n")
+	end := bytes.Index(data[start:], []byte("\nendstream")) + start
+	zr, err := zlib.NewReader(bytes.NewReader(data[start:end]))
+	if err != nil {
+		t.Fatalf("content stream: %v", err)
+	}
+	content, _ := io.ReadAll(zr)
+	return string(content)
+}
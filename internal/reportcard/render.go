@@ -0,0 +1,284 @@
+// Package reportcard met en page les bulletins scolaires en PDF (package pdf,
+// sans service externe) et les regroupe en archive ZIP pour une classe.
+package reportcard
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"strings"
+
+	"educnet/internal/domain"
+	"educnet/internal/pdf"
+	"educnet/internal/utils"
+)
+
+// ! Mise en page A4 (points)
+const (
+	marginX      = 40.0
+	contentRight = pdf.PageWidth - marginX
+	bottomLimit  = pdf.PageHeight - 60
+	footerY      = pdf.PageHeight - 30
+	logoBox      = 70.0
+)
+
+// ! Colonnes du tableau des matières
+var columns = []struct {
+	title string
+	x, w  float64
+}{
+	{"Matière", marginX, 150},
+	{"Moyenne", 190, 55},
+	{"Moy. classe", 245, 60},
+	{"Min", 305, 40},
+	{"Max", 345, 40},
+	{"Appréciation", 385, contentRight - 385},
+}
+
+// ! Render bulletin PDF d'un élève ; logo optionnel (JPEG/PNG, ignoré sinon)
+func Render(card *domain.ReportCard, logo []byte) ([]byte, error) {
+	doc := pdf.New(fmt.Sprintf("Bulletin %s - %s", card.Term.Name, card.Student.GetFullName()))
+	doc.Author = card.School.Name
+
+	var logoImg *pdf.Image
+	if len(logo) > 0 {
+		logoImg, _ = doc.AddImage(logo) //! logo illisible : bulletin sans logo
+	}
+
+	r := &renderer{doc: doc, card: card, logo: logoImg}
+	r.newPage()
+	r.studentBlock()
+	r.subjectsTable()
+	r.summary()
+	return doc.Bytes()
+}
+
+// ! FileName nom de fichier du bulletin (ASCII, unique par élève)
+func FileName(card *domain.ReportCard) string {
+	return fmt.Sprintf("bulletin-%s-%s-%d.pdf",
+		utils.CreateSlug(card.Term.Name), utils.CreateSlug(card.Student.GetFullName()), card.Student.ID)
+}
+
+// ! WriteZip écrit une archive ZIP contenant un bulletin PDF par élève
+func WriteZip(w io.Writer, cards []*domain.ReportCard, logo []byte) error {
+	zw := zip.NewWriter(w)
+	for _, card := range cards {
+		data, err := Render(card, logo)
+		if err != nil {
+			return fmt.Errorf("render report card for student %d: %w", card.Student.ID, err)
+		}
+		//! Les PDF sont déjà compressés : stockage sans recompression
+		f, err := zw.CreateHeader(&zip.FileHeader{Name: FileName(card), Method: zip.Store, Modified: card.GeneratedAt})
+		if err != nil {
+			return err
+		}
+		if _, err := f.Write(data); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// ! ==================== LAYOUT ====================
+
+type renderer struct {
+	doc  *pdf.Document
+	card *domain.ReportCard
+	logo *pdf.Image
+	page *pdf.Page
+	y    float64
+}
+
+// ! newPage en-tête école + titre, répété sur chaque page
+func (r *renderer) newPage() {
+	r.page = r.doc.AddPage()
+	p, card := r.page, r.card
+
+	textX := marginX
+	if r.logo != nil {
+		w, h := r.logo.Size()
+		scale := logoBox / float64(max(w, h))
+		p.DrawImage(r.logo, marginX, 40, float64(w)*scale, float64(h)*scale)
+		textX += logoBox + 15
+	}
+
+	p.Text(textX, 58, pdf.HelveticaBold, 16, pdf.Truncate(card.School.Name, pdf.HelveticaBold, 16, 300))
+	p.Text(textX, 74, pdf.Helvetica, 9, card.School.Address)
+	p.Text(textX, 86, pdf.Helvetica, 9, joinNonEmpty(" - ", card.School.Phone, card.School.Email))
+
+	p.TextRight(contentRight, 58, pdf.HelveticaBold, 14, "BULLETIN SCOLAIRE")
+	p.TextRight(contentRight, 74, pdf.Helvetica, 10, card.Term.Name)
+	p.TextRight(contentRight, 86, pdf.Helvetica, 9, "Année scolaire "+card.Term.AcademicYear)
+
+	p.Line(marginX, 120, contentRight, 120, 1)
+
+	p.SetGray(0.5)
+	p.TextCenter(pdf.PageWidth/2, footerY, pdf.Helvetica, 7,
+		fmt.Sprintf("%s - édité le %s - page %d", card.School.Name, card.GeneratedAt.Format("02/01/2006"), r.doc.PageCount()))
+	p.SetGray(0)
+
+	r.y = 135
+}
+
+func (r *renderer) studentBlock() {
+	p, card := r.page, r.card
+
+	p.SetGray(0.94)
+	p.Rect(marginX, r.y, contentRight-marginX, 48, 0, true)
+	p.SetGray(0)
+
+	p.Text(marginX+10, r.y+18, pdf.HelveticaBold, 11, "Élève : "+card.Student.GetFullName())
+	p.Text(marginX+10, r.y+36, pdf.Helvetica, 10, "Classe : "+card.Class.Name)
+	if card.StudentNumber != "" {
+		p.Text(marginX+260, r.y+18, pdf.Helvetica, 10, "Matricule : "+card.StudentNumber)
+	}
+	p.TextRight(contentRight-10, r.y+18, pdf.Helvetica, 10, fmt.Sprintf("Effectif : %d", card.ClassSize))
+	p.TextRight(contentRight-10, r.y+36, pdf.Helvetica, 10, fmt.Sprintf("Période : du %s au %s",
+		card.Term.StartDate.Format("02/01/2006"), card.Term.EndDate.Format("02/01/2006")))
+
+	r.y += 65
+}
+
+func (r *renderer) tableHeader() {
+	p := r.page
+	p.SetGray(0.85)
+	p.Rect(marginX, r.y, contentRight-marginX, 20, 0, true)
+	p.SetGray(0)
+	for _, col := range columns {
+		p.Text(col.x+4, r.y+13, pdf.HelveticaBold, 8.5, col.title)
+	}
+	r.y += 20
+}
+
+func (r *renderer) subjectsTable() {
+	r.tableHeader()
+	if len(r.card.Subjects) == 0 {
+		r.page.Text(marginX+4, r.y+16, pdf.Helvetica, 9, "Aucune note pour cette période.")
+		r.y += 26
+		return
+	}
+
+	commentCol := columns[len(columns)-1]
+	for _, subject := range r.card.Subjects {
+		lines := []string{}
+		if subject.Comment != "" {
+			lines = pdf.WrapText(subject.Comment, pdf.Helvetica, 8, commentCol.w-8)
+		}
+		height := max(28, float64(len(lines))*10+10)
+
+		if r.y+height > bottomLimit {
+			r.newPage()
+			r.tableHeader()
+		}
+
+		p := r.page
+		p.Text(columns[0].x+4, r.y+12, pdf.HelveticaBold, 9, pdf.Truncate(subject.SubjectName, pdf.HelveticaBold, 9, columns[0].w-8))
+		if subject.TeacherName != "" {
+			p.SetGray(0.4)
+			p.Text(columns[0].x+4, r.y+23, pdf.Helvetica, 7.5, pdf.Truncate(subject.TeacherName, pdf.Helvetica, 7.5, columns[0].w-8))
+			p.SetGray(0)
+		}
+
+		values := []*float64{subject.Average, subject.ClassAverage, subject.Min, subject.Max}
+		for i, v := range values {
+			col := columns[i+1]
+			font := pdf.Helvetica
+			if i == 0 {
+				font = pdf.HelveticaBold
+			}
+			p.TextCenter(col.x+col.w/2, r.y+15, font, 9, formatAverage(v))
+		}
+
+		for i, line := range lines {
+			p.Text(commentCol.x+4, r.y+12+float64(i)*10, pdf.Helvetica, 8, line)
+		}
+
+		r.y += height
+		p.Line(marginX, r.y, contentRight, r.y, 0.3)
+	}
+}
+
+func (r *renderer) summary() {
+	card := r.card
+	commentLines := []string{}
+	if card.Comment != "" {
+		commentLines = pdf.WrapText(card.Comment, pdf.Helvetica, 9, contentRight-marginX-20)
+	}
+	height := 80 + float64(len(commentLines))*12
+	if card.Comment != "" {
+		height += 20
+	}
+	if r.y+20+height > bottomLimit {
+		r.newPage()
+	}
+
+	r.y += 20
+	p := r.page
+	p.Rect(marginX, r.y, contentRight-marginX, height, 0.8, false)
+
+	average := "Non classé"
+	if card.GeneralAverage != nil {
+		average = formatAverage(card.GeneralAverage) + " / 20"
+	}
+	p.Text(marginX+10, r.y+20, pdf.HelveticaBold, 11, "Moyenne générale : "+average)
+	p.TextRight(contentRight-10, r.y+20, pdf.HelveticaBold, 11, "Rang : "+formatRank(card.Rank, card.ClassSize))
+	p.Text(marginX+10, r.y+38, pdf.Helvetica, 9, "Moyenne de la classe : "+formatAverage(card.ClassAverage))
+
+	a := card.Attendance
+	p.Text(marginX+10, r.y+56, pdf.Helvetica, 9, fmt.Sprintf("Absences : %d (dont %d justifiée%s)    Retards : %d",
+		a.Absences, a.JustifiedAbsences, plural(a.JustifiedAbsences), a.Lates))
+
+	y := r.y + 80
+	if card.Comment != "" {
+		p.Text(marginX+10, y, pdf.HelveticaBold, 9, "Appréciation générale")
+		y += 16
+		for _, line := range commentLines {
+			p.Text(marginX+10, y, pdf.Helvetica, 9, line)
+			y += 12
+		}
+	}
+	r.y += height
+
+	if r.y+40 <= bottomLimit {
+		p.TextRight(contentRight, r.y+30, pdf.Helvetica, 9, "Le chef d'établissement")
+	}
+}
+
+// ! ==================== FORMAT ====================
+
+// ! formatAverage 14,50 (virgule décimale), "-" si absente
+func formatAverage(v *float64) string {
+	if v == nil {
+		return "-"
+	}
+	return strings.Replace(fmt.Sprintf("%.2f", *v), ".", ",", 1)
+}
+
+// ! formatRank 1er / 30, 2e / 30
+func formatRank(rank, size int) string {
+	if rank == 0 {
+		return "-"
+	}
+	suffix := "e"
+	if rank == 1 {
+		suffix = "er"
+	}
+	return fmt.Sprintf("%d%s / %d", rank, suffix, size)
+}
+
+func plural(n int) string {
+	if n > 1 {
+		return "s"
+	}
+	return ""
+}
+
+func joinNonEmpty(sep string, values ...string) string {
+	kept := values[:0:0]
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			kept = append(kept, v)
+		}
+	}
+	return strings.Join(kept, sep)
+}
@@ -0,0 +1,117 @@
+package reportcard
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"image"
+	"image/png"
+	"testing"
+	"time"
+
+	"educnet/internal/domain"
+)
+
+func sampleCard(subjects int) *domain.ReportCard {
+	avg, classAvg := 14.5, 12.25
+	card := &domain.ReportCard{
+		School:         &domain.School{Name: "Lycée Andohalo", Address: "Antananarivo", Phone: "+261 34 00 000 00"},
+		Student:        &domain.User{ID: 7, FirstName: "Hery", LastName: "Rakoto"},
+		StudentNumber:  "2025-6EME-0007",
+		Class:          &domain.Class{Name: "6ème A"},
+		Term:           &domain.Term{Name: "Trimestre 1", AcademicYear: "2025-2026", StartDate: time.Date(2025, 9, 15, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 12, 19, 0, 0, 0, 0, time.UTC)},
+		GeneralAverage: &avg,
+		ClassAverage:   &classAvg,
+		Rank:           2,
+		ClassSize:      31,
+		Attendance:     domain.AttendanceSummary{Absences: 2, JustifiedAbsences: 1, Lates: 3},
+		Comment:        "Bon trimestre dans l'ensemble, des efforts à poursuivre en sciences.",
+		GeneratedAt:    time.Date(2025, 12, 20, 0, 0, 0, 0, time.UTC),
+	}
+	for i := 0; i < subjects; i++ {
+		card.Subjects = append(card.Subjects, domain.SubjectResult{
+			SubjectName:  fmt.Sprintf("Matière %d", i+1),
+			TeacherName:  "Mme Rabe",
+			Average:      &avg,
+			ClassAverage: &classAvg,
+			Comment:      "Travail sérieux et régulier, participation active en classe.",
+		})
+	}
+	return card
+}
+
+func TestRender(t *testing.T) {
+	var logo bytes.Buffer
+	if err := png.Encode(&logo, image.NewRGBA(image.Rect(0, 0, 20, 10))); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := Render(sampleCard(8), logo.Bytes())
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !bytes.HasPrefix(data, []byte("%PDF-")) {
+		t.Fatal("Render() should produce a PDF")
+	}
+	if pages := bytes.Count(data, []byte("/Type /Page /Parent")); pages != 1 {
+		t.Errorf("8 subjects rendered on %d pages, want 1", pages)
+	}
+	if !bytes.Contains(data, []byte("/Subtype /Image")) {
+		t.Error("logo should be embedded")
+	}
+
+	//! Logo illisible (SVG) : bulletin généré sans logo
+	if _, err := Render(sampleCard(1), []byte("<svg/>")); err != nil {
+		t.Errorf("Render() with unsupported logo error = %v", err)
+	}
+}
+
+func TestRender_PageBreak(t *testing.T) {
+	data, err := Render(sampleCard(40), nil)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if pages := bytes.Count(data, []byte("/Type /Page /Parent")); pages < 2 {
+		t.Errorf("40 subjects rendered on %d page(s), want a page break", pages)
+	}
+}
+
+func TestWriteZip(t *testing.T) {
+	first, second := sampleCard(2), sampleCard(2)
+	second.Student = &domain.User{ID: 8, FirstName: "Soa", LastName: "Randria"}
+
+	var buf bytes.Buffer
+	if err := WriteZip(&buf, []*domain.ReportCard{first, second}, nil); err != nil {
+		t.Fatalf("WriteZip() error = %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("invalid zip: %v", err)
+	}
+	want := []string{"bulletin-trimestre-1-hery-rakoto-7.pdf", "bulletin-trimestre-1-soa-randria-8.pdf"}
+	if len(zr.File) != len(want) {
+		t.Fatalf("zip has %d files, want %d", len(zr.File), len(want))
+	}
+	for i, f := range zr.File {
+		if f.Name != want[i] {
+			t.Errorf("file %d = %q, want %q", i, f.Name, want[i])
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	v := 14.5
+	if got := formatAverage(&v); got != "14,50" {
+		t.Errorf("formatAverage() = %q", got)
+	}
+	if got := formatAverage(nil); got != "-" {
+		t.Errorf("formatAverage(nil) = %q", got)
+	}
+	if got := formatRank(1, 30); got != "1er / 30" {
+		t.Errorf("formatRank(1) = %q", got)
+	}
+	if got := formatRank(3, 30); got != "3e / 30" {
+		t.Errorf("formatRank(3) = %q", got)
+	}
+}
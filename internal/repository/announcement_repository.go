@@ -0,0 +1,374 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"educnet/internal/db"
+	"educnet/internal/domain"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type AnnouncementRepository interface {
+	//! Annonces
+	Create(ctx context.Context, a *domain.Announcement) error
+	FindByID(ctx context.Context, id int) (*domain.Announcement, error)
+	Update(ctx context.Context, a *domain.Announcement) error
+	Delete(ctx context.Context, id int) error
+	FindBySchool(ctx context.Context, schoolID int) ([]*domain.Announcement, error)
+	FindActive(ctx context.Context, schoolID int, now time.Time) ([]*domain.Announcement, error)
+
+	//! Diffusion
+	FindUndelivered(ctx context.Context, now time.Time) ([]*domain.Announcement, error)
+	MarkNotified(ctx context.Context, id int) (bool, error)
+	FindRecipients(ctx context.Context, a *domain.Announcement) ([]*domain.AnnouncementRecipient, error)
+
+	//! Lectures et accusés de réception
+	MarkRead(ctx context.Context, schoolID, announcementID, userID int) error
+	Acknowledge(ctx context.Context, schoolID, announcementID, userID int) (*domain.AnnouncementReceipt, error)
+	FindReceipts(ctx context.Context, userID int, announcementIDs []int) (map[int]*domain.AnnouncementReceipt, error)
+
+	//! Pièces jointes
+	AddAttachment(ctx context.Context, schoolID int, f *domain.AnnouncementAttachment) error
+	FindAttachments(ctx context.Context, announcementID int) ([]*domain.AnnouncementAttachment, error)
+	FindAttachment(ctx context.Context, announcementID, id int) (*domain.AnnouncementAttachment, error)
+	DeleteAttachment(ctx context.Context, announcementID, id int) error
+}
+
+type announcementRepository struct {
+	db *sql.DB
+}
+
+func NewAnnouncementRepository(db *sql.DB) AnnouncementRepository {
+	return &announcementRepository{db: db}
+}
+
+const announcementColumns = `id,school_id,author_id,title,body,target,target_role,class_ids,levels,publish_at,expires_at,requires_ack,notified_at,created_at,updated_at`
+
+const attachmentColumns = `id,announcement_id,file_name,content_type,size,storage_path,created_at`
+
+// ! ==================== HELPERS ====================
+func (r *announcementRepository) scanAnnouncementRow(row domainScanner, a *domain.Announcement) error {
+	var authorID sql.NullInt64
+	var classIDs pq.Int64Array
+	var levels pq.StringArray
+	var expiresAt, notifiedAt sql.NullTime
+	err := row.Scan(&a.ID, &a.SchoolID, &authorID, &a.Title, &a.Body, &a.Target, &a.TargetRole, &classIDs, &levels,
+		&a.PublishAt, &expiresAt, &a.RequiresAck, &notifiedAt, &a.CreatedAt, &a.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("scan announcement row: %w", err)
+	}
+
+	if authorID.Valid {
+		a.AuthorID = int(authorID.Int64)
+	}
+	for _, id := range classIDs {
+		a.ClassIDs = append(a.ClassIDs, int(id))
+	}
+	if len(levels) > 0 {
+		a.Levels = levels
+	}
+	if expiresAt.Valid {
+		a.ExpiresAt = &expiresAt.Time
+	}
+	if notifiedAt.Valid {
+		a.NotifiedAt = &notifiedAt.Time
+	}
+	return nil
+}
+
+func (r *announcementRepository) findAnnouncements(ctx context.Context, query string, args ...any) ([]*domain.Announcement, error) {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("find announcements: %w", err)
+	}
+	defer rows.Close()
+
+	announcements := []*domain.Announcement{}
+	for rows.Next() {
+		a := &domain.Announcement{}
+		if err := r.scanAnnouncementRow(rows, a); err != nil {
+			return nil, err
+		}
+		announcements = append(announcements, a)
+	}
+	return announcements, rows.Err()
+}
+
+func (r *announcementRepository) scanAttachmentRow(row domainScanner, f *domain.AnnouncementAttachment) error {
+	err := row.Scan(&f.ID, &f.AnnouncementID, &f.FileName, &f.ContentType, &f.Size, &f.StoragePath, &f.CreatedAt)
+	if err == sql.ErrNoRows {
+		return err
+	}
+	return scanError(err, "scan announcement attachment row")
+}
+
+// ! targetClause condition SQL sur users u des destinataires (cf. domain.Announcement.Targets)
+func targetClause(a *domain.Announcement, next int) (string, []any) {
+	switch a.Target {
+	case domain.AnnounceSchool:
+		return `u.role IN ('admin','teacher','student','parent')`, nil
+	case domain.AnnounceRole:
+		return fmt.Sprintf("u.role = $%d", next), []any{a.TargetRole}
+	case domain.AnnounceParents:
+		return `u.role = 'parent'`, nil
+	case domain.AnnounceClasses:
+		return fmt.Sprintf(`u.role = 'student' AND EXISTS (
+                SELECT 1 FROM student_classes sc
+                WHERE sc.student_id = u.id AND sc.is_active AND sc.class_id = ANY($%d))`, next),
+			[]any{intArray(a.ClassIDs)}
+	case domain.AnnounceLevels:
+		return fmt.Sprintf(`u.role = 'student' AND EXISTS (
+                SELECT 1 FROM student_classes sc JOIN classes c ON c.id = sc.class_id
+                WHERE sc.student_id = u.id AND sc.is_active AND c.level = ANY($%d))`, next),
+			[]any{pq.StringArray(a.Levels)}
+	}
+	return "FALSE", nil
+}
+
+// ! ==================== ANNOUNCEMENTS ====================
+func (r *announcementRepository) Create(ctx context.Context, a *domain.Announcement) error {
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO announcements (school_id,author_id,title,body,target,target_role,class_ids,levels,publish_at,expires_at,requires_ack)
+         VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11) RETURNING id,created_at,updated_at`,
+		a.SchoolID, a.AuthorID, a.Title, a.Body, a.Target, a.TargetRole, intArray(a.ClassIDs),
+		pq.StringArray(a.Levels), a.PublishAt, a.ExpiresAt, a.RequiresAck,
+	).Scan(&a.ID, &a.CreatedAt, &a.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("create announcement: %w", err)
+	}
+	return nil
+}
+
+func (r *announcementRepository) FindByID(ctx context.Context, id int) (*domain.Announcement, error) {
+	a := &domain.Announcement{}
+	row := db.Conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+announcementColumns+` FROM announcements WHERE id = $1`, id)
+	if err := r.scanAnnouncementRow(row, a); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrAnnouncementNotFound
+		}
+		return nil, err
+	}
+	return a, nil
+}
+
+// ! Update notified_at n'est pas modifié : une annonce déjà diffusée n'est pas renvoyée
+func (r *announcementRepository) Update(ctx context.Context, a *domain.Announcement) error {
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`UPDATE announcements SET title=$1,body=$2,target=$3,target_role=$4,class_ids=$5,levels=$6,
+                publish_at=$7,expires_at=$8,requires_ack=$9,updated_at=NOW()
+         WHERE id=$10 RETURNING updated_at`,
+		a.Title, a.Body, a.Target, a.TargetRole, intArray(a.ClassIDs), pq.StringArray(a.Levels),
+		a.PublishAt, a.ExpiresAt, a.RequiresAck, a.ID,
+	).Scan(&a.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return domain.ErrAnnouncementNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("update announcement: %w", err)
+	}
+	return nil
+}
+
+func (r *announcementRepository) Delete(ctx context.Context, id int) error {
+	result, err := db.Conn(ctx, r.db).ExecContext(ctx, `DELETE FROM announcements WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete announcement: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrAnnouncementNotFound
+	}
+	return nil
+}
+
+// ! FindBySchool toutes les annonces (programmées et expirées comprises), plus récentes d'abord
+func (r *announcementRepository) FindBySchool(ctx context.Context, schoolID int) ([]*domain.Announcement, error) {
+	return r.findAnnouncements(ctx,
+		`SELECT `+announcementColumns+` FROM announcements WHERE school_id = $1 ORDER BY publish_at DESC, id DESC`, schoolID)
+}
+
+// ! FindActive annonces publiées et non expirées (le ciblage est vérifié par le usecase)
+func (r *announcementRepository) FindActive(ctx context.Context, schoolID int, now time.Time) ([]*domain.Announcement, error) {
+	return r.findAnnouncements(ctx,
+		`SELECT `+announcementColumns+` FROM announcements
+         WHERE school_id = $1 AND publish_at <= $2 AND (expires_at IS NULL OR expires_at > $2)
+         ORDER BY publish_at DESC, id DESC`, schoolID, now)
+}
+
+// ! ==================== DELIVERY ====================
+
+// ! FindUndelivered annonces publiées, non expirées et pas encore notifiées (toutes écoles)
+func (r *announcementRepository) FindUndelivered(ctx context.Context, now time.Time) ([]*domain.Announcement, error) {
+	return r.findAnnouncements(ctx,
+		`SELECT `+announcementColumns+` FROM announcements
+         WHERE notified_at IS NULL AND publish_at <= $1 AND (expires_at IS NULL OR expires_at > $1)
+         ORDER BY school_id, publish_at`, now)
+}
+
+// ! MarkNotified false si l'annonce a déjà été notifiée (diffusion concurrente)
+func (r *announcementRepository) MarkNotified(ctx context.Context, id int) (bool, error) {
+	result, err := db.Conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE announcements SET notified_at = NOW() WHERE id = $1 AND notified_at IS NULL`, id)
+	if err != nil {
+		return false, fmt.Errorf("mark announcement notified: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+// ! FindRecipients comptes approuvés ciblés par l'annonce, avec leur état de lecture
+func (r *announcementRepository) FindRecipients(ctx context.Context, a *domain.Announcement) ([]*domain.AnnouncementRecipient, error) {
+	clause, args := targetClause(a, 3)
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx,
+		`SELECT u.id, u.first_name, u.last_name, u.email, u.role, ar.read_at, ar.acknowledged_at
+         FROM users u
+         LEFT JOIN announcement_receipts ar ON ar.announcement_id = $1 AND ar.user_id = u.id
+         WHERE u.school_id = $2 AND u.status = 'approved' AND `+clause+`
+         ORDER BY u.last_name, u.first_name, u.id`,
+		append([]any{a.ID, a.SchoolID}, args...)...)
+	if err != nil {
+		return nil, fmt.Errorf("find announcement recipients: %w", err)
+	}
+	defer rows.Close()
+
+	recipients := []*domain.AnnouncementRecipient{}
+	for rows.Next() {
+		var readAt, acknowledgedAt sql.NullTime
+		rec := &domain.AnnouncementRecipient{}
+		if err := rows.Scan(&rec.UserID, &rec.FirstName, &rec.LastName, &rec.Email, &rec.Role, &readAt, &acknowledgedAt); err != nil {
+			return nil, scanError(err, "scan announcement recipient")
+		}
+		if readAt.Valid {
+			rec.ReadAt = &readAt.Time
+		}
+		if acknowledgedAt.Valid {
+			rec.AcknowledgedAt = &acknowledgedAt.Time
+		}
+		recipients = append(recipients, rec)
+	}
+	return recipients, rows.Err()
+}
+
+// ! ==================== RECEIPTS ====================
+
+// ! MarkRead enregistre la première lecture (les suivantes sont ignorées)
+func (r *announcementRepository) MarkRead(ctx context.Context, schoolID, announcementID, userID int) error {
+	_, err := db.Conn(ctx, r.db).ExecContext(ctx,
+		`INSERT INTO announcement_receipts (announcement_id,user_id,school_id) VALUES ($1,$2,$3)
+         ON CONFLICT (announcement_id, user_id) DO NOTHING`,
+		announcementID, userID, schoolID)
+	if err != nil {
+		return fmt.Errorf("mark announcement read: %w", err)
+	}
+	return nil
+}
+
+// ! Acknowledge vaut lecture ; la date du premier accusé de réception est conservée
+func (r *announcementRepository) Acknowledge(ctx context.Context, schoolID, announcementID, userID int) (*domain.AnnouncementReceipt, error) {
+	receipt := &domain.AnnouncementReceipt{AnnouncementID: announcementID, UserID: userID}
+	var acknowledgedAt time.Time
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO announcement_receipts (announcement_id,user_id,school_id,acknowledged_at) VALUES ($1,$2,$3,NOW())
+         ON CONFLICT (announcement_id, user_id)
+         DO UPDATE SET acknowledged_at = COALESCE(announcement_receipts.acknowledged_at, NOW())
+         RETURNING read_at, acknowledged_at`,
+		announcementID, userID, schoolID,
+	).Scan(&receipt.ReadAt, &acknowledgedAt)
+	if err != nil {
+		return nil, fmt.Errorf("acknowledge announcement: %w", err)
+	}
+	receipt.AcknowledgedAt = &acknowledgedAt
+	return receipt, nil
+}
+
+// ! FindReceipts accusés de l'utilisateur, indexés par annonce
+func (r *announcementRepository) FindReceipts(ctx context.Context, userID int, announcementIDs []int) (map[int]*domain.AnnouncementReceipt, error) {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx,
+		`SELECT announcement_id, read_at, acknowledged_at FROM announcement_receipts
+         WHERE user_id = $1 AND announcement_id = ANY($2)`,
+		userID, intArray(announcementIDs))
+	if err != nil {
+		return nil, fmt.Errorf("find announcement receipts: %w", err)
+	}
+	defer rows.Close()
+
+	receipts := map[int]*domain.AnnouncementReceipt{}
+	for rows.Next() {
+		var acknowledgedAt sql.NullTime
+		receipt := &domain.AnnouncementReceipt{UserID: userID}
+		if err := rows.Scan(&receipt.AnnouncementID, &receipt.ReadAt, &acknowledgedAt); err != nil {
+			return nil, scanError(err, "scan announcement receipt")
+		}
+		if acknowledgedAt.Valid {
+			receipt.AcknowledgedAt = &acknowledgedAt.Time
+		}
+		receipts[receipt.AnnouncementID] = receipt
+	}
+	return receipts, rows.Err()
+}
+
+// ! ==================== ATTACHMENTS ====================
+func (r *announcementRepository) AddAttachment(ctx context.Context, schoolID int, f *domain.AnnouncementAttachment) error {
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO announcement_attachments (announcement_id,school_id,file_name,content_type,size,storage_path)
+         VALUES ($1,$2,$3,$4,$5,$6) RETURNING id,created_at`,
+		f.AnnouncementID, schoolID, f.FileName, f.ContentType, f.Size, f.StoragePath,
+	).Scan(&f.ID, &f.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("add announcement attachment: %w", err)
+	}
+	return nil
+}
+
+func (r *announcementRepository) FindAttachments(ctx context.Context, announcementID int) ([]*domain.AnnouncementAttachment, error) {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx,
+		`SELECT `+attachmentColumns+` FROM announcement_attachments WHERE announcement_id = $1 ORDER BY id`,
+		announcementID)
+	if err != nil {
+		return nil, fmt.Errorf("find announcement attachments: %w", err)
+	}
+	defer rows.Close()
+
+	attachments := []*domain.AnnouncementAttachment{}
+	for rows.Next() {
+		f := &domain.AnnouncementAttachment{}
+		if err := r.scanAttachmentRow(rows, f); err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, f)
+	}
+	return attachments, rows.Err()
+}
+
+func (r *announcementRepository) FindAttachment(ctx context.Context, announcementID, id int) (*domain.AnnouncementAttachment, error) {
+	f := &domain.AnnouncementAttachment{}
+	row := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT `+attachmentColumns+` FROM announcement_attachments WHERE announcement_id = $1 AND id = $2`,
+		announcementID, id)
+	if err := r.scanAttachmentRow(row, f); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrAnnouncementAttachmentNotFound
+		}
+		return nil, err
+	}
+	return f, nil
+}
+
+func (r *announcementRepository) DeleteAttachment(ctx context.Context, announcementID, id int) error {
+	result, err := db.Conn(ctx, r.db).ExecContext(ctx,
+		`DELETE FROM announcement_attachments WHERE announcement_id = $1 AND id = $2`, announcementID, id)
+	if err != nil {
+		return fmt.Errorf("delete announcement attachment: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrAnnouncementAttachmentNotFound
+	}
+	return nil
+}
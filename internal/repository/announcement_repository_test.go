@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"educnet/internal/domain"
+	"educnet/internal/testutil"
+)
+
+func TestAnnouncementRepository_Recipients(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := NewAnnouncementRepository(db)
+	ctx := context.Background()
+	schoolID := testutil.SeedTestSchool(t, db, "Test School", "test-school", "test@school.mg")
+	adminID := testutil.SeedTestUser(t, db, schoolID, "admin@test.mg", domain.RoleAdmin)
+	testutil.SeedTestUser(t, db, schoolID, "prof@test.mg", domain.RoleTeacher)
+	testutil.SeedTestUser(t, db, schoolID, "parent@test.mg", domain.RoleParent)
+	sixthID := testutil.SeedTestClass(t, db, schoolID, "6ème A", "6ème", "A", "2025-2026")
+	thirdID := testutil.SeedTestClass(t, db, schoolID, "3ème A", "3ème", "A", "2025-2026")
+	studentID := testutil.SeedTestUser(t, db, schoolID, "eleve@test.mg", domain.RoleStudent)
+	otherStudentID := testutil.SeedTestUser(t, db, schoolID, "eleve2@test.mg", domain.RoleStudent)
+	testutil.SeedTestStudentClass(t, db, studentID, sixthID)
+	testutil.SeedTestStudentClass(t, db, otherStudentID, thirdID)
+
+	tests := []struct {
+		name       string
+		target     string
+		targetRole string
+		classIDs   []int
+		levels     []string
+		want       int
+	}{
+		{"Whole school", domain.AnnounceSchool, "", nil, nil, 5},
+		{"Teachers", domain.AnnounceRole, domain.RoleTeacher, nil, nil, 1},
+		{"Parents", domain.AnnounceParents, "", nil, nil, 1},
+		{"Classes", domain.AnnounceClasses, "", []int{sixthID}, nil, 1},
+		{"Levels", domain.AnnounceLevels, "", nil, []string{"6ème", "3ème"}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := domain.NewAnnouncement(schoolID, adminID, tt.name, "Corps", tt.target, tt.targetRole, tt.classIDs, tt.levels, time.Time{}, nil, true)
+			if err != nil {
+				t.Fatalf("NewAnnouncement() error = %v", err)
+			}
+			if err := repo.Create(ctx, a); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			recipients, err := repo.FindRecipients(ctx, a)
+			if err != nil {
+				t.Fatalf("FindRecipients() error = %v", err)
+			}
+			if len(recipients) != tt.want {
+				t.Errorf("FindRecipients() = %d recipients, want %d", len(recipients), tt.want)
+			}
+		})
+	}
+}
+
+func TestAnnouncementRepository_DeliveryAndReceipts(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := NewAnnouncementRepository(db)
+	ctx := context.Background()
+	schoolID := testutil.SeedTestSchool(t, db, "Test School", "test-school", "test@school.mg")
+	adminID := testutil.SeedTestUser(t, db, schoolID, "admin@test.mg", domain.RoleAdmin)
+	parentID := testutil.SeedTestUser(t, db, schoolID, "parent@test.mg", domain.RoleParent)
+
+	now := time.Now()
+	published, _ := domain.NewAnnouncement(schoolID, adminID, "Réunion", "Jeudi 18h", domain.AnnounceParents, "", nil, nil, now.Add(-time.Hour), nil, true)
+	scheduled, _ := domain.NewAnnouncement(schoolID, adminID, "Vacances", "Du 24 au 2", domain.AnnounceSchool, "", nil, nil, now.Add(24*time.Hour), nil, false)
+	for _, a := range []*domain.Announcement{published, scheduled} {
+		if err := repo.Create(ctx, a); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	active, err := repo.FindActive(ctx, schoolID, now)
+	if err != nil || len(active) != 1 || active[0].ID != published.ID {
+		t.Fatalf("FindActive() = %v, err = %v, want only the published announcement", active, err)
+	}
+
+	//! Diffusion : une seule fois par annonce
+	pending, err := repo.FindUndelivered(ctx, now)
+	if err != nil || len(pending) != 1 {
+		t.Fatalf("FindUndelivered() = %d, err = %v, want 1", len(pending), err)
+	}
+	for want, i := true, 0; i < 2; want, i = false, i+1 {
+		if ok, err := repo.MarkNotified(ctx, published.ID); err != nil || ok != want {
+			t.Errorf("MarkNotified() #%d = %v, err = %v, want %v", i+1, ok, err, want)
+		}
+	}
+
+	//! Lecture puis accusé de réception
+	if err := repo.MarkRead(ctx, schoolID, published.ID, parentID); err != nil {
+		t.Fatalf("MarkRead() error = %v", err)
+	}
+	receipts, err := repo.FindReceipts(ctx, parentID, []int{published.ID, scheduled.ID})
+	if err != nil || len(receipts) != 1 || receipts[published.ID].AcknowledgedAt != nil {
+		t.Fatalf("FindReceipts() = %v, err = %v", receipts, err)
+	}
+	receipt, err := repo.Acknowledge(ctx, schoolID, published.ID, parentID)
+	if err != nil || receipt.AcknowledgedAt == nil {
+		t.Fatalf("Acknowledge() = %+v, err = %v", receipt, err)
+	}
+	recipients, err := repo.FindRecipients(ctx, published)
+	if err != nil || len(recipients) != 1 || recipients[0].AcknowledgedAt == nil {
+		t.Errorf("FindRecipients() = %v, err = %v, want the acknowledged parent", recipients, err)
+	}
+
+	//! Pièces jointes
+	file, _ := domain.NewAnnouncementAttachment(published.ID, "circulaire.pdf", "application/pdf", 128)
+	file.StoragePath = "/tmp/circulaire.pdf"
+	if err := repo.AddAttachment(ctx, schoolID, file); err != nil {
+		t.Fatalf("AddAttachment() error = %v", err)
+	}
+	if _, err := repo.FindAttachment(ctx, scheduled.ID, file.ID); err != domain.ErrAnnouncementAttachmentNotFound {
+		t.Errorf("FindAttachment() other announcement error = %v, want %v", err, domain.ErrAnnouncementAttachmentNotFound)
+	}
+	if err := repo.Delete(ctx, published.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if files, err := repo.FindAttachments(ctx, published.ID); err != nil || len(files) != 0 {
+		t.Errorf("FindAttachments() after delete = %v, err = %v", files, err)
+	}
+}
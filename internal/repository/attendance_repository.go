@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"educnet/internal/db"
+	"educnet/internal/domain"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type AttendanceRepository interface {
+	Upsert(ctx context.Context, record *domain.AttendanceRecord) error
+	Delete(ctx context.Context, studentID int, date time.Time) error
+	FindByClassDate(ctx context.Context, classID int, date time.Time) ([]*domain.AttendanceRecord, error)
+	FindByStudent(ctx context.Context, studentID int) ([]*domain.AttendanceRecord, error)
+	Summaries(ctx context.Context, studentIDs []int, from, to time.Time) (map[int]domain.AttendanceSummary, error)
+}
+
+type attendanceRepository struct {
+	db *sql.DB
+}
+
+func NewAttendanceRepository(db *sql.DB) AttendanceRepository {
+	return &attendanceRepository{db: db}
+}
+
+// ! Upsert un enregistrement par élève et par jour : le dernier appel remplace le précédent
+func (r *attendanceRepository) Upsert(ctx context.Context, record *domain.AttendanceRecord) error {
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO attendance_records (school_id,student_id,class_id,recorded_by,date,status,justified,note)
+         VALUES ($1,$2,$3,$4,$5,$6,$7,NULLIF($8,''))
+         ON CONFLICT (student_id, date) DO UPDATE SET
+             class_id=EXCLUDED.class_id, recorded_by=EXCLUDED.recorded_by, status=EXCLUDED.status,
+             justified=EXCLUDED.justified, note=EXCLUDED.note
+         RETURNING id,created_at`,
+		record.SchoolID, record.StudentID, record.ClassID, record.RecordedBy,
+		record.Date, record.Status, record.Justified, record.Note,
+	).Scan(&record.ID, &record.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("upsert attendance record: %w", err)
+	}
+	return nil
+}
+
+// ! Delete élève finalement présent : aucune erreur si rien n'était enregistré
+func (r *attendanceRepository) Delete(ctx context.Context, studentID int, date time.Time) error {
+	_, err := db.Conn(ctx, r.db).ExecContext(ctx,
+		`DELETE FROM attendance_records WHERE student_id = $1 AND date = $2`, studentID, date)
+	if err != nil {
+		return fmt.Errorf("delete attendance record: %w", err)
+	}
+	return nil
+}
+
+const attendanceColumns = `id,school_id,student_id,class_id,recorded_by,date,status,justified,note,created_at`
+
+func (r *attendanceRepository) FindByClassDate(ctx context.Context, classID int, date time.Time) ([]*domain.AttendanceRecord, error) {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx,
+		`SELECT `+attendanceColumns+` FROM attendance_records WHERE class_id = $1 AND date = $2
+         ORDER BY student_id`, classID, date)
+	if err != nil {
+		return nil, fmt.Errorf("find class attendance: %w", err)
+	}
+	return r.scanRecords(rows)
+}
+
+func (r *attendanceRepository) FindByStudent(ctx context.Context, studentID int) ([]*domain.AttendanceRecord, error) {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx,
+		`SELECT `+attendanceColumns+` FROM attendance_records WHERE student_id = $1 ORDER BY date`, studentID)
+	if err != nil {
+		return nil, fmt.Errorf("find student attendance: %w", err)
+	}
+	return r.scanRecords(rows)
+}
+
+// ! scanRecords lit les lignes attendanceColumns et ferme rows
+func (r *attendanceRepository) scanRecords(rows *sql.Rows) ([]*domain.AttendanceRecord, error) {
+	defer rows.Close()
+
+	records := []*domain.AttendanceRecord{}
+	for rows.Next() {
+		rec := &domain.AttendanceRecord{}
+		var recordedBy sql.NullInt64
+		var note sql.NullString
+		if err := rows.Scan(&rec.ID, &rec.SchoolID, &rec.StudentID, &rec.ClassID, &recordedBy,
+			&rec.Date, &rec.Status, &rec.Justified, &note, &rec.CreatedAt); err != nil {
+			return nil, scanError(err, "scan attendance record")
+		}
+		if recordedBy.Valid {
+			rec.RecordedBy = int(recordedBy.Int64)
+		}
+		rec.Note = nullString(note)
+		records = append(records, rec)
+	}
+	return records, rows.Err()
+}
+
+// ! Summaries absences / retards par élève entre from et to (bornes incluses)
+func (r *attendanceRepository) Summaries(ctx context.Context, studentIDs []int, from, to time.Time) (map[int]domain.AttendanceSummary, error) {
+	ids := make(pq.Int64Array, len(studentIDs))
+	for i, id := range studentIDs {
+		ids[i] = int64(id)
+	}
+
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx,
+		`SELECT student_id,
+                COUNT(*) FILTER (WHERE status = 'absent'),
+                COUNT(*) FILTER (WHERE status = 'absent' AND justified),
+                COUNT(*) FILTER (WHERE status = 'late')
+         FROM attendance_records
+         WHERE student_id = ANY($1) AND date BETWEEN $2 AND $3
+         GROUP BY student_id`, ids, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("summarize attendance: %w", err)
+	}
+	defer rows.Close()
+
+	summaries := make(map[int]domain.AttendanceSummary, len(studentIDs))
+	for rows.Next() {
+		var studentID int
+		var s domain.AttendanceSummary
+		if err := rows.Scan(&studentID, &s.Absences, &s.JustifiedAbsences, &s.Lates); err != nil {
+			return nil, scanError(err, "scan attendance summary")
+		}
+		summaries[studentID] = s
+	}
+	return summaries, rows.Err()
+}
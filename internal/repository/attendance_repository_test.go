@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"educnet/internal/domain"
+	"educnet/internal/testutil"
+)
+
+func TestAttendanceRepository_UpsertAndSummaries(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := NewAttendanceRepository(db)
+	ctx := context.Background()
+	schoolID := testutil.SeedTestSchool(t, db, "Test School", "test-school", "test@school.mg")
+	teacherID := testutil.SeedTestUser(t, db, schoolID, "prof@test.mg", domain.RoleTeacher)
+	studentID := testutil.SeedTestUser(t, db, schoolID, "eleve@test.mg", domain.RoleStudent)
+	classID := testutil.SeedTestClass(t, db, schoolID, "6ème A", "6ème", "A", "2025-2026")
+
+	day := time.Date(2025, 10, 6, 0, 0, 0, 0, time.UTC)
+	records := []struct {
+		date      time.Time
+		status    string
+		justified bool
+	}{
+		{day, domain.AttendanceLate, false},
+		{day, domain.AttendanceAbsent, true}, //! remplace le retard du même jour
+		{day.AddDate(0, 0, 1), domain.AttendanceAbsent, false},
+		{day.AddDate(0, 0, 2), domain.AttendanceLate, false},
+		{day.AddDate(0, 1, 0), domain.AttendanceAbsent, false}, //! hors période
+	}
+	for _, r := range records {
+		rec, _ := domain.NewAttendanceRecord(schoolID, studentID, classID, teacherID, r.date, r.status, r.justified, "")
+		if err := repo.Upsert(ctx, rec); err != nil {
+			t.Fatalf("Upsert() error = %v", err)
+		}
+	}
+
+	onDay, err := repo.FindByClassDate(ctx, classID, day)
+	if err != nil || len(onDay) != 1 || onDay[0].Status != domain.AttendanceAbsent {
+		t.Fatalf("FindByClassDate() = %v, err = %v", onDay, err)
+	}
+
+	summaries, err := repo.Summaries(ctx, []int{studentID}, day, day.AddDate(0, 0, 7))
+	if err != nil {
+		t.Fatalf("Summaries() error = %v", err)
+	}
+	want := domain.AttendanceSummary{Absences: 2, JustifiedAbsences: 1, Lates: 1}
+	if summaries[studentID] != want {
+		t.Errorf("Summaries() = %+v, want %+v", summaries[studentID], want)
+	}
+
+	if err := repo.Delete(ctx, studentID, day); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if onDay, _ := repo.FindByClassDate(ctx, classID, day); len(onDay) != 0 {
+		t.Errorf("FindByClassDate() after delete = %d records, want 0", len(onDay))
+	}
+}
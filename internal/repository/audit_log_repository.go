@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"educnet/internal/db"
+	"educnet/internal/domain"
+	"fmt"
+)
+
+type AuditLogRepository interface {
+	Create(ctx context.Context, log *domain.AuditLog) error
+	FindRecent(ctx context.Context, limit int) ([]*domain.AuditLog, error)
+	FindBySchool(ctx context.Context, schoolID, limit int) ([]*domain.AuditLog, error)
+}
+
+type auditLogRepository struct {
+	db *sql.DB
+}
+
+func NewAuditLogRepository(db *sql.DB) AuditLogRepository {
+	return &auditLogRepository{db: db}
+}
+
+// ! ==================== PRO SCANNER ====================
+func (r *auditLogRepository) scanAuditLogRow(row domainScanner, log *domain.AuditLog) error {
+	var actorUserID, schoolID, targetUserID, impersonatorID sql.NullInt64
+	var details, ipAddress sql.NullString
+
+	err := row.Scan(
+		&log.ID, &actorUserID, &log.Action, &schoolID, &targetUserID,
+		&details, &ipAddress, &impersonatorID, &log.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("scan audit log row: %w", err)
+	}
+
+	log.ActorUserID = nullInt(actorUserID)
+	log.SchoolID = nullInt(schoolID)
+	log.TargetUserID = nullInt(targetUserID)
+	log.ImpersonatorID = nullInt(impersonatorID)
+	log.Details = nullString(details)
+	log.IPAddress = nullString(ipAddress)
+	return nil
+}
+
+// ! ==================== METHODS PRO ====================
+func (r *auditLogRepository) Create(ctx context.Context, log *domain.AuditLog) error {
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO audit_logs (actor_user_id,action,school_id,target_user_id,details,ip_address,impersonator_id)
+         VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING id,created_at`,
+		log.ActorUserID, log.Action, log.SchoolID, log.TargetUserID, log.Details, log.IPAddress, log.ImpersonatorID,
+	).Scan(&log.ID, &log.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("create audit log: %w", err)
+	}
+	return nil
+}
+
+func (r *auditLogRepository) FindRecent(ctx context.Context, limit int) ([]*domain.AuditLog, error) {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx,
+		`SELECT id,actor_user_id,action,school_id,target_user_id,details,ip_address,impersonator_id,created_at
+         FROM audit_logs ORDER BY created_at DESC, id DESC LIMIT $1`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("find recent audit logs: %w", err)
+	}
+	defer rows.Close()
+
+	return r.collect(rows)
+}
+
+func (r *auditLogRepository) FindBySchool(ctx context.Context, schoolID, limit int) ([]*domain.AuditLog, error) {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx,
+		`SELECT id,actor_user_id,action,school_id,target_user_id,details,ip_address,impersonator_id,created_at
+         FROM audit_logs WHERE school_id=$1 ORDER BY created_at DESC, id DESC LIMIT $2`, schoolID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("find school audit logs: %w", err)
+	}
+	defer rows.Close()
+
+	return r.collect(rows)
+}
+
+func (r *auditLogRepository) collect(rows *sql.Rows) ([]*domain.AuditLog, error) {
+	var logs []*domain.AuditLog
+	for rows.Next() {
+		log := &domain.AuditLog{}
+		if err := r.scanAuditLogRow(rows, log); err != nil {
+			return nil, err
+		}
+		logs = append(logs, log)
+	}
+	return logs, rows.Err()
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"educnet/internal/domain"
+	"educnet/internal/testutil"
+)
+
+func TestAuditLogRepository_CreateAndFindBySchool(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := NewAuditLogRepository(db)
+	ctx := context.Background()
+	schoolID := testutil.SeedTestSchool(t, db, "Test School", "test-school", "test@school.mg")
+	adminID := testutil.SeedTestUser(t, db, schoolID, "admin@test.mg", domain.RoleAdmin)
+
+	log, _ := domain.NewAuditLog(0, domain.AuditActionImpersonation, schoolID, adminID, "support ticket", "127.0.0.1")
+	if err := repo.Create(ctx, log); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if log.ID == 0 {
+		t.Error("Create() ID was not set")
+	}
+
+	logs, err := repo.FindBySchool(ctx, schoolID, 10)
+	if err != nil {
+		t.Fatalf("FindBySchool() error = %v", err)
+	}
+	if len(logs) != 1 {
+		t.Fatalf("FindBySchool() got %d logs, want 1", len(logs))
+	}
+	if logs[0].Action != domain.AuditActionImpersonation {
+		t.Errorf("Action = %v, want %v", logs[0].Action, domain.AuditActionImpersonation)
+	}
+	if logs[0].TargetUserID == nil || *logs[0].TargetUserID != adminID {
+		t.Errorf("TargetUserID = %v, want %d", logs[0].TargetUserID, adminID)
+	}
+	if logs[0].ImpersonatorID != nil {
+		t.Errorf("ImpersonatorID = %v, want nil", *logs[0].ImpersonatorID)
+	}
+}
+
+func TestAuditLogRepository_Impersonator(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := NewAuditLogRepository(db)
+	ctx := context.Background()
+	schoolID := testutil.SeedTestSchool(t, db, "Test School", "test-school", "test@school.mg")
+	adminID := testutil.SeedTestUser(t, db, schoolID, "admin@test.mg", domain.RoleAdmin)
+	superAdminID := testutil.SeedTestUser(t, db, schoolID, "super@test.mg", domain.RoleAdmin)
+
+	//! Action de l'admin faite par un superadmin impersonant
+	log, _ := domain.NewAuditLog(adminID, domain.AuditActionUserErased, schoolID, 0, "", "127.0.0.1")
+	log.ImpersonatorID = &superAdminID
+	if err := repo.Create(ctx, log); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	logs, err := repo.FindRecent(ctx, 10)
+	if err != nil {
+		t.Fatalf("FindRecent() error = %v", err)
+	}
+	if len(logs) != 1 || logs[0].ImpersonatorID == nil || *logs[0].ImpersonatorID != superAdminID {
+		t.Errorf("FindRecent() = %+v, want impersonator %d", logs, superAdminID)
+	}
+}
@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"educnet/internal/db"
+	"educnet/internal/domain"
+	"fmt"
+	"strings"
+)
+
+type CalendarRepository interface {
+	//! Événements
+	Create(ctx context.Context, event *domain.Event) error
+	FindByID(ctx context.Context, id int) (*domain.Event, error)
+	Update(ctx context.Context, event *domain.Event) error
+	Delete(ctx context.Context, id int) error
+	FindEvents(ctx context.Context, filter domain.EventFilter) ([]*domain.Event, error)
+
+	//! Flux iCalendar
+	FindFeed(ctx context.Context, userID int) (*domain.CalendarFeed, error)
+	RotateFeed(ctx context.Context, userID, schoolID int) (*domain.CalendarFeed, error)
+	DeleteFeed(ctx context.Context, userID int) error
+}
+
+type calendarRepository struct {
+	db *sql.DB
+}
+
+func NewCalendarRepository(db *sql.DB) CalendarRepository {
+	return &calendarRepository{db: db}
+}
+
+const eventColumns = `id,school_id,class_id,room_id,title,description,location,category,audience,starts_at,ends_at,all_day,rrule,created_by,created_at,updated_at`
+
+// ! ==================== HELPERS ====================
+func (r *calendarRepository) scanEventRow(row domainScanner, e *domain.Event) error {
+	var classID, roomID, createdBy sql.NullInt64
+	err := row.Scan(&e.ID, &e.SchoolID, &classID, &roomID, &e.Title, &e.Description, &e.Location, &e.Category,
+		&e.Audience, &e.StartsAt, &e.EndsAt, &e.AllDay, &e.RRule, &createdBy, &e.CreatedAt, &e.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("scan event row: %w", err)
+	}
+	e.ClassID = nullInt(classID)
+	e.RoomID = nullInt(roomID)
+	if createdBy.Valid {
+		e.CreatedBy = int(createdBy.Int64)
+	}
+	return nil
+}
+
+// ! ==================== EVENTS ====================
+func (r *calendarRepository) Create(ctx context.Context, e *domain.Event) error {
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO events (school_id,class_id,room_id,title,description,location,category,audience,starts_at,ends_at,all_day,rrule,created_by)
+         VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13) RETURNING id,created_at,updated_at`,
+		e.SchoolID, nullID(e.ClassID), nullID(e.RoomID), e.Title, e.Description, e.Location, e.Category, e.Audience,
+		e.StartsAt, e.EndsAt, e.AllDay, e.RRule, e.CreatedBy,
+	).Scan(&e.ID, &e.CreatedAt, &e.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("create event: %w", err)
+	}
+	return nil
+}
+
+func (r *calendarRepository) FindByID(ctx context.Context, id int) (*domain.Event, error) {
+	e := &domain.Event{}
+	row := db.Conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+eventColumns+` FROM events WHERE id = $1`, id)
+	if err := r.scanEventRow(row, e); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrEventNotFound
+		}
+		return nil, err
+	}
+	return e, nil
+}
+
+func (r *calendarRepository) Update(ctx context.Context, e *domain.Event) error {
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`UPDATE events SET class_id=$1,room_id=$2,title=$3,description=$4,location=$5,category=$6,audience=$7,
+                starts_at=$8,ends_at=$9,all_day=$10,rrule=$11
+         WHERE id=$12 RETURNING updated_at`,
+		nullID(e.ClassID), nullID(e.RoomID), e.Title, e.Description, e.Location, e.Category, e.Audience,
+		e.StartsAt, e.EndsAt, e.AllDay, e.RRule, e.ID,
+	).Scan(&e.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return domain.ErrEventNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("update event: %w", err)
+	}
+	return nil
+}
+
+func (r *calendarRepository) Delete(ctx context.Context, id int) error {
+	result, err := db.Conn(ctx, r.db).ExecContext(ctx, `DELETE FROM events WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete event: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrEventNotFound
+	}
+	return nil
+}
+
+// ! FindEvents les événements récurrents commencés avant From sont toujours renvoyés :
+// ! l'expansion des occurrences (et UNTIL / COUNT) est faite par domain.Event.Occurrences
+func (r *calendarRepository) FindEvents(ctx context.Context, f domain.EventFilter) ([]*domain.Event, error) {
+	where := []string{"school_id = $1", "starts_at < $2", "(rrule <> '' OR ends_at > $3)"}
+	args := []any{f.SchoolID, f.To, f.From}
+
+	if f.ClassIDs != nil || f.ClassEventsBy > 0 {
+		args = append(args, intArray(f.ClassIDs), f.ClassEventsBy)
+		where = append(where, fmt.Sprintf("(class_id IS NULL OR class_id = ANY($%d) OR created_by = $%d)",
+			len(args)-1, len(args)))
+	}
+	if f.ClassID > 0 {
+		args = append(args, f.ClassID)
+		where = append(where, fmt.Sprintf("(class_id IS NULL OR class_id = $%d)", len(args)))
+	}
+	if f.Category != "" {
+		args = append(args, f.Category)
+		where = append(where, fmt.Sprintf("category = $%d", len(args)))
+	}
+	if f.WithRoom {
+		where = append(where, "room_id IS NOT NULL")
+	}
+
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx,
+		`SELECT `+eventColumns+` FROM events WHERE `+strings.Join(where, " AND ")+` ORDER BY starts_at, id`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("find events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*domain.Event{}
+	for rows.Next() {
+		e := &domain.Event{}
+		if err := r.scanEventRow(rows, e); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// ! ==================== FEEDS ====================
+func (r *calendarRepository) FindFeed(ctx context.Context, userID int) (*domain.CalendarFeed, error) {
+	feed := &domain.CalendarFeed{}
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT user_id,school_id,version,updated_at FROM calendar_feeds WHERE user_id = $1`, userID,
+	).Scan(&feed.UserID, &feed.SchoolID, &feed.Version, &feed.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrCalendarFeedNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find calendar feed: %w", err)
+	}
+	return feed, nil
+}
+
+// ! RotateFeed crée le flux (version 1) ou incrémente sa version (ancien lien révoqué)
+func (r *calendarRepository) RotateFeed(ctx context.Context, userID, schoolID int) (*domain.CalendarFeed, error) {
+	feed := &domain.CalendarFeed{}
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO calendar_feeds (user_id, school_id) VALUES ($1, $2)
+         ON CONFLICT (user_id) DO UPDATE SET version = calendar_feeds.version + 1, updated_at = NOW()
+         RETURNING user_id,school_id,version,updated_at`, userID, schoolID,
+	).Scan(&feed.UserID, &feed.SchoolID, &feed.Version, &feed.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("rotate calendar feed: %w", err)
+	}
+	return feed, nil
+}
+
+func (r *calendarRepository) DeleteFeed(ctx context.Context, userID int) error {
+	_, err := db.Conn(ctx, r.db).ExecContext(ctx, `DELETE FROM calendar_feeds WHERE user_id = $1`, userID)
+	if err != nil {
+		return fmt.Errorf("delete calendar feed: %w", err)
+	}
+	return nil
+}
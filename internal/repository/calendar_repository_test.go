@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"educnet/internal/domain"
+	"educnet/internal/testutil"
+)
+
+func TestCalendarRepository_Events(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := NewCalendarRepository(db)
+	ctx := context.Background()
+	schoolID := testutil.SeedTestSchool(t, db, "Test School", "test-school", "test@school.mg")
+	adminID := testutil.SeedTestUser(t, db, schoolID, "admin@test.mg", domain.RoleAdmin)
+	teacherID := testutil.SeedTestUser(t, db, schoolID, "prof@test.mg", domain.RoleTeacher)
+	classID := testutil.SeedTestClass(t, db, schoolID, "6ème A", "6ème", "A", "2025-2026")
+	otherClassID := testutil.SeedTestClass(t, db, schoolID, "6ème B", "6ème", "B", "2025-2026")
+
+	start := time.Date(2026, 9, 7, 8, 0, 0, 0, time.UTC)
+	holiday, _ := domain.NewEvent(schoolID, nil, "Vacances", "", "", domain.EventHoliday, domain.AudienceAll,
+		start.AddDate(0, 1, 0), start.AddDate(0, 1, 9), true, "", adminID)
+	lesson, _ := domain.NewEvent(schoolID, &classID, "Maths", "", "Salle 3", domain.EventLesson, domain.AudienceStudents,
+		start, start.Add(time.Hour), false, "FREQ=WEEKLY;BYDAY=MO,TH", teacherID)
+	outing, _ := domain.NewEvent(schoolID, &otherClassID, "Sortie", "", "", domain.EventOther, domain.AudienceAll,
+		start.AddDate(0, 0, 2), start.AddDate(0, 0, 2).Add(4*time.Hour), false, "", adminID)
+	for _, e := range []*domain.Event{holiday, lesson, outing} {
+		if err := repo.Create(ctx, e); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	september := domain.EventFilter{SchoolID: schoolID, From: start.AddDate(0, 0, 14), To: start.AddDate(0, 0, 21)}
+	tests := []struct {
+		name   string
+		filter func(f domain.EventFilter) domain.EventFilter
+		want   int
+	}{
+		{"All events (recurring started before range)", func(f domain.EventFilter) domain.EventFilter { return f }, 1},
+		{"Whole term", func(f domain.EventFilter) domain.EventFilter { f.To = start.AddDate(0, 3, 0); return f }, 3},
+		{"Student classes", func(f domain.EventFilter) domain.EventFilter {
+			f.From, f.To, f.ClassIDs = start, start.AddDate(0, 3, 0), []int{otherClassID}
+			return f
+		}, 2},
+		{"Teacher timetable", func(f domain.EventFilter) domain.EventFilter {
+			f.From, f.To, f.ClassEventsBy = start, start.AddDate(0, 3, 0), teacherID
+			return f
+		}, 2},
+		{"Category", func(f domain.EventFilter) domain.EventFilter {
+			f.To, f.Category = start.AddDate(0, 3, 0), domain.EventHoliday
+			return f
+		}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := repo.FindEvents(ctx, tt.filter(september))
+			if err != nil {
+				t.Fatalf("FindEvents() error = %v", err)
+			}
+			if len(events) != tt.want {
+				t.Errorf("FindEvents() = %d events, want %d", len(events), tt.want)
+			}
+		})
+	}
+
+	//! Mise à jour puis suppression
+	lesson.Location = "Salle 4"
+	if err := repo.Update(ctx, lesson); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	found, err := repo.FindByID(ctx, lesson.ID)
+	if err != nil || found.Location != "Salle 4" || found.RRule != lesson.RRule || *found.ClassID != classID {
+		t.Fatalf("FindByID() = %+v, err = %v", found, err)
+	}
+	if err := repo.Delete(ctx, lesson.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repo.FindByID(ctx, lesson.ID); err != domain.ErrEventNotFound {
+		t.Errorf("FindByID() after delete error = %v, want %v", err, domain.ErrEventNotFound)
+	}
+}
+
+func TestCalendarRepository_Feeds(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := NewCalendarRepository(db)
+	ctx := context.Background()
+	schoolID := testutil.SeedTestSchool(t, db, "Test School", "test-school", "test@school.mg")
+	studentID := testutil.SeedTestUser(t, db, schoolID, "eleve@test.mg", domain.RoleStudent)
+
+	if _, err := repo.FindFeed(ctx, studentID); err != domain.ErrCalendarFeedNotFound {
+		t.Errorf("FindFeed() before creation error = %v, want %v", err, domain.ErrCalendarFeedNotFound)
+	}
+	for want := 1; want <= 2; want++ {
+		feed, err := repo.RotateFeed(ctx, studentID, schoolID)
+		if err != nil {
+			t.Fatalf("RotateFeed() error = %v", err)
+		}
+		if feed.Version != want {
+			t.Errorf("RotateFeed() version = %d, want %d", feed.Version, want)
+		}
+	}
+	if err := repo.DeleteFeed(ctx, studentID); err != nil {
+		t.Fatalf("DeleteFeed() error = %v", err)
+	}
+	if _, err := repo.FindFeed(ctx, studentID); err != domain.ErrCalendarFeedNotFound {
+		t.Errorf("FindFeed() after delete error = %v, want %v", err, domain.ErrCalendarFeedNotFound)
+	}
+}
@@ -1,21 +1,23 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"educnet/internal/db"
 	"educnet/internal/domain"
 	"errors"
 	"fmt"
 )
 
 type ClassRepository interface {
-	Create(class *domain.Class) error
-	FindByID(id int) (*domain.Class, error)
-	FindBySchoolID(schoolID int) ([]*domain.Class, error)
-	GetAll(schoolID int) ([]*domain.Class, error)
-	FindBySchoolAndYear(schoolID int, academicYear string) ([]*domain.Class, error)
-	Update(class *domain.Class) error
-	Delete(id int) error
-	ExistsByName(schoolID int, name string, excludeID int) (bool, error)
+	Create(ctx context.Context, class *domain.Class) error
+	FindByID(ctx context.Context, id int) (*domain.Class, error)
+	FindBySchoolID(ctx context.Context, schoolID int) ([]*domain.Class, error)
+	GetAll(ctx context.Context, schoolID int) ([]*domain.Class, error)
+	FindBySchoolAndYear(ctx context.Context, schoolID int, academicYear string) ([]*domain.Class, error)
+	Update(ctx context.Context, class *domain.Class) error
+	Delete(ctx context.Context, id int) error
+	ExistsByName(ctx context.Context, schoolID int, name string, excludeID int) (bool, error)
 
 	//! HELPER
 	ScanClassRow(row domainScanner, classObj *domain.Class) error
@@ -50,8 +52,8 @@ func (r *classRepository) ScanClassRow(row domainScanner, classObj *domain.Class
 }
 
 // ! ==================== METHODS PRO ====================
-func (r *classRepository) Create(class *domain.Class) error {
-	err := r.db.QueryRow(
+func (r *classRepository) Create(ctx context.Context, class *domain.Class) error {
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
 		`INSERT INTO classes (school_id,name,level,section,capacity,academic_year,status)
         VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING id,created_at,updated_at`,
 		class.SchoolID, class.Name, class.Level, class.Section, class.Capacity,
@@ -63,14 +65,14 @@ func (r *classRepository) Create(class *domain.Class) error {
 	return nil
 }
 
-func (r *classRepository) FindByID(id int) (*domain.Class, error) {
+func (r *classRepository) FindByID(ctx context.Context, id int) (*domain.Class, error) {
 	class := &domain.Class{}
 	query := `
         SELECT id, school_id, name, level, section, capacity, academic_year, 
                created_at, updated_at
         FROM classes WHERE id = $1`
 
-	err := r.db.QueryRow(query, id).Scan(
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx, query, id).Scan(
 		&class.ID,
 		&class.SchoolID,
 		&class.Name,
@@ -90,8 +92,8 @@ func (r *classRepository) FindByID(id int) (*domain.Class, error) {
 	return class, nil
 }
 
-func (r *classRepository) FindBySchoolID(schoolID int) ([]*domain.Class, error) {
-	rows, err := r.db.Query(
+func (r *classRepository) FindBySchoolID(ctx context.Context, schoolID int) ([]*domain.Class, error) {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx,
 		`SELECT id,school_id,name,level,section,capacity,academic_year,created_at,updated_at 
          FROM classes WHERE school_id=$1 ORDER BY level,name`, schoolID)
 	if err != nil {
@@ -110,12 +112,12 @@ func (r *classRepository) FindBySchoolID(schoolID int) ([]*domain.Class, error)
 	return classes, rows.Err()
 }
 
-func (r *classRepository) GetAll(schoolID int) ([]*domain.Class, error) {
-	return r.FindBySchoolID(schoolID)
+func (r *classRepository) GetAll(ctx context.Context, schoolID int) ([]*domain.Class, error) {
+	return r.FindBySchoolID(ctx, schoolID)
 }
 
-func (r *classRepository) FindBySchoolAndYear(schoolID int, academicYear string) ([]*domain.Class, error) {
-	rows, err := r.db.Query(
+func (r *classRepository) FindBySchoolAndYear(ctx context.Context, schoolID int, academicYear string) ([]*domain.Class, error) {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx,
 		`SELECT id,school_id,name,level,section,capacity,academic_year,created_at,updated_at 
         FROM classes WHERE school_id=$1 AND academic_year=$2 ORDER BY level,name`,
 		schoolID, academicYear)
@@ -135,8 +137,8 @@ func (r *classRepository) FindBySchoolAndYear(schoolID int, academicYear string)
 	return classes, rows.Err()
 }
 
-func (r *classRepository) Update(class *domain.Class) error {
-	result, err := r.db.Exec(
+func (r *classRepository) Update(ctx context.Context, class *domain.Class) error {
+	result, err := db.Conn(ctx, r.db).ExecContext(ctx,
 		`UPDATE classes SET name=$1,level=$2,section=$3,capacity=$4,academic_year=$5,status=$6,updated_at=NOW() 
          WHERE id=$7`,
 		class.Name, class.Level, class.Section, class.Capacity, class.AcademicYear, class.Status, class.ID)
@@ -151,8 +153,8 @@ func (r *classRepository) Update(class *domain.Class) error {
 	return nil
 }
 
-func (r *classRepository) Delete(id int) error {
-	result, err := r.db.Exec(`DELETE FROM classes WHERE id=$1`, id)
+func (r *classRepository) Delete(ctx context.Context, id int) error {
+	result, err := db.Conn(ctx, r.db).ExecContext(ctx, `DELETE FROM classes WHERE id=$1`, id)
 	if err != nil {
 		return fmt.Errorf("delete class: %w", err)
 	}
@@ -164,9 +166,9 @@ func (r *classRepository) Delete(id int) error {
 	return nil
 }
 
-func (r *classRepository) ExistsByName(schoolID int, name string, excludeID int) (bool, error) {
+func (r *classRepository) ExistsByName(ctx context.Context, schoolID int, name string, excludeID int) (bool, error) {
 	var exists bool
-	err := r.db.QueryRow(
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
 		`SELECT EXISTS(SELECT 1 FROM classes WHERE school_id=$1 AND name=$2 AND id!=$3)`,
 		schoolID, name, excludeID).Scan(&exists)
 	return exists, err
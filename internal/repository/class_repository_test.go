@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"testing"
 
 	"educnet/internal/domain"
@@ -16,6 +17,7 @@ func TestClassRepository_Create(t *testing.T) {
 	defer testutil.CleanupTestDB(t, db)
 
 	repo := NewClassRepository(db)
+	ctx := context.Background()
 
 	//! Seed school
 	schoolID := testutil.SeedTestSchool(t, db, "Test School", "test", "test@school.mg")
@@ -30,7 +32,7 @@ func TestClassRepository_Create(t *testing.T) {
 		Status:       domain.ClassStatusActive,
 	}
 
-	err := repo.Create(class)
+	err := repo.Create(ctx, class)
 	if err != nil {
 		t.Fatalf("Create() error = %v", err)
 	}
@@ -52,13 +54,14 @@ func TestClassRepository_FindByID(t *testing.T) {
 	defer testutil.CleanupTestDB(t, db)
 
 	repo := NewClassRepository(db)
+	ctx := context.Background()
 
 	//! Seed
 	schoolID := testutil.SeedTestSchool(t, db, "Test", "test", "test@school.mg")
 	classID := testutil.SeedTestClass(t, db, schoolID, "6ème A", "6ème", "A", "2025-2026")
 
 	//! Test
-	class, err := repo.FindByID(classID)
+	class, err := repo.FindByID(ctx, classID)
 	if err != nil {
 		t.Fatalf("FindByID() error = %v", err)
 	}
@@ -80,8 +83,9 @@ func TestClassRepository_FindByID_NotFound(t *testing.T) {
 	defer testutil.CleanupTestDB(t, db)
 
 	repo := NewClassRepository(db)
+	ctx := context.Background()
 
-	_, err := repo.FindByID(99999)
+	_, err := repo.FindByID(ctx, 99999)
 	if err != domain.ErrClassNotFound {
 		t.Errorf("FindByID() error = %v, want ErrClassNotFound", err)
 	}
@@ -96,6 +100,7 @@ func TestClassRepository_FindBySchoolID(t *testing.T) {
 	defer testutil.CleanupTestDB(t, db)
 
 	repo := NewClassRepository(db)
+	ctx := context.Background()
 
 	//! Seed
 	schoolID := testutil.SeedTestSchool(t, db, "Test", "test", "test@school.mg")
@@ -103,7 +108,7 @@ func TestClassRepository_FindBySchoolID(t *testing.T) {
 	testutil.SeedTestClass(t, db, schoolID, "5ème B", "5ème", "B", "2025-2026")
 
 	//! Test
-	classes, err := repo.FindBySchoolID(schoolID)
+	classes, err := repo.FindBySchoolID(ctx, schoolID)
 	if err != nil {
 		t.Fatalf("FindBySchoolID() error = %v", err)
 	}
@@ -122,6 +127,7 @@ func TestClassRepository_FindBySchoolAndYear(t *testing.T) {
 	defer testutil.CleanupTestDB(t, db)
 
 	repo := NewClassRepository(db)
+	ctx := context.Background()
 
 	//! Seed
 	schoolID := testutil.SeedTestSchool(t, db, "Test", "test", "test@school.mg")
@@ -130,7 +136,7 @@ func TestClassRepository_FindBySchoolAndYear(t *testing.T) {
 	testutil.SeedTestClass(t, db, schoolID, "5ème A", "5ème", "A", "2024-2025") // autre année
 
 	//! Test
-	classes, err := repo.FindBySchoolAndYear(schoolID, "2025-2026")
+	classes, err := repo.FindBySchoolAndYear(ctx, schoolID, "2025-2026")
 	if err != nil {
 		t.Fatalf("FindBySchoolAndYear() error = %v", err)
 	}
@@ -149,6 +155,7 @@ func TestClassRepository_ExistsByName(t *testing.T) {
 	defer testutil.CleanupTestDB(t, db)
 
 	repo := NewClassRepository(db)
+	ctx := context.Background()
 
 	//! Seed
 	schoolID := testutil.SeedTestSchool(t, db, "Test", "test", "test@school.mg")
@@ -186,7 +193,7 @@ func TestClassRepository_ExistsByName(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			exists, err := repo.ExistsByName(tt.schoolID, tt.className, tt.excludeID)
+			exists, err := repo.ExistsByName(ctx, tt.schoolID, tt.className, tt.excludeID)
 			if err != nil {
 				t.Fatalf("ExistsByName() error = %v", err)
 			}
@@ -206,13 +213,14 @@ func TestClassRepository_Update(t *testing.T) {
 	defer testutil.CleanupTestDB(t, db)
 
 	repo := NewClassRepository(db)
+	ctx := context.Background()
 
 	//! Seed
 	schoolID := testutil.SeedTestSchool(t, db, "Test", "test", "test@school.mg")
 	classID := testutil.SeedTestClass(t, db, schoolID, "6ème A", "6ème", "A", "2025-2026")
 
 	//! Get class
-	class, err := repo.FindByID(classID)
+	class, err := repo.FindByID(ctx, classID)
 	if err != nil {
 		t.Fatalf("FindByID() error = %v", err)
 	}
@@ -222,13 +230,13 @@ func TestClassRepository_Update(t *testing.T) {
 	class.Capacity = 45
 	class.Activate()
 
-	err = repo.Update(class)
+	err = repo.Update(ctx, class)
 	if err != nil {
 		t.Fatalf("Update() error = %v", err)
 	}
 
 	//! Verify
-	updated, err := repo.FindByID(classID)
+	updated, err := repo.FindByID(ctx, classID)
 	if err != nil {
 		t.Fatalf("FindByID() after update error = %v", err)
 	}
@@ -0,0 +1,307 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"educnet/internal/db"
+	"educnet/internal/domain"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ! DisciplineRepository incidents disciplinaires, sanctions et réglages de l'école
+type DisciplineRepository interface {
+	FindSettings(ctx context.Context, schoolID int) (*domain.DisciplineSettings, error)
+	SaveSettings(ctx context.Context, settings *domain.DisciplineSettings) error
+
+	//! Incidents (chargés avec leurs sanctions et les noms élève / classe / auteur)
+	CreateIncident(ctx context.Context, incident *domain.Incident) error
+	UpdateIncident(ctx context.Context, incident *domain.Incident) error
+	FindIncident(ctx context.Context, incidentID int) (*domain.Incident, error)
+	FindIncidents(ctx context.Context, f domain.IncidentFilter) ([]*domain.Incident, error)
+	//! CountSince incidents de l'élève depuis since (hors classés sans suite)
+	CountSince(ctx context.Context, studentID int, since time.Time) (int, error)
+
+	//! Sanctions
+	CreateSanction(ctx context.Context, sanction *domain.Sanction) error
+	DeleteSanction(ctx context.Context, incidentID, sanctionID int) error
+
+	//! Effacement RGPD : incidents et sanctions de l'élève
+	DeleteByStudent(ctx context.Context, studentID int) error
+}
+
+type disciplineRepository struct {
+	db *sql.DB
+}
+
+func NewDisciplineRepository(db *sql.DB) DisciplineRepository {
+	return &disciplineRepository{db: db}
+}
+
+const incidentSelect = `SELECT i.id,i.school_id,i.student_id,i.class_id,i.reported_by,i.occurred_at,i.location,i.description,
+        i.severity,i.status,i.confidential,i.escalated_by,i.escalated_at,i.escalation_reason,
+        i.resolved_by,i.resolved_at,i.resolution,i.created_at,i.updated_at,
+        s.first_name || ' ' || s.last_name, c.name, COALESCE(r.first_name || ' ' || r.last_name, '')
+    FROM incidents i
+    JOIN users s ON s.id = i.student_id
+    JOIN classes c ON c.id = i.class_id
+    LEFT JOIN users r ON r.id = i.reported_by`
+
+const sanctionColumns = `id,school_id,incident_id,student_id,type,starts_on,ends_on,notes,issued_by,created_at`
+
+func (r *disciplineRepository) scanIncidentRow(row domainScanner, i *domain.Incident) error {
+	var reportedBy, escalatedBy, resolvedBy sql.NullInt64
+	var escalatedAt, resolvedAt sql.NullTime
+	err := row.Scan(&i.ID, &i.SchoolID, &i.StudentID, &i.ClassID, &reportedBy, &i.OccurredAt, &i.Location, &i.Description,
+		&i.Severity, &i.Status, &i.Confidential, &escalatedBy, &escalatedAt, &i.EscalationReason,
+		&resolvedBy, &resolvedAt, &i.Resolution, &i.CreatedAt, &i.UpdatedAt,
+		&i.StudentName, &i.ClassName, &i.ReporterName)
+	if err == sql.ErrNoRows {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("scan incident row: %w", err)
+	}
+	i.ReportedBy = int(reportedBy.Int64)
+	i.EscalatedBy = nullInt(escalatedBy)
+	i.ResolvedBy = nullInt(resolvedBy)
+	if escalatedAt.Valid {
+		i.EscalatedAt = &escalatedAt.Time
+	}
+	if resolvedAt.Valid {
+		i.ResolvedAt = &resolvedAt.Time
+	}
+	i.Sanctions = []*domain.Sanction{}
+	return nil
+}
+
+func (r *disciplineRepository) scanSanctionRow(row domainScanner, s *domain.Sanction) error {
+	var startsOn, endsOn sql.NullTime
+	var issuedBy sql.NullInt64
+	err := row.Scan(&s.ID, &s.SchoolID, &s.IncidentID, &s.StudentID, &s.Type, &startsOn, &endsOn, &s.Notes, &issuedBy, &s.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("scan sanction row: %w", err)
+	}
+	if startsOn.Valid {
+		s.StartsOn = &startsOn.Time
+	}
+	if endsOn.Valid {
+		s.EndsOn = &endsOn.Time
+	}
+	s.IssuedBy = int(issuedBy.Int64)
+	return nil
+}
+
+// ! ==================== SETTINGS ====================
+
+// ! FindSettings réglages par défaut si l'école n'a rien configuré
+func (r *disciplineRepository) FindSettings(ctx context.Context, schoolID int) (*domain.DisciplineSettings, error) {
+	s := &domain.DisciplineSettings{SchoolID: schoolID}
+	var updatedBy sql.NullInt64
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT student_visibility,parent_visibility,escalate_severity,escalate_threshold,escalate_window_days,updated_by,updated_at
+         FROM discipline_settings WHERE school_id = $1`, schoolID,
+	).Scan(&s.StudentVisibility, &s.ParentVisibility, &s.EscalateSeverity, &s.EscalateThreshold, &s.EscalateWindow, &updatedBy, &s.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return domain.DefaultDisciplineSettings(schoolID), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find discipline settings: %w", err)
+	}
+	s.UpdatedBy = int(updatedBy.Int64)
+	return s, nil
+}
+
+func (r *disciplineRepository) SaveSettings(ctx context.Context, s *domain.DisciplineSettings) error {
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO discipline_settings (school_id,student_visibility,parent_visibility,escalate_severity,
+             escalate_threshold,escalate_window_days,updated_by)
+         VALUES ($1,$2,$3,$4,$5,$6,NULLIF($7,0))
+         ON CONFLICT (school_id) DO UPDATE SET
+             student_visibility=EXCLUDED.student_visibility, parent_visibility=EXCLUDED.parent_visibility,
+             escalate_severity=EXCLUDED.escalate_severity, escalate_threshold=EXCLUDED.escalate_threshold,
+             escalate_window_days=EXCLUDED.escalate_window_days, updated_by=EXCLUDED.updated_by, updated_at=NOW()
+         RETURNING updated_at`,
+		s.SchoolID, s.StudentVisibility, s.ParentVisibility, s.EscalateSeverity, s.EscalateThreshold, s.EscalateWindow, s.UpdatedBy,
+	).Scan(&s.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("save discipline settings: %w", err)
+	}
+	return nil
+}
+
+// ! ==================== INCIDENTS ====================
+
+func (r *disciplineRepository) CreateIncident(ctx context.Context, i *domain.Incident) error {
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO incidents (school_id,student_id,class_id,reported_by,occurred_at,location,description,severity,
+             status,confidential,escalated_by,escalated_at,escalation_reason)
+         VALUES ($1,$2,$3,NULLIF($4,0),$5,$6,$7,$8,$9,$10,$11,$12,$13) RETURNING id,created_at,updated_at`,
+		i.SchoolID, i.StudentID, i.ClassID, i.ReportedBy, i.OccurredAt, i.Location, i.Description, i.Severity,
+		i.Status, i.Confidential, nullID(i.EscalatedBy), i.EscalatedAt, i.EscalationReason,
+	).Scan(&i.ID, &i.CreatedAt, &i.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("create incident: %w", err)
+	}
+	return nil
+}
+
+// ! UpdateIncident workflow uniquement (statut, escalade, clôture) ; les faits ne sont pas modifiables
+func (r *disciplineRepository) UpdateIncident(ctx context.Context, i *domain.Incident) error {
+	result, err := db.Conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE incidents SET status=$2, escalated_by=$3, escalated_at=$4, escalation_reason=$5,
+             resolved_by=$6, resolved_at=$7, resolution=$8
+         WHERE id = $1`,
+		i.ID, i.Status, nullID(i.EscalatedBy), i.EscalatedAt, i.EscalationReason,
+		nullID(i.ResolvedBy), i.ResolvedAt, i.Resolution)
+	if err != nil {
+		return fmt.Errorf("update incident: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return domain.ErrIncidentNotFound
+	}
+	return nil
+}
+
+func (r *disciplineRepository) FindIncident(ctx context.Context, incidentID int) (*domain.Incident, error) {
+	incident := &domain.Incident{}
+	row := db.Conn(ctx, r.db).QueryRowContext(ctx, incidentSelect+` WHERE i.id = $1`, incidentID)
+	if err := r.scanIncidentRow(row, incident); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrIncidentNotFound
+		}
+		return nil, err
+	}
+	if err := r.loadSanctions(ctx, []*domain.Incident{incident}); err != nil {
+		return nil, err
+	}
+	return incident, nil
+}
+
+// ! FindIncidents du plus récent au plus ancien
+func (r *disciplineRepository) FindIncidents(ctx context.Context, f domain.IncidentFilter) ([]*domain.Incident, error) {
+	where := []string{"i.school_id = $1"}
+	args := []any{f.SchoolID}
+	add := func(clause string, value any) {
+		args = append(args, value)
+		where = append(where, fmt.Sprintf(clause, len(args)))
+	}
+	if f.StudentID > 0 {
+		add("i.student_id = $%d", f.StudentID)
+	}
+	if f.ClassID > 0 {
+		add("i.class_id = $%d", f.ClassID)
+	}
+	if f.ReportedBy > 0 {
+		add("i.reported_by = $%d", f.ReportedBy)
+	}
+	if f.Status != "" {
+		add("i.status = $%d", f.Status)
+	}
+	if f.Severity != "" {
+		add("i.severity = $%d", f.Severity)
+	}
+	if !f.From.IsZero() {
+		add("i.occurred_at >= $%d", f.From)
+	}
+	if !f.To.IsZero() {
+		add("i.occurred_at < $%d", f.To)
+	}
+
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx,
+		incidentSelect+` WHERE `+strings.Join(where, " AND ")+` ORDER BY i.occurred_at DESC, i.id DESC`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("find incidents: %w", err)
+	}
+	defer rows.Close()
+
+	incidents := []*domain.Incident{}
+	for rows.Next() {
+		incident := &domain.Incident{}
+		if err := r.scanIncidentRow(rows, incident); err != nil {
+			return nil, err
+		}
+		incidents = append(incidents, incident)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if err := r.loadSanctions(ctx, incidents); err != nil {
+		return nil, err
+	}
+	return incidents, nil
+}
+
+// ! loadSanctions une requête pour tous les incidents
+func (r *disciplineRepository) loadSanctions(ctx context.Context, incidents []*domain.Incident) error {
+	if len(incidents) == 0 {
+		return nil
+	}
+	byID := make(map[int]*domain.Incident, len(incidents))
+	ids := make([]int, len(incidents))
+	for i, incident := range incidents {
+		byID[incident.ID] = incident
+		ids[i] = incident.ID
+	}
+
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx,
+		`SELECT `+sanctionColumns+` FROM sanctions WHERE incident_id = ANY($1) ORDER BY created_at, id`, intArray(ids))
+	if err != nil {
+		return fmt.Errorf("find sanctions: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		s := &domain.Sanction{}
+		if err := r.scanSanctionRow(rows, s); err != nil {
+			return err
+		}
+		byID[s.IncidentID].Sanctions = append(byID[s.IncidentID].Sanctions, s)
+	}
+	return rows.Err()
+}
+
+func (r *disciplineRepository) CountSince(ctx context.Context, studentID int, since time.Time) (int, error) {
+	var count int
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM incidents WHERE student_id = $1 AND occurred_at >= $2 AND status <> 'dismissed'`,
+		studentID, since,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("count incidents: %w", err)
+	}
+	return count, nil
+}
+
+// ! ==================== SANCTIONS ====================
+
+func (r *disciplineRepository) CreateSanction(ctx context.Context, s *domain.Sanction) error {
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO sanctions (school_id,incident_id,student_id,type,starts_on,ends_on,notes,issued_by)
+         VALUES ($1,$2,$3,$4,$5,$6,$7,NULLIF($8,0)) RETURNING id,created_at`,
+		s.SchoolID, s.IncidentID, s.StudentID, s.Type, s.StartsOn, s.EndsOn, s.Notes, s.IssuedBy,
+	).Scan(&s.ID, &s.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("create sanction: %w", err)
+	}
+	return nil
+}
+
+func (r *disciplineRepository) DeleteSanction(ctx context.Context, incidentID, sanctionID int) error {
+	result, err := db.Conn(ctx, r.db).ExecContext(ctx,
+		`DELETE FROM sanctions WHERE id = $1 AND incident_id = $2`, sanctionID, incidentID)
+	if err != nil {
+		return fmt.Errorf("delete sanction: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return domain.ErrSanctionNotFound
+	}
+	return nil
+}
+
+func (r *disciplineRepository) DeleteByStudent(ctx context.Context, studentID int) error {
+	if _, err := db.Conn(ctx, r.db).ExecContext(ctx, `DELETE FROM incidents WHERE student_id = $1`, studentID); err != nil {
+		return fmt.Errorf("delete student incidents: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"educnet/internal/domain"
+	"educnet/internal/testutil"
+)
+
+func TestDisciplineRepository_Settings(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := NewDisciplineRepository(db)
+	ctx := context.Background()
+	schoolID := testutil.SeedTestSchool(t, db, "Test School", "test-school", "test@school.mg")
+	adminID := testutil.SeedTestUser(t, db, schoolID, "admin@school.mg", "admin")
+
+	settings, err := repo.FindSettings(ctx, schoolID)
+	if err != nil {
+		t.Fatalf("FindSettings() error = %v", err)
+	}
+	if *settings != *domain.DefaultDisciplineSettings(schoolID) {
+		t.Errorf("FindSettings() = %+v, want defaults", settings)
+	}
+
+	settings.StudentVisibility = domain.DisciplineVisibilityNone
+	settings.EscalateThreshold = 5
+	settings.UpdatedBy = adminID
+	if err := repo.SaveSettings(ctx, settings); err != nil {
+		t.Fatalf("SaveSettings() error = %v", err)
+	}
+	saved, err := repo.FindSettings(ctx, schoolID)
+	if err != nil {
+		t.Fatalf("FindSettings() error = %v", err)
+	}
+	if saved.StudentVisibility != domain.DisciplineVisibilityNone || saved.EscalateThreshold != 5 || saved.UpdatedBy != adminID {
+		t.Errorf("FindSettings() = %+v, want saved values", saved)
+	}
+}
+
+func TestDisciplineRepository_Incidents(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := NewDisciplineRepository(db)
+	ctx := context.Background()
+	schoolID := testutil.SeedTestSchool(t, db, "Test School", "test-school", "test@school.mg")
+	teacherID := testutil.SeedTestUser(t, db, schoolID, "teacher@school.mg", "teacher")
+	studentID := testutil.SeedTestUser(t, db, schoolID, "student@school.mg", "student")
+	otherID := testutil.SeedTestUser(t, db, schoolID, "other@school.mg", "student")
+	classID := testutil.SeedTestClass(t, db, schoolID, "6ème A", "6ème", "A", "2026-2027")
+
+	now := time.Now()
+	report := func(studentID int, severity string, occurredAt time.Time) *domain.Incident {
+		t.Helper()
+		incident, err := domain.NewIncident(schoolID, studentID, classID, teacherID, occurredAt, "Cour", "Bagarre", severity, false, now)
+		if err != nil {
+			t.Fatalf("NewIncident() error = %v", err)
+		}
+		if err := repo.CreateIncident(ctx, incident); err != nil {
+			t.Fatalf("CreateIncident() error = %v", err)
+		}
+		return incident
+	}
+	first := report(studentID, domain.SeverityMinor, now.AddDate(0, 0, -40))
+	second := report(studentID, domain.SeveritySerious, now.AddDate(0, 0, -2))
+	report(otherID, domain.SeverityModerate, now.AddDate(0, 0, -1))
+
+	//! Sanction et workflow
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	sanction, err := domain.NewSanction(second, domain.SanctionDetention, &day, nil, "Mercredi", teacherID, now)
+	if err != nil {
+		t.Fatalf("NewSanction() error = %v", err)
+	}
+	if err := repo.CreateSanction(ctx, sanction); err != nil {
+		t.Fatalf("CreateSanction() error = %v", err)
+	}
+	if err := second.Escalate(&teacherID, "Récidive", now); err != nil {
+		t.Fatalf("Escalate() error = %v", err)
+	}
+	if err := repo.UpdateIncident(ctx, second); err != nil {
+		t.Fatalf("UpdateIncident() error = %v", err)
+	}
+
+	found, err := repo.FindIncident(ctx, second.ID)
+	if err != nil {
+		t.Fatalf("FindIncident() error = %v", err)
+	}
+	if found.Status != domain.IncidentEscalated || found.EscalatedBy == nil || *found.EscalatedBy != teacherID {
+		t.Errorf("FindIncident() status = %s escalated_by = %v", found.Status, found.EscalatedBy)
+	}
+	if len(found.Sanctions) != 1 || found.Sanctions[0].Days() != 1 {
+		t.Errorf("FindIncident() sanctions = %+v, want one detention", found.Sanctions)
+	}
+	if found.ClassName != "6ème A" || found.StudentName == "" || found.ReporterName == "" {
+		t.Errorf("FindIncident() names = %q %q %q", found.StudentName, found.ClassName, found.ReporterName)
+	}
+
+	tests := []struct {
+		name   string
+		filter domain.IncidentFilter
+		want   int
+	}{
+		{"School", domain.IncidentFilter{SchoolID: schoolID}, 3},
+		{"Student", domain.IncidentFilter{SchoolID: schoolID, StudentID: studentID}, 2},
+		{"Status", domain.IncidentFilter{SchoolID: schoolID, Status: domain.IncidentEscalated}, 1},
+		{"Severity", domain.IncidentFilter{SchoolID: schoolID, Severity: domain.SeverityMinor}, 1},
+		{"Period", domain.IncidentFilter{SchoolID: schoolID, From: now.AddDate(0, 0, -7), To: now}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			incidents, err := repo.FindIncidents(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("FindIncidents() error = %v", err)
+			}
+			if len(incidents) != tt.want {
+				t.Errorf("FindIncidents() = %d incidents, want %d", len(incidents), tt.want)
+			}
+		})
+	}
+
+	count, err := repo.CountSince(ctx, studentID, now.AddDate(0, 0, -30))
+	if err != nil || count != 1 {
+		t.Errorf("CountSince() = %d, %v, want 1", count, err)
+	}
+
+	if err := repo.DeleteSanction(ctx, first.ID, sanction.ID); err != domain.ErrSanctionNotFound {
+		t.Errorf("DeleteSanction() other incident error = %v, want ErrSanctionNotFound", err)
+	}
+	if err := repo.DeleteSanction(ctx, second.ID, sanction.ID); err != nil {
+		t.Errorf("DeleteSanction() error = %v", err)
+	}
+
+	if err := repo.DeleteByStudent(ctx, studentID); err != nil {
+		t.Fatalf("DeleteByStudent() error = %v", err)
+	}
+	if _, err := repo.FindIncident(ctx, first.ID); err != domain.ErrIncidentNotFound {
+		t.Errorf("FindIncident() after erasure error = %v, want ErrIncidentNotFound", err)
+	}
+	remaining, err := repo.FindIncidents(ctx, domain.IncidentFilter{SchoolID: schoolID})
+	if err != nil || len(remaining) != 1 {
+		t.Errorf("FindIncidents() after erasure = %d, %v, want 1", len(remaining), err)
+	}
+}
@@ -0,0 +1,468 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"educnet/internal/db"
+	"educnet/internal/domain"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+type GradeRepository interface {
+	//! Périodes
+	CreateTerm(ctx context.Context, term *domain.Term) error
+	FindTermByID(ctx context.Context, id int) (*domain.Term, error)
+	FindTermsBySchool(ctx context.Context, schoolID int, academicYear string) ([]*domain.Term, error)
+	TermExists(ctx context.Context, schoolID int, academicYear, name string) (bool, error)
+
+	//! Notes
+	Create(ctx context.Context, grade *domain.Grade) error
+	FindByID(ctx context.Context, id int) (*domain.Grade, error)
+	Update(ctx context.Context, grade *domain.Grade) error
+	Delete(ctx context.Context, id int) error
+	FindByClassTerm(ctx context.Context, classID, termID int) ([]*domain.Grade, error)
+	FindByStudent(ctx context.Context, studentID int) ([]*domain.Grade, error)
+
+	//! Carnet de notes : évaluations (colonnes) et notes associées (cellules)
+	CreateEvaluation(ctx context.Context, evaluation *domain.Evaluation) error
+	UpdateEvaluation(ctx context.Context, evaluation *domain.Evaluation) error
+	DeleteEvaluation(ctx context.Context, id int) error
+	FindEvaluation(ctx context.Context, id int) (*domain.Evaluation, error)
+	FindEvaluations(ctx context.Context, classID, subjectID, termID int) ([]*domain.Evaluation, error)
+	//! FindCells notes des évaluations ; forUpdate verrouille les lignes jusqu'au commit
+	FindCells(ctx context.Context, evaluationIDs []int, forUpdate bool) ([]*domain.Grade, error)
+	ApplyGradebook(ctx context.Context, plan *domain.GradebookPlan) error
+
+	//! Appréciations
+	UpsertComment(ctx context.Context, comment *domain.ReportComment) error
+	FindComments(ctx context.Context, termID int, studentIDs []int) ([]*domain.ReportComment, error)
+}
+
+type gradeRepository struct {
+	db *sql.DB
+}
+
+func NewGradeRepository(db *sql.DB) GradeRepository {
+	return &gradeRepository{db: db}
+}
+
+const (
+	termColumns  = `id,school_id,academic_year,name,position,start_date,end_date,created_at`
+	gradeColumns = `id,school_id,student_id,subject_id,class_id,term_id,teacher_id,label,score,max_score,coefficient,graded_on,created_at,updated_at,evaluation_id,version`
+
+	evaluationColumns = `id,school_id,class_id,subject_id,term_id,created_by,label,max_score,coefficient,graded_on,created_at,updated_at`
+)
+
+// ! checkViolation code PostgreSQL d'une contrainte CHECK (note supérieure au nouveau barème)
+const checkViolation = "23514"
+
+// ! ==================== HELPERS ====================
+func (r *gradeRepository) scanTermRow(row domainScanner, term *domain.Term) error {
+	err := row.Scan(
+		&term.ID, &term.SchoolID, &term.AcademicYear, &term.Name, &term.Position,
+		&term.StartDate, &term.EndDate, &term.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return err
+	}
+	return scanError(err, "scan term row")
+}
+
+func (r *gradeRepository) scanGradeRow(row domainScanner, grade *domain.Grade) error {
+	var teacherID, evaluationID sql.NullInt64
+	err := row.Scan(
+		&grade.ID, &grade.SchoolID, &grade.StudentID, &grade.SubjectID, &grade.ClassID, &grade.TermID,
+		&teacherID, &grade.Label, &grade.Score, &grade.MaxScore, &grade.Coefficient,
+		&grade.GradedOn, &grade.CreatedAt, &grade.UpdatedAt, &evaluationID, &grade.Version,
+	)
+	if err == sql.ErrNoRows {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("scan grade row: %w", err)
+	}
+	if teacherID.Valid {
+		grade.TeacherID = int(teacherID.Int64)
+	}
+	grade.EvaluationID = nullInt(evaluationID)
+	return nil
+}
+
+func (r *gradeRepository) scanEvaluationRow(row domainScanner, e *domain.Evaluation) error {
+	var createdBy sql.NullInt64
+	err := row.Scan(
+		&e.ID, &e.SchoolID, &e.ClassID, &e.SubjectID, &e.TermID, &createdBy,
+		&e.Label, &e.MaxScore, &e.Coefficient, &e.GradedOn, &e.CreatedAt, &e.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("scan evaluation row: %w", err)
+	}
+	e.CreatedBy = int(createdBy.Int64)
+	return nil
+}
+
+// ! ==================== TERMS ====================
+func (r *gradeRepository) CreateTerm(ctx context.Context, term *domain.Term) error {
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO terms (school_id,academic_year,name,position,start_date,end_date)
+         VALUES ($1,$2,$3,$4,$5,$6) RETURNING id,created_at`,
+		term.SchoolID, term.AcademicYear, term.Name, term.Position, term.StartDate, term.EndDate,
+	).Scan(&term.ID, &term.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("create term: %w", err)
+	}
+	return nil
+}
+
+func (r *gradeRepository) FindTermByID(ctx context.Context, id int) (*domain.Term, error) {
+	term := &domain.Term{}
+	row := db.Conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+termColumns+` FROM terms WHERE id = $1`, id)
+	if err := r.scanTermRow(row, term); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrTermNotFound
+		}
+		return nil, err
+	}
+	return term, nil
+}
+
+// ! FindTermsBySchool academicYear vide = toutes les années
+func (r *gradeRepository) FindTermsBySchool(ctx context.Context, schoolID int, academicYear string) ([]*domain.Term, error) {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx,
+		`SELECT `+termColumns+` FROM terms
+         WHERE school_id = $1 AND ($2 = '' OR academic_year = $2)
+         ORDER BY academic_year DESC, position, start_date`, schoolID, academicYear)
+	if err != nil {
+		return nil, fmt.Errorf("find school terms: %w", err)
+	}
+	defer rows.Close()
+
+	terms := []*domain.Term{}
+	for rows.Next() {
+		term := &domain.Term{}
+		if err := r.scanTermRow(rows, term); err != nil {
+			return nil, err
+		}
+		terms = append(terms, term)
+	}
+	return terms, rows.Err()
+}
+
+func (r *gradeRepository) TermExists(ctx context.Context, schoolID int, academicYear, name string) (bool, error) {
+	var exists bool
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT EXISTS(SELECT 1 FROM terms WHERE school_id=$1 AND academic_year=$2 AND LOWER(name)=LOWER($3))`,
+		schoolID, academicYear, name).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("check term exists: %w", err)
+	}
+	return exists, nil
+}
+
+// ! ==================== GRADES ====================
+func (r *gradeRepository) Create(ctx context.Context, grade *domain.Grade) error {
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO grades (school_id,student_id,subject_id,class_id,term_id,teacher_id,label,score,max_score,coefficient,graded_on,evaluation_id)
+         VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12) RETURNING id,created_at,updated_at,version`,
+		grade.SchoolID, grade.StudentID, grade.SubjectID, grade.ClassID, grade.TermID, grade.TeacherID,
+		grade.Label, grade.Score, grade.MaxScore, grade.Coefficient, grade.GradedOn, nullID(grade.EvaluationID),
+	).Scan(&grade.ID, &grade.CreatedAt, &grade.UpdatedAt, &grade.Version)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+		//! cellule du carnet remplie entre-temps par un autre enseignant
+		return domain.ErrGradebookConflict
+	}
+	if err != nil {
+		return fmt.Errorf("create grade: %w", err)
+	}
+	return nil
+}
+
+func (r *gradeRepository) FindByID(ctx context.Context, id int) (*domain.Grade, error) {
+	grade := &domain.Grade{}
+	row := db.Conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+gradeColumns+` FROM grades WHERE id = $1`, id)
+	if err := r.scanGradeRow(row, grade); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrGradeNotFound
+		}
+		return nil, err
+	}
+	return grade, nil
+}
+
+func (r *gradeRepository) Update(ctx context.Context, grade *domain.Grade) error {
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`UPDATE grades SET label=$1,score=$2,max_score=$3,coefficient=$4,graded_on=$5,version=version+1
+         WHERE id=$6 RETURNING updated_at,version`,
+		grade.Label, grade.Score, grade.MaxScore, grade.Coefficient, grade.GradedOn, grade.ID,
+	).Scan(&grade.UpdatedAt, &grade.Version)
+	if err == sql.ErrNoRows {
+		return domain.ErrGradeNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("update grade: %w", err)
+	}
+	return nil
+}
+
+func (r *gradeRepository) Delete(ctx context.Context, id int) error {
+	result, err := db.Conn(ctx, r.db).ExecContext(ctx, `DELETE FROM grades WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete grade: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrGradeNotFound
+	}
+	return nil
+}
+
+func (r *gradeRepository) FindByClassTerm(ctx context.Context, classID, termID int) ([]*domain.Grade, error) {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx,
+		`SELECT `+gradeColumns+` FROM grades
+         WHERE class_id = $1 AND term_id = $2
+         ORDER BY graded_on, id`, classID, termID)
+	if err != nil {
+		return nil, fmt.Errorf("find class grades: %w", err)
+	}
+	defer rows.Close()
+
+	grades := []*domain.Grade{}
+	for rows.Next() {
+		grade := &domain.Grade{}
+		if err := r.scanGradeRow(rows, grade); err != nil {
+			return nil, err
+		}
+		grades = append(grades, grade)
+	}
+	return grades, rows.Err()
+}
+
+func (r *gradeRepository) FindByStudent(ctx context.Context, studentID int) ([]*domain.Grade, error) {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx,
+		`SELECT `+gradeColumns+` FROM grades WHERE student_id = $1 ORDER BY graded_on, id`, studentID)
+	if err != nil {
+		return nil, fmt.Errorf("find student grades: %w", err)
+	}
+	defer rows.Close()
+
+	grades := []*domain.Grade{}
+	for rows.Next() {
+		grade := &domain.Grade{}
+		if err := r.scanGradeRow(rows, grade); err != nil {
+			return nil, err
+		}
+		grades = append(grades, grade)
+	}
+	return grades, rows.Err()
+}
+
+// ! ==================== GRADEBOOK ====================
+
+func (r *gradeRepository) CreateEvaluation(ctx context.Context, e *domain.Evaluation) error {
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO evaluations (school_id,class_id,subject_id,term_id,created_by,label,max_score,coefficient,graded_on)
+         VALUES ($1,$2,$3,$4,NULLIF($5,0),$6,$7,$8,$9) RETURNING id,created_at,updated_at`,
+		e.SchoolID, e.ClassID, e.SubjectID, e.TermID, e.CreatedBy, e.Label, e.MaxScore, e.Coefficient, e.GradedOn,
+	).Scan(&e.ID, &e.CreatedAt, &e.UpdatedAt)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+		return domain.ErrEvaluationExists
+	}
+	if err != nil {
+		return fmt.Errorf("create evaluation: %w", err)
+	}
+	return nil
+}
+
+// ! UpdateEvaluation répercute libellé, barème, coefficient et date sur les notes (version
+// ! incrémentée) ; ErrEvaluationScoreAboveMax si une note dépasse le nouveau barème
+func (r *gradeRepository) UpdateEvaluation(ctx context.Context, e *domain.Evaluation) error {
+	return db.RunInTx(ctx, r.db, func(ctx context.Context) error {
+		conn := db.Conn(ctx, r.db)
+		err := conn.QueryRowContext(ctx,
+			`UPDATE evaluations SET label=$2,max_score=$3,coefficient=$4,graded_on=$5
+             WHERE id=$1 RETURNING updated_at`,
+			e.ID, e.Label, e.MaxScore, e.Coefficient, e.GradedOn,
+		).Scan(&e.UpdatedAt)
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return domain.ErrEvaluationExists
+		}
+		if err == sql.ErrNoRows {
+			return domain.ErrEvaluationNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("update evaluation: %w", err)
+		}
+
+		_, err = conn.ExecContext(ctx,
+			`UPDATE grades SET label=$2,max_score=$3,coefficient=$4,graded_on=$5,version=version+1
+             WHERE evaluation_id=$1`,
+			e.ID, e.Label, e.MaxScore, e.Coefficient, e.GradedOn)
+		if errors.As(err, &pqErr) && pqErr.Code == checkViolation {
+			return domain.ErrEvaluationScoreAboveMax
+		}
+		if err != nil {
+			return fmt.Errorf("update evaluation grades: %w", err)
+		}
+		return nil
+	})
+}
+
+// ! DeleteEvaluation supprime aussi ses notes (ON DELETE CASCADE)
+func (r *gradeRepository) DeleteEvaluation(ctx context.Context, id int) error {
+	result, err := db.Conn(ctx, r.db).ExecContext(ctx, `DELETE FROM evaluations WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete evaluation: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return domain.ErrEvaluationNotFound
+	}
+	return nil
+}
+
+func (r *gradeRepository) FindEvaluation(ctx context.Context, id int) (*domain.Evaluation, error) {
+	e := &domain.Evaluation{}
+	row := db.Conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+evaluationColumns+` FROM evaluations WHERE id = $1`, id)
+	if err := r.scanEvaluationRow(row, e); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrEvaluationNotFound
+		}
+		return nil, err
+	}
+	return e, nil
+}
+
+func (r *gradeRepository) FindEvaluations(ctx context.Context, classID, subjectID, termID int) ([]*domain.Evaluation, error) {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx,
+		`SELECT `+evaluationColumns+` FROM evaluations
+         WHERE class_id = $1 AND subject_id = $2 AND term_id = $3
+         ORDER BY graded_on, id`, classID, subjectID, termID)
+	if err != nil {
+		return nil, fmt.Errorf("find evaluations: %w", err)
+	}
+	defer rows.Close()
+
+	evaluations := []*domain.Evaluation{}
+	for rows.Next() {
+		e := &domain.Evaluation{}
+		if err := r.scanEvaluationRow(rows, e); err != nil {
+			return nil, err
+		}
+		evaluations = append(evaluations, e)
+	}
+	return evaluations, rows.Err()
+}
+
+func (r *gradeRepository) FindCells(ctx context.Context, evaluationIDs []int, forUpdate bool) ([]*domain.Grade, error) {
+	query := `SELECT ` + gradeColumns + ` FROM grades WHERE evaluation_id = ANY($1) ORDER BY id`
+	if forUpdate {
+		query += ` FOR UPDATE`
+	}
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx, query, intArray(evaluationIDs))
+	if err != nil {
+		return nil, fmt.Errorf("find gradebook cells: %w", err)
+	}
+	defer rows.Close()
+
+	grades := []*domain.Grade{}
+	for rows.Next() {
+		grade := &domain.Grade{}
+		if err := r.scanGradeRow(rows, grade); err != nil {
+			return nil, err
+		}
+		grades = append(grades, grade)
+	}
+	return grades, rows.Err()
+}
+
+// ! ApplyGradebook tout ou rien ; chaque modification / suppression vérifie la version lue,
+// ! une cellule modifiée ou remplie entre-temps donne ErrGradebookConflict
+func (r *gradeRepository) ApplyGradebook(ctx context.Context, plan *domain.GradebookPlan) error {
+	return db.RunInTx(ctx, r.db, func(ctx context.Context) error {
+		conn := db.Conn(ctx, r.db)
+		for _, g := range plan.Delete {
+			result, err := conn.ExecContext(ctx, `DELETE FROM grades WHERE id = $1 AND version = $2`, g.ID, g.Version)
+			if err != nil {
+				return fmt.Errorf("delete gradebook cell: %w", err)
+			}
+			if n, _ := result.RowsAffected(); n == 0 {
+				return domain.ErrGradebookConflict
+			}
+		}
+		for _, g := range plan.Update {
+			err := conn.QueryRowContext(ctx,
+				`UPDATE grades SET score=$3, teacher_id=$4, version=version+1
+                 WHERE id = $1 AND version = $2 RETURNING updated_at,version`,
+				g.ID, g.Version, g.Score, g.TeacherID,
+			).Scan(&g.UpdatedAt, &g.Version)
+			if err == sql.ErrNoRows {
+				return domain.ErrGradebookConflict
+			}
+			if err != nil {
+				return fmt.Errorf("update gradebook cell: %w", err)
+			}
+		}
+		for _, g := range plan.Create {
+			if err := r.Create(ctx, g); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ! ==================== COMMENTS ====================
+
+// ! UpsertComment une appréciation par (élève, période, matière) : la dernière remplace la précédente
+func (r *gradeRepository) UpsertComment(ctx context.Context, comment *domain.ReportComment) error {
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO report_comments (school_id,student_id,term_id,subject_id,author_id,comment)
+         VALUES ($1,$2,$3,$4,$5,$6)
+         ON CONFLICT (student_id, term_id, COALESCE(subject_id, 0))
+         DO UPDATE SET comment=EXCLUDED.comment, author_id=EXCLUDED.author_id, updated_at=NOW()
+         RETURNING id,updated_at`,
+		comment.SchoolID, comment.StudentID, comment.TermID, comment.SubjectID, comment.AuthorID, comment.Comment,
+	).Scan(&comment.ID, &comment.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("upsert report comment: %w", err)
+	}
+	return nil
+}
+
+func (r *gradeRepository) FindComments(ctx context.Context, termID int, studentIDs []int) ([]*domain.ReportComment, error) {
+	ids := make(pq.Int64Array, len(studentIDs))
+	for i, id := range studentIDs {
+		ids[i] = int64(id)
+	}
+
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx,
+		`SELECT id,school_id,student_id,term_id,subject_id,author_id,comment,updated_at
+         FROM report_comments WHERE term_id = $1 AND student_id = ANY($2)`, termID, ids)
+	if err != nil {
+		return nil, fmt.Errorf("find report comments: %w", err)
+	}
+	defer rows.Close()
+
+	comments := []*domain.ReportComment{}
+	for rows.Next() {
+		c := &domain.ReportComment{}
+		var subjectID, authorID sql.NullInt64
+		if err := rows.Scan(&c.ID, &c.SchoolID, &c.StudentID, &c.TermID, &subjectID, &authorID, &c.Comment, &c.UpdatedAt); err != nil {
+			return nil, scanError(err, "scan report comment")
+		}
+		c.SubjectID = nullInt(subjectID)
+		if authorID.Valid {
+			c.AuthorID = int(authorID.Int64)
+		}
+		comments = append(comments, c)
+	}
+	return comments, rows.Err()
+}
@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"educnet/internal/domain"
+	"educnet/internal/testutil"
+)
+
+func TestGradeRepository_TermsAndGrades(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := NewGradeRepository(db)
+	ctx := context.Background()
+	schoolID := testutil.SeedTestSchool(t, db, "Test School", "test-school", "test@school.mg")
+	teacherID := testutil.SeedTestUser(t, db, schoolID, "prof@test.mg", domain.RoleTeacher)
+	studentID := testutil.SeedTestUser(t, db, schoolID, "eleve@test.mg", domain.RoleStudent)
+	classID := testutil.SeedTestClass(t, db, schoolID, "6ème A", "6ème", "A", "2025-2026")
+	subjectID := testutil.SeedTestSubject(t, db, schoolID, "Math", "MATH", "")
+
+	start := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	term, _ := domain.NewTerm(schoolID, "2025-2026", "Trimestre 1", 1, start, start.AddDate(0, 3, 0))
+	if err := repo.CreateTerm(ctx, term); err != nil {
+		t.Fatalf("CreateTerm() error = %v", err)
+	}
+	if exists, _ := repo.TermExists(ctx, schoolID, "2025-2026", "trimestre 1"); !exists {
+		t.Error("TermExists() = false, want true (case-insensitive)")
+	}
+	terms, err := repo.FindTermsBySchool(ctx, schoolID, "")
+	if err != nil || len(terms) != 1 {
+		t.Fatalf("FindTermsBySchool() = %d terms, err = %v", len(terms), err)
+	}
+
+	grade, _ := domain.NewGrade(schoolID, studentID, subjectID, classID, term.ID, teacherID, "Devoir 1", 15, 20, 2, start.AddDate(0, 0, 10))
+	if err := repo.Create(ctx, grade); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	grade.Score = 17.5
+	if err := repo.Update(ctx, grade); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	grades, err := repo.FindByClassTerm(ctx, classID, term.ID)
+	if err != nil || len(grades) != 1 {
+		t.Fatalf("FindByClassTerm() = %d grades, err = %v", len(grades), err)
+	}
+	if grades[0].Score != 17.5 || grades[0].Coefficient != 2 || grades[0].TeacherID != teacherID {
+		t.Errorf("FindByClassTerm() = %+v", grades[0])
+	}
+
+	if err := repo.Delete(ctx, grade.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repo.FindByID(ctx, grade.ID); err != domain.ErrGradeNotFound {
+		t.Errorf("FindByID() after delete error = %v, want %v", err, domain.ErrGradeNotFound)
+	}
+}
+
+func TestGradeRepository_UpsertComment(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := NewGradeRepository(db)
+	ctx := context.Background()
+	schoolID := testutil.SeedTestSchool(t, db, "Test School", "test-school", "test@school.mg")
+	adminID := testutil.SeedTestUser(t, db, schoolID, "admin@test.mg", domain.RoleAdmin)
+	studentID := testutil.SeedTestUser(t, db, schoolID, "eleve@test.mg", domain.RoleStudent)
+	subjectID := testutil.SeedTestSubject(t, db, schoolID, "Math", "MATH", "")
+
+	start := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	term, _ := domain.NewTerm(schoolID, "2025-2026", "Trimestre 1", 1, start, start.AddDate(0, 3, 0))
+	if err := repo.CreateTerm(ctx, term); err != nil {
+		t.Fatalf("CreateTerm() error = %v", err)
+	}
+
+	//! Deux appréciations générales successives + une par matière
+	for _, c := range []*domain.ReportComment{
+		{SchoolID: schoolID, StudentID: studentID, TermID: term.ID, AuthorID: adminID, Comment: "Bon trimestre"},
+		{SchoolID: schoolID, StudentID: studentID, TermID: term.ID, AuthorID: adminID, Comment: "Très bon trimestre"},
+		{SchoolID: schoolID, StudentID: studentID, TermID: term.ID, SubjectID: &subjectID, AuthorID: adminID, Comment: "Sérieux"},
+	} {
+		if err := repo.UpsertComment(ctx, c); err != nil {
+			t.Fatalf("UpsertComment() error = %v", err)
+		}
+	}
+
+	comments, err := repo.FindComments(ctx, term.ID, []int{studentID})
+	if err != nil || len(comments) != 2 {
+		t.Fatalf("FindComments() = %d comments, err = %v, want 2", len(comments), err)
+	}
+	for _, c := range comments {
+		if c.SubjectID == nil && c.Comment != "Très bon trimestre" {
+			t.Errorf("general comment = %q, want the latest one", c.Comment)
+		}
+	}
+}
+
+func TestGradeRepository_Gradebook(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := NewGradeRepository(db)
+	ctx := context.Background()
+	schoolID := testutil.SeedTestSchool(t, db, "Test School", "test-school", "test@school.mg")
+	teacherID := testutil.SeedTestUser(t, db, schoolID, "prof@test.mg", domain.RoleTeacher)
+	studentID := testutil.SeedTestUser(t, db, schoolID, "eleve@test.mg", domain.RoleStudent)
+	classID := testutil.SeedTestClass(t, db, schoolID, "6ème A", "6ème", "A", "2025-2026")
+	subjectID := testutil.SeedTestSubject(t, db, schoolID, "Math", "MATH", "")
+
+	start := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	term, _ := domain.NewTerm(schoolID, "2025-2026", "Trimestre 1", 1, start, start.AddDate(0, 3, 0))
+	if err := repo.CreateTerm(ctx, term); err != nil {
+		t.Fatalf("CreateTerm() error = %v", err)
+	}
+
+	evaluation, _ := domain.NewEvaluation(schoolID, classID, subjectID, term.ID, teacherID, "Devoir 1", 20, 2, start.AddDate(0, 0, 10))
+	if err := repo.CreateEvaluation(ctx, evaluation); err != nil {
+		t.Fatalf("CreateEvaluation() error = %v", err)
+	}
+	duplicate, _ := domain.NewEvaluation(schoolID, classID, subjectID, term.ID, teacherID, "devoir 1", 20, 1, start)
+	if err := repo.CreateEvaluation(ctx, duplicate); err != domain.ErrEvaluationExists {
+		t.Errorf("CreateEvaluation() duplicate label error = %v, want ErrEvaluationExists", err)
+	}
+
+	//! Création puis modification : la version suit chaque écriture
+	grade, _ := evaluation.NewGrade(studentID, teacherID, 15)
+	if err := repo.ApplyGradebook(ctx, &domain.GradebookPlan{Create: []*domain.Grade{grade}}); err != nil {
+		t.Fatalf("ApplyGradebook() create error = %v", err)
+	}
+	cells, err := repo.FindCells(ctx, []int{evaluation.ID}, false)
+	if err != nil || len(cells) != 1 {
+		t.Fatalf("FindCells() = %d cells, err = %v", len(cells), err)
+	}
+	cell := cells[0]
+	if cell.Version != 1 || cell.EvaluationID == nil || *cell.EvaluationID != evaluation.ID || cell.Coefficient != 2 {
+		t.Fatalf("FindCells() = %+v", cell)
+	}
+
+	stale := *cell
+	cell.Score = 18
+	if err := repo.ApplyGradebook(ctx, &domain.GradebookPlan{Update: []*domain.Grade{cell}}); err != nil {
+		t.Fatalf("ApplyGradebook() update error = %v", err)
+	}
+	if cell.Version != 2 {
+		t.Errorf("Version after update = %d, want 2", cell.Version)
+	}
+	stale.Score = 10
+	if err := repo.ApplyGradebook(ctx, &domain.GradebookPlan{Update: []*domain.Grade{&stale}}); err != domain.ErrGradebookConflict {
+		t.Errorf("ApplyGradebook() stale version error = %v, want ErrGradebookConflict", err)
+	}
+	again, _ := evaluation.NewGrade(studentID, teacherID, 12)
+	if err := repo.ApplyGradebook(ctx, &domain.GradebookPlan{Create: []*domain.Grade{again}}); err != domain.ErrGradebookConflict {
+		t.Errorf("ApplyGradebook() filled cell error = %v, want ErrGradebookConflict", err)
+	}
+
+	//! Les notes suivent l'évaluation ; un barème inférieur à une note est refusé
+	evaluation.Label, evaluation.Coefficient = "Devoir surveillé", 3
+	if err := repo.UpdateEvaluation(ctx, evaluation); err != nil {
+		t.Fatalf("UpdateEvaluation() error = %v", err)
+	}
+	updated, err := repo.FindByID(ctx, grade.ID)
+	if err != nil || updated.Label != "Devoir surveillé" || updated.Coefficient != 3 || updated.Version != 3 {
+		t.Errorf("FindByID() after UpdateEvaluation = %+v, err = %v", updated, err)
+	}
+	evaluation.MaxScore = 10
+	if err := repo.UpdateEvaluation(ctx, evaluation); err != domain.ErrEvaluationScoreAboveMax {
+		t.Errorf("UpdateEvaluation() lower max error = %v, want ErrEvaluationScoreAboveMax", err)
+	}
+
+	evaluations, err := repo.FindEvaluations(ctx, classID, subjectID, term.ID)
+	if err != nil || len(evaluations) != 1 || evaluations[0].MaxScore != 20 {
+		t.Errorf("FindEvaluations() = %+v, err = %v", evaluations, err)
+	}
+
+	if err := repo.DeleteEvaluation(ctx, evaluation.ID); err != nil {
+		t.Fatalf("DeleteEvaluation() error = %v", err)
+	}
+	if _, err := repo.FindByID(ctx, grade.ID); err != domain.ErrGradeNotFound {
+		t.Errorf("FindByID() after DeleteEvaluation error = %v, want ErrGradeNotFound", err)
+	}
+}
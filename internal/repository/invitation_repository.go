@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"educnet/internal/db"
+	"educnet/internal/domain"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+type InvitationRepository interface {
+	Create(ctx context.Context, invitation *domain.Invitation) error
+	FindByID(ctx context.Context, id int) (*domain.Invitation, error)
+	FindBySchool(ctx context.Context, schoolID int) ([]*domain.Invitation, error)
+	MarkAccepted(ctx context.Context, id, userID int) error
+	MarkRevoked(ctx context.Context, id int) error
+}
+
+type invitationRepository struct {
+	db *sql.DB
+}
+
+func NewInvitationRepository(db *sql.DB) InvitationRepository {
+	return &invitationRepository{db: db}
+}
+
+const invitationColumns = `id,school_id,email,role,class_id,subject_ids,invited_by,expires_at,accepted_at,accepted_user_id,revoked_at,created_at`
+
+// ! ==================== HELPERS ====================
+func (r *invitationRepository) scanInvitationRow(row domainScanner, inv *domain.Invitation) error {
+	var classID, invitedBy, acceptedUserID sql.NullInt64
+	var subjectIDs pq.Int64Array
+	var acceptedAt, revokedAt sql.NullTime
+
+	err := row.Scan(
+		&inv.ID, &inv.SchoolID, &inv.Email, &inv.Role,
+		&classID, &subjectIDs, &invitedBy, &inv.ExpiresAt,
+		&acceptedAt, &acceptedUserID, &revokedAt, &inv.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("scan invitation row: %w", err)
+	}
+
+	inv.ClassID = nullInt(classID)
+	inv.AcceptedUserID = nullInt(acceptedUserID)
+	if invitedBy.Valid {
+		inv.InvitedBy = int(invitedBy.Int64)
+	}
+	inv.SubjectIDs = make([]int, len(subjectIDs))
+	for i, id := range subjectIDs {
+		inv.SubjectIDs[i] = int(id)
+	}
+	if acceptedAt.Valid {
+		inv.AcceptedAt = &acceptedAt.Time
+	}
+	if revokedAt.Valid {
+		inv.RevokedAt = &revokedAt.Time
+	}
+	return nil
+}
+
+// ! ==================== METHODS ====================
+func (r *invitationRepository) Create(ctx context.Context, inv *domain.Invitation) error {
+	subjectIDs := make(pq.Int64Array, len(inv.SubjectIDs))
+	for i, id := range inv.SubjectIDs {
+		subjectIDs[i] = int64(id)
+	}
+
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO invitations (school_id,email,role,class_id,subject_ids,invited_by,expires_at)
+         VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING id,created_at`,
+		inv.SchoolID, inv.Email, inv.Role, inv.ClassID, subjectIDs, inv.InvitedBy, inv.ExpiresAt,
+	).Scan(&inv.ID, &inv.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("create invitation: %w", err)
+	}
+	return nil
+}
+
+func (r *invitationRepository) FindByID(ctx context.Context, id int) (*domain.Invitation, error) {
+	inv := &domain.Invitation{}
+	row := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT `+invitationColumns+` FROM invitations WHERE id = $1`, id)
+
+	if err := r.scanInvitationRow(row, inv); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrInvitationNotFound
+		}
+		return nil, err
+	}
+	return inv, nil
+}
+
+func (r *invitationRepository) FindBySchool(ctx context.Context, schoolID int) ([]*domain.Invitation, error) {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx,
+		`SELECT `+invitationColumns+` FROM invitations WHERE school_id = $1 ORDER BY created_at DESC`, schoolID)
+	if err != nil {
+		return nil, fmt.Errorf("find school invitations: %w", err)
+	}
+	defer rows.Close()
+
+	invitations := []*domain.Invitation{}
+	for rows.Next() {
+		inv := &domain.Invitation{}
+		if err := r.scanInvitationRow(rows, inv); err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, inv)
+	}
+	return invitations, rows.Err()
+}
+
+// ! MarkAccepted usage unique : échoue si l'invitation a déjà été acceptée ou révoquée
+func (r *invitationRepository) MarkAccepted(ctx context.Context, id, userID int) error {
+	result, err := db.Conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE invitations SET accepted_at=NOW(),accepted_user_id=$1
+         WHERE id=$2 AND accepted_at IS NULL AND revoked_at IS NULL`, userID, id)
+	if err != nil {
+		return fmt.Errorf("accept invitation: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrInvitationAlreadyAccepted
+	}
+	return nil
+}
+
+func (r *invitationRepository) MarkRevoked(ctx context.Context, id int) error {
+	result, err := db.Conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE invitations SET revoked_at=NOW() WHERE id=$1 AND accepted_at IS NULL AND revoked_at IS NULL`, id)
+	if err != nil {
+		return fmt.Errorf("revoke invitation: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrInvitationNotFound
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"educnet/internal/domain"
+	"educnet/internal/testutil"
+)
+
+func TestInvitationRepository_CreateAndFind(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := NewInvitationRepository(db)
+	ctx := context.Background()
+	schoolID := testutil.SeedTestSchool(t, db, "Test School", "test-school", "test@school.mg")
+	adminID := testutil.SeedTestUser(t, db, schoolID, "admin@test.mg", domain.RoleAdmin)
+	subjectA := testutil.SeedTestSubject(t, db, schoolID, "Math", "MATH", "")
+	subjectB := testutil.SeedTestSubject(t, db, schoolID, "Physique", "PHY", "")
+
+	inv, _ := domain.NewInvitation(schoolID, adminID, "prof@test.mg", domain.RoleTeacher, 0, []int{subjectA, subjectB}, 72*time.Hour)
+	if err := repo.Create(ctx, inv); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	found, err := repo.FindByID(ctx, inv.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if found.Email != "prof@test.mg" || found.InvitedBy != adminID {
+		t.Errorf("FindByID() = %+v", found)
+	}
+	if len(found.SubjectIDs) != 2 || found.SubjectIDs[0] != subjectA {
+		t.Errorf("FindByID() SubjectIDs = %v, want [%d %d]", found.SubjectIDs, subjectA, subjectB)
+	}
+	if found.Status() != domain.InvitationStatusPending {
+		t.Errorf("FindByID() Status = %v, want pending", found.Status())
+	}
+
+	all, err := repo.FindBySchool(ctx, schoolID)
+	if err != nil || len(all) != 1 {
+		t.Errorf("FindBySchool() = %d invitations, err = %v", len(all), err)
+	}
+
+	if _, err := repo.FindByID(ctx, 99999); err != domain.ErrInvitationNotFound {
+		t.Errorf("FindByID() unknown error = %v, want %v", err, domain.ErrInvitationNotFound)
+	}
+}
+
+func TestInvitationRepository_MarkAcceptedOnce(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := NewInvitationRepository(db)
+	ctx := context.Background()
+	schoolID := testutil.SeedTestSchool(t, db, "Test School", "test-school", "test@school.mg")
+	adminID := testutil.SeedTestUser(t, db, schoolID, "admin@test.mg", domain.RoleAdmin)
+	classID := testutil.SeedTestClass(t, db, schoolID, "6ème A", "6ème", "A", "2025-2026")
+	studentID := testutil.SeedTestUser(t, db, schoolID, "eleve@test.mg", domain.RoleStudent)
+
+	inv, _ := domain.NewInvitation(schoolID, adminID, "eleve@test.mg", domain.RoleStudent, classID, nil, time.Hour)
+	if err := repo.Create(ctx, inv); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if err := repo.MarkAccepted(ctx, inv.ID, studentID); err != nil {
+		t.Fatalf("MarkAccepted() error = %v", err)
+	}
+	if err := repo.MarkAccepted(ctx, inv.ID, studentID); err != domain.ErrInvitationAlreadyAccepted {
+		t.Errorf("MarkAccepted() twice error = %v, want %v", err, domain.ErrInvitationAlreadyAccepted)
+	}
+	if err := repo.MarkRevoked(ctx, inv.ID); err != domain.ErrInvitationNotFound {
+		t.Errorf("MarkRevoked() accepted error = %v, want %v", err, domain.ErrInvitationNotFound)
+	}
+
+	found, _ := repo.FindByID(ctx, inv.ID)
+	if found.Status() != domain.InvitationStatusAccepted || *found.AcceptedUserID != studentID {
+		t.Errorf("After MarkAccepted() Status = %v, AcceptedUserID = %v", found.Status(), found.AcceptedUserID)
+	}
+}
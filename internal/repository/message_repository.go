@@ -3,7 +3,9 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"educnet/internal/db"
 	"educnet/internal/domain"
+	"fmt"
 )
 
 type MessageRepository interface {
@@ -11,6 +13,8 @@ type MessageRepository interface {
 	GetRecentMessages(ctx context.Context, classID int, limit int) ([]domain.Message, error)
 	UserInClass(ctx context.Context, userID, classID int) (bool, error)
 	DeleteMessage(ctx context.Context, messageID int) error
+	EachByClass(ctx context.Context, classID int, fn func(domain.Message) error) error
+	FindByUser(ctx context.Context, userID int) ([]domain.Message, error)
 }
 
 type messageRepository struct {
@@ -79,7 +83,7 @@ func (r *messageRepository) UserInClass(ctx context.Context, userID, classID int
 	err := r.db.QueryRowContext(ctx, `
         SELECT EXISTS (
             SELECT 1 FROM student_classes 
-            WHERE student_id = $1 AND class_id = $2
+            WHERE student_id = $1 AND class_id = $2 AND is_active
         )
     `, userID, classID).Scan(&exists)
 
@@ -91,3 +95,59 @@ func (r *messageRepository) DeleteMessage(ctx context.Context, messageID int) er
 		"DELETE FROM messages WHERE id = $1", messageID)
 	return err
 }
+
+const messageViewColumns = `id, content, COALESCE(message_type, 'text'), file_url, COALESCE(is_pinned, FALSE), created_at, updated_at,
+            user_id, first_name, last_name, full_name, role, avatar_url, class_id, class_name`
+
+// ! EachByClass historique complet de la classe, du plus ancien au plus récent ;
+// ! fn est appelée message par message (export des conversations)
+func (r *messageRepository) EachByClass(ctx context.Context, classID int, fn func(domain.Message) error) error {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx, `
+        SELECT `+messageViewColumns+`
+        FROM messages_view WHERE class_id = $1
+        ORDER BY created_at, id`, classID)
+	if err != nil {
+		return fmt.Errorf("find class messages: %w", err)
+	}
+	return r.eachMessage(rows, fn)
+}
+
+// ! FindByUser messages écrits par l'utilisateur, toutes classes confondues (export RGPD)
+func (r *messageRepository) FindByUser(ctx context.Context, userID int) ([]domain.Message, error) {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx, `
+        SELECT `+messageViewColumns+`
+        FROM messages_view WHERE user_id = $1
+        ORDER BY created_at, id`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("find user messages: %w", err)
+	}
+
+	messages := []domain.Message{}
+	err = r.eachMessage(rows, func(msg domain.Message) error {
+		messages = append(messages, msg)
+		return nil
+	})
+	return messages, err
+}
+
+// ! eachMessage lit les lignes messageViewColumns et ferme rows
+func (r *messageRepository) eachMessage(rows *sql.Rows, fn func(domain.Message) error) error {
+	defer rows.Close()
+
+	for rows.Next() {
+		var msg domain.Message
+		if err := rows.Scan(
+			&msg.ID, &msg.Content, &msg.MessageType, &msg.FileURL, &msg.IsPinned,
+			&msg.CreatedAt, &msg.UpdatedAt, &msg.User.ID, &msg.User.FirstName,
+			&msg.User.LastName, &msg.User.FullName, &msg.User.Role, &msg.User.AvatarURL,
+			&msg.ClassID, &msg.ClassName,
+		); err != nil {
+			return scanError(err, "scan message")
+		}
+		msg.UserID = msg.User.ID
+		if err := fn(msg); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"educnet/internal/db"
+	"educnet/internal/domain"
+	"fmt"
+	"time"
+)
+
+// ! PrivacyRepository RGPD : politiques de rétention, purge et nettoyage avant effacement
+type PrivacyRepository interface {
+	//! Rétention
+	FindRetentionPolicy(ctx context.Context, schoolID int) (*domain.RetentionPolicy, error)
+	SaveRetentionPolicy(ctx context.Context, policy *domain.RetentionPolicy) error
+	FindRetentionPolicies(ctx context.Context) ([]*domain.RetentionPolicy, error)
+	PurgeMessages(ctx context.Context, schoolID int, before time.Time) (int64, error)
+
+	//! Effacement
+	ClearWaitlists(ctx context.Context, studentID int) error
+	DeleteInvitationsByEmail(ctx context.Context, schoolID int, email string) error
+}
+
+type privacyRepository struct {
+	db *sql.DB
+}
+
+func NewPrivacyRepository(db *sql.DB) PrivacyRepository {
+	return &privacyRepository{db: db}
+}
+
+const retentionColumns = `school_id,message_retention_days,updated_by,updated_at`
+
+func (r *privacyRepository) scanPolicyRow(row domainScanner, policy *domain.RetentionPolicy) error {
+	var days, updatedBy sql.NullInt64
+	err := row.Scan(&policy.SchoolID, &days, &updatedBy, &policy.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("scan retention policy: %w", err)
+	}
+	policy.MessageRetentionDays = nullInt(days)
+	if updatedBy.Valid {
+		policy.UpdatedBy = int(updatedBy.Int64)
+	}
+	return nil
+}
+
+// ! ==================== RETENTION ====================
+
+// ! FindRetentionPolicy politique vide (conservation illimitée) si l'école n'en a jamais défini
+func (r *privacyRepository) FindRetentionPolicy(ctx context.Context, schoolID int) (*domain.RetentionPolicy, error) {
+	policy := &domain.RetentionPolicy{}
+	row := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT `+retentionColumns+` FROM retention_policies WHERE school_id = $1`, schoolID)
+	if err := r.scanPolicyRow(row, policy); err != nil {
+		if err == sql.ErrNoRows {
+			return &domain.RetentionPolicy{SchoolID: schoolID}, nil
+		}
+		return nil, err
+	}
+	return policy, nil
+}
+
+func (r *privacyRepository) SaveRetentionPolicy(ctx context.Context, policy *domain.RetentionPolicy) error {
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO retention_policies (school_id,message_retention_days,updated_by)
+         VALUES ($1,$2,NULLIF($3,0))
+         ON CONFLICT (school_id) DO UPDATE SET
+             message_retention_days=EXCLUDED.message_retention_days,
+             updated_by=EXCLUDED.updated_by, updated_at=NOW()
+         RETURNING updated_at`,
+		policy.SchoolID, policy.MessageRetentionDays, policy.UpdatedBy,
+	).Scan(&policy.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("save retention policy: %w", err)
+	}
+	return nil
+}
+
+// ! FindRetentionPolicies écoles ayant une durée de conservation des messages (purge périodique)
+func (r *privacyRepository) FindRetentionPolicies(ctx context.Context) ([]*domain.RetentionPolicy, error) {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx,
+		`SELECT `+retentionColumns+` FROM retention_policies
+         WHERE message_retention_days IS NOT NULL ORDER BY school_id`)
+	if err != nil {
+		return nil, fmt.Errorf("find retention policies: %w", err)
+	}
+	defer rows.Close()
+
+	policies := []*domain.RetentionPolicy{}
+	for rows.Next() {
+		policy := &domain.RetentionPolicy{}
+		if err := r.scanPolicyRow(rows, policy); err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+	return policies, rows.Err()
+}
+
+// ! PurgeMessages supprime les messages des classes de l'école antérieurs à before
+func (r *privacyRepository) PurgeMessages(ctx context.Context, schoolID int, before time.Time) (int64, error) {
+	result, err := db.Conn(ctx, r.db).ExecContext(ctx,
+		`DELETE FROM messages m USING classes c
+         WHERE c.id = m.class_id AND c.school_id = $1 AND m.created_at < $2`, schoolID, before)
+	if err != nil {
+		return 0, fmt.Errorf("purge messages: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// ! ==================== ERASURE ====================
+
+// ! ClearWaitlists retire l'élève de toutes les listes d'attente
+func (r *privacyRepository) ClearWaitlists(ctx context.Context, studentID int) error {
+	_, err := db.Conn(ctx, r.db).ExecContext(ctx, `DELETE FROM class_waitlist WHERE student_id = $1`, studentID)
+	if err != nil {
+		return fmt.Errorf("clear student waitlists: %w", err)
+	}
+	return nil
+}
+
+// ! DeleteInvitationsByEmail supprime les invitations adressées à email (adresse = donnée personnelle)
+func (r *privacyRepository) DeleteInvitationsByEmail(ctx context.Context, schoolID int, email string) error {
+	_, err := db.Conn(ctx, r.db).ExecContext(ctx,
+		`DELETE FROM invitations WHERE school_id = $1 AND LOWER(email) = LOWER($2)`, schoolID, email)
+	if err != nil {
+		return fmt.Errorf("delete invitations by email: %w", err)
+	}
+	return nil
+}
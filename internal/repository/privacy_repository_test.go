@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"educnet/internal/domain"
+	"educnet/internal/testutil"
+)
+
+func TestPrivacyRepository_RetentionPolicy(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := NewPrivacyRepository(db)
+	ctx := context.Background()
+	schoolID := testutil.SeedTestSchool(t, db, "Test School", "test-school", "test@school.mg")
+	adminID := testutil.SeedTestUser(t, db, schoolID, "admin@test.mg", domain.RoleAdmin)
+
+	policy, err := repo.FindRetentionPolicy(ctx, schoolID)
+	if err != nil || policy.MessageRetentionDays != nil {
+		t.Fatalf("FindRetentionPolicy() default = %+v, err = %v", policy, err)
+	}
+
+	days := 90
+	policy, _ = domain.NewRetentionPolicy(schoolID, &days, adminID)
+	if err := repo.SaveRetentionPolicy(ctx, policy); err != nil {
+		t.Fatalf("SaveRetentionPolicy() error = %v", err)
+	}
+
+	found, err := repo.FindRetentionPolicy(ctx, schoolID)
+	if err != nil || found.MessageRetentionDays == nil || *found.MessageRetentionDays != 90 || found.UpdatedBy != adminID {
+		t.Errorf("FindRetentionPolicy() = %+v, err = %v", found, err)
+	}
+
+	all, err := repo.FindRetentionPolicies(ctx)
+	if err != nil || len(all) != 1 {
+		t.Errorf("FindRetentionPolicies() = %d policies, err = %v", len(all), err)
+	}
+
+	//! Retour à la conservation illimitée : exclue de la purge
+	policy, _ = domain.NewRetentionPolicy(schoolID, nil, adminID)
+	if err := repo.SaveRetentionPolicy(ctx, policy); err != nil {
+		t.Fatalf("SaveRetentionPolicy() nil error = %v", err)
+	}
+	if all, _ := repo.FindRetentionPolicies(ctx); len(all) != 0 {
+		t.Errorf("FindRetentionPolicies() = %d policies, want 0", len(all))
+	}
+}
+
+func TestPrivacyRepository_PurgeMessages(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := NewPrivacyRepository(db)
+	ctx := context.Background()
+	schoolA := testutil.SeedTestSchool(t, db, "School A", "school-a", "a@school.mg")
+	schoolB := testutil.SeedTestSchool(t, db, "School B", "school-b", "b@school.mg")
+	userA := testutil.SeedTestUser(t, db, schoolA, "a@test.mg", domain.RoleTeacher)
+	userB := testutil.SeedTestUser(t, db, schoolB, "b@test.mg", domain.RoleTeacher)
+	classA := testutil.SeedTestClass(t, db, schoolA, "6eme A", "6eme", "A", "2025-2026")
+	classB := testutil.SeedTestClass(t, db, schoolB, "6eme A", "6eme", "A", "2025-2026")
+
+	insert := func(userID, classID int, age time.Duration) {
+		if _, err := db.Exec(`INSERT INTO messages (content,user_id,class_id,created_at) VALUES ('msg',$1,$2,$3)`,
+			userID, classID, time.Now().Add(-age)); err != nil {
+			t.Fatalf("insert message: %v", err)
+		}
+	}
+	insert(userA, classA, 100*24*time.Hour)
+	insert(userA, classA, time.Hour)
+	insert(userB, classB, 100*24*time.Hour)
+
+	purged, err := repo.PurgeMessages(ctx, schoolA, time.Now().AddDate(0, 0, -30))
+	if err != nil || purged != 1 {
+		t.Fatalf("PurgeMessages() = %d, err = %v, want 1", purged, err)
+	}
+
+	var remaining int
+	db.QueryRow(`SELECT COUNT(*) FROM messages`).Scan(&remaining)
+	if remaining != 2 {
+		t.Errorf("remaining messages = %d, want 2 (other school untouched)", remaining)
+	}
+}
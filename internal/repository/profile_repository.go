@@ -0,0 +1,280 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"educnet/internal/db"
+	"educnet/internal/domain"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+type ProfileRepository interface {
+	//! Élèves
+	FindStudentProfile(ctx context.Context, userID int) (*domain.StudentProfile, error)
+	SaveStudentProfile(ctx context.Context, profile *domain.StudentProfile) error
+	StudentNumberExists(ctx context.Context, schoolID int, number string, excludeUserID int) (bool, error)
+
+	//! Enseignants
+	FindTeacherProfile(ctx context.Context, userID int) (*domain.TeacherProfile, error)
+	SaveTeacherProfile(ctx context.Context, profile *domain.TeacherProfile) error
+
+	//! Documents
+	CreateDocument(ctx context.Context, doc *domain.ProfileDocument) error
+	FindDocument(ctx context.Context, userID, documentID int) (*domain.ProfileDocument, error)
+	FindDocuments(ctx context.Context, userID int) ([]*domain.ProfileDocument, error)
+	DeleteDocument(ctx context.Context, userID, documentID int) error
+
+	//! Effacement RGPD : supprime profil, responsables et documents, retourne les fichiers à supprimer
+	DeleteByUser(ctx context.Context, userID int) ([]string, error)
+}
+
+type profileRepository struct {
+	db *sql.DB
+}
+
+func NewProfileRepository(db *sql.DB) ProfileRepository {
+	return &profileRepository{db: db}
+}
+
+// ! uniqueViolation code PostgreSQL d'une violation d'unicité (matricule attribué en concurrence)
+const uniqueViolation = "23505"
+
+const documentColumns = `id,school_id,user_id,kind,title,file_name,content_type,size,storage_path,uploaded_by,created_at`
+
+func (r *profileRepository) scanDocumentRow(row domainScanner, doc *domain.ProfileDocument) error {
+	var uploadedBy sql.NullInt64
+	err := row.Scan(&doc.ID, &doc.SchoolID, &doc.UserID, &doc.Kind, &doc.Title, &doc.FileName,
+		&doc.ContentType, &doc.Size, &doc.StoragePath, &uploadedBy, &doc.CreatedAt)
+	if err == sql.ErrNoRows {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("scan profile document row: %w", err)
+	}
+	doc.UploadedBy = int(uploadedBy.Int64)
+	return nil
+}
+
+// ! ==================== STUDENTS ====================
+
+// ! FindStudentProfile profil vide (UpdatedAt zéro) si jamais renseigné
+func (r *profileRepository) FindStudentProfile(ctx context.Context, userID int) (*domain.StudentProfile, error) {
+	p := &domain.StudentProfile{UserID: userID, Guardians: []*domain.Guardian{}}
+	var dateOfBirth sql.NullTime
+	var number sql.NullString
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT school_id,date_of_birth,address,student_number,medical_notes,updated_at
+         FROM student_profiles WHERE user_id = $1`, userID,
+	).Scan(&p.SchoolID, &dateOfBirth, &p.Address, &number, &p.MedicalNotes, &p.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return p, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find student profile: %w", err)
+	}
+	if dateOfBirth.Valid {
+		p.DateOfBirth = &dateOfBirth.Time
+	}
+	p.StudentNumber = nullString(number)
+
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx,
+		`SELECT id,full_name,relationship,phone,email,is_legal_guardian,is_emergency_contact
+         FROM student_guardians WHERE student_id = $1 ORDER BY position, id`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("find guardians: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		g := &domain.Guardian{}
+		if err := rows.Scan(&g.ID, &g.FullName, &g.Relationship, &g.Phone, &g.Email, &g.IsLegalGuardian, &g.IsEmergencyContact); err != nil {
+			return nil, scanError(err, "scan guardian")
+		}
+		p.Guardians = append(p.Guardians, g)
+	}
+	return p, rows.Err()
+}
+
+// ! SaveStudentProfile upsert du profil ; la liste des responsables est remplacée en entier
+func (r *profileRepository) SaveStudentProfile(ctx context.Context, p *domain.StudentProfile) error {
+	return db.RunInTx(ctx, r.db, func(ctx context.Context) error {
+		conn := db.Conn(ctx, r.db)
+		err := conn.QueryRowContext(ctx,
+			`INSERT INTO student_profiles (user_id,school_id,date_of_birth,address,student_number,medical_notes)
+             VALUES ($1,$2,$3,$4,$5,$6)
+             ON CONFLICT (user_id) DO UPDATE SET date_of_birth=EXCLUDED.date_of_birth, address=EXCLUDED.address,
+                 student_number=EXCLUDED.student_number, medical_notes=EXCLUDED.medical_notes
+             RETURNING updated_at`,
+			p.UserID, p.SchoolID, p.DateOfBirth, p.Address,
+			sql.NullString{String: p.StudentNumber, Valid: p.StudentNumber != ""}, p.MedicalNotes,
+		).Scan(&p.UpdatedAt)
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return domain.ErrStudentNumberTaken
+		}
+		if err != nil {
+			return fmt.Errorf("save student profile: %w", err)
+		}
+
+		if _, err := conn.ExecContext(ctx, `DELETE FROM student_guardians WHERE student_id = $1`, p.UserID); err != nil {
+			return fmt.Errorf("clear guardians: %w", err)
+		}
+		for i, g := range p.Guardians {
+			err := conn.QueryRowContext(ctx,
+				`INSERT INTO student_guardians (school_id,student_id,full_name,relationship,phone,email,
+                     is_legal_guardian,is_emergency_contact,position)
+                 VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING id`,
+				p.SchoolID, p.UserID, g.FullName, g.Relationship, g.Phone, g.Email, g.IsLegalGuardian, g.IsEmergencyContact, i,
+			).Scan(&g.ID)
+			if err != nil {
+				return fmt.Errorf("create guardian: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// ! StudentNumberExists matricule déjà attribué dans l'école, hors excludeUserID
+func (r *profileRepository) StudentNumberExists(ctx context.Context, schoolID int, number string, excludeUserID int) (bool, error) {
+	var exists bool
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT EXISTS(SELECT 1 FROM student_profiles WHERE school_id = $1 AND student_number = $2 AND user_id <> $3)`,
+		schoolID, number, excludeUserID,
+	).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("check student number: %w", err)
+	}
+	return exists, nil
+}
+
+// ! ==================== TEACHERS ====================
+
+// ! FindTeacherProfile profil vide (UpdatedAt zéro) si jamais renseigné
+func (r *profileRepository) FindTeacherProfile(ctx context.Context, userID int) (*domain.TeacherProfile, error) {
+	p := &domain.TeacherProfile{UserID: userID, Qualifications: []string{}}
+	var qualifications pq.StringArray
+	var hireDate sql.NullTime
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT school_id,qualifications,hire_date,updated_at FROM teacher_profiles WHERE user_id = $1`, userID,
+	).Scan(&p.SchoolID, &qualifications, &hireDate, &p.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return p, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find teacher profile: %w", err)
+	}
+	p.Qualifications = []string(qualifications)
+	if hireDate.Valid {
+		p.HireDate = &hireDate.Time
+	}
+	return p, nil
+}
+
+func (r *profileRepository) SaveTeacherProfile(ctx context.Context, p *domain.TeacherProfile) error {
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO teacher_profiles (user_id,school_id,qualifications,hire_date)
+         VALUES ($1,$2,$3,$4)
+         ON CONFLICT (user_id) DO UPDATE SET qualifications=EXCLUDED.qualifications, hire_date=EXCLUDED.hire_date
+         RETURNING updated_at`,
+		p.UserID, p.SchoolID, pq.StringArray(p.Qualifications), p.HireDate,
+	).Scan(&p.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("save teacher profile: %w", err)
+	}
+	return nil
+}
+
+// ! ==================== DOCUMENTS ====================
+func (r *profileRepository) CreateDocument(ctx context.Context, doc *domain.ProfileDocument) error {
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO profile_documents (school_id,user_id,kind,title,file_name,content_type,size,storage_path,uploaded_by)
+         VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING id,created_at`,
+		doc.SchoolID, doc.UserID, doc.Kind, doc.Title, doc.FileName, doc.ContentType, doc.Size, doc.StoragePath, doc.UploadedBy,
+	).Scan(&doc.ID, &doc.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("create profile document: %w", err)
+	}
+	return nil
+}
+
+func (r *profileRepository) FindDocument(ctx context.Context, userID, documentID int) (*domain.ProfileDocument, error) {
+	doc := &domain.ProfileDocument{}
+	row := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT `+documentColumns+` FROM profile_documents WHERE id = $1 AND user_id = $2`, documentID, userID)
+	if err := r.scanDocumentRow(row, doc); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrProfileDocumentNotFound
+		}
+		return nil, err
+	}
+	return doc, nil
+}
+
+func (r *profileRepository) FindDocuments(ctx context.Context, userID int) ([]*domain.ProfileDocument, error) {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx,
+		`SELECT `+documentColumns+` FROM profile_documents WHERE user_id = $1 ORDER BY created_at DESC, id DESC`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("find profile documents: %w", err)
+	}
+	defer rows.Close()
+
+	docs := []*domain.ProfileDocument{}
+	for rows.Next() {
+		doc := &domain.ProfileDocument{}
+		if err := r.scanDocumentRow(rows, doc); err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+	return docs, rows.Err()
+}
+
+func (r *profileRepository) DeleteDocument(ctx context.Context, userID, documentID int) error {
+	result, err := db.Conn(ctx, r.db).ExecContext(ctx,
+		`DELETE FROM profile_documents WHERE id = $1 AND user_id = $2`, documentID, userID)
+	if err != nil {
+		return fmt.Errorf("delete profile document: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrProfileDocumentNotFound
+	}
+	return nil
+}
+
+// ! ==================== ERASURE ====================
+func (r *profileRepository) DeleteByUser(ctx context.Context, userID int) ([]string, error) {
+	var paths []string
+	err := db.RunInTx(ctx, r.db, func(ctx context.Context) error {
+		conn := db.Conn(ctx, r.db)
+		rows, err := conn.QueryContext(ctx, `DELETE FROM profile_documents WHERE user_id = $1 RETURNING storage_path`, userID)
+		if err != nil {
+			return fmt.Errorf("delete profile documents: %w", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var path string
+			if err := rows.Scan(&path); err != nil {
+				return scanError(err, "scan document path")
+			}
+			paths = append(paths, path)
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		for _, query := range []string{
+			`DELETE FROM student_guardians WHERE student_id = $1`,
+			`DELETE FROM student_profiles WHERE user_id = $1`,
+			`DELETE FROM teacher_profiles WHERE user_id = $1`,
+		} {
+			if _, err := conn.ExecContext(ctx, query, userID); err != nil {
+				return fmt.Errorf("delete profile: %w", err)
+			}
+		}
+		return nil
+	})
+	return paths, err
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"educnet/internal/domain"
+	"educnet/internal/testutil"
+)
+
+func TestProfileRepository_StudentProfile(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := NewProfileRepository(db)
+	ctx := context.Background()
+	schoolID := testutil.SeedTestSchool(t, db, "Test School", "test-school", "test@school.mg")
+	studentID := testutil.SeedTestUser(t, db, schoolID, "eleve@test.mg", domain.RoleStudent)
+	otherID := testutil.SeedTestUser(t, db, schoolID, "eleve2@test.mg", domain.RoleStudent)
+
+	empty, err := repo.FindStudentProfile(ctx, studentID)
+	if err != nil || !empty.UpdatedAt.IsZero() || len(empty.Guardians) != 0 {
+		t.Fatalf("FindStudentProfile() before save = %+v, %v, want empty profile", empty, err)
+	}
+
+	born := time.Date(2012, 3, 14, 0, 0, 0, 0, time.UTC)
+	father, _ := domain.NewGuardian("Rakoto Jean", "Père", "0340000000", "", true, true)
+	mother, _ := domain.NewGuardian("Rasoa", "Mère", "", "rasoa@mail.mg", true, false)
+	profile := &domain.StudentProfile{
+		UserID:        studentID,
+		SchoolID:      schoolID,
+		DateOfBirth:   &born,
+		Address:       "Lot II",
+		StudentNumber: "2026-0001",
+		MedicalNotes:  "Asthme",
+		Guardians:     []*domain.Guardian{father, mother},
+	}
+	if err := repo.SaveStudentProfile(ctx, profile); err != nil {
+		t.Fatalf("SaveStudentProfile() error = %v", err)
+	}
+
+	//! Les responsables sont remplacés, pas ajoutés
+	profile.Guardians = []*domain.Guardian{mother}
+	if err := repo.SaveStudentProfile(ctx, profile); err != nil {
+		t.Fatalf("SaveStudentProfile() second call error = %v", err)
+	}
+	found, err := repo.FindStudentProfile(ctx, studentID)
+	if err != nil {
+		t.Fatalf("FindStudentProfile() error = %v", err)
+	}
+	if found.StudentNumber != "2026-0001" || found.DateOfBirth == nil || !found.DateOfBirth.Equal(born) {
+		t.Errorf("FindStudentProfile() = %+v", found)
+	}
+	if len(found.Guardians) != 1 || found.Guardians[0].FullName != "Rasoa" {
+		t.Errorf("Guardians = %+v, want only Rasoa", found.Guardians)
+	}
+
+	taken, err := repo.StudentNumberExists(ctx, schoolID, "2026-0001", otherID)
+	if err != nil || !taken {
+		t.Errorf("StudentNumberExists() = %v, %v, want true", taken, err)
+	}
+	taken, err = repo.StudentNumberExists(ctx, schoolID, "2026-0001", studentID)
+	if err != nil || taken {
+		t.Errorf("StudentNumberExists() for its owner = %v, %v, want false", taken, err)
+	}
+}
+
+func TestProfileRepository_DocumentsAndErasure(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := NewProfileRepository(db)
+	ctx := context.Background()
+	schoolID := testutil.SeedTestSchool(t, db, "Test School", "test-school", "test@school.mg")
+	teacherID := testutil.SeedTestUser(t, db, schoolID, "prof@test.mg", domain.RoleTeacher)
+
+	hired := time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC)
+	if err := repo.SaveTeacherProfile(ctx, &domain.TeacherProfile{
+		UserID: teacherID, SchoolID: schoolID, Qualifications: []string{"CAPES"}, HireDate: &hired,
+	}); err != nil {
+		t.Fatalf("SaveTeacherProfile() error = %v", err)
+	}
+
+	doc, _ := domain.NewProfileDocument(schoolID, teacherID, domain.DocumentCV, "", "cv.pdf", "application/pdf", 1024, teacherID)
+	doc.StoragePath = "storage/profiles/1/cv.pdf"
+	if err := repo.CreateDocument(ctx, doc); err != nil {
+		t.Fatalf("CreateDocument() error = %v", err)
+	}
+	if _, err := repo.FindDocument(ctx, teacherID+1, doc.ID); err != domain.ErrProfileDocumentNotFound {
+		t.Errorf("FindDocument() other user error = %v, want %v", err, domain.ErrProfileDocumentNotFound)
+	}
+
+	paths, err := repo.DeleteByUser(ctx, teacherID)
+	if err != nil || len(paths) != 1 || paths[0] != doc.StoragePath {
+		t.Fatalf("DeleteByUser() = %v, %v, want [%s]", paths, err, doc.StoragePath)
+	}
+	profile, err := repo.FindTeacherProfile(ctx, teacherID)
+	if err != nil || len(profile.Qualifications) != 0 || profile.HireDate != nil {
+		t.Errorf("FindTeacherProfile() after erasure = %+v, %v, want empty", profile, err)
+	}
+	docs, err := repo.FindDocuments(ctx, teacherID)
+	if err != nil || len(docs) != 0 {
+		t.Errorf("FindDocuments() after erasure = %d, %v, want 0", len(docs), err)
+	}
+}
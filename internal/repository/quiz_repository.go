@@ -0,0 +1,443 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"educnet/internal/db"
+	"educnet/internal/domain"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type QuizRepository interface {
+	//! Banque de questions
+	CreateQuestion(ctx context.Context, question *domain.Question) error
+	FindQuestionByID(ctx context.Context, id int) (*domain.Question, error)
+	UpdateQuestion(ctx context.Context, question *domain.Question) error
+	DeleteQuestion(ctx context.Context, id int) error
+	FindQuestionsBySubject(ctx context.Context, subjectID int) ([]*domain.Question, error)
+	QuestionInUse(ctx context.Context, id int) (bool, error)
+
+	//! Quiz
+	Create(ctx context.Context, quiz *domain.Quiz, questionIDs []int) error
+	FindByID(ctx context.Context, id int) (*domain.Quiz, error)
+	FindByIDForUpdate(ctx context.Context, id int) (*domain.Quiz, error)
+	FindByClass(ctx context.Context, classID int) ([]*domain.Quiz, error)
+	FindClosingBetween(ctx context.Context, classIDs []int, teacherID int, from, to time.Time) ([]*domain.Quiz, error)
+	FindQuestions(ctx context.Context, quizID int) ([]*domain.Question, error)
+	Delete(ctx context.Context, id int) error
+
+	//! Tentatives
+	CreateAttempt(ctx context.Context, attempt *domain.QuizAttempt) error
+	FindAttemptByID(ctx context.Context, id int) (*domain.QuizAttempt, error)
+	FindAttempts(ctx context.Context, quizID, studentID int) ([]*domain.QuizAttempt, error)
+	FindAttemptsByQuiz(ctx context.Context, quizID int) ([]*domain.QuizAttempt, error)
+	SubmitAttempt(ctx context.Context, attempt *domain.QuizAttempt) error
+
+	//! Note reportée dans grades (une par élève et par quiz)
+	UpsertGrade(ctx context.Context, quizID int, grade *domain.Grade) error
+}
+
+type quizRepository struct {
+	db *sql.DB
+}
+
+func NewQuizRepository(db *sql.DB) QuizRepository {
+	return &quizRepository{db: db}
+}
+
+const (
+	questionColumns = `id,school_id,subject_id,author_id,type,prompt,choices,answer_key,points,created_at,updated_at`
+	quizColumns     = `id,school_id,subject_id,class_id,term_id,teacher_id,title,description,duration_minutes,opens_at,closes_at,max_attempts,coefficient,created_at,updated_at`
+	attemptColumns  = `id,school_id,quiz_id,student_id,number,started_at,deadline_at,submitted_at,answers,score,max_score`
+)
+
+// ! ==================== HELPERS ====================
+func (r *quizRepository) scanQuestionRow(row domainScanner, q *domain.Question) error {
+	var authorID sql.NullInt64
+	var choices, answer []byte
+	err := row.Scan(
+		&q.ID, &q.SchoolID, &q.SubjectID, &authorID, &q.Type, &q.Prompt,
+		&choices, &answer, &q.Points, &q.CreatedAt, &q.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("scan question row: %w", err)
+	}
+	if authorID.Valid {
+		q.AuthorID = int(authorID.Int64)
+	}
+	if err := json.Unmarshal(choices, &q.Choices); err != nil {
+		return fmt.Errorf("decode question choices: %w", err)
+	}
+	if err := json.Unmarshal(answer, &q.Answer); err != nil {
+		return fmt.Errorf("decode answer key: %w", err)
+	}
+	return nil
+}
+
+func (r *quizRepository) scanQuizRow(row domainScanner, quiz *domain.Quiz) error {
+	var teacherID sql.NullInt64
+	err := row.Scan(
+		&quiz.ID, &quiz.SchoolID, &quiz.SubjectID, &quiz.ClassID, &quiz.TermID, &teacherID,
+		&quiz.Title, &quiz.Description, &quiz.DurationMinutes, &quiz.OpensAt, &quiz.ClosesAt,
+		&quiz.MaxAttempts, &quiz.Coefficient, &quiz.CreatedAt, &quiz.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("scan quiz row: %w", err)
+	}
+	if teacherID.Valid {
+		quiz.TeacherID = int(teacherID.Int64)
+	}
+	return nil
+}
+
+func (r *quizRepository) scanAttemptRow(row domainScanner, a *domain.QuizAttempt) error {
+	var submittedAt sql.NullTime
+	var answers []byte
+	err := row.Scan(
+		&a.ID, &a.SchoolID, &a.QuizID, &a.StudentID, &a.Number, &a.StartedAt, &a.DeadlineAt,
+		&submittedAt, &answers, &a.Score, &a.MaxScore,
+	)
+	if err == sql.ErrNoRows {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("scan quiz attempt row: %w", err)
+	}
+	if submittedAt.Valid {
+		a.SubmittedAt = &submittedAt.Time
+	}
+	if err := json.Unmarshal(answers, &a.Answers); err != nil {
+		return fmt.Errorf("decode attempt answers: %w", err)
+	}
+	return nil
+}
+
+// ! jsonParam JSONB envoyé sous forme de texte (lib/pq enverrait []byte en bytea)
+func jsonParam(v any) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("encode json: %w", err)
+	}
+	return string(b), nil
+}
+
+func (r *quizRepository) queryQuestions(ctx context.Context, query string, args ...any) ([]*domain.Question, error) {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("find questions: %w", err)
+	}
+	defer rows.Close()
+
+	questions := []*domain.Question{}
+	for rows.Next() {
+		q := &domain.Question{}
+		if err := r.scanQuestionRow(rows, q); err != nil {
+			return nil, err
+		}
+		questions = append(questions, q)
+	}
+	return questions, rows.Err()
+}
+
+func (r *quizRepository) queryAttempts(ctx context.Context, query string, args ...any) ([]*domain.QuizAttempt, error) {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("find quiz attempts: %w", err)
+	}
+	defer rows.Close()
+
+	attempts := []*domain.QuizAttempt{}
+	for rows.Next() {
+		a := &domain.QuizAttempt{}
+		if err := r.scanAttemptRow(rows, a); err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, a)
+	}
+	return attempts, rows.Err()
+}
+
+// ! ==================== QUESTIONS ====================
+func (r *quizRepository) CreateQuestion(ctx context.Context, q *domain.Question) error {
+	choices, err := jsonParam(q.Choices)
+	if err != nil {
+		return err
+	}
+	answer, err := jsonParam(q.Answer)
+	if err != nil {
+		return err
+	}
+
+	err = db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO questions (school_id,subject_id,author_id,type,prompt,choices,answer_key,points)
+         VALUES ($1,$2,$3,$4,$5,$6,$7,$8) RETURNING id,created_at,updated_at`,
+		q.SchoolID, q.SubjectID, q.AuthorID, q.Type, q.Prompt, choices, answer, q.Points,
+	).Scan(&q.ID, &q.CreatedAt, &q.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("create question: %w", err)
+	}
+	return nil
+}
+
+func (r *quizRepository) FindQuestionByID(ctx context.Context, id int) (*domain.Question, error) {
+	q := &domain.Question{}
+	row := db.Conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+questionColumns+` FROM questions WHERE id = $1`, id)
+	if err := r.scanQuestionRow(row, q); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrQuestionNotFound
+		}
+		return nil, err
+	}
+	return q, nil
+}
+
+func (r *quizRepository) UpdateQuestion(ctx context.Context, q *domain.Question) error {
+	choices, err := jsonParam(q.Choices)
+	if err != nil {
+		return err
+	}
+	answer, err := jsonParam(q.Answer)
+	if err != nil {
+		return err
+	}
+
+	err = db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`UPDATE questions SET type=$1,prompt=$2,choices=$3,answer_key=$4,points=$5
+         WHERE id=$6 RETURNING updated_at`,
+		q.Type, q.Prompt, choices, answer, q.Points, q.ID,
+	).Scan(&q.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return domain.ErrQuestionNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("update question: %w", err)
+	}
+	return nil
+}
+
+func (r *quizRepository) DeleteQuestion(ctx context.Context, id int) error {
+	result, err := db.Conn(ctx, r.db).ExecContext(ctx, `DELETE FROM questions WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete question: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrQuestionNotFound
+	}
+	return nil
+}
+
+func (r *quizRepository) FindQuestionsBySubject(ctx context.Context, subjectID int) ([]*domain.Question, error) {
+	return r.queryQuestions(ctx,
+		`SELECT `+questionColumns+` FROM questions WHERE subject_id = $1 ORDER BY created_at, id`, subjectID)
+}
+
+// ! QuestionInUse vrai si la question figure dans au moins un quiz
+func (r *quizRepository) QuestionInUse(ctx context.Context, id int) (bool, error) {
+	var used bool
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT EXISTS(SELECT 1 FROM quiz_questions WHERE question_id = $1)`, id).Scan(&used)
+	if err != nil {
+		return false, fmt.Errorf("check question in use: %w", err)
+	}
+	return used, nil
+}
+
+// ! ==================== QUIZZES ====================
+
+// ! Create quiz et ses questions (ordre de questionIDs) dans une transaction
+func (r *quizRepository) Create(ctx context.Context, quiz *domain.Quiz, questionIDs []int) error {
+	return db.RunInTx(ctx, r.db, func(ctx context.Context) error {
+		conn := db.Conn(ctx, r.db)
+		err := conn.QueryRowContext(ctx,
+			`INSERT INTO quizzes (school_id,subject_id,class_id,term_id,teacher_id,title,description,
+                duration_minutes,opens_at,closes_at,max_attempts,coefficient)
+             VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12) RETURNING id,created_at,updated_at`,
+			quiz.SchoolID, quiz.SubjectID, quiz.ClassID, quiz.TermID, quiz.TeacherID, quiz.Title, quiz.Description,
+			quiz.DurationMinutes, quiz.OpensAt, quiz.ClosesAt, quiz.MaxAttempts, quiz.Coefficient,
+		).Scan(&quiz.ID, &quiz.CreatedAt, &quiz.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("create quiz: %w", err)
+		}
+
+		for i, questionID := range questionIDs {
+			if _, err := conn.ExecContext(ctx,
+				`INSERT INTO quiz_questions (quiz_id,question_id,position) VALUES ($1,$2,$3)`,
+				quiz.ID, questionID, i+1); err != nil {
+				return fmt.Errorf("add quiz question: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *quizRepository) findQuiz(ctx context.Context, query string, id int) (*domain.Quiz, error) {
+	quiz := &domain.Quiz{}
+	row := db.Conn(ctx, r.db).QueryRowContext(ctx, query, id)
+	if err := r.scanQuizRow(row, quiz); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrQuizNotFound
+		}
+		return nil, err
+	}
+	return quiz, nil
+}
+
+func (r *quizRepository) FindByID(ctx context.Context, id int) (*domain.Quiz, error) {
+	return r.findQuiz(ctx, `SELECT `+quizColumns+` FROM quizzes WHERE id = $1`, id)
+}
+
+// ! FindByIDForUpdate verrouille le quiz (FOR UPDATE : les démarrages de
+// ! tentatives concurrents sont sérialisés). À appeler dans une transaction.
+func (r *quizRepository) FindByIDForUpdate(ctx context.Context, id int) (*domain.Quiz, error) {
+	return r.findQuiz(ctx, `SELECT `+quizColumns+` FROM quizzes WHERE id = $1 FOR UPDATE`, id)
+}
+
+func (r *quizRepository) FindByClass(ctx context.Context, classID int) ([]*domain.Quiz, error) {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx,
+		`SELECT `+quizColumns+` FROM quizzes WHERE class_id = $1 ORDER BY opens_at DESC, id`, classID)
+	if err != nil {
+		return nil, fmt.Errorf("find class quizzes: %w", err)
+	}
+	defer rows.Close()
+
+	quizzes := []*domain.Quiz{}
+	for rows.Next() {
+		quiz := &domain.Quiz{}
+		if err := r.scanQuizRow(rows, quiz); err != nil {
+			return nil, err
+		}
+		quizzes = append(quizzes, quiz)
+	}
+	return quizzes, rows.Err()
+}
+
+// ! FindClosingBetween quiz des classes (ou de l'enseignant) dont la clôture tombe dans [from, to)
+func (r *quizRepository) FindClosingBetween(ctx context.Context, classIDs []int, teacherID int, from, to time.Time) ([]*domain.Quiz, error) {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx,
+		`SELECT `+quizColumns+` FROM quizzes
+         WHERE (class_id = ANY($1) OR teacher_id = $2) AND closes_at >= $3 AND closes_at < $4
+         ORDER BY closes_at, id`, intArray(classIDs), teacherID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("find closing quizzes: %w", err)
+	}
+	defer rows.Close()
+
+	quizzes := []*domain.Quiz{}
+	for rows.Next() {
+		quiz := &domain.Quiz{}
+		if err := r.scanQuizRow(rows, quiz); err != nil {
+			return nil, err
+		}
+		quizzes = append(quizzes, quiz)
+	}
+	return quizzes, rows.Err()
+}
+
+func (r *quizRepository) FindQuestions(ctx context.Context, quizID int) ([]*domain.Question, error) {
+	return r.queryQuestions(ctx,
+		`SELECT q.id,q.school_id,q.subject_id,q.author_id,q.type,q.prompt,q.choices,q.answer_key,q.points,q.created_at,q.updated_at
+         FROM quiz_questions qq JOIN questions q ON q.id = qq.question_id
+         WHERE qq.quiz_id = $1 ORDER BY qq.position`, quizID)
+}
+
+func (r *quizRepository) Delete(ctx context.Context, id int) error {
+	result, err := db.Conn(ctx, r.db).ExecContext(ctx, `DELETE FROM quizzes WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete quiz: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrQuizNotFound
+	}
+	return nil
+}
+
+// ! ==================== ATTEMPTS ====================
+func (r *quizRepository) CreateAttempt(ctx context.Context, a *domain.QuizAttempt) error {
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO quiz_attempts (school_id,quiz_id,student_id,number,started_at,deadline_at,max_score)
+         VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING id`,
+		a.SchoolID, a.QuizID, a.StudentID, a.Number, a.StartedAt, a.DeadlineAt, a.MaxScore,
+	).Scan(&a.ID)
+	if err != nil {
+		return fmt.Errorf("create quiz attempt: %w", err)
+	}
+	if a.Answers == nil {
+		a.Answers = []domain.Answer{}
+	}
+	return nil
+}
+
+func (r *quizRepository) FindAttemptByID(ctx context.Context, id int) (*domain.QuizAttempt, error) {
+	a := &domain.QuizAttempt{}
+	row := db.Conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+attemptColumns+` FROM quiz_attempts WHERE id = $1`, id)
+	if err := r.scanAttemptRow(row, a); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrQuizAttemptNotFound
+		}
+		return nil, err
+	}
+	return a, nil
+}
+
+func (r *quizRepository) FindAttempts(ctx context.Context, quizID, studentID int) ([]*domain.QuizAttempt, error) {
+	return r.queryAttempts(ctx,
+		`SELECT `+attemptColumns+` FROM quiz_attempts WHERE quiz_id = $1 AND student_id = $2 ORDER BY number`,
+		quizID, studentID)
+}
+
+func (r *quizRepository) FindAttemptsByQuiz(ctx context.Context, quizID int) ([]*domain.QuizAttempt, error) {
+	return r.queryAttempts(ctx,
+		`SELECT `+attemptColumns+` FROM quiz_attempts WHERE quiz_id = $1 ORDER BY student_id, number`, quizID)
+}
+
+// ! SubmitAttempt enregistre la correction ; une tentative déjà rendue n'est pas modifiée
+func (r *quizRepository) SubmitAttempt(ctx context.Context, a *domain.QuizAttempt) error {
+	answers, err := jsonParam(a.Answers)
+	if err != nil {
+		return err
+	}
+
+	result, err := db.Conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE quiz_attempts SET submitted_at=$1,answers=$2,score=$3,max_score=$4
+         WHERE id=$5 AND submitted_at IS NULL`,
+		a.SubmittedAt, answers, a.Score, a.MaxScore, a.ID)
+	if err != nil {
+		return fmt.Errorf("submit quiz attempt: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrQuizAttemptSubmitted
+	}
+	return nil
+}
+
+// ! ==================== GRADES ====================
+
+// ! UpsertGrade crée ou met à jour la note de l'élève pour ce quiz
+func (r *quizRepository) UpsertGrade(ctx context.Context, quizID int, g *domain.Grade) error {
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO grades (school_id,student_id,subject_id,class_id,term_id,teacher_id,label,score,max_score,coefficient,graded_on,quiz_id)
+         VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12)
+         ON CONFLICT (quiz_id, student_id) WHERE quiz_id IS NOT NULL
+         DO UPDATE SET label=EXCLUDED.label, score=EXCLUDED.score, max_score=EXCLUDED.max_score,
+             coefficient=EXCLUDED.coefficient, graded_on=EXCLUDED.graded_on, version=grades.version+1
+         RETURNING id,created_at,updated_at`,
+		g.SchoolID, g.StudentID, g.SubjectID, g.ClassID, g.TermID,
+		sql.NullInt64{Int64: int64(g.TeacherID), Valid: g.TeacherID > 0},
+		g.Label, g.Score, g.MaxScore, g.Coefficient, g.GradedOn, quizID,
+	).Scan(&g.ID, &g.CreatedAt, &g.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("upsert quiz grade: %w", err)
+	}
+	return nil
+}
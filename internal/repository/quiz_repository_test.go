@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"educnet/internal/domain"
+	"educnet/internal/testutil"
+)
+
+func TestQuizRepository_QuizLifecycle(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := NewQuizRepository(db)
+	gradeRepo := NewGradeRepository(db)
+	ctx := context.Background()
+	schoolID := testutil.SeedTestSchool(t, db, "Test School", "test-school", "test@school.mg")
+	teacherID := testutil.SeedTestUser(t, db, schoolID, "prof@test.mg", domain.RoleTeacher)
+	studentID := testutil.SeedTestUser(t, db, schoolID, "eleve@test.mg", domain.RoleStudent)
+	classID := testutil.SeedTestClass(t, db, schoolID, "6ème A", "6ème", "A", "2025-2026")
+	subjectID := testutil.SeedTestSubject(t, db, schoolID, "Math", "MATH", "")
+
+	start := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	term, _ := domain.NewTerm(schoolID, "2025-2026", "Trimestre 1", 1, start, start.AddDate(0, 3, 0))
+	if err := gradeRepo.CreateTerm(ctx, term); err != nil {
+		t.Fatalf("CreateTerm() error = %v", err)
+	}
+
+	//! Banque de questions (clé JSONB relue à l'identique)
+	yes := true
+	question, _ := domain.NewQuestion(schoolID, subjectID, teacherID, domain.QuestionMultipleChoice, "Nombres premiers ?",
+		[]string{"2", "4", "5"}, domain.AnswerKey{Choices: []int{0, 2}}, 2)
+	tf, _ := domain.NewQuestion(schoolID, subjectID, teacherID, domain.QuestionTrueFalse, "0 est pair", nil,
+		domain.AnswerKey{Bool: &yes}, 1)
+	for _, q := range []*domain.Question{question, tf} {
+		if err := repo.CreateQuestion(ctx, q); err != nil {
+			t.Fatalf("CreateQuestion() error = %v", err)
+		}
+	}
+	found, err := repo.FindQuestionByID(ctx, question.ID)
+	if err != nil {
+		t.Fatalf("FindQuestionByID() error = %v", err)
+	}
+	if len(found.Choices) != 3 || len(found.Answer.Choices) != 2 || found.Answer.Choices[1] != 2 {
+		t.Errorf("FindQuestionByID() = %+v", found)
+	}
+	bank, err := repo.FindQuestionsBySubject(ctx, subjectID)
+	if err != nil || len(bank) != 2 {
+		t.Fatalf("FindQuestionsBySubject() = %d questions, err = %v", len(bank), err)
+	}
+
+	//! Quiz : questions dans l'ordre demandé, question utilisée verrouillée
+	opens := start.AddDate(0, 0, 7)
+	quiz, _ := domain.NewQuiz(schoolID, subjectID, classID, term.ID, teacherID, "Quiz 1", "", 20, opens, opens.Add(48*time.Hour), 2, 1)
+	if err := repo.Create(ctx, quiz, []int{tf.ID, question.ID}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	questions, err := repo.FindQuestions(ctx, quiz.ID)
+	if err != nil || len(questions) != 2 || questions[0].ID != tf.ID {
+		t.Fatalf("FindQuestions() = %v, err = %v", questions, err)
+	}
+	if used, _ := repo.QuestionInUse(ctx, question.ID); !used {
+		t.Error("QuestionInUse() = false, want true")
+	}
+	if quizzes, _ := repo.FindByClass(ctx, classID); len(quizzes) != 1 {
+		t.Errorf("FindByClass() = %d quizzes, want 1", len(quizzes))
+	}
+	if quizzes, _ := repo.FindClosingBetween(ctx, []int{classID}, 0, opens, opens.AddDate(0, 0, 7)); len(quizzes) != 1 {
+		t.Errorf("FindClosingBetween() = %d quizzes, want 1", len(quizzes))
+	}
+	if quizzes, _ := repo.FindClosingBetween(ctx, nil, teacherID, opens.AddDate(0, 0, 7), opens.AddDate(0, 0, 14)); len(quizzes) != 0 {
+		t.Errorf("FindClosingBetween() after close = %d quizzes, want 0", len(quizzes))
+	}
+
+	//! Tentative : soumise une seule fois
+	attempt := &domain.QuizAttempt{SchoolID: schoolID, QuizID: quiz.ID, StudentID: studentID, Number: 1,
+		StartedAt: opens, DeadlineAt: quiz.Deadline(opens), MaxScore: 3}
+	if err := repo.CreateAttempt(ctx, attempt); err != nil {
+		t.Fatalf("CreateAttempt() error = %v", err)
+	}
+	attempt.Grade(questions, []domain.Answer{{QuestionID: tf.ID, Bool: &yes}}, opens.Add(5*time.Minute))
+	if err := repo.SubmitAttempt(ctx, attempt); err != nil {
+		t.Fatalf("SubmitAttempt() error = %v", err)
+	}
+	if err := repo.SubmitAttempt(ctx, attempt); err != domain.ErrQuizAttemptSubmitted {
+		t.Errorf("SubmitAttempt() twice error = %v, want %v", err, domain.ErrQuizAttemptSubmitted)
+	}
+	attempts, err := repo.FindAttempts(ctx, quiz.ID, studentID)
+	if err != nil || len(attempts) != 1 || attempts[0].Score != 1 || len(attempts[0].Answers) != 1 {
+		t.Fatalf("FindAttempts() = %+v, err = %v", attempts, err)
+	}
+
+	//! Note du quiz : une seule ligne mise à jour
+	for _, score := range []float64{6.67, 20} {
+		grade, _ := domain.NewGrade(schoolID, studentID, subjectID, classID, term.ID, teacherID, quiz.Title, score, 20, 1, opens)
+		if err := repo.UpsertGrade(ctx, quiz.ID, grade); err != nil {
+			t.Fatalf("UpsertGrade() error = %v", err)
+		}
+	}
+	grades, err := gradeRepo.FindByClassTerm(ctx, classID, term.ID)
+	if err != nil || len(grades) != 1 || grades[0].Score != 20 {
+		t.Fatalf("FindByClassTerm() = %+v, err = %v", grades, err)
+	}
+
+	//! Suppression du quiz : tentatives et note supprimées
+	if err := repo.Delete(ctx, quiz.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repo.FindAttemptByID(ctx, attempt.ID); err != domain.ErrQuizAttemptNotFound {
+		t.Errorf("FindAttemptByID() after delete error = %v, want %v", err, domain.ErrQuizAttemptNotFound)
+	}
+	if err := repo.DeleteQuestion(ctx, question.ID); err != nil {
+		t.Errorf("DeleteQuestion() error = %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"educnet/internal/db"
+	"educnet/internal/domain"
+	"fmt"
+)
+
+// ! RankingRepository réglage de visibilité du rang et empreinte des notes (cache des classements)
+type RankingRepository interface {
+	FindSettings(ctx context.Context, schoolID int) (*domain.RankingSettings, error)
+	SaveSettings(ctx context.Context, settings *domain.RankingSettings) error
+
+	//! GradeSetVersion empreinte des notes d'une classe pour une période (une seule agrégation indexée)
+	GradeSetVersion(ctx context.Context, classID, termID int) (domain.GradeSetVersion, error)
+}
+
+type rankingRepository struct {
+	db *sql.DB
+}
+
+func NewRankingRepository(db *sql.DB) RankingRepository {
+	return &rankingRepository{db: db}
+}
+
+// ! FindSettings réglages par défaut si l'école n'a rien configuré
+func (r *rankingRepository) FindSettings(ctx context.Context, schoolID int) (*domain.RankingSettings, error) {
+	s := &domain.RankingSettings{SchoolID: schoolID}
+	var updatedBy sql.NullInt64
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT student_rank_visible,updated_by,updated_at FROM ranking_settings WHERE school_id = $1`, schoolID,
+	).Scan(&s.StudentRankVisible, &updatedBy, &s.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return domain.DefaultRankingSettings(schoolID), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find ranking settings: %w", err)
+	}
+	s.UpdatedBy = int(updatedBy.Int64)
+	return s, nil
+}
+
+func (r *rankingRepository) SaveSettings(ctx context.Context, s *domain.RankingSettings) error {
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO ranking_settings (school_id,student_rank_visible,updated_by)
+         VALUES ($1,$2,NULLIF($3,0))
+         ON CONFLICT (school_id) DO UPDATE SET
+             student_rank_visible=EXCLUDED.student_rank_visible, updated_by=EXCLUDED.updated_by, updated_at=NOW()
+         RETURNING updated_at`,
+		s.SchoolID, s.StudentRankVisible, s.UpdatedBy,
+	).Scan(&s.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("save ranking settings: %w", err)
+	}
+	return nil
+}
+
+// ! GradeSetVersion une suppression baisse le nombre (ou s'accompagne d'un ID plus grand),
+// ! toute modification incrémente une version et le trigger met à jour updated_at
+func (r *rankingRepository) GradeSetVersion(ctx context.Context, classID, termID int) (domain.GradeSetVersion, error) {
+	var v domain.GradeSetVersion
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT COUNT(*), COALESCE(MAX(id),0), COALESCE(SUM(version),0),
+                COALESCE((EXTRACT(EPOCH FROM MAX(updated_at)) * 1000000)::BIGINT, 0) * 1000
+         FROM grades WHERE class_id = $1 AND term_id = $2`, classID, termID,
+	).Scan(&v.Count, &v.MaxID, &v.VersionSum, &v.UpdatedAt)
+	if err != nil {
+		return v, fmt.Errorf("grade set version: %w", err)
+	}
+	return v, nil
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"educnet/internal/domain"
+	"educnet/internal/testutil"
+)
+
+func TestRankingRepository_Settings(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := NewRankingRepository(db)
+	ctx := context.Background()
+	schoolID := testutil.SeedTestSchool(t, db, "Test School", "test-school", "test@school.mg")
+	adminID := testutil.SeedTestUser(t, db, schoolID, "admin@school.mg", domain.RoleAdmin)
+
+	settings, err := repo.FindSettings(ctx, schoolID)
+	if err != nil {
+		t.Fatalf("FindSettings() error = %v", err)
+	}
+	if settings.StudentRankVisible {
+		t.Error("FindSettings() default StudentRankVisible = true, want false")
+	}
+
+	settings.StudentRankVisible = true
+	settings.UpdatedBy = adminID
+	if err := repo.SaveSettings(ctx, settings); err != nil {
+		t.Fatalf("SaveSettings() error = %v", err)
+	}
+	saved, err := repo.FindSettings(ctx, schoolID)
+	if err != nil || !saved.StudentRankVisible || saved.UpdatedBy != adminID {
+		t.Errorf("FindSettings() = %+v, err = %v, want saved values", saved, err)
+	}
+}
+
+func TestRankingRepository_GradeSetVersion(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := NewRankingRepository(db)
+	gradeRepo := NewGradeRepository(db)
+	ctx := context.Background()
+	schoolID := testutil.SeedTestSchool(t, db, "Test School", "test-school", "test@school.mg")
+	teacherID := testutil.SeedTestUser(t, db, schoolID, "prof@test.mg", domain.RoleTeacher)
+	studentID := testutil.SeedTestUser(t, db, schoolID, "eleve@test.mg", domain.RoleStudent)
+	classID := testutil.SeedTestClass(t, db, schoolID, "6ème A", "6ème", "A", "2025-2026")
+	subjectID := testutil.SeedTestSubject(t, db, schoolID, "Math", "MATH", "")
+
+	start := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	term, _ := domain.NewTerm(schoolID, "2025-2026", "Trimestre 1", 1, start, start.AddDate(0, 3, 0))
+	if err := gradeRepo.CreateTerm(ctx, term); err != nil {
+		t.Fatalf("CreateTerm() error = %v", err)
+	}
+
+	empty, err := repo.GradeSetVersion(ctx, classID, term.ID)
+	if err != nil || empty != (domain.GradeSetVersion{}) {
+		t.Fatalf("GradeSetVersion() empty = %+v, err = %v", empty, err)
+	}
+
+	grade, _ := domain.NewGrade(schoolID, studentID, subjectID, classID, term.ID, teacherID, "Devoir 1", 15, 20, 1, start.AddDate(0, 0, 10))
+	if err := gradeRepo.Create(ctx, grade); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	created, _ := repo.GradeSetVersion(ctx, classID, term.ID)
+	if created.Count != 1 || created.MaxID != grade.ID {
+		t.Errorf("GradeSetVersion() after create = %+v", created)
+	}
+
+	grade.Score = 11
+	if err := gradeRepo.Update(ctx, grade); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	updated, _ := repo.GradeSetVersion(ctx, classID, term.ID)
+	if updated == created {
+		t.Error("GradeSetVersion() unchanged after update")
+	}
+
+	if err := gradeRepo.Delete(ctx, grade.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	deleted, _ := repo.GradeSetVersion(ctx, classID, term.ID)
+	if deleted.Count != 0 || deleted == updated {
+		t.Errorf("GradeSetVersion() after delete = %+v", deleted)
+	}
+}
@@ -0,0 +1,373 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"educnet/internal/db"
+	"educnet/internal/domain"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+type ResourceRepository interface {
+	//! Dossiers
+	CreateFolder(ctx context.Context, folder *domain.ResourceFolder) error
+	FindFolderByID(ctx context.Context, id int) (*domain.ResourceFolder, error)
+	FindFolders(ctx context.Context, subjectID int) ([]*domain.ResourceFolder, error)
+	FolderExists(ctx context.Context, subjectID int, parentID *int, name string) (bool, error)
+	FolderIsEmpty(ctx context.Context, id int) (bool, error)
+	DeleteFolder(ctx context.Context, id int) error
+
+	//! Ressources
+	Create(ctx context.Context, resource *domain.Resource) error
+	FindByID(ctx context.Context, id int) (*domain.Resource, error)
+	Update(ctx context.Context, resource *domain.Resource) error
+	Delete(ctx context.Context, id int) error
+	Search(ctx context.Context, filter domain.ResourceFilter) ([]*domain.Resource, error)
+	IncrementDownloads(ctx context.Context, id int) error
+
+	//! Versions de fichier
+	AddVersion(ctx context.Context, version *domain.ResourceVersion) error
+	FindVersion(ctx context.Context, resourceID, version int) (*domain.ResourceVersion, error)
+	FindVersions(ctx context.Context, resourceID int) ([]*domain.ResourceVersion, error)
+}
+
+type resourceRepository struct {
+	db *sql.DB
+}
+
+func NewResourceRepository(db *sql.DB) ResourceRepository {
+	return &resourceRepository{db: db}
+}
+
+const (
+	folderColumns   = `id,school_id,subject_id,parent_id,name,created_by,created_at`
+	resourceColumns = `id,school_id,subject_id,class_id,level,chapter,folder_id,title,description,kind,url,tags,current_version,download_count,created_by,created_at,updated_at`
+	versionColumns  = `id,resource_id,version,file_name,content_type,size_bytes,storage_path,uploaded_by,created_at`
+)
+
+// ! ==================== HELPERS ====================
+func (r *resourceRepository) scanFolderRow(row domainScanner, f *domain.ResourceFolder) error {
+	var parentID, createdBy sql.NullInt64
+	err := row.Scan(&f.ID, &f.SchoolID, &f.SubjectID, &parentID, &f.Name, &createdBy, &f.CreatedAt)
+	if err == sql.ErrNoRows {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("scan resource folder row: %w", err)
+	}
+	f.ParentID = nullInt(parentID)
+	if createdBy.Valid {
+		f.CreatedBy = int(createdBy.Int64)
+	}
+	return nil
+}
+
+func (r *resourceRepository) scanResourceRow(row domainScanner, res *domain.Resource) error {
+	var classID, folderID, createdBy sql.NullInt64
+	var tags pq.StringArray
+	err := row.Scan(
+		&res.ID, &res.SchoolID, &res.SubjectID, &classID, &res.Level, &res.Chapter, &folderID,
+		&res.Title, &res.Description, &res.Kind, &res.URL, &tags, &res.CurrentVersion,
+		&res.DownloadCount, &createdBy, &res.CreatedAt, &res.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("scan resource row: %w", err)
+	}
+	res.ClassID = nullInt(classID)
+	res.FolderID = nullInt(folderID)
+	res.Tags = []string(tags)
+	if res.Tags == nil {
+		res.Tags = []string{}
+	}
+	if createdBy.Valid {
+		res.CreatedBy = int(createdBy.Int64)
+	}
+	return nil
+}
+
+func (r *resourceRepository) scanVersionRow(row domainScanner, v *domain.ResourceVersion) error {
+	var uploadedBy sql.NullInt64
+	err := row.Scan(&v.ID, &v.ResourceID, &v.Version, &v.FileName, &v.ContentType, &v.Size,
+		&v.StoragePath, &uploadedBy, &v.CreatedAt)
+	if err == sql.ErrNoRows {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("scan resource version row: %w", err)
+	}
+	if uploadedBy.Valid {
+		v.UploadedBy = int(uploadedBy.Int64)
+	}
+	return nil
+}
+
+// ! ==================== FOLDERS ====================
+func (r *resourceRepository) CreateFolder(ctx context.Context, f *domain.ResourceFolder) error {
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO resource_folders (school_id,subject_id,parent_id,name,created_by)
+         VALUES ($1,$2,$3,$4,$5) RETURNING id,created_at`,
+		f.SchoolID, f.SubjectID, nullID(f.ParentID), f.Name, f.CreatedBy,
+	).Scan(&f.ID, &f.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("create resource folder: %w", err)
+	}
+	return nil
+}
+
+func (r *resourceRepository) FindFolderByID(ctx context.Context, id int) (*domain.ResourceFolder, error) {
+	f := &domain.ResourceFolder{}
+	row := db.Conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+folderColumns+` FROM resource_folders WHERE id = $1`, id)
+	if err := r.scanFolderRow(row, f); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrResourceFolderNotFound
+		}
+		return nil, err
+	}
+	return f, nil
+}
+
+func (r *resourceRepository) FindFolders(ctx context.Context, subjectID int) ([]*domain.ResourceFolder, error) {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx,
+		`SELECT `+folderColumns+` FROM resource_folders WHERE subject_id = $1 ORDER BY LOWER(name), id`, subjectID)
+	if err != nil {
+		return nil, fmt.Errorf("find resource folders: %w", err)
+	}
+	defer rows.Close()
+
+	folders := []*domain.ResourceFolder{}
+	for rows.Next() {
+		f := &domain.ResourceFolder{}
+		if err := r.scanFolderRow(rows, f); err != nil {
+			return nil, err
+		}
+		folders = append(folders, f)
+	}
+	return folders, rows.Err()
+}
+
+// ! FolderExists nom déjà pris au même niveau de l'arborescence (insensible à la casse)
+func (r *resourceRepository) FolderExists(ctx context.Context, subjectID int, parentID *int, name string) (bool, error) {
+	var exists bool
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT EXISTS(SELECT 1 FROM resource_folders
+            WHERE subject_id=$1 AND COALESCE(parent_id, 0)=COALESCE($2, 0) AND LOWER(name)=LOWER($3))`,
+		subjectID, nullID(parentID), name).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("check resource folder exists: %w", err)
+	}
+	return exists, nil
+}
+
+// ! FolderIsEmpty ni sous-dossier ni ressource
+func (r *resourceRepository) FolderIsEmpty(ctx context.Context, id int) (bool, error) {
+	var used bool
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT EXISTS(SELECT 1 FROM resource_folders WHERE parent_id = $1)
+             OR EXISTS(SELECT 1 FROM resources WHERE folder_id = $1)`, id).Scan(&used)
+	if err != nil {
+		return false, fmt.Errorf("check resource folder empty: %w", err)
+	}
+	return !used, nil
+}
+
+func (r *resourceRepository) DeleteFolder(ctx context.Context, id int) error {
+	result, err := db.Conn(ctx, r.db).ExecContext(ctx, `DELETE FROM resource_folders WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete resource folder: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrResourceFolderNotFound
+	}
+	return nil
+}
+
+// ! ==================== RESOURCES ====================
+func (r *resourceRepository) Create(ctx context.Context, res *domain.Resource) error {
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO resources (school_id,subject_id,class_id,level,chapter,folder_id,title,description,kind,url,tags,created_by)
+         VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12) RETURNING id,created_at,updated_at`,
+		res.SchoolID, res.SubjectID, nullID(res.ClassID), res.Level, res.Chapter, nullID(res.FolderID),
+		res.Title, res.Description, res.Kind, res.URL, pq.StringArray(res.Tags), res.CreatedBy,
+	).Scan(&res.ID, &res.CreatedAt, &res.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("create resource: %w", err)
+	}
+	return nil
+}
+
+func (r *resourceRepository) FindByID(ctx context.Context, id int) (*domain.Resource, error) {
+	res := &domain.Resource{}
+	row := db.Conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+resourceColumns+` FROM resources WHERE id = $1`, id)
+	if err := r.scanResourceRow(row, res); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrResourceNotFound
+		}
+		return nil, err
+	}
+	return res, nil
+}
+
+// ! Update métadonnées uniquement (le fichier passe par AddVersion)
+func (r *resourceRepository) Update(ctx context.Context, res *domain.Resource) error {
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`UPDATE resources SET class_id=$1,level=$2,chapter=$3,folder_id=$4,title=$5,description=$6,url=$7,tags=$8,updated_at=NOW()
+         WHERE id=$9 RETURNING updated_at`,
+		nullID(res.ClassID), res.Level, res.Chapter, nullID(res.FolderID), res.Title, res.Description,
+		res.URL, pq.StringArray(res.Tags), res.ID,
+	).Scan(&res.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return domain.ErrResourceNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("update resource: %w", err)
+	}
+	return nil
+}
+
+func (r *resourceRepository) Delete(ctx context.Context, id int) error {
+	result, err := db.Conn(ctx, r.db).ExecContext(ctx, `DELETE FROM resources WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete resource: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrResourceNotFound
+	}
+	return nil
+}
+
+// ! Search filtres combinés (ET) ; Query cherche dans le titre, la description et le chapitre
+func (r *resourceRepository) Search(ctx context.Context, f domain.ResourceFilter) ([]*domain.Resource, error) {
+	where := []string{"school_id = $1"}
+	args := []any{f.SchoolID}
+	add := func(clause string, value any) {
+		args = append(args, value)
+		where = append(where, strings.ReplaceAll(clause, "?", fmt.Sprintf("$%d", len(args))))
+	}
+
+	//! Périmètre d'accès
+	if f.SubjectIDs != nil {
+		add("subject_id = ANY(?)", intArray(f.SubjectIDs))
+	}
+	if f.ClassIDs != nil || f.Levels != nil {
+		levels := make(pq.StringArray, len(f.Levels))
+		for i, level := range f.Levels {
+			levels[i] = strings.ToLower(level)
+		}
+		args = append(args, intArray(f.ClassIDs), levels)
+		where = append(where, fmt.Sprintf("(class_id = ANY($%d) OR (class_id IS NULL AND LOWER(level) = ANY($%d)))",
+			len(args)-1, len(args)))
+	}
+
+	//! Filtres de recherche
+	if f.SubjectID > 0 {
+		add("subject_id = ?", f.SubjectID)
+	}
+	if f.ClassID > 0 {
+		add("class_id = ?", f.ClassID)
+	}
+	if f.Level != "" {
+		add("LOWER(level) = LOWER(?)", f.Level)
+	}
+	if f.Chapter != "" {
+		add("LOWER(chapter) = LOWER(?)", f.Chapter)
+	}
+	if f.FolderID > 0 {
+		add("folder_id = ?", f.FolderID)
+	}
+	if f.Tag != "" {
+		add("? = ANY(tags)", strings.ToLower(f.Tag))
+	}
+	if f.Query != "" {
+		add("(title ILIKE ? OR description ILIKE ? OR chapter ILIKE ?)", "%"+escapeLike(f.Query)+"%")
+	}
+
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx,
+		`SELECT `+resourceColumns+` FROM resources WHERE `+strings.Join(where, " AND ")+
+			` ORDER BY subject_id, chapter, LOWER(title), id`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("search resources: %w", err)
+	}
+	defer rows.Close()
+
+	resources := []*domain.Resource{}
+	for rows.Next() {
+		res := &domain.Resource{}
+		if err := r.scanResourceRow(rows, res); err != nil {
+			return nil, err
+		}
+		resources = append(resources, res)
+	}
+	return resources, rows.Err()
+}
+
+func (r *resourceRepository) IncrementDownloads(ctx context.Context, id int) error {
+	_, err := db.Conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE resources SET download_count = download_count + 1 WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("increment resource downloads: %w", err)
+	}
+	return nil
+}
+
+// ! ==================== VERSIONS ====================
+
+// ! AddVersion enregistre le fichier et en fait la version courante
+func (r *resourceRepository) AddVersion(ctx context.Context, v *domain.ResourceVersion) error {
+	return db.RunInTx(ctx, r.db, func(ctx context.Context) error {
+		conn := db.Conn(ctx, r.db)
+		err := conn.QueryRowContext(ctx,
+			`INSERT INTO resource_versions (resource_id,version,file_name,content_type,size_bytes,storage_path,uploaded_by)
+             VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING id,created_at`,
+			v.ResourceID, v.Version, v.FileName, v.ContentType, v.Size, v.StoragePath, v.UploadedBy,
+		).Scan(&v.ID, &v.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("create resource version: %w", err)
+		}
+
+		if _, err := conn.ExecContext(ctx,
+			`UPDATE resources SET current_version = $1, updated_at = NOW() WHERE id = $2`, v.Version, v.ResourceID); err != nil {
+			return fmt.Errorf("set resource current version: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *resourceRepository) FindVersion(ctx context.Context, resourceID, version int) (*domain.ResourceVersion, error) {
+	v := &domain.ResourceVersion{}
+	row := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT `+versionColumns+` FROM resource_versions WHERE resource_id = $1 AND version = $2`, resourceID, version)
+	if err := r.scanVersionRow(row, v); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrResourceVersionNotFound
+		}
+		return nil, err
+	}
+	return v, nil
+}
+
+func (r *resourceRepository) FindVersions(ctx context.Context, resourceID int) ([]*domain.ResourceVersion, error) {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx,
+		`SELECT `+versionColumns+` FROM resource_versions WHERE resource_id = $1 ORDER BY version DESC`, resourceID)
+	if err != nil {
+		return nil, fmt.Errorf("find resource versions: %w", err)
+	}
+	defer rows.Close()
+
+	versions := []*domain.ResourceVersion{}
+	for rows.Next() {
+		v := &domain.ResourceVersion{}
+		if err := r.scanVersionRow(rows, v); err != nil {
+			return nil, err
+		}
+		versions = append(versions, v)
+	}
+	return versions, rows.Err()
+}
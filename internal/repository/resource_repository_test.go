@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"educnet/internal/domain"
+	"educnet/internal/testutil"
+)
+
+func TestResourceRepository_Lifecycle(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := NewResourceRepository(db)
+	ctx := context.Background()
+	schoolID := testutil.SeedTestSchool(t, db, "Test School", "test-school", "test@school.mg")
+	teacherID := testutil.SeedTestUser(t, db, schoolID, "prof@test.mg", domain.RoleTeacher)
+	classID := testutil.SeedTestClass(t, db, schoolID, "6ème A", "6ème", "A", "2025-2026")
+	otherClassID := testutil.SeedTestClass(t, db, schoolID, "5ème A", "5ème", "A", "2025-2026")
+	subjectID := testutil.SeedTestSubject(t, db, schoolID, "Math", "MATH", "")
+
+	//! Dossiers : nom unique par niveau, insensible à la casse
+	folder, _ := domain.NewResourceFolder(schoolID, subjectID, nil, "Géométrie", teacherID)
+	if err := repo.CreateFolder(ctx, folder); err != nil {
+		t.Fatalf("CreateFolder() error = %v", err)
+	}
+	if exists, _ := repo.FolderExists(ctx, subjectID, nil, "GÉOMÉTRIE"); !exists {
+		t.Error("FolderExists() = false, want true")
+	}
+	if exists, _ := repo.FolderExists(ctx, subjectID, &folder.ID, "Géométrie"); exists {
+		t.Error("FolderExists() in subfolder = true, want false")
+	}
+
+	//! Ressources : une pour la classe, une pour tout le niveau 5ème
+	forClass, _ := domain.NewResource(schoolID, subjectID, &classID, "", "Chapitre 1", &folder.ID,
+		"Cours triangles", "", domain.ResourceFile, "", []string{"cours", "triangles"}, teacherID)
+	forLevel, _ := domain.NewResource(schoolID, subjectID, nil, "5ème", "Chapitre 2", nil,
+		"Vidéo fractions", "", domain.ResourceLink, "https://example.org/fractions", []string{"video"}, teacherID)
+	for _, res := range []*domain.Resource{forClass, forLevel} {
+		if err := repo.Create(ctx, res); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	if empty, _ := repo.FolderIsEmpty(ctx, folder.ID); empty {
+		t.Error("FolderIsEmpty() = true, want false")
+	}
+
+	tests := []struct {
+		name   string
+		filter domain.ResourceFilter
+		want   int
+	}{
+		{"Teacher subjects", domain.ResourceFilter{SchoolID: schoolID, SubjectIDs: []int{subjectID}}, 2},
+		{"Other subject", domain.ResourceFilter{SchoolID: schoolID, SubjectIDs: []int{subjectID + 1}}, 0},
+		{"Student of 6ème A", domain.ResourceFilter{SchoolID: schoolID, ClassIDs: []int{classID}, Levels: []string{"6ème"}}, 1},
+		{"Student of 5ème A", domain.ResourceFilter{SchoolID: schoolID, ClassIDs: []int{otherClassID}, Levels: []string{"5ÈME"}}, 1},
+		{"Tag", domain.ResourceFilter{SchoolID: schoolID, Tag: "Video"}, 1},
+		{"Folder", domain.ResourceFilter{SchoolID: schoolID, FolderID: folder.ID}, 1},
+		{"Query", domain.ResourceFilter{SchoolID: schoolID, Query: "triangle"}, 1},
+		{"Query wildcard escaped", domain.ResourceFilter{SchoolID: schoolID, Query: "%"}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.Search(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			if len(got) != tt.want {
+				t.Errorf("Search() = %d resources, want %d", len(got), tt.want)
+			}
+		})
+	}
+
+	//! Versions : la dernière devient la version courante
+	for n := 1; n <= 2; n++ {
+		v, _ := domain.NewResourceVersion(forClass.ID, n, "triangles.pdf", "application/pdf", 1024, teacherID)
+		v.StoragePath = "storage/test.pdf"
+		if err := repo.AddVersion(ctx, v); err != nil {
+			t.Fatalf("AddVersion(%d) error = %v", n, err)
+		}
+	}
+	if err := repo.IncrementDownloads(ctx, forClass.ID); err != nil {
+		t.Fatalf("IncrementDownloads() error = %v", err)
+	}
+	found, err := repo.FindByID(ctx, forClass.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if found.CurrentVersion != 2 || found.DownloadCount != 1 || len(found.Tags) != 2 {
+		t.Errorf("FindByID() = %+v", found)
+	}
+	if versions, _ := repo.FindVersions(ctx, forClass.ID); len(versions) != 2 || versions[0].Version != 2 {
+		t.Errorf("FindVersions() = %v, want newest first", versions)
+	}
+	if _, err := repo.FindVersion(ctx, forClass.ID, 3); err != domain.ErrResourceVersionNotFound {
+		t.Errorf("FindVersion(3) error = %v, want %v", err, domain.ErrResourceVersionNotFound)
+	}
+
+	//! Suppression : le dossier vidé peut être supprimé
+	if err := repo.Delete(ctx, forClass.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repo.FindByID(ctx, forClass.ID); err != domain.ErrResourceNotFound {
+		t.Errorf("FindByID() after delete error = %v, want %v", err, domain.ErrResourceNotFound)
+	}
+	if empty, _ := repo.FolderIsEmpty(ctx, folder.ID); !empty {
+		t.Error("FolderIsEmpty() = false, want true")
+	}
+	if err := repo.DeleteFolder(ctx, folder.ID); err != nil {
+		t.Fatalf("DeleteFolder() error = %v", err)
+	}
+}
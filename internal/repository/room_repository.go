@@ -0,0 +1,402 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"educnet/internal/db"
+	"educnet/internal/domain"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type RoomRepository interface {
+	//! Salles
+	CreateRoom(ctx context.Context, room *domain.Room) error
+	FindRoomByID(ctx context.Context, id int) (*domain.Room, error)
+	UpdateRoom(ctx context.Context, room *domain.Room) error
+	DeleteRoom(ctx context.Context, id int) error
+	RoomNameExists(ctx context.Context, schoolID int, name string, excludeID int) (bool, error)
+	FindRooms(ctx context.Context, filter domain.RoomFilter) ([]*domain.Room, error)
+
+	//! Équipements
+	CreateEquipment(ctx context.Context, equipment *domain.Equipment) error
+	FindEquipmentByID(ctx context.Context, id int) (*domain.Equipment, error)
+	UpdateEquipment(ctx context.Context, equipment *domain.Equipment) error
+	DeleteEquipment(ctx context.Context, id int) error
+	EquipmentNameExists(ctx context.Context, schoolID int, name string, excludeID int) (bool, error)
+	FindEquipment(ctx context.Context, schoolID int, includeInactive bool) ([]*domain.Equipment, error)
+
+	//! Réservations
+	CreateBooking(ctx context.Context, booking *domain.Booking) error
+	FindBookingByID(ctx context.Context, id int) (*domain.Booking, error)
+	UpdateBookingStatus(ctx context.Context, booking *domain.Booking) error
+	FindBookings(ctx context.Context, filter domain.BookingFilter) ([]*domain.Booking, error)
+	FindBusy(ctx context.Context, schoolID int, from, to time.Time) (roomIDs, equipmentIDs []int, err error)
+}
+
+type roomRepository struct {
+	db *sql.DB
+}
+
+func NewRoomRepository(db *sql.DB) RoomRepository {
+	return &roomRepository{db: db}
+}
+
+const roomColumns = `id,school_id,name,type,capacity,equipment,requires_approval,is_active,created_at,updated_at`
+
+const equipmentColumns = `id,school_id,name,description,requires_approval,is_active,created_at,updated_at`
+
+const bookingColumns = `id,school_id,room_id,equipment_id,user_id,purpose,starts_at,ends_at,status,reviewed_by,reviewed_at,review_note,created_at,updated_at`
+
+// ! exclusionViolation code PostgreSQL d'une contrainte EXCLUDE (créneau déjà réservé)
+const exclusionViolation = "23P01"
+
+// ! ==================== HELPERS ====================
+func (r *roomRepository) scanRoomRow(row domainScanner, room *domain.Room) error {
+	var equipment pq.StringArray
+	err := row.Scan(&room.ID, &room.SchoolID, &room.Name, &room.Type, &room.Capacity, &equipment,
+		&room.RequiresApproval, &room.IsActive, &room.CreatedAt, &room.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("scan room row: %w", err)
+	}
+	room.Equipment = []string(equipment)
+	return nil
+}
+
+func (r *roomRepository) scanEquipmentRow(row domainScanner, e *domain.Equipment) error {
+	err := row.Scan(&e.ID, &e.SchoolID, &e.Name, &e.Description, &e.RequiresApproval, &e.IsActive, &e.CreatedAt, &e.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return err
+	}
+	return scanError(err, "scan equipment row")
+}
+
+func (r *roomRepository) scanBookingRow(row domainScanner, b *domain.Booking) error {
+	var roomID, equipmentID, reviewedBy sql.NullInt64
+	var reviewedAt sql.NullTime
+	err := row.Scan(&b.ID, &b.SchoolID, &roomID, &equipmentID, &b.UserID, &b.Purpose, &b.StartsAt, &b.EndsAt,
+		&b.Status, &reviewedBy, &reviewedAt, &b.ReviewNote, &b.CreatedAt, &b.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("scan booking row: %w", err)
+	}
+	b.RoomID = nullInt(roomID)
+	b.EquipmentID = nullInt(equipmentID)
+	b.ReviewedBy = nullInt(reviewedBy)
+	if reviewedAt.Valid {
+		b.ReviewedAt = &reviewedAt.Time
+	}
+	return nil
+}
+
+// ! ==================== ROOMS ====================
+func (r *roomRepository) CreateRoom(ctx context.Context, room *domain.Room) error {
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO rooms (school_id,name,type,capacity,equipment,requires_approval,is_active)
+         VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING id,created_at,updated_at`,
+		room.SchoolID, room.Name, room.Type, room.Capacity, pq.StringArray(room.Equipment), room.RequiresApproval, room.IsActive,
+	).Scan(&room.ID, &room.CreatedAt, &room.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("create room: %w", err)
+	}
+	return nil
+}
+
+func (r *roomRepository) FindRoomByID(ctx context.Context, id int) (*domain.Room, error) {
+	room := &domain.Room{}
+	row := db.Conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+roomColumns+` FROM rooms WHERE id = $1`, id)
+	if err := r.scanRoomRow(row, room); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrRoomNotFound
+		}
+		return nil, err
+	}
+	return room, nil
+}
+
+func (r *roomRepository) UpdateRoom(ctx context.Context, room *domain.Room) error {
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`UPDATE rooms SET name=$1,type=$2,capacity=$3,equipment=$4,requires_approval=$5,is_active=$6
+         WHERE id=$7 RETURNING updated_at`,
+		room.Name, room.Type, room.Capacity, pq.StringArray(room.Equipment), room.RequiresApproval, room.IsActive, room.ID,
+	).Scan(&room.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return domain.ErrRoomNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("update room: %w", err)
+	}
+	return nil
+}
+
+// ! DeleteRoom supprime aussi ses réservations ; les cours de l'emploi du temps perdent leur salle
+func (r *roomRepository) DeleteRoom(ctx context.Context, id int) error {
+	result, err := db.Conn(ctx, r.db).ExecContext(ctx, `DELETE FROM rooms WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete room: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrRoomNotFound
+	}
+	return nil
+}
+
+// ! RoomNameExists nom déjà pris dans l'école (insensible à la casse), hors excludeID
+func (r *roomRepository) RoomNameExists(ctx context.Context, schoolID int, name string, excludeID int) (bool, error) {
+	var exists bool
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT EXISTS(SELECT 1 FROM rooms WHERE school_id = $1 AND LOWER(name) = LOWER($2) AND id <> $3)`,
+		schoolID, name, excludeID,
+	).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("check room name: %w", err)
+	}
+	return exists, nil
+}
+
+// ! FindRooms salles de l'école par nom ; l'équipement demandé est vérifié par domain.Room.HasEquipment
+func (r *roomRepository) FindRooms(ctx context.Context, f domain.RoomFilter) ([]*domain.Room, error) {
+	where := []string{"school_id = $1"}
+	args := []any{f.SchoolID}
+	if !f.IncludeInactive {
+		where = append(where, "is_active")
+	}
+	if f.Type != "" {
+		args = append(args, f.Type)
+		where = append(where, fmt.Sprintf("type = $%d", len(args)))
+	}
+	if f.MinCapacity > 0 {
+		args = append(args, f.MinCapacity)
+		where = append(where, fmt.Sprintf("capacity >= $%d", len(args)))
+	}
+
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx,
+		`SELECT `+roomColumns+` FROM rooms WHERE `+strings.Join(where, " AND ")+` ORDER BY name, id`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("find rooms: %w", err)
+	}
+	defer rows.Close()
+
+	rooms := []*domain.Room{}
+	for rows.Next() {
+		room := &domain.Room{}
+		if err := r.scanRoomRow(rows, room); err != nil {
+			return nil, err
+		}
+		if room.HasEquipment(f.Equipment) {
+			rooms = append(rooms, room)
+		}
+	}
+	return rooms, rows.Err()
+}
+
+// ! ==================== EQUIPMENT ====================
+func (r *roomRepository) CreateEquipment(ctx context.Context, e *domain.Equipment) error {
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO equipment (school_id,name,description,requires_approval,is_active)
+         VALUES ($1,$2,$3,$4,$5) RETURNING id,created_at,updated_at`,
+		e.SchoolID, e.Name, e.Description, e.RequiresApproval, e.IsActive,
+	).Scan(&e.ID, &e.CreatedAt, &e.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("create equipment: %w", err)
+	}
+	return nil
+}
+
+func (r *roomRepository) FindEquipmentByID(ctx context.Context, id int) (*domain.Equipment, error) {
+	e := &domain.Equipment{}
+	row := db.Conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+equipmentColumns+` FROM equipment WHERE id = $1`, id)
+	if err := r.scanEquipmentRow(row, e); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrEquipmentNotFound
+		}
+		return nil, err
+	}
+	return e, nil
+}
+
+func (r *roomRepository) UpdateEquipment(ctx context.Context, e *domain.Equipment) error {
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`UPDATE equipment SET name=$1,description=$2,requires_approval=$3,is_active=$4
+         WHERE id=$5 RETURNING updated_at`,
+		e.Name, e.Description, e.RequiresApproval, e.IsActive, e.ID,
+	).Scan(&e.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return domain.ErrEquipmentNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("update equipment: %w", err)
+	}
+	return nil
+}
+
+func (r *roomRepository) DeleteEquipment(ctx context.Context, id int) error {
+	result, err := db.Conn(ctx, r.db).ExecContext(ctx, `DELETE FROM equipment WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete equipment: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrEquipmentNotFound
+	}
+	return nil
+}
+
+func (r *roomRepository) EquipmentNameExists(ctx context.Context, schoolID int, name string, excludeID int) (bool, error) {
+	var exists bool
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT EXISTS(SELECT 1 FROM equipment WHERE school_id = $1 AND LOWER(name) = LOWER($2) AND id <> $3)`,
+		schoolID, name, excludeID,
+	).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("check equipment name: %w", err)
+	}
+	return exists, nil
+}
+
+func (r *roomRepository) FindEquipment(ctx context.Context, schoolID int, includeInactive bool) ([]*domain.Equipment, error) {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx,
+		`SELECT `+equipmentColumns+` FROM equipment WHERE school_id = $1 AND (is_active OR $2) ORDER BY name, id`,
+		schoolID, includeInactive)
+	if err != nil {
+		return nil, fmt.Errorf("find equipment: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*domain.Equipment{}
+	for rows.Next() {
+		e := &domain.Equipment{}
+		if err := r.scanEquipmentRow(rows, e); err != nil {
+			return nil, err
+		}
+		items = append(items, e)
+	}
+	return items, rows.Err()
+}
+
+// ! ==================== BOOKINGS ====================
+
+// ! CreateBooking ErrBookingConflict si le créneau chevauche une réservation en attente ou approuvée
+func (r *roomRepository) CreateBooking(ctx context.Context, b *domain.Booking) error {
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO bookings (school_id,room_id,equipment_id,user_id,purpose,starts_at,ends_at,status)
+         VALUES ($1,$2,$3,$4,$5,$6,$7,$8) RETURNING id,created_at,updated_at`,
+		b.SchoolID, nullID(b.RoomID), nullID(b.EquipmentID), b.UserID, b.Purpose, b.StartsAt, b.EndsAt, b.Status,
+	).Scan(&b.ID, &b.CreatedAt, &b.UpdatedAt)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == exclusionViolation {
+		return domain.ErrBookingConflict
+	}
+	if err != nil {
+		return fmt.Errorf("create booking: %w", err)
+	}
+	return nil
+}
+
+func (r *roomRepository) FindBookingByID(ctx context.Context, id int) (*domain.Booking, error) {
+	b := &domain.Booking{}
+	row := db.Conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+bookingColumns+` FROM bookings WHERE id = $1`, id)
+	if err := r.scanBookingRow(row, b); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrBookingNotFound
+		}
+		return nil, err
+	}
+	return b, nil
+}
+
+func (r *roomRepository) UpdateBookingStatus(ctx context.Context, b *domain.Booking) error {
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`UPDATE bookings SET status=$1,reviewed_by=$2,reviewed_at=$3,review_note=$4
+         WHERE id=$5 RETURNING updated_at`,
+		b.Status, nullID(b.ReviewedBy), b.ReviewedAt, b.ReviewNote, b.ID,
+	).Scan(&b.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return domain.ErrBookingNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("update booking: %w", err)
+	}
+	return nil
+}
+
+func (r *roomRepository) FindBookings(ctx context.Context, f domain.BookingFilter) ([]*domain.Booking, error) {
+	where := []string{"school_id = $1"}
+	args := []any{f.SchoolID}
+	add := func(clause string, value any) {
+		args = append(args, value)
+		where = append(where, fmt.Sprintf(clause, len(args)))
+	}
+	if f.UserID > 0 {
+		add("user_id = $%d", f.UserID)
+	}
+	if f.RoomID > 0 {
+		add("room_id = $%d", f.RoomID)
+	}
+	if f.EquipmentID > 0 {
+		add("equipment_id = $%d", f.EquipmentID)
+	}
+	if f.Status != "" {
+		add("status = $%d", f.Status)
+	}
+	if !f.From.IsZero() {
+		add("ends_at > $%d", f.From)
+	}
+	if !f.To.IsZero() {
+		add("starts_at < $%d", f.To)
+	}
+
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx,
+		`SELECT `+bookingColumns+` FROM bookings WHERE `+strings.Join(where, " AND ")+` ORDER BY starts_at, id`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("find bookings: %w", err)
+	}
+	defer rows.Close()
+
+	bookings := []*domain.Booking{}
+	for rows.Next() {
+		b := &domain.Booking{}
+		if err := r.scanBookingRow(rows, b); err != nil {
+			return nil, err
+		}
+		bookings = append(bookings, b)
+	}
+	return bookings, rows.Err()
+}
+
+// ! FindBusy salles et équipements réservés (en attente ou approuvés) sur [from, to)
+func (r *roomRepository) FindBusy(ctx context.Context, schoolID int, from, to time.Time) ([]int, []int, error) {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx,
+		`SELECT COALESCE(room_id, 0), COALESCE(equipment_id, 0) FROM bookings
+         WHERE school_id = $1 AND status IN ('pending', 'approved')
+           AND starts_at < $3 AND ends_at > $2`,
+		schoolID, from, to)
+	if err != nil {
+		return nil, nil, fmt.Errorf("find busy resources: %w", err)
+	}
+	defer rows.Close()
+
+	var roomIDs, equipmentIDs []int
+	for rows.Next() {
+		var roomID, equipmentID int
+		if err := rows.Scan(&roomID, &equipmentID); err != nil {
+			return nil, nil, scanError(err, "scan busy resource")
+		}
+		if roomID > 0 {
+			roomIDs = append(roomIDs, roomID)
+		} else {
+			equipmentIDs = append(equipmentIDs, equipmentID)
+		}
+	}
+	return roomIDs, equipmentIDs, rows.Err()
+}
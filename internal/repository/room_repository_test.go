@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"educnet/internal/domain"
+	"educnet/internal/testutil"
+)
+
+func TestRoomRepository_FindRooms(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := NewRoomRepository(db)
+	ctx := context.Background()
+	schoolID := testutil.SeedTestSchool(t, db, "Test School", "test-school", "test@school.mg")
+
+	for _, r := range []struct {
+		name      string
+		roomType  string
+		capacity  int
+		equipment []string
+	}{
+		{"Salle 12", domain.RoomClassroom, 35, []string{"Vidéoprojecteur"}},
+		{"Salle 14", domain.RoomClassroom, 20, nil},
+		{"Labo SVT", domain.RoomLab, 24, []string{"Paillasses", "Vidéoprojecteur"}},
+	} {
+		room, err := domain.NewRoom(schoolID, r.name, r.roomType, r.capacity, r.equipment, nil)
+		if err != nil {
+			t.Fatalf("NewRoom() error = %v", err)
+		}
+		if err := repo.CreateRoom(ctx, room); err != nil {
+			t.Fatalf("CreateRoom() error = %v", err)
+		}
+	}
+
+	exists, err := repo.RoomNameExists(ctx, schoolID, "salle 12", 0)
+	if err != nil || !exists {
+		t.Errorf("RoomNameExists() = %v, %v, want true", exists, err)
+	}
+
+	tests := []struct {
+		name   string
+		filter domain.RoomFilter
+		want   int
+	}{
+		{"All", domain.RoomFilter{SchoolID: schoolID}, 3},
+		{"Labs", domain.RoomFilter{SchoolID: schoolID, Type: domain.RoomLab}, 1},
+		{"Capacity", domain.RoomFilter{SchoolID: schoolID, MinCapacity: 24}, 2},
+		{"Equipment", domain.RoomFilter{SchoolID: schoolID, Equipment: []string{"vidéoprojecteur"}}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rooms, err := repo.FindRooms(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("FindRooms() error = %v", err)
+			}
+			if len(rooms) != tt.want {
+				t.Errorf("FindRooms() = %d rooms, want %d", len(rooms), tt.want)
+			}
+		})
+	}
+}
+
+func TestRoomRepository_BookingOverlap(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := NewRoomRepository(db)
+	ctx := context.Background()
+	schoolID := testutil.SeedTestSchool(t, db, "Test School", "test-school", "test@school.mg")
+	teacherID := testutil.SeedTestUser(t, db, schoolID, "prof@test.mg", domain.RoleTeacher)
+
+	room, _ := domain.NewRoom(schoolID, "Gymnase", domain.RoomGym, 60, nil, nil)
+	if err := repo.CreateRoom(ctx, room); err != nil {
+		t.Fatalf("CreateRoom() error = %v", err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Hour)
+	start := now.Add(24 * time.Hour)
+	book := func(from, to time.Time) (*domain.Booking, error) {
+		b, err := domain.NewBooking(schoolID, &room.ID, nil, teacherID, "EPS", from, to, room.RequiresApproval, now)
+		if err != nil {
+			t.Fatalf("NewBooking() error = %v", err)
+		}
+		return b, repo.CreateBooking(ctx, b)
+	}
+
+	first, err := book(start, start.Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("CreateBooking() error = %v", err)
+	}
+	if first.Status != domain.BookingPending {
+		t.Errorf("Status = %s, want pending for a gym", first.Status)
+	}
+
+	//! Une réservation en attente bloque déjà le créneau
+	if _, err := book(start.Add(time.Hour), start.Add(3*time.Hour)); err != domain.ErrBookingConflict {
+		t.Errorf("CreateBooking() overlap error = %v, want %v", err, domain.ErrBookingConflict)
+	}
+	//! Bout à bout autorisé
+	if _, err := book(start.Add(2*time.Hour), start.Add(3*time.Hour)); err != nil {
+		t.Errorf("CreateBooking() adjacent error = %v", err)
+	}
+
+	busyRooms, _, err := repo.FindBusy(ctx, schoolID, start.Add(30*time.Minute), start.Add(time.Hour))
+	if err != nil || len(busyRooms) != 1 || busyRooms[0] != room.ID {
+		t.Errorf("FindBusy() = %v, %v, want [%d]", busyRooms, err, room.ID)
+	}
+
+	//! Une fois annulée, le créneau se libère
+	if err := first.Cancel(now); err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+	if err := repo.UpdateBookingStatus(ctx, first); err != nil {
+		t.Fatalf("UpdateBookingStatus() error = %v", err)
+	}
+	if _, err := book(start, start.Add(time.Hour)); err != nil {
+		t.Errorf("CreateBooking() after cancel error = %v", err)
+	}
+
+	pending, err := repo.FindBookings(ctx, domain.BookingFilter{SchoolID: schoolID, Status: domain.BookingPending})
+	if err != nil || len(pending) != 2 {
+		t.Errorf("FindBookings(pending) = %d, %v, want 2", len(pending), err)
+	}
+}
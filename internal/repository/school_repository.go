@@ -1,20 +1,26 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"educnet/internal/db"
 	"educnet/internal/domain"
 	"fmt"
 )
 
 // ! SchoolRepository interface (PARFAIT)
 type SchoolRepository interface {
-	Create(school *domain.School) error
-	Update(school *domain.School) error
-	GetAll() ([]*domain.School, error)
-	FindByID(id int) (*domain.School, error)
-	FindBySlug(slug string) (*domain.School, error)
-	ExistsBySlug(slug string) (bool, error)
-	UpdateLogo(schoolID int, logoURL string) error
+	Create(ctx context.Context, school *domain.School) error
+	Update(ctx context.Context, school *domain.School) error
+	GetAll(ctx context.Context) ([]*domain.School, error)
+	FindByID(ctx context.Context, id int) (*domain.School, error)
+	FindBySlug(ctx context.Context, slug string) (*domain.School, error)
+	ExistsBySlug(ctx context.Context, slug string) (bool, error)
+	UpdateLogo(ctx context.Context, schoolID int, logoURL string) error
+	UpdateStatus(ctx context.Context, schoolID int, status string) error
+	UpdateRegistrationMode(ctx context.Context, schoolID int, mode string) error
+	GetAllWithStats(ctx context.Context) ([]*domain.SchoolStats, error)
+	FindStatsByID(ctx context.Context, id int) (*domain.SchoolStats, error)
 }
 
 type schoolRepository struct {
@@ -33,7 +39,7 @@ func (r *schoolRepository) scanSchoolRow(row domainScanner, school *domain.Schoo
 	err := row.Scan(
 		&school.ID, &school.Name, &school.Slug,
 		&address, &phone, &email, &logoURL,
-		&adminUserID, &school.Status,
+		&adminUserID, &school.Status, &school.RegistrationMode,
 		&school.CreatedAt, &school.UpdatedAt,
 	)
 
@@ -53,12 +59,72 @@ func (r *schoolRepository) scanSchoolRow(row domainScanner, school *domain.Schoo
 	return nil
 }
 
+// ! schoolStatsQuery agrège l'usage de chaque école en une seule requête
+const schoolStatsQuery = `
+    SELECT s.id,s.name,s.slug,s.address,s.phone,s.email,s.logo_url,s.admin_user_id,s.status,s.registration_mode,s.created_at,s.updated_at,
+        COALESCE(u.total, 0), COALESCE(u.admins, 0), COALESCE(u.teachers, 0),
+        COALESCE(u.students, 0), COALESCE(u.pending, 0),
+        (SELECT COUNT(*) FROM classes c WHERE c.school_id = s.id),
+        (SELECT COUNT(*) FROM subjects sub WHERE sub.school_id = s.id),
+        COALESCE(m.total, 0),
+        GREATEST(u.last_activity, m.last_activity)
+    FROM schools s
+    LEFT JOIN (
+        SELECT school_id,
+            COUNT(*) AS total,
+            COUNT(*) FILTER (WHERE role = 'admin') AS admins,
+            COUNT(*) FILTER (WHERE role = 'teacher') AS teachers,
+            COUNT(*) FILTER (WHERE role = 'student') AS students,
+            COUNT(*) FILTER (WHERE status = 'pending') AS pending,
+            MAX(updated_at) AS last_activity
+        FROM users GROUP BY school_id
+    ) u ON u.school_id = s.id
+    LEFT JOIN (
+        SELECT c.school_id, COUNT(*) AS total, MAX(msg.created_at)::timestamp AS last_activity
+        FROM messages msg JOIN classes c ON msg.class_id = c.id
+        GROUP BY c.school_id
+    ) m ON m.school_id = s.id`
+
+func (r *schoolRepository) scanSchoolStatsRow(row domainScanner) (*domain.SchoolStats, error) {
+	stats := &domain.SchoolStats{School: &domain.School{}}
+	var address, phone, email, logoURL sql.NullString
+	var adminUserID sql.NullInt64
+	var lastActivity sql.NullTime
+
+	err := row.Scan(
+		&stats.School.ID, &stats.School.Name, &stats.School.Slug,
+		&address, &phone, &email, &logoURL,
+		&adminUserID, &stats.School.Status, &stats.School.RegistrationMode,
+		&stats.School.CreatedAt, &stats.School.UpdatedAt,
+		&stats.TotalUsers, &stats.TotalAdmins, &stats.TotalTeachers,
+		&stats.TotalStudents, &stats.PendingUsers,
+		&stats.TotalClasses, &stats.TotalSubjects,
+		&stats.TotalMessages, &lastActivity,
+	)
+	if err == sql.ErrNoRows {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("scan school stats row: %w", err)
+	}
+
+	stats.School.Address = nullString(address)
+	stats.School.Phone = nullString(phone)
+	stats.School.Email = nullString(email)
+	stats.School.LogoURL = nullString(logoURL)
+	stats.School.AdminUserID = nullInt(adminUserID)
+	if lastActivity.Valid {
+		stats.LastActivity = &lastActivity.Time
+	}
+	return stats, nil
+}
+
 // ! ==================== METHODS ====================
-func (r *schoolRepository) Create(school *domain.School) error {
-	err := r.db.QueryRow(
-		`INSERT INTO schools (name, slug, address, phone, email, status) 
-        VALUES ($1,$2,$3,$4,$5,$6) RETURNING id, created_at, updated_at`,
-		school.Name, school.Slug, school.Address, school.Phone, school.Email, school.Status,
+func (r *schoolRepository) Create(ctx context.Context, school *domain.School) error {
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO schools (name, slug, address, phone, email, status, registration_mode) 
+        VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING id, created_at, updated_at`,
+		school.Name, school.Slug, school.Address, school.Phone, school.Email, school.Status, school.RegistrationMode,
 	).Scan(&school.ID, &school.CreatedAt, &school.UpdatedAt)
 
 	if err != nil {
@@ -67,10 +133,10 @@ func (r *schoolRepository) Create(school *domain.School) error {
 	return nil
 }
 
-func (r *schoolRepository) FindByID(id int) (*domain.School, error) {
+func (r *schoolRepository) FindByID(ctx context.Context, id int) (*domain.School, error) {
 	school := &domain.School{}
-	row := r.db.QueryRow(
-		`SELECT id,name,slug,address,phone,email,logo_url,admin_user_id,status,created_at,updated_at 
+	row := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT id,name,slug,address,phone,email,logo_url,admin_user_id,status,registration_mode,created_at,updated_at 
         FROM schools WHERE id = $1`, id)
 
 	if err := r.scanSchoolRow(row, school); err != nil {
@@ -82,10 +148,10 @@ func (r *schoolRepository) FindByID(id int) (*domain.School, error) {
 	return school, nil
 }
 
-func (r *schoolRepository) FindBySlug(slug string) (*domain.School, error) {
+func (r *schoolRepository) FindBySlug(ctx context.Context, slug string) (*domain.School, error) {
 	school := &domain.School{}
-	row := r.db.QueryRow(
-		`SELECT id,name,slug,address,phone,email,logo_url,admin_user_id,status,created_at,updated_at 
+	row := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT id,name,slug,address,phone,email,logo_url,admin_user_id,status,registration_mode,created_at,updated_at 
         FROM schools WHERE slug = $1`, slug)
 
 	if err := r.scanSchoolRow(row, school); err != nil {
@@ -97,9 +163,9 @@ func (r *schoolRepository) FindBySlug(slug string) (*domain.School, error) {
 	return school, nil
 }
 
-func (r *schoolRepository) GetAll() ([]*domain.School, error) {
-	rows, err := r.db.Query(
-		`SELECT id,name,slug,address,phone,email,logo_url,admin_user_id,status,created_at,updated_at 
+func (r *schoolRepository) GetAll(ctx context.Context) ([]*domain.School, error) {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx,
+		`SELECT id,name,slug,address,phone,email,logo_url,admin_user_id,status,registration_mode,created_at,updated_at 
         FROM schools`)
 	if err != nil {
 		return nil, fmt.Errorf("get all schools: %w", err)
@@ -117,14 +183,14 @@ func (r *schoolRepository) GetAll() ([]*domain.School, error) {
 	return schools, rows.Err()
 }
 
-func (r *schoolRepository) ExistsBySlug(slug string) (bool, error) {
+func (r *schoolRepository) ExistsBySlug(ctx context.Context, slug string) (bool, error) {
 	var exists bool
-	err := r.db.QueryRow(`SELECT EXISTS(SELECT 1 FROM schools WHERE slug = $1)`, slug).Scan(&exists)
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM schools WHERE slug = $1)`, slug).Scan(&exists)
 	return exists, err
 }
 
-func (r *schoolRepository) Update(school *domain.School) error {
-	result, err := r.db.Exec(
+func (r *schoolRepository) Update(ctx context.Context, school *domain.School) error {
+	result, err := db.Conn(ctx, r.db).ExecContext(ctx,
 		`UPDATE schools SET name=$1,address=$2,phone=$3,email=$4,admin_user_id=$5,updated_at=NOW() 
          WHERE id=$6`,
 		school.Name, school.Address, school.Phone, school.Email, school.AdminUserID, school.ID)
@@ -138,8 +204,8 @@ func (r *schoolRepository) Update(school *domain.School) error {
 	return nil
 }
 
-func (r *schoolRepository) UpdateLogo(schoolID int, logoURL string) error {
-	result, err := r.db.Exec(`UPDATE schools SET logo_url=$1,updated_at=NOW() WHERE id=$2`, logoURL, schoolID)
+func (r *schoolRepository) UpdateLogo(ctx context.Context, schoolID int, logoURL string) error {
+	result, err := db.Conn(ctx, r.db).ExecContext(ctx, `UPDATE schools SET logo_url=$1,updated_at=NOW() WHERE id=$2`, logoURL, schoolID)
 	if err != nil {
 		return fmt.Errorf("update school logo: %w", err)
 	}
@@ -149,3 +215,53 @@ func (r *schoolRepository) UpdateLogo(schoolID int, logoURL string) error {
 	}
 	return nil
 }
+
+func (r *schoolRepository) UpdateStatus(ctx context.Context, schoolID int, status string) error {
+	result, err := db.Conn(ctx, r.db).ExecContext(ctx, `UPDATE schools SET status=$1,updated_at=NOW() WHERE id=$2`, status, schoolID)
+	if err != nil {
+		return fmt.Errorf("update school status: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrSchoolNotFound
+	}
+	return nil
+}
+
+func (r *schoolRepository) UpdateRegistrationMode(ctx context.Context, schoolID int, mode string) error {
+	result, err := db.Conn(ctx, r.db).ExecContext(ctx, `UPDATE schools SET registration_mode=$1,updated_at=NOW() WHERE id=$2`, mode, schoolID)
+	if err != nil {
+		return fmt.Errorf("update school registration mode: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrSchoolNotFound
+	}
+	return nil
+}
+
+func (r *schoolRepository) GetAllWithStats(ctx context.Context) ([]*domain.SchoolStats, error) {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx, schoolStatsQuery+` ORDER BY s.name`)
+	if err != nil {
+		return nil, fmt.Errorf("get all school stats: %w", err)
+	}
+	defer rows.Close()
+
+	var all []*domain.SchoolStats
+	for rows.Next() {
+		stats, err := r.scanSchoolStatsRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, stats)
+	}
+	return all, rows.Err()
+}
+
+func (r *schoolRepository) FindStatsByID(ctx context.Context, id int) (*domain.SchoolStats, error) {
+	stats, err := r.scanSchoolStatsRow(db.Conn(ctx, r.db).QueryRowContext(ctx, schoolStatsQuery+` WHERE s.id = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrSchoolNotFound
+	}
+	return stats, err
+}
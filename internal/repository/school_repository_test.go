@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"testing"
 
 	"educnet/internal/domain"
@@ -17,6 +18,7 @@ func TestSchoolRepository_Create(t *testing.T) {
 	defer testutil.CleanupTestDB(t, db)
 
 	repo := NewSchoolRepository(db)
+	ctx := context.Background()
 
 	school := &domain.School{
 		Name:    "Test School",
@@ -25,9 +27,11 @@ func TestSchoolRepository_Create(t *testing.T) {
 		Address: "Test Address",
 		Phone:   "+261 34 12 345 67",
 		Status:  "active",
+
+		RegistrationMode: domain.RegistrationModeApproval,
 	}
 
-	err := repo.Create(school)
+	err := repo.Create(ctx, school)
 	if err != nil {
 		t.Fatalf("Create() error = %v", err)
 	}
@@ -52,12 +56,13 @@ func TestSchoolRepository_FindByID(t *testing.T) {
 	defer testutil.CleanupTestDB(t, db)
 
 	repo := NewSchoolRepository(db)
+	ctx := context.Background()
 
 	//! Seed data
 	schoolID := testutil.SeedTestSchool(t, db, "Test School", "test-school", "test@school.mg")
 
 	//! Test
-	school, err := repo.FindByID(schoolID)
+	school, err := repo.FindByID(ctx, schoolID)
 	if err != nil {
 		t.Fatalf("FindByID() error = %v", err)
 	}
@@ -79,8 +84,9 @@ func TestSchoolRepository_FindByID_NotFound(t *testing.T) {
 	defer testutil.CleanupTestDB(t, db)
 
 	repo := NewSchoolRepository(db)
+	ctx := context.Background()
 
-	_, err := repo.FindByID(99999)
+	_, err := repo.FindByID(ctx, 99999)
 	if err != domain.ErrSchoolNotFound {
 		t.Errorf("FindByID() error = %v, want ErrSchoolNotFound", err)
 	}
@@ -95,12 +101,13 @@ func TestSchoolRepository_FindBySlug(t *testing.T) {
 	defer testutil.CleanupTestDB(t, db)
 
 	repo := NewSchoolRepository(db)
+	ctx := context.Background()
 
 	//! Seed
 	testutil.SeedTestSchool(t, db, "Test School", "test-school", "test@school.mg")
 
 	//! Test
-	school, err := repo.FindBySlug("test-school")
+	school, err := repo.FindBySlug(ctx, "test-school")
 	if err != nil {
 		t.Fatalf("FindBySlug() error = %v", err)
 	}
@@ -119,6 +126,7 @@ func TestSchoolRepository_ExistsBySlug(t *testing.T) {
 	defer testutil.CleanupTestDB(t, db)
 
 	repo := NewSchoolRepository(db)
+	ctx := context.Background()
 
 	//! Seed
 	testutil.SeedTestSchool(t, db, "Test School", "test-school", "test@school.mg")
@@ -142,7 +150,7 @@ func TestSchoolRepository_ExistsBySlug(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			exists, err := repo.ExistsBySlug(tt.slug)
+			exists, err := repo.ExistsBySlug(ctx, tt.slug)
 			if err != nil {
 				t.Fatalf("ExistsBySlug() error = %v", err)
 			}
@@ -162,13 +170,14 @@ func TestSchoolRepository_Update(t *testing.T) {
 	defer testutil.CleanupTestDB(t, db)
 
 	repo := NewSchoolRepository(db)
+	ctx := context.Background()
 
 	//! Seed
 	schoolID := testutil.SeedTestSchool(t, db, "Original Name", "original", "original@school.mg")
 	adminID := testutil.SeedTestUser(t, db, schoolID, "admin@school.mg", "admin")
 
 	//! Get school
-	school, err := repo.FindByID(schoolID)
+	school, err := repo.FindByID(ctx, schoolID)
 	if err != nil {
 		t.Fatalf("FindByID() error = %v", err)
 	}
@@ -178,13 +187,13 @@ func TestSchoolRepository_Update(t *testing.T) {
 	school.Address = "Updated Address"
 	school.SetAdmin(adminID)
 
-	err = repo.Update(school)
+	err = repo.Update(ctx, school)
 	if err != nil {
 		t.Fatalf("Update() error = %v", err)
 	}
 
 	//! Verify
-	updated, err := repo.FindByID(schoolID)
+	updated, err := repo.FindByID(ctx, schoolID)
 	if err != nil {
 		t.Fatalf("FindByID() after update error = %v", err)
 	}
@@ -197,3 +206,93 @@ func TestSchoolRepository_Update(t *testing.T) {
 	}
 }
 
+
+func TestSchoolRepository_UpdateStatus(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := NewSchoolRepository(db)
+	ctx := context.Background()
+	schoolID := testutil.SeedTestSchool(t, db, "Test School", "test-school", "test@school.mg")
+
+	if err := repo.UpdateStatus(ctx, schoolID, domain.SchoolStatusSuspended); err != nil {
+		t.Fatalf("UpdateStatus() error = %v", err)
+	}
+
+	school, _ := repo.FindByID(ctx, schoolID)
+	if !school.IsSuspended() {
+		t.Errorf("UpdateStatus() Status = %v, want suspended", school.Status)
+	}
+
+	if err := repo.UpdateStatus(ctx, 99999, domain.SchoolStatusActive); err != domain.ErrSchoolNotFound {
+		t.Errorf("UpdateStatus() unknown school error = %v, want %v", err, domain.ErrSchoolNotFound)
+	}
+}
+
+func TestSchoolRepository_UpdateRegistrationMode(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := NewSchoolRepository(db)
+	ctx := context.Background()
+	schoolID := testutil.SeedTestSchool(t, db, "Test School", "test-school", "test@school.mg")
+
+	//! Défaut de la colonne
+	school, _ := repo.FindByID(ctx, schoolID)
+	if school.RegistrationMode != domain.RegistrationModeApproval {
+		t.Errorf("FindByID() RegistrationMode = %v, want %v", school.RegistrationMode, domain.RegistrationModeApproval)
+	}
+
+	if err := repo.UpdateRegistrationMode(ctx, schoolID, domain.RegistrationModeInviteOnly); err != nil {
+		t.Fatalf("UpdateRegistrationMode() error = %v", err)
+	}
+
+	school, _ = repo.FindByID(ctx, schoolID)
+	if school.AllowsSelfRegistration() {
+		t.Errorf("UpdateRegistrationMode() RegistrationMode = %v, want invite_only", school.RegistrationMode)
+	}
+
+	if err := repo.UpdateRegistrationMode(ctx, 99999, domain.RegistrationModeOpen); err != domain.ErrSchoolNotFound {
+		t.Errorf("UpdateRegistrationMode() unknown school error = %v, want %v", err, domain.ErrSchoolNotFound)
+	}
+}
+
+func TestSchoolRepository_FindStatsByID(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := NewSchoolRepository(db)
+	ctx := context.Background()
+	schoolID := testutil.SeedTestSchool(t, db, "Test School", "test-school", "test@school.mg")
+	testutil.SeedTestUser(t, db, schoolID, "admin@test.mg", domain.RoleAdmin)
+	testutil.SeedTestUser(t, db, schoolID, "teacher@test.mg", domain.RoleTeacher)
+	testutil.SeedTestUser(t, db, schoolID, "student@test.mg", domain.RoleStudent)
+	testutil.SeedTestClass(t, db, schoolID, "6ème A", "6ème", "A", "2025-2026")
+
+	stats, err := repo.FindStatsByID(ctx, schoolID)
+	if err != nil {
+		t.Fatalf("FindStatsByID() error = %v", err)
+	}
+
+	if stats.TotalUsers != 3 {
+		t.Errorf("TotalUsers = %d, want 3", stats.TotalUsers)
+	}
+	if stats.TotalTeachers != 1 || stats.TotalStudents != 1 || stats.TotalAdmins != 1 {
+		t.Errorf("role counts = %d/%d/%d, want 1/1/1", stats.TotalAdmins, stats.TotalTeachers, stats.TotalStudents)
+	}
+	if stats.TotalClasses != 1 {
+		t.Errorf("TotalClasses = %d, want 1", stats.TotalClasses)
+	}
+}
@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"educnet/internal/db"
+	"educnet/internal/domain"
+	"fmt"
+)
+
+// ! StatsRepository statistiques agrégées en SQL (dashboard / analytics admin)
+type StatsRepository interface {
+	CountUsers(ctx context.Context, schoolID int) (*domain.UserCounts, error)
+	Registrations(ctx context.Context, schoolID int, period domain.StatsPeriod) ([]domain.RegistrationPoint, error)
+	ApprovalLatency(ctx context.Context, schoolID int, period domain.StatsPeriod) (*domain.ApprovalLatency, error)
+	ClassFillRates(ctx context.Context, schoolID int) ([]domain.ClassFill, error)
+	TeachersPerSubject(ctx context.Context, schoolID int) ([]domain.SubjectTeachers, error)
+	ChatActivity(ctx context.Context, schoolID int, period domain.StatsPeriod) ([]domain.ClassChatActivity, error)
+}
+
+type statsRepository struct {
+	db *sql.DB
+}
+
+func NewStatsRepository(db *sql.DB) StatsRepository {
+	return &statsRepository{db: db}
+}
+
+// ! ==================== METHODS ====================
+func (r *statsRepository) CountUsers(ctx context.Context, schoolID int) (*domain.UserCounts, error) {
+	counts := &domain.UserCounts{}
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx, `
+        SELECT COUNT(*),
+            COUNT(*) FILTER (WHERE role = 'admin'),
+            COUNT(*) FILTER (WHERE role = 'teacher'),
+            COUNT(*) FILTER (WHERE role = 'student'),
+            COUNT(*) FILTER (WHERE status = 'pending'),
+            COUNT(*) FILTER (WHERE status = 'approved'),
+            COUNT(*) FILTER (WHERE status = 'rejected')
+        FROM users WHERE school_id = $1`, schoolID,
+	).Scan(&counts.Total, &counts.Admins, &counts.Teachers, &counts.Students,
+		&counts.Pending, &counts.Approved, &counts.Rejected)
+	if err != nil {
+		return nil, fmt.Errorf("count users: %w", err)
+	}
+	return counts, nil
+}
+
+// ! Registrations inscriptions par bucket (buckets vides inclus grâce à generate_series)
+func (r *statsRepository) Registrations(ctx context.Context, schoolID int, period domain.StatsPeriod) ([]domain.RegistrationPoint, error) {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx, `
+        WITH buckets AS (
+            SELECT generate_series(
+                date_trunc($2, $3::timestamp),
+                $4::timestamp - interval '1 microsecond',
+                ('1 ' || $2)::interval
+            ) AS period
+        )
+        SELECT b.period,
+            COUNT(u.id) FILTER (WHERE u.role = 'teacher'),
+            COUNT(u.id) FILTER (WHERE u.role = 'student'),
+            COUNT(u.id)
+        FROM buckets b
+        LEFT JOIN users u ON u.school_id = $1
+            AND u.role IN ('teacher', 'student')
+            AND u.created_at >= $3 AND u.created_at < $4
+            AND date_trunc($2, u.created_at) = b.period
+        GROUP BY b.period
+        ORDER BY b.period`,
+		schoolID, period.Granularity, period.From, period.To)
+	if err != nil {
+		return nil, fmt.Errorf("registrations over time: %w", err)
+	}
+	defer rows.Close()
+
+	points := []domain.RegistrationPoint{}
+	for rows.Next() {
+		var p domain.RegistrationPoint
+		if err := rows.Scan(&p.Period, &p.Teachers, &p.Students, &p.Total); err != nil {
+			return nil, scanError(err, "scan registration point")
+		}
+		points = append(points, p)
+	}
+	return points, rows.Err()
+}
+
+// ! ApprovalLatency délai inscription -> validation des comptes validés sur la période
+func (r *statsRepository) ApprovalLatency(ctx context.Context, schoolID int, period domain.StatsPeriod) (*domain.ApprovalLatency, error) {
+	latency := &domain.ApprovalLatency{}
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx, `
+        WITH latencies AS (
+            SELECT EXTRACT(EPOCH FROM (approved_at - created_at)) / 3600.0 AS hours
+            FROM users
+            WHERE school_id = $1 AND approved_at IS NOT NULL
+                AND approved_at >= $2 AND approved_at < $3
+        )
+        SELECT COUNT(*),
+            COALESCE(AVG(hours), 0),
+            COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY hours), 0),
+            COALESCE(percentile_cont(0.9) WITHIN GROUP (ORDER BY hours), 0),
+            COALESCE(MAX(hours), 0)
+        FROM latencies`,
+		schoolID, period.From, period.To,
+	).Scan(&latency.Approved, &latency.AverageHours, &latency.MedianHours, &latency.P90Hours, &latency.MaxHours)
+	if err != nil {
+		return nil, fmt.Errorf("approval latency: %w", err)
+	}
+	return latency, nil
+}
+
+func (r *statsRepository) ClassFillRates(ctx context.Context, schoolID int) ([]domain.ClassFill, error) {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx, `
+        SELECT c.id, c.name, COALESCE(c.capacity, 0),
+            COUNT(u.id) FILTER (WHERE u.status = 'approved'),
+            COUNT(u.id) FILTER (WHERE u.status = 'pending')
+        FROM classes c
+        LEFT JOIN student_classes sc ON sc.class_id = c.id AND sc.is_active
+        LEFT JOIN users u ON u.id = sc.student_id
+        WHERE c.school_id = $1 AND c.status = 'active'
+        GROUP BY c.id, c.name, c.capacity
+        ORDER BY c.name`, schoolID)
+	if err != nil {
+		return nil, fmt.Errorf("class fill rates: %w", err)
+	}
+	defer rows.Close()
+
+	fills := []domain.ClassFill{}
+	for rows.Next() {
+		var f domain.ClassFill
+		if err := rows.Scan(&f.ClassID, &f.ClassName, &f.Capacity, &f.Enrolled, &f.Pending); err != nil {
+			return nil, scanError(err, "scan class fill")
+		}
+		if f.Capacity > 0 {
+			f.FillRate = float64(f.Enrolled) / float64(f.Capacity)
+		}
+		fills = append(fills, f)
+	}
+	return fills, rows.Err()
+}
+
+func (r *statsRepository) TeachersPerSubject(ctx context.Context, schoolID int) ([]domain.SubjectTeachers, error) {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx, `
+        SELECT s.id, s.name, s.code, COUNT(u.id)
+        FROM subjects s
+        LEFT JOIN teacher_subjects ts ON ts.subject_id = s.id
+        LEFT JOIN users u ON u.id = ts.teacher_id AND u.status = 'approved'
+        WHERE s.school_id = $1
+        GROUP BY s.id, s.name, s.code
+        ORDER BY s.name`, schoolID)
+	if err != nil {
+		return nil, fmt.Errorf("teachers per subject: %w", err)
+	}
+	defer rows.Close()
+
+	subjects := []domain.SubjectTeachers{}
+	for rows.Next() {
+		var s domain.SubjectTeachers
+		if err := rows.Scan(&s.SubjectID, &s.SubjectName, &s.SubjectCode, &s.Teachers); err != nil {
+			return nil, scanError(err, "scan subject teachers")
+		}
+		subjects = append(subjects, s)
+	}
+	return subjects, rows.Err()
+}
+
+// ! ChatActivity messages par classe et par jour (jours sans message omis)
+func (r *statsRepository) ChatActivity(ctx context.Context, schoolID int, period domain.StatsPeriod) ([]domain.ClassChatActivity, error) {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx, `
+        SELECT c.id, c.name, date_trunc('day', m.created_at AT TIME ZONE 'UTC') AS day, COUNT(*)
+        FROM messages m
+        JOIN classes c ON c.id = m.class_id
+        WHERE c.school_id = $1
+            AND m.created_at >= ($2::timestamp AT TIME ZONE 'UTC')
+            AND m.created_at < ($3::timestamp AT TIME ZONE 'UTC')
+        GROUP BY c.id, c.name, day
+        ORDER BY day, c.name`,
+		schoolID, period.From, period.To)
+	if err != nil {
+		return nil, fmt.Errorf("chat activity: %w", err)
+	}
+	defer rows.Close()
+
+	activity := []domain.ClassChatActivity{}
+	for rows.Next() {
+		var a domain.ClassChatActivity
+		if err := rows.Scan(&a.ClassID, &a.ClassName, &a.Day, &a.Messages); err != nil {
+			return nil, scanError(err, "scan chat activity")
+		}
+		activity = append(activity, a)
+	}
+	return activity, rows.Err()
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"educnet/internal/domain"
+	"educnet/internal/testutil"
+)
+
+func TestStatsRepository_CountsAndFill(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := NewStatsRepository(db)
+	ctx := context.Background()
+	schoolID := testutil.SeedTestSchool(t, db, "Test School", "test-school", "test@school.mg")
+	testutil.SeedTestUser(t, db, schoolID, "admin@test.mg", domain.RoleAdmin)
+	teacherID := testutil.SeedTestUser(t, db, schoolID, "prof@test.mg", domain.RoleTeacher)
+	studentA := testutil.SeedTestUser(t, db, schoolID, "a@test.mg", domain.RoleStudent)
+	studentB := testutil.SeedTestUser(t, db, schoolID, "b@test.mg", domain.RoleStudent)
+	classID := testutil.SeedTestClass(t, db, schoolID, "6ème A", "6ème", "A", "2025-2026")
+	subjectID := testutil.SeedTestSubject(t, db, schoolID, "Math", "MATH", "")
+	testutil.SeedTestSubject(t, db, schoolID, "Physique", "PHY", "")
+	testutil.SeedTestStudentClass(t, db, studentA, classID)
+	testutil.SeedTestStudentClass(t, db, studentB, classID)
+	db.Exec(`UPDATE users SET status = 'pending' WHERE id = $1`, studentB)
+	db.Exec(`INSERT INTO teacher_subjects (teacher_id, subject_id) VALUES ($1, $2)`, teacherID, subjectID)
+
+	counts, err := repo.CountUsers(ctx, schoolID)
+	if err != nil {
+		t.Fatalf("CountUsers() error = %v", err)
+	}
+	if counts.Total != 4 || counts.Students != 2 || counts.Pending != 1 || counts.Admins != 1 {
+		t.Errorf("CountUsers() = %+v", counts)
+	}
+
+	fills, err := repo.ClassFillRates(ctx, schoolID)
+	if err != nil || len(fills) != 1 {
+		t.Fatalf("ClassFillRates() = %v, err = %v", fills, err)
+	}
+	if fills[0].Enrolled != 1 || fills[0].Pending != 1 || fills[0].FillRate != 1.0/40 {
+		t.Errorf("ClassFillRates() = %+v", fills[0])
+	}
+
+	subjects, err := repo.TeachersPerSubject(ctx, schoolID)
+	if err != nil || len(subjects) != 2 {
+		t.Fatalf("TeachersPerSubject() = %v, err = %v", subjects, err)
+	}
+	if subjects[0].SubjectCode != "MATH" || subjects[0].Teachers != 1 || subjects[1].Teachers != 0 {
+		t.Errorf("TeachersPerSubject() = %+v", subjects)
+	}
+}
+
+func TestStatsRepository_RegistrationsAndLatency(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := NewStatsRepository(db)
+	ctx := context.Background()
+	schoolID := testutil.SeedTestSchool(t, db, "Test School", "test-school", "test@school.mg")
+	studentID := testutil.SeedTestUser(t, db, schoolID, "eleve@test.mg", domain.RoleStudent)
+
+	//! Inscrit il y a 2 jours, validé maintenant (trigger approved_at)
+	db.Exec(`UPDATE users SET status = 'pending', created_at = NOW() - interval '48 hours' WHERE id = $1`, studentID)
+	db.Exec(`UPDATE users SET status = 'approved' WHERE id = $1`, studentID)
+
+	period, _ := domain.NewStatsPeriod(time.Time{}, time.Time{}, domain.GranularityDay, time.Now())
+
+	points, err := repo.Registrations(ctx, schoolID, period)
+	if err != nil {
+		t.Fatalf("Registrations() error = %v", err)
+	}
+	total := 0
+	for _, p := range points {
+		total += p.Total
+	}
+	if len(points) < 30 || total != 1 {
+		t.Errorf("Registrations() = %d buckets, %d registrations; want >= 30 buckets, 1 registration", len(points), total)
+	}
+
+	latency, err := repo.ApprovalLatency(ctx, schoolID, period)
+	if err != nil {
+		t.Fatalf("ApprovalLatency() error = %v", err)
+	}
+	if latency.Approved != 1 || latency.AverageHours < 47 || latency.AverageHours > 49 {
+		t.Errorf("ApprovalLatency() = %+v, want 1 approval of ~48h", latency)
+	}
+}
@@ -1,19 +1,33 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"educnet/internal/db"
 	"educnet/internal/domain"
+	"errors"
 	"fmt"
 )
 
 type StudentClassRepository interface {
-	Create(studentID, classID int) error
-	Delete(studentID, classID int) error
-	Exists(studentID, classID int) (bool, error)
-	FindByStudent(studentID int) ([]*domain.Class, error)
-	FindByClass(classID int) ([]*domain.User, error)
-	DeleteByStudent(studentID int) error
-	DeleteByClass(classID int) error
+	Create(ctx context.Context, studentID, classID int) error
+	Enroll(ctx context.Context, studentID, classID int) error
+	EnrollOrWaitlist(ctx context.Context, studentID, classID int) (bool, error)
+	ConfirmSeats(ctx context.Context, studentID int) ([]int, error)
+	Delete(ctx context.Context, studentID, classID int) error
+	EndEnrollments(ctx context.Context, studentID int, academicYear, reason string) ([]int, error)
+	FindHistory(ctx context.Context, studentID int) ([]*domain.Enrollment, error)
+	Exists(ctx context.Context, studentID, classID int) (bool, error)
+	FindByStudent(ctx context.Context, studentID int) ([]*domain.Class, error)
+	FindByClass(ctx context.Context, classID int) ([]*domain.User, error)
+	EachByClass(ctx context.Context, classID int, fn func(*domain.User) error) error
+	DeleteByStudent(ctx context.Context, studentID int) error
+	DeleteByClass(ctx context.Context, classID int) error
+
+	//! WAITLIST
+	FindWaitlist(ctx context.Context, classID int) ([]*domain.WaitlistEntry, error)
+	RemoveFromWaitlist(ctx context.Context, studentID, classID int) error
+	PromoteWaitlist(ctx context.Context, classID int) ([]int, error)
 }
 
 type studentClassRepository struct {
@@ -25,8 +39,11 @@ func NewStudentClassRepository(db *sql.DB) StudentClassRepository {
 }
 
 // ! ==================== METHODS PRO ====================
-func (r *studentClassRepository) Create(studentID, classID int) error {
-	_, err := r.db.Exec(
+
+// ! Create inscription sans contrôle de capacité (élève en attente de validation :
+// ! la place est vérifiée à l'approbation par ConfirmSeats)
+func (r *studentClassRepository) Create(ctx context.Context, studentID, classID int) error {
+	_, err := db.Conn(ctx, r.db).ExecContext(ctx,
 		`INSERT INTO student_classes (student_id, class_id) VALUES ($1, $2)`,
 		studentID, classID,
 	)
@@ -36,36 +53,108 @@ func (r *studentClassRepository) Create(studentID, classID int) error {
 	return nil
 }
 
-func (r *studentClassRepository) Delete(studentID, classID int) error {
-	result, err := r.db.Exec(
-		`DELETE FROM student_classes WHERE student_id=$1 AND class_id=$2`,
-		studentID, classID)
+// ! unenrolledEndReason motif des inscriptions fermées par Delete / DeleteByStudent
+const unenrolledEndReason = "Désinscription"
+
+// ! Delete désinscrit l'élève (inscription fermée, conservée dans l'historique)
+// ! et promeut la liste d'attente sur la place libérée
+func (r *studentClassRepository) Delete(ctx context.Context, studentID, classID int) error {
+	return db.RunInTx(ctx, r.db, func(ctx context.Context) error {
+		result, err := db.Conn(ctx, r.db).ExecContext(ctx, `
+            UPDATE student_classes
+            SET is_active = FALSE, ended_at = NOW(), end_reason = $3
+            WHERE student_id = $1 AND class_id = $2 AND is_active`,
+			studentID, classID, unenrolledEndReason)
+		if err != nil {
+			return fmt.Errorf("end student-class: %w", err)
+		}
+
+		rowsAffected, _ := result.RowsAffected()
+		if rowsAffected == 0 {
+			return domain.ErrStudentClassNotFound
+		}
+
+		_, err = r.PromoteWaitlist(ctx, classID)
+		return err
+	})
+}
+
+// ! EndEnrollments ferme les inscriptions actives de l'élève pour academicYear
+// ! (toutes années si vide) sans les supprimer, puis promeut les listes d'attente.
+// ! Retourne les classes quittées.
+func (r *studentClassRepository) EndEnrollments(ctx context.Context, studentID int, academicYear, reason string) ([]int, error) {
+	var classIDs []int
+	err := db.RunInTx(ctx, r.db, func(ctx context.Context) error {
+		rows, err := db.Conn(ctx, r.db).QueryContext(ctx, `
+            UPDATE student_classes
+            SET is_active = FALSE, ended_at = NOW(), end_reason = NULLIF($3, '')
+            WHERE student_id = $1 AND is_active AND ($2 = '' OR academic_year = $2)
+            RETURNING class_id`, studentID, academicYear, reason)
+		if err != nil {
+			return fmt.Errorf("end student enrollments: %w", err)
+		}
+		if classIDs, err = scanIDs(rows); err != nil {
+			return err
+		}
+
+		for _, classID := range classIDs {
+			if _, err := r.PromoteWaitlist(ctx, classID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return classIDs, err
+}
+
+// ! FindHistory toutes les inscriptions de l'élève (actives et fermées), plus récentes d'abord
+func (r *studentClassRepository) FindHistory(ctx context.Context, studentID int) ([]*domain.Enrollment, error) {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx, `
+        SELECT sc.id, sc.student_id, sc.class_id, c.name, sc.academic_year,
+            COALESCE(sc.enrollment_date, sc.created_at), sc.ended_at, sc.end_reason, sc.is_active
+        FROM student_classes sc
+        JOIN classes c ON c.id = sc.class_id
+        WHERE sc.student_id = $1
+        ORDER BY sc.academic_year DESC, sc.is_active DESC, COALESCE(sc.enrollment_date, sc.created_at) DESC, sc.id DESC`,
+		studentID)
 	if err != nil {
-		return fmt.Errorf("delete student-class: %w", err)
+		return nil, fmt.Errorf("find enrollment history: %w", err)
 	}
+	defer rows.Close()
 
-	rowsAffected, _ := result.RowsAffected()
-	if rowsAffected == 0 {
-		return domain.ErrStudentClassNotFound
+	history := []*domain.Enrollment{}
+	for rows.Next() {
+		e := &domain.Enrollment{}
+		var endedAt sql.NullTime
+		var endReason sql.NullString
+		if err := rows.Scan(&e.ID, &e.StudentID, &e.ClassID, &e.ClassName, &e.AcademicYear,
+			&e.EnrolledAt, &endedAt, &endReason, &e.IsActive); err != nil {
+			return nil, scanError(err, "scan enrollment")
+		}
+		if endedAt.Valid {
+			e.EndedAt = &endedAt.Time
+		}
+		e.EndReason = nullString(endReason)
+		history = append(history, e)
 	}
-	return nil
+	return history, rows.Err()
 }
 
-func (r *studentClassRepository) Exists(studentID, classID int) (bool, error) {
+func (r *studentClassRepository) Exists(ctx context.Context, studentID, classID int) (bool, error) {
 	var exists bool
-	err := r.db.QueryRow(
-		`SELECT EXISTS(SELECT 1 FROM student_classes WHERE student_id=$1 AND class_id=$2)`,
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT EXISTS(SELECT 1 FROM student_classes WHERE student_id=$1 AND class_id=$2 AND is_active)`,
 		studentID, classID).Scan(&exists)
 	return exists, err
 }
 
-func (r *studentClassRepository) FindByStudent(studentID int) ([]*domain.Class, error) {
-	rows, err := r.db.Query(`
+func (r *studentClassRepository) FindByStudent(ctx context.Context, studentID int) ([]*domain.Class, error) {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx, `
         SELECT c.id, c.school_id, c.name, c.level, c.section, c.capacity, c.academic_year, 
             c.created_at, c.updated_at
         FROM student_classes sc
         JOIN classes c ON sc.class_id = c.id 
-        WHERE sc.student_id = $1 
+        WHERE sc.student_id = $1 AND sc.is_active
         ORDER BY c.name`, studentID)
 	if err != nil {
 		return nil, fmt.Errorf("find student classes: %w", err)
@@ -84,43 +173,256 @@ func (r *studentClassRepository) FindByStudent(studentID int) ([]*domain.Class,
 	return classes, rows.Err()
 }
 
-func (r *studentClassRepository) FindByClass(classID int) ([]*domain.User, error) {
-	rows, err := r.db.Query(`
+func (r *studentClassRepository) FindByClass(ctx context.Context, classID int) ([]*domain.User, error) {
+	var students []*domain.User
+	err := r.EachByClass(ctx, classID, func(student *domain.User) error {
+		students = append(students, student)
+		return nil
+	})
+	return students, err
+}
+
+// ! EachByClass comme FindByClass, mais appelle fn ligne par ligne (exports volumineux)
+func (r *studentClassRepository) EachByClass(ctx context.Context, classID int, fn func(*domain.User) error) error {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx, `
         SELECT u.id, u.school_id, u.email, u.password_hash, u.first_name, u.last_name, 
-            u.phone, u.role, u.avatar_url, u.status, u.created_at, u.updated_at
+            u.phone, u.role, u.avatar_url, u.status, u.must_change_password, u.created_at, u.updated_at
         FROM student_classes sc
         JOIN users u ON sc.student_id = u.id 
-        WHERE sc.class_id = $1 AND u.role = $2 
+        WHERE sc.class_id = $1 AND sc.is_active AND u.role = $2
         ORDER BY u.first_name, u.last_name`, classID, domain.RoleStudent)
 	if err != nil {
-		return nil, fmt.Errorf("find class students: %w", err)
+		return fmt.Errorf("find class students: %w", err)
 	}
 	defer rows.Close()
 
 	userRepo := NewUserRepository(r.db)
-	var students []*domain.User
 	for rows.Next() {
 		student := &domain.User{}
 		if err := userRepo.ScanUserRow(rows, student); err != nil {
-			return nil, err
+			return err
 		}
-		students = append(students, student)
+		if err := fn(student); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// ! DeleteByStudent retire l'élève des listes d'attente, ferme ses inscriptions
+// ! (historique conservé) et promeut les listes d'attente des classes libérées
+func (r *studentClassRepository) DeleteByStudent(ctx context.Context, studentID int) error {
+	return db.RunInTx(ctx, r.db, func(ctx context.Context) error {
+		if _, err := db.Conn(ctx, r.db).ExecContext(ctx,
+			`DELETE FROM class_waitlist WHERE student_id = $1`, studentID); err != nil {
+			return fmt.Errorf("delete student waitlist entries: %w", err)
+		}
+
+		_, err := r.EndEnrollments(ctx, studentID, "", unenrolledEndReason)
+		return err
+	})
+}
+
+func (r *studentClassRepository) DeleteByClass(ctx context.Context, classID int) error {
+	_, err := db.Conn(ctx, r.db).ExecContext(ctx, `DELETE FROM student_classes WHERE class_id = $1`, classID)
+	if err != nil {
+		return fmt.Errorf("delete class students: %w", err)
+	}
+	return nil
+}
+
+// ! ==================== CAPACITY & WAITLIST ====================
+
+// ! Enroll inscrit un élève approuvé, ErrClassFull si la classe est complète
+func (r *studentClassRepository) Enroll(ctx context.Context, studentID, classID int) error {
+	return db.RunInTx(ctx, r.db, func(ctx context.Context) error {
+		hasSeat, err := r.lockSeat(ctx, studentID, classID)
+		if err != nil {
+			return err
+		}
+		if !hasSeat {
+			return domain.ErrClassFull
+		}
+		return r.Create(ctx, studentID, classID)
+	})
+}
+
+// ! EnrollOrWaitlist inscrit l'élève approuvé, ou le place en liste d'attente
+// ! si la classe est complète (retourne true dans ce cas)
+func (r *studentClassRepository) EnrollOrWaitlist(ctx context.Context, studentID, classID int) (bool, error) {
+	waitlisted := false
+	err := db.RunInTx(ctx, r.db, func(ctx context.Context) error {
+		hasSeat, err := r.lockSeat(ctx, studentID, classID)
+		if err != nil {
+			return err
+		}
+		if hasSeat {
+			return r.Create(ctx, studentID, classID)
+		}
+		waitlisted = true
+		return r.addToWaitlist(ctx, studentID, classID)
+	})
+	return waitlisted, err
+}
+
+// ! ConfirmSeats vérifie les places des inscriptions d'un élève qui vient d'être
+// ! approuvé : les inscriptions en surnombre passent en liste d'attente.
+// ! Retourne les classes où l'élève a été mis en attente.
+func (r *studentClassRepository) ConfirmSeats(ctx context.Context, studentID int) ([]int, error) {
+	var waitlisted []int
+	err := db.RunInTx(ctx, r.db, func(ctx context.Context) error {
+		rows, err := db.Conn(ctx, r.db).QueryContext(ctx,
+			`SELECT class_id FROM student_classes WHERE student_id = $1 AND is_active ORDER BY class_id`, studentID)
+		if err != nil {
+			return fmt.Errorf("find student enrollments: %w", err)
+		}
+		classIDs, err := scanIDs(rows)
+		if err != nil {
+			return err
+		}
+
+		for _, classID := range classIDs {
+			hasSeat, err := r.lockSeat(ctx, studentID, classID)
+			if err != nil {
+				return err
+			}
+			if hasSeat {
+				continue
+			}
+			if _, err := db.Conn(ctx, r.db).ExecContext(ctx,
+				`DELETE FROM student_classes WHERE student_id = $1 AND class_id = $2 AND is_active`, studentID, classID); err != nil {
+				return fmt.Errorf("release student-class: %w", err)
+			}
+			if err := r.addToWaitlist(ctx, studentID, classID); err != nil {
+				return err
+			}
+			waitlisted = append(waitlisted, classID)
+		}
+		return nil
+	})
+	return waitlisted, err
+}
+
+// ! FindWaitlist liste d'attente d'une classe, dans l'ordre de promotion
+func (r *studentClassRepository) FindWaitlist(ctx context.Context, classID int) ([]*domain.WaitlistEntry, error) {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx, `
+        SELECT w.id, w.class_id, w.student_id, u.first_name || ' ' || u.last_name, u.email,
+            ROW_NUMBER() OVER (ORDER BY w.created_at, w.id), w.created_at
+        FROM class_waitlist w
+        JOIN users u ON u.id = w.student_id
+        WHERE w.class_id = $1
+        ORDER BY w.created_at, w.id`, classID)
+	if err != nil {
+		return nil, fmt.Errorf("find class waitlist: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*domain.WaitlistEntry{}
+	for rows.Next() {
+		e := &domain.WaitlistEntry{}
+		if err := rows.Scan(&e.ID, &e.ClassID, &e.StudentID, &e.StudentName, &e.StudentEmail,
+			&e.Position, &e.CreatedAt); err != nil {
+			return nil, scanError(err, "scan waitlist entry")
+		}
+		entries = append(entries, e)
 	}
-	return students, rows.Err()
+	return entries, rows.Err()
 }
 
-func (r *studentClassRepository) DeleteByStudent(studentID int) error {
-	_, err := r.db.Exec(`DELETE FROM student_classes WHERE student_id = $1`, studentID)
+func (r *studentClassRepository) RemoveFromWaitlist(ctx context.Context, studentID, classID int) error {
+	result, err := db.Conn(ctx, r.db).ExecContext(ctx,
+		`DELETE FROM class_waitlist WHERE student_id = $1 AND class_id = $2`, studentID, classID)
 	if err != nil {
-		return fmt.Errorf("delete student classes: %w", err)
+		return fmt.Errorf("remove from waitlist: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return domain.ErrWaitlistEntryNotFound
 	}
 	return nil
 }
 
-func (r *studentClassRepository) DeleteByClass(classID int) error {
-	_, err := r.db.Exec(`DELETE FROM student_classes WHERE class_id = $1`, classID)
+// ! PromoteWaitlist inscrit les premiers élèves en attente tant qu'il reste des
+// ! places (suppression d'inscription, capacité augmentée). Retourne les élèves promus.
+func (r *studentClassRepository) PromoteWaitlist(ctx context.Context, classID int) ([]int, error) {
+	var promoted []int
+	err := db.RunInTx(ctx, r.db, func(ctx context.Context) error {
+		for {
+			hasSeat, err := r.lockSeat(ctx, 0, classID)
+			if err != nil || !hasSeat {
+				return err
+			}
+
+			//! Premier élève encore approuvé (les comptes suspendus/rejetés depuis gardent leur rang)
+			var studentID int
+			err = db.Conn(ctx, r.db).QueryRowContext(ctx, `
+                DELETE FROM class_waitlist WHERE id = (
+                    SELECT w.id FROM class_waitlist w
+                    JOIN users u ON u.id = w.student_id
+                    WHERE w.class_id = $1 AND u.status = $2
+                    ORDER BY w.created_at, w.id
+                    LIMIT 1
+                ) RETURNING student_id`, classID, domain.UserStatusApproved).Scan(&studentID)
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("pop class waitlist: %w", err)
+			}
+
+			result, err := db.Conn(ctx, r.db).ExecContext(ctx,
+				`INSERT INTO student_classes (student_id, class_id) VALUES ($1, $2)
+                 ON CONFLICT DO NOTHING`, studentID, classID)
+			if err != nil {
+				return fmt.Errorf("promote waitlisted student: %w", err)
+			}
+			//! Élève déjà inscrit ailleurs cette année (uniq_student_classes_active_year) :
+			//! son entrée est abandonnée et la place revient au suivant
+			if n, _ := result.RowsAffected(); n == 0 {
+				continue
+			}
+			promoted = append(promoted, studentID)
+		}
+	})
+	return promoted, err
+}
+
+// ! ==================== HELPERS ====================
+
+// ! lockSeat verrouille la classe (FOR UPDATE : les approbations concurrentes
+// ! attendent) et indique s'il reste une place pour studentID. Occupent une place
+// ! les élèves inscrits dont le compte n'est ni en attente ni rejeté.
+func (r *studentClassRepository) lockSeat(ctx context.Context, studentID, classID int) (bool, error) {
+	class := &domain.Class{ID: classID}
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT capacity FROM classes WHERE id = $1 FOR UPDATE`, classID).Scan(&class.Capacity)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, domain.ErrClassNotFound
+	}
 	if err != nil {
-		return fmt.Errorf("delete class students: %w", err)
+		return false, fmt.Errorf("lock class %d: %w", classID, err)
+	}
+
+	var enrolled int
+	err = db.Conn(ctx, r.db).QueryRowContext(ctx, `
+        SELECT COUNT(*) FROM student_classes sc
+        JOIN users u ON u.id = sc.student_id
+        WHERE sc.class_id = $1 AND sc.is_active AND sc.student_id <> $2
+            AND u.status NOT IN ($3, $4)`,
+		classID, studentID, domain.UserStatusPending, domain.UserStatusRejected).Scan(&enrolled)
+	if err != nil {
+		return false, fmt.Errorf("count class seats: %w", err)
+	}
+	return class.HasSeat(enrolled), nil
+}
+
+func (r *studentClassRepository) addToWaitlist(ctx context.Context, studentID, classID int) error {
+	_, err := db.Conn(ctx, r.db).ExecContext(ctx,
+		`INSERT INTO class_waitlist (class_id, student_id) VALUES ($1, $2)
+         ON CONFLICT (class_id, student_id) DO NOTHING`, classID, studentID)
+	if err != nil {
+		return fmt.Errorf("add to waitlist: %w", err)
 	}
 	return nil
 }
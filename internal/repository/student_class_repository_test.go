@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"educnet/internal/domain"
 	"educnet/internal/testutil"
 	"testing"
@@ -14,6 +15,7 @@ func TestStudentClassRepository_Create(t *testing.T) {
 	db := testutil.SetupTestDB(t)
 	defer testutil.CleanupTestDB(t, db)
 	repo := NewStudentClassRepository(db)
+	ctx := context.Background()
 
 	//! Seed prerequisites
 	schoolID := testutil.SeedTestSchool(t, db, "Test School", "test", "test@school.mg")
@@ -21,13 +23,13 @@ func TestStudentClassRepository_Create(t *testing.T) {
 	classID := testutil.SeedTestClass(t, db, schoolID, "6ème A", "6ème", "A", "2025-2026")
 
 	//! Test Create
-	err := repo.Create(studentID, classID)
+	err := repo.Create(ctx, studentID, classID)
 	if err != nil {
 		t.Fatalf("Create() error = %v", err)
 	}
 
 	//! Verify exists
-	exists, err := repo.Exists(studentID, classID)
+	exists, err := repo.Exists(ctx, studentID, classID)
 	if err != nil {
 		t.Fatalf("Exists() error = %v", err)
 	}
@@ -44,25 +46,39 @@ func TestStudentClassRepository_Delete(t *testing.T) {
 	db := testutil.SetupTestDB(t)
 	defer testutil.CleanupTestDB(t, db)
 	repo := NewStudentClassRepository(db)
+	ctx := context.Background()
 
 	schoolID := testutil.SeedTestSchool(t, db, "Test", "test", "test@school.mg")
 	studentID := testutil.SeedTestUser(t, db, schoolID, "student@test.mg", domain.RoleStudent)
 	classID := testutil.SeedTestClass(t, db, schoolID, "6ème A", "6ème", "A", "2025-2026")
 
 	//! Create first
-	repo.Create(studentID, classID)
+	repo.Create(ctx, studentID, classID)
 
 	//! Test Delete
-	err := repo.Delete(studentID, classID)
+	err := repo.Delete(ctx, studentID, classID)
 	if err != nil {
 		t.Fatalf("Delete() error = %v", err)
 	}
 
 	//! Verify deleted
-	exists, _ := repo.Exists(studentID, classID)
+	exists, _ := repo.Exists(ctx, studentID, classID)
 	if exists {
 		t.Error("Delete() should remove association")
 	}
+
+	//! Inscription fermée, pas effacée
+	history, err := repo.FindHistory(ctx, studentID)
+	if err != nil {
+		t.Fatalf("FindHistory() error = %v", err)
+	}
+	if len(history) != 1 || history[0].IsActive || history[0].EndedAt == nil || history[0].EndReason == "" {
+		t.Errorf("FindHistory() after Delete() = %+v, want one closed enrollment", history)
+	}
+
+	if err := repo.Delete(ctx, studentID, classID); err != domain.ErrStudentClassNotFound {
+		t.Errorf("Delete() twice error = %v, want %v", err, domain.ErrStudentClassNotFound)
+	}
 }
 
 func TestStudentClassRepository_FindByStudent(t *testing.T) {
@@ -73,6 +89,7 @@ func TestStudentClassRepository_FindByStudent(t *testing.T) {
 	db := testutil.SetupTestDB(t)
 	defer testutil.CleanupTestDB(t, db)
 	repo := NewStudentClassRepository(db)
+	ctx := context.Background()
 
 	schoolID := testutil.SeedTestSchool(t, db, "Test", "test", "test@school.mg")
 	studentID := testutil.SeedTestUser(t, db, schoolID, "student@test.mg", domain.RoleStudent)
@@ -80,11 +97,11 @@ func TestStudentClassRepository_FindByStudent(t *testing.T) {
 	class2ID := testutil.SeedTestClass(t, db, schoolID, "5ème B", "5ème", "B", "2025-2026")
 
 	//! Enroll student in 2 classes
-	repo.Create(studentID, class1ID)
-	repo.Create(studentID, class2ID)
+	repo.Create(ctx, studentID, class1ID)
+	repo.Create(ctx, studentID, class2ID)
 
 	//! Test FindByStudent
-	classes, err := repo.FindByStudent(studentID)
+	classes, err := repo.FindByStudent(ctx, studentID)
 	if err != nil {
 		t.Fatalf("FindByStudent() error = %v", err)
 	}
@@ -101,6 +118,7 @@ func TestStudentClassRepository_FindByClass(t *testing.T) {
 	db := testutil.SetupTestDB(t)
 	defer testutil.CleanupTestDB(t, db)
 	repo := NewStudentClassRepository(db)
+	ctx := context.Background()
 
 	schoolID := testutil.SeedTestSchool(t, db, "Test", "test", "test@school.mg")
 	student1ID := testutil.SeedTestUser(t, db, schoolID, "student1@test.mg", domain.RoleStudent)
@@ -108,11 +126,11 @@ func TestStudentClassRepository_FindByClass(t *testing.T) {
 	classID := testutil.SeedTestClass(t, db, schoolID, "6ème A", "6ème", "A", "2025-2026")
 
 	//! Enroll 2 students in class
-	repo.Create(student1ID, classID)
-	repo.Create(student2ID, classID)
+	repo.Create(ctx, student1ID, classID)
+	repo.Create(ctx, student2ID, classID)
 
 	//! Test FindByClass
-	students, err := repo.FindByClass(classID)
+	students, err := repo.FindByClass(ctx, classID)
 	if err != nil {
 		t.Fatalf("FindByClass() error = %v", err)
 	}
@@ -129,19 +147,210 @@ func TestStudentClassRepository_DeleteByStudent(t *testing.T) {
 	db := testutil.SetupTestDB(t)
 	defer testutil.CleanupTestDB(t, db)
 	repo := NewStudentClassRepository(db)
+	ctx := context.Background()
 
 	schoolID := testutil.SeedTestSchool(t, db, "Test", "test", "test@school.mg")
 	studentID := testutil.SeedTestUser(t, db, schoolID, "student@test.mg", domain.RoleStudent)
 	classID := testutil.SeedTestClass(t, db, schoolID, "6ème A", "6ème", "A", "2025-2026")
 
-	repo.Create(studentID, classID)
-	err := repo.DeleteByStudent(studentID)
+	repo.Create(ctx, studentID, classID)
+	err := repo.DeleteByStudent(ctx, studentID)
 	if err != nil {
 		t.Fatalf("DeleteByStudent() error = %v", err)
 	}
 
-	classes, _ := repo.FindByStudent(studentID)
+	classes, _ := repo.FindByStudent(ctx, studentID)
 	if len(classes) != 0 {
 		t.Error("DeleteByStudent() should remove all student classes")
 	}
+	if history, _ := repo.FindHistory(ctx, studentID); len(history) != 1 || history[0].IsActive {
+		t.Errorf("FindHistory() after DeleteByStudent() = %+v, want one closed enrollment", history)
+	}
+}
+
+func TestStudentClassRepository_Waitlist(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+	repo := NewStudentClassRepository(db)
+	ctx := context.Background()
+
+	schoolID := testutil.SeedTestSchool(t, db, "Test", "test", "test@school.mg")
+	classID := testutil.SeedTestClass(t, db, schoolID, "6ème A", "6ème", "A", "2025-2026")
+	if _, err := db.Exec(`UPDATE classes SET capacity = 1 WHERE id = $1`, classID); err != nil {
+		t.Fatalf("set capacity: %v", err)
+	}
+	first := testutil.SeedTestUser(t, db, schoolID, "first@test.mg", domain.RoleStudent)
+	second := testutil.SeedTestUser(t, db, schoolID, "second@test.mg", domain.RoleStudent)
+	third := testutil.SeedTestUser(t, db, schoolID, "third@test.mg", domain.RoleStudent)
+
+	//! 1 place : le premier est inscrit, les suivants attendent dans l'ordre
+	if waitlisted, err := repo.EnrollOrWaitlist(ctx, first, classID); err != nil || waitlisted {
+		t.Fatalf("EnrollOrWaitlist(first) = %v, %v; want enrolled", waitlisted, err)
+	}
+	for _, studentID := range []int{second, third} {
+		if waitlisted, err := repo.EnrollOrWaitlist(ctx, studentID, classID); err != nil || !waitlisted {
+			t.Fatalf("EnrollOrWaitlist(%d) = %v, %v; want waitlisted", studentID, waitlisted, err)
+		}
+	}
+	if err := repo.Enroll(ctx, second, classID); err != domain.ErrClassFull {
+		t.Errorf("Enroll() on full class error = %v, want ErrClassFull", err)
+	}
+
+	waitlist, err := repo.FindWaitlist(ctx, classID)
+	if err != nil {
+		t.Fatalf("FindWaitlist() error = %v", err)
+	}
+	if len(waitlist) != 2 || waitlist[0].StudentID != second || waitlist[0].Position != 1 {
+		t.Fatalf("FindWaitlist() = %+v, want second then third", waitlist)
+	}
+
+	//! Place libérée : le premier de la liste est promu
+	if err := repo.Delete(ctx, first, classID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if exists, _ := repo.Exists(ctx, second, classID); !exists {
+		t.Error("Delete() should promote the first waitlisted student")
+	}
+	waitlist, _ = repo.FindWaitlist(ctx, classID)
+	if len(waitlist) != 1 || waitlist[0].StudentID != third || waitlist[0].Position != 1 {
+		t.Errorf("FindWaitlist() after promotion = %+v, want third only", waitlist)
+	}
+
+	//! DeleteByStudent libère aussi la place
+	if err := repo.DeleteByStudent(ctx, second); err != nil {
+		t.Fatalf("DeleteByStudent() error = %v", err)
+	}
+	if exists, _ := repo.Exists(ctx, third, classID); !exists {
+		t.Error("DeleteByStudent() should promote the next waitlisted student")
+	}
+
+	//! Un élève entre-temps inscrit ailleurs la même année est sauté au profit du suivant
+	otherClassID := testutil.SeedTestClass(t, db, schoolID, "6ème B", "6ème", "B", "2025-2026")
+	enrolled := testutil.SeedTestUser(t, db, schoolID, "enrolled@test.mg", domain.RoleStudent)
+	fourth := testutil.SeedTestUser(t, db, schoolID, "fourth@test.mg", domain.RoleStudent)
+	for _, studentID := range []int{enrolled, fourth} {
+		if waitlisted, err := repo.EnrollOrWaitlist(ctx, studentID, classID); err != nil || !waitlisted {
+			t.Fatalf("EnrollOrWaitlist(%d) = %v, %v; want waitlisted", studentID, waitlisted, err)
+		}
+	}
+	if err := repo.Create(ctx, enrolled, otherClassID); err != nil {
+		t.Fatalf("Create() in other class error = %v", err)
+	}
+	if err := repo.Delete(ctx, third, classID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if exists, _ := repo.Exists(ctx, enrolled, classID); exists {
+		t.Error("Delete() should skip a student already enrolled elsewhere")
+	}
+	if exists, _ := repo.Exists(ctx, fourth, classID); !exists {
+		t.Error("Delete() should promote the next eligible waitlisted student")
+	}
+	if waitlist, _ := repo.FindWaitlist(ctx, classID); len(waitlist) != 0 {
+		t.Errorf("FindWaitlist() after skip = %+v, want empty", waitlist)
+	}
+}
+
+func TestStudentClassRepository_ConfirmSeats(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+	repo := NewStudentClassRepository(db)
+	ctx := context.Background()
+
+	schoolID := testutil.SeedTestSchool(t, db, "Test", "test", "test@school.mg")
+	classID := testutil.SeedTestClass(t, db, schoolID, "6ème A", "6ème", "A", "2025-2026")
+	if _, err := db.Exec(`UPDATE classes SET capacity = 1 WHERE id = $1`, classID); err != nil {
+		t.Fatalf("set capacity: %v", err)
+	}
+	seated := testutil.SeedTestUser(t, db, schoolID, "seated@test.mg", domain.RoleStudent)
+	pending := testutil.SeedTestUser(t, db, schoolID, "pending@test.mg", domain.RoleStudent)
+	testutil.SeedTestStudentClass(t, db, seated, classID)
+	testutil.SeedTestStudentClass(t, db, pending, classID)
+	if _, err := db.Exec(`UPDATE users SET status = 'pending' WHERE id = $1`, pending); err != nil {
+		t.Fatalf("set pending: %v", err)
+	}
+
+	//! L'élève déjà en place garde la sienne
+	waitlisted, err := repo.ConfirmSeats(ctx, seated)
+	if err != nil || len(waitlisted) != 0 {
+		t.Fatalf("ConfirmSeats(seated) = %v, %v; want no waitlist", waitlisted, err)
+	}
+
+	//! Le second (approuvé après coup) passe en liste d'attente
+	if _, err := db.Exec(`UPDATE users SET status = 'approved' WHERE id = $1`, pending); err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	waitlisted, err = repo.ConfirmSeats(ctx, pending)
+	if err != nil {
+		t.Fatalf("ConfirmSeats(pending) error = %v", err)
+	}
+	if len(waitlisted) != 1 || waitlisted[0] != classID {
+		t.Errorf("ConfirmSeats(pending) = %v, want [%d]", waitlisted, classID)
+	}
+	if exists, _ := repo.Exists(ctx, pending, classID); exists {
+		t.Error("ConfirmSeats() should release the overflowing enrollment")
+	}
+}
+
+func TestStudentClassRepository_EnrollmentHistory(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+	repo := NewStudentClassRepository(db)
+	ctx := context.Background()
+
+	schoolID := testutil.SeedTestSchool(t, db, "Test", "test", "test@school.mg")
+	studentID := testutil.SeedTestUser(t, db, schoolID, "student@test.mg", domain.RoleStudent)
+	classA := testutil.SeedTestClass(t, db, schoolID, "6ème A", "6ème", "A", "2025-2026")
+	classB := testutil.SeedTestClass(t, db, schoolID, "6ème B", "6ème", "B", "2025-2026")
+
+	if err := repo.Create(ctx, studentID, classA); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	//! Une seule classe active par année
+	if err := repo.Create(ctx, studentID, classB); err == nil {
+		t.Fatal("Create() should reject a second active class in the same year")
+	}
+
+	//! Transfert A -> B
+	ended, err := repo.EndEnrollments(ctx, studentID, "2025-2026", "Changement de section")
+	if err != nil {
+		t.Fatalf("EndEnrollments() error = %v", err)
+	}
+	if len(ended) != 1 || ended[0] != classA {
+		t.Errorf("EndEnrollments() = %v, want [%d]", ended, classA)
+	}
+	if err := repo.Create(ctx, studentID, classB); err != nil {
+		t.Fatalf("Create() after transfer error = %v", err)
+	}
+
+	classes, _ := repo.FindByStudent(ctx, studentID)
+	if len(classes) != 1 || classes[0].ID != classB {
+		t.Errorf("FindByStudent() = %v, want only the new class", classes)
+	}
+
+	history, err := repo.FindHistory(ctx, studentID)
+	if err != nil {
+		t.Fatalf("FindHistory() error = %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("FindHistory() returned %d enrollments, want 2", len(history))
+	}
+	if !history[0].IsActive || history[0].ClassID != classB {
+		t.Errorf("history[0] = %+v, want active enrollment in class B", history[0])
+	}
+	if history[1].IsActive || history[1].EndedAt == nil || history[1].EndReason != "Changement de section" {
+		t.Errorf("history[1] = %+v, want closed enrollment with reason", history[1])
+	}
 }
@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"educnet/internal/db"
+	"educnet/internal/domain"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// ! StudentNumberRepository matricules : format de l'école, compteurs et attribution
+// ! (le matricule lui-même est stocké dans student_profiles, cf. ProfileRepository)
+type StudentNumberRepository interface {
+	FindFormat(ctx context.Context, schoolID int) (*domain.StudentNumberFormat, error)
+	SaveFormat(ctx context.Context, format *domain.StudentNumberFormat) error
+
+	//! NextSequence incrémente atomiquement le compteur de la portée (1 au premier appel)
+	NextSequence(ctx context.Context, schoolID int, scope string) (int, error)
+	//! Assign ErrStudentNumberTaken si le matricule est déjà pris dans l'école
+	Assign(ctx context.Context, schoolID, userID int, number string) error
+
+	FindNumber(ctx context.Context, userID int) (string, error)
+	FindStudentID(ctx context.Context, schoolID int, number string) (int, error)
+	//! FindBySchool matricules de l'école par élève (exports, bulletins, listes)
+	FindBySchool(ctx context.Context, schoolID int) (map[int]string, error)
+}
+
+type studentNumberRepository struct {
+	db *sql.DB
+}
+
+func NewStudentNumberRepository(db *sql.DB) StudentNumberRepository {
+	return &studentNumberRepository{db: db}
+}
+
+// ! FindFormat format par défaut si l'école n'en a jamais défini
+func (r *studentNumberRepository) FindFormat(ctx context.Context, schoolID int) (*domain.StudentNumberFormat, error) {
+	format := &domain.StudentNumberFormat{SchoolID: schoolID}
+	var updatedBy sql.NullInt64
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT format,updated_by,updated_at FROM student_number_formats WHERE school_id = $1`, schoolID,
+	).Scan(&format.Format, &updatedBy, &format.UpdatedAt)
+	if err == sql.ErrNoRows {
+		format.Format = domain.DefaultStudentNumberFormat
+		return format, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find student number format: %w", err)
+	}
+	format.UpdatedBy = int(updatedBy.Int64)
+	return format, nil
+}
+
+func (r *studentNumberRepository) SaveFormat(ctx context.Context, format *domain.StudentNumberFormat) error {
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO student_number_formats (school_id,format,updated_by)
+         VALUES ($1,$2,NULLIF($3,0))
+         ON CONFLICT (school_id) DO UPDATE SET
+             format=EXCLUDED.format, updated_by=EXCLUDED.updated_by, updated_at=NOW()
+         RETURNING updated_at`,
+		format.SchoolID, format.Format, format.UpdatedBy,
+	).Scan(&format.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("save student number format: %w", err)
+	}
+	return nil
+}
+
+// ! NextSequence la ligne du compteur reste verrouillée jusqu'à la fin de la transaction :
+// ! deux validations concurrentes dans la même portée ne peuvent pas obtenir la même valeur
+func (r *studentNumberRepository) NextSequence(ctx context.Context, schoolID int, scope string) (int, error) {
+	var value int
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO student_number_sequences (school_id,scope,last_value) VALUES ($1,$2,1)
+         ON CONFLICT (school_id,scope) DO UPDATE SET
+             last_value = student_number_sequences.last_value + 1, updated_at = NOW()
+         RETURNING last_value`,
+		schoolID, scope,
+	).Scan(&value)
+	if err != nil {
+		return 0, fmt.Errorf("next student number sequence: %w", err)
+	}
+	return value, nil
+}
+
+// ! Assign savepoint : un conflit d'unicité n'annule pas la transaction appelante
+func (r *studentNumberRepository) Assign(ctx context.Context, schoolID, userID int, number string) error {
+	return db.RunInTx(ctx, r.db, func(ctx context.Context) error {
+		_, err := db.Conn(ctx, r.db).ExecContext(ctx,
+			`INSERT INTO student_profiles (user_id,school_id,student_number) VALUES ($1,$2,$3)
+             ON CONFLICT (user_id) DO UPDATE SET student_number=EXCLUDED.student_number, updated_at=NOW()`,
+			userID, schoolID, number)
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return domain.ErrStudentNumberTaken
+		}
+		if err != nil {
+			return fmt.Errorf("assign student number: %w", err)
+		}
+		return nil
+	})
+}
+
+// ! FindNumber "" si l'élève n'a pas de matricule
+func (r *studentNumberRepository) FindNumber(ctx context.Context, userID int) (string, error) {
+	var number sql.NullString
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT student_number FROM student_profiles WHERE user_id = $1`, userID,
+	).Scan(&number)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("find student number: %w", err)
+	}
+	return nullString(number), nil
+}
+
+// ! FindStudentID recherche exacte, insensible à la casse
+func (r *studentNumberRepository) FindStudentID(ctx context.Context, schoolID int, number string) (int, error) {
+	var userID int
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT user_id FROM student_profiles WHERE school_id = $1 AND UPPER(student_number) = UPPER($2)`,
+		schoolID, number,
+	).Scan(&userID)
+	if err == sql.ErrNoRows {
+		return 0, domain.ErrStudentNumberNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("find student by number: %w", err)
+	}
+	return userID, nil
+}
+
+func (r *studentNumberRepository) FindBySchool(ctx context.Context, schoolID int) (map[int]string, error) {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx,
+		`SELECT user_id,student_number FROM student_profiles WHERE school_id = $1 AND student_number IS NOT NULL`,
+		schoolID)
+	if err != nil {
+		return nil, fmt.Errorf("find student numbers: %w", err)
+	}
+	defer rows.Close()
+
+	numbers := map[int]string{}
+	for rows.Next() {
+		var userID int
+		var number string
+		if err := rows.Scan(&userID, &number); err != nil {
+			return nil, scanError(err, "scan student number")
+		}
+		numbers[userID] = number
+	}
+	return numbers, rows.Err()
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"educnet/internal/db"
+	"educnet/internal/domain"
+	"educnet/internal/testutil"
+)
+
+func TestStudentNumberRepository_Format(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	database := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, database)
+
+	repo := NewStudentNumberRepository(database)
+	ctx := context.Background()
+	schoolID := testutil.SeedTestSchool(t, database, "Test School", "test-school", "test@school.mg")
+
+	format, err := repo.FindFormat(ctx, schoolID)
+	if err != nil || format.Format != domain.DefaultStudentNumberFormat {
+		t.Fatalf("FindFormat() before save = %+v, %v, want default format", format, err)
+	}
+
+	format.Format = "{YEAR}-{LEVEL}-{SEQ:4}"
+	if err := repo.SaveFormat(ctx, format); err != nil {
+		t.Fatalf("SaveFormat() error = %v", err)
+	}
+	found, err := repo.FindFormat(ctx, schoolID)
+	if err != nil || found.Format != "{YEAR}-{LEVEL}-{SEQ:4}" {
+		t.Errorf("FindFormat() = %+v, %v", found, err)
+	}
+}
+
+func TestStudentNumberRepository_ConcurrentSequence(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	database := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, database)
+
+	repo := NewStudentNumberRepository(database)
+	ctx := context.Background()
+	schoolID := testutil.SeedTestSchool(t, database, "Test School", "test-school", "test@school.mg")
+
+	//! Chaque goroutine dans sa propre transaction, comme des validations simultanées
+	const workers = 20
+	values := make(chan int, workers)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := db.RunInTx(ctx, database, func(ctx context.Context) error {
+				value, err := repo.NextSequence(ctx, schoolID, "2026-6E-{SEQ}")
+				values <- value
+				return err
+			})
+			if err != nil {
+				t.Errorf("NextSequence() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	close(values)
+
+	seen := map[int]bool{}
+	for value := range values {
+		if seen[value] || value < 1 || value > workers {
+			t.Errorf("NextSequence() returned %d twice or out of range", value)
+		}
+		seen[value] = true
+	}
+
+	//! Une autre portée a son propre compteur
+	if value, err := repo.NextSequence(ctx, schoolID, "2026-5E-{SEQ}"); err != nil || value != 1 {
+		t.Errorf("NextSequence(other scope) = %d, %v, want 1", value, err)
+	}
+}
+
+func TestStudentNumberRepository_AssignAndLookup(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	database := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, database)
+
+	repo := NewStudentNumberRepository(database)
+	ctx := context.Background()
+	schoolID := testutil.SeedTestSchool(t, database, "Test School", "test-school", "test@school.mg")
+	studentID := testutil.SeedTestUser(t, database, schoolID, "eleve@test.mg", domain.RoleStudent)
+	otherID := testutil.SeedTestUser(t, database, schoolID, "eleve2@test.mg", domain.RoleStudent)
+
+	if number, err := repo.FindNumber(ctx, studentID); err != nil || number != "" {
+		t.Fatalf("FindNumber() before assign = %q, %v, want empty", number, err)
+	}
+	if err := repo.Assign(ctx, schoolID, studentID, "2026-6E-0001"); err != nil {
+		t.Fatalf("Assign() error = %v", err)
+	}
+	if err := repo.Assign(ctx, schoolID, otherID, "2026-6E-0001"); err != domain.ErrStudentNumberTaken {
+		t.Errorf("Assign() duplicate error = %v, want %v", err, domain.ErrStudentNumberTaken)
+	}
+
+	if id, err := repo.FindStudentID(ctx, schoolID, "2026-6e-0001"); err != nil || id != studentID {
+		t.Errorf("FindStudentID() = %d, %v, want %d", id, err, studentID)
+	}
+	if _, err := repo.FindStudentID(ctx, schoolID, "2026-6E-9999"); err != domain.ErrStudentNumberNotFound {
+		t.Errorf("FindStudentID(unknown) error = %v, want %v", err, domain.ErrStudentNumberNotFound)
+	}
+
+	numbers, err := repo.FindBySchool(ctx, schoolID)
+	if err != nil || len(numbers) != 1 || numbers[studentID] != "2026-6E-0001" {
+		t.Errorf("FindBySchool() = %v, %v", numbers, err)
+	}
+
+	//! Recherche par début de matricule dans la liste des utilisateurs
+	users, err := NewUserRepository(database).FindBySchool(ctx, schoolID, map[string]string{"student_number": "2026-6e"})
+	if err != nil || len(users) != 1 || users[0].ID != studentID {
+		t.Errorf("FindBySchool(student_number) = %v, %v, want only student %d", users, err, studentID)
+	}
+}
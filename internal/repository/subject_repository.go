@@ -1,20 +1,22 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"educnet/internal/db"
 	"educnet/internal/domain"
 	"errors"
 	"fmt"
 )
 
 type SubjectRepository interface {
-	Create(subject *domain.Subject) error
-	FindByID(id int) (*domain.Subject, error)
-	FindBySchoolID(schoolID int) ([]*domain.Subject, error)
-	FindBySchoolAndCode(schoolID int, code string) (*domain.Subject, error)
-	Update(subject *domain.Subject) error
-	Delete(id int) error
-	ExistsByCode(schoolID int, code string, excludeID int) (bool, error)
+	Create(ctx context.Context, subject *domain.Subject) error
+	FindByID(ctx context.Context, id int) (*domain.Subject, error)
+	FindBySchoolID(ctx context.Context, schoolID int) ([]*domain.Subject, error)
+	FindBySchoolAndCode(ctx context.Context, schoolID int, code string) (*domain.Subject, error)
+	Update(ctx context.Context, subject *domain.Subject) error
+	Delete(ctx context.Context, id int) error
+	ExistsByCode(ctx context.Context, schoolID int, code string, excludeID int) (bool, error)
 
 	//! HELPER
 	ScanSubjectRow(row domainScanner, subjectObj *domain.Subject) error
@@ -53,8 +55,8 @@ func (r *subjectRepository) ScanSubjectRow(row domainScanner, subjectObj *domain
 }
 
 // ! ==================== METHODS PRO ====================
-func (r *subjectRepository) Create(subject *domain.Subject) error {
-	err := r.db.QueryRow(
+func (r *subjectRepository) Create(ctx context.Context, subject *domain.Subject) error {
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
 		`INSERT INTO subjects (school_id, name, code, description)
          VALUES ($1,$2,$3,$4) RETURNING id, created_at, updated_at`,
 		subject.SchoolID, subject.Name, subject.Code, subject.Description,
@@ -65,13 +67,13 @@ func (r *subjectRepository) Create(subject *domain.Subject) error {
 	return nil
 }
 
-func (r *subjectRepository) FindByID(id int) (*domain.Subject, error) {
+func (r *subjectRepository) FindByID(ctx context.Context, id int) (*domain.Subject, error) {
 	subject := &domain.Subject{}
 	query := `
         SELECT id, school_id, name, code, description, created_at, updated_at
         FROM subjects WHERE id = $1`
 
-	err := r.db.QueryRow(query, id).Scan(
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx, query, id).Scan(
 		&subject.ID,
 		&subject.SchoolID,
 		&subject.Name,
@@ -89,8 +91,8 @@ func (r *subjectRepository) FindByID(id int) (*domain.Subject, error) {
 	return subject, nil
 }
 
-func (r *subjectRepository) FindBySchoolID(schoolID int) ([]*domain.Subject, error) {
-	rows, err := r.db.Query(
+func (r *subjectRepository) FindBySchoolID(ctx context.Context, schoolID int) ([]*domain.Subject, error) {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx,
 		`SELECT id,school_id,name,code,description,created_at,updated_at 
          FROM subjects WHERE school_id=$1 ORDER BY name`, schoolID)
 	if err != nil {
@@ -109,13 +111,13 @@ func (r *subjectRepository) FindBySchoolID(schoolID int) ([]*domain.Subject, err
 	return subjects, rows.Err()
 }
 
-func (r *subjectRepository) FindBySchoolAndCode(schoolID int, code string) (*domain.Subject, error) {
+func (r *subjectRepository) FindBySchoolAndCode(ctx context.Context, schoolID int, code string) (*domain.Subject, error) {
 	subject := &domain.Subject{}
 	query := `
         SELECT id, school_id, name, code, description, created_at, updated_at
         FROM subjects WHERE school_id=$1 AND code=$2`
 
-	err := r.db.QueryRow(query, schoolID, code).Scan(
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx, query, schoolID, code).Scan(
 		&subject.ID,
 		&subject.SchoolID,
 		&subject.Name,
@@ -133,8 +135,8 @@ func (r *subjectRepository) FindBySchoolAndCode(schoolID int, code string) (*dom
 	return subject, nil
 }
 
-func (r *subjectRepository) Update(subject *domain.Subject) error {
-	result, err := r.db.Exec(
+func (r *subjectRepository) Update(ctx context.Context, subject *domain.Subject) error {
+	result, err := db.Conn(ctx, r.db).ExecContext(ctx,
 		`UPDATE subjects SET name=$1,code=$2,description=$3,updated_at=NOW() 
          WHERE id=$4`,
 		subject.Name, subject.Code, subject.Description, subject.ID)
@@ -149,8 +151,8 @@ func (r *subjectRepository) Update(subject *domain.Subject) error {
 	return nil
 }
 
-func (r *subjectRepository) Delete(id int) error {
-	result, err := r.db.Exec(`DELETE FROM subjects WHERE id=$1`, id)
+func (r *subjectRepository) Delete(ctx context.Context, id int) error {
+	result, err := db.Conn(ctx, r.db).ExecContext(ctx, `DELETE FROM subjects WHERE id=$1`, id)
 	if err != nil {
 		return fmt.Errorf("delete subject: %w", err)
 	}
@@ -162,9 +164,9 @@ func (r *subjectRepository) Delete(id int) error {
 	return nil
 }
 
-func (r *subjectRepository) ExistsByCode(schoolID int, code string, excludeID int) (bool, error) {
+func (r *subjectRepository) ExistsByCode(ctx context.Context, schoolID int, code string, excludeID int) (bool, error) {
 	var exists bool
-	err := r.db.QueryRow(
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
 		`SELECT EXISTS(SELECT 1 FROM subjects WHERE school_id=$1 AND code=$2 AND id!=$3)`,
 		schoolID, code, excludeID).Scan(&exists)
 	return exists, err
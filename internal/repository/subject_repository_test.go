@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -17,6 +18,7 @@ func TestSubjectRepository_Create(t *testing.T) {
 	defer testutil.CleanupTestDB(t, db)
 
 	repo := NewSubjectRepository(db)
+	ctx := context.Background()
 
 	// Seed school
 	schoolID := testutil.SeedTestSchool(t, db, "Test School", "test", "test@school.mg")
@@ -28,7 +30,7 @@ func TestSubjectRepository_Create(t *testing.T) {
 		Description: "Cours de mathématiques",
 	}
 
-	err := repo.Create(subject)
+	err := repo.Create(ctx, subject)
 	if err != nil {
 		t.Fatalf("Create() error = %v", err)
 	}
@@ -47,13 +49,14 @@ func TestSubjectRepository_FindByID(t *testing.T) {
 	defer testutil.CleanupTestDB(t, db)
 
 	repo := NewSubjectRepository(db)
+	ctx := context.Background()
 
 	// Seed
 	schoolID := testutil.SeedTestSchool(t, db, "Test", "test", "test@school.mg")
 	subjectID := testutil.SeedTestSubject(t, db, schoolID, "Maths", "MATH", "Mathématiques")
 
 	// Test
-	subject, err := repo.FindByID(subjectID)
+	subject, err := repo.FindByID(ctx, subjectID)
 	if err != nil {
 		t.Fatalf("FindByID() error = %v", err)
 	}
@@ -75,8 +78,9 @@ func TestSubjectRepository_FindByID_NotFound(t *testing.T) {
 	defer testutil.CleanupTestDB(t, db)
 
 	repo := NewSubjectRepository(db)
+	ctx := context.Background()
 
-	_, err := repo.FindByID(99999)
+	_, err := repo.FindByID(ctx, 99999)
 	if !errors.Is(err, domain.ErrSubjectNotFound) {
 		t.Errorf("FindByID() error = %v, want ErrSubjectNotFound", err)
 	}
@@ -91,6 +95,7 @@ func TestSubjectRepository_FindBySchoolID(t *testing.T) {
 	defer testutil.CleanupTestDB(t, db)
 
 	repo := NewSubjectRepository(db)
+	ctx := context.Background()
 
 	// Seed
 	schoolID := testutil.SeedTestSchool(t, db, "Test", "test", "test@school.mg")
@@ -98,7 +103,7 @@ func TestSubjectRepository_FindBySchoolID(t *testing.T) {
 	testutil.SeedTestSubject(t, db, schoolID, "Français", "FR", "Français")
 
 	// Test
-	subjects, err := repo.FindBySchoolID(schoolID)
+	subjects, err := repo.FindBySchoolID(ctx, schoolID)
 	if err != nil {
 		t.Fatalf("FindBySchoolID() error = %v", err)
 	}
@@ -117,13 +122,14 @@ func TestSubjectRepository_FindBySchoolAndCode(t *testing.T) {
 	defer testutil.CleanupTestDB(t, db)
 
 	repo := NewSubjectRepository(db)
+	ctx := context.Background()
 
 	// Seed
 	schoolID := testutil.SeedTestSchool(t, db, "Test", "test", "test@school.mg")
 	testutil.SeedTestSubject(t, db, schoolID, "Maths", "MATH", "Mathématiques")
 
 	// Test
-	subject, err := repo.FindBySchoolAndCode(schoolID, "MATH")
+	subject, err := repo.FindBySchoolAndCode(ctx, schoolID, "MATH")
 	if err != nil {
 		t.Fatalf("FindBySchoolAndCode() error = %v", err)
 	}
@@ -142,9 +148,10 @@ func TestSubjectRepository_FindBySchoolAndCode_NotFound(t *testing.T) {
 	defer testutil.CleanupTestDB(t, db)
 
 	repo := NewSubjectRepository(db)
+	ctx := context.Background()
 	schoolID := testutil.SeedTestSchool(t, db, "Test", "test", "test@school.mg")
 
-	_, err := repo.FindBySchoolAndCode(schoolID, "PHYS")
+	_, err := repo.FindBySchoolAndCode(ctx, schoolID, "PHYS")
 	if !errors.Is(err, domain.ErrSubjectNotFound) {
 		t.Errorf("FindBySchoolAndCode() error = %v, want ErrSubjectNotFound", err)
 	}
@@ -159,13 +166,14 @@ func TestSubjectRepository_Update(t *testing.T) {
 	defer testutil.CleanupTestDB(t, db)
 
 	repo := NewSubjectRepository(db)
+	ctx := context.Background()
 
 	// Seed
 	schoolID := testutil.SeedTestSchool(t, db, "Test", "test", "test@school.mg")
 	subjectID := testutil.SeedTestSubject(t, db, schoolID, "Maths", "MATH", "Mathématiques")
 
 	// Get subject
-	subject, err := repo.FindByID(subjectID)
+	subject, err := repo.FindByID(ctx, subjectID)
 	if err != nil {
 		t.Fatalf("FindByID() error = %v", err)
 	}
@@ -174,13 +182,13 @@ func TestSubjectRepository_Update(t *testing.T) {
 	subject.Name = "Algèbre"
 	subject.Code = "ALG"
 
-	err = repo.Update(subject)
+	err = repo.Update(ctx, subject)
 	if err != nil {
 		t.Fatalf("Update() error = %v", err)
 	}
 
 	// Verify
-	updated, err := repo.FindByID(subjectID)
+	updated, err := repo.FindByID(ctx, subjectID)
 	if err != nil {
 		t.Fatalf("FindByID() after update error = %v", err)
 	}
@@ -202,17 +210,18 @@ func TestSubjectRepository_Delete(t *testing.T) {
 	defer testutil.CleanupTestDB(t, db)
 
 	repo := NewSubjectRepository(db)
+	ctx := context.Background()
 
 	// Seed
 	schoolID := testutil.SeedTestSchool(t, db, "Test", "test", "test@school.mg")
 	subjectID := testutil.SeedTestSubject(t, db, schoolID, "Maths", "MATH", "Mathématiques")
 
-	err := repo.Delete(subjectID)
+	err := repo.Delete(ctx, subjectID)
 	if err != nil {
 		t.Fatalf("Delete() error = %v", err)
 	}
 
-	_, err = repo.FindByID(subjectID)
+	_, err = repo.FindByID(ctx, subjectID)
 	if !errors.Is(err, domain.ErrSubjectNotFound) {
 		t.Errorf("Delete() subject still exists, want ErrSubjectNotFound")
 	}
@@ -228,6 +237,7 @@ func TestSubjectRepository_ExistsByCode(t *testing.T) {
 	defer testutil.CleanupTestDB(t, db)
 
 	repo := NewSubjectRepository(db)
+	ctx := context.Background()
 
 	// Seed
 	schoolID := testutil.SeedTestSchool(t, db, "Test", "test", "test@school.mg")
@@ -265,7 +275,7 @@ func TestSubjectRepository_ExistsByCode(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			exists, err := repo.ExistsByCode(tt.schoolID, tt.code, tt.excludeID)
+			exists, err := repo.ExistsByCode(ctx, tt.schoolID, tt.code, tt.excludeID)
 			if err != nil {
 				t.Fatalf("ExistsByCode() error = %v", err)
 			}
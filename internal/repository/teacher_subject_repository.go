@@ -1,19 +1,22 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"educnet/internal/db"
 	"educnet/internal/domain"
 	"fmt"
 )
 
 type TeacherSubjectRepository interface {
-	Create(teacherID, subjectID int) error
-	Delete(teacherID, subjectID int) error
-	Exists(teacherID, subjectID int) (bool, error)
-	FindByTeacher(teacherID int) ([]*domain.Subject, error)
-	FindBySubject(subjectID int) ([]*domain.User, error)
-	DeleteByTeacher(teacherID int) error
-	DeleteBySubject(subjectID int) error
+	Create(ctx context.Context, teacherID, subjectID int) error
+	Delete(ctx context.Context, teacherID, subjectID int) error
+	Exists(ctx context.Context, teacherID, subjectID int) (bool, error)
+	FindByTeacher(ctx context.Context, teacherID int) ([]*domain.Subject, error)
+	FindBySubject(ctx context.Context, subjectID int) ([]*domain.User, error)
+	EachBySchool(ctx context.Context, schoolID int, fn func(subject *domain.Subject, teacher *domain.User) error) error
+	DeleteByTeacher(ctx context.Context, teacherID int) error
+	DeleteBySubject(ctx context.Context, subjectID int) error
 }
 
 type teacherSubjectRepository struct {
@@ -39,8 +42,8 @@ func scanTeacherSubjectRow(rows *sql.Rows) (*domain.Subject, error) {
 	return &subject, err
 }
 
-func (r *teacherSubjectRepository) Create(teacherID, subjectID int) error {
-	_, err := r.db.Exec(
+func (r *teacherSubjectRepository) Create(ctx context.Context, teacherID, subjectID int) error {
+	_, err := db.Conn(ctx, r.db).ExecContext(ctx,
 		`INSERT INTO teacher_subjects (teacher_id, subject_id) VALUES ($1, $2)`,
 		teacherID, subjectID,
 	)
@@ -50,8 +53,8 @@ func (r *teacherSubjectRepository) Create(teacherID, subjectID int) error {
 	return nil
 }
 
-func (r *teacherSubjectRepository) Delete(teacherID, subjectID int) error {
-	result, err := r.db.Exec(
+func (r *teacherSubjectRepository) Delete(ctx context.Context, teacherID, subjectID int) error {
+	result, err := db.Conn(ctx, r.db).ExecContext(ctx,
 		`DELETE FROM teacher_subjects WHERE teacher_id=$1 AND subject_id=$2`,
 		teacherID, subjectID)
 	if err != nil {
@@ -65,16 +68,16 @@ func (r *teacherSubjectRepository) Delete(teacherID, subjectID int) error {
 	return nil
 }
 
-func (r *teacherSubjectRepository) Exists(teacherID, subjectID int) (bool, error) {
+func (r *teacherSubjectRepository) Exists(ctx context.Context, teacherID, subjectID int) (bool, error) {
 	var exists bool
-	err := r.db.QueryRow(
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
 		`SELECT EXISTS(SELECT 1 FROM teacher_subjects WHERE teacher_id=$1 AND subject_id=$2)`,
 		teacherID, subjectID).Scan(&exists)
 	return exists, err
 }
 
-func (r *teacherSubjectRepository) FindByTeacher(teacherID int) ([]*domain.Subject, error) {
-	rows, err := r.db.Query(`
+func (r *teacherSubjectRepository) FindByTeacher(ctx context.Context, teacherID int) ([]*domain.Subject, error) {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx, `
         SELECT s.id, s.name, s.code, s.description, s.created_at, s.updated_at
 		FROM teacher_subjects ts
 		JOIN subjects s ON ts.subject_id = s.id
@@ -97,10 +100,10 @@ func (r *teacherSubjectRepository) FindByTeacher(teacherID int) ([]*domain.Subje
 	return subjects, rows.Err()
 }
 
-func (r *teacherSubjectRepository) FindBySubject(subjectID int) ([]*domain.User, error) {
-	rows, err := r.db.Query(`
+func (r *teacherSubjectRepository) FindBySubject(ctx context.Context, subjectID int) ([]*domain.User, error) {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx, `
         SELECT u.id, u.school_id, u.email, u.password_hash, u.first_name, u.last_name,
-            u.phone, u.role, u.avatar_url, u.status, u.created_at, u.updated_at
+            u.phone, u.role, u.avatar_url, u.status, u.must_change_password, u.created_at, u.updated_at
         FROM teacher_subjects ts
         JOIN users u ON ts.teacher_id = u.id
         WHERE ts.subject_id = $1 AND u.role = $2
@@ -122,16 +125,59 @@ func (r *teacherSubjectRepository) FindBySubject(subjectID int) ([]*domain.User,
 	return teachers, rows.Err()
 }
 
-func (r *teacherSubjectRepository) DeleteByTeacher(teacherID int) error {
-	_, err := r.db.Exec(`DELETE FROM teacher_subjects WHERE teacher_id = $1`, teacherID)
+// ! EachBySchool une ligne par couple (matière, enseignant), teacher nil pour une
+// ! matière sans enseignant ; fn est appelée ligne par ligne (exports volumineux)
+func (r *teacherSubjectRepository) EachBySchool(ctx context.Context, schoolID int, fn func(subject *domain.Subject, teacher *domain.User) error) error {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx, `
+        SELECT s.id, s.code, s.name, u.id, u.first_name, u.last_name, u.email, u.status
+        FROM subjects s
+        LEFT JOIN teacher_subjects ts ON ts.subject_id = s.id
+        LEFT JOIN users u ON u.id = ts.teacher_id AND u.role = $2
+        WHERE s.school_id = $1
+        ORDER BY s.name, u.last_name, u.first_name`, schoolID, domain.RoleTeacher)
+	if err != nil {
+		return fmt.Errorf("find school teacher subjects: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		subject := &domain.Subject{SchoolID: schoolID}
+		var teacherID sql.NullInt64
+		var firstName, lastName, email, status sql.NullString
+		if err := rows.Scan(&subject.ID, &subject.Code, &subject.Name,
+			&teacherID, &firstName, &lastName, &email, &status); err != nil {
+			return scanError(err, "scan teacher subject")
+		}
+
+		var teacher *domain.User
+		if teacherID.Valid {
+			teacher = &domain.User{
+				ID:        int(teacherID.Int64),
+				SchoolID:  schoolID,
+				FirstName: firstName.String,
+				LastName:  lastName.String,
+				Email:     email.String,
+				Role:      domain.RoleTeacher,
+				Status:    status.String,
+			}
+		}
+		if err := fn(subject, teacher); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (r *teacherSubjectRepository) DeleteByTeacher(ctx context.Context, teacherID int) error {
+	_, err := db.Conn(ctx, r.db).ExecContext(ctx, `DELETE FROM teacher_subjects WHERE teacher_id = $1`, teacherID)
 	if err != nil {
 		return fmt.Errorf("delete teacher subjects: %w", err)
 	}
 	return nil
 }
 
-func (r *teacherSubjectRepository) DeleteBySubject(subjectID int) error {
-	_, err := r.db.Exec(`DELETE FROM teacher_subjects WHERE subject_id = $1`, subjectID)
+func (r *teacherSubjectRepository) DeleteBySubject(ctx context.Context, subjectID int) error {
+	_, err := db.Conn(ctx, r.db).ExecContext(ctx, `DELETE FROM teacher_subjects WHERE subject_id = $1`, subjectID)
 	if err != nil {
 		return fmt.Errorf("delete subject teachers: %w", err)
 	}
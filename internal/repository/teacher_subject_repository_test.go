@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"educnet/internal/domain"
 	"educnet/internal/testutil"
 	"testing"
@@ -14,6 +15,7 @@ func TestTeacherSubjectRepository_Create(t *testing.T) {
 	db := testutil.SetupTestDB(t)
 	defer testutil.CleanupTestDB(t, db)
 	repo := NewTeacherSubjectRepository(db)
+	ctx := context.Background()
 
 	//! Seed prerequisites
 	schoolID := testutil.SeedTestSchool(t, db, "Test School", "test", "test@school.mg")
@@ -21,13 +23,13 @@ func TestTeacherSubjectRepository_Create(t *testing.T) {
 	subjectID := testutil.SeedTestSubject(t, db, schoolID, "Mathématiques", "MATH", "Test maths subject")
 
 	//! Test Create
-	err := repo.Create(teacherID, subjectID)
+	err := repo.Create(ctx, teacherID, subjectID)
 	if err != nil {
 		t.Fatalf("Create() error = %v", err)
 	}
 
 	//! Verify exists
-	exists, err := repo.Exists(teacherID, subjectID)
+	exists, err := repo.Exists(ctx, teacherID, subjectID)
 	if err != nil {
 		t.Fatalf("Exists() error = %v", err)
 	}
@@ -44,22 +46,23 @@ func TestTeacherSubjectRepository_Delete(t *testing.T) {
 	db := testutil.SetupTestDB(t)
 	defer testutil.CleanupTestDB(t, db)
 	repo := NewTeacherSubjectRepository(db)
+	ctx := context.Background()
 
 	schoolID := testutil.SeedTestSchool(t, db, "Test", "test", "test@school.mg")
 	teacherID := testutil.SeedTestUser(t, db, schoolID, "teacher@test.mg", domain.RoleTeacher)
 	subjectID := testutil.SeedTestSubject(t, db, schoolID, "Math", "MATH", "Test maths subject")
 
 	//! Create first
-	repo.Create(teacherID, subjectID)
+	repo.Create(ctx, teacherID, subjectID)
 
 	//! Test Delete
-	err := repo.Delete(teacherID, subjectID)
+	err := repo.Delete(ctx, teacherID, subjectID)
 	if err != nil {
 		t.Fatalf("Delete() error = %v", err)
 	}
 
 	//! Verify deleted
-	exists, _ := repo.Exists(teacherID, subjectID)
+	exists, _ := repo.Exists(ctx, teacherID, subjectID)
 	if exists {
 		t.Error("Delete() should remove association")
 	}
@@ -73,6 +76,7 @@ func TestTeacherSubjectRepository_FindByTeacher(t *testing.T) {
 	db := testutil.SetupTestDB(t)
 	defer testutil.CleanupTestDB(t, db)
 	repo := NewTeacherSubjectRepository(db)
+	ctx := context.Background()
 
 	schoolID := testutil.SeedTestSchool(t, db, "Test", "test", "test@school.mg")
 	teacherID := testutil.SeedTestUser(t, db, schoolID, "teacher@test.mg", domain.RoleTeacher)
@@ -80,11 +84,11 @@ func TestTeacherSubjectRepository_FindByTeacher(t *testing.T) {
 	subject2ID := testutil.SeedTestSubject(t, db, schoolID, "Français", "FR", "Test frs subject")
 
 	//! Assign 2 subjects to teacher
-	repo.Create(teacherID, subject1ID)
-	repo.Create(teacherID, subject2ID)
+	repo.Create(ctx, teacherID, subject1ID)
+	repo.Create(ctx, teacherID, subject2ID)
 
 	//! Test FindByTeacher
-	subjects, err := repo.FindByTeacher(teacherID)
+	subjects, err := repo.FindByTeacher(ctx, teacherID)
 	if err != nil {
 		t.Fatalf("FindByTeacher() error = %v", err)
 	}
@@ -101,6 +105,7 @@ func TestTeacherSubjectRepository_FindBySubject(t *testing.T) {
 	db := testutil.SetupTestDB(t)
 	defer testutil.CleanupTestDB(t, db)
 	repo := NewTeacherSubjectRepository(db)
+	ctx := context.Background()
 
 	schoolID := testutil.SeedTestSchool(t, db, "Test", "test", "test@school.mg")
 	teacher1ID := testutil.SeedTestUser(t, db, schoolID, "teacher1@test.mg", domain.RoleTeacher)
@@ -108,11 +113,11 @@ func TestTeacherSubjectRepository_FindBySubject(t *testing.T) {
 	subjectID := testutil.SeedTestSubject(t, db, schoolID, "Mathématiques", "MATH", "Test maths subject")
 
 	// Assign 2 teachers to subject
-	repo.Create(teacher1ID, subjectID)
-	repo.Create(teacher2ID, subjectID)
+	repo.Create(ctx, teacher1ID, subjectID)
+	repo.Create(ctx, teacher2ID, subjectID)
 
 	// Test FindBySubject
-	teachers, err := repo.FindBySubject(subjectID)
+	teachers, err := repo.FindBySubject(ctx, subjectID)
 	if err != nil {
 		t.Fatalf("FindBySubject() error = %v", err)
 	}
@@ -129,18 +134,19 @@ func TestTeacherSubjectRepository_DeleteByTeacher(t *testing.T) {
 	db := testutil.SetupTestDB(t)
 	defer testutil.CleanupTestDB(t, db)
 	repo := NewTeacherSubjectRepository(db)
+	ctx := context.Background()
 
 	schoolID := testutil.SeedTestSchool(t, db, "Test", "test", "test@school.mg")
 	teacherID := testutil.SeedTestUser(t, db, schoolID, "teacher@test.mg", domain.RoleTeacher)
 	subjectID := testutil.SeedTestSubject(t, db, schoolID, "Math", "MATH", "Test maths subject")
 
-	repo.Create(teacherID, subjectID)
-	err := repo.DeleteByTeacher(teacherID)
+	repo.Create(ctx, teacherID, subjectID)
+	err := repo.DeleteByTeacher(ctx, teacherID)
 	if err != nil {
 		t.Fatalf("DeleteByTeacher() error = %v", err)
 	}
 
-	subjects, _ := repo.FindByTeacher(teacherID)
+	subjects, _ := repo.FindByTeacher(ctx, teacherID)
 	if len(subjects) != 0 {
 		t.Error("DeleteByTeacher() should remove all teacher subjects")
 	}
@@ -154,14 +160,15 @@ func TestTeacherSubjectRepository_Exists(t *testing.T) {
 	db := testutil.SetupTestDB(t)
 	defer testutil.CleanupTestDB(t, db)
 	repo := NewTeacherSubjectRepository(db)
+	ctx := context.Background()
 
 	schoolID := testutil.SeedTestSchool(t, db, "Test", "test", "test@school.mg")
 	teacherID := testutil.SeedTestUser(t, db, schoolID, "teacher@test.mg", domain.RoleTeacher)
 	subjectID := testutil.SeedTestSubject(t, db, schoolID, "Math", "MATH", "Test maths subject")
 
-	repo.Create(teacherID, subjectID)
+	repo.Create(ctx, teacherID, subjectID)
 
-	exists, err := repo.Exists(teacherID, subjectID)
+	exists, err := repo.Exists(ctx, teacherID, subjectID)
 	if err != nil {
 		t.Fatalf("Exists() error = %v", err)
 	}
@@ -169,3 +176,41 @@ func TestTeacherSubjectRepository_Exists(t *testing.T) {
 		t.Error("Exists() = false, want true")
 	}
 }
+
+func TestTeacherSubjectRepository_EachBySchool(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+	repo := NewTeacherSubjectRepository(db)
+	ctx := context.Background()
+
+	schoolID := testutil.SeedTestSchool(t, db, "Test School", "test", "test@school.mg")
+	teacherID := testutil.SeedTestUser(t, db, schoolID, "teacher@test.mg", domain.RoleTeacher)
+	mathID := testutil.SeedTestSubject(t, db, schoolID, "Mathématiques", "MATH", "")
+	testutil.SeedTestSubject(t, db, schoolID, "Physique", "PHY", "")
+	if err := repo.Create(ctx, teacherID, mathID); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	//! Une ligne par matière, enseignant nil pour une matière sans enseignant
+	teachers := map[string]*domain.User{}
+	err := repo.EachBySchool(ctx, schoolID, func(subject *domain.Subject, teacher *domain.User) error {
+		teachers[subject.Code] = teacher
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("EachBySchool() error = %v", err)
+	}
+	if len(teachers) != 2 {
+		t.Fatalf("EachBySchool() = %d rows, want 2", len(teachers))
+	}
+	if teachers["MATH"] == nil || teachers["MATH"].ID != teacherID {
+		t.Errorf("EachBySchool() MATH teacher = %+v, want %d", teachers["MATH"], teacherID)
+	}
+	if teachers["PHY"] != nil {
+		t.Errorf("EachBySchool() PHY teacher = %+v, want nil", teachers["PHY"])
+	}
+}
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"testing"
+
+	"educnet/internal/domain"
+	"educnet/internal/testutil"
+)
+
+func TestTenantIsolation_CrossSchoolReads(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	//! Seed two schools
+	schoolA := testutil.SeedTestSchool(t, db, "School A", "school-a", "a@school.mg")
+	schoolB := testutil.SeedTestSchool(t, db, "School B", "school-b", "b@school.mg")
+	classB := testutil.SeedTestClass(t, db, schoolB, "6ème B", "6ème", "B", "2025-2026")
+	subjectB := testutil.SeedTestSubject(t, db, schoolB, "Math", "MATH", "")
+	userB := testutil.SeedTestUser(t, db, schoolB, "b@user.mg", "teacher")
+
+	//! Request scoped on school A
+	ctx := testutil.BeginTenantTestTx(t, db, schoolA)
+
+	if _, err := NewClassRepository(db).FindByID(ctx, classB); err != domain.ErrClassNotFound {
+		t.Errorf("ClassRepository.FindByID() cross-school error = %v, want %v", err, domain.ErrClassNotFound)
+	}
+	if _, err := NewSubjectRepository(db).FindByID(ctx, subjectB); err != domain.ErrSubjectNotFound {
+		t.Errorf("SubjectRepository.FindByID() cross-school error = %v, want %v", err, domain.ErrSubjectNotFound)
+	}
+	if _, err := NewUserRepository(db).FindByID(ctx, userB); err != domain.ErrUserNotFound {
+		t.Errorf("UserRepository.FindByID() cross-school error = %v, want %v", err, domain.ErrUserNotFound)
+	}
+	if _, err := NewSchoolRepository(db).FindByID(ctx, schoolB); err != domain.ErrSchoolNotFound {
+		t.Errorf("SchoolRepository.FindByID() cross-school error = %v, want %v", err, domain.ErrSchoolNotFound)
+	}
+
+	//! Own school stays visible
+	if _, err := NewSchoolRepository(db).FindByID(ctx, schoolA); err != nil {
+		t.Errorf("SchoolRepository.FindByID() own school error = %v", err)
+	}
+}
+
+func TestTenantIsolation_CrossSchoolWrite(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	schoolA := testutil.SeedTestSchool(t, db, "School A", "school-a", "a@school.mg")
+	schoolB := testutil.SeedTestSchool(t, db, "School B", "school-b", "b@school.mg")
+
+	ctx := testutil.BeginTenantTestTx(t, db, schoolA)
+
+	//! Inserting into another school violates the WITH CHECK policy
+	class := &domain.Class{
+		SchoolID:     schoolB,
+		Name:         "Intrus",
+		Level:        "6ème",
+		Section:      "A",
+		Capacity:     40,
+		AcademicYear: "2025-2026",
+		Status:       domain.ClassStatusActive,
+	}
+	if err := NewClassRepository(db).Create(ctx, class); err == nil {
+		t.Error("ClassRepository.Create() cross-school expected error, got nil")
+	}
+}
+
+func TestTenantIsolation_EmailUniqueAcrossSchools(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	schoolA := testutil.SeedTestSchool(t, db, "School A", "school-a", "a@school.mg")
+	schoolB := testutil.SeedTestSchool(t, db, "School B", "school-b", "b@school.mg")
+	testutil.SeedTestUser(t, db, schoolB, "shared@user.mg", "teacher")
+
+	ctx := testutil.BeginTenantTestTx(t, db, schoolA)
+	repo := NewUserRepository(db)
+
+	//! L'email de l'école B reste pris vu depuis l'école A
+	exists, err := repo.ExistsByEmail(ctx, "shared@user.mg")
+	if err != nil {
+		t.Fatalf("ExistsByEmail() error = %v", err)
+	}
+	if !exists {
+		t.Error("ExistsByEmail() cross-school = false, want true")
+	}
+
+	user := &domain.User{
+		SchoolID:     schoolA,
+		Email:        "shared@user.mg",
+		PasswordHash: "hash",
+		FirstName:    "Jean",
+		LastName:     "Rakoto",
+		Role:         "teacher",
+		Status:       "pending",
+	}
+	if err := repo.Create(ctx, user); err != domain.ErrEmailAlreadyExists {
+		t.Errorf("Create() cross-school duplicate error = %v, want %v", err, domain.ErrEmailAlreadyExists)
+	}
+}
+
+func TestTenantIsolation_UnscopedDenied(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	schoolA := testutil.SeedTestSchool(t, db, "School A", "school-a", "a@school.mg")
+	classA := testutil.SeedTestClass(t, db, schoolA, "6ème A", "6ème", "A", "2025-2026")
+	testutil.SeedTestUser(t, db, schoolA, "a@user.mg", "teacher")
+
+	//! Sans école ni accès plateforme : aucune ligne visible, aucune écriture
+	ctx := testutil.BeginUnscopedTestTx(t, db)
+
+	if _, err := NewSchoolRepository(db).FindByID(ctx, schoolA); err != domain.ErrSchoolNotFound {
+		t.Errorf("SchoolRepository.FindByID() unscoped error = %v, want %v", err, domain.ErrSchoolNotFound)
+	}
+	if _, err := NewClassRepository(db).FindByID(ctx, classA); err != domain.ErrClassNotFound {
+		t.Errorf("ClassRepository.FindByID() unscoped error = %v, want %v", err, domain.ErrClassNotFound)
+	}
+	if exists, err := NewUserRepository(db).ExistsByEmail(ctx, "a@user.mg"); err != nil || exists {
+		t.Errorf("UserRepository.ExistsByEmail() unscoped = %v, %v; want false", exists, err)
+	}
+
+	class := &domain.Class{
+		SchoolID:     schoolA,
+		Name:         "Intrus",
+		Level:        "6ème",
+		Section:      "B",
+		Capacity:     40,
+		AcademicYear: "2025-2026",
+		Status:       domain.ClassStatusActive,
+	}
+	if err := NewClassRepository(db).Create(ctx, class); err == nil {
+		t.Error("ClassRepository.Create() unscoped expected error, got nil")
+	}
+}
+
+func TestTenantIsolation_PlatformBypass(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	schoolA := testutil.SeedTestSchool(t, db, "School A", "school-a", "a@school.mg")
+	schoolB := testutil.SeedTestSchool(t, db, "School B", "school-b", "b@school.mg")
+	testutil.SeedTestUser(t, db, schoolB, "b@user.mg", "teacher")
+
+	//! Accès plateforme explicite (app.bypass_rls) : toutes les écoles
+	ctx := testutil.BeginPlatformTestTx(t, db)
+
+	for _, schoolID := range []int{schoolA, schoolB} {
+		if _, err := NewSchoolRepository(db).FindByID(ctx, schoolID); err != nil {
+			t.Errorf("SchoolRepository.FindByID(%d) platform error = %v", schoolID, err)
+		}
+	}
+	if _, err := NewUserRepository(db).FindByEmail(ctx, "b@user.mg"); err != nil {
+		t.Errorf("UserRepository.FindByEmail() platform error = %v", err)
+	}
+}
@@ -1,20 +1,28 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"educnet/internal/db"
 	"educnet/internal/domain"
+	"errors"
 	"fmt"
+
+	"github.com/lib/pq"
 )
 
 type UserRepository interface {
-	Create(user *domain.User) error
-	FindByID(id int) (*domain.User, error)
-	FindByEmail(email string) (*domain.User, error)
-	ExistsByEmail(email string) (bool, error)
-	Update(user *domain.User) error
-	UpdateAvatar(userID int, avatarURL string) error
-	FindPendingBySchool(schoolID int) ([]*domain.User, error)
-	FindBySchool(schoolID int, filters map[string]string) ([]*domain.User, error)
+	Create(ctx context.Context, user *domain.User) error
+	FindByID(ctx context.Context, id int) (*domain.User, error)
+	FindByEmail(ctx context.Context, email string) (*domain.User, error)
+	ExistsByEmail(ctx context.Context, email string) (bool, error)
+	Update(ctx context.Context, user *domain.User) error
+	Erase(ctx context.Context, user *domain.User) error
+	UpdateAvatar(ctx context.Context, userID int, avatarURL string) error
+	UpdateRole(ctx context.Context, userID int, role string) error
+	FindPendingBySchool(ctx context.Context, schoolID int) ([]*domain.User, error)
+	FindBySchool(ctx context.Context, schoolID int, filters map[string]string) ([]*domain.User, error)
+	EachBySchool(ctx context.Context, schoolID int, filters map[string]string, fn func(*domain.User) error) error
 
 	//! HELPER
 	ScanUserRow(row domainScanner, user *domain.User) error
@@ -31,10 +39,11 @@ func NewUserRepository(db *sql.DB) UserRepository {
 // ! ==================== PRO HELPERS ====================
 func (r *userRepository) ScanUserRow(row domainScanner, user *domain.User) error {
 	var phone, avatarURL sql.NullString
+	var schoolID sql.NullInt64
 	err := row.Scan(
-		&user.ID, &user.SchoolID, &user.Email, &user.PasswordHash,
+		&user.ID, &schoolID, &user.Email, &user.PasswordHash,
 		&user.FirstName, &user.LastName, &phone, &user.Role,
-		&avatarURL, &user.Status, &user.CreatedAt, &user.UpdatedAt,
+		&avatarURL, &user.Status, &user.MustChangePassword, &user.CreatedAt, &user.UpdatedAt,
 	)
 	if err == sql.ErrNoRows {
 		return err
@@ -43,29 +52,35 @@ func (r *userRepository) ScanUserRow(row domainScanner, user *domain.User) error
 		return fmt.Errorf("scan user row: %w", err)
 	}
 
+	user.SchoolID = int(schoolID.Int64) //! 0 pour un superadmin (hors école)
 	user.Phone = nullString(phone)
 	user.AvatarURL = nullString(avatarURL)
 	return nil
 }
 
 // ! ==================== METHODS PRO ====================
-func (r *userRepository) Create(user *domain.User) error {
-	err := r.db.QueryRow(
-		`INSERT INTO users (school_id,email,password_hash,first_name,last_name,phone,role,status)
-         VALUES ($1,$2,$3,$4,$5,$6,$7,$8) RETURNING id,created_at,updated_at`,
-		user.SchoolID, user.Email, user.PasswordHash, user.FirstName, user.LastName,
-		user.Phone, user.Role, user.Status,
+func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO users (school_id,email,password_hash,first_name,last_name,phone,role,status,must_change_password)
+         VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING id,created_at,updated_at`,
+		nullSchoolID(user.SchoolID), user.Email, user.PasswordHash, user.FirstName, user.LastName,
+		user.Phone, user.Role, user.Status, user.MustChangePassword,
 	).Scan(&user.ID, &user.CreatedAt, &user.UpdatedAt)
+	//! Email pris entre la vérification et l'INSERT (users.email UNIQUE, toutes écoles)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+		return domain.ErrEmailAlreadyExists
+	}
 	if err != nil {
 		return fmt.Errorf("create user: %w", err)
 	}
 	return nil
 }
 
-func (r *userRepository) FindByID(id int) (*domain.User, error) {
+func (r *userRepository) FindByID(ctx context.Context, id int) (*domain.User, error) {
 	user := &domain.User{}
-	row := r.db.QueryRow(
-		`SELECT id,school_id,email,password_hash,first_name,last_name,phone,role,avatar_url,status,created_at,updated_at 
+	row := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT id,school_id,email,password_hash,first_name,last_name,phone,role,avatar_url,status,must_change_password,created_at,updated_at 
          FROM users WHERE id=$1`, id)
 
 	if err := r.ScanUserRow(row, user); err != nil {
@@ -77,10 +92,10 @@ func (r *userRepository) FindByID(id int) (*domain.User, error) {
 	return user, nil
 }
 
-func (r *userRepository) FindByEmail(email string) (*domain.User, error) {
+func (r *userRepository) FindByEmail(ctx context.Context, email string) (*domain.User, error) {
 	user := &domain.User{}
-	row := r.db.QueryRow(
-		`SELECT id,school_id,email,password_hash,first_name,last_name,phone,role,avatar_url,status,created_at,updated_at 
+	row := db.Conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT id,school_id,email,password_hash,first_name,last_name,phone,role,avatar_url,status,must_change_password,created_at,updated_at 
          FROM users WHERE email=$1`, email)
 
 	if err := r.ScanUserRow(row, user); err != nil {
@@ -92,17 +107,19 @@ func (r *userRepository) FindByEmail(email string) (*domain.User, error) {
 	return user, nil
 }
 
-func (r *userRepository) ExistsByEmail(email string) (bool, error) {
+// ! ExistsByEmail vérifie l'email sur toute la plateforme, y compris depuis une transaction
+// ! scopée sur une école (app_email_exists, migration 025)
+func (r *userRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
 	var exists bool
-	err := r.db.QueryRow(`SELECT EXISTS(SELECT 1 FROM users WHERE email=$1)`, email).Scan(&exists)
+	err := db.Conn(ctx, r.db).QueryRowContext(ctx, `SELECT app_email_exists($1)`, email).Scan(&exists)
 	return exists, err
 }
 
-func (r *userRepository) Update(user *domain.User) error {
-	result, err := r.db.Exec(
-		`UPDATE users SET first_name=$1,last_name=$2,phone=$3,avatar_url=$4,password_hash=$5,status=$6,updated_at=NOW() 
-         WHERE id=$7`,
-		user.FirstName, user.LastName, user.Phone, user.AvatarURL, user.PasswordHash, user.Status, user.ID)
+func (r *userRepository) Update(ctx context.Context, user *domain.User) error {
+	result, err := db.Conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE users SET first_name=$1,last_name=$2,phone=$3,avatar_url=$4,password_hash=$5,status=$6,must_change_password=$7,updated_at=NOW() 
+         WHERE id=$8`,
+		user.FirstName, user.LastName, user.Phone, user.AvatarURL, user.PasswordHash, user.Status, user.MustChangePassword, user.ID)
 	if err != nil {
 		return fmt.Errorf("update user: %w", err)
 	}
@@ -114,8 +131,26 @@ func (r *userRepository) Update(user *domain.User) error {
 	return nil
 }
 
-func (r *userRepository) UpdateAvatar(userID int, avatarURL string) error {
-	result, err := r.db.Exec(`UPDATE users SET avatar_url=$1,updated_at=NOW() WHERE id=$2`, avatarURL, userID)
+// ! Erase enregistre l'anonymisation (domain.User.Erase) : email compris, contrairement à Update
+func (r *userRepository) Erase(ctx context.Context, user *domain.User) error {
+	result, err := db.Conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE users SET email=$1,first_name=$2,last_name=$3,phone=NULL,avatar_url=NULL,password_hash=$4,
+             status=$5,must_change_password=FALSE,updated_at=NOW()
+         WHERE id=$6`,
+		user.Email, user.FirstName, user.LastName, user.PasswordHash, user.Status, user.ID)
+	if err != nil {
+		return fmt.Errorf("erase user: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return domain.ErrUserNotFound
+	}
+	return nil
+}
+
+func (r *userRepository) UpdateAvatar(ctx context.Context, userID int, avatarURL string) error {
+	result, err := db.Conn(ctx, r.db).ExecContext(ctx, `UPDATE users SET avatar_url=$1,updated_at=NOW() WHERE id=$2`, avatarURL, userID)
 	if err != nil {
 		return fmt.Errorf("update user avatar: %w", err)
 	}
@@ -126,9 +161,21 @@ func (r *userRepository) UpdateAvatar(userID int, avatarURL string) error {
 	return nil
 }
 
-func (r *userRepository) FindPendingBySchool(schoolID int) ([]*domain.User, error) {
-	rows, err := r.db.Query(
-		`SELECT id,school_id,email,password_hash,first_name,last_name,phone,role,avatar_url,status,created_at,updated_at 
+func (r *userRepository) UpdateRole(ctx context.Context, userID int, role string) error {
+	result, err := db.Conn(ctx, r.db).ExecContext(ctx, `UPDATE users SET role=$1,updated_at=NOW() WHERE id=$2`, role, userID)
+	if err != nil {
+		return fmt.Errorf("update user role: %w", err)
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return domain.ErrUserNotFound
+	}
+	return nil
+}
+
+func (r *userRepository) FindPendingBySchool(ctx context.Context, schoolID int) ([]*domain.User, error) {
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx,
+		`SELECT id,school_id,email,password_hash,first_name,last_name,phone,role,avatar_url,status,must_change_password,created_at,updated_at 
          FROM users WHERE school_id=$1 AND status='pending' ORDER BY created_at DESC`, schoolID)
 	if err != nil {
 		return nil, fmt.Errorf("find pending users: %w", err)
@@ -146,8 +193,18 @@ func (r *userRepository) FindPendingBySchool(schoolID int) ([]*domain.User, erro
 	return users, rows.Err()
 }
 
-func (r *userRepository) FindBySchool(schoolID int, filters map[string]string) ([]*domain.User, error) {
-	query := `SELECT id,school_id,email,password_hash,first_name,last_name,phone,role,avatar_url,status,created_at,updated_at 
+func (r *userRepository) FindBySchool(ctx context.Context, schoolID int, filters map[string]string) ([]*domain.User, error) {
+	var users []*domain.User
+	err := r.EachBySchool(ctx, schoolID, filters, func(user *domain.User) error {
+		users = append(users, user)
+		return nil
+	})
+	return users, err
+}
+
+// ! EachBySchool comme FindBySchool, mais appelle fn ligne par ligne (exports volumineux)
+func (r *userRepository) EachBySchool(ctx context.Context, schoolID int, filters map[string]string, fn func(*domain.User) error) error {
+	query := `SELECT id,school_id,email,password_hash,first_name,last_name,phone,role,avatar_url,status,must_change_password,created_at,updated_at 
               FROM users WHERE school_id=$1`
 	args := []interface{}{schoolID}
 
@@ -159,21 +216,28 @@ func (r *userRepository) FindBySchool(schoolID int, filters map[string]string) (
 		query += ` AND status=$` + fmt.Sprint(len(args)+1)
 		args = append(args, status)
 	}
+	//! Recherche par début de matricule (insensible à la casse)
+	if number, ok := filters["student_number"]; ok && number != "" {
+		query += ` AND EXISTS (SELECT 1 FROM student_profiles sp WHERE sp.user_id = users.id
+                  AND sp.student_number ILIKE $` + fmt.Sprint(len(args)+1) + `)`
+		args = append(args, escapeLike(number)+"%")
+	}
 	query += ` ORDER BY created_at DESC`
 
-	rows, err := r.db.Query(query, args...)
+	rows, err := db.Conn(ctx, r.db).QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("find users by school: %w", err)
+		return fmt.Errorf("find users by school: %w", err)
 	}
 	defer rows.Close()
 
-	var users []*domain.User
 	for rows.Next() {
 		user := &domain.User{}
 		if err := r.ScanUserRow(rows, user); err != nil {
-			return nil, err
+			return err
+		}
+		if err := fn(user); err != nil {
+			return err
 		}
-		users = append(users, user)
 	}
-	return users, rows.Err()
+	return rows.Err()
 }
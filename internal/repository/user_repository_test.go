@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"testing"
 
 	"educnet/internal/domain"
@@ -16,6 +17,7 @@ func TestUserRepository_Create(t *testing.T) {
 	defer testutil.CleanupTestDB(t, db)
 
 	repo := NewUserRepository(db)
+	ctx := context.Background()
 
 	//! Seed school
 	schoolID := testutil.SeedTestSchool(t, db, "Test School", "test", "test@school.mg")
@@ -31,7 +33,7 @@ func TestUserRepository_Create(t *testing.T) {
 		Status:       "pending",
 	}
 
-	err := repo.Create(user)
+	err := repo.Create(ctx, user)
 	if err != nil {
 		t.Fatalf("Create() error = %v", err)
 	}
@@ -39,6 +41,13 @@ func TestUserRepository_Create(t *testing.T) {
 	if user.ID == 0 {
 		t.Error("Create() ID was not set")
 	}
+
+	//! Email déjà pris : erreur métier, pas l'erreur brute de l'index unique
+	duplicate := *user
+	duplicate.ID = 0
+	if err := repo.Create(ctx, &duplicate); err != domain.ErrEmailAlreadyExists {
+		t.Errorf("Create() duplicate email error = %v, want %v", err, domain.ErrEmailAlreadyExists)
+	}
 }
 
 func TestUserRepository_FindByEmail(t *testing.T) {
@@ -50,13 +59,14 @@ func TestUserRepository_FindByEmail(t *testing.T) {
 	defer testutil.CleanupTestDB(t, db)
 
 	repo := NewUserRepository(db)
+	ctx := context.Background()
 
 	//! Seed
 	schoolID := testutil.SeedTestSchool(t, db, "Test", "test", "test@school.mg")
 	testutil.SeedTestUser(t, db, schoolID, "teacher@test.mg", "teacher")
 
 	//! Test
-	user, err := repo.FindByEmail("teacher@test.mg")
+	user, err := repo.FindByEmail(ctx, "teacher@test.mg")
 	if err != nil {
 		t.Fatalf("FindByEmail() error = %v", err)
 	}
@@ -78,6 +88,7 @@ func TestUserRepository_ExistsByEmail(t *testing.T) {
 	defer testutil.CleanupTestDB(t, db)
 
 	repo := NewUserRepository(db)
+	ctx := context.Background()
 
 	//! Seed
 	schoolID := testutil.SeedTestSchool(t, db, "Test", "test", "test@school.mg")
@@ -102,7 +113,7 @@ func TestUserRepository_ExistsByEmail(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			exists, err := repo.ExistsByEmail(tt.email)
+			exists, err := repo.ExistsByEmail(ctx, tt.email)
 			if err != nil {
 				t.Fatalf("ExistsByEmail() error = %v", err)
 			}
@@ -112,3 +123,41 @@ func TestUserRepository_ExistsByEmail(t *testing.T) {
 		})
 	}
 }
+
+func TestUserRepository_UpdateLifecycle(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test")
+	}
+
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := NewUserRepository(db)
+	ctx := context.Background()
+
+	//! Seed
+	schoolID := testutil.SeedTestSchool(t, db, "Test", "test", "test@school.mg")
+	userID := testutil.SeedTestUser(t, db, schoolID, "eleve@test.mg", "student")
+
+	user, err := repo.FindByID(ctx, userID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+
+	user.Suspend()
+	user.MustChangePassword = true
+	if err := repo.Update(ctx, user); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	found, err := repo.FindByID(ctx, userID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if found.Status != domain.UserStatusSuspended {
+		t.Errorf("Status = %v, want %v", found.Status, domain.UserStatusSuspended)
+	}
+	if !found.MustChangePassword {
+		t.Error("MustChangePassword = false, want true")
+	}
+}
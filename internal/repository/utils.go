@@ -3,6 +3,9 @@ package repository
 import (
 	"database/sql"
 	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
 )
 
 // ! domainScanner interface générique pour tous les repositories
@@ -27,6 +30,35 @@ func nullInt(ni sql.NullInt64) *int {
 	return nil
 }
 
+// ! nullSchoolID convertit un school_id 0 → NULL (superadmin hors école)
+func nullSchoolID(schoolID int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(schoolID), Valid: schoolID > 0}
+}
+
+// ! nullID convertit un ID optionnel → NULL si nil
+func nullID(id *int) sql.NullInt64 {
+	if id == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: int64(*id), Valid: true}
+}
+
+// ! intArray convertit []int → pq.Int64Array (paramètre ANY($n))
+func intArray(ids []int) pq.Int64Array {
+	array := make(pq.Int64Array, len(ids))
+	for i, id := range ids {
+		array[i] = int64(id)
+	}
+	return array
+}
+
+// ! escapeLike échappe les jokers % et _ d'un motif LIKE / ILIKE
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
 // ! scanError wrapper standard pour tous les scan
 func scanError(err error, operation string) error {
 	if err != nil {
@@ -34,3 +66,18 @@ func scanError(err error, operation string) error {
 	}
 	return nil
 }
+
+// ! scanIDs lit une colonne d'IDs et ferme rows
+func scanIDs(rows *sql.Rows) ([]int, error) {
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, scanError(err, "scan id")
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
@@ -8,10 +8,11 @@ import (
 )
 
 // SetupAdminRoutes configure les routes admin (authentification + rôle admin requis)
-func SetupAdminRoutes(api *mux.Router, h *Handlers, jwtService *auth.JWTService) {
+func SetupAdminRoutes(api *mux.Router, h *Handlers, jwtService *auth.JWTService, tenant mux.MiddlewareFunc) {
 	// Admin routes (JWT + AdminOnly middleware)
 	admin := api.PathPrefix("/admin").Subrouter()
 	admin.Use(middleware.JWTAuth(jwtService))
+	admin.Use(tenant)
 	admin.Use(middleware.AdminOnly)
 
 	// ========== USER MANAGEMENT ==========
@@ -19,8 +20,36 @@ func SetupAdminRoutes(api *mux.Router, h *Handlers, jwtService *auth.JWTService)
 	admin.HandleFunc("/users/{id}/approve", h.Admin.ApproveUser).Methods("POST")
 	admin.HandleFunc("/users/{id}/reject", h.Admin.RejectUser).Methods("POST")
 	admin.HandleFunc("/users", h.Admin.GetAllUsers).Methods("GET")
-	// admin.HandleFunc("/users/{id}", h.Admin.GetUserByID).Methods("GET")           // À venir
-	// admin.HandleFunc("/users/{id}/suspend", h.Admin.SuspendUser).Methods("POST")  // À venir
+	admin.HandleFunc("/users/import", h.Import.ImportUsers).Methods("POST")
+	admin.HandleFunc("/users/bulk-approve", h.Admin.BulkApproveUsers).Methods("POST")
+	admin.HandleFunc("/users/bulk-reject", h.Admin.BulkRejectUsers).Methods("POST")
+	admin.HandleFunc("/users/{id}", h.Admin.GetUserByID).Methods("GET")
+	admin.HandleFunc("/users/{id}/suspend", h.Admin.SuspendUser).Methods("POST")
+	admin.HandleFunc("/users/{id}/reactivate", h.Admin.ReactivateUser).Methods("POST")
+	admin.HandleFunc("/users/{id}/deactivate", h.Admin.DeactivateUser).Methods("POST")
+	admin.HandleFunc("/users/{id}/reset-password", h.Admin.ResetUserPassword).Methods("POST")
+	admin.HandleFunc("/users/{id}/class", h.Admin.ChangeStudentClass).Methods("PUT")
+	admin.HandleFunc("/users/{id}/transfer", h.Admin.ChangeStudentClass).Methods("POST")
+	admin.HandleFunc("/users/{id}/enrollments", h.Admin.GetStudentEnrollments).Methods("GET")
+	admin.HandleFunc("/users/{id}/subjects", h.Admin.UpdateTeacherSubjects).Methods("PUT")
+	admin.HandleFunc("/users/{id}/erase", h.Privacy.EraseUser).Methods("POST")
+	admin.HandleFunc("/users/{id}/student-number", h.Admin.AssignStudentNumber).Methods("POST")
+	admin.HandleFunc("/students/by-number/{number}", h.Admin.FindByStudentNumber).Methods("GET")
+	admin.HandleFunc("/users/{id}/profile", h.Profile.GetUserProfile).Methods("GET")
+	admin.HandleFunc("/users/{id}/profile", h.Profile.UpdateExtendedProfile).Methods("PUT")
+	admin.HandleFunc("/users/{id}/documents", h.Profile.UploadDocument).Methods("POST")
+	admin.HandleFunc("/users/{id}/documents/{documentId}", h.Profile.DownloadDocument).Methods("GET")
+	admin.HandleFunc("/users/{id}/documents/{documentId}", h.Profile.DeleteDocument).Methods("DELETE")
+
+	// ========== INVITATIONS & REGISTRATION POLICY ==========
+	admin.HandleFunc("/invitations", h.Invitation.GetInvitations).Methods("GET")
+	admin.HandleFunc("/invitations", h.Invitation.CreateInvitation).Methods("POST")
+	admin.HandleFunc("/invitations/{id}", h.Invitation.RevokeInvitation).Methods("DELETE")
+	admin.HandleFunc("/school/registration-mode", h.Invitation.UpdateRegistrationMode).Methods("PUT")
+	admin.HandleFunc("/school/retention", h.Privacy.GetRetentionPolicy).Methods("GET")
+	admin.HandleFunc("/school/retention", h.Privacy.UpdateRetentionPolicy).Methods("PUT")
+	admin.HandleFunc("/school/student-numbers", h.Admin.GetStudentNumberFormat).Methods("GET")
+	admin.HandleFunc("/school/student-numbers", h.Admin.UpdateStudentNumberFormat).Methods("PUT")
 
 	// ========== SUBJECT MANAGEMENT (CRUD) - À IMPLÉMENTER ==========
 	admin.HandleFunc("/subjects", h.Admin.GetAllSubjects).Methods("GET")
@@ -33,8 +62,60 @@ func SetupAdminRoutes(api *mux.Router, h *Handlers, jwtService *auth.JWTService)
 	admin.HandleFunc("/classes", h.Admin.CreateClass).Methods("POST")
 	admin.HandleFunc("/classes/{id}", h.Admin.UpdateClass).Methods("PUT")
 	admin.HandleFunc("/classes/{id}", h.Admin.DeleteClass).Methods("DELETE")
+	admin.HandleFunc("/classes/{id}/waitlist", h.Admin.GetClassWaitlist).Methods("GET")
+	admin.HandleFunc("/classes/{id}/waitlist/{studentId}", h.Admin.RemoveFromWaitlist).Methods("DELETE")
+
+	// ========== TERMS & REPORT CARDS ==========
+	admin.HandleFunc("/terms", h.Grade.ListTerms).Methods("GET")
+	admin.HandleFunc("/terms", h.Grade.CreateTerm).Methods("POST")
+	admin.HandleFunc("/report-comments", h.Grade.SetReportComment).Methods("PUT")
+	admin.HandleFunc("/report-cards/students/{id}", h.ReportCard.StudentReportCard).Methods("GET")
+	admin.HandleFunc("/report-cards/classes/{id}", h.ReportCard.ClassReportCards).Methods("GET")
+
+	// ========== RANKINGS & CLASS STATISTICS ==========
+	admin.HandleFunc("/classes/{id}/ranking", h.Ranking.ClassRanking).Methods("GET")
+	admin.HandleFunc("/school/ranking", h.Ranking.GetSettings).Methods("GET")
+	admin.HandleFunc("/school/ranking", h.Ranking.UpdateSettings).Methods("PUT")
+
+	// ========== ANNOUNCEMENTS ==========
+	admin.HandleFunc("/announcements", h.Announcement.ListAnnouncements).Methods("GET")
+	admin.HandleFunc("/announcements", h.Announcement.CreateAnnouncement).Methods("POST")
+	admin.HandleFunc("/announcements/{id}", h.Announcement.UpdateAnnouncement).Methods("PUT")
+	admin.HandleFunc("/announcements/{id}", h.Announcement.DeleteAnnouncement).Methods("DELETE")
+	admin.HandleFunc("/announcements/{id}/report", h.Announcement.GetReport).Methods("GET")
+	admin.HandleFunc("/announcements/{id}/attachments", h.Announcement.AddAttachment).Methods("POST")
+	admin.HandleFunc("/announcements/{id}/attachments/{attachmentId}", h.Announcement.DeleteAttachment).Methods("DELETE")
+
+	// ========== ROOMS & EQUIPMENT ==========
+	admin.HandleFunc("/rooms", h.Room.CreateRoom).Methods("POST")
+	admin.HandleFunc("/rooms/{id}", h.Room.UpdateRoom).Methods("PUT")
+	admin.HandleFunc("/rooms/{id}", h.Room.DeleteRoom).Methods("DELETE")
+	admin.HandleFunc("/equipment", h.Room.CreateEquipment).Methods("POST")
+	admin.HandleFunc("/equipment/{id}", h.Room.UpdateEquipment).Methods("PUT")
+	admin.HandleFunc("/equipment/{id}", h.Room.DeleteEquipment).Methods("DELETE")
+	admin.HandleFunc("/bookings", h.Room.ListBookings).Methods("GET")
+	admin.HandleFunc("/bookings/{id}/review", h.Room.ReviewBooking).Methods("POST")
+
+	// ========== DISCIPLINE ==========
+	admin.HandleFunc("/incidents", h.Discipline.ListIncidents).Methods("GET")
+	admin.HandleFunc("/incidents", h.Discipline.ReportIncident).Methods("POST")
+	admin.HandleFunc("/incidents/{id}", h.Discipline.GetIncident).Methods("GET")
+	admin.HandleFunc("/incidents/{id}/escalate", h.Discipline.EscalateIncident).Methods("POST")
+	admin.HandleFunc("/incidents/{id}/close", h.Discipline.CloseIncident).Methods("POST")
+	admin.HandleFunc("/incidents/{id}/sanctions", h.Discipline.AddSanction).Methods("POST")
+	admin.HandleFunc("/incidents/{id}/sanctions/{sanctionId}", h.Discipline.DeleteSanction).Methods("DELETE")
+	admin.HandleFunc("/students/{id}/incidents", h.Discipline.GetStudentHistory).Methods("GET")
+	admin.HandleFunc("/classes/{id}/discipline-summary", h.Discipline.GetClassSummary).Methods("GET")
+	admin.HandleFunc("/school/discipline", h.Discipline.GetSettings).Methods("GET")
+	admin.HandleFunc("/school/discipline", h.Discipline.UpdateSettings).Methods("PUT")
+
+	// ========== EXPORTS (CSV / XLSX) ==========
+	admin.HandleFunc("/exports/users", h.Export.ExportUsers).Methods("GET")
+	admin.HandleFunc("/exports/teacher-subjects", h.Export.ExportTeacherSubjects).Methods("GET")
+	admin.HandleFunc("/exports/classes/{id}/roster", h.Export.ExportClassRoster).Methods("GET")
+	admin.HandleFunc("/exports/classes/{id}/messages", h.Export.ExportClassMessages).Methods("GET")
 
 	// ========== DASHBOARD & STATS ==========
 	admin.HandleFunc("/dashboard", h.Admin.GetDashboard).Methods("GET")
-	// admin.HandleFunc("/stats", h.Admin.GetStats).Methods("GET")                   // À venir
+	admin.HandleFunc("/stats", h.Stats.GetStats).Methods("GET")
 }
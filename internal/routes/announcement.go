@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"educnet/internal/auth"
+	"educnet/internal/middleware"
+
+	"github.com/gorilla/mux"
+)
+
+// ! SetupAnnouncementRoutes annonces reçues (tous les rôles de l'école) ;
+// ! la gestion est dans les routes admin
+func SetupAnnouncementRoutes(api *mux.Router, h *Handlers, jwtService *auth.JWTService, tenant mux.MiddlewareFunc) {
+	announcements := api.PathPrefix("/announcements").Subrouter()
+	announcements.Use(middleware.JWTAuth(jwtService))
+	announcements.Use(tenant)
+
+	announcements.HandleFunc("", h.Announcement.ListMyAnnouncements).Methods("GET")
+	announcements.HandleFunc("/{id}", h.Announcement.GetAnnouncement).Methods("GET")
+	announcements.HandleFunc("/{id}/acknowledge", h.Announcement.Acknowledge).Methods("POST")
+	announcements.HandleFunc("/{id}/attachments/{attachmentId}", h.Announcement.DownloadAttachment).Methods("GET")
+}
@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"educnet/internal/auth"
+	"educnet/internal/middleware"
+
+	"github.com/gorilla/mux"
+)
+
+// ! SetupCalendarRoutes calendrier de l'école (lecture pour tous, écriture admin/enseignant
+// ! vérifiée par le usecase) et gestion du flux .ics personnel
+func SetupCalendarRoutes(api *mux.Router, h *Handlers, jwtService *auth.JWTService, tenant mux.MiddlewareFunc) {
+	calendar := api.PathPrefix("/calendar").Subrouter()
+	calendar.Use(middleware.JWTAuth(jwtService))
+	calendar.Use(tenant)
+
+	//! Événements
+	calendar.HandleFunc("/events", h.Calendar.ListEvents).Methods("GET")
+	calendar.HandleFunc("/events", h.Calendar.CreateEvent).Methods("POST")
+	calendar.HandleFunc("/events/{id}", h.Calendar.GetEvent).Methods("GET")
+	calendar.HandleFunc("/events/{id}", h.Calendar.UpdateEvent).Methods("PUT")
+	calendar.HandleFunc("/events/{id}", h.Calendar.DeleteEvent).Methods("DELETE")
+
+	//! Flux iCalendar
+	calendar.HandleFunc("/feed", h.Calendar.GetFeed).Methods("GET")
+	calendar.HandleFunc("/feed/rotate", h.Calendar.RotateFeed).Methods("POST")
+	calendar.HandleFunc("/feed", h.Calendar.RevokeFeed).Methods("DELETE")
+}
@@ -0,0 +1,42 @@
+package routes
+
+import (
+	"database/sql"
+	"educnet/internal/metrics"
+	ws "educnet/internal/websocket"
+
+	"github.com/gorilla/mux"
+)
+
+// ! registerMetrics expose GET /metrics (format Prometheus) : pool SQL et WebSocket
+// ! lus à chaque scrape. Retourne l'histogramme des latences HTTP.
+func registerMetrics(r *mux.Router, registry *metrics.Registry, db *sql.DB) *metrics.HistogramVec {
+	durations := registry.NewHistogramVec("educnet_http_request_duration_seconds",
+		"HTTP request latency by method, route template and status.",
+		metrics.DefaultBuckets, "method", "route", "status")
+
+	//! Pool de connexions PostgreSQL
+	registry.NewGaugeFunc("educnet_db_open_connections", "Open database connections (in use + idle).",
+		func() float64 { return float64(db.Stats().OpenConnections) })
+	registry.NewGaugeFunc("educnet_db_in_use_connections", "Database connections currently in use.",
+		func() float64 { return float64(db.Stats().InUse) })
+	registry.NewGaugeFunc("educnet_db_idle_connections", "Idle database connections.",
+		func() float64 { return float64(db.Stats().Idle) })
+	registry.NewGaugeFunc("educnet_db_max_open_connections", "Maximum number of open database connections.",
+		func() float64 { return float64(db.Stats().MaxOpenConnections) })
+	registry.NewCounterFunc("educnet_db_wait_count_total", "Connections waited for because the pool was exhausted.",
+		func() float64 { return float64(db.Stats().WaitCount) })
+	registry.NewCounterFunc("educnet_db_wait_duration_seconds_total", "Total time spent waiting for a connection.",
+		func() float64 { return db.Stats().WaitDuration.Seconds() })
+
+	//! Chat temps réel
+	registry.NewGaugeFunc("educnet_websocket_connections", "Active WebSocket chat connections.",
+		func() float64 { return float64(ws.CurrentStats().Clients) })
+	registry.NewGaugeFunc("educnet_websocket_rooms", "Chat rooms with at least one connected client.",
+		func() float64 { return float64(ws.CurrentStats().Rooms) })
+	registry.NewCounterFunc("educnet_websocket_dropped_messages_total", "Broadcast messages dropped because a client was too slow.",
+		func() float64 { return float64(ws.CurrentStats().DroppedMessages) })
+
+	r.Handle("/metrics", registry.Handler()).Methods("GET")
+	return durations
+}
@@ -9,16 +9,22 @@ import (
 )
 
 // ! SetupProfileRoutes configure les routes de profil (protégées par JWT)
-func SetupProfileRoutes(api *mux.Router, h *Handlers, jwtService *auth.JWTService) {
+func SetupProfileRoutes(api *mux.Router, h *Handlers, jwtService *auth.JWTService, tenant mux.MiddlewareFunc) {
 	//! Routes accessibles à tous (authentifiés)
 	profile := api.PathPrefix("/me").Subrouter()
 	profile.Use(middleware.JWTAuth(jwtService))
+	profile.Use(tenant)
 
 	profile.HandleFunc("", h.Profile.GetProfile).Methods("GET")
 	profile.HandleFunc("", h.Profile.UpdateProfile).Methods("PUT")
-	profile.HandleFunc("/password", h.Profile.ChangePassword).Methods("PUT")
+	profile.HandleFunc("/password", h.Profile.ChangePassword).Methods("PUT").Name(middleware.ChangePasswordRoute)
 	profile.HandleFunc("/avatar", h.Profile.UploadAvatar).Methods("POST")
 	profile.HandleFunc("/school", h.Profile.GetSchool).Methods("GET")
+	profile.HandleFunc("/data-export", h.Privacy.ExportMyData).Methods("GET")
+	profile.HandleFunc("/details", h.Profile.UpdateExtendedProfile).Methods("PUT")
+	profile.HandleFunc("/documents", h.Profile.UploadDocument).Methods("POST")
+	profile.HandleFunc("/documents/{documentId}", h.Profile.DownloadDocument).Methods("GET")
+	profile.HandleFunc("/documents/{documentId}", h.Profile.DeleteDocument).Methods("DELETE")
 
 	//! Routes ADMIN ONLY
 	admin := profile.PathPrefix("/school").Subrouter()
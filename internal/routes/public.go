@@ -7,29 +7,40 @@ import (
 	"github.com/gorilla/mux"
 )
 
-// ! SetupPublicRoutes configure les routes publiques (sans authentification)
-func SetupPublicRoutes(api *mux.Router, h *Handlers) {
+// ! SetupPublicRoutes configure les routes publiques (sans authentification).
+// ! Sans transaction, la RLS ne laisse voir aucune ligne d'école : les routes qui
+// ! cherchent au-delà d'une école passent par platform, celles d'une école par school.
+func SetupPublicRoutes(api *mux.Router, h *Handlers, platform, school mux.MiddlewareFunc) {
 	//! Health check
 	api.HandleFunc("/health", health).Methods("GET")
+	api.HandleFunc("/health/live", h.Health.Live).Methods("GET")
+	api.HandleFunc("/health/ready", h.Health.Ready).Methods("GET")
 
 	//! Registration
-	api.HandleFunc("/schools/register", h.School.CreateSchool).Methods("POST")
-	api.HandleFunc("/teachers/register", h.Teacher.Register).Methods("POST")
-	api.HandleFunc("/students/register", h.Student.Register).Methods("POST")
+	api.Handle("/schools/register", platform(http.HandlerFunc(h.School.CreateSchool))).Methods("POST")
+	api.Handle("/teachers/register", platform(http.HandlerFunc(h.Teacher.Register))).Methods("POST")
+	api.Handle("/students/register", platform(http.HandlerFunc(h.Student.Register))).Methods("POST")
+
+	//! Invitations (lien signé envoyé par l'admin, transaction scopée par le usecase)
+	api.HandleFunc("/invitations", h.Invitation.GetInvitation).Methods("GET")
+	api.HandleFunc("/invitations/accept", h.Invitation.AcceptInvitation).Methods("POST")
+
+	//! Flux iCalendar (URL signée, transaction scopée par le usecase)
+	api.HandleFunc("/calendar/feed/{token}.ics", h.Calendar.Feed).Methods("GET")
 
 	//! Authentication
-	api.HandleFunc("/auth/login", h.Auth.Login).Methods("POST")
+	api.Handle("/auth/login", platform(http.HandlerFunc(h.Auth.Login))).Methods("POST")
 	// api.HandleFunc("/auth/refresh", h.Auth.RefreshToken).Methods("POST")  // À venir
 	// api.HandleFunc("/auth/logout", h.Auth.Logout).Methods("POST")
 
 	//! School
-	api.HandleFunc("/schools", h.School.GetAllSchool).Methods("GET")
+	api.Handle("/schools", platform(http.HandlerFunc(h.School.GetAllSchool))).Methods("GET")
 
 	//! Class
-	api.HandleFunc("/classes/{schoolId}", h.Class.GetClassesBySchoolID).Methods("GET")
+	api.Handle("/classes/{schoolId}", school(http.HandlerFunc(h.Class.GetClassesBySchoolID))).Methods("GET")
 
 	//! Subject
-	api.HandleFunc("/subjects/{schoolId}", h.Subject.GetSubjectsBySchoolID).Methods("GET")
+	api.Handle("/subjects/{schoolId}", school(http.HandlerFunc(h.Subject.GetSubjectsBySchoolID))).Methods("GET")
 
 }
 
@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"educnet/internal/auth"
+	"educnet/internal/middleware"
+
+	"github.com/gorilla/mux"
+)
+
+// ! SetupRoomRoutes consultation des salles / équipements et réservations (enseignants et
+// ! admins, vérifié par le usecase) ; la gestion et la validation sont dans les routes admin
+func SetupRoomRoutes(api *mux.Router, h *Handlers, jwtService *auth.JWTService, tenant mux.MiddlewareFunc) {
+	rooms := api.PathPrefix("/rooms").Subrouter()
+	rooms.Use(middleware.JWTAuth(jwtService))
+	rooms.Use(tenant)
+
+	rooms.HandleFunc("", h.Room.ListRooms).Methods("GET")
+	rooms.HandleFunc("/equipment", h.Room.ListEquipment).Methods("GET")
+	rooms.HandleFunc("/availability", h.Room.SearchAvailability).Methods("GET")
+
+	//! Réservations
+	rooms.HandleFunc("/bookings", h.Room.ListMyBookings).Methods("GET")
+	rooms.HandleFunc("/bookings", h.Room.CreateBooking).Methods("POST")
+	rooms.HandleFunc("/bookings/{id}/cancel", h.Room.CancelBooking).Methods("POST")
+}
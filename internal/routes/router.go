@@ -4,7 +4,10 @@ import (
 	"database/sql"
 	"educnet/internal/auth"
 	"educnet/internal/handler"
+	"educnet/internal/mailer"
+	"educnet/internal/metrics"
 	"educnet/internal/middleware"
+	"educnet/internal/migrate"
 	"educnet/internal/repository"
 	"educnet/internal/usecase"
 
@@ -22,12 +25,31 @@ type Handlers struct {
 	Class   *handler.ClassHandler
 	Subject *handler.SubjectHandler
 	Chat    *handler.ChatHandler
+
+	SuperAdmin   *handler.SuperAdminHandler
+	Import       *handler.ImportHandler
+	Invitation   *handler.InvitationHandler
+	Stats        *handler.StatsHandler
+	Grade        *handler.GradeHandler
+	ReportCard   *handler.ReportCardHandler
+	Export       *handler.ExportHandler
+	Privacy      *handler.PrivacyHandler
+	Health       *handler.HealthHandler
+	Quiz         *handler.QuizHandler
+	Resource     *handler.ResourceHandler
+	Calendar     *handler.CalendarHandler
+	Announcement *handler.AnnouncementHandler
+	Room         *handler.RoomHandler
+	Discipline   *handler.DisciplineHandler
+	Gradebook    *handler.GradebookHandler
+	Ranking      *handler.RankingHandler
 }
 
 func NewRouter(
 	db *sql.DB,
 	jwtService *auth.JWTService,
 	jwtSecret string,
+	frontendURL string,
 	//! REPOSITORIES
 	schoolRepo repository.SchoolRepository,
 	userRepo repository.UserRepository,
@@ -36,18 +58,54 @@ func NewRouter(
 	teacherSubjectRepo repository.TeacherSubjectRepository,
 	studentClassRepo repository.StudentClassRepository,
 	messageRepository repository.MessageRepository,
+	auditLogRepo repository.AuditLogRepository,
+	invitationRepo repository.InvitationRepository,
+	statsRepo repository.StatsRepository,
+	gradeRepo repository.GradeRepository,
+	attendanceRepo repository.AttendanceRepository,
+	privacyRepo repository.PrivacyRepository,
+	quizRepo repository.QuizRepository,
+	resourceRepo repository.ResourceRepository,
+	calendarRepo repository.CalendarRepository,
+	announcementRepo repository.AnnouncementRepository,
+	roomRepo repository.RoomRepository,
+	profileRepo repository.ProfileRepository,
+	studentNumberRepo repository.StudentNumberRepository,
+	disciplineRepo repository.DisciplineRepository,
+	rankingRepo repository.RankingRepository,
+	//! SERVICES
+	mailService mailer.Mailer,
+	//! OBSERVABILITY
+	migrator *migrate.Migrator,
+	registry *metrics.Registry,
 ) *mux.Router {
 
 	//! ========== USECASES ==========
 	schoolUseCase := usecase.NewSchoolUseCase(db, schoolRepo, userRepo, jwtSecret) // ✅ FIXÉ
 	teacherUseCase := usecase.NewTeacherUseCase(db, userRepo, schoolRepo, subjectRepo, teacherSubjectRepo)
-	studentUseCase := usecase.NewStudentUseCase(db, userRepo, schoolRepo, classRepo, studentClassRepo)
-	authUseCase := usecase.NewAuthUseCase(userRepo, jwtService)
-	adminUseCase := usecase.NewAdminUseCase(userRepo, teacherSubjectRepo, studentClassRepo, subjectRepo, classRepo)
-	profileUseCase := usecase.NewProfileUseCase(userRepo, subjectRepo, classRepo, teacherSubjectRepo, studentClassRepo, schoolRepo)
+	studentUseCase := usecase.NewStudentUseCase(db, userRepo, schoolRepo, classRepo, studentClassRepo, studentNumberRepo)
+	authUseCase := usecase.NewAuthUseCase(userRepo, schoolRepo, jwtService)
+	adminUseCase := usecase.NewAdminUseCase(db, userRepo, teacherSubjectRepo, studentClassRepo, subjectRepo, classRepo, statsRepo, studentNumberRepo, mailService)
+	profileUseCase := usecase.NewProfileUseCase(userRepo, subjectRepo, classRepo, teacherSubjectRepo, studentClassRepo, schoolRepo, profileRepo)
 	classUsecase := usecase.NewClassUsecase(classRepo)
 	subjectUsecase := usecase.NewSubjectUsecase(subjectRepo)
 	messageUsecase := usecase.NewMessageUseCase(messageRepository)
+	superAdminUseCase := usecase.NewSuperAdminUseCase(userRepo, schoolRepo, auditLogRepo, jwtService)
+	userImportUseCase := usecase.NewUserImportUseCase(db, userRepo, schoolRepo, classRepo, subjectRepo, studentClassRepo, teacherSubjectRepo, studentNumberRepo, mailService)
+	statsUseCase := usecase.NewStatsUseCase(userRepo, statsRepo)
+	invitationUseCase := usecase.NewInvitationUseCase(db, invitationRepo, userRepo, schoolRepo, classRepo, subjectRepo, studentClassRepo, teacherSubjectRepo, studentNumberRepo, jwtService, mailService, frontendURL)
+	gradeUseCase := usecase.NewGradeUseCase(db, userRepo, classRepo, teacherSubjectRepo, studentClassRepo, gradeRepo, attendanceRepo)
+	reportCardUseCase := usecase.NewReportCardUseCase(userRepo, schoolRepo, classRepo, subjectRepo, studentClassRepo, gradeRepo, attendanceRepo, studentNumberRepo)
+	exportUseCase := usecase.NewExportUseCase(userRepo, classRepo, studentClassRepo, teacherSubjectRepo, messageRepository, studentNumberRepo)
+	privacyUseCase := usecase.NewPrivacyUseCase(db, userRepo, schoolRepo, studentClassRepo, teacherSubjectRepo, messageRepository, gradeRepo, attendanceRepo, privacyRepo, auditLogRepo, profileRepo, disciplineRepo)
+	quizUseCase := usecase.NewQuizUseCase(db, userRepo, classRepo, teacherSubjectRepo, studentClassRepo, gradeRepo, quizRepo)
+	resourceUseCase := usecase.NewResourceUseCase(db, userRepo, classRepo, subjectRepo, teacherSubjectRepo, studentClassRepo, resourceRepo)
+	calendarUseCase := usecase.NewCalendarUseCase(db, userRepo, schoolRepo, classRepo, studentClassRepo, quizRepo, calendarRepo, roomRepo, jwtService)
+	announcementUseCase := usecase.NewAnnouncementUseCase(db, userRepo, schoolRepo, classRepo, studentClassRepo, announcementRepo, mailService, frontendURL)
+	roomUseCase := usecase.NewRoomUseCase(userRepo, roomRepo, calendarRepo)
+	disciplineUseCase := usecase.NewDisciplineUseCase(db, userRepo, schoolRepo, classRepo, studentClassRepo, profileRepo, disciplineRepo, mailService, frontendURL)
+	gradebookUseCase := usecase.NewGradebookUseCase(db, userRepo, classRepo, subjectRepo, teacherSubjectRepo, studentClassRepo, gradeRepo, studentNumberRepo)
+	rankingUseCase := usecase.NewRankingUseCase(userRepo, classRepo, subjectRepo, studentClassRepo, gradeRepo, rankingRepo)
 	//! ========== HANDLERS ==========
 	handlers := &Handlers{
 		School:  handler.NewSchoolHandler(schoolUseCase),
@@ -59,24 +117,54 @@ func NewRouter(
 		Profile: handler.NewProfileHandler(profileUseCase),
 		Class:   handler.NewClassHandler(classUsecase),
 		Subject: handler.NewSubjectHandler(subjectUsecase),
-		Chat:    handler.NewChatHandler(messageUsecase),
+		Chat:    handler.NewChatHandler(messageUsecase, db),
+
+		SuperAdmin:   handler.NewSuperAdminHandler(superAdminUseCase),
+		Import:       handler.NewImportHandler(userImportUseCase),
+		Invitation:   handler.NewInvitationHandler(invitationUseCase),
+		Stats:        handler.NewStatsHandler(statsUseCase),
+		Grade:        handler.NewGradeHandler(gradeUseCase),
+		ReportCard:   handler.NewReportCardHandler(reportCardUseCase),
+		Export:       handler.NewExportHandler(exportUseCase),
+		Privacy:      handler.NewPrivacyHandler(privacyUseCase),
+		Health:       handler.NewHealthHandler(db, migrator),
+		Quiz:         handler.NewQuizHandler(quizUseCase),
+		Resource:     handler.NewResourceHandler(resourceUseCase),
+		Calendar:     handler.NewCalendarHandler(calendarUseCase),
+		Announcement: handler.NewAnnouncementHandler(announcementUseCase),
+		Room:         handler.NewRoomHandler(roomUseCase),
+		Discipline:   handler.NewDisciplineHandler(disciplineUseCase),
+		Gradebook:    handler.NewGradebookHandler(gradebookUseCase),
+		Ranking:      handler.NewRankingHandler(rankingUseCase),
 	}
 
 	r := mux.NewRouter()
 
 	r.Use(middleware.CORS)
+	r.Use(middleware.RequestID)
 	r.Use(middleware.Logger)
+	r.Use(middleware.Metrics(registerMetrics(r, registry, db)))
 
 	api := r.PathPrefix("/api").Subrouter()
 
+	//! Transaction par requête scopée sur l'école du JWT (RLS)
+	tenant := middleware.TenantScope(db)
+	//! Routes publiques : accès plateforme explicite ou école de l'URL
+	platform := middleware.PlatformScope(db)
+	school := middleware.SchoolScope(db, "schoolId")
+
 	//! ========== SUB-ROUTERS ==========
-	SetupPublicRoutes(api, handlers)
-	SetupUserRoutes(api, handlers, jwtService)
-	SetupAdminRoutes(api, handlers, jwtService)
-	SetupProfileRoutes(api, handlers, jwtService)
-	SetupTeacherRoutes(api, handlers, jwtService)
-	SetupStudentRoutes(api, handlers, jwtService)
+	SetupPublicRoutes(api, handlers, platform, school)
+	SetupUserRoutes(api, handlers, jwtService, tenant)
+	SetupAdminRoutes(api, handlers, jwtService, tenant)
+	SetupProfileRoutes(api, handlers, jwtService, tenant)
+	SetupTeacherRoutes(api, handlers, jwtService, tenant)
+	SetupStudentRoutes(api, handlers, jwtService, tenant)
+	SetupCalendarRoutes(api, handlers, jwtService, tenant)
+	SetupAnnouncementRoutes(api, handlers, jwtService, tenant)
+	SetupRoomRoutes(api, handlers, jwtService, tenant)
 	SetupWebSocketRoutes(api, handlers, jwtService)
+	SetupSuperAdminRoutes(api, handlers, jwtService, tenant)
 
 	return r
 }
@@ -8,10 +8,11 @@ import (
 )
 
 // SetupStudentRoutes configure les routes étudiant
-func SetupStudentRoutes(api *mux.Router, h *Handlers, jwtService *auth.JWTService) {
+func SetupStudentRoutes(api *mux.Router, h *Handlers, jwtService *auth.JWTService, tenant mux.MiddlewareFunc) {
 	// Student routes (JWT + StudentOnly middleware)
 	student := api.PathPrefix("/student").Subrouter()
 	student.Use(middleware.JWTAuth(jwtService))
+	student.Use(tenant)
 	student.Use(middleware.RoleRequired("student")) // À créer
 
 	// ========== MY CLASS ==========
@@ -23,6 +24,25 @@ func SetupStudentRoutes(api *mux.Router, h *Handlers, jwtService *auth.JWTServic
 	// ========== MY GRADES ==========
 	// student.HandleFunc("/grades", h.Student.GetMyGrades).Methods("GET")
 
+	// ========== MY REPORT CARD ==========
+	student.HandleFunc("/terms", h.Grade.ListTerms).Methods("GET")
+	student.HandleFunc("/report-card", h.ReportCard.MyReportCard).Methods("GET")
+	student.HandleFunc("/ranking", h.Ranking.MyRanking).Methods("GET")
+
+	// ========== MY QUIZZES ==========
+	student.HandleFunc("/quizzes", h.Quiz.ListMyQuizzes).Methods("GET")
+	student.HandleFunc("/quizzes/{id}", h.Quiz.GetMyQuiz).Methods("GET")
+	student.HandleFunc("/quizzes/{id}/attempts", h.Quiz.StartAttempt).Methods("POST")
+	student.HandleFunc("/quiz-attempts/{id}/submit", h.Quiz.SubmitAttempt).Methods("POST")
+
+	// ========== MY RESOURCES ==========
+	student.HandleFunc("/resource-folders", h.Resource.ListFolders).Methods("GET")
+	student.HandleFunc("/resources", h.Resource.ListMyResources).Methods("GET")
+	student.HandleFunc("/resources/{id}/download", h.Resource.Download).Methods("GET")
+
+	// ========== MY INCIDENTS ==========
+	student.HandleFunc("/incidents", h.Discipline.ListStudentIncidents).Methods("GET")
+
 	// ========== MY ATTENDANCE ==========
 	// student.HandleFunc("/attendance", h.Student.GetMyAttendance).Methods("GET")
 
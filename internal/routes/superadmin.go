@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"educnet/internal/auth"
+	"educnet/internal/middleware"
+
+	"github.com/gorilla/mux"
+)
+
+// SetupSuperAdminRoutes configure la console plateforme (JWT + rôle superadmin).
+// tenant ouvre pour le superadmin une transaction plateforme (app.bypass_rls).
+func SetupSuperAdminRoutes(api *mux.Router, h *Handlers, jwtService *auth.JWTService, tenant mux.MiddlewareFunc) {
+	superAdmin := api.PathPrefix("/superadmin").Subrouter()
+	superAdmin.Use(middleware.JWTAuth(jwtService))
+	superAdmin.Use(middleware.RoleRequired("superadmin"))
+	superAdmin.Use(tenant)
+
+	// ========== SCHOOLS ==========
+	superAdmin.HandleFunc("/schools", h.SuperAdmin.ListSchools).Methods("GET")
+	superAdmin.HandleFunc("/schools/{id}", h.SuperAdmin.GetSchool).Methods("GET")
+	superAdmin.HandleFunc("/schools/{id}/suspend", h.SuperAdmin.SuspendSchool).Methods("POST")
+	superAdmin.HandleFunc("/schools/{id}/reactivate", h.SuperAdmin.ReactivateSchool).Methods("POST")
+	superAdmin.HandleFunc("/schools/{id}/impersonate", h.SuperAdmin.Impersonate).Methods("POST")
+	superAdmin.HandleFunc("/schools/{id}/transfer-ownership", h.SuperAdmin.TransferOwnership).Methods("POST")
+
+	// ========== AUDIT ==========
+	superAdmin.HandleFunc("/audit-logs", h.SuperAdmin.GetAuditLogs).Methods("GET")
+}
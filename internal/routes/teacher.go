@@ -8,10 +8,11 @@ import (
 )
 
 // SetupTeacherRoutes configure les routes enseignant
-func SetupTeacherRoutes(api *mux.Router, h *Handlers, jwtService *auth.JWTService) {
+func SetupTeacherRoutes(api *mux.Router, h *Handlers, jwtService *auth.JWTService, tenant mux.MiddlewareFunc) {
 	// Teacher routes (JWT + TeacherOnly middleware)
 	teacher := api.PathPrefix("/teacher").Subrouter()
 	teacher.Use(middleware.JWTAuth(jwtService))
+	teacher.Use(tenant)
 	teacher.Use(middleware.RoleRequired("teacher")) // À créer
 
 	// ========== MY SUBJECTS ==========
@@ -22,12 +23,58 @@ func SetupTeacherRoutes(api *mux.Router, h *Handlers, jwtService *auth.JWTServic
 
 	// ========== STUDENTS ==========
 	// teacher.HandleFunc("/students", h.Teacher.GetMyStudents).Methods("GET")
+	teacher.HandleFunc("/students/{id}/profile", h.Profile.GetUserProfile).Methods("GET")
 
 	// ========== GRADES ==========
-	// teacher.HandleFunc("/grades", h.Teacher.CreateGrade).Methods("POST")
-	// teacher.HandleFunc("/grades/{id}", h.Teacher.UpdateGrade).Methods("PUT")
+	teacher.HandleFunc("/terms", h.Grade.ListTerms).Methods("GET")
+	teacher.HandleFunc("/grades", h.Grade.ListGrades).Methods("GET")
+	teacher.HandleFunc("/grades", h.Grade.RecordGrade).Methods("POST")
+	teacher.HandleFunc("/grades/{id}", h.Grade.UpdateGrade).Methods("PUT")
+	teacher.HandleFunc("/grades/{id}", h.Grade.DeleteGrade).Methods("DELETE")
+	teacher.HandleFunc("/report-comments", h.Grade.SetReportComment).Methods("PUT")
+
+	// ========== GRADEBOOK ==========
+	teacher.HandleFunc("/evaluations", h.Gradebook.CreateEvaluation).Methods("POST")
+	teacher.HandleFunc("/evaluations/{id}", h.Gradebook.UpdateEvaluation).Methods("PUT")
+	teacher.HandleFunc("/evaluations/{id}", h.Gradebook.DeleteEvaluation).Methods("DELETE")
+	teacher.HandleFunc("/gradebook", h.Gradebook.GetGradebook).Methods("GET")
+	teacher.HandleFunc("/gradebook", h.Gradebook.SaveGradebook).Methods("PUT")
+	teacher.HandleFunc("/gradebook/export", h.Gradebook.ExportGradebook).Methods("GET")
+	teacher.HandleFunc("/gradebook/import", h.Gradebook.ImportGradebook).Methods("POST")
+
+	// ========== RANKINGS & CLASS STATISTICS ==========
+	teacher.HandleFunc("/classes/{id}/ranking", h.Ranking.ClassRanking).Methods("GET")
+
+	// ========== QUIZZES ==========
+	teacher.HandleFunc("/questions", h.Quiz.ListQuestions).Methods("GET")
+	teacher.HandleFunc("/questions", h.Quiz.CreateQuestion).Methods("POST")
+	teacher.HandleFunc("/questions/{id}", h.Quiz.UpdateQuestion).Methods("PUT")
+	teacher.HandleFunc("/questions/{id}", h.Quiz.DeleteQuestion).Methods("DELETE")
+	teacher.HandleFunc("/quizzes", h.Quiz.ListQuizzes).Methods("GET")
+	teacher.HandleFunc("/quizzes", h.Quiz.CreateQuiz).Methods("POST")
+	teacher.HandleFunc("/quizzes/{id}", h.Quiz.GetQuiz).Methods("GET")
+	teacher.HandleFunc("/quizzes/{id}", h.Quiz.DeleteQuiz).Methods("DELETE")
+	teacher.HandleFunc("/quizzes/{id}/attempts", h.Quiz.ListAttempts).Methods("GET")
+
+	// ========== RESOURCES ==========
+	teacher.HandleFunc("/resource-folders", h.Resource.ListFolders).Methods("GET")
+	teacher.HandleFunc("/resource-folders", h.Resource.CreateFolder).Methods("POST")
+	teacher.HandleFunc("/resource-folders/{id}", h.Resource.DeleteFolder).Methods("DELETE")
+	teacher.HandleFunc("/resources", h.Resource.ListResources).Methods("GET")
+	teacher.HandleFunc("/resources", h.Resource.CreateResource).Methods("POST")
+	teacher.HandleFunc("/resources/{id}", h.Resource.UpdateResource).Methods("PUT")
+	teacher.HandleFunc("/resources/{id}", h.Resource.DeleteResource).Methods("DELETE")
+	teacher.HandleFunc("/resources/{id}/versions", h.Resource.ListVersions).Methods("GET")
+	teacher.HandleFunc("/resources/{id}/versions", h.Resource.AddVersion).Methods("POST")
+	teacher.HandleFunc("/resources/{id}/download", h.Resource.Download).Methods("GET")
+
+	// ========== DISCIPLINE ==========
+	teacher.HandleFunc("/incidents", h.Discipline.ListMyIncidents).Methods("GET")
+	teacher.HandleFunc("/incidents", h.Discipline.ReportIncident).Methods("POST")
+	teacher.HandleFunc("/incidents/{id}/escalate", h.Discipline.EscalateIncident).Methods("POST")
+	teacher.HandleFunc("/incidents/{id}/sanctions", h.Discipline.AddSanction).Methods("POST")
 
 	// ========== ATTENDANCE ==========
-	// teacher.HandleFunc("/attendance", h.Teacher.TakeAttendance).Methods("POST")
+	teacher.HandleFunc("/attendance", h.Grade.RecordAttendance).Methods("POST")
 	// teacher.HandleFunc("/attendance", h.Teacher.GetAttendance).Methods("GET")
 }
@@ -7,9 +7,10 @@ import (
 	"github.com/gorilla/mux"
 )
 
-func SetupUserRoutes(api *mux.Router, h *Handlers, jwtService *auth.JWTService) {
+func SetupUserRoutes(api *mux.Router, h *Handlers, jwtService *auth.JWTService, tenant mux.MiddlewareFunc) {
 	protected := api.PathPrefix("/").Subrouter()
 	protected.Use(middleware.JWTAuth(jwtService))
+	protected.Use(tenant)
 
 	//! Profile management
 	// protected.HandleFunc("/me", h.Profile.GetProfile).Methods("GET")
@@ -1,7 +1,9 @@
 package testutil
 
 import (
+	"context"
 	"database/sql"
+	"educnet/internal/db"
 	"fmt"
 	"log"
 	"os"
@@ -141,6 +143,66 @@ func SeedTestStudentClass(t *testing.T, db *sql.DB, studentID, classID int) {
 	}
 }
 
+// ! tenantTestRole rôle sans BYPASSRLS (postgres est superuser et ignore les policies)
+const tenantTestRole = "educnet_rls_test"
+
+// ! BeginTenantTestTx ouvre une transaction scopée sur schoolID avec un rôle soumis à la RLS
+// ! Retourne le context à passer aux repositories (rollback automatique en fin de test)
+func BeginTenantTestTx(t *testing.T, database *sql.DB, schoolID int) context.Context {
+	t.Helper()
+	return beginRLSTestTx(t, database, func(ctx context.Context) (*sql.Tx, error) {
+		return db.BeginTenantTx(ctx, database, schoolID)
+	})
+}
+
+// ! BeginUnscopedTestTx transaction sans école ni accès plateforme (rôle soumis à la RLS)
+func BeginUnscopedTestTx(t *testing.T, database *sql.DB) context.Context {
+	t.Helper()
+	return beginRLSTestTx(t, database, func(ctx context.Context) (*sql.Tx, error) {
+		return database.BeginTx(ctx, nil)
+	})
+}
+
+// ! BeginPlatformTestTx transaction plateforme (app.bypass_rls) avec un rôle soumis à la RLS
+func BeginPlatformTestTx(t *testing.T, database *sql.DB) context.Context {
+	t.Helper()
+	return beginRLSTestTx(t, database, func(ctx context.Context) (*sql.Tx, error) {
+		return db.BeginPlatformTx(ctx, database)
+	})
+}
+
+func beginRLSTestTx(t *testing.T, database *sql.DB, begin func(ctx context.Context) (*sql.Tx, error)) context.Context {
+	t.Helper()
+
+	setup := []string{
+		`DO $$ BEGIN
+            IF NOT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = '` + tenantTestRole + `') THEN
+                CREATE ROLE ` + tenantTestRole + ` NOLOGIN;
+            END IF;
+        END $$`,
+		"GRANT USAGE ON SCHEMA public TO " + tenantTestRole,
+		"GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public TO " + tenantTestRole,
+		"GRANT USAGE ON ALL SEQUENCES IN SCHEMA public TO " + tenantTestRole,
+	}
+	for _, query := range setup {
+		if _, err := database.Exec(query); err != nil {
+			t.Fatalf("Failed to prepare tenant test role: %v", err)
+		}
+	}
+
+	tx, err := begin(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to begin tenant tx: %v", err)
+	}
+	t.Cleanup(func() { tx.Rollback() })
+
+	if _, err := tx.Exec("SET LOCAL ROLE " + tenantTestRole); err != nil {
+		t.Fatalf("Failed to switch to tenant test role: %v", err)
+	}
+
+	return db.WithTx(context.Background(), tx)
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -1,61 +1,99 @@
 package usecase
 
 import (
+	"context"
+	"database/sql"
+	"educnet/internal/db"
 	"educnet/internal/domain"
 	"educnet/internal/handler/dto"
+	"educnet/internal/mailer"
 	"educnet/internal/repository"
-	"log"
+	"educnet/internal/utils"
+	"log/slog"
+	"strings"
+	"time"
 
 	"errors"
 	"fmt"
 )
 
 type AdminUseCase interface {
-	GetPendingUsers(adminUserID int) (*dto.PendingUsersResponse, error)
-	ApproveUser(adminUserID, targetUserID int) error
-	RejectUser(adminUserID, targetUserID int, reason string) error
-	GetAllUsers(adminUserID int, filters map[string]string) (*dto.UserListResponse, error)
-
-	GetAllSubjects(schoolID int) ([]dto.SubjectResponse, error)
-	CreateSubject(adminUserID int, req *dto.CreateSubjectRequest) (*dto.SubjectResponse, error)
-	UpdateSubject(adminUserID, subjectID int, req *dto.UpdateSubjectRequest) (*dto.SubjectResponse, error)
-	DeleteSubject(adminUserID, subjectID int) error
-
-	GetAllClasses(schoolID int) ([]dto.ClassResponse, error)
-	CreateClass(adminUserID int, req *dto.CreateClassRequest) (*dto.ClassResponse, error)
-	UpdateClass(adminUserID, classID int, req *dto.UpdateClassRequest) (*dto.ClassResponse, error)
-	DeleteClass(adminUserID, classID int) error
-
-	GetDashboard(adminUserID int) (*dto.DashboardResponse, error)
+	GetPendingUsers(ctx context.Context, adminUserID int) (*dto.PendingUsersResponse, error)
+	ApproveUser(ctx context.Context, adminUserID, targetUserID int) error
+	RejectUser(ctx context.Context, adminUserID, targetUserID int, reason string) error
+	GetAllUsers(ctx context.Context, adminUserID int, filters map[string]string) (*dto.UserListResponse, error)
+
+	GetAllSubjects(ctx context.Context, schoolID int) ([]dto.SubjectResponse, error)
+	CreateSubject(ctx context.Context, adminUserID int, req *dto.CreateSubjectRequest) (*dto.SubjectResponse, error)
+	UpdateSubject(ctx context.Context, adminUserID, subjectID int, req *dto.UpdateSubjectRequest) (*dto.SubjectResponse, error)
+	DeleteSubject(ctx context.Context, adminUserID, subjectID int) error
+
+	GetAllClasses(ctx context.Context, schoolID int) ([]dto.ClassResponse, error)
+	CreateClass(ctx context.Context, adminUserID int, req *dto.CreateClassRequest) (*dto.ClassResponse, error)
+	UpdateClass(ctx context.Context, adminUserID, classID int, req *dto.UpdateClassRequest) (*dto.ClassResponse, error)
+	DeleteClass(ctx context.Context, adminUserID, classID int) error
+
+	GetDashboard(ctx context.Context, adminUserID int) (*dto.DashboardResponse, error)
+
+	GetUserByID(ctx context.Context, adminUserID, targetUserID int) (*dto.UserDetailResponse, error)
+	SuspendUser(ctx context.Context, adminUserID, targetUserID int) error
+	ReactivateUser(ctx context.Context, adminUserID, targetUserID int) error
+	DeactivateUser(ctx context.Context, adminUserID, targetUserID int) error
+	ResetUserPassword(ctx context.Context, adminUserID, targetUserID int, req *dto.ResetPasswordRequest) (*dto.ResetPasswordResponse, error)
+	ChangeStudentClass(ctx context.Context, adminUserID, studentID int, req *dto.ChangeStudentClassRequest) (*dto.UserDetailResponse, error)
+	GetStudentEnrollments(ctx context.Context, adminUserID, studentID int) (*dto.EnrollmentHistoryResponse, error)
+	GetClassWaitlist(ctx context.Context, adminUserID, classID int) ([]dto.WaitlistEntryResponse, error)
+	RemoveFromWaitlist(ctx context.Context, adminUserID, classID, studentID int) error
+	UpdateTeacherSubjects(ctx context.Context, adminUserID, teacherID int, req *dto.UpdateTeacherSubjectsRequest) (*dto.UserDetailResponse, error)
+
+	BulkApproveUsers(ctx context.Context, adminUserID int, req *dto.BulkUserActionRequest) (*dto.BulkUserActionResponse, error)
+	BulkRejectUsers(ctx context.Context, adminUserID int, req *dto.BulkUserActionRequest) (*dto.BulkUserActionResponse, error)
+
+	GetStudentNumberFormat(ctx context.Context, adminUserID int) (*dto.StudentNumberFormatResponse, error)
+	UpdateStudentNumberFormat(ctx context.Context, adminUserID int, req *dto.StudentNumberFormatRequest) (*dto.StudentNumberFormatResponse, error)
+	AssignStudentNumber(ctx context.Context, adminUserID, studentID int) (*dto.UserDetailResponse, error)
+	FindByStudentNumber(ctx context.Context, adminUserID int, number string) (*dto.UserDetailResponse, error)
 }
 
 type adminUseCase struct {
+	db                 *sql.DB
 	userRepo           repository.UserRepository
 	teacherSubjectRepo repository.TeacherSubjectRepository
 	studentClassRepo   repository.StudentClassRepository
 	subjectRepo        repository.SubjectRepository
 	classRepo          repository.ClassRepository
+	statsRepo          repository.StatsRepository
+	studentNumberRepo  repository.StudentNumberRepository
+	mailer             mailer.Mailer
 }
 
 func NewAdminUseCase(
+	db *sql.DB,
 	userRepo repository.UserRepository,
 	teacherSubjectRepo repository.TeacherSubjectRepository,
 	studentClassRepo repository.StudentClassRepository,
 	subjectRepo repository.SubjectRepository,
 	classRepo repository.ClassRepository,
+	statsRepo repository.StatsRepository,
+	studentNumberRepo repository.StudentNumberRepository,
+	mailer mailer.Mailer,
 ) AdminUseCase {
 	return &adminUseCase{
+		db:                 db,
 		userRepo:           userRepo,
 		teacherSubjectRepo: teacherSubjectRepo,
 		studentClassRepo:   studentClassRepo,
 		subjectRepo:        subjectRepo,
 		classRepo:          classRepo,
+		statsRepo:          statsRepo,
+		studentNumberRepo:  studentNumberRepo,
+		mailer:             mailer,
 	}
 }
 
-func (uc *adminUseCase) GetPendingUsers(adminUserID int) (*dto.PendingUsersResponse, error) {
+func (uc *adminUseCase) GetPendingUsers(ctx context.Context, adminUserID int) (*dto.PendingUsersResponse, error) {
 	//! 1. Get admin user to verify permissions and get school_id
-	admin, err := uc.userRepo.FindByID(adminUserID)
+	admin, err := uc.userRepo.FindByID(ctx, adminUserID)
 	if err != nil {
 		return nil, err
 	}
@@ -65,7 +103,7 @@ func (uc *adminUseCase) GetPendingUsers(adminUserID int) (*dto.PendingUsersRespo
 	}
 
 	//! 2. Get pending users from same school
-	users, err := uc.userRepo.FindPendingBySchool(admin.SchoolID)
+	users, err := uc.userRepo.FindPendingBySchool(ctx, admin.SchoolID)
 	if err != nil {
 		return nil, err
 	}
@@ -85,7 +123,7 @@ func (uc *adminUseCase) GetPendingUsers(adminUserID int) (*dto.PendingUsersRespo
 
 		//! Add subjects for teachers
 		if user.IsTeacher() {
-			subjects, err := uc.teacherSubjectRepo.FindByTeacher(user.ID)
+			subjects, err := uc.teacherSubjectRepo.FindByTeacher(ctx, user.ID)
 			if err != nil {
 				subjects = []*domain.Subject{}
 			}
@@ -98,7 +136,7 @@ func (uc *adminUseCase) GetPendingUsers(adminUserID int) (*dto.PendingUsersRespo
 
 		//! Add class for students
 		if user.IsStudent() {
-			classes, err := uc.studentClassRepo.FindByStudent(user.ID)
+			classes, err := uc.studentClassRepo.FindByStudent(ctx, user.ID)
 			if err != nil || len(classes) == 0 {
 				classes = []*domain.Class{}
 			}
@@ -118,9 +156,9 @@ func (uc *adminUseCase) GetPendingUsers(adminUserID int) (*dto.PendingUsersRespo
 	}, nil
 }
 
-func (uc *adminUseCase) ApproveUser(adminUserID, targetUserID int) error {
+func (uc *adminUseCase) ApproveUser(ctx context.Context, adminUserID, targetUserID int) error {
 	//! 1. Verify admin permissions
-	admin, err := uc.userRepo.FindByID(adminUserID)
+	admin, err := uc.userRepo.FindByID(ctx, adminUserID)
 	if err != nil {
 		return err
 	}
@@ -129,32 +167,12 @@ func (uc *adminUseCase) ApproveUser(adminUserID, targetUserID int) error {
 		return errors.New("unauthorized: admin role required")
 	}
 
-	//! 2. Get target user
-	targetUser, err := uc.userRepo.FindByID(targetUserID)
-	if err != nil {
-		return err
-	}
-
-	//! 3. Verify same school
-	if targetUser.SchoolID != admin.SchoolID {
-		return errors.New("unauthorized: cannot approve users from other schools")
-	}
-
-	//! 4. Verify user is pending
-	if !targetUser.IsPending() {
-		return fmt.Errorf("user is not pending (status: %s)", targetUser.Status)
-	}
-
-	//! 5. Approve user
-	targetUser.Approve()
-
-	//! 6. Save
-	return uc.userRepo.Update(targetUser)
+	return uc.approveUser(ctx, admin, targetUserID)
 }
 
-func (uc *adminUseCase) RejectUser(adminUserID, targetUserID int, reason string) error {
+func (uc *adminUseCase) RejectUser(ctx context.Context, adminUserID, targetUserID int, reason string) error {
 	//! 1. Verify admin permissions
-	admin, err := uc.userRepo.FindByID(adminUserID)
+	admin, err := uc.userRepo.FindByID(ctx, adminUserID)
 	if err != nil {
 		return err
 	}
@@ -163,32 +181,12 @@ func (uc *adminUseCase) RejectUser(adminUserID, targetUserID int, reason string)
 		return errors.New("unauthorized: admin role required")
 	}
 
-	//! 2. Get target user
-	targetUser, err := uc.userRepo.FindByID(targetUserID)
-	if err != nil {
-		return err
-	}
-
-	//! 3. Verify same school
-	if targetUser.SchoolID != admin.SchoolID {
-		return errors.New("unauthorized: cannot reject users from other schools")
-	}
-
-	//! 4. Verify user is pending
-	if !targetUser.IsPending() {
-		return fmt.Errorf("user is not pending (status: %s)", targetUser.Status)
-	}
-
-	//! 5. Reject user
-	targetUser.Reject()
-
-	//! 6. Save (TODO: store rejection reason in a separate table if needed)
-	return uc.userRepo.Update(targetUser)
+	return uc.rejectUser(ctx, admin, targetUserID, reason)
 }
 
-func (uc *adminUseCase) GetAllUsers(adminUserID int, filters map[string]string) (*dto.UserListResponse, error) {
+func (uc *adminUseCase) GetAllUsers(ctx context.Context, adminUserID int, filters map[string]string) (*dto.UserListResponse, error) {
 	//! 1. Verify admin permissions
-	admin, err := uc.userRepo.FindByID(adminUserID)
+	admin, err := uc.userRepo.FindByID(ctx, adminUserID)
 	if err != nil {
 		return nil, err
 	}
@@ -198,7 +196,12 @@ func (uc *adminUseCase) GetAllUsers(adminUserID int, filters map[string]string)
 	}
 
 	//! 2. Get users from same school
-	users, err := uc.userRepo.FindBySchool(admin.SchoolID, filters)
+	users, err := uc.userRepo.FindBySchool(ctx, admin.SchoolID, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	numbers, err := uc.studentNumberRepo.FindBySchool(ctx, admin.SchoolID)
 	if err != nil {
 		return nil, err
 	}
@@ -207,13 +210,14 @@ func (uc *adminUseCase) GetAllUsers(adminUserID int, filters map[string]string)
 	userList := []dto.UserListInfo{}
 	for _, user := range users {
 		userList = append(userList, dto.UserListInfo{
-			ID:        user.ID,
-			Email:     user.Email,
-			FullName:  user.GetFullName(),
-			Role:      user.Role,
-			Status:    user.Status,
-			Phone:     user.Phone,
-			CreatedAt: user.CreatedAt.Format("2006-01-02 15:04:05"),
+			ID:            user.ID,
+			Email:         user.Email,
+			FullName:      user.GetFullName(),
+			Role:          user.Role,
+			Status:        user.Status,
+			Phone:         user.Phone,
+			StudentNumber: numbers[user.ID],
+			CreatedAt:     user.CreatedAt.Format("2006-01-02 15:04:05"),
 		})
 	}
 
@@ -224,9 +228,9 @@ func (uc *adminUseCase) GetAllUsers(adminUserID int, filters map[string]string)
 }
 
 // ! ========== SUBJECTS ==========
-func (uc *adminUseCase) CreateSubject(adminUserID int, req *dto.CreateSubjectRequest) (*dto.SubjectResponse, error) {
+func (uc *adminUseCase) CreateSubject(ctx context.Context, adminUserID int, req *dto.CreateSubjectRequest) (*dto.SubjectResponse, error) {
 	//! 1. Verify admin
-	admin, err := uc.userRepo.FindByID(adminUserID)
+	admin, err := uc.userRepo.FindByID(ctx, adminUserID)
 	if err != nil {
 		return nil, err
 	}
@@ -244,7 +248,7 @@ func (uc *adminUseCase) CreateSubject(adminUserID int, req *dto.CreateSubjectReq
 	}
 
 	//! 3. Check if code already exists
-	exists, err := uc.subjectRepo.ExistsByCode(admin.SchoolID, req.Code, 0)
+	exists, err := uc.subjectRepo.ExistsByCode(ctx, admin.SchoolID, req.Code, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -259,7 +263,7 @@ func (uc *adminUseCase) CreateSubject(adminUserID int, req *dto.CreateSubjectReq
 	}
 	subject.Description = req.Description
 
-	if err := uc.subjectRepo.Create(subject); err != nil {
+	if err := uc.subjectRepo.Create(ctx, subject); err != nil {
 		return nil, err
 	}
 
@@ -273,9 +277,9 @@ func (uc *adminUseCase) CreateSubject(adminUserID int, req *dto.CreateSubjectReq
 	}, nil
 }
 
-func (uc *adminUseCase) UpdateSubject(adminUserID, subjectID int, req *dto.UpdateSubjectRequest) (*dto.SubjectResponse, error) {
+func (uc *adminUseCase) UpdateSubject(ctx context.Context, adminUserID, subjectID int, req *dto.UpdateSubjectRequest) (*dto.SubjectResponse, error) {
 	//! 1. Verify admin
-	admin, err := uc.userRepo.FindByID(adminUserID)
+	admin, err := uc.userRepo.FindByID(ctx, adminUserID)
 	if err != nil {
 		return nil, err
 	}
@@ -285,7 +289,7 @@ func (uc *adminUseCase) UpdateSubject(adminUserID, subjectID int, req *dto.Updat
 	}
 
 	//! 2. Get subject
-	subject, err := uc.subjectRepo.FindByID(subjectID)
+	subject, err := uc.subjectRepo.FindByID(ctx, subjectID)
 	if err != nil {
 		return nil, err
 	}
@@ -305,7 +309,7 @@ func (uc *adminUseCase) UpdateSubject(adminUserID, subjectID int, req *dto.Updat
 
 	//! 5. Check if new code conflicts
 	if req.Code != subject.Code {
-		exists, err := uc.subjectRepo.ExistsByCode(admin.SchoolID, req.Code, subjectID)
+		exists, err := uc.subjectRepo.ExistsByCode(ctx, admin.SchoolID, req.Code, subjectID)
 		if err != nil {
 			return nil, err
 		}
@@ -319,7 +323,7 @@ func (uc *adminUseCase) UpdateSubject(adminUserID, subjectID int, req *dto.Updat
 	subject.Code = req.Code
 	subject.Description = req.Description
 
-	if err := uc.subjectRepo.Update(subject); err != nil {
+	if err := uc.subjectRepo.Update(ctx, subject); err != nil {
 		return nil, err
 	}
 
@@ -333,9 +337,9 @@ func (uc *adminUseCase) UpdateSubject(adminUserID, subjectID int, req *dto.Updat
 	}, nil
 }
 
-func (uc *adminUseCase) DeleteSubject(adminUserID, subjectID int) error {
+func (uc *adminUseCase) DeleteSubject(ctx context.Context, adminUserID, subjectID int) error {
 	//! 1. Verify admin
-	admin, err := uc.userRepo.FindByID(adminUserID)
+	admin, err := uc.userRepo.FindByID(ctx, adminUserID)
 	if err != nil {
 		return err
 	}
@@ -345,7 +349,7 @@ func (uc *adminUseCase) DeleteSubject(adminUserID, subjectID int) error {
 	}
 
 	//! 2. Get subject
-	subject, err := uc.subjectRepo.FindByID(subjectID)
+	subject, err := uc.subjectRepo.FindByID(ctx, subjectID)
 	if err != nil {
 		return err
 	}
@@ -356,11 +360,11 @@ func (uc *adminUseCase) DeleteSubject(adminUserID, subjectID int) error {
 	}
 
 	//! 4. Delete subject
-	return uc.subjectRepo.Delete(subjectID)
+	return uc.subjectRepo.Delete(ctx, subjectID)
 }
 
-func (uc *adminUseCase) GetAllSubjects(schoolID int) ([]dto.SubjectResponse, error) {
-	subjects, err := uc.subjectRepo.FindBySchoolID(schoolID)
+func (uc *adminUseCase) GetAllSubjects(ctx context.Context, schoolID int) ([]dto.SubjectResponse, error) {
+	subjects, err := uc.subjectRepo.FindBySchoolID(ctx, schoolID)
 	if err != nil {
 		return nil, err
 	}
@@ -369,8 +373,8 @@ func (uc *adminUseCase) GetAllSubjects(schoolID int) ([]dto.SubjectResponse, err
 }
 
 // ! ========== CLASSES ==========
-func (uc *adminUseCase) GetAllClasses(schoolID int) ([]dto.ClassResponse, error) {
-	classes, err := uc.classRepo.GetAll(schoolID)
+func (uc *adminUseCase) GetAllClasses(ctx context.Context, schoolID int) ([]dto.ClassResponse, error) {
+	classes, err := uc.classRepo.GetAll(ctx, schoolID)
 	if err != nil {
 		return nil, err
 	}
@@ -378,9 +382,9 @@ func (uc *adminUseCase) GetAllClasses(schoolID int) ([]dto.ClassResponse, error)
 	return dto.ClassResponsesFromDomain(classes), nil
 }
 
-func (uc *adminUseCase) CreateClass(adminUserID int, req *dto.CreateClassRequest) (*dto.ClassResponse, error) {
+func (uc *adminUseCase) CreateClass(ctx context.Context, adminUserID int, req *dto.CreateClassRequest) (*dto.ClassResponse, error) {
 	//! 1. Verify admin
-	admin, err := uc.userRepo.FindByID(adminUserID)
+	admin, err := uc.userRepo.FindByID(ctx, adminUserID)
 	if err != nil {
 		return nil, err
 	}
@@ -411,7 +415,7 @@ func (uc *adminUseCase) CreateClass(adminUserID int, req *dto.CreateClassRequest
 	class.Section = req.Section
 	class.Capacity = req.Capacity
 
-	if err := uc.classRepo.Create(class); err != nil {
+	if err := uc.classRepo.Create(ctx, class); err != nil {
 		return nil, err
 	}
 
@@ -427,9 +431,9 @@ func (uc *adminUseCase) CreateClass(adminUserID int, req *dto.CreateClassRequest
 	}, nil
 }
 
-func (uc *adminUseCase) UpdateClass(adminUserID, classID int, req *dto.UpdateClassRequest) (*dto.ClassResponse, error) {
+func (uc *adminUseCase) UpdateClass(ctx context.Context, adminUserID, classID int, req *dto.UpdateClassRequest) (*dto.ClassResponse, error) {
 	//! 1. Verify admin
-	admin, err := uc.userRepo.FindByID(adminUserID)
+	admin, err := uc.userRepo.FindByID(ctx, adminUserID)
 	if err != nil {
 		return nil, err
 	}
@@ -439,7 +443,7 @@ func (uc *adminUseCase) UpdateClass(adminUserID, classID int, req *dto.UpdateCla
 	}
 
 	//! 2. Get class
-	class, err := uc.classRepo.FindByID(classID)
+	class, err := uc.classRepo.FindByID(ctx, classID)
 	if err != nil {
 		return nil, err
 	}
@@ -467,11 +471,16 @@ func (uc *adminUseCase) UpdateClass(adminUserID, classID int, req *dto.UpdateCla
 	class.Capacity = req.Capacity
 	class.AcademicYear = req.AcademicYear
 
-	if err := uc.classRepo.Update(class); err != nil {
+	if err := uc.classRepo.Update(ctx, class); err != nil {
 		return nil, err
 	}
 
-	//! 6. Return response
+	//! 6. Capacité augmentée : promotion de la liste d'attente
+	if _, err := uc.studentClassRepo.PromoteWaitlist(ctx, class.ID); err != nil {
+		return nil, err
+	}
+
+	//! 7. Return response
 	return &dto.ClassResponse{
 		ID:           class.ID,
 		Name:         class.Name,
@@ -483,9 +492,9 @@ func (uc *adminUseCase) UpdateClass(adminUserID, classID int, req *dto.UpdateCla
 	}, nil
 }
 
-func (uc *adminUseCase) DeleteClass(adminUserID, classID int) error {
+func (uc *adminUseCase) DeleteClass(ctx context.Context, adminUserID, classID int) error {
 	//! 1. Verify admin
-	admin, err := uc.userRepo.FindByID(adminUserID)
+	admin, err := uc.userRepo.FindByID(ctx, adminUserID)
 	if err != nil {
 		return err
 	}
@@ -495,7 +504,7 @@ func (uc *adminUseCase) DeleteClass(adminUserID, classID int) error {
 	}
 
 	//! 2. Get class
-	class, err := uc.classRepo.FindByID(classID)
+	class, err := uc.classRepo.FindByID(ctx, classID)
 	if err != nil {
 		return err
 	}
@@ -506,12 +515,12 @@ func (uc *adminUseCase) DeleteClass(adminUserID, classID int) error {
 	}
 
 	//! 4. Delete class
-	return uc.classRepo.Delete(classID)
+	return uc.classRepo.Delete(ctx, classID)
 }
 
-func (uc *adminUseCase) GetDashboard(adminUserID int) (*dto.DashboardResponse, error) {
+func (uc *adminUseCase) GetDashboard(ctx context.Context, adminUserID int) (*dto.DashboardResponse, error) {
 	//! 1. Verify admin
-	admin, err := uc.userRepo.FindByID(adminUserID)
+	admin, err := uc.userRepo.FindByID(ctx, adminUserID)
 	if err != nil {
 		return nil, err
 	}
@@ -520,51 +529,38 @@ func (uc *adminUseCase) GetDashboard(adminUserID int) (*dto.DashboardResponse, e
 		return nil, errors.New("unauthorized: admin role required")
 	}
 
-	//! 2. Get all users from school
-	allUsers, err := uc.userRepo.FindBySchool(admin.SchoolID, map[string]string{})
+	//! 2. Count users in SQL (pas de chargement de toute l'école)
+	counts, err := uc.statsRepo.CountUsers(ctx, admin.SchoolID)
 	if err != nil {
 		return nil, err
 	}
 
 	//! 3. Calculate stats
-	stats := dto.DashboardStats{}
-	stats.TotalUsers = len(allUsers)
-
-	for _, user := range allUsers {
-		switch user.Role {
-		case "teacher":
-			stats.TotalTeachers++
-		case "student":
-			stats.TotalStudents++
-		case "admin":
-			stats.TotalAdmins++
-		}
-
-		switch user.Status {
-		case "pending":
-			stats.PendingUsers++
-		case "approved":
-			stats.ApprovedUsers++
-		case "rejected":
-			stats.RejectedUsers++
-		}
+	stats := dto.DashboardStats{
+		TotalUsers:    counts.Total,
+		TotalTeachers: counts.Teachers,
+		TotalStudents: counts.Students,
+		TotalAdmins:   counts.Admins,
+		PendingUsers:  counts.Pending,
+		ApprovedUsers: counts.Approved,
+		RejectedUsers: counts.Rejected,
 	}
 
 	//! 4. Get subjects and classes count
-	subjects, err := uc.subjectRepo.FindBySchoolID(admin.SchoolID)
+	subjects, err := uc.subjectRepo.FindBySchoolID(ctx, admin.SchoolID)
 	if err != nil {
 		return nil, fmt.Errorf("get subjects: %w", err)
 	}
 	stats.TotalSubjects = len(subjects)
 
-	classes, err := uc.classRepo.FindBySchoolID(admin.SchoolID)
+	classes, err := uc.classRepo.FindBySchoolID(ctx, admin.SchoolID)
 	if err != nil {
 		return nil, fmt.Errorf("get classes: %w", err)
 	}
 	stats.TotalClasses = len(classes)
 
 	//! 5. Get pending users (limit 5 for dashboard)
-	pendingUsers, err := uc.userRepo.FindPendingBySchool(admin.SchoolID)
+	pendingUsers, err := uc.userRepo.FindPendingBySchool(ctx, admin.SchoolID)
 	if err != nil {
 		return nil, err
 	}
@@ -588,9 +584,9 @@ func (uc *adminUseCase) GetDashboard(adminUserID int) (*dto.DashboardResponse, e
 		}
 
 		if user.IsTeacher() {
-			subjects, err := uc.teacherSubjectRepo.FindByTeacher(user.ID)
+			subjects, err := uc.teacherSubjectRepo.FindByTeacher(ctx, user.ID)
 			if err != nil {
-				log.Printf("Dashboard warning: %v", err)
+				slog.WarnContext(ctx, "dashboard: load teacher subjects", "user_id", user.ID, "error", err)
 				subjects = []*domain.Subject{}
 			}
 			subjNames := make([]string, len(subjects))
@@ -601,9 +597,9 @@ func (uc *adminUseCase) GetDashboard(adminUserID int) (*dto.DashboardResponse, e
 		}
 
 		if user.IsStudent() {
-			classes, err := uc.studentClassRepo.FindByStudent(user.ID)
+			classes, err := uc.studentClassRepo.FindByStudent(ctx, user.ID)
 			if err != nil {
-				log.Printf("Dashboard warning: %v", err)
+				slog.WarnContext(ctx, "dashboard: load student classes", "user_id", user.ID, "error", err)
 				classes = []*domain.Class{}
 			}
 			classNames := make([]string, len(classes))
@@ -621,3 +617,614 @@ func (uc *adminUseCase) GetDashboard(adminUserID int) (*dto.DashboardResponse, e
 		PendingUsers: pendingList,
 	}, nil
 }
+
+// ! ========== USER LIFECYCLE ==========
+func (uc *adminUseCase) GetUserByID(ctx context.Context, adminUserID, targetUserID int) (*dto.UserDetailResponse, error) {
+	admin, err := uc.verifyAdmin(ctx, adminUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.findSchoolUser(ctx, admin, targetUserID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.userDetail(ctx, user)
+}
+
+func (uc *adminUseCase) SuspendUser(ctx context.Context, adminUserID, targetUserID int) error {
+	admin, err := uc.verifyAdmin(ctx, adminUserID)
+	if err != nil {
+		return err
+	}
+
+	user, err := uc.findManagedUser(ctx, admin, targetUserID)
+	if err != nil {
+		return err
+	}
+	if user.IsSuspended() {
+		return domain.ErrUserAlreadySuspended
+	}
+	if !user.IsApproved() {
+		return domain.ErrUserNotApproved
+	}
+
+	user.Suspend()
+	return uc.userRepo.Update(ctx, user)
+}
+
+func (uc *adminUseCase) ReactivateUser(ctx context.Context, adminUserID, targetUserID int) error {
+	admin, err := uc.verifyAdmin(ctx, adminUserID)
+	if err != nil {
+		return err
+	}
+
+	user, err := uc.findManagedUser(ctx, admin, targetUserID)
+	if err != nil {
+		return err
+	}
+	if err := user.Reactivate(); err != nil {
+		return err
+	}
+	return uc.userRepo.Update(ctx, user)
+}
+
+func (uc *adminUseCase) DeactivateUser(ctx context.Context, adminUserID, targetUserID int) error {
+	admin, err := uc.verifyAdmin(ctx, adminUserID)
+	if err != nil {
+		return err
+	}
+
+	user, err := uc.findManagedUser(ctx, admin, targetUserID)
+	if err != nil {
+		return err
+	}
+
+	user.Deactivate()
+	return uc.userRepo.Update(ctx, user)
+}
+
+func (uc *adminUseCase) ResetUserPassword(ctx context.Context, adminUserID, targetUserID int, req *dto.ResetPasswordRequest) (*dto.ResetPasswordResponse, error) {
+	//! 1. Verify admin + target
+	admin, err := uc.verifyAdmin(ctx, adminUserID)
+	if err != nil {
+		return nil, err
+	}
+	user, err := uc.findManagedUser(ctx, admin, targetUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	//! 2. Temporary password (must be changed at next login)
+	password, err := utils.GeneratePassword(importPasswordLength)
+	if err != nil {
+		return nil, err
+	}
+	if err := user.ResetPassword(password); err != nil {
+		return nil, err
+	}
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	//! 3. Deliver: email after commit, or returned to the admin
+	resp := &dto.ResetPasswordResponse{UserID: user.ID, Email: user.Email}
+	if !req.SendEmail {
+		resp.TemporaryPassword = password
+		return resp, nil
+	}
+
+	body := fmt.Sprintf(
+		"Bonjour %s,\n\nVotre mot de passe EducNet a été réinitialisé par l'administration.\n\nMot de passe temporaire : %s\n\nVous devrez le changer à votre prochaine connexion.\n",
+		user.GetFullName(), password,
+	)
+	db.AfterCommit(ctx, func() {
+		go func() {
+			if err := uc.mailer.Send(user.Email, "Réinitialisation de votre mot de passe", body); err != nil {
+				slog.ErrorContext(ctx, "failed to email reset password", "target_user_id", user.ID, "error", err)
+			}
+		}()
+	})
+	resp.EmailSent = true
+	return resp, nil
+}
+
+func (uc *adminUseCase) ChangeStudentClass(ctx context.Context, adminUserID, studentID int, req *dto.ChangeStudentClassRequest) (*dto.UserDetailResponse, error) {
+	//! 1. Verify admin + student
+	admin, err := uc.verifyAdmin(ctx, adminUserID)
+	if err != nil {
+		return nil, err
+	}
+	student, err := uc.findSchoolUser(ctx, admin, studentID)
+	if err != nil {
+		return nil, err
+	}
+	if !student.IsStudent() {
+		return nil, domain.ErrUserNotStudent
+	}
+
+	//! 2. Validate class
+	class, err := uc.classRepo.FindByID(ctx, req.ClassID)
+	if errors.Is(err, domain.ErrClassNotFound) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if class.SchoolID != admin.SchoolID {
+		return nil, domain.ErrForbidden
+	}
+
+	//! 3. Déjà inscrit : rien à faire (évite de céder sa place à la liste d'attente)
+	enrolled, err := uc.studentClassRepo.Exists(ctx, student.ID, class.ID)
+	if err != nil {
+		return nil, err
+	}
+	if enrolled {
+		return uc.userDetail(ctx, student)
+	}
+
+	reason, err := domain.NormalizeEndReason(req.Reason)
+	if err != nil {
+		return nil, err
+	}
+	if reason == "" {
+		reason = "Transferred to " + class.Name
+	}
+
+	//! 4. Transfert : l'inscription active de l'année est fermée (historique conservé)
+	//! puis la nouvelle est ouverte (ErrClassFull si la classe est complète)
+	err = db.RunInTx(ctx, uc.db, func(ctx context.Context) error {
+		if _, err := uc.studentClassRepo.EndEnrollments(ctx, student.ID, class.AcademicYear, reason); err != nil {
+			return err
+		}
+		if !student.IsApproved() {
+			return uc.studentClassRepo.Create(ctx, student.ID, class.ID)
+		}
+		return uc.studentClassRepo.Enroll(ctx, student.ID, class.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.userDetail(ctx, student)
+}
+
+// ! GetStudentEnrollments historique des inscriptions de l'élève, toutes années
+func (uc *adminUseCase) GetStudentEnrollments(ctx context.Context, adminUserID, studentID int) (*dto.EnrollmentHistoryResponse, error) {
+	admin, err := uc.verifyAdmin(ctx, adminUserID)
+	if err != nil {
+		return nil, err
+	}
+	student, err := uc.findSchoolUser(ctx, admin, studentID)
+	if err != nil {
+		return nil, err
+	}
+	if !student.IsStudent() {
+		return nil, domain.ErrUserNotStudent
+	}
+
+	history, err := uc.studentClassRepo.FindHistory(ctx, student.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.EnrollmentHistoryResponse{
+		StudentID:   student.ID,
+		FullName:    student.GetFullName(),
+		Enrollments: dto.EnrollmentResponsesFromDomain(history),
+	}, nil
+}
+
+// ! ========== WAITLIST ==========
+func (uc *adminUseCase) GetClassWaitlist(ctx context.Context, adminUserID, classID int) ([]dto.WaitlistEntryResponse, error) {
+	admin, err := uc.verifyAdmin(ctx, adminUserID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uc.findSchoolClass(ctx, admin, classID); err != nil {
+		return nil, err
+	}
+
+	entries, err := uc.studentClassRepo.FindWaitlist(ctx, classID)
+	if err != nil {
+		return nil, err
+	}
+	return dto.WaitlistEntryResponsesFromDomain(entries), nil
+}
+
+func (uc *adminUseCase) RemoveFromWaitlist(ctx context.Context, adminUserID, classID, studentID int) error {
+	admin, err := uc.verifyAdmin(ctx, adminUserID)
+	if err != nil {
+		return err
+	}
+	if _, err := uc.findSchoolClass(ctx, admin, classID); err != nil {
+		return err
+	}
+	return uc.studentClassRepo.RemoveFromWaitlist(ctx, studentID, classID)
+}
+
+func (uc *adminUseCase) UpdateTeacherSubjects(ctx context.Context, adminUserID, teacherID int, req *dto.UpdateTeacherSubjectsRequest) (*dto.UserDetailResponse, error) {
+	//! 1. Verify admin + teacher
+	admin, err := uc.verifyAdmin(ctx, adminUserID)
+	if err != nil {
+		return nil, err
+	}
+	teacher, err := uc.findSchoolUser(ctx, admin, teacherID)
+	if err != nil {
+		return nil, err
+	}
+	if !teacher.IsTeacher() {
+		return nil, domain.ErrUserNotTeacher
+	}
+
+	//! 2. Validate subjects
+	if len(req.SubjectIDs) == 0 {
+		return nil, domain.ErrValidation
+	}
+	for _, subjectID := range req.SubjectIDs {
+		subject, err := uc.subjectRepo.FindByID(ctx, subjectID)
+		if errors.Is(err, domain.ErrSubjectNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		if subject.SchoolID != admin.SchoolID {
+			return nil, domain.ErrForbidden
+		}
+	}
+
+	//! 3. Replace assignments
+	err = db.RunInTx(ctx, uc.db, func(ctx context.Context) error {
+		if err := uc.teacherSubjectRepo.DeleteByTeacher(ctx, teacher.ID); err != nil {
+			return err
+		}
+		for _, subjectID := range req.SubjectIDs {
+			if err := uc.teacherSubjectRepo.Create(ctx, teacher.ID, subjectID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.userDetail(ctx, teacher)
+}
+
+// ! ========== BULK ACTIONS ==========
+func (uc *adminUseCase) BulkApproveUsers(ctx context.Context, adminUserID int, req *dto.BulkUserActionRequest) (*dto.BulkUserActionResponse, error) {
+	return uc.bulk(ctx, adminUserID, req, func(ctx context.Context, admin *domain.User, userID int) error {
+		return uc.approveUser(ctx, admin, userID)
+	})
+}
+
+func (uc *adminUseCase) BulkRejectUsers(ctx context.Context, adminUserID int, req *dto.BulkUserActionRequest) (*dto.BulkUserActionResponse, error) {
+	return uc.bulk(ctx, adminUserID, req, func(ctx context.Context, admin *domain.User, userID int) error {
+		return uc.rejectUser(ctx, admin, userID, req.Reason)
+	})
+}
+
+// ! bulk applique action à chaque ID (savepoint par item) et retourne un résultat par item
+func (uc *adminUseCase) bulk(
+	ctx context.Context,
+	adminUserID int,
+	req *dto.BulkUserActionRequest,
+	action func(ctx context.Context, admin *domain.User, userID int) error,
+) (*dto.BulkUserActionResponse, error) {
+	admin, err := uc.verifyAdmin(ctx, adminUserID)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.UserIDs) == 0 {
+		return nil, domain.ErrValidation
+	}
+
+	resp := &dto.BulkUserActionResponse{Results: make([]dto.BulkUserActionResult, 0, len(req.UserIDs))}
+	for _, userID := range req.UserIDs {
+		result := dto.BulkUserActionResult{UserID: userID, Success: true}
+		err := db.RunInTx(ctx, uc.db, func(ctx context.Context) error {
+			return action(ctx, admin, userID)
+		})
+		if err != nil {
+			result.Success = false
+			result.Error = domainMessage(err)
+			//! Erreur technique : message générique côté client, détail dans les logs
+			var domainErr *domain.DomainError
+			if !errors.As(err, &domainErr) {
+				slog.ErrorContext(ctx, "bulk user action failed", "user_id", userID, "error", err)
+			}
+			resp.Failed++
+		} else {
+			resp.Succeeded++
+		}
+		resp.Results = append(resp.Results, result)
+	}
+	return resp, nil
+}
+
+// ! ==================== HELPERS ====================
+func (uc *adminUseCase) verifyAdmin(ctx context.Context, adminUserID int) (*domain.User, error) {
+	admin, err := uc.userRepo.FindByID(ctx, adminUserID)
+	if err != nil {
+		return nil, err
+	}
+	if !admin.IsAdmin() {
+		return nil, domain.ErrForbidden
+	}
+	return admin, nil
+}
+
+// ! findSchoolUser utilisateur de l'école de l'admin (NotFound sinon)
+func (uc *adminUseCase) findSchoolUser(ctx context.Context, admin *domain.User, userID int) (*domain.User, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.SchoolID != admin.SchoolID {
+		return nil, domain.ErrNotFound
+	}
+	return user, nil
+}
+
+// ! findSchoolClass classe de l'école de l'admin (NotFound sinon)
+func (uc *adminUseCase) findSchoolClass(ctx context.Context, admin *domain.User, classID int) (*domain.Class, error) {
+	class, err := uc.classRepo.FindByID(ctx, classID)
+	if errors.Is(err, domain.ErrClassNotFound) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if class.SchoolID != admin.SchoolID {
+		return nil, domain.ErrNotFound
+	}
+	return class, nil
+}
+
+// ! findManagedUser comme findSchoolUser, mais l'admin ne peut pas agir sur son propre compte
+func (uc *adminUseCase) findManagedUser(ctx context.Context, admin *domain.User, userID int) (*domain.User, error) {
+	if userID == admin.ID {
+		return nil, domain.ErrCannotModifySelf
+	}
+	return uc.findSchoolUser(ctx, admin, userID)
+}
+
+func (uc *adminUseCase) approveUser(ctx context.Context, admin *domain.User, targetUserID int) error {
+	//! 2. Get target user
+	targetUser, err := uc.userRepo.FindByID(ctx, targetUserID)
+	if err != nil {
+		return err
+	}
+
+	//! 3. Verify same school
+	if targetUser.SchoolID != admin.SchoolID {
+		return domain.ErrForbidden
+	}
+
+	//! 4. Verify user is pending
+	if !targetUser.IsPending() {
+		return domain.ErrUserNotPending
+	}
+
+	//! 5. Approve user
+	targetUser.Approve()
+
+	//! 6. Save
+	if err := uc.userRepo.Update(ctx, targetUser); err != nil {
+		return err
+	}
+
+	//! 7. Élève : place confirmée dans sa classe, sinon liste d'attente, puis matricule
+	if targetUser.IsStudent() {
+		if _, err := uc.studentClassRepo.ConfirmSeats(ctx, targetUser.ID); err != nil {
+			return err
+		}
+		if _, err := assignStudentNumber(ctx, uc.studentNumberRepo, uc.studentClassRepo, targetUser); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (uc *adminUseCase) rejectUser(ctx context.Context, admin *domain.User, targetUserID int, reason string) error {
+	//! 2. Get target user
+	targetUser, err := uc.userRepo.FindByID(ctx, targetUserID)
+	if err != nil {
+		return err
+	}
+
+	//! 3. Verify same school
+	if targetUser.SchoolID != admin.SchoolID {
+		return domain.ErrForbidden
+	}
+
+	//! 4. Verify user is pending
+	if !targetUser.IsPending() {
+		return domain.ErrUserNotPending
+	}
+
+	//! 5. Reject user
+	targetUser.Reject()
+
+	//! 6. Save (TODO: store rejection reason in a separate table if needed)
+	return uc.userRepo.Update(ctx, targetUser)
+}
+
+func (uc *adminUseCase) userDetail(ctx context.Context, user *domain.User) (*dto.UserDetailResponse, error) {
+	detail := &dto.UserDetailResponse{
+		UserListInfo: dto.UserListInfo{
+			ID:        user.ID,
+			Email:     user.Email,
+			FullName:  user.GetFullName(),
+			Role:      user.Role,
+			Status:    user.Status,
+			Phone:     user.Phone,
+			CreatedAt: user.CreatedAt.Format("2006-01-02 15:04:05"),
+		},
+		SchoolID:           user.SchoolID,
+		AvatarURL:          user.AvatarURL,
+		MustChangePassword: user.MustChangePassword,
+		UpdatedAt:          user.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+
+	switch {
+	case user.IsTeacher():
+		subjects, err := uc.teacherSubjectRepo.FindByTeacher(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		detail.Subjects = dto.SubjectResponsesFromDomain(subjects)
+	case user.IsStudent():
+		classes, err := uc.studentClassRepo.FindByStudent(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		detail.Classes = dto.ClassResponsesFromDomain(classes)
+		if detail.StudentNumber, err = uc.studentNumberRepo.FindNumber(ctx, user.ID); err != nil {
+			return nil, err
+		}
+	}
+	return detail, nil
+}
+
+// ! ==================== STUDENT NUMBERS ====================
+
+// ! maxStudentNumberAttempts valeurs sautées si un matricule saisi à la main occupe déjà la place
+const maxStudentNumberAttempts = 20
+
+func (uc *adminUseCase) GetStudentNumberFormat(ctx context.Context, adminUserID int) (*dto.StudentNumberFormatResponse, error) {
+	admin, err := uc.verifyAdmin(ctx, adminUserID)
+	if err != nil {
+		return nil, err
+	}
+	format, err := uc.studentNumberRepo.FindFormat(ctx, admin.SchoolID)
+	if err != nil {
+		return nil, err
+	}
+	return studentNumberFormatResponse(format)
+}
+
+// ! UpdateStudentNumberFormat s'applique aux prochaines validations ; les matricules attribués ne changent pas
+func (uc *adminUseCase) UpdateStudentNumberFormat(ctx context.Context, adminUserID int, req *dto.StudentNumberFormatRequest) (*dto.StudentNumberFormatResponse, error) {
+	admin, err := uc.verifyAdmin(ctx, adminUserID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := domain.ParseStudentNumberFormat(req.Format); err != nil {
+		return nil, err
+	}
+
+	format := &domain.StudentNumberFormat{
+		SchoolID:  admin.SchoolID,
+		Format:    strings.TrimSpace(req.Format),
+		UpdatedBy: admin.ID,
+	}
+	if err := uc.studentNumberRepo.SaveFormat(ctx, format); err != nil {
+		return nil, err
+	}
+	return studentNumberFormatResponse(format)
+}
+
+// ! AssignStudentNumber rattrapage pour les élèves créés sans validation (inscription libre, import)
+func (uc *adminUseCase) AssignStudentNumber(ctx context.Context, adminUserID, studentID int) (*dto.UserDetailResponse, error) {
+	admin, err := uc.verifyAdmin(ctx, adminUserID)
+	if err != nil {
+		return nil, err
+	}
+	student, err := uc.findSchoolUser(ctx, admin, studentID)
+	if err != nil {
+		return nil, err
+	}
+	if !student.IsStudent() {
+		return nil, domain.ErrUserNotStudent
+	}
+
+	if _, err := assignStudentNumber(ctx, uc.studentNumberRepo, uc.studentClassRepo, student); err != nil {
+		return nil, err
+	}
+	return uc.userDetail(ctx, student)
+}
+
+func (uc *adminUseCase) FindByStudentNumber(ctx context.Context, adminUserID int, number string) (*dto.UserDetailResponse, error) {
+	admin, err := uc.verifyAdmin(ctx, adminUserID)
+	if err != nil {
+		return nil, err
+	}
+	studentID, err := uc.studentNumberRepo.FindStudentID(ctx, admin.SchoolID, strings.TrimSpace(number))
+	if err != nil {
+		return nil, err
+	}
+	student, err := uc.userRepo.FindByID(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.userDetail(ctx, student)
+}
+
+// ! assignStudentNumber matricule existant conservé ; sinon prochaine valeur du compteur de la
+// ! portée (année de rentrée + niveau de la classe). Le compteur ne recule jamais, donc un
+// ! matricule n'est jamais réattribué, même après effacement de l'élève. Appelé dans la
+// ! transaction de chaque chemin qui crée ou rend un élève approuvé.
+func assignStudentNumber(
+	ctx context.Context,
+	studentNumberRepo repository.StudentNumberRepository,
+	studentClassRepo repository.StudentClassRepository,
+	student *domain.User,
+) (string, error) {
+	current, err := studentNumberRepo.FindNumber(ctx, student.ID)
+	if err != nil || current != "" {
+		return current, err
+	}
+
+	format, err := studentNumberRepo.FindFormat(ctx, student.SchoolID)
+	if err != nil {
+		return "", err
+	}
+	template, err := domain.ParseStudentNumberFormat(format.Format)
+	if err != nil {
+		return "", err
+	}
+	classes, err := studentClassRepo.FindByStudent(ctx, student.ID)
+	if err != nil {
+		return "", err
+	}
+	level, academicYear := "", ""
+	if len(classes) > 0 {
+		level, academicYear = classes[0].Level, classes[0].AcademicYear
+	}
+	scope := template.Scope(domain.StudentNumberYear(academicYear, time.Now()), level)
+
+	for attempt := 0; attempt < maxStudentNumberAttempts; attempt++ {
+		seq, err := studentNumberRepo.NextSequence(ctx, student.SchoolID, scope)
+		if err != nil {
+			return "", err
+		}
+		number := template.Number(scope, seq)
+		err = studentNumberRepo.Assign(ctx, student.SchoolID, student.ID, number)
+		if errors.Is(err, domain.ErrStudentNumberTaken) {
+			continue
+		}
+		return number, err
+	}
+	return "", domain.ErrStudentNumberUnavailable
+}
+
+func studentNumberFormatResponse(format *domain.StudentNumberFormat) (*dto.StudentNumberFormatResponse, error) {
+	template, err := domain.ParseStudentNumberFormat(format.Format)
+	if err != nil {
+		return nil, err
+	}
+	resp := &dto.StudentNumberFormatResponse{
+		Format:  format.Format,
+		Example: template.Example(time.Now().Year()),
+	}
+	if !format.UpdatedAt.IsZero() {
+		resp.UpdatedAt = format.UpdatedAt.Format("2006-01-02 15:04:05")
+	}
+	return resp, nil
+}
@@ -0,0 +1,549 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"educnet/internal/db"
+	"educnet/internal/domain"
+	"educnet/internal/handler/dto"
+	"educnet/internal/mailer"
+	"educnet/internal/repository"
+	"educnet/internal/utils"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// ! AnnouncementUseCase annonces de l'administration : ciblage, publication programmée,
+// ! pièces jointes, notification par email et suivi des lectures / accusés de réception
+type AnnouncementUseCase interface {
+	//! Administration
+	CreateAnnouncement(ctx context.Context, adminID int, req *dto.AnnouncementRequest) (*dto.AnnouncementResponse, error)
+	UpdateAnnouncement(ctx context.Context, adminID, announcementID int, req *dto.AnnouncementRequest) (*dto.AnnouncementResponse, error)
+	DeleteAnnouncement(ctx context.Context, adminID, announcementID int) error
+	ListAnnouncements(ctx context.Context, adminID int) ([]dto.AnnouncementResponse, error)
+	GetReport(ctx context.Context, adminID, announcementID int) (*dto.AnnouncementReportResponse, error)
+	AddAttachment(ctx context.Context, adminID, announcementID int, file *dto.UploadedFile) (*dto.AnnouncementResponse, error)
+	DeleteAttachment(ctx context.Context, adminID, announcementID, attachmentID int) error
+
+	//! Destinataires
+	ListMyAnnouncements(ctx context.Context, userID int) ([]dto.AnnouncementResponse, error)
+	GetAnnouncement(ctx context.Context, userID, announcementID int) (*dto.AnnouncementResponse, error)
+	Acknowledge(ctx context.Context, userID, announcementID int) (*dto.AnnouncementResponse, error)
+	DownloadAttachment(ctx context.Context, userID, announcementID, attachmentID int) (*dto.FileResponse, error)
+
+	//! Tâche périodique
+	DeliverScheduled(ctx context.Context) (int, error)
+}
+
+type announcementUseCase struct {
+	db               *sql.DB
+	userRepo         repository.UserRepository
+	schoolRepo       repository.SchoolRepository
+	classRepo        repository.ClassRepository
+	studentClassRepo repository.StudentClassRepository
+	announcementRepo repository.AnnouncementRepository
+	mailer           mailer.Mailer
+	frontendURL      string
+}
+
+func NewAnnouncementUseCase(
+	db *sql.DB,
+	userRepo repository.UserRepository,
+	schoolRepo repository.SchoolRepository,
+	classRepo repository.ClassRepository,
+	studentClassRepo repository.StudentClassRepository,
+	announcementRepo repository.AnnouncementRepository,
+	mailService mailer.Mailer,
+	frontendURL string,
+) AnnouncementUseCase {
+	return &announcementUseCase{
+		db:               db,
+		userRepo:         userRepo,
+		schoolRepo:       schoolRepo,
+		classRepo:        classRepo,
+		studentClassRepo: studentClassRepo,
+		announcementRepo: announcementRepo,
+		mailer:           mailService,
+		frontendURL:      frontendURL,
+	}
+}
+
+// ! ==================== ADMIN ====================
+
+// ! CreateAnnouncement publiée immédiatement : destinataires prévenus après commit ;
+// ! programmée : prévenus par DeliverScheduled à la date de publication
+func (uc *announcementUseCase) CreateAnnouncement(ctx context.Context, adminID int, req *dto.AnnouncementRequest) (*dto.AnnouncementResponse, error) {
+	admin, err := uc.verifyAdmin(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	a, err := uc.buildAnnouncement(ctx, admin, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.announcementRepo.Create(ctx, a); err != nil {
+		return nil, err
+	}
+	if a.IsActive(time.Now()) {
+		if _, err := uc.deliver(ctx, a); err != nil {
+			return nil, err
+		}
+	}
+	response := dto.AnnouncementResponseFromDomain(a)
+	return &response, nil
+}
+
+// ! UpdateAnnouncement une annonce déjà diffusée n'est pas renvoyée
+func (uc *announcementUseCase) UpdateAnnouncement(ctx context.Context, adminID, announcementID int, req *dto.AnnouncementRequest) (*dto.AnnouncementResponse, error) {
+	admin, err := uc.verifyAdmin(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	current, err := uc.findAnnouncement(ctx, admin.SchoolID, announcementID)
+	if err != nil {
+		return nil, err
+	}
+	a, err := uc.buildAnnouncement(ctx, admin, req)
+	if err != nil {
+		return nil, err
+	}
+	a.ID = current.ID
+	a.AuthorID = current.AuthorID
+	a.NotifiedAt = current.NotifiedAt
+	a.CreatedAt = current.CreatedAt
+
+	if err := uc.announcementRepo.Update(ctx, a); err != nil {
+		return nil, err
+	}
+	if a.NotifiedAt == nil && a.IsActive(time.Now()) {
+		if _, err := uc.deliver(ctx, a); err != nil {
+			return nil, err
+		}
+	}
+	return uc.announcementResponse(ctx, a, nil)
+}
+
+// ! DeleteAnnouncement les pièces jointes sont supprimées du disque après validation
+func (uc *announcementUseCase) DeleteAnnouncement(ctx context.Context, adminID, announcementID int) error {
+	admin, err := uc.verifyAdmin(ctx, adminID)
+	if err != nil {
+		return err
+	}
+	a, err := uc.findAnnouncement(ctx, admin.SchoolID, announcementID)
+	if err != nil {
+		return err
+	}
+	files, err := uc.announcementRepo.FindAttachments(ctx, a.ID)
+	if err != nil {
+		return err
+	}
+	if err := uc.announcementRepo.Delete(ctx, a.ID); err != nil {
+		return err
+	}
+
+	db.AfterCommit(ctx, func() {
+		for _, f := range files {
+			os.Remove(f.StoragePath)
+		}
+	})
+	return nil
+}
+
+// ! ListAnnouncements toutes les annonces de l'école (programmées et expirées comprises)
+func (uc *announcementUseCase) ListAnnouncements(ctx context.Context, adminID int) ([]dto.AnnouncementResponse, error) {
+	admin, err := uc.verifyAdmin(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	announcements, err := uc.announcementRepo.FindBySchool(ctx, admin.SchoolID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.AnnouncementResponse, len(announcements))
+	for i, a := range announcements {
+		responses[i] = dto.AnnouncementResponseFromDomain(a)
+	}
+	return responses, nil
+}
+
+// ! GetReport destinataires actuels (comptes approuvés ciblés) et leur état de lecture
+func (uc *announcementUseCase) GetReport(ctx context.Context, adminID, announcementID int) (*dto.AnnouncementReportResponse, error) {
+	admin, err := uc.verifyAdmin(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	a, err := uc.findAnnouncement(ctx, admin.SchoolID, announcementID)
+	if err != nil {
+		return nil, err
+	}
+	recipients, err := uc.announcementRepo.FindRecipients(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &dto.AnnouncementReportResponse{
+		AnnouncementID: a.ID,
+		Recipients:     len(recipients),
+		Unread:         []dto.AnnouncementRecipientResponse{},
+	}
+	if a.RequiresAck {
+		report.Unacknowledged = []dto.AnnouncementRecipientResponse{}
+	}
+	for _, r := range recipients {
+		if r.ReadAt == nil {
+			report.Unread = append(report.Unread, dto.AnnouncementRecipientResponseFromDomain(r))
+		} else {
+			report.Read++
+		}
+		if r.AcknowledgedAt != nil {
+			report.Acknowledged++
+		} else if a.RequiresAck {
+			report.Unacknowledged = append(report.Unacknowledged, dto.AnnouncementRecipientResponseFromDomain(r))
+		}
+	}
+	return report, nil
+}
+
+// ! AddAttachment fichier écrit puis enregistré ; retiré si l'insertion échoue
+func (uc *announcementUseCase) AddAttachment(ctx context.Context, adminID, announcementID int, file *dto.UploadedFile) (*dto.AnnouncementResponse, error) {
+	admin, err := uc.verifyAdmin(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	a, err := uc.findAnnouncement(ctx, admin.SchoolID, announcementID)
+	if err != nil {
+		return nil, err
+	}
+	if file == nil {
+		return nil, domain.ErrAnnouncementFileRequired
+	}
+	files, err := uc.announcementRepo.FindAttachments(ctx, a.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) >= domain.AnnouncementMaxAttachments {
+		return nil, domain.ErrAnnouncementTooManyFiles
+	}
+
+	attachment, err := domain.NewAnnouncementAttachment(a.ID, file.FileName, file.ContentType, file.Size)
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(utils.AnnouncementDir, strconv.Itoa(a.SchoolID))
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("create announcement directory: %w", err)
+	}
+	attachment.StoragePath = filepath.Join(dir, fmt.Sprintf("%d_%d%s", a.ID, time.Now().UnixNano(), attachment.Ext()))
+
+	err = writeLimitedFile(attachment.StoragePath, file.Content, domain.AnnouncementMaxFileSize, domain.ErrAnnouncementFileTooLarge)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.announcementRepo.AddAttachment(ctx, a.SchoolID, attachment); err != nil {
+		os.Remove(attachment.StoragePath)
+		return nil, err
+	}
+	return uc.announcementResponse(ctx, a, nil)
+}
+
+func (uc *announcementUseCase) DeleteAttachment(ctx context.Context, adminID, announcementID, attachmentID int) error {
+	admin, err := uc.verifyAdmin(ctx, adminID)
+	if err != nil {
+		return err
+	}
+	a, err := uc.findAnnouncement(ctx, admin.SchoolID, announcementID)
+	if err != nil {
+		return err
+	}
+	attachment, err := uc.announcementRepo.FindAttachment(ctx, a.ID, attachmentID)
+	if err != nil {
+		return err
+	}
+	if err := uc.announcementRepo.DeleteAttachment(ctx, a.ID, attachment.ID); err != nil {
+		return err
+	}
+
+	db.AfterCommit(ctx, func() {
+		os.Remove(attachment.StoragePath)
+	})
+	return nil
+}
+
+// ! ==================== RECIPIENTS ====================
+
+// ! ListMyAnnouncements annonces actives qui ciblent l'utilisateur, avec son état de lecture
+func (uc *announcementUseCase) ListMyAnnouncements(ctx context.Context, userID int) ([]dto.AnnouncementResponse, error) {
+	user, classes, err := uc.findRecipient(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	active, err := uc.announcementRepo.FindActive(ctx, user.SchoolID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	var mine []*domain.Announcement
+	var ids []int
+	for _, a := range active {
+		if a.Targets(user.Role, classes) {
+			mine = append(mine, a)
+			ids = append(ids, a.ID)
+		}
+	}
+	receipts, err := uc.announcementRepo.FindReceipts(ctx, user.ID, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.AnnouncementResponse, len(mine))
+	for i, a := range mine {
+		responses[i] = dto.AnnouncementResponseFromDomain(a)
+		if receipt, ok := receipts[a.ID]; ok {
+			responses[i].ReadAt = &receipt.ReadAt
+			responses[i].AcknowledgedAt = receipt.AcknowledgedAt
+		}
+	}
+	return responses, nil
+}
+
+// ! GetAnnouncement enregistre la lecture
+func (uc *announcementUseCase) GetAnnouncement(ctx context.Context, userID, announcementID int) (*dto.AnnouncementResponse, error) {
+	user, a, err := uc.findTargetedAnnouncement(ctx, userID, announcementID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.announcementRepo.MarkRead(ctx, user.SchoolID, a.ID, user.ID); err != nil {
+		return nil, err
+	}
+	receipts, err := uc.announcementRepo.FindReceipts(ctx, user.ID, []int{a.ID})
+	if err != nil {
+		return nil, err
+	}
+	return uc.announcementResponse(ctx, a, receipts[a.ID])
+}
+
+func (uc *announcementUseCase) Acknowledge(ctx context.Context, userID, announcementID int) (*dto.AnnouncementResponse, error) {
+	user, a, err := uc.findTargetedAnnouncement(ctx, userID, announcementID)
+	if err != nil {
+		return nil, err
+	}
+	if !a.RequiresAck {
+		return nil, domain.ErrAnnouncementAckNotRequired
+	}
+	receipt, err := uc.announcementRepo.Acknowledge(ctx, user.SchoolID, a.ID, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.announcementResponse(ctx, a, receipt)
+}
+
+// ! DownloadAttachment destinataires de l'annonce active ; les admins, toujours
+func (uc *announcementUseCase) DownloadAttachment(ctx context.Context, userID, announcementID, attachmentID int) (*dto.FileResponse, error) {
+	_, a, err := uc.findTargetedAnnouncement(ctx, userID, announcementID)
+	if err != nil {
+		return nil, err
+	}
+	attachment, err := uc.announcementRepo.FindAttachment(ctx, a.ID, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(attachment.StoragePath)
+	if err != nil {
+		return nil, fmt.Errorf("read announcement attachment: %w", err)
+	}
+	return &dto.FileResponse{FileName: attachment.FileName, ContentType: attachment.ContentType, Data: data}, nil
+}
+
+// ! ==================== DELIVERY ====================
+
+// ! DeliverScheduled tâche périodique : notifie les annonces programmées arrivées à
+// ! publication, une transaction scopée par école. Retourne le nombre d'annonces diffusées.
+func (uc *announcementUseCase) DeliverScheduled(ctx context.Context) (int, error) {
+	//! Annonces de toutes les écoles (accès plateforme), diffusion scopée par école
+	var pending []*domain.Announcement
+	err := db.InPlatformTx(ctx, uc.db, func(ctx context.Context) error {
+		var err error
+		pending, err = uc.announcementRepo.FindUndelivered(ctx, time.Now())
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, a := range pending {
+		err := db.InTenantTx(ctx, uc.db, a.SchoolID, func(ctx context.Context) error {
+			school, err := uc.schoolRepo.FindByID(ctx, a.SchoolID)
+			if err != nil || school.IsSuspended() {
+				return err
+			}
+			ok, err := uc.deliver(ctx, a)
+			if ok {
+				delivered++
+			}
+			return err
+		})
+		if err != nil {
+			return delivered, fmt.Errorf("deliver announcement %d: %w", a.ID, err)
+		}
+	}
+	return delivered, nil
+}
+
+// ! deliver marque l'annonce notifiée puis envoie les emails après commit.
+// ! false si une autre diffusion l'a déjà notifiée.
+func (uc *announcementUseCase) deliver(ctx context.Context, a *domain.Announcement) (bool, error) {
+	ok, err := uc.announcementRepo.MarkNotified(ctx, a.ID)
+	if err != nil || !ok {
+		return false, err
+	}
+	school, err := uc.schoolRepo.FindByID(ctx, a.SchoolID)
+	if err != nil {
+		return false, err
+	}
+	recipients, err := uc.announcementRepo.FindRecipients(ctx, a)
+	if err != nil {
+		return false, err
+	}
+
+	subject := "[" + school.Name + "] " + a.Title
+	link := uc.frontendURL + "/announcements/" + strconv.Itoa(a.ID)
+	db.AfterCommit(ctx, func() {
+		go uc.sendAnnouncement(a, subject, link, recipients)
+	})
+	return true, nil
+}
+
+func (uc *announcementUseCase) sendAnnouncement(a *domain.Announcement, subject, link string, recipients []*domain.AnnouncementRecipient) {
+	ack := ""
+	if a.RequiresAck {
+		ack = "Merci d'en accuser réception sur EducNet.\n\n"
+	}
+	for _, r := range recipients {
+		body := fmt.Sprintf("Bonjour %s,\n\n%s\n\n%sConsulter l'annonce : %s\n", r.FirstName, a.Body, ack, link)
+		if err := uc.mailer.Send(r.Email, subject, body); err != nil {
+			slog.Error("failed to email announcement", "announcement_id", a.ID, "user_id", r.UserID, "error", err)
+		}
+	}
+}
+
+// ! ==================== HELPERS ====================
+
+// ! buildAnnouncement valide la requête ; les classes ciblées doivent appartenir à l'école
+func (uc *announcementUseCase) buildAnnouncement(ctx context.Context, admin *domain.User, req *dto.AnnouncementRequest) (*domain.Announcement, error) {
+	var publishAt time.Time
+	var expiresAt *time.Time
+	var err error
+	if req.PublishAt != "" {
+		if publishAt, err = parseTimestamp(req.PublishAt); err != nil {
+			return nil, domain.ErrAnnouncementInvalidDates
+		}
+	}
+	if req.ExpiresAt != "" {
+		t, err := parseTimestamp(req.ExpiresAt)
+		if err != nil {
+			return nil, domain.ErrAnnouncementInvalidDates
+		}
+		expiresAt = &t
+	}
+
+	a, err := domain.NewAnnouncement(admin.SchoolID, admin.ID, req.Title, req.Body, req.Target, req.TargetRole,
+		req.ClassIDs, req.Levels, publishAt, expiresAt, req.RequiresAck)
+	if err != nil {
+		return nil, err
+	}
+	for _, classID := range a.ClassIDs {
+		class, err := uc.classRepo.FindByID(ctx, classID)
+		if errors.Is(err, domain.ErrClassNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		if class.SchoolID != admin.SchoolID {
+			return nil, domain.ErrNotFound
+		}
+	}
+	return a, nil
+}
+
+func (uc *announcementUseCase) announcementResponse(ctx context.Context, a *domain.Announcement, receipt *domain.AnnouncementReceipt) (*dto.AnnouncementResponse, error) {
+	files, err := uc.announcementRepo.FindAttachments(ctx, a.ID)
+	if err != nil {
+		return nil, err
+	}
+	response := dto.AnnouncementResponseFromDomain(a)
+	response.Attachments = dto.AnnouncementAttachmentResponsesFromDomain(files)
+	if receipt != nil {
+		response.ReadAt = &receipt.ReadAt
+		response.AcknowledgedAt = receipt.AcknowledgedAt
+	}
+	return &response, nil
+}
+
+func (uc *announcementUseCase) verifyAdmin(ctx context.Context, adminID int) (*domain.User, error) {
+	admin, err := uc.userRepo.FindByID(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	if !admin.IsAdmin() {
+		return nil, domain.ErrForbidden
+	}
+	return admin, nil
+}
+
+// ! findRecipient utilisateur de l'école et, pour un élève, ses classes actives
+func (uc *announcementUseCase) findRecipient(ctx context.Context, userID int) (*domain.User, []*domain.Class, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if user.SchoolID == 0 {
+		return nil, nil, domain.ErrForbidden
+	}
+	if !user.IsStudent() {
+		return user, nil, nil
+	}
+	classes, err := uc.studentClassRepo.FindByStudent(ctx, user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, classes, nil
+}
+
+func (uc *announcementUseCase) findAnnouncement(ctx context.Context, schoolID, announcementID int) (*domain.Announcement, error) {
+	a, err := uc.announcementRepo.FindByID(ctx, announcementID)
+	if err != nil {
+		return nil, err
+	}
+	if a.SchoolID != schoolID {
+		return nil, domain.ErrAnnouncementNotFound
+	}
+	return a, nil
+}
+
+// ! findTargetedAnnouncement annonce active ciblant l'utilisateur (les admins voient tout)
+func (uc *announcementUseCase) findTargetedAnnouncement(ctx context.Context, userID, announcementID int) (*domain.User, *domain.Announcement, error) {
+	user, classes, err := uc.findRecipient(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	a, err := uc.findAnnouncement(ctx, user.SchoolID, announcementID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if user.IsAdmin() {
+		return user, a, nil
+	}
+	if !a.IsActive(time.Now()) || !a.Targets(user.Role, classes) {
+		return nil, nil, domain.ErrAnnouncementNotFound
+	}
+	return user, a, nil
+}
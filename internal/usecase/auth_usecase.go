@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"educnet/internal/auth"
 	"educnet/internal/domain"
 	"educnet/internal/handler/dto"
@@ -9,24 +10,26 @@ import (
 )
 
 type AuthUseCase interface {
-	Login(req *dto.LoginRequest) (*dto.LoginResponse, error)
+	Login(ctx context.Context, req *dto.LoginRequest) (*dto.LoginResponse, error)
 }
 
 type authUseCase struct {
 	userRepo   repository.UserRepository
+	schoolRepo repository.SchoolRepository
 	jwtService *auth.JWTService
 }
 
-func NewAuthUseCase(userRepo repository.UserRepository, jwtService *auth.JWTService) AuthUseCase {
+func NewAuthUseCase(userRepo repository.UserRepository, schoolRepo repository.SchoolRepository, jwtService *auth.JWTService) AuthUseCase {
 	return &authUseCase{
 		userRepo:   userRepo,
+		schoolRepo: schoolRepo,
 		jwtService: jwtService,
 	}
 }
 
-func (uc *authUseCase) Login(req *dto.LoginRequest) (*dto.LoginResponse, error) {
+func (uc *authUseCase) Login(ctx context.Context, req *dto.LoginRequest) (*dto.LoginResponse, error) {
 	//! 1. Find user by email
-	user, err := uc.userRepo.FindByEmail(req.Email)
+	user, err := uc.userRepo.FindByEmail(ctx, req.Email)
 	if err != nil {
 		if err == domain.ErrNotFound {
 			return nil, domain.ErrInvalidCredentials
@@ -40,12 +43,30 @@ func (uc *authUseCase) Login(req *dto.LoginRequest) (*dto.LoginResponse, error)
 	}
 
 	//! 3. Check if user is approved (only approved users can login)
+	if user.IsSuspended() {
+		return nil, domain.ErrUserSuspended
+	}
 	if !user.IsApproved() {
 		return nil, errors.New("your account is pending approval")
 	}
 
-	//! 4. Generate tokens
-	accessToken, err := uc.jwtService.GenerateAccessToken(
+	//! 4. Block users of suspended schools (superadmin has no school)
+	if !user.IsSuperAdmin() {
+		school, err := uc.schoolRepo.FindByID(ctx, user.SchoolID)
+		if err != nil {
+			return nil, err
+		}
+		if school.IsSuspended() {
+			return nil, domain.ErrSchoolSuspended
+		}
+	}
+
+	//! 5. Generate tokens (token restreint au changement de mot de passe si requis)
+	generateAccessToken := uc.jwtService.GenerateAccessToken
+	if user.MustChangePassword {
+		generateAccessToken = uc.jwtService.GeneratePasswordChangeToken
+	}
+	accessToken, err := generateAccessToken(
 		user.ID,
 		user.Email,
 		user.Role,
@@ -60,7 +81,7 @@ func (uc *authUseCase) Login(req *dto.LoginRequest) (*dto.LoginResponse, error)
 		return nil, err
 	}
 
-	//! 5. Return response
+	//! 6. Return response
 	return &dto.LoginResponse{
 		User: dto.UserInfo{
 			ID:        user.ID,
@@ -70,6 +91,8 @@ func (uc *authUseCase) Login(req *dto.LoginRequest) (*dto.LoginResponse, error)
 			Status:    user.Status,
 			SchoolID:  user.SchoolID,
 			AvatarURL: user.AvatarURL,
+
+			MustChangePassword: user.MustChangePassword,
 		},
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
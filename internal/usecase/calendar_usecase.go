@@ -0,0 +1,515 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"educnet/internal/auth"
+	"educnet/internal/db"
+	"educnet/internal/domain"
+	"educnet/internal/handler/dto"
+	"educnet/internal/ical"
+	"educnet/internal/repository"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	// ! calendarMaxRange période maximale d'une liste d'occurrences
+	calendarMaxRange = 366 * 24 * time.Hour
+	// ! feedPast / feedFuture fenêtre des événements non récurrents du flux .ics
+	feedPast   = 90 * 24 * time.Hour
+	feedFuture = 400 * 24 * time.Hour
+	// ! quizDueSlot durée affichée d'une échéance de quiz (se termine à la clôture)
+	quizDueSlot = 30 * time.Minute
+)
+
+// ! CalendarUseCase calendrier de l'école (admins, enseignants pour leurs classes)
+// ! et flux iCalendar personnels : événements visibles, emploi du temps, échéances des quiz
+type CalendarUseCase interface {
+	CreateEvent(ctx context.Context, userID int, req *dto.EventRequest) (*dto.EventResponse, error)
+	UpdateEvent(ctx context.Context, userID, eventID int, req *dto.EventRequest) (*dto.EventResponse, error)
+	DeleteEvent(ctx context.Context, userID, eventID int) error
+	GetEvent(ctx context.Context, userID, eventID int) (*dto.EventResponse, error)
+	ListOccurrences(ctx context.Context, userID int, query dto.EventQuery) ([]dto.OccurrenceResponse, error)
+
+	GetFeed(ctx context.Context, userID int) (*dto.CalendarFeedResponse, error)
+	RotateFeed(ctx context.Context, userID int) (*dto.CalendarFeedResponse, error)
+	RevokeFeed(ctx context.Context, userID int) error
+	Feed(ctx context.Context, token string) (*dto.FileResponse, error)
+}
+
+type calendarUseCase struct {
+	db               *sql.DB
+	userRepo         repository.UserRepository
+	schoolRepo       repository.SchoolRepository
+	classRepo        repository.ClassRepository
+	studentClassRepo repository.StudentClassRepository
+	quizRepo         repository.QuizRepository
+	calendarRepo     repository.CalendarRepository
+	roomRepo         repository.RoomRepository
+	jwtService       *auth.JWTService
+}
+
+func NewCalendarUseCase(
+	db *sql.DB,
+	userRepo repository.UserRepository,
+	schoolRepo repository.SchoolRepository,
+	classRepo repository.ClassRepository,
+	studentClassRepo repository.StudentClassRepository,
+	quizRepo repository.QuizRepository,
+	calendarRepo repository.CalendarRepository,
+	roomRepo repository.RoomRepository,
+	jwtService *auth.JWTService,
+) CalendarUseCase {
+	return &calendarUseCase{
+		db:               db,
+		userRepo:         userRepo,
+		schoolRepo:       schoolRepo,
+		classRepo:        classRepo,
+		studentClassRepo: studentClassRepo,
+		quizRepo:         quizRepo,
+		calendarRepo:     calendarRepo,
+		roomRepo:         roomRepo,
+		jwtService:       jwtService,
+	}
+}
+
+// ! ==================== EVENTS ====================
+
+// ! CreateEvent admin : tout événement ; enseignant : événements d'une classe uniquement
+func (uc *calendarUseCase) CreateEvent(ctx context.Context, userID int, req *dto.EventRequest) (*dto.EventResponse, error) {
+	user, err := uc.verifyEditor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	event, err := uc.buildEvent(ctx, user, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.calendarRepo.Create(ctx, event); err != nil {
+		return nil, err
+	}
+	response := dto.EventResponseFromDomain(event)
+	return &response, nil
+}
+
+func (uc *calendarUseCase) UpdateEvent(ctx context.Context, userID, eventID int, req *dto.EventRequest) (*dto.EventResponse, error) {
+	user, event, err := uc.findEditableEvent(ctx, userID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := uc.buildEvent(ctx, user, req)
+	if err != nil {
+		return nil, err
+	}
+	updated.ID = event.ID
+	updated.CreatedBy = event.CreatedBy
+	updated.CreatedAt = event.CreatedAt
+
+	if err := uc.calendarRepo.Update(ctx, updated); err != nil {
+		return nil, err
+	}
+	response := dto.EventResponseFromDomain(updated)
+	return &response, nil
+}
+
+func (uc *calendarUseCase) DeleteEvent(ctx context.Context, userID, eventID int) error {
+	if _, _, err := uc.findEditableEvent(ctx, userID, eventID); err != nil {
+		return err
+	}
+	return uc.calendarRepo.Delete(ctx, eventID)
+}
+
+func (uc *calendarUseCase) GetEvent(ctx context.Context, userID, eventID int) (*dto.EventResponse, error) {
+	user, classIDs, err := uc.findViewer(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	event, err := uc.findEvent(ctx, user.SchoolID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if !event.VisibleTo(user.Role, classIDs) {
+		return nil, domain.ErrEventNotFound
+	}
+	response := dto.EventResponseFromDomain(event)
+	return &response, nil
+}
+
+// ! ListOccurrences occurrences visibles dans la période, récurrences dépliées
+func (uc *calendarUseCase) ListOccurrences(ctx context.Context, userID int, query dto.EventQuery) ([]dto.OccurrenceResponse, error) {
+	user, classIDs, err := uc.findViewer(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to, err := occurrenceRange(query)
+	if err != nil {
+		return nil, err
+	}
+	if query.Category != "" && !domain.IsEventCategory(query.Category) {
+		return nil, domain.ErrEventInvalidCategory
+	}
+
+	filter := domain.EventFilter{SchoolID: user.SchoolID, From: from, To: to, ClassID: query.ClassID, Category: query.Category}
+	if user.IsStudent() {
+		filter.ClassIDs = classIDs
+	}
+	events, err := uc.calendarRepo.FindEvents(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	occurrences := []dto.OccurrenceResponse{}
+	for _, event := range events {
+		if !event.VisibleTo(user.Role, classIDs) {
+			continue
+		}
+		duration := event.EndsAt.Sub(event.StartsAt)
+		for _, start := range event.Occurrences(from, to) {
+			occurrences = append(occurrences, dto.OccurrenceResponse{
+				EventID:   event.ID,
+				ClassID:   event.ClassID,
+				RoomID:    event.RoomID,
+				Title:     event.Title,
+				Location:  event.Location,
+				Category:  event.Category,
+				StartsAt:  start,
+				EndsAt:    start.Add(duration),
+				AllDay:    event.AllDay,
+				Recurring: event.RRule != "",
+			})
+		}
+	}
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].StartsAt.Before(occurrences[j].StartsAt)
+	})
+	return occurrences, nil
+}
+
+// ! ==================== ICS FEEDS ====================
+
+// ! GetFeed URL du flux personnel, créée à la première demande
+func (uc *calendarUseCase) GetFeed(ctx context.Context, userID int) (*dto.CalendarFeedResponse, error) {
+	user, _, err := uc.findViewer(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	feed, err := uc.calendarRepo.FindFeed(ctx, user.ID)
+	if errors.Is(err, domain.ErrCalendarFeedNotFound) {
+		feed, err = uc.calendarRepo.RotateFeed(ctx, user.ID, user.SchoolID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return uc.feedResponse(feed)
+}
+
+// ! RotateFeed nouvelle URL ; l'ancienne cesse immédiatement de fonctionner
+func (uc *calendarUseCase) RotateFeed(ctx context.Context, userID int) (*dto.CalendarFeedResponse, error) {
+	user, _, err := uc.findViewer(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	feed, err := uc.calendarRepo.RotateFeed(ctx, user.ID, user.SchoolID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.feedResponse(feed)
+}
+
+func (uc *calendarUseCase) RevokeFeed(ctx context.Context, userID int) error {
+	return uc.calendarRepo.DeleteFeed(ctx, userID)
+}
+
+// ! Feed calendrier .ics d'un token signé (route publique : transaction scopée sur l'école du token).
+// ! Les événements récurrents sont transmis avec leur RRULE, les applications les déplient.
+func (uc *calendarUseCase) Feed(ctx context.Context, token string) (*dto.FileResponse, error) {
+	claims, err := uc.jwtService.ValidateCalendarToken(token)
+	if err != nil {
+		return nil, domain.ErrCalendarFeedNotFound
+	}
+
+	cal := &ical.Calendar{ProdID: "-//EducNet//Calendar//FR"}
+	err = db.InTenantTx(ctx, uc.db, claims.SchoolID, func(ctx context.Context) error {
+		feed, err := uc.calendarRepo.FindFeed(ctx, claims.UserID)
+		if err != nil {
+			return err
+		}
+		if feed.Version != claims.Version || feed.SchoolID != claims.SchoolID {
+			return domain.ErrCalendarFeedNotFound
+		}
+
+		user, err := uc.userRepo.FindByID(ctx, claims.UserID)
+		if err != nil {
+			return domain.ErrCalendarFeedNotFound
+		}
+		if !user.IsApproved() || user.IsSuspended() {
+			return domain.ErrCalendarFeedNotFound
+		}
+		school, err := uc.schoolRepo.FindByID(ctx, user.SchoolID)
+		if err != nil {
+			return err
+		}
+		if school.IsSuspended() {
+			return domain.ErrSchoolSuspended
+		}
+		cal.Name = school.Name
+
+		cal.Events, err = uc.feedEvents(ctx, user, time.Now().UTC())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := cal.Write(&buf); err != nil {
+		return nil, fmt.Errorf("write calendar: %w", err)
+	}
+	return &dto.FileResponse{FileName: "calendar.ics", ContentType: ical.ContentType, Data: buf.Bytes()}, nil
+}
+
+// ! feedEvents événements visibles + emploi du temps + échéances des quiz
+func (uc *calendarUseCase) feedEvents(ctx context.Context, user *domain.User, now time.Time) ([]ical.Event, error) {
+	var classIDs []int
+	filter := domain.EventFilter{SchoolID: user.SchoolID, From: now.Add(-feedPast), To: now.Add(feedFuture)}
+	switch {
+	case user.IsStudent():
+		classes, err := uc.studentClassRepo.FindByStudent(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		classIDs = classIDsOf(classes)
+		filter.ClassIDs = classIDs
+	case user.IsTeacher():
+		//! Emploi du temps de l'enseignant : événements de classe qu'il a créés
+		filter.ClassEventsBy = user.ID
+	}
+
+	events, err := uc.calendarRepo.FindEvents(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	var feed []ical.Event
+	for _, e := range events {
+		if !e.VisibleTo(user.Role, classIDs) {
+			continue
+		}
+		feed = append(feed, ical.Event{
+			UID:         fmt.Sprintf("event-%d@educnet", e.ID),
+			Summary:     e.Title,
+			Description: e.Description,
+			Location:    e.Location,
+			Categories:  []string{e.Category},
+			Start:       e.StartsAt,
+			End:         e.EndsAt,
+			AllDay:      e.AllDay,
+			RRule:       e.RRule,
+			Updated:     e.UpdatedAt,
+		})
+	}
+
+	//! Échéances : quiz des classes de l'élève, ou créés par l'enseignant
+	if !user.IsStudent() && !user.IsTeacher() {
+		return feed, nil
+	}
+	teacherID := 0
+	if user.IsTeacher() {
+		teacherID = user.ID
+	}
+	quizzes, err := uc.quizRepo.FindClosingBetween(ctx, classIDs, teacherID, filter.From, filter.To)
+	if err != nil {
+		return nil, err
+	}
+	for _, quiz := range quizzes {
+		feed = append(feed, ical.Event{
+			UID:         fmt.Sprintf("quiz-%d@educnet", quiz.ID),
+			Summary:     "Quiz : " + quiz.Title,
+			Description: quiz.Description,
+			Categories:  []string{"quiz"},
+			Start:       quiz.ClosesAt.Add(-quizDueSlot),
+			End:         quiz.ClosesAt,
+			Updated:     quiz.UpdatedAt,
+		})
+	}
+	return feed, nil
+}
+
+func (uc *calendarUseCase) feedResponse(feed *domain.CalendarFeed) (*dto.CalendarFeedResponse, error) {
+	token, err := uc.jwtService.GenerateCalendarToken(feed.UserID, feed.SchoolID, feed.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate calendar token: %w", err)
+	}
+	return &dto.CalendarFeedResponse{Token: token, Version: feed.Version}, nil
+}
+
+// ! ==================== HELPERS ====================
+
+// ! buildEvent valide la requête ; un enseignant ne gère que des événements de classe.
+// ! room_id : salle active de l'école (lieu par défaut = nom de la salle)
+func (uc *calendarUseCase) buildEvent(ctx context.Context, user *domain.User, req *dto.EventRequest) (*domain.Event, error) {
+	if req.ClassID == nil && !user.IsAdmin() {
+		return nil, domain.ErrEventSchoolWide
+	}
+	if req.ClassID != nil {
+		class, err := uc.classRepo.FindByID(ctx, *req.ClassID)
+		if errors.Is(err, domain.ErrClassNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		if class.SchoolID != user.SchoolID {
+			return nil, domain.ErrNotFound
+		}
+	}
+
+	location := req.Location
+	if req.RoomID != nil {
+		room, err := uc.roomRepo.FindRoomByID(ctx, *req.RoomID)
+		if err != nil {
+			return nil, err
+		}
+		if room.SchoolID != user.SchoolID {
+			return nil, domain.ErrRoomNotFound
+		}
+		if !room.IsActive {
+			return nil, domain.ErrBookingUnavailable
+		}
+		if location == "" {
+			location = room.Name
+		}
+	}
+
+	startsAt, endsAt, err := parseEventDates(req)
+	if err != nil {
+		return nil, err
+	}
+	event, err := domain.NewEvent(user.SchoolID, req.ClassID, req.Title, req.Description, location,
+		req.Category, req.Audience, startsAt, endsAt, req.AllDay, req.RRule, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	event.RoomID = req.RoomID
+	return event, nil
+}
+
+// ! parseEventDates RFC 3339, ou jours YYYY-MM-DD si all_day (dernier jour inclus)
+func parseEventDates(req *dto.EventRequest) (time.Time, time.Time, error) {
+	if req.AllDay {
+		start, err := parseDay(req.StartsAt)
+		if err != nil {
+			return time.Time{}, time.Time{}, domain.ErrEventInvalidDates
+		}
+		end := start
+		if req.EndsAt != "" {
+			if end, err = parseDay(req.EndsAt); err != nil {
+				return time.Time{}, time.Time{}, domain.ErrEventInvalidDates
+			}
+		}
+		return start, end.AddDate(0, 0, 1), nil
+	}
+
+	start, err := parseTimestamp(req.StartsAt)
+	if err != nil {
+		return time.Time{}, time.Time{}, domain.ErrEventInvalidDates
+	}
+	end, err := parseTimestamp(req.EndsAt)
+	if err != nil {
+		return time.Time{}, time.Time{}, domain.ErrEventInvalidDates
+	}
+	return start, end, nil
+}
+
+// ! occurrenceRange [from, to) en jours ; défaut 30 jours à partir d'aujourd'hui, un an maximum
+func occurrenceRange(query dto.EventQuery) (time.Time, time.Time, error) {
+	from, err := parseOptionalDay(query.From)
+	if err != nil {
+		return time.Time{}, time.Time{}, domain.ErrEventInvalidDates
+	}
+	if from.IsZero() {
+		from = time.Now().UTC().Truncate(24 * time.Hour)
+	}
+	to, err := parseOptionalDay(query.To)
+	if err != nil {
+		return time.Time{}, time.Time{}, domain.ErrEventInvalidDates
+	}
+	if to.IsZero() {
+		to = from.AddDate(0, 0, 30)
+	}
+	if !to.After(from) || to.Sub(from) > calendarMaxRange {
+		return time.Time{}, time.Time{}, domain.ErrEventInvalidDates
+	}
+	return from, to, nil
+}
+
+// ! verifyEditor admins et enseignants
+func (uc *calendarUseCase) verifyEditor(ctx context.Context, userID int) (*domain.User, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsAdmin() && !user.IsTeacher() {
+		return nil, domain.ErrForbidden
+	}
+	return user, nil
+}
+
+// ! findViewer utilisateur de l'école et, pour un élève, ses classes
+func (uc *calendarUseCase) findViewer(ctx context.Context, userID int) (*domain.User, []int, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if user.SchoolID == 0 {
+		return nil, nil, domain.ErrForbidden
+	}
+	if !user.IsStudent() {
+		return user, nil, nil
+	}
+	classes, err := uc.studentClassRepo.FindByStudent(ctx, user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, classIDsOf(classes), nil
+}
+
+func (uc *calendarUseCase) findEvent(ctx context.Context, schoolID, eventID int) (*domain.Event, error) {
+	event, err := uc.calendarRepo.FindByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if event.SchoolID != schoolID {
+		return nil, domain.ErrEventNotFound
+	}
+	return event, nil
+}
+
+// ! findEditableEvent admin : tous ; enseignant : ses événements de classe
+func (uc *calendarUseCase) findEditableEvent(ctx context.Context, userID, eventID int) (*domain.User, *domain.Event, error) {
+	user, err := uc.verifyEditor(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	event, err := uc.findEvent(ctx, user.SchoolID, eventID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !user.IsAdmin() && event.CreatedBy != user.ID {
+		return nil, nil, domain.ErrForbidden
+	}
+	return user, event, nil
+}
+
+func classIDsOf(classes []*domain.Class) []int {
+	ids := make([]int, len(classes))
+	for i, class := range classes {
+		ids[i] = class.ID
+	}
+	return ids
+}
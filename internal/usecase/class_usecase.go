@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"educnet/internal/handler/dto"
 	"educnet/internal/repository"
 	"fmt"
@@ -8,7 +9,7 @@ import (
 
 // ! ClassUseCase interface
 type ClassUseCase interface {
-	GetAllBySchoolID(schoolID int) ([]*dto.ClassInfo, error)
+	GetAllBySchoolID(ctx context.Context, schoolID int) ([]*dto.ClassInfo, error)
 }
 
 // ! classUseCase implémentation
@@ -22,8 +23,8 @@ func NewClassUsecase(classRepo repository.ClassRepository) ClassUseCase {
 	}
 }
 
-func (uc *classUseCase) GetAllBySchoolID(schoolID int) ([]*dto.ClassInfo, error) {
-	classes, err := uc.classRepo.FindBySchoolID(schoolID)
+func (uc *classUseCase) GetAllBySchoolID(ctx context.Context, schoolID int) ([]*dto.ClassInfo, error) {
+	classes, err := uc.classRepo.FindBySchoolID(ctx, schoolID)
 	if err != nil {
 		return nil, fmt.Errorf("[ERROR_GET_CLASSES_BY_SCHOOL_ID]: %w", err)
 	}
@@ -0,0 +1,601 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"educnet/internal/db"
+	"educnet/internal/domain"
+	"educnet/internal/handler/dto"
+	"educnet/internal/mailer"
+	"educnet/internal/repository"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+)
+
+// ! DisciplineUseCase incidents disciplinaires signalés par les enseignants, escalade vers la
+// ! direction (manuelle ou automatique selon les seuils de l'école), sanctions et synthèses
+type DisciplineUseCase interface {
+	//! Enseignants et admins
+	ReportIncident(ctx context.Context, userID int, req *dto.IncidentRequest) (*dto.IncidentResponse, error)
+	ListMyIncidents(ctx context.Context, userID int) ([]dto.IncidentResponse, error)
+	EscalateIncident(ctx context.Context, userID, incidentID int, req *dto.EscalateIncidentRequest) (*dto.IncidentResponse, error)
+	AddSanction(ctx context.Context, userID, incidentID int, req *dto.SanctionRequest) (*dto.IncidentResponse, error)
+
+	//! Administration
+	ListIncidents(ctx context.Context, adminID int, query dto.IncidentQuery) ([]dto.IncidentResponse, error)
+	GetIncident(ctx context.Context, adminID, incidentID int) (*dto.IncidentResponse, error)
+	CloseIncident(ctx context.Context, adminID, incidentID int, req *dto.CloseIncidentRequest) (*dto.IncidentResponse, error)
+	DeleteSanction(ctx context.Context, adminID, incidentID, sanctionID int) error
+	GetStudentHistory(ctx context.Context, adminID, studentID int) (*dto.StudentDisciplineResponse, error)
+	GetClassSummary(ctx context.Context, adminID, classID int, from, to string) (*dto.ClassDisciplineSummaryResponse, error)
+	GetSettings(ctx context.Context, adminID int) (*domain.DisciplineSettings, error)
+	UpdateSettings(ctx context.Context, adminID int, req *dto.DisciplineSettingsRequest) (*domain.DisciplineSettings, error)
+
+	//! Élèves
+	ListStudentIncidents(ctx context.Context, studentID int) ([]dto.IncidentResponse, error)
+}
+
+type disciplineUseCase struct {
+	db               *sql.DB
+	userRepo         repository.UserRepository
+	schoolRepo       repository.SchoolRepository
+	classRepo        repository.ClassRepository
+	studentClassRepo repository.StudentClassRepository
+	profileRepo      repository.ProfileRepository
+	disciplineRepo   repository.DisciplineRepository
+	mailer           mailer.Mailer
+	frontendURL      string
+}
+
+func NewDisciplineUseCase(
+	db *sql.DB,
+	userRepo repository.UserRepository,
+	schoolRepo repository.SchoolRepository,
+	classRepo repository.ClassRepository,
+	studentClassRepo repository.StudentClassRepository,
+	profileRepo repository.ProfileRepository,
+	disciplineRepo repository.DisciplineRepository,
+	mailService mailer.Mailer,
+	frontendURL string,
+) DisciplineUseCase {
+	return &disciplineUseCase{
+		db:               db,
+		userRepo:         userRepo,
+		schoolRepo:       schoolRepo,
+		classRepo:        classRepo,
+		studentClassRepo: studentClassRepo,
+		profileRepo:      profileRepo,
+		disciplineRepo:   disciplineRepo,
+		mailer:           mailService,
+		frontendURL:      frontendURL,
+	}
+}
+
+// ! ==================== INCIDENTS ====================
+
+// ! ReportIncident l'élève doit être inscrit dans la classe ; escalade automatique si la gravité
+// ! ou le nombre d'incidents récents de l'élève atteint les seuils de l'école
+func (uc *disciplineUseCase) ReportIncident(ctx context.Context, userID int, req *dto.IncidentRequest) (*dto.IncidentResponse, error) {
+	reporter, err := uc.verifyStaff(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	class, err := uc.findClass(ctx, reporter.SchoolID, req.ClassID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.verifyEnrolled(ctx, req.StudentID, class.ID); err != nil {
+		return nil, err
+	}
+	occurredAt, err := parseTimestamp(req.OccurredAt)
+	if err != nil {
+		return nil, domain.ErrIncidentInvalidDate
+	}
+	now := time.Now()
+	incident, err := domain.NewIncident(reporter.SchoolID, req.StudentID, class.ID, reporter.ID, occurredAt,
+		req.Location, req.Description, req.Severity, req.Confidential, now)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.RunInTx(ctx, uc.db, func(ctx context.Context) error {
+		settings, err := uc.disciplineRepo.FindSettings(ctx, reporter.SchoolID)
+		if err != nil {
+			return err
+		}
+		recent, err := uc.disciplineRepo.CountSince(ctx, incident.StudentID, now.AddDate(0, 0, -settings.EscalateWindow))
+		if err != nil {
+			return err
+		}
+		if settings.RequiresEscalation(incident.Severity, recent+1) {
+			reason := fmt.Sprintf("Escalade automatique : %d incident(s) sur %d jours, gravité %s",
+				recent+1, settings.EscalateWindow, incident.Severity)
+			if err := incident.Escalate(nil, reason, now); err != nil {
+				return err
+			}
+		}
+		if err := uc.disciplineRepo.CreateIncident(ctx, incident); err != nil {
+			return err
+		}
+		if incident.Status == domain.IncidentEscalated {
+			return uc.notifyEscalation(ctx, incident)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return uc.incidentResponse(ctx, incident.ID)
+}
+
+// ! ListMyIncidents incidents signalés par l'utilisateur
+func (uc *disciplineUseCase) ListMyIncidents(ctx context.Context, userID int) ([]dto.IncidentResponse, error) {
+	user, err := uc.verifyStaff(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	incidents, err := uc.disciplineRepo.FindIncidents(ctx, domain.IncidentFilter{SchoolID: user.SchoolID, ReportedBy: user.ID})
+	if err != nil {
+		return nil, err
+	}
+	return dto.IncidentResponsesFromDomain(incidents), nil
+}
+
+// ! EscalateIncident par l'auteur du signalement ou un admin ; la direction est prévenue par email
+func (uc *disciplineUseCase) EscalateIncident(ctx context.Context, userID, incidentID int, req *dto.EscalateIncidentRequest) (*dto.IncidentResponse, error) {
+	user, err := uc.verifyStaff(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	incident, err := uc.findIncident(ctx, user, incidentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := incident.Escalate(&user.ID, req.Reason, time.Now()); err != nil {
+		return nil, err
+	}
+
+	err = db.RunInTx(ctx, uc.db, func(ctx context.Context) error {
+		if err := uc.disciplineRepo.UpdateIncident(ctx, incident); err != nil {
+			return err
+		}
+		return uc.notifyEscalation(ctx, incident)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return uc.incidentResponse(ctx, incident.ID)
+}
+
+// ! AddSanction les enseignants sanctionnent leurs propres signalements, hors exclusion ;
+// ! l'élève et ses responsables sont prévenus selon la visibilité configurée
+func (uc *disciplineUseCase) AddSanction(ctx context.Context, userID, incidentID int, req *dto.SanctionRequest) (*dto.IncidentResponse, error) {
+	user, err := uc.verifyStaff(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	incident, err := uc.findIncident(ctx, user, incidentID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsAdmin() && domain.SanctionRequiresAdmin(req.Type) {
+		return nil, domain.ErrSanctionAdminOnly
+	}
+	startsOn, endsOn, err := sanctionDates(req)
+	if err != nil {
+		return nil, err
+	}
+	sanction, err := domain.NewSanction(incident, req.Type, startsOn, endsOn, req.Notes, user.ID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.RunInTx(ctx, uc.db, func(ctx context.Context) error {
+		if err := uc.disciplineRepo.CreateSanction(ctx, sanction); err != nil {
+			return err
+		}
+		incident.Sanctions = append(incident.Sanctions, sanction)
+		return uc.notifySanction(ctx, incident, sanction)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return uc.incidentResponse(ctx, incident.ID)
+}
+
+// ! ==================== ADMIN ====================
+
+func (uc *disciplineUseCase) ListIncidents(ctx context.Context, adminID int, query dto.IncidentQuery) ([]dto.IncidentResponse, error) {
+	admin, err := uc.verifyAdmin(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	from, to, err := incidentPeriod(query.From, query.To)
+	if err != nil {
+		return nil, err
+	}
+	incidents, err := uc.disciplineRepo.FindIncidents(ctx, domain.IncidentFilter{
+		SchoolID:  admin.SchoolID,
+		StudentID: query.StudentID,
+		ClassID:   query.ClassID,
+		Status:    query.Status,
+		Severity:  query.Severity,
+		From:      from,
+		To:        to,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dto.IncidentResponsesFromDomain(incidents), nil
+}
+
+func (uc *disciplineUseCase) GetIncident(ctx context.Context, adminID, incidentID int) (*dto.IncidentResponse, error) {
+	admin, err := uc.verifyAdmin(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	incident, err := uc.findIncident(ctx, admin, incidentID)
+	if err != nil {
+		return nil, err
+	}
+	response := dto.IncidentResponseFromDomain(incident)
+	return &response, nil
+}
+
+// ! CloseIncident résolution ou classement sans suite, réservé à l'administration
+func (uc *disciplineUseCase) CloseIncident(ctx context.Context, adminID, incidentID int, req *dto.CloseIncidentRequest) (*dto.IncidentResponse, error) {
+	admin, err := uc.verifyAdmin(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	incident, err := uc.findIncident(ctx, admin, incidentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := incident.Close(admin.ID, req.Resolution, req.Dismiss, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := uc.disciplineRepo.UpdateIncident(ctx, incident); err != nil {
+		return nil, err
+	}
+	response := dto.IncidentResponseFromDomain(incident)
+	return &response, nil
+}
+
+func (uc *disciplineUseCase) DeleteSanction(ctx context.Context, adminID, incidentID, sanctionID int) error {
+	admin, err := uc.verifyAdmin(ctx, adminID)
+	if err != nil {
+		return err
+	}
+	if _, err := uc.findIncident(ctx, admin, incidentID); err != nil {
+		return err
+	}
+	return uc.disciplineRepo.DeleteSanction(ctx, incidentID, sanctionID)
+}
+
+// ! GetStudentHistory tous les incidents de l'élève (toutes classes) et leur synthèse
+func (uc *disciplineUseCase) GetStudentHistory(ctx context.Context, adminID, studentID int) (*dto.StudentDisciplineResponse, error) {
+	admin, err := uc.verifyAdmin(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	student, err := uc.userRepo.FindByID(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	if student.SchoolID != admin.SchoolID {
+		return nil, domain.ErrUserNotFound
+	}
+	if !student.IsStudent() {
+		return nil, domain.ErrUserNotStudent
+	}
+
+	incidents, err := uc.disciplineRepo.FindIncidents(ctx, domain.IncidentFilter{SchoolID: admin.SchoolID, StudentID: student.ID})
+	if err != nil {
+		return nil, err
+	}
+	return &dto.StudentDisciplineResponse{
+		StudentID:   student.ID,
+		StudentName: student.GetFullName(),
+		Summary:     domain.SummarizeIncidents(incidents),
+		Incidents:   dto.IncidentResponsesFromDomain(incidents),
+	}, nil
+}
+
+// ! GetClassSummary synthèse des incidents de la classe sur [from, to] (YYYY-MM-DD, optionnels)
+func (uc *disciplineUseCase) GetClassSummary(ctx context.Context, adminID, classID int, from, to string) (*dto.ClassDisciplineSummaryResponse, error) {
+	admin, err := uc.verifyAdmin(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	class, err := uc.findClass(ctx, admin.SchoolID, classID)
+	if err != nil {
+		return nil, err
+	}
+	start, end, err := incidentPeriod(from, to)
+	if err != nil {
+		return nil, err
+	}
+	incidents, err := uc.disciplineRepo.FindIncidents(ctx, domain.IncidentFilter{
+		SchoolID: admin.SchoolID,
+		ClassID:  class.ID,
+		From:     start,
+		To:       end,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &dto.ClassDisciplineSummaryResponse{
+		ClassID:   class.ID,
+		ClassName: class.Name,
+		From:      from,
+		To:        to,
+		Summary:   domain.SummarizeIncidents(incidents),
+	}, nil
+}
+
+func (uc *disciplineUseCase) GetSettings(ctx context.Context, adminID int) (*domain.DisciplineSettings, error) {
+	admin, err := uc.verifyAdmin(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.disciplineRepo.FindSettings(ctx, admin.SchoolID)
+}
+
+func (uc *disciplineUseCase) UpdateSettings(ctx context.Context, adminID int, req *dto.DisciplineSettingsRequest) (*domain.DisciplineSettings, error) {
+	admin, err := uc.verifyAdmin(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	settings := &domain.DisciplineSettings{
+		SchoolID:          admin.SchoolID,
+		StudentVisibility: req.StudentVisibility,
+		ParentVisibility:  req.ParentVisibility,
+		EscalateSeverity:  req.EscalateSeverity,
+		EscalateThreshold: req.EscalateThreshold,
+		EscalateWindow:    req.EscalateWindow,
+		UpdatedBy:         admin.ID,
+	}
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+	if err := uc.disciplineRepo.SaveSettings(ctx, settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// ! ==================== STUDENT ====================
+
+// ! ListStudentIncidents incidents visibles par l'élève selon le réglage de l'école
+// ! (jamais les confidentiels ni les classés sans suite)
+func (uc *disciplineUseCase) ListStudentIncidents(ctx context.Context, studentID int) ([]dto.IncidentResponse, error) {
+	student, err := uc.userRepo.FindByID(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	if !student.IsStudent() {
+		return nil, domain.ErrForbidden
+	}
+	settings, err := uc.disciplineRepo.FindSettings(ctx, student.SchoolID)
+	if err != nil {
+		return nil, err
+	}
+	incidents, err := uc.disciplineRepo.FindIncidents(ctx, domain.IncidentFilter{SchoolID: student.SchoolID, StudentID: student.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	visible := make([]*domain.Incident, 0, len(incidents))
+	for _, incident := range incidents {
+		if incident.VisibleWith(settings.StudentVisibility) {
+			visible = append(visible, incident)
+		}
+	}
+	return dto.StudentIncidentResponsesFromDomain(visible), nil
+}
+
+// ! ==================== NOTIFICATIONS ====================
+
+// ! notifyEscalation prévient les admins de l'école après commit
+func (uc *disciplineUseCase) notifyEscalation(ctx context.Context, incident *domain.Incident) error {
+	school, err := uc.schoolRepo.FindByID(ctx, incident.SchoolID)
+	if err != nil {
+		return err
+	}
+	admins, err := uc.userRepo.FindBySchool(ctx, incident.SchoolID, map[string]string{
+		"role":   domain.RoleAdmin,
+		"status": domain.UserStatusApproved,
+	})
+	if err != nil {
+		return err
+	}
+	student, err := uc.userRepo.FindByID(ctx, incident.StudentID)
+	if err != nil {
+		return err
+	}
+
+	subject := "[" + school.Name + "] Incident à traiter : " + student.GetFullName()
+	body := fmt.Sprintf("Un incident (gravité %s) concernant %s a été transmis à la direction.\n\n%s\n\nMotif : %s\n\nConsulter l'incident : %s\n",
+		incident.Severity, student.GetFullName(), incident.Description, incident.EscalationReason,
+		uc.frontendURL+"/admin/incidents/"+strconv.Itoa(incident.ID))
+	recipients := make([]string, 0, len(admins))
+	for _, admin := range admins {
+		recipients = append(recipients, admin.Email)
+	}
+	db.AfterCommit(ctx, func() {
+		go uc.sendDiscipline(incident.ID, subject, body, recipients)
+	})
+	return nil
+}
+
+// ! notifySanction prévient l'élève et ses responsables (email du profil) si l'incident
+// ! leur est visible ; les incidents confidentiels ne sont jamais communiqués
+func (uc *disciplineUseCase) notifySanction(ctx context.Context, incident *domain.Incident, sanction *domain.Sanction) error {
+	settings, err := uc.disciplineRepo.FindSettings(ctx, incident.SchoolID)
+	if err != nil {
+		return err
+	}
+	toStudent := incident.VisibleWith(settings.StudentVisibility)
+	toGuardians := incident.VisibleWith(settings.ParentVisibility)
+	if !toStudent && !toGuardians {
+		return nil
+	}
+	school, err := uc.schoolRepo.FindByID(ctx, incident.SchoolID)
+	if err != nil {
+		return err
+	}
+	student, err := uc.userRepo.FindByID(ctx, incident.StudentID)
+	if err != nil {
+		return err
+	}
+
+	var recipients []string
+	if toStudent {
+		recipients = append(recipients, student.Email)
+	}
+	if toGuardians {
+		profile, err := uc.profileRepo.FindStudentProfile(ctx, student.ID)
+		if err != nil {
+			return err
+		}
+		for _, g := range profile.Guardians {
+			if g.Email != "" {
+				recipients = append(recipients, g.Email)
+			}
+		}
+	}
+
+	subject := "[" + school.Name + "] Sanction : " + student.GetFullName()
+	body := fmt.Sprintf("Une sanction (%s) a été prononcée à l'encontre de %s suite à l'incident du %s.\n\n%s\n",
+		sanction.Type, student.GetFullName(), incident.OccurredAt.Format("02/01/2006"), incident.Description)
+	if sanction.StartsOn != nil {
+		body += fmt.Sprintf("\nDu %s au %s inclus.\n", sanction.StartsOn.Format("02/01/2006"), sanction.EndsOn.Format("02/01/2006"))
+	}
+	db.AfterCommit(ctx, func() {
+		go uc.sendDiscipline(incident.ID, subject, body, recipients)
+	})
+	return nil
+}
+
+func (uc *disciplineUseCase) sendDiscipline(incidentID int, subject, body string, recipients []string) {
+	for _, to := range recipients {
+		if err := uc.mailer.Send(to, subject, "Bonjour,\n\n"+body); err != nil {
+			slog.Error("failed to email discipline notification", "incident_id", incidentID, "error", err)
+		}
+	}
+}
+
+// ! ==================== HELPERS ====================
+
+// ! verifyStaff enseignant ou admin
+func (uc *disciplineUseCase) verifyStaff(ctx context.Context, userID int) (*domain.User, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsTeacher() && !user.IsAdmin() {
+		return nil, domain.ErrForbidden
+	}
+	return user, nil
+}
+
+func (uc *disciplineUseCase) verifyAdmin(ctx context.Context, adminID int) (*domain.User, error) {
+	admin, err := uc.userRepo.FindByID(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	if !admin.IsAdmin() {
+		return nil, domain.ErrForbidden
+	}
+	return admin, nil
+}
+
+func (uc *disciplineUseCase) verifyEnrolled(ctx context.Context, studentID, classID int) error {
+	enrolled, err := uc.studentClassRepo.Exists(ctx, studentID, classID)
+	if err != nil {
+		return err
+	}
+	if !enrolled {
+		return domain.ErrStudentClassNotFound
+	}
+	return nil
+}
+
+// ! findClass classe de l'école (NotFound sinon)
+func (uc *disciplineUseCase) findClass(ctx context.Context, schoolID, classID int) (*domain.Class, error) {
+	class, err := uc.classRepo.FindByID(ctx, classID)
+	if errors.Is(err, domain.ErrClassNotFound) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if class.SchoolID != schoolID {
+		return nil, domain.ErrNotFound
+	}
+	return class, nil
+}
+
+// ! findIncident incident de l'école ; un enseignant n'accède qu'à ses propres signalements
+func (uc *disciplineUseCase) findIncident(ctx context.Context, user *domain.User, incidentID int) (*domain.Incident, error) {
+	incident, err := uc.disciplineRepo.FindIncident(ctx, incidentID)
+	if err != nil {
+		return nil, err
+	}
+	if incident.SchoolID != user.SchoolID || (!user.IsAdmin() && incident.ReportedBy != user.ID) {
+		return nil, domain.ErrIncidentNotFound
+	}
+	return incident, nil
+}
+
+func (uc *disciplineUseCase) incidentResponse(ctx context.Context, incidentID int) (*dto.IncidentResponse, error) {
+	incident, err := uc.disciplineRepo.FindIncident(ctx, incidentID)
+	if err != nil {
+		return nil, err
+	}
+	response := dto.IncidentResponseFromDomain(incident)
+	return &response, nil
+}
+
+// ! sanctionDates dates YYYY-MM-DD optionnelles de la requête
+func sanctionDates(req *dto.SanctionRequest) (*time.Time, *time.Time, error) {
+	start, err := parseOptionalDay(req.StartsOn)
+	if err != nil {
+		return nil, nil, domain.ErrSanctionInvalidDates
+	}
+	end, err := parseOptionalDay(req.EndsOn)
+	if err != nil {
+		return nil, nil, domain.ErrSanctionInvalidDates
+	}
+	var startsOn, endsOn *time.Time
+	if !start.IsZero() {
+		startsOn = &start
+	}
+	if !end.IsZero() {
+		endsOn = &end
+	}
+	return startsOn, endsOn, nil
+}
+
+// ! incidentPeriod bornes [from, to] YYYY-MM-DD converties en [from, to+1j)
+func incidentPeriod(from, to string) (time.Time, time.Time, error) {
+	start, err := parseOptionalDay(from)
+	if err != nil {
+		return time.Time{}, time.Time{}, domain.ErrIncidentInvalidDate
+	}
+	end, err := parseOptionalDay(to)
+	if err != nil {
+		return time.Time{}, time.Time{}, domain.ErrIncidentInvalidDate
+	}
+	if !end.IsZero() {
+		end = end.AddDate(0, 0, 1)
+	}
+	if !start.IsZero() && !end.IsZero() && !start.Before(end) {
+		return time.Time{}, time.Time{}, domain.ErrIncidentInvalidDate
+	}
+	return start, end, nil
+}
@@ -0,0 +1,186 @@
+package usecase
+
+import (
+	"context"
+	"educnet/internal/domain"
+	"educnet/internal/export"
+	"educnet/internal/repository"
+	"educnet/internal/utils"
+	"errors"
+	"strconv"
+)
+
+// ! exportTimeLayout horodatages des exports (heure locale du serveur)
+const exportTimeLayout = "2006-01-02 15:04:05"
+
+// ! ExportUseCase exports tableur (admin). Les vérifications d'accès sont faites
+// ! à la préparation ; les lignes sont lues en base pendant l'écriture de la réponse.
+type ExportUseCase interface {
+	Users(ctx context.Context, adminUserID int, filters map[string]string) (*export.Table, error)
+	ClassRoster(ctx context.Context, adminUserID, classID int) (*export.Table, error)
+	TeacherSubjects(ctx context.Context, adminUserID int) (*export.Table, error)
+	ClassMessages(ctx context.Context, adminUserID, classID int) (*export.Table, error)
+}
+
+type exportUseCase struct {
+	userRepo           repository.UserRepository
+	classRepo          repository.ClassRepository
+	studentClassRepo   repository.StudentClassRepository
+	teacherSubjectRepo repository.TeacherSubjectRepository
+	messageRepo        repository.MessageRepository
+	studentNumberRepo  repository.StudentNumberRepository
+}
+
+func NewExportUseCase(
+	userRepo repository.UserRepository,
+	classRepo repository.ClassRepository,
+	studentClassRepo repository.StudentClassRepository,
+	teacherSubjectRepo repository.TeacherSubjectRepository,
+	messageRepo repository.MessageRepository,
+	studentNumberRepo repository.StudentNumberRepository,
+) ExportUseCase {
+	return &exportUseCase{
+		userRepo:           userRepo,
+		classRepo:          classRepo,
+		studentClassRepo:   studentClassRepo,
+		teacherSubjectRepo: teacherSubjectRepo,
+		messageRepo:        messageRepo,
+		studentNumberRepo:  studentNumberRepo,
+	}
+}
+
+// ! Users mêmes filtres que GetAllUsers (role, status, student_number)
+func (uc *exportUseCase) Users(ctx context.Context, adminUserID int, filters map[string]string) (*export.Table, error) {
+	admin, err := uc.verifyAdmin(ctx, adminUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &export.Table{
+		Name:    "users",
+		Columns: []string{"user_id", "student_number", "email", "last_name", "first_name", "phone", "role", "status", "created_at"},
+		Rows: func(ctx context.Context, emit func([]string) error) error {
+			numbers, err := uc.studentNumberRepo.FindBySchool(ctx, admin.SchoolID)
+			if err != nil {
+				return err
+			}
+			return uc.userRepo.EachBySchool(ctx, admin.SchoolID, filters, func(u *domain.User) error {
+				return emit([]string{
+					strconv.Itoa(u.ID), numbers[u.ID], u.Email, u.LastName, u.FirstName, u.Phone,
+					u.Role, u.Status, u.CreatedAt.Format(exportTimeLayout),
+				})
+			})
+		},
+	}, nil
+}
+
+func (uc *exportUseCase) ClassRoster(ctx context.Context, adminUserID, classID int) (*export.Table, error) {
+	admin, err := uc.verifyAdmin(ctx, adminUserID)
+	if err != nil {
+		return nil, err
+	}
+	class, err := uc.findSchoolClass(ctx, admin, classID)
+	if err != nil {
+		return nil, err
+	}
+
+	classIDValue := strconv.Itoa(class.ID)
+	return &export.Table{
+		Name: "roster-" + utils.CreateSlug(class.Name),
+		Columns: []string{"class_id", "class_name", "academic_year", "student_id", "student_number",
+			"last_name", "first_name", "email", "phone", "status"},
+		Rows: func(ctx context.Context, emit func([]string) error) error {
+			numbers, err := uc.studentNumberRepo.FindBySchool(ctx, class.SchoolID)
+			if err != nil {
+				return err
+			}
+			return uc.studentClassRepo.EachByClass(ctx, class.ID, func(s *domain.User) error {
+				return emit([]string{
+					classIDValue, class.Name, class.AcademicYear,
+					strconv.Itoa(s.ID), numbers[s.ID], s.LastName, s.FirstName, s.Email, s.Phone, s.Status,
+				})
+			})
+		},
+	}, nil
+}
+
+// ! TeacherSubjects une ligne par couple (matière, enseignant) ; colonnes enseignant vides si aucun
+func (uc *exportUseCase) TeacherSubjects(ctx context.Context, adminUserID int) (*export.Table, error) {
+	admin, err := uc.verifyAdmin(ctx, adminUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &export.Table{
+		Name: "teacher-subjects",
+		Columns: []string{"subject_id", "subject_code", "subject_name",
+			"teacher_id", "teacher_last_name", "teacher_first_name", "teacher_email", "teacher_status"},
+		Rows: func(ctx context.Context, emit func([]string) error) error {
+			return uc.teacherSubjectRepo.EachBySchool(ctx, admin.SchoolID, func(s *domain.Subject, t *domain.User) error {
+				row := []string{strconv.Itoa(s.ID), s.Code, s.Name, "", "", "", "", ""}
+				if t != nil {
+					row[3], row[4], row[5], row[6], row[7] = strconv.Itoa(t.ID), t.LastName, t.FirstName, t.Email, t.Status
+				}
+				return emit(row)
+			})
+		},
+	}, nil
+}
+
+// ! ClassMessages transcription complète du chat de la classe
+func (uc *exportUseCase) ClassMessages(ctx context.Context, adminUserID, classID int) (*export.Table, error) {
+	admin, err := uc.verifyAdmin(ctx, adminUserID)
+	if err != nil {
+		return nil, err
+	}
+	class, err := uc.findSchoolClass(ctx, admin, classID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &export.Table{
+		Name: "messages-" + utils.CreateSlug(class.Name),
+		Columns: []string{"message_id", "class_id", "class_name", "created_at",
+			"user_id", "author", "role", "message_type", "content", "file_url"},
+		Rows: func(ctx context.Context, emit func([]string) error) error {
+			return uc.messageRepo.EachByClass(ctx, class.ID, func(m domain.Message) error {
+				fileURL := ""
+				if m.FileURL != nil {
+					fileURL = *m.FileURL
+				}
+				return emit([]string{
+					strconv.FormatInt(m.ID, 10), strconv.Itoa(m.ClassID), m.ClassName, m.CreatedAt.Local().Format(exportTimeLayout),
+					strconv.Itoa(m.User.ID), m.User.FullName, m.User.Role, m.MessageType, m.Content, fileURL,
+				})
+			})
+		},
+	}, nil
+}
+
+// ! ==================== HELPERS ====================
+
+func (uc *exportUseCase) verifyAdmin(ctx context.Context, adminUserID int) (*domain.User, error) {
+	admin, err := uc.userRepo.FindByID(ctx, adminUserID)
+	if err != nil {
+		return nil, err
+	}
+	if !admin.IsAdmin() {
+		return nil, domain.ErrForbidden
+	}
+	return admin, nil
+}
+
+// ! findSchoolClass classe de l'école de l'admin (NotFound sinon)
+func (uc *exportUseCase) findSchoolClass(ctx context.Context, admin *domain.User, classID int) (*domain.Class, error) {
+	class, err := uc.classRepo.FindByID(ctx, classID)
+	if errors.Is(err, domain.ErrClassNotFound) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if class.SchoolID != admin.SchoolID {
+		return nil, domain.ErrNotFound
+	}
+	return class, nil
+}
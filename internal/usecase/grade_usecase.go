@@ -0,0 +1,449 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"educnet/internal/db"
+	"educnet/internal/domain"
+	"educnet/internal/handler/dto"
+	"educnet/internal/repository"
+	"errors"
+	"strings"
+	"time"
+)
+
+// ! GradeUseCase périodes (admin), notes / appréciations / absences (enseignants)
+type GradeUseCase interface {
+	CreateTerm(ctx context.Context, adminUserID int, req *dto.CreateTermRequest) (*dto.TermResponse, error)
+	ListTerms(ctx context.Context, userID int, academicYear string) ([]dto.TermResponse, error)
+
+	RecordGrade(ctx context.Context, teacherID int, req *dto.CreateGradeRequest) (*dto.GradeResponse, error)
+	UpdateGrade(ctx context.Context, teacherID, gradeID int, req *dto.UpdateGradeRequest) (*dto.GradeResponse, error)
+	DeleteGrade(ctx context.Context, teacherID, gradeID int) error
+	ListGrades(ctx context.Context, teacherID, classID, termID int) ([]dto.GradeResponse, error)
+
+	SetReportComment(ctx context.Context, authorID int, req *dto.ReportCommentRequest) error
+	RecordAttendance(ctx context.Context, teacherID int, req *dto.RecordAttendanceRequest) (*dto.AttendanceResponse, error)
+}
+
+type gradeUseCase struct {
+	db                 *sql.DB
+	userRepo           repository.UserRepository
+	classRepo          repository.ClassRepository
+	teacherSubjectRepo repository.TeacherSubjectRepository
+	studentClassRepo   repository.StudentClassRepository
+	gradeRepo          repository.GradeRepository
+	attendanceRepo     repository.AttendanceRepository
+}
+
+func NewGradeUseCase(
+	db *sql.DB,
+	userRepo repository.UserRepository,
+	classRepo repository.ClassRepository,
+	teacherSubjectRepo repository.TeacherSubjectRepository,
+	studentClassRepo repository.StudentClassRepository,
+	gradeRepo repository.GradeRepository,
+	attendanceRepo repository.AttendanceRepository,
+) GradeUseCase {
+	return &gradeUseCase{
+		db:                 db,
+		userRepo:           userRepo,
+		classRepo:          classRepo,
+		teacherSubjectRepo: teacherSubjectRepo,
+		studentClassRepo:   studentClassRepo,
+		gradeRepo:          gradeRepo,
+		attendanceRepo:     attendanceRepo,
+	}
+}
+
+// ! ==================== TERMS ====================
+
+func (uc *gradeUseCase) CreateTerm(ctx context.Context, adminUserID int, req *dto.CreateTermRequest) (*dto.TermResponse, error) {
+	//! 1. Verify admin
+	admin, err := uc.userRepo.FindByID(ctx, adminUserID)
+	if err != nil {
+		return nil, err
+	}
+	if !admin.IsAdmin() {
+		return nil, domain.ErrForbidden
+	}
+
+	//! 2. Validate term
+	start, err := parseDay(req.StartDate)
+	if err != nil {
+		return nil, domain.ErrTermInvalidDates
+	}
+	end, err := parseDay(req.EndDate)
+	if err != nil {
+		return nil, domain.ErrTermInvalidDates
+	}
+	term, err := domain.NewTerm(admin.SchoolID, req.AcademicYear, req.Name, req.Position, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	//! 3. Unicité du nom dans l'année scolaire
+	exists, err := uc.gradeRepo.TermExists(ctx, term.SchoolID, term.AcademicYear, term.Name)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, domain.ErrTermAlreadyExists
+	}
+
+	if err := uc.gradeRepo.CreateTerm(ctx, term); err != nil {
+		return nil, err
+	}
+	response := dto.TermResponseFromDomain(term)
+	return &response, nil
+}
+
+func (uc *gradeUseCase) ListTerms(ctx context.Context, userID int, academicYear string) ([]dto.TermResponse, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	terms, err := uc.gradeRepo.FindTermsBySchool(ctx, user.SchoolID, strings.TrimSpace(academicYear))
+	if err != nil {
+		return nil, err
+	}
+	return dto.TermResponsesFromDomain(terms), nil
+}
+
+// ! ==================== GRADES ====================
+
+func (uc *gradeUseCase) RecordGrade(ctx context.Context, teacherID int, req *dto.CreateGradeRequest) (*dto.GradeResponse, error) {
+	//! 1. Enseignant de la matière, classe et période de son école
+	teacher, err := uc.verifyTeacher(ctx, teacherID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.verifyTeachesSubject(ctx, teacher.ID, req.SubjectID); err != nil {
+		return nil, err
+	}
+	class, term, err := uc.findClassTerm(ctx, teacher.SchoolID, req.ClassID, req.TermID)
+	if err != nil {
+		return nil, err
+	}
+
+	//! 2. L'élève doit être inscrit dans la classe
+	if err := uc.verifyEnrolled(ctx, req.StudentID, class.ID); err != nil {
+		return nil, err
+	}
+
+	//! 3. Validate grade (date dans la période)
+	gradedOn, err := parseOptionalDay(req.GradedOn)
+	if err != nil {
+		return nil, domain.ErrValidation
+	}
+	grade, err := domain.NewGrade(teacher.SchoolID, req.StudentID, req.SubjectID, class.ID, term.ID, teacher.ID,
+		req.Label, req.Score, req.MaxScore, req.Coefficient, gradedOn)
+	if err != nil {
+		return nil, err
+	}
+	if !term.Contains(grade.GradedOn) {
+		return nil, domain.ErrTermInvalidDates
+	}
+
+	if err := uc.gradeRepo.Create(ctx, grade); err != nil {
+		return nil, err
+	}
+	response := dto.GradeResponseFromDomain(grade)
+	return &response, nil
+}
+
+func (uc *gradeUseCase) UpdateGrade(ctx context.Context, teacherID, gradeID int, req *dto.UpdateGradeRequest) (*dto.GradeResponse, error) {
+	teacher, grade, err := uc.findOwnGrade(ctx, teacherID, gradeID)
+	if err != nil {
+		return nil, err
+	}
+	//! Libellé, barème et coefficient appartiennent à l'évaluation du carnet
+	if grade.EvaluationID != nil {
+		return nil, domain.ErrGradeInGradebook
+	}
+	term, err := uc.gradeRepo.FindTermByID(ctx, grade.TermID)
+	if err != nil {
+		return nil, err
+	}
+
+	gradedOn, err := parseOptionalDay(req.GradedOn)
+	if err != nil {
+		return nil, domain.ErrValidation
+	}
+	if gradedOn.IsZero() {
+		gradedOn = grade.GradedOn
+	}
+
+	//! Revalidation complète via le constructeur domaine
+	updated, err := domain.NewGrade(teacher.SchoolID, grade.StudentID, grade.SubjectID, grade.ClassID, grade.TermID, grade.TeacherID,
+		req.Label, req.Score, req.MaxScore, req.Coefficient, gradedOn)
+	if err != nil {
+		return nil, err
+	}
+	if !term.Contains(updated.GradedOn) {
+		return nil, domain.ErrTermInvalidDates
+	}
+	updated.ID = grade.ID
+	updated.CreatedAt = grade.CreatedAt
+
+	if err := uc.gradeRepo.Update(ctx, updated); err != nil {
+		return nil, err
+	}
+	response := dto.GradeResponseFromDomain(updated)
+	return &response, nil
+}
+
+func (uc *gradeUseCase) DeleteGrade(ctx context.Context, teacherID, gradeID int) error {
+	if _, _, err := uc.findOwnGrade(ctx, teacherID, gradeID); err != nil {
+		return err
+	}
+	return uc.gradeRepo.Delete(ctx, gradeID)
+}
+
+// ! ListGrades notes de la classe dans les matières enseignées par le professeur
+func (uc *gradeUseCase) ListGrades(ctx context.Context, teacherID, classID, termID int) ([]dto.GradeResponse, error) {
+	teacher, err := uc.verifyTeacher(ctx, teacherID)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err := uc.findClassTerm(ctx, teacher.SchoolID, classID, termID); err != nil {
+		return nil, err
+	}
+
+	subjects, err := uc.teacherSubjectRepo.FindByTeacher(ctx, teacher.ID)
+	if err != nil {
+		return nil, err
+	}
+	taught := make(map[int]bool, len(subjects))
+	for _, s := range subjects {
+		taught[s.ID] = true
+	}
+
+	grades, err := uc.gradeRepo.FindByClassTerm(ctx, classID, termID)
+	if err != nil {
+		return nil, err
+	}
+	visible := make([]*domain.Grade, 0, len(grades))
+	for _, g := range grades {
+		if taught[g.SubjectID] {
+			visible = append(visible, g)
+		}
+	}
+	return dto.GradeResponsesFromDomain(visible), nil
+}
+
+// ! ==================== COMMENTS ====================
+
+// ! SetReportComment appréciation par matière (enseignant de la matière) ou générale (admin)
+func (uc *gradeUseCase) SetReportComment(ctx context.Context, authorID int, req *dto.ReportCommentRequest) error {
+	//! 1. Verify author
+	author, err := uc.userRepo.FindByID(ctx, authorID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case author.IsAdmin():
+		//! un admin peut commenter toutes les matières
+	case author.IsTeacher() && req.SubjectID != nil:
+		if err := uc.verifyTeachesSubject(ctx, author.ID, *req.SubjectID); err != nil {
+			return err
+		}
+	default:
+		return domain.ErrForbidden
+	}
+
+	comment := strings.TrimSpace(req.Comment)
+	if comment == "" {
+		return domain.ErrReportCommentRequired
+	}
+
+	//! 2. Élève et période de la même école
+	student, err := uc.userRepo.FindByID(ctx, req.StudentID)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return domain.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if student.SchoolID != author.SchoolID {
+		return domain.ErrNotFound
+	}
+	if !student.IsStudent() {
+		return domain.ErrUserNotStudent
+	}
+	if _, err := uc.findTerm(ctx, author.SchoolID, req.TermID); err != nil {
+		return err
+	}
+
+	return uc.gradeRepo.UpsertComment(ctx, &domain.ReportComment{
+		SchoolID:  author.SchoolID,
+		StudentID: student.ID,
+		TermID:    req.TermID,
+		SubjectID: req.SubjectID,
+		AuthorID:  author.ID,
+		Comment:   comment,
+	})
+}
+
+// ! ==================== ATTENDANCE ====================
+
+// ! RecordAttendance appel d'une classe : tout ou rien (transaction)
+func (uc *gradeUseCase) RecordAttendance(ctx context.Context, teacherID int, req *dto.RecordAttendanceRequest) (*dto.AttendanceResponse, error) {
+	//! 1. Verify teacher + class
+	teacher, err := uc.verifyTeacher(ctx, teacherID)
+	if err != nil {
+		return nil, err
+	}
+	class, err := uc.findClass(ctx, teacher.SchoolID, req.ClassID)
+	if err != nil {
+		return nil, err
+	}
+	date, err := parseDay(req.Date)
+	if err != nil {
+		return nil, domain.ErrAttendanceDateRequired
+	}
+
+	//! 2. Absences / retards enregistrés, "present" efface
+	err = db.RunInTx(ctx, uc.db, func(ctx context.Context) error {
+		for _, entry := range req.Records {
+			if err := uc.verifyEnrolled(ctx, entry.StudentID, class.ID); err != nil {
+				return err
+			}
+			if entry.Status == domain.AttendancePresent {
+				if err := uc.attendanceRepo.Delete(ctx, entry.StudentID, date); err != nil {
+					return err
+				}
+				continue
+			}
+
+			record, err := domain.NewAttendanceRecord(teacher.SchoolID, entry.StudentID, class.ID, teacher.ID,
+				date, entry.Status, entry.Justified, entry.Note)
+			if err != nil {
+				return err
+			}
+			if err := uc.attendanceRepo.Upsert(ctx, record); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := uc.attendanceRepo.FindByClassDate(ctx, class.ID, date)
+	if err != nil {
+		return nil, err
+	}
+	return dto.AttendanceResponseFromDomain(class.ID, date.Format("2006-01-02"), records), nil
+}
+
+// ! ==================== HELPERS ====================
+
+func (uc *gradeUseCase) verifyTeacher(ctx context.Context, teacherID int) (*domain.User, error) {
+	teacher, err := uc.userRepo.FindByID(ctx, teacherID)
+	if err != nil {
+		return nil, err
+	}
+	if !teacher.IsTeacher() {
+		return nil, domain.ErrForbidden
+	}
+	return teacher, nil
+}
+
+func (uc *gradeUseCase) verifyTeachesSubject(ctx context.Context, teacherID, subjectID int) error {
+	teaches, err := uc.teacherSubjectRepo.Exists(ctx, teacherID, subjectID)
+	if err != nil {
+		return err
+	}
+	if !teaches {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+func (uc *gradeUseCase) verifyEnrolled(ctx context.Context, studentID, classID int) error {
+	enrolled, err := uc.studentClassRepo.Exists(ctx, studentID, classID)
+	if err != nil {
+		return err
+	}
+	if !enrolled {
+		return domain.ErrStudentClassNotFound
+	}
+	return nil
+}
+
+// ! findClass classe de l'école (NotFound sinon)
+func (uc *gradeUseCase) findClass(ctx context.Context, schoolID, classID int) (*domain.Class, error) {
+	class, err := uc.classRepo.FindByID(ctx, classID)
+	if errors.Is(err, domain.ErrClassNotFound) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if class.SchoolID != schoolID {
+		return nil, domain.ErrNotFound
+	}
+	return class, nil
+}
+
+// ! findTerm période de l'école (TermNotFound sinon)
+func (uc *gradeUseCase) findTerm(ctx context.Context, schoolID, termID int) (*domain.Term, error) {
+	term, err := uc.gradeRepo.FindTermByID(ctx, termID)
+	if err != nil {
+		return nil, err
+	}
+	if term.SchoolID != schoolID {
+		return nil, domain.ErrTermNotFound
+	}
+	return term, nil
+}
+
+// ! findClassTerm classe + période de la même année scolaire
+func (uc *gradeUseCase) findClassTerm(ctx context.Context, schoolID, classID, termID int) (*domain.Class, *domain.Term, error) {
+	class, err := uc.findClass(ctx, schoolID, classID)
+	if err != nil {
+		return nil, nil, err
+	}
+	term, err := uc.findTerm(ctx, schoolID, termID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if term.AcademicYear != class.AcademicYear {
+		return nil, nil, domain.ErrTermClassYearMismatch
+	}
+	return class, term, nil
+}
+
+// ! findOwnGrade un enseignant ne modifie que ses propres notes
+func (uc *gradeUseCase) findOwnGrade(ctx context.Context, teacherID, gradeID int) (*domain.User, *domain.Grade, error) {
+	teacher, err := uc.verifyTeacher(ctx, teacherID)
+	if err != nil {
+		return nil, nil, err
+	}
+	grade, err := uc.gradeRepo.FindByID(ctx, gradeID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if grade.SchoolID != teacher.SchoolID {
+		return nil, nil, domain.ErrGradeNotFound
+	}
+	if grade.TeacherID != teacher.ID {
+		return nil, nil, domain.ErrForbidden
+	}
+	return teacher, grade, nil
+}
+
+// ! parseDay date YYYY-MM-DD obligatoire
+func parseDay(value string) (time.Time, error) {
+	return time.Parse("2006-01-02", strings.TrimSpace(value))
+}
+
+// ! parseOptionalDay date YYYY-MM-DD optionnelle (zéro si absente)
+func parseOptionalDay(value string) (time.Time, error) {
+	if strings.TrimSpace(value) == "" {
+		return time.Time{}, nil
+	}
+	return parseDay(value)
+}
@@ -0,0 +1,612 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"educnet/internal/db"
+	"educnet/internal/domain"
+	"educnet/internal/export"
+	"educnet/internal/handler/dto"
+	"educnet/internal/repository"
+	"educnet/internal/utils"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ! GradebookUseCase carnet de notes des enseignants : évaluations (colonnes) d'une classe
+// ! dans une matière pour une période, saisie en lot avec verrou optimiste, export / import
+type GradebookUseCase interface {
+	CreateEvaluation(ctx context.Context, teacherID int, req *dto.EvaluationRequest) (*dto.EvaluationResponse, error)
+	UpdateEvaluation(ctx context.Context, teacherID, evaluationID int, req *dto.EvaluationRequest) (*dto.EvaluationResponse, error)
+	DeleteEvaluation(ctx context.Context, teacherID, evaluationID int) error
+
+	GetGradebook(ctx context.Context, teacherID int, query dto.GradebookQuery) (*dto.GradebookResponse, error)
+	SaveGradebook(ctx context.Context, teacherID int, req *dto.GradebookSaveRequest) (*dto.GradebookSaveResponse, error)
+	ExportGradebook(ctx context.Context, teacherID int, query dto.GradebookQuery) (*export.Table, error)
+	ImportGradebook(ctx context.Context, teacherID int, rows [][]string, req *dto.GradebookImportRequest) (*dto.GradebookImportReport, error)
+}
+
+type gradebookUseCase struct {
+	db                 *sql.DB
+	userRepo           repository.UserRepository
+	classRepo          repository.ClassRepository
+	subjectRepo        repository.SubjectRepository
+	teacherSubjectRepo repository.TeacherSubjectRepository
+	studentClassRepo   repository.StudentClassRepository
+	gradeRepo          repository.GradeRepository
+	studentNumberRepo  repository.StudentNumberRepository
+}
+
+func NewGradebookUseCase(
+	db *sql.DB,
+	userRepo repository.UserRepository,
+	classRepo repository.ClassRepository,
+	subjectRepo repository.SubjectRepository,
+	teacherSubjectRepo repository.TeacherSubjectRepository,
+	studentClassRepo repository.StudentClassRepository,
+	gradeRepo repository.GradeRepository,
+	studentNumberRepo repository.StudentNumberRepository,
+) GradebookUseCase {
+	return &gradebookUseCase{
+		db:                 db,
+		userRepo:           userRepo,
+		classRepo:          classRepo,
+		subjectRepo:        subjectRepo,
+		teacherSubjectRepo: teacherSubjectRepo,
+		studentClassRepo:   studentClassRepo,
+		gradeRepo:          gradeRepo,
+		studentNumberRepo:  studentNumberRepo,
+	}
+}
+
+// ! gradebookData contenu d'un carnet : évaluations, roster (élèves inscrits) et notes
+type gradebookData struct {
+	evaluations []*domain.Evaluation
+	students    []*domain.User
+	cells       []*domain.Grade
+}
+
+func (d *gradebookData) plan(changes []domain.GradebookChange, teacherID int) (*domain.GradebookPlan, []domain.GradebookConflict, error) {
+	evaluations := make(map[int]*domain.Evaluation, len(d.evaluations))
+	for _, e := range d.evaluations {
+		evaluations[e.ID] = e
+	}
+	roster := make(map[int]bool, len(d.students))
+	for _, s := range d.students {
+		roster[s.ID] = true
+	}
+	current := make(map[domain.GradebookKey]*domain.Grade, len(d.cells))
+	for _, g := range d.cells {
+		current[domain.GradebookKey{StudentID: g.StudentID, EvaluationID: *g.EvaluationID}] = g
+	}
+	return domain.PlanGradebook(evaluations, roster, current, changes, teacherID)
+}
+
+// ! ==================== EVALUATIONS ====================
+
+func (uc *gradebookUseCase) CreateEvaluation(ctx context.Context, teacherID int, req *dto.EvaluationRequest) (*dto.EvaluationResponse, error) {
+	teacher, class, term, err := uc.openGradebook(ctx, teacherID, dto.GradebookQuery{ClassID: req.ClassID, SubjectID: req.SubjectID, TermID: req.TermID})
+	if err != nil {
+		return nil, err
+	}
+	gradedOn, err := parseOptionalDay(req.GradedOn)
+	if err != nil {
+		return nil, domain.ErrValidation
+	}
+	evaluation, err := domain.NewEvaluation(teacher.SchoolID, class.ID, req.SubjectID, term.ID, teacher.ID,
+		req.Label, req.MaxScore, req.Coefficient, gradedOn)
+	if err != nil {
+		return nil, err
+	}
+	if !term.Contains(evaluation.GradedOn) {
+		return nil, domain.ErrTermInvalidDates
+	}
+
+	if err := uc.gradeRepo.CreateEvaluation(ctx, evaluation); err != nil {
+		return nil, err
+	}
+	response := dto.EvaluationResponseFromDomain(evaluation)
+	return &response, nil
+}
+
+// ! UpdateEvaluation les notes déjà saisies suivent (libellé, barème, coefficient, date)
+func (uc *gradebookUseCase) UpdateEvaluation(ctx context.Context, teacherID, evaluationID int, req *dto.EvaluationRequest) (*dto.EvaluationResponse, error) {
+	current, err := uc.findEvaluation(ctx, teacherID, evaluationID)
+	if err != nil {
+		return nil, err
+	}
+	term, err := uc.gradeRepo.FindTermByID(ctx, current.TermID)
+	if err != nil {
+		return nil, err
+	}
+	gradedOn, err := parseOptionalDay(req.GradedOn)
+	if err != nil {
+		return nil, domain.ErrValidation
+	}
+	if gradedOn.IsZero() {
+		gradedOn = current.GradedOn
+	}
+
+	//! Revalidation complète via le constructeur domaine
+	evaluation, err := domain.NewEvaluation(current.SchoolID, current.ClassID, current.SubjectID, current.TermID, current.CreatedBy,
+		req.Label, req.MaxScore, req.Coefficient, gradedOn)
+	if err != nil {
+		return nil, err
+	}
+	if !term.Contains(evaluation.GradedOn) {
+		return nil, domain.ErrTermInvalidDates
+	}
+	evaluation.ID = current.ID
+	evaluation.CreatedAt = current.CreatedAt
+
+	if err := uc.gradeRepo.UpdateEvaluation(ctx, evaluation); err != nil {
+		return nil, err
+	}
+	response := dto.EvaluationResponseFromDomain(evaluation)
+	return &response, nil
+}
+
+// ! DeleteEvaluation supprime la colonne et ses notes
+func (uc *gradebookUseCase) DeleteEvaluation(ctx context.Context, teacherID, evaluationID int) error {
+	if _, err := uc.findEvaluation(ctx, teacherID, evaluationID); err != nil {
+		return err
+	}
+	return uc.gradeRepo.DeleteEvaluation(ctx, evaluationID)
+}
+
+// ! ==================== GRADEBOOK ====================
+
+// ! GetGradebook roster actuel de la classe × évaluations de la matière pour la période
+func (uc *gradebookUseCase) GetGradebook(ctx context.Context, teacherID int, query dto.GradebookQuery) (*dto.GradebookResponse, error) {
+	if _, _, _, err := uc.openGradebook(ctx, teacherID, query); err != nil {
+		return nil, err
+	}
+	data, err := uc.loadGradebook(ctx, query, false)
+	if err != nil {
+		return nil, err
+	}
+	return dto.GradebookResponseFromDomain(query, domain.BuildGradebook(data.evaluations, data.students, data.cells)), nil
+}
+
+// ! SaveGradebook lot de cellules en tout ou rien : les notes du carnet sont verrouillées le
+// ! temps de la transaction et chaque cellule doit porter la version lue par le client ;
+// ! si l'une a changé entre-temps, rien n'est écrit et les conflits sont retournés
+func (uc *gradebookUseCase) SaveGradebook(ctx context.Context, teacherID int, req *dto.GradebookSaveRequest) (*dto.GradebookSaveResponse, error) {
+	query := dto.GradebookQuery{ClassID: req.ClassID, SubjectID: req.SubjectID, TermID: req.TermID}
+	teacher, _, _, err := uc.openGradebook(ctx, teacherID, query)
+	if err != nil {
+		return nil, err
+	}
+	changes := make([]domain.GradebookChange, len(req.Cells))
+	for i, cell := range req.Cells {
+		changes[i] = domain.GradebookChange{
+			StudentID:    cell.StudentID,
+			EvaluationID: cell.EvaluationID,
+			Score:        cell.Score,
+			Version:      cell.Version,
+			CheckVersion: true,
+		}
+	}
+
+	response := &dto.GradebookSaveResponse{}
+	err = db.RunInTx(ctx, uc.db, func(ctx context.Context) error {
+		data, err := uc.loadGradebook(ctx, query, true)
+		if err != nil {
+			return err
+		}
+		plan, conflicts, err := data.plan(changes, teacher.ID)
+		if err != nil {
+			return err
+		}
+		if len(conflicts) > 0 {
+			response.Conflicts = conflicts
+			return nil
+		}
+		if err := uc.gradeRepo.ApplyGradebook(ctx, plan); err != nil {
+			return err
+		}
+		response.Saved = true
+		response.Created, response.Updated, response.Deleted = len(plan.Create), len(plan.Update), len(plan.Delete)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := uc.loadGradebook(ctx, query, false)
+	if err != nil {
+		return nil, err
+	}
+	response.Gradebook = dto.GradebookResponseFromDomain(query, domain.BuildGradebook(data.evaluations, data.students, data.cells))
+	return response, nil
+}
+
+// ! ExportGradebook une ligne par élève, une colonne par évaluation (réimportable tel quel)
+func (uc *gradebookUseCase) ExportGradebook(ctx context.Context, teacherID int, query dto.GradebookQuery) (*export.Table, error) {
+	teacher, class, term, err := uc.openGradebook(ctx, teacherID, query)
+	if err != nil {
+		return nil, err
+	}
+	subject, err := uc.subjectRepo.FindByID(ctx, query.SubjectID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := uc.loadGradebook(ctx, query, false)
+	if err != nil {
+		return nil, err
+	}
+	numbers, err := uc.studentNumberRepo.FindBySchool(ctx, teacher.SchoolID)
+	if err != nil {
+		return nil, err
+	}
+	book := domain.BuildGradebook(data.evaluations, data.students, data.cells)
+
+	columns := []string{"student_id", "student_number", "last_name", "first_name"}
+	for _, e := range book.Evaluations {
+		columns = append(columns, e.Label)
+	}
+	columns = append(columns, "average")
+
+	students := make(map[int]*domain.User, len(data.students))
+	for _, s := range data.students {
+		students[s.ID] = s
+	}
+	return &export.Table{
+		Name:    "gradebook-" + utils.CreateSlug(class.Name) + "-" + utils.CreateSlug(subject.Name) + "-" + utils.CreateSlug(term.Name),
+		Columns: columns,
+		Rows: func(ctx context.Context, emit func(row []string) error) error {
+			for _, row := range book.Rows {
+				student := students[row.StudentID]
+				record := []string{strconv.Itoa(student.ID), numbers[student.ID], student.LastName, student.FirstName}
+				for _, cell := range row.Cells {
+					record = append(record, formatScore(cell.Score))
+				}
+				record = append(record, formatScore(row.Average))
+				if err := emit(record); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}, nil
+}
+
+// ! ImportGradebook fichier au format de l'export : élève identifié par student_id, student_number
+// ! ou email, une colonne par évaluation (libellé) ; une cellule vide laisse la note inchangée.
+// ! Tout ou rien : la moindre ligne invalide annule l'import (rapport ligne par ligne).
+func (uc *gradebookUseCase) ImportGradebook(ctx context.Context, teacherID int, rows [][]string, req *dto.GradebookImportRequest) (*dto.GradebookImportReport, error) {
+	query := dto.GradebookQuery{ClassID: req.ClassID, SubjectID: req.SubjectID, TermID: req.TermID}
+	teacher, _, _, err := uc.openGradebook(ctx, teacherID, query)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) < 2 {
+		return nil, domain.ErrImportEmpty
+	}
+	data, err := uc.loadGradebook(ctx, query, false)
+	if err != nil {
+		return nil, err
+	}
+	numbers, err := uc.studentNumberRepo.FindBySchool(ctx, teacher.SchoolID)
+	if err != nil {
+		return nil, err
+	}
+
+	//! 1. En-tête : colonnes d'identification et d'évaluations
+	layout, err := parseGradebookHeader(rows[0], data.evaluations)
+	if err != nil {
+		return nil, err
+	}
+	report := &dto.GradebookImportReport{DryRun: req.DryRun, Rows: []dto.GradebookImportRow{}}
+	for _, column := range layout.evaluations {
+		report.Evaluations = append(report.Evaluations, column.evaluation.Label)
+	}
+
+	//! 2. Lignes : élève du roster et notes dans le barème
+	roster := newGradebookRoster(data.students, numbers)
+	seen := map[int]bool{}
+	var changes []domain.GradebookChange
+	for i, cells := range rows[1:] {
+		if isBlankRow(cells) {
+			continue
+		}
+		result := dto.GradebookImportRow{Line: i + 2}
+		student := roster.find(layout.value(cells, layout.studentID), layout.value(cells, layout.studentNumber), layout.value(cells, layout.email))
+		switch {
+		case student == nil:
+			result.Errors = append(result.Errors, "Student not found in this class")
+		case seen[student.ID]:
+			result.Errors = append(result.Errors, "Student appears on several lines")
+		}
+		var rowChanges []domain.GradebookChange
+		if student != nil {
+			result.StudentID, result.StudentName = student.ID, student.GetFullName()
+			seen[student.ID] = true
+			for _, column := range layout.evaluations {
+				value := layout.value(cells, column.index)
+				if value == "" {
+					continue
+				}
+				score, err := parseScore(value)
+				if err != nil || score < 0 || score > column.evaluation.MaxScore {
+					result.Errors = append(result.Errors, fmt.Sprintf("%s: score must be a number between 0 and %g", column.evaluation.Label, column.evaluation.MaxScore))
+					continue
+				}
+				rowChanges = append(rowChanges, domain.GradebookChange{StudentID: student.ID, EvaluationID: column.evaluation.ID, Score: &score})
+			}
+		}
+		result.Scores = len(rowChanges)
+		report.TotalRows++
+		if len(result.Errors) > 0 {
+			report.InvalidRows++
+		} else {
+			report.ValidRows++
+			changes = append(changes, rowChanges...)
+		}
+		report.Rows = append(report.Rows, result)
+	}
+	if report.TotalRows == 0 {
+		return nil, domain.ErrImportEmpty
+	}
+
+	//! 3. Dry-run ou lignes invalides : rapport seulement (écritures prévues comptées)
+	if req.DryRun || report.InvalidRows > 0 {
+		plan, _, err := data.plan(changes, teacher.ID)
+		if err != nil {
+			return nil, err
+		}
+		report.Created, report.Updated = len(plan.Create), len(plan.Update)
+		return report, nil
+	}
+
+	//! 4. Import : notes verrouillées puis rejouées sur l'état courant
+	err = db.RunInTx(ctx, uc.db, func(ctx context.Context) error {
+		data, err := uc.loadGradebook(ctx, query, true)
+		if err != nil {
+			return err
+		}
+		plan, _, err := data.plan(changes, teacher.ID)
+		if err != nil {
+			return err
+		}
+		if err := uc.gradeRepo.ApplyGradebook(ctx, plan); err != nil {
+			return err
+		}
+		report.Created, report.Updated = len(plan.Create), len(plan.Update)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	report.Committed = true
+	return report, nil
+}
+
+// ! ==================== HELPERS ====================
+
+// ! openGradebook enseignant de la matière, classe et période de son école (même année)
+func (uc *gradebookUseCase) openGradebook(ctx context.Context, teacherID int, query dto.GradebookQuery) (*domain.User, *domain.Class, *domain.Term, error) {
+	teacher, err := uc.verifyTeacher(ctx, teacherID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if err := uc.verifyTeachesSubject(ctx, teacher.ID, query.SubjectID); err != nil {
+		return nil, nil, nil, err
+	}
+	class, err := uc.classRepo.FindByID(ctx, query.ClassID)
+	if errors.Is(err, domain.ErrClassNotFound) {
+		return nil, nil, nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if class.SchoolID != teacher.SchoolID {
+		return nil, nil, nil, domain.ErrNotFound
+	}
+	term, err := uc.gradeRepo.FindTermByID(ctx, query.TermID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if term.SchoolID != teacher.SchoolID {
+		return nil, nil, nil, domain.ErrTermNotFound
+	}
+	if term.AcademicYear != class.AcademicYear {
+		return nil, nil, nil, domain.ErrTermClassYearMismatch
+	}
+	return teacher, class, term, nil
+}
+
+// ! loadGradebook forUpdate : notes verrouillées jusqu'au commit (dans une transaction)
+func (uc *gradebookUseCase) loadGradebook(ctx context.Context, query dto.GradebookQuery, forUpdate bool) (*gradebookData, error) {
+	evaluations, err := uc.gradeRepo.FindEvaluations(ctx, query.ClassID, query.SubjectID, query.TermID)
+	if err != nil {
+		return nil, err
+	}
+	students, err := uc.studentClassRepo.FindByClass(ctx, query.ClassID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int, len(evaluations))
+	for i, e := range evaluations {
+		ids[i] = e.ID
+	}
+	cells := []*domain.Grade{}
+	if len(ids) > 0 {
+		if cells, err = uc.gradeRepo.FindCells(ctx, ids, forUpdate); err != nil {
+			return nil, err
+		}
+	}
+	return &gradebookData{evaluations: evaluations, students: students, cells: cells}, nil
+}
+
+// ! findEvaluation évaluation de l'école dans une matière enseignée par le professeur
+func (uc *gradebookUseCase) findEvaluation(ctx context.Context, teacherID, evaluationID int) (*domain.Evaluation, error) {
+	teacher, err := uc.verifyTeacher(ctx, teacherID)
+	if err != nil {
+		return nil, err
+	}
+	evaluation, err := uc.gradeRepo.FindEvaluation(ctx, evaluationID)
+	if err != nil {
+		return nil, err
+	}
+	if evaluation.SchoolID != teacher.SchoolID {
+		return nil, domain.ErrEvaluationNotFound
+	}
+	if err := uc.verifyTeachesSubject(ctx, teacher.ID, evaluation.SubjectID); err != nil {
+		return nil, err
+	}
+	return evaluation, nil
+}
+
+func (uc *gradebookUseCase) verifyTeacher(ctx context.Context, teacherID int) (*domain.User, error) {
+	teacher, err := uc.userRepo.FindByID(ctx, teacherID)
+	if err != nil {
+		return nil, err
+	}
+	if !teacher.IsTeacher() {
+		return nil, domain.ErrForbidden
+	}
+	return teacher, nil
+}
+
+func (uc *gradebookUseCase) verifyTeachesSubject(ctx context.Context, teacherID, subjectID int) error {
+	teaches, err := uc.teacherSubjectRepo.Exists(ctx, teacherID, subjectID)
+	if err != nil {
+		return err
+	}
+	if !teaches {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+// ! gradebookLayout colonnes du fichier importé (-1 = absente)
+type gradebookLayout struct {
+	studentID     int
+	studentNumber int
+	email         int
+	evaluations   []gradebookColumn
+}
+
+type gradebookColumn struct {
+	index      int
+	evaluation *domain.Evaluation
+}
+
+// ! gradebookIgnoredColumns colonnes informatives de l'export
+var gradebookIgnoredColumns = map[string]bool{
+	"last_name": true, "first_name": true, "student_name": true, "average": true,
+}
+
+// ! parseGradebookHeader une colonne d'identification au moins ; toute autre colonne doit
+// ! correspondre au libellé d'une évaluation (insensible à la casse)
+func parseGradebookHeader(header []string, evaluations []*domain.Evaluation) (*gradebookLayout, error) {
+	layout := &gradebookLayout{studentID: -1, studentNumber: -1, email: -1}
+	byLabel := make(map[string]*domain.Evaluation, len(evaluations))
+	for _, e := range evaluations {
+		byLabel[strings.ToLower(e.Label)] = e
+	}
+	for i, name := range header {
+		key := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\uFEFF")))
+		switch {
+		case key == "":
+		case key == "student_id":
+			layout.studentID = i
+		case key == "student_number":
+			layout.studentNumber = i
+		case key == "email":
+			layout.email = i
+		case gradebookIgnoredColumns[key]:
+		case byLabel[key] != nil:
+			layout.evaluations = append(layout.evaluations, gradebookColumn{index: i, evaluation: byLabel[key]})
+		default:
+			return nil, fmt.Errorf("%w: %s", domain.ErrGradebookUnknownColumn, strings.TrimSpace(name))
+		}
+	}
+	if layout.studentID < 0 && layout.studentNumber < 0 && layout.email < 0 {
+		return nil, fmt.Errorf("%w: student_id, student_number or email", domain.ErrImportMissingColumn)
+	}
+	if len(layout.evaluations) == 0 {
+		return nil, fmt.Errorf("%w: evaluation", domain.ErrImportMissingColumn)
+	}
+	return layout, nil
+}
+
+func (l *gradebookLayout) value(cells []string, index int) string {
+	if index < 0 || index >= len(cells) {
+		return ""
+	}
+	return strings.TrimSpace(cells[index])
+}
+
+// ! gradebookRoster recherche d'un élève de la classe par ID, matricule ou email
+type gradebookRoster struct {
+	byID     map[int]*domain.User
+	byNumber map[string]*domain.User
+	byEmail  map[string]*domain.User
+}
+
+func newGradebookRoster(students []*domain.User, numbers map[int]string) *gradebookRoster {
+	r := &gradebookRoster{
+		byID:     make(map[int]*domain.User, len(students)),
+		byNumber: make(map[string]*domain.User, len(students)),
+		byEmail:  make(map[string]*domain.User, len(students)),
+	}
+	for _, s := range students {
+		r.byID[s.ID] = s
+		r.byEmail[strings.ToLower(s.Email)] = s
+		if number := numbers[s.ID]; number != "" {
+			r.byNumber[strings.ToLower(number)] = s
+		}
+	}
+	return r
+}
+
+// ! find premier identifiant renseigné : student_id, puis matricule, puis email
+func (r *gradebookRoster) find(studentID, number, email string) *domain.User {
+	switch {
+	case studentID != "":
+		id, err := strconv.Atoi(studentID)
+		if err != nil {
+			return nil
+		}
+		return r.byID[id]
+	case number != "":
+		return r.byNumber[strings.ToLower(number)]
+	case email != "":
+		return r.byEmail[strings.ToLower(email)]
+	}
+	return nil
+}
+
+// ! parseScore accepte la virgule décimale (tableurs FR)
+func parseScore(value string) (float64, error) {
+	score, err := strconv.ParseFloat(strings.Replace(strings.TrimSpace(value), ",", ".", 1), 64)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(score) || math.IsInf(score, 0) {
+		return 0, domain.ErrGradeInvalidScore
+	}
+	return score, nil
+}
+
+// ! formatScore note pour l'export (vide si absente)
+func formatScore(score *float64) string {
+	if score == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*score, 'f', -1, 64)
+}
+
+func isBlankRow(cells []string) bool {
+	for _, cell := range cells {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
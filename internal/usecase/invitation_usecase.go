@@ -0,0 +1,424 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"educnet/internal/auth"
+	"educnet/internal/db"
+	"educnet/internal/domain"
+	"educnet/internal/handler/dto"
+	"educnet/internal/mailer"
+	"educnet/internal/repository"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	defaultInvitationDays = 7
+	maxInvitationDays     = 30
+)
+
+// ! InvitationUseCase onboarding par invitation + politique d'inscription de l'école
+type InvitationUseCase interface {
+	CreateInvitation(ctx context.Context, adminUserID int, req *dto.CreateInvitationRequest) (*dto.InvitationResponse, error)
+	ListInvitations(ctx context.Context, adminUserID int) ([]*dto.InvitationResponse, error)
+	RevokeInvitation(ctx context.Context, adminUserID, invitationID int) error
+	UpdateRegistrationMode(ctx context.Context, adminUserID int, req *dto.UpdateRegistrationModeRequest) (*dto.SchoolDTO, error)
+
+	GetInvitation(ctx context.Context, token string) (*dto.InvitationPreviewResponse, error)
+	AcceptInvitation(ctx context.Context, req *dto.AcceptInvitationRequest) (*dto.AcceptInvitationResponse, error)
+}
+
+type invitationUseCase struct {
+	db                 *sql.DB
+	invitationRepo     repository.InvitationRepository
+	userRepo           repository.UserRepository
+	schoolRepo         repository.SchoolRepository
+	classRepo          repository.ClassRepository
+	subjectRepo        repository.SubjectRepository
+	studentClassRepo   repository.StudentClassRepository
+	teacherSubjectRepo repository.TeacherSubjectRepository
+	studentNumberRepo  repository.StudentNumberRepository
+	jwtService         *auth.JWTService
+	mailer             mailer.Mailer
+	frontendURL        string
+}
+
+func NewInvitationUseCase(
+	db *sql.DB,
+	invitationRepo repository.InvitationRepository,
+	userRepo repository.UserRepository,
+	schoolRepo repository.SchoolRepository,
+	classRepo repository.ClassRepository,
+	subjectRepo repository.SubjectRepository,
+	studentClassRepo repository.StudentClassRepository,
+	teacherSubjectRepo repository.TeacherSubjectRepository,
+	studentNumberRepo repository.StudentNumberRepository,
+	jwtService *auth.JWTService,
+	mailer mailer.Mailer,
+	frontendURL string,
+) InvitationUseCase {
+	return &invitationUseCase{
+		db:                 db,
+		invitationRepo:     invitationRepo,
+		userRepo:           userRepo,
+		schoolRepo:         schoolRepo,
+		classRepo:          classRepo,
+		subjectRepo:        subjectRepo,
+		studentClassRepo:   studentClassRepo,
+		teacherSubjectRepo: teacherSubjectRepo,
+		studentNumberRepo:  studentNumberRepo,
+		jwtService:         jwtService,
+		mailer:             mailer,
+		frontendURL:        strings.TrimRight(frontendURL, "/"),
+	}
+}
+
+func (uc *invitationUseCase) CreateInvitation(ctx context.Context, adminUserID int, req *dto.CreateInvitationRequest) (*dto.InvitationResponse, error) {
+	//! 1. Verify admin
+	admin, err := uc.verifyAdmin(ctx, adminUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	//! 2. Build invitation (validation email / role / preset)
+	days := req.ExpiresInDays
+	if days <= 0 {
+		days = defaultInvitationDays
+	}
+	if days > maxInvitationDays {
+		return nil, domain.ErrValidation
+	}
+	inv, err := domain.NewInvitation(admin.SchoolID, admin.ID, req.Email, req.Role, req.ClassID, req.SubjectIDs, time.Duration(days)*24*time.Hour)
+	if err != nil {
+		return nil, err
+	}
+
+	//! 3. Email libre sur toute la plateforme + classe / matières de l'école
+	exists, err := uc.userRepo.ExistsByEmail(ctx, inv.Email)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, domain.ErrEmailAlreadyExists
+	}
+	className, subjectNames, err := uc.presetNames(ctx, inv)
+	if err != nil {
+		return nil, err
+	}
+
+	//! 4. Save + signed link
+	if err := uc.invitationRepo.Create(ctx, inv); err != nil {
+		return nil, err
+	}
+	token, err := uc.jwtService.GenerateInvitationToken(inv.ID, inv.SchoolID, inv.Email, inv.Role, inv.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := dto.InvitationResponseFromDomain(inv)
+	resp.Token = token
+	resp.InviteURL = uc.frontendURL + "/invitations/accept?token=" + url.QueryEscape(token)
+
+	//! 5. Email après commit
+	if req.SendEmail {
+		school, err := uc.schoolRepo.FindByID(ctx, admin.SchoolID)
+		if err != nil {
+			return nil, err
+		}
+		preset := className
+		if inv.Role == domain.RoleTeacher {
+			preset = strings.Join(subjectNames, ", ")
+		}
+		body := fmt.Sprintf(
+			"Bonjour,\n\n%s vous invite à rejoindre %s sur EducNet (%s).\n\nCréez votre compte avec ce lien : %s\n\nCe lien expire le %s.\n",
+			admin.GetFullName(), school.Name, preset, resp.InviteURL, inv.ExpiresAt.Format("02/01/2006 15:04"),
+		)
+		db.AfterCommit(ctx, func() {
+			go func() {
+				if err := uc.mailer.Send(inv.Email, "Invitation à rejoindre "+school.Name, body); err != nil {
+					slog.ErrorContext(ctx, "failed to email invitation", "invitation_id", inv.ID, "error", err)
+				}
+			}()
+		})
+		resp.EmailSent = true
+	}
+
+	return resp, nil
+}
+
+func (uc *invitationUseCase) ListInvitations(ctx context.Context, adminUserID int) ([]*dto.InvitationResponse, error) {
+	admin, err := uc.verifyAdmin(ctx, adminUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	invitations, err := uc.invitationRepo.FindBySchool(ctx, admin.SchoolID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]*dto.InvitationResponse, len(invitations))
+	for i, inv := range invitations {
+		resp[i] = dto.InvitationResponseFromDomain(inv)
+	}
+	return resp, nil
+}
+
+func (uc *invitationUseCase) RevokeInvitation(ctx context.Context, adminUserID, invitationID int) error {
+	admin, err := uc.verifyAdmin(ctx, adminUserID)
+	if err != nil {
+		return err
+	}
+
+	inv, err := uc.invitationRepo.FindByID(ctx, invitationID)
+	if err != nil {
+		return err
+	}
+	if inv.SchoolID != admin.SchoolID {
+		return domain.ErrInvitationNotFound
+	}
+	if err := inv.Revoke(); err != nil {
+		return err
+	}
+	return uc.invitationRepo.MarkRevoked(ctx, inv.ID)
+}
+
+func (uc *invitationUseCase) UpdateRegistrationMode(ctx context.Context, adminUserID int, req *dto.UpdateRegistrationModeRequest) (*dto.SchoolDTO, error) {
+	admin, err := uc.verifyAdmin(ctx, adminUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	school, err := uc.schoolRepo.FindByID(ctx, admin.SchoolID)
+	if err != nil {
+		return nil, err
+	}
+	if err := school.SetRegistrationMode(req.Mode); err != nil {
+		return nil, err
+	}
+	if err := uc.schoolRepo.UpdateRegistrationMode(ctx, school.ID, school.RegistrationMode); err != nil {
+		return nil, err
+	}
+	return dto.SchoolDTOFromDomain(school), nil
+}
+
+// ! GetInvitation aperçu public d'une invitation valide (page d'acceptation)
+func (uc *invitationUseCase) GetInvitation(ctx context.Context, token string) (*dto.InvitationPreviewResponse, error) {
+	claims, err := uc.validateToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp *dto.InvitationPreviewResponse
+	err = db.InTenantTx(ctx, uc.db, claims.SchoolID, func(ctx context.Context) error {
+		inv, err := uc.findValidInvitation(ctx, claims)
+		if err != nil {
+			return err
+		}
+		school, err := uc.schoolRepo.FindByID(ctx, inv.SchoolID)
+		if err != nil {
+			return err
+		}
+		className, subjectNames, err := uc.presetNames(ctx, inv)
+		if err != nil {
+			return err
+		}
+
+		resp = &dto.InvitationPreviewResponse{
+			SchoolName: school.Name,
+			Email:      inv.Email,
+			Role:       inv.Role,
+			ClassName:  className,
+			Subjects:   subjectNames,
+			ExpiresAt:  inv.ExpiresAt,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ! AcceptInvitation crée le compte invité, déjà approuvé, avec sa classe ou ses matières
+func (uc *invitationUseCase) AcceptInvitation(ctx context.Context, req *dto.AcceptInvitationRequest) (*dto.AcceptInvitationResponse, error) {
+	//! 1. Verify signed token
+	claims, err := uc.validateToken(req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	//! 2. Validate input + hash password before the transaction (bcrypt)
+	user, err := domain.NewUser(claims.SchoolID, claims.Email, req.Password, req.FirstName, req.LastName, req.Phone, claims.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	//! 3. Transaction scopée sur l'école de l'invitation
+	waitlisted := false
+	err = db.InTenantTx(ctx, uc.db, claims.SchoolID, func(ctx context.Context) error {
+		inv, err := uc.findValidInvitation(ctx, claims)
+		if err != nil {
+			return err
+		}
+
+		school, err := uc.schoolRepo.FindByID(ctx, inv.SchoolID)
+		if err != nil {
+			return err
+		}
+		if school.IsSuspended() {
+			return domain.ErrSchoolSuspended
+		}
+
+		//! users.email est unique toutes écoles confondues
+		exists, err := uc.userRepo.ExistsByEmail(ctx, inv.Email)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return domain.ErrEmailAlreadyExists
+		}
+
+		//! 4. Create approved user
+		user.Approve()
+		if err := uc.userRepo.Create(ctx, user); err != nil {
+			return fmt.Errorf("failed to create invited user: %w", err)
+		}
+
+		//! 5. Preset class / subjects
+		if waitlisted, err = uc.applyPreset(ctx, inv, user); err != nil {
+			return err
+		}
+
+		//! 6. Usage unique
+		if err := inv.Accept(user.ID); err != nil {
+			return err
+		}
+		return uc.invitationRepo.MarkAccepted(ctx, inv.ID, user.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	message := "Invitation accepted. You can now log in."
+	if waitlisted {
+		message = "Invitation accepted. The class is full: you are on its waitlist."
+	}
+	return &dto.AcceptInvitationResponse{
+		UserID:     user.ID,
+		Email:      user.Email,
+		FullName:   user.GetFullName(),
+		SchoolID:   user.SchoolID,
+		Role:       user.Role,
+		Status:     user.Status,
+		Waitlisted: waitlisted,
+		Message:    message,
+	}, nil
+}
+
+// ! ==================== HELPERS ====================
+func (uc *invitationUseCase) verifyAdmin(ctx context.Context, adminUserID int) (*domain.User, error) {
+	admin, err := uc.userRepo.FindByID(ctx, adminUserID)
+	if err != nil {
+		return nil, err
+	}
+	if !admin.IsAdmin() {
+		return nil, domain.ErrForbidden
+	}
+	return admin, nil
+}
+
+func (uc *invitationUseCase) validateToken(token string) (*auth.InvitationClaims, error) {
+	claims, err := uc.jwtService.ValidateInvitationToken(token)
+	if errors.Is(err, auth.ErrExpiredToken) {
+		return nil, domain.ErrInvitationExpired
+	}
+	if err != nil {
+		return nil, domain.ErrInvitationInvalidToken
+	}
+	return claims, nil
+}
+
+// ! findValidInvitation invitation du token, encore utilisable (non acceptée / révoquée / expirée)
+func (uc *invitationUseCase) findValidInvitation(ctx context.Context, claims *auth.InvitationClaims) (*domain.Invitation, error) {
+	inv, err := uc.invitationRepo.FindByID(ctx, claims.InvitationID)
+	if err != nil {
+		return nil, err
+	}
+	if inv.SchoolID != claims.SchoolID || inv.Role != claims.Role || !strings.EqualFold(inv.Email, claims.Email) {
+		return nil, domain.ErrInvitationInvalidToken
+	}
+	if err := inv.CanBeAccepted(); err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
+
+// ! presetNames vérifie que classe / matières appartiennent à l'école et retourne leurs noms
+func (uc *invitationUseCase) presetNames(ctx context.Context, inv *domain.Invitation) (string, []string, error) {
+	if inv.Role == domain.RoleStudent {
+		if inv.ClassID == nil {
+			return "", nil, domain.ErrInvitationClassRequired
+		}
+		class, err := uc.classRepo.FindByID(ctx, *inv.ClassID)
+		if errors.Is(err, domain.ErrClassNotFound) {
+			return "", nil, domain.ErrNotFound
+		}
+		if err != nil {
+			return "", nil, err
+		}
+		if class.SchoolID != inv.SchoolID {
+			return "", nil, domain.ErrNotFound
+		}
+		return class.Name, nil, nil
+	}
+
+	subjectNames := make([]string, 0, len(inv.SubjectIDs))
+	for _, subjectID := range inv.SubjectIDs {
+		subject, err := uc.subjectRepo.FindByID(ctx, subjectID)
+		if errors.Is(err, domain.ErrSubjectNotFound) {
+			return "", nil, domain.ErrNotFound
+		}
+		if err != nil {
+			return "", nil, err
+		}
+		if subject.SchoolID != inv.SchoolID {
+			return "", nil, domain.ErrNotFound
+		}
+		subjectNames = append(subjectNames, subject.Name)
+	}
+	return "", subjectNames, nil
+}
+
+// ! applyPreset inscrit l'élève dans sa classe (liste d'attente si complète, retourne true)
+// ! et lui attribue son matricule, ou affecte les matières de l'enseignant
+func (uc *invitationUseCase) applyPreset(ctx context.Context, inv *domain.Invitation, user *domain.User) (bool, error) {
+	//! Classe / matières supprimées depuis l'envoi de l'invitation
+	if _, _, err := uc.presetNames(ctx, inv); err != nil {
+		return false, err
+	}
+
+	if inv.Role == domain.RoleStudent {
+		waitlisted, err := uc.studentClassRepo.EnrollOrWaitlist(ctx, user.ID, *inv.ClassID)
+		if err != nil {
+			return false, fmt.Errorf("failed to enroll invited student: %w", err)
+		}
+		//! Compte créé approuvé : matricule attribué dans la même transaction
+		if _, err := assignStudentNumber(ctx, uc.studentNumberRepo, uc.studentClassRepo, user); err != nil {
+			return false, fmt.Errorf("failed to assign student number: %w", err)
+		}
+		return waitlisted, nil
+	}
+
+	for _, subjectID := range inv.SubjectIDs {
+		if err := uc.teacherSubjectRepo.Create(ctx, user.ID, subjectID); err != nil {
+			return false, fmt.Errorf("failed to assign subject %d: %w", subjectID, err)
+		}
+	}
+	return false, nil
+}
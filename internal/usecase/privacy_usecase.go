@@ -0,0 +1,452 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"educnet/internal/auth"
+	"educnet/internal/db"
+	"educnet/internal/domain"
+	"educnet/internal/handler/dto"
+	"educnet/internal/repository"
+	"educnet/internal/utils"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ! erasureEndReason motif des inscriptions fermées par un effacement de compte
+const erasureEndReason = "Compte effacé (RGPD)"
+
+// ! PrivacyUseCase RGPD : export des données personnelles, droit à l'effacement,
+// ! durées de conservation des messages
+type PrivacyUseCase interface {
+	ExportMyData(ctx context.Context, userID int) (*dto.FileResponse, error)
+	EraseUser(ctx context.Context, adminUserID, userID int, ipAddress string) error
+	GetRetentionPolicy(ctx context.Context, adminUserID int) (*dto.RetentionPolicyResponse, error)
+	UpdateRetentionPolicy(ctx context.Context, adminUserID int, req *dto.RetentionPolicyRequest, ipAddress string) (*dto.RetentionPolicyResponse, error)
+	PurgeExpiredMessages(ctx context.Context) (int64, error)
+}
+
+type privacyUseCase struct {
+	db                 *sql.DB
+	userRepo           repository.UserRepository
+	schoolRepo         repository.SchoolRepository
+	studentClassRepo   repository.StudentClassRepository
+	teacherSubjectRepo repository.TeacherSubjectRepository
+	messageRepo        repository.MessageRepository
+	gradeRepo          repository.GradeRepository
+	attendanceRepo     repository.AttendanceRepository
+	privacyRepo        repository.PrivacyRepository
+	auditLogRepo       repository.AuditLogRepository
+	profileRepo        repository.ProfileRepository
+	disciplineRepo     repository.DisciplineRepository
+}
+
+func NewPrivacyUseCase(
+	db *sql.DB,
+	userRepo repository.UserRepository,
+	schoolRepo repository.SchoolRepository,
+	studentClassRepo repository.StudentClassRepository,
+	teacherSubjectRepo repository.TeacherSubjectRepository,
+	messageRepo repository.MessageRepository,
+	gradeRepo repository.GradeRepository,
+	attendanceRepo repository.AttendanceRepository,
+	privacyRepo repository.PrivacyRepository,
+	auditLogRepo repository.AuditLogRepository,
+	profileRepo repository.ProfileRepository,
+	disciplineRepo repository.DisciplineRepository,
+) PrivacyUseCase {
+	return &privacyUseCase{
+		db:                 db,
+		userRepo:           userRepo,
+		schoolRepo:         schoolRepo,
+		studentClassRepo:   studentClassRepo,
+		teacherSubjectRepo: teacherSubjectRepo,
+		messageRepo:        messageRepo,
+		gradeRepo:          gradeRepo,
+		attendanceRepo:     attendanceRepo,
+		privacyRepo:        privacyRepo,
+		auditLogRepo:       auditLogRepo,
+		profileRepo:        profileRepo,
+		disciplineRepo:     disciplineRepo,
+	}
+}
+
+// ! ==================== EXPORT ====================
+
+// ! ExportMyData archive ZIP : data.json (profil, inscriptions, messages, notes,
+// ! absences) + fichiers téléversés par l'utilisateur sous files/
+func (uc *privacyUseCase) ExportMyData(ctx context.Context, userID int) (*dto.FileResponse, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	data := &dto.PersonalDataExport{
+		GeneratedAt: now.Format(exportTimeLayout),
+		Profile: dto.ProfileResponse{
+			ID:        user.ID,
+			Email:     user.Email,
+			FirstName: user.FirstName,
+			LastName:  user.LastName,
+			FullName:  user.GetFullName(),
+			Phone:     user.Phone,
+			Role:      user.Role,
+			Status:    user.Status,
+			SchoolID:  user.SchoolID,
+			AvatarURL: user.AvatarURL,
+			CreatedAt: user.CreatedAt.Format(exportTimeLayout),
+		},
+		Enrollments: []dto.EnrollmentResponse{},
+		Subjects:    []dto.SubjectInfo{},
+		Grades:      []dto.GradeResponse{},
+		Attendance:  []dto.PersonalAttendance{},
+		Files:       []string{},
+	}
+
+	if user.SchoolID > 0 {
+		school, err := uc.schoolRepo.FindByID(ctx, user.SchoolID)
+		if err != nil {
+			return nil, err
+		}
+		data.School = &dto.PersonalDataSchool{ID: school.ID, Name: school.Name}
+	}
+
+	switch user.Role {
+	case domain.RoleStudent:
+		profile, err := uc.profileRepo.FindStudentProfile(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		data.Profile.StudentProfile = dto.StudentProfileResponseFromDomain(profile)
+
+		enrollments, err := uc.studentClassRepo.FindHistory(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		data.Enrollments = dto.EnrollmentResponsesFromDomain(enrollments)
+
+		grades, err := uc.gradeRepo.FindByStudent(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		data.Grades = dto.GradeResponsesFromDomain(grades)
+
+		records, err := uc.attendanceRepo.FindByStudent(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, rec := range records {
+			data.Attendance = append(data.Attendance, dto.PersonalAttendance{
+				Date:      rec.Date.Format("2006-01-02"),
+				ClassID:   rec.ClassID,
+				Status:    rec.Status,
+				Justified: rec.Justified,
+				Note:      rec.Note,
+			})
+		}
+	case domain.RoleTeacher:
+		profile, err := uc.profileRepo.FindTeacherProfile(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		data.Profile.TeacherProfile = dto.TeacherProfileResponseFromDomain(profile)
+
+		subjects, err := uc.teacherSubjectRepo.FindByTeacher(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, subject := range subjects {
+			data.Subjects = append(data.Subjects, *dto.SubjectInfoFromDomain(subject))
+		}
+	}
+
+	messages, err := uc.messageRepo.FindByUser(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	data.Messages = make([]dto.PersonalMessage, len(messages))
+	fileURLs := []string{user.AvatarURL}
+	for i, msg := range messages {
+		data.Messages[i] = dto.PersonalMessage{
+			ID:          msg.ID,
+			ClassID:     msg.ClassID,
+			ClassName:   msg.ClassName,
+			MessageType: msg.MessageType,
+			Content:     msg.Content,
+			CreatedAt:   msg.CreatedAt.Format(exportTimeLayout),
+		}
+		if msg.FileURL != nil {
+			data.Messages[i].FileURL = *msg.FileURL
+			fileURLs = append(fileURLs, *msg.FileURL)
+		}
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, path := range uploadedFiles(user.ID, fileURLs) {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue //! fichier supprimé du disque : seule la référence reste dans data.json
+		}
+		name := "files/" + filepath.ToSlash(strings.TrimPrefix(path, "uploads"+string(filepath.Separator)))
+		f, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: now})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := f.Write(content); err != nil {
+			return nil, err
+		}
+		data.Files = append(data.Files, name)
+	}
+
+	documents, err := uc.profileRepo.FindDocuments(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	data.Profile.Documents = dto.ProfileDocumentResponsesFromDomain(documents)
+	for _, doc := range documents {
+		content, err := os.ReadFile(doc.StoragePath)
+		if err != nil {
+			continue
+		}
+		name := fmt.Sprintf("documents/%d_%s", doc.ID, doc.FileName)
+		f, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: now})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := f.Write(content); err != nil {
+			return nil, err
+		}
+		data.Files = append(data.Files, name)
+	}
+
+	payload, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	f, err := zw.CreateHeader(&zip.FileHeader{Name: "data.json", Method: zip.Deflate, Modified: now})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.Write(payload); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return &dto.FileResponse{
+		FileName:    fmt.Sprintf("educnet-donnees-%d-%s.zip", user.ID, now.Format("20060102")),
+		ContentType: "application/zip",
+		Data:        buf.Bytes(),
+	}, nil
+}
+
+// ! ==================== ERASURE ====================
+
+// ! EraseUser anonymise le compte (élève ou enseignant) : la ligne users est conservée
+// ! pour les messages, notes et historiques qui la référencent ; inscriptions fermées,
+// ! listes d'attente, incidents disciplinaires, matières, invitations et profil étendu
+// ! supprimés, avatars et documents du profil effacés du disque
+func (uc *privacyUseCase) EraseUser(ctx context.Context, adminUserID, userID int, ipAddress string) error {
+	admin, err := uc.verifyAdmin(ctx, adminUserID)
+	if err != nil {
+		return err
+	}
+	if userID == admin.ID {
+		return domain.ErrCannotModifySelf
+	}
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if errors.Is(err, domain.ErrUserNotFound) || (err == nil && user.SchoolID != admin.SchoolID) {
+		return domain.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	email, role := user.Email, user.Role
+	files := uploadedFiles(user.ID, []string{user.AvatarURL})
+	if err := user.Erase(); err != nil {
+		return err
+	}
+
+	err = db.RunInTx(ctx, uc.db, func(ctx context.Context) error {
+		switch role {
+		case domain.RoleStudent:
+			if _, err := uc.studentClassRepo.EndEnrollments(ctx, user.ID, "", erasureEndReason); err != nil {
+				return err
+			}
+			if err := uc.privacyRepo.ClearWaitlists(ctx, user.ID); err != nil {
+				return err
+			}
+			if err := uc.disciplineRepo.DeleteByStudent(ctx, user.ID); err != nil {
+				return err
+			}
+		case domain.RoleTeacher:
+			if err := uc.teacherSubjectRepo.DeleteByTeacher(ctx, user.ID); err != nil {
+				return err
+			}
+		}
+		if err := uc.privacyRepo.DeleteInvitationsByEmail(ctx, admin.SchoolID, email); err != nil {
+			return err
+		}
+		documents, err := uc.profileRepo.DeleteByUser(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+		files = append(files, documents...)
+		if err := uc.userRepo.Erase(ctx, user); err != nil {
+			return err
+		}
+		//! Aucune donnée personnelle dans le journal : seul l'ID reste
+		return uc.audit(ctx, admin.ID, domain.AuditActionUserErased, admin.SchoolID, user.ID, "role="+role, ipAddress)
+	})
+	if err != nil {
+		return err
+	}
+
+	//! Fichiers supprimés seulement une fois l'anonymisation validée
+	db.AfterCommit(ctx, func() {
+		for _, path := range files {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				slog.ErrorContext(ctx, "erase user: remove file", "target_user_id", user.ID, "file", path, "error", err)
+			}
+		}
+	})
+	return nil
+}
+
+// ! ==================== RETENTION ====================
+
+func (uc *privacyUseCase) GetRetentionPolicy(ctx context.Context, adminUserID int) (*dto.RetentionPolicyResponse, error) {
+	admin, err := uc.verifyAdmin(ctx, adminUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := uc.privacyRepo.FindRetentionPolicy(ctx, admin.SchoolID)
+	if err != nil {
+		return nil, err
+	}
+	return dto.RetentionPolicyResponseFromDomain(policy), nil
+}
+
+func (uc *privacyUseCase) UpdateRetentionPolicy(ctx context.Context, adminUserID int, req *dto.RetentionPolicyRequest, ipAddress string) (*dto.RetentionPolicyResponse, error) {
+	admin, err := uc.verifyAdmin(ctx, adminUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := domain.NewRetentionPolicy(admin.SchoolID, req.MessageRetentionDays, admin.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	details := "message_retention_days=unlimited"
+	if days := policy.MessageRetentionDays; days != nil {
+		details = fmt.Sprintf("message_retention_days=%d", *days)
+	}
+	err = db.RunInTx(ctx, uc.db, func(ctx context.Context) error {
+		if err := uc.privacyRepo.SaveRetentionPolicy(ctx, policy); err != nil {
+			return err
+		}
+		return uc.audit(ctx, admin.ID, domain.AuditActionRetentionUpdated, admin.SchoolID, 0, details, ipAddress)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dto.RetentionPolicyResponseFromDomain(policy), nil
+}
+
+// ! PurgeExpiredMessages tâche périodique : applique la politique de chaque école,
+// ! une transaction scopée par école. Retourne le nombre total de messages supprimés.
+func (uc *privacyUseCase) PurgeExpiredMessages(ctx context.Context) (int64, error) {
+	//! Politiques de toutes les écoles (accès plateforme), purge scopée école par école
+	var policies []*domain.RetentionPolicy
+	err := db.InPlatformTx(ctx, uc.db, func(ctx context.Context) error {
+		var err error
+		policies, err = uc.privacyRepo.FindRetentionPolicies(ctx)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	now := time.Now()
+	for _, policy := range policies {
+		cutoff, ok := policy.MessageCutoff(now)
+		if !ok {
+			continue
+		}
+		err := db.InTenantTx(ctx, uc.db, policy.SchoolID, func(ctx context.Context) error {
+			purged, err := uc.privacyRepo.PurgeMessages(ctx, policy.SchoolID, cutoff)
+			if err != nil || purged == 0 {
+				return err
+			}
+			total += purged
+			details := fmt.Sprintf("messages=%d before=%s", purged, cutoff.Format(exportTimeLayout))
+			return uc.audit(ctx, 0, domain.AuditActionMessagesPurged, policy.SchoolID, 0, details, "")
+		})
+		if err != nil {
+			return total, fmt.Errorf("purge messages for school %d: %w", policy.SchoolID, err)
+		}
+	}
+	return total, nil
+}
+
+// ! ==================== HELPERS ====================
+func (uc *privacyUseCase) verifyAdmin(ctx context.Context, adminUserID int) (*domain.User, error) {
+	admin, err := uc.userRepo.FindByID(ctx, adminUserID)
+	if err != nil {
+		return nil, err
+	}
+	if !admin.IsAdmin() {
+		return nil, domain.ErrForbidden
+	}
+	return admin, nil
+}
+
+func (uc *privacyUseCase) audit(ctx context.Context, actorID int, action string, schoolID, targetUserID int, details, ipAddress string) error {
+	log, err := domain.NewAuditLog(actorID, action, schoolID, targetUserID, details, ipAddress)
+	if err != nil {
+		return err
+	}
+	if impersonatorID := auth.ImpersonatorFromContext(ctx); impersonatorID > 0 {
+		log.ImpersonatorID = &impersonatorID
+	}
+	return uc.auditLogRepo.Create(ctx, log)
+}
+
+// ! uploadedFiles fichiers locaux de l'utilisateur : tous ses avatars (les anciens
+// ! ne sont jamais supprimés à l'upload) + les URLs /uploads/ données, sans doublon
+func uploadedFiles(userID int, urls []string) []string {
+	paths, _ := filepath.Glob(filepath.Join(utils.UploadDir, "avatars", fmt.Sprintf("avatar_%d_*", userID)))
+	for i, path := range paths {
+		paths[i] = filepath.Clean(path)
+	}
+	for _, url := range urls {
+		if path, ok := utils.UploadPath(url); ok {
+			paths = append(paths, path)
+		}
+	}
+
+	seen := make(map[string]bool, len(paths))
+	unique := paths[:0]
+	for _, path := range paths {
+		if !seen[path] {
+			seen[path] = true
+			unique = append(unique, path)
+		}
+	}
+	return unique
+}
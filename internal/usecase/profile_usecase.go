@@ -1,24 +1,39 @@
 package usecase
 
 import (
+	"context"
+	"educnet/internal/db"
 	"educnet/internal/domain"
 	"educnet/internal/handler/dto"
 	"educnet/internal/repository"
+	"educnet/internal/utils"
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
 )
 
 type ProfileUseCase interface {
-	GetProfile(userID int) (*dto.ProfileResponse, error)
-	UpdateProfile(userID int, req *dto.UpdateProfileRequest) (*dto.ProfileResponse, error)
-	ChangePassword(userID int, req *dto.ChangePasswordRequest) error
-
-	UpdateAvatar(userID int, avatarURL string) error
-	GetSchool(userID, schoolID int) (*domain.School, error)
-	UpdateSchool(userID, schoolID int, req *dto.UpdateSchoolRequest) (*domain.School, error)
-	UpdateSchoolLogo(userID, schoolID int, logoURL string) error
-
-	GetTeacherSubjects(userID int) (*dto.TeacherSubjectsResponse, error)
-	GetStudentClasses(userID int) (*dto.StudentClassesResponse, error)
+	GetProfile(ctx context.Context, userID int) (*dto.ProfileResponse, error)
+	UpdateProfile(ctx context.Context, userID int, req *dto.UpdateProfileRequest) (*dto.ProfileResponse, error)
+	ChangePassword(ctx context.Context, userID int, req *dto.ChangePasswordRequest) error
+
+	UpdateAvatar(ctx context.Context, userID int, avatarURL string) error
+	GetSchool(ctx context.Context, userID, schoolID int) (*domain.School, error)
+	UpdateSchool(ctx context.Context, userID, schoolID int, req *dto.UpdateSchoolRequest) (*domain.School, error)
+	UpdateSchoolLogo(ctx context.Context, userID, schoolID int, logoURL string) error
+
+	GetTeacherSubjects(ctx context.Context, userID int) (*dto.TeacherSubjectsResponse, error)
+	GetStudentClasses(ctx context.Context, userID int) (*dto.StudentClassesResponse, error)
+
+	//! Profil étendu (élèves, enseignants) : droits par champ selon le lecteur (soi, admin, enseignant)
+	GetUserProfile(ctx context.Context, actorID, userID int) (*dto.ProfileResponse, error)
+	UpdateExtendedProfile(ctx context.Context, actorID, userID int, req *dto.ExtendedProfileRequest) (*dto.ProfileResponse, error)
+	UploadDocument(ctx context.Context, actorID, userID int, req dto.ProfileDocumentRequest, file *dto.UploadedFile) (*dto.ProfileDocumentResponse, error)
+	DownloadDocument(ctx context.Context, actorID, userID, documentID int) (*dto.FileResponse, error)
+	DeleteDocument(ctx context.Context, actorID, userID, documentID int) error
 }
 
 type profileUseCase struct {
@@ -28,6 +43,7 @@ type profileUseCase struct {
 	teacherSubjectRepo repository.TeacherSubjectRepository
 	studentClassRepo   repository.StudentClassRepository
 	schoolRepo         repository.SchoolRepository
+	profileRepo        repository.ProfileRepository
 }
 
 func NewProfileUseCase(
@@ -37,6 +53,7 @@ func NewProfileUseCase(
 	teacherSubjectRepo repository.TeacherSubjectRepository,
 	studentClassRepo repository.StudentClassRepository,
 	schoolRepo repository.SchoolRepository,
+	profileRepo repository.ProfileRepository,
 ) ProfileUseCase {
 	return &profileUseCase{
 		userRepo:           userRepo,
@@ -45,11 +62,12 @@ func NewProfileUseCase(
 		teacherSubjectRepo: teacherSubjectRepo,
 		studentClassRepo:   studentClassRepo,
 		schoolRepo:         schoolRepo,
+		profileRepo:        profileRepo,
 	}
 }
 
-func (uc *profileUseCase) GetProfile(userID int) (*dto.ProfileResponse, error) {
-	user, err := uc.userRepo.FindByID(userID)
+func (uc *profileUseCase) GetProfile(ctx context.Context, userID int) (*dto.ProfileResponse, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
 	if err != nil {
 		if errors.Is(err, domain.ErrUserNotFound) {
 			return nil, domain.ErrNotFound
@@ -57,24 +75,12 @@ func (uc *profileUseCase) GetProfile(userID int) (*dto.ProfileResponse, error) {
 		return nil, domain.ErrInternal
 	}
 
-	return &dto.ProfileResponse{
-		ID:        user.ID,
-		Email:     user.Email,
-		FirstName: user.FirstName,
-		LastName:  user.LastName,
-		FullName:  user.GetFullName(),
-		Phone:     user.Phone,
-		Role:      user.Role,
-		Status:    user.Status,
-		SchoolID:  user.SchoolID,
-		AvatarURL: user.AvatarURL,
-		CreatedAt: user.CreatedAt.Format("2006-01-02 15:04:05"),
-	}, nil
+	return uc.profileResponse(ctx, user, domain.ViewerSelf)
 }
 
-func (uc *profileUseCase) UpdateProfile(userID int, req *dto.UpdateProfileRequest) (*dto.ProfileResponse, error) {
+func (uc *profileUseCase) UpdateProfile(ctx context.Context, userID int, req *dto.UpdateProfileRequest) (*dto.ProfileResponse, error) {
 	//! 1. Get user
-	user, err := uc.userRepo.FindByID(userID)
+	user, err := uc.userRepo.FindByID(ctx, userID)
 	if err != nil {
 		if errors.Is(err, domain.ErrUserNotFound) {
 			return nil, domain.ErrNotFound
@@ -99,15 +105,15 @@ func (uc *profileUseCase) UpdateProfile(userID int, req *dto.UpdateProfileReques
 	}
 
 	//! 4. Save
-	if err := uc.userRepo.Update(user); err != nil {
+	if err := uc.userRepo.Update(ctx, user); err != nil {
 		return nil, err
 	}
 
 	//! 5. Return updated profile
-	return uc.GetProfile(userID)
+	return uc.GetProfile(ctx, userID)
 }
 
-func (uc *profileUseCase) ChangePassword(userID int, req *dto.ChangePasswordRequest) error {
+func (uc *profileUseCase) ChangePassword(ctx context.Context, userID int, req *dto.ChangePasswordRequest) error {
 	//! 1. Validate input
 	if req.CurrentPassword == "" {
 		return domain.ErrPasswordRequired
@@ -123,7 +129,7 @@ func (uc *profileUseCase) ChangePassword(userID int, req *dto.ChangePasswordRequ
 	}
 
 	//! 2. Get user
-	user, err := uc.userRepo.FindByID(userID)
+	user, err := uc.userRepo.FindByID(ctx, userID)
 	if err != nil {
 		return err
 	}
@@ -139,26 +145,26 @@ func (uc *profileUseCase) ChangePassword(userID int, req *dto.ChangePasswordRequ
 	}
 
 	//! 5. Save
-	return uc.userRepo.Update(user)
+	return uc.userRepo.Update(ctx, user)
 }
 
-func (uc *profileUseCase) UpdateAvatar(userID int, avatarURL string) error {
-	return uc.userRepo.UpdateAvatar(userID, avatarURL)
+func (uc *profileUseCase) UpdateAvatar(ctx context.Context, userID int, avatarURL string) error {
+	return uc.userRepo.UpdateAvatar(ctx, userID, avatarURL)
 }
 
-func (uc *profileUseCase) GetSchool(userID, schoolID int) (*domain.School, error) {
-	user, err := uc.userRepo.FindByID(userID)
+func (uc *profileUseCase) GetSchool(ctx context.Context, userID, schoolID int) (*domain.School, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 	if user.SchoolID != schoolID {
 		return nil, domain.ErrUnauthorized
 	}
-	return uc.schoolRepo.FindByID(schoolID)
+	return uc.schoolRepo.FindByID(ctx, schoolID)
 }
 
-func (uc *profileUseCase) UpdateSchool(userID, schoolID int, req *dto.UpdateSchoolRequest) (*domain.School, error) {
-	user, err := uc.userRepo.FindByID(userID)
+func (uc *profileUseCase) UpdateSchool(ctx context.Context, userID, schoolID int, req *dto.UpdateSchoolRequest) (*domain.School, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
 	if err != nil || !user.IsAdmin() {
 		return nil, domain.ErrForbidden
 	}
@@ -166,7 +172,7 @@ func (uc *profileUseCase) UpdateSchool(userID, schoolID int, req *dto.UpdateScho
 		return nil, domain.ErrUnauthorized
 	}
 
-	school, err := uc.schoolRepo.FindByID(schoolID)
+	school, err := uc.schoolRepo.FindByID(ctx, schoolID)
 	if err != nil {
 		return nil, err
 	}
@@ -184,31 +190,31 @@ func (uc *profileUseCase) UpdateSchool(userID, schoolID int, req *dto.UpdateScho
 		school.Email = req.Email
 	}
 
-	if err := uc.schoolRepo.Update(school); err != nil {
+	if err := uc.schoolRepo.Update(ctx, school); err != nil {
 		return nil, err
 	}
 
 	return school, nil
 }
 
-func (uc *profileUseCase) UpdateSchoolLogo(userID, schoolID int, logoURL string) error {
-	user, err := uc.userRepo.FindByID(userID)
+func (uc *profileUseCase) UpdateSchoolLogo(ctx context.Context, userID, schoolID int, logoURL string) error {
+	user, err := uc.userRepo.FindByID(ctx, userID)
 	if err != nil || !user.IsAdmin() {
 		return domain.ErrForbidden
 	}
 	if user.SchoolID != schoolID {
 		return domain.ErrForbidden
 	}
-	return uc.schoolRepo.UpdateLogo(schoolID, logoURL)
+	return uc.schoolRepo.UpdateLogo(ctx, schoolID, logoURL)
 }
 
-func (uc *profileUseCase) GetTeacherSubjects(userID int) (*dto.TeacherSubjectsResponse, error) {
-	user, err := uc.userRepo.FindByID(userID)
+func (uc *profileUseCase) GetTeacherSubjects(ctx context.Context, userID int) (*dto.TeacherSubjectsResponse, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
 	if err != nil || !user.IsTeacher() {
 		return nil, domain.ErrForbidden
 	}
 
-	subjects, err := uc.teacherSubjectRepo.FindByTeacher(userID)
+	subjects, err := uc.teacherSubjectRepo.FindByTeacher(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -229,13 +235,13 @@ func (uc *profileUseCase) GetTeacherSubjects(userID int) (*dto.TeacherSubjectsRe
 	}, nil
 }
 
-func (uc *profileUseCase) GetStudentClasses(userID int) (*dto.StudentClassesResponse, error) {
-	user, err := uc.userRepo.FindByID(userID)
+func (uc *profileUseCase) GetStudentClasses(ctx context.Context, userID int) (*dto.StudentClassesResponse, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
 	if err != nil || !user.IsStudent() {
 		return nil, domain.ErrForbidden
 	}
 
-	classes, err := uc.studentClassRepo.FindByStudent(userID)
+	classes, err := uc.studentClassRepo.FindByStudent(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -257,3 +263,296 @@ func (uc *profileUseCase) GetStudentClasses(userID int) (*dto.StudentClassesResp
 		Total:   len(classesInfo),
 	}, nil
 }
+
+// ! ==================== EXTENDED PROFILE ====================
+
+// ! GetUserProfile admin : tout le profil ; enseignant : élève de l'école, champs restreints
+func (uc *profileUseCase) GetUserProfile(ctx context.Context, actorID, userID int) (*dto.ProfileResponse, error) {
+	_, target, viewer, err := uc.findProfileTarget(ctx, actorID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.profileResponse(ctx, target, viewer)
+}
+
+// ! UpdateExtendedProfile applique les champs envoyés, chacun devant exister pour le rôle
+// ! du titulaire et être modifiable par l'appelant
+func (uc *profileUseCase) UpdateExtendedProfile(ctx context.Context, actorID, userID int, req *dto.ExtendedProfileRequest) (*dto.ProfileResponse, error) {
+	_, target, viewer, err := uc.findProfileTarget(ctx, actorID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.HasExtendedProfile(target.Role) {
+		return nil, domain.ErrProfileNotExtended
+	}
+	for _, field := range requestedProfileFields(req) {
+		if !domain.ProfileHasField(target.Role, field) || !domain.CanWriteProfileField(viewer, field) {
+			return nil, domain.ErrProfileFieldReadOnly
+		}
+	}
+
+	now := time.Now().UTC()
+	switch target.Role {
+	case domain.RoleStudent:
+		err = uc.updateStudentProfile(ctx, target, req, now)
+	case domain.RoleTeacher:
+		err = uc.updateTeacherProfile(ctx, target, req, now)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return uc.profileResponse(ctx, target, viewer)
+}
+
+// ! UploadDocument pièce ajoutée au dossier par le titulaire ou un admin
+func (uc *profileUseCase) UploadDocument(ctx context.Context, actorID, userID int, req dto.ProfileDocumentRequest, file *dto.UploadedFile) (*dto.ProfileDocumentResponse, error) {
+	actor, target, viewer, err := uc.findProfileTarget(ctx, actorID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.ProfileHasField(target.Role, domain.FieldDocuments) {
+		return nil, domain.ErrProfileNotExtended
+	}
+	if !domain.CanWriteProfileField(viewer, domain.FieldDocuments) {
+		return nil, domain.ErrForbidden
+	}
+	if file == nil {
+		return nil, domain.ErrProfileDocumentRequired
+	}
+	docs, err := uc.profileRepo.FindDocuments(ctx, target.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(docs) >= domain.ProfileMaxDocuments {
+		return nil, domain.ErrProfileTooManyDocuments
+	}
+
+	doc, err := domain.NewProfileDocument(target.SchoolID, target.ID, req.Kind, req.Title, file.FileName, file.ContentType, file.Size, actor.ID)
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(utils.ProfileDir, strconv.Itoa(target.SchoolID))
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("create profile directory: %w", err)
+	}
+	doc.StoragePath = filepath.Join(dir, fmt.Sprintf("%d_%d%s", target.ID, time.Now().UnixNano(), doc.Ext()))
+
+	if err := writeLimitedFile(doc.StoragePath, file.Content, domain.ProfileMaxFileSize, domain.ErrProfileDocumentTooLarge); err != nil {
+		return nil, err
+	}
+	if err := uc.profileRepo.CreateDocument(ctx, doc); err != nil {
+		os.Remove(doc.StoragePath)
+		return nil, err
+	}
+	return &dto.ProfileDocumentResponsesFromDomain([]*domain.ProfileDocument{doc})[0], nil
+}
+
+func (uc *profileUseCase) DownloadDocument(ctx context.Context, actorID, userID, documentID int) (*dto.FileResponse, error) {
+	_, target, viewer, err := uc.findProfileTarget(ctx, actorID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.CanReadProfileField(viewer, domain.FieldDocuments) {
+		return nil, domain.ErrForbidden
+	}
+	doc, err := uc.profileRepo.FindDocument(ctx, target.ID, documentID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(doc.StoragePath)
+	if err != nil {
+		return nil, fmt.Errorf("read profile document: %w", err)
+	}
+	return &dto.FileResponse{FileName: doc.FileName, ContentType: doc.ContentType, Data: data}, nil
+}
+
+// ! DeleteDocument admin : tout document ; titulaire : ceux qu'il a lui-même déposés
+func (uc *profileUseCase) DeleteDocument(ctx context.Context, actorID, userID, documentID int) error {
+	actor, target, viewer, err := uc.findProfileTarget(ctx, actorID, userID)
+	if err != nil {
+		return err
+	}
+	if !domain.CanWriteProfileField(viewer, domain.FieldDocuments) {
+		return domain.ErrForbidden
+	}
+	doc, err := uc.profileRepo.FindDocument(ctx, target.ID, documentID)
+	if err != nil {
+		return err
+	}
+	if viewer != domain.ViewerAdmin && doc.UploadedBy != actor.ID {
+		return domain.ErrForbidden
+	}
+	if err := uc.profileRepo.DeleteDocument(ctx, target.ID, doc.ID); err != nil {
+		return err
+	}
+
+	db.AfterCommit(ctx, func() {
+		os.Remove(doc.StoragePath)
+	})
+	return nil
+}
+
+// ! ==================== HELPERS ====================
+
+// ! profileResponse profil de base et profil étendu, réduit aux champs lisibles par viewer
+func (uc *profileUseCase) profileResponse(ctx context.Context, user *domain.User, viewer string) (*dto.ProfileResponse, error) {
+	response := &dto.ProfileResponse{
+		ID:        user.ID,
+		Email:     user.Email,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		FullName:  user.GetFullName(),
+		Phone:     user.Phone,
+		Role:      user.Role,
+		Status:    user.Status,
+		SchoolID:  user.SchoolID,
+		AvatarURL: user.AvatarURL,
+		CreatedAt: user.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+
+	switch user.Role {
+	case domain.RoleStudent:
+		profile, err := uc.profileRepo.FindStudentProfile(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		profile.Redact(viewer)
+		response.StudentProfile = dto.StudentProfileResponseFromDomain(profile)
+	case domain.RoleTeacher:
+		profile, err := uc.profileRepo.FindTeacherProfile(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		response.TeacherProfile = dto.TeacherProfileResponseFromDomain(profile)
+	}
+
+	if domain.ProfileHasField(user.Role, domain.FieldDocuments) && domain.CanReadProfileField(viewer, domain.FieldDocuments) {
+		docs, err := uc.profileRepo.FindDocuments(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		response.Documents = dto.ProfileDocumentResponsesFromDomain(docs)
+	}
+	return response, nil
+}
+
+func (uc *profileUseCase) updateStudentProfile(ctx context.Context, student *domain.User, req *dto.ExtendedProfileRequest, now time.Time) error {
+	profile, err := uc.profileRepo.FindStudentProfile(ctx, student.ID)
+	if err != nil {
+		return err
+	}
+	profile.SchoolID = student.SchoolID
+
+	if req.DateOfBirth != nil {
+		if profile.DateOfBirth, err = parseProfileDate(*req.DateOfBirth); err != nil {
+			return err
+		}
+	}
+	if req.Address != nil {
+		profile.Address = *req.Address
+	}
+	if req.StudentNumber != nil {
+		profile.StudentNumber = *req.StudentNumber
+	}
+	if req.MedicalNotes != nil {
+		profile.MedicalNotes = *req.MedicalNotes
+	}
+	if req.Guardians != nil {
+		profile.Guardians = make([]*domain.Guardian, len(*req.Guardians))
+		for i, g := range *req.Guardians {
+			guardian, err := domain.NewGuardian(g.FullName, g.Relationship, g.Phone, g.Email, g.IsLegalGuardian, g.IsEmergencyContact)
+			if err != nil {
+				return err
+			}
+			profile.Guardians[i] = guardian
+		}
+	}
+	if err := profile.Validate(now); err != nil {
+		return err
+	}
+
+	if req.StudentNumber != nil && profile.StudentNumber != "" {
+		taken, err := uc.profileRepo.StudentNumberExists(ctx, student.SchoolID, profile.StudentNumber, student.ID)
+		if err != nil {
+			return err
+		}
+		if taken {
+			return domain.ErrStudentNumberTaken
+		}
+	}
+	return uc.profileRepo.SaveStudentProfile(ctx, profile)
+}
+
+func (uc *profileUseCase) updateTeacherProfile(ctx context.Context, teacher *domain.User, req *dto.ExtendedProfileRequest, now time.Time) error {
+	profile, err := uc.profileRepo.FindTeacherProfile(ctx, teacher.ID)
+	if err != nil {
+		return err
+	}
+	profile.SchoolID = teacher.SchoolID
+
+	if req.Qualifications != nil {
+		profile.Qualifications = *req.Qualifications
+	}
+	if req.HireDate != nil {
+		if profile.HireDate, err = parseProfileDate(*req.HireDate); err != nil {
+			return err
+		}
+	}
+	if err := profile.Validate(now); err != nil {
+		return err
+	}
+	return uc.profileRepo.SaveTeacherProfile(ctx, profile)
+}
+
+// ! findProfileTarget appelant, titulaire et relation entre eux (cf. domain.ProfileViewerFor)
+func (uc *profileUseCase) findProfileTarget(ctx context.Context, actorID, userID int) (*domain.User, *domain.User, string, error) {
+	actor, err := uc.userRepo.FindByID(ctx, actorID)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	target := actor
+	if userID != actorID {
+		target, err = uc.userRepo.FindByID(ctx, userID)
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, nil, "", domain.ErrNotFound
+		}
+		if err != nil {
+			return nil, nil, "", err
+		}
+	}
+	viewer, err := domain.ProfileViewerFor(actor, target)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	return actor, target, viewer, nil
+}
+
+// ! requestedProfileFields champs présents dans la requête
+func requestedProfileFields(req *dto.ExtendedProfileRequest) []string {
+	fields := []string{}
+	add := func(set bool, field string) {
+		if set {
+			fields = append(fields, field)
+		}
+	}
+	add(req.DateOfBirth != nil, domain.FieldDateOfBirth)
+	add(req.Address != nil, domain.FieldAddress)
+	add(req.StudentNumber != nil, domain.FieldStudentNumber)
+	add(req.MedicalNotes != nil, domain.FieldMedicalNotes)
+	add(req.Guardians != nil, domain.FieldGuardians)
+	add(req.Qualifications != nil, domain.FieldQualifications)
+	add(req.HireDate != nil, domain.FieldHireDate)
+	return fields
+}
+
+// ! parseProfileDate YYYY-MM-DD ; chaîne vide => date effacée (nil)
+func parseProfileDate(value string) (*time.Time, error) {
+	day, err := parseOptionalDay(value)
+	if err != nil {
+		return nil, domain.ErrProfileInvalidDate
+	}
+	if day.IsZero() {
+		return nil, nil
+	}
+	return &day, nil
+}
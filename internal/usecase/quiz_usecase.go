@@ -0,0 +1,657 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"educnet/internal/db"
+	"educnet/internal/domain"
+	"educnet/internal/handler/dto"
+	"educnet/internal/repository"
+	"errors"
+	"math"
+	"strings"
+	"time"
+)
+
+// ! QuizUseCase banque de questions et quiz (enseignants), passage minuté (élèves).
+// ! Le chronomètre est côté serveur et la meilleure tentative devient une note.
+type QuizUseCase interface {
+	CreateQuestion(ctx context.Context, teacherID int, req *dto.QuestionRequest) (*dto.QuestionResponse, error)
+	ListQuestions(ctx context.Context, teacherID, subjectID int) ([]dto.QuestionResponse, error)
+	UpdateQuestion(ctx context.Context, teacherID, questionID int, req *dto.QuestionRequest) (*dto.QuestionResponse, error)
+	DeleteQuestion(ctx context.Context, teacherID, questionID int) error
+
+	CreateQuiz(ctx context.Context, teacherID int, req *dto.CreateQuizRequest) (*dto.QuizResponse, error)
+	ListQuizzes(ctx context.Context, teacherID, classID int) ([]dto.QuizResponse, error)
+	GetQuiz(ctx context.Context, teacherID, quizID int) (*dto.QuizResponse, error)
+	DeleteQuiz(ctx context.Context, teacherID, quizID int) error
+	ListAttempts(ctx context.Context, teacherID, quizID int) ([]dto.AttemptResponse, error)
+
+	ListMyQuizzes(ctx context.Context, studentID int) ([]dto.StudentQuizResponse, error)
+	GetMyQuiz(ctx context.Context, studentID, quizID int) (*dto.StudentQuizResponse, error)
+	StartAttempt(ctx context.Context, studentID, quizID int) (*dto.AttemptResponse, error)
+	SubmitAttempt(ctx context.Context, studentID, attemptID int, req *dto.SubmitAttemptRequest) (*dto.AttemptResponse, error)
+}
+
+type quizUseCase struct {
+	db                 *sql.DB
+	userRepo           repository.UserRepository
+	classRepo          repository.ClassRepository
+	teacherSubjectRepo repository.TeacherSubjectRepository
+	studentClassRepo   repository.StudentClassRepository
+	gradeRepo          repository.GradeRepository
+	quizRepo           repository.QuizRepository
+}
+
+func NewQuizUseCase(
+	db *sql.DB,
+	userRepo repository.UserRepository,
+	classRepo repository.ClassRepository,
+	teacherSubjectRepo repository.TeacherSubjectRepository,
+	studentClassRepo repository.StudentClassRepository,
+	gradeRepo repository.GradeRepository,
+	quizRepo repository.QuizRepository,
+) QuizUseCase {
+	return &quizUseCase{
+		db:                 db,
+		userRepo:           userRepo,
+		classRepo:          classRepo,
+		teacherSubjectRepo: teacherSubjectRepo,
+		studentClassRepo:   studentClassRepo,
+		gradeRepo:          gradeRepo,
+		quizRepo:           quizRepo,
+	}
+}
+
+// ! ==================== QUESTION BANK ====================
+
+func (uc *quizUseCase) CreateQuestion(ctx context.Context, teacherID int, req *dto.QuestionRequest) (*dto.QuestionResponse, error) {
+	teacher, err := uc.verifySubjectTeacher(ctx, teacherID, req.SubjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	question, err := domain.NewQuestion(teacher.SchoolID, req.SubjectID, teacher.ID,
+		req.Type, req.Prompt, req.Choices, req.AnswerKey, req.Points)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.quizRepo.CreateQuestion(ctx, question); err != nil {
+		return nil, err
+	}
+	response := dto.QuestionResponseFromDomain(question)
+	return &response, nil
+}
+
+func (uc *quizUseCase) ListQuestions(ctx context.Context, teacherID, subjectID int) ([]dto.QuestionResponse, error) {
+	if _, err := uc.verifySubjectTeacher(ctx, teacherID, subjectID); err != nil {
+		return nil, err
+	}
+	questions, err := uc.quizRepo.FindQuestionsBySubject(ctx, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	return dto.QuestionResponsesFromDomain(questions), nil
+}
+
+// ! UpdateQuestion une question déjà utilisée par un quiz est figée (corrections existantes)
+func (uc *quizUseCase) UpdateQuestion(ctx context.Context, teacherID, questionID int, req *dto.QuestionRequest) (*dto.QuestionResponse, error) {
+	teacher, question, err := uc.findEditableQuestion(ctx, teacherID, questionID)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := domain.NewQuestion(teacher.SchoolID, question.SubjectID, question.AuthorID,
+		req.Type, req.Prompt, req.Choices, req.AnswerKey, req.Points)
+	if err != nil {
+		return nil, err
+	}
+	updated.ID = question.ID
+	updated.CreatedAt = question.CreatedAt
+
+	if err := uc.quizRepo.UpdateQuestion(ctx, updated); err != nil {
+		return nil, err
+	}
+	response := dto.QuestionResponseFromDomain(updated)
+	return &response, nil
+}
+
+func (uc *quizUseCase) DeleteQuestion(ctx context.Context, teacherID, questionID int) error {
+	if _, _, err := uc.findEditableQuestion(ctx, teacherID, questionID); err != nil {
+		return err
+	}
+	return uc.quizRepo.DeleteQuestion(ctx, questionID)
+}
+
+// ! ==================== QUIZZES (TEACHER) ====================
+
+func (uc *quizUseCase) CreateQuiz(ctx context.Context, teacherID int, req *dto.CreateQuizRequest) (*dto.QuizResponse, error) {
+	//! 1. Enseignant de la matière, classe et période de la même année
+	teacher, err := uc.verifySubjectTeacher(ctx, teacherID, req.SubjectID)
+	if err != nil {
+		return nil, err
+	}
+	_, term, err := uc.findClassTerm(ctx, teacher.SchoolID, req.ClassID, req.TermID)
+	if err != nil {
+		return nil, err
+	}
+
+	//! 2. Fenêtre d'ouverture comprise dans la période (la note y sera rattachée)
+	opensAt, err := parseTimestamp(req.OpensAt)
+	if err != nil {
+		return nil, domain.ErrQuizInvalidWindow
+	}
+	closesAt, err := parseTimestamp(req.ClosesAt)
+	if err != nil {
+		return nil, domain.ErrQuizInvalidWindow
+	}
+	quiz, err := domain.NewQuiz(teacher.SchoolID, req.SubjectID, req.ClassID, term.ID, teacher.ID,
+		req.Title, req.Description, req.DurationMinutes, opensAt, closesAt, req.MaxAttempts, req.Coefficient)
+	if err != nil {
+		return nil, err
+	}
+	if !term.Contains(quiz.OpensAt) || !term.Contains(quiz.ClosesAt) {
+		return nil, domain.ErrTermInvalidDates
+	}
+
+	//! 3. Questions de la banque de la matière (doublons ignorés)
+	questionIDs := make([]int, 0, len(req.QuestionIDs))
+	seen := make(map[int]bool, len(req.QuestionIDs))
+	questions := make([]*domain.Question, 0, len(req.QuestionIDs))
+	for _, id := range req.QuestionIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		question, err := uc.findQuestion(ctx, teacher.SchoolID, id)
+		if err != nil {
+			return nil, err
+		}
+		if question.SubjectID != quiz.SubjectID {
+			return nil, domain.ErrQuestionNotFound
+		}
+		questionIDs = append(questionIDs, id)
+		questions = append(questions, question)
+	}
+	if len(questionIDs) == 0 {
+		return nil, domain.ErrQuizQuestionsRequired
+	}
+
+	if err := uc.quizRepo.Create(ctx, quiz, questionIDs); err != nil {
+		return nil, err
+	}
+	response := dto.QuizResponseFromDomain(quiz, time.Now().UTC())
+	response.Questions = dto.QuestionResponsesFromDomain(questions)
+	return &response, nil
+}
+
+// ! ListQuizzes quiz de la classe dans les matières enseignées par le professeur
+func (uc *quizUseCase) ListQuizzes(ctx context.Context, teacherID, classID int) ([]dto.QuizResponse, error) {
+	teacher, err := uc.verifyTeacher(ctx, teacherID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uc.findClass(ctx, teacher.SchoolID, classID); err != nil {
+		return nil, err
+	}
+
+	subjects, err := uc.teacherSubjectRepo.FindByTeacher(ctx, teacher.ID)
+	if err != nil {
+		return nil, err
+	}
+	taught := make(map[int]bool, len(subjects))
+	for _, s := range subjects {
+		taught[s.ID] = true
+	}
+
+	quizzes, err := uc.quizRepo.FindByClass(ctx, classID)
+	if err != nil {
+		return nil, err
+	}
+	visible := make([]*domain.Quiz, 0, len(quizzes))
+	for _, quiz := range quizzes {
+		if taught[quiz.SubjectID] {
+			visible = append(visible, quiz)
+		}
+	}
+	return dto.QuizResponsesFromDomain(visible, time.Now().UTC()), nil
+}
+
+func (uc *quizUseCase) GetQuiz(ctx context.Context, teacherID, quizID int) (*dto.QuizResponse, error) {
+	_, quiz, err := uc.findTeacherQuiz(ctx, teacherID, quizID)
+	if err != nil {
+		return nil, err
+	}
+	questions, err := uc.quizRepo.FindQuestions(ctx, quiz.ID)
+	if err != nil {
+		return nil, err
+	}
+	response := dto.QuizResponseFromDomain(quiz, time.Now().UTC())
+	response.Questions = dto.QuestionResponsesFromDomain(questions)
+	return &response, nil
+}
+
+// ! DeleteQuiz par son auteur, tant qu'il n'est pas ouvert
+func (uc *quizUseCase) DeleteQuiz(ctx context.Context, teacherID, quizID int) error {
+	teacher, quiz, err := uc.findTeacherQuiz(ctx, teacherID, quizID)
+	if err != nil {
+		return err
+	}
+	if quiz.TeacherID != teacher.ID {
+		return domain.ErrForbidden
+	}
+	if !time.Now().UTC().Before(quiz.OpensAt) {
+		return domain.ErrQuizAlreadyOpened
+	}
+	return uc.quizRepo.Delete(ctx, quiz.ID)
+}
+
+// ! ListAttempts résultats détaillés (réponses comprises) pour l'enseignant
+func (uc *quizUseCase) ListAttempts(ctx context.Context, teacherID, quizID int) ([]dto.AttemptResponse, error) {
+	_, quiz, err := uc.findTeacherQuiz(ctx, teacherID, quizID)
+	if err != nil {
+		return nil, err
+	}
+	attempts, err := uc.quizRepo.FindAttemptsByQuiz(ctx, quiz.ID)
+	if err != nil {
+		return nil, err
+	}
+	return dto.AttemptResponsesFromDomain(attempts, time.Now().UTC(), true), nil
+}
+
+// ! ==================== QUIZZES (STUDENT) ====================
+
+// ! ListMyQuizzes quiz des classes actives de l'élève
+func (uc *quizUseCase) ListMyQuizzes(ctx context.Context, studentID int) ([]dto.StudentQuizResponse, error) {
+	student, err := uc.verifyStudent(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	classes, err := uc.studentClassRepo.FindByStudent(ctx, student.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	responses := []dto.StudentQuizResponse{}
+	for _, class := range classes {
+		quizzes, err := uc.quizRepo.FindByClass(ctx, class.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, quiz := range quizzes {
+			attempts, err := uc.quizRepo.FindAttempts(ctx, quiz.ID, student.ID)
+			if err != nil {
+				return nil, err
+			}
+			responses = append(responses, studentQuizResponse(quiz, attempts, now))
+		}
+	}
+	return responses, nil
+}
+
+// ! GetMyQuiz tentatives de l'élève ; corrigé et réponses visibles après la clôture
+func (uc *quizUseCase) GetMyQuiz(ctx context.Context, studentID, quizID int) (*dto.StudentQuizResponse, error) {
+	student, quiz, err := uc.findStudentQuiz(ctx, studentID, quizID)
+	if err != nil {
+		return nil, err
+	}
+	attempts, err := uc.quizRepo.FindAttempts(ctx, quiz.ID, student.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	response := studentQuizResponse(quiz, attempts, now)
+	closed := quiz.IsClosed(now)
+	response.Attempts = dto.AttemptResponsesFromDomain(attempts, now, closed)
+	if closed {
+		questions, err := uc.quizRepo.FindQuestions(ctx, quiz.ID)
+		if err != nil {
+			return nil, err
+		}
+		response.Corrections = dto.QuestionResponsesFromDomain(questions)
+	}
+	return &response, nil
+}
+
+// ! StartAttempt démarre une tentative (ou reprend celle en cours). La deadline est
+// ! fixée par le serveur : min(maintenant + durée, clôture du quiz).
+func (uc *quizUseCase) StartAttempt(ctx context.Context, studentID, quizID int) (*dto.AttemptResponse, error) {
+	student, err := uc.verifyStudent(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var attempt *domain.QuizAttempt
+	var questions []*domain.Question
+	now := time.Now().UTC()
+	err = db.RunInTx(ctx, uc.db, func(ctx context.Context) error {
+		//! 1. Quiz verrouillé : deux démarrages simultanés ne créent pas deux tentatives
+		quiz, err := uc.quizRepo.FindByIDForUpdate(ctx, quizID)
+		if err != nil {
+			return err
+		}
+		if err := uc.verifyQuizStudent(ctx, student, quiz); err != nil {
+			return err
+		}
+		if !quiz.IsOpen(now) {
+			return domain.ErrQuizNotOpen
+		}
+		questions, err = uc.quizRepo.FindQuestions(ctx, quiz.ID)
+		if err != nil {
+			return err
+		}
+
+		//! 2. Tentative en cours : reprise ; expirée sans rendu : close à 0
+		attempts, err := uc.quizRepo.FindAttempts(ctx, quiz.ID, student.ID)
+		if err != nil {
+			return err
+		}
+		if n := len(attempts); n > 0 && !attempts[n-1].IsSubmitted() {
+			last := attempts[n-1]
+			if !last.Expired(now) {
+				attempt = last
+				return nil
+			}
+			last.Grade(questions, nil, now)
+			if err := uc.quizRepo.SubmitAttempt(ctx, last); err != nil {
+				return err
+			}
+			if err := uc.syncGrade(ctx, quiz, student.ID, now); err != nil {
+				return err
+			}
+		}
+		if len(attempts) >= quiz.MaxAttempts {
+			return domain.ErrQuizNoAttemptsLeft
+		}
+
+		//! 3. Nouvelle tentative
+		attempt = &domain.QuizAttempt{
+			SchoolID:   student.SchoolID,
+			QuizID:     quiz.ID,
+			StudentID:  student.ID,
+			Number:     len(attempts) + 1,
+			StartedAt:  now,
+			DeadlineAt: quiz.Deadline(now),
+			MaxScore:   totalPoints(questions),
+		}
+		return uc.quizRepo.CreateAttempt(ctx, attempt)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := dto.AttemptResponseFromDomain(attempt, now, false)
+	response.Questions = dto.QuizQuestionViewsFromDomain(questions)
+	return &response, nil
+}
+
+// ! SubmitAttempt corrige côté serveur ; hors délai (tolérance incluse) la tentative vaut 0.
+// ! Le score est renvoyé, le détail par question seulement après la clôture.
+func (uc *quizUseCase) SubmitAttempt(ctx context.Context, studentID, attemptID int, req *dto.SubmitAttemptRequest) (*dto.AttemptResponse, error) {
+	student, err := uc.verifyStudent(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	attempt, err := uc.quizRepo.FindAttemptByID(ctx, attemptID)
+	if err != nil {
+		return nil, err
+	}
+	if attempt.StudentID != student.ID {
+		return nil, domain.ErrQuizAttemptNotFound
+	}
+	if attempt.IsSubmitted() {
+		return nil, domain.ErrQuizAttemptSubmitted
+	}
+
+	quiz, err := uc.quizRepo.FindByID(ctx, attempt.QuizID)
+	if err != nil {
+		return nil, err
+	}
+	questions, err := uc.quizRepo.FindQuestions(ctx, quiz.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	attempt.Grade(questions, req.Answers, now)
+	err = db.RunInTx(ctx, uc.db, func(ctx context.Context) error {
+		if err := uc.quizRepo.SubmitAttempt(ctx, attempt); err != nil {
+			return err
+		}
+		return uc.syncGrade(ctx, quiz, student.ID, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := dto.AttemptResponseFromDomain(attempt, now, quiz.IsClosed(now))
+	return &response, nil
+}
+
+// ! ==================== HELPERS ====================
+
+// ! syncGrade reporte la meilleure tentative rendue dans grades (ramenée sur 20)
+func (uc *quizUseCase) syncGrade(ctx context.Context, quiz *domain.Quiz, studentID int, now time.Time) error {
+	attempts, err := uc.quizRepo.FindAttempts(ctx, quiz.ID, studentID)
+	if err != nil {
+		return err
+	}
+	var best *domain.QuizAttempt
+	for _, a := range attempts {
+		if a.IsSubmitted() && a.MaxScore > 0 && (best == nil || a.Score/a.MaxScore > best.Score/best.MaxScore) {
+			best = a
+		}
+	}
+	if best == nil {
+		return nil
+	}
+
+	gradedOn := now
+	if gradedOn.After(quiz.ClosesAt) {
+		gradedOn = quiz.ClosesAt
+	}
+	score := math.Round(best.Score/best.MaxScore*domain.DefaultMaxScore*100) / 100
+	grade, err := domain.NewGrade(quiz.SchoolID, studentID, quiz.SubjectID, quiz.ClassID, quiz.TermID, quiz.TeacherID,
+		quiz.Title, score, domain.DefaultMaxScore, quiz.Coefficient, gradedOn)
+	if err != nil {
+		return err
+	}
+	return uc.quizRepo.UpsertGrade(ctx, quiz.ID, grade)
+}
+
+func studentQuizResponse(quiz *domain.Quiz, attempts []*domain.QuizAttempt, now time.Time) dto.StudentQuizResponse {
+	response := dto.StudentQuizResponse{
+		QuizResponse: dto.QuizResponseFromDomain(quiz, now),
+		AttemptsUsed: len(attempts),
+	}
+	for _, a := range attempts {
+		if !a.IsSubmitted() {
+			continue
+		}
+		if response.BestScore == nil || a.Score > *response.BestScore {
+			score := a.Score
+			response.BestScore = &score
+			response.MaxScore = a.MaxScore
+		}
+	}
+	return response
+}
+
+func totalPoints(questions []*domain.Question) float64 {
+	var total float64
+	for _, q := range questions {
+		total += q.Points
+	}
+	return total
+}
+
+func (uc *quizUseCase) verifyTeacher(ctx context.Context, teacherID int) (*domain.User, error) {
+	teacher, err := uc.userRepo.FindByID(ctx, teacherID)
+	if err != nil {
+		return nil, err
+	}
+	if !teacher.IsTeacher() {
+		return nil, domain.ErrForbidden
+	}
+	return teacher, nil
+}
+
+// ! verifySubjectTeacher la banque d'une matière est partagée par ses enseignants
+func (uc *quizUseCase) verifySubjectTeacher(ctx context.Context, teacherID, subjectID int) (*domain.User, error) {
+	teacher, err := uc.verifyTeacher(ctx, teacherID)
+	if err != nil {
+		return nil, err
+	}
+	teaches, err := uc.teacherSubjectRepo.Exists(ctx, teacher.ID, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	if !teaches {
+		return nil, domain.ErrForbidden
+	}
+	return teacher, nil
+}
+
+func (uc *quizUseCase) verifyStudent(ctx context.Context, studentID int) (*domain.User, error) {
+	student, err := uc.userRepo.FindByID(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	if !student.IsStudent() {
+		return nil, domain.ErrForbidden
+	}
+	return student, nil
+}
+
+// ! verifyQuizStudent quiz de l'école et d'une classe où l'élève est inscrit
+func (uc *quizUseCase) verifyQuizStudent(ctx context.Context, student *domain.User, quiz *domain.Quiz) error {
+	if quiz.SchoolID != student.SchoolID {
+		return domain.ErrQuizNotFound
+	}
+	enrolled, err := uc.studentClassRepo.Exists(ctx, student.ID, quiz.ClassID)
+	if err != nil {
+		return err
+	}
+	if !enrolled {
+		return domain.ErrQuizNotFound
+	}
+	return nil
+}
+
+func (uc *quizUseCase) findStudentQuiz(ctx context.Context, studentID, quizID int) (*domain.User, *domain.Quiz, error) {
+	student, err := uc.verifyStudent(ctx, studentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	quiz, err := uc.quizRepo.FindByID(ctx, quizID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := uc.verifyQuizStudent(ctx, student, quiz); err != nil {
+		return nil, nil, err
+	}
+	return student, quiz, nil
+}
+
+// ! findTeacherQuiz quiz d'une matière enseignée par le professeur
+func (uc *quizUseCase) findTeacherQuiz(ctx context.Context, teacherID, quizID int) (*domain.User, *domain.Quiz, error) {
+	teacher, err := uc.verifyTeacher(ctx, teacherID)
+	if err != nil {
+		return nil, nil, err
+	}
+	quiz, err := uc.quizRepo.FindByID(ctx, quizID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if quiz.SchoolID != teacher.SchoolID {
+		return nil, nil, domain.ErrQuizNotFound
+	}
+	teaches, err := uc.teacherSubjectRepo.Exists(ctx, teacher.ID, quiz.SubjectID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !teaches {
+		return nil, nil, domain.ErrForbidden
+	}
+	return teacher, quiz, nil
+}
+
+// ! findQuestion question de l'école (QuestionNotFound sinon)
+func (uc *quizUseCase) findQuestion(ctx context.Context, schoolID, questionID int) (*domain.Question, error) {
+	question, err := uc.quizRepo.FindQuestionByID(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+	if question.SchoolID != schoolID {
+		return nil, domain.ErrQuestionNotFound
+	}
+	return question, nil
+}
+
+// ! findEditableQuestion enseignant de la matière, question inutilisée
+func (uc *quizUseCase) findEditableQuestion(ctx context.Context, teacherID, questionID int) (*domain.User, *domain.Question, error) {
+	teacher, err := uc.verifyTeacher(ctx, teacherID)
+	if err != nil {
+		return nil, nil, err
+	}
+	question, err := uc.findQuestion(ctx, teacher.SchoolID, questionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := uc.verifySubjectTeacher(ctx, teacher.ID, question.SubjectID); err != nil {
+		return nil, nil, err
+	}
+	used, err := uc.quizRepo.QuestionInUse(ctx, question.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if used {
+		return nil, nil, domain.ErrQuestionInUse
+	}
+	return teacher, question, nil
+}
+
+// ! findClass classe de l'école (NotFound sinon)
+func (uc *quizUseCase) findClass(ctx context.Context, schoolID, classID int) (*domain.Class, error) {
+	class, err := uc.classRepo.FindByID(ctx, classID)
+	if errors.Is(err, domain.ErrClassNotFound) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if class.SchoolID != schoolID {
+		return nil, domain.ErrNotFound
+	}
+	return class, nil
+}
+
+// ! findClassTerm classe + période de l'école, même année scolaire
+func (uc *quizUseCase) findClassTerm(ctx context.Context, schoolID, classID, termID int) (*domain.Class, *domain.Term, error) {
+	class, err := uc.findClass(ctx, schoolID, classID)
+	if err != nil {
+		return nil, nil, err
+	}
+	term, err := uc.gradeRepo.FindTermByID(ctx, termID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if term.SchoolID != schoolID {
+		return nil, nil, domain.ErrTermNotFound
+	}
+	if term.AcademicYear != class.AcademicYear {
+		return nil, nil, domain.ErrTermClassYearMismatch
+	}
+	return class, term, nil
+}
+
+// ! parseTimestamp date-heure RFC 3339, ramenée en UTC
+func parseTimestamp(value string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, strings.TrimSpace(value))
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.UTC(), nil
+}
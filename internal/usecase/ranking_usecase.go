@@ -0,0 +1,236 @@
+package usecase
+
+import (
+	"context"
+	"educnet/internal/cache"
+	"educnet/internal/domain"
+	"educnet/internal/handler/dto"
+	"educnet/internal/repository"
+	"errors"
+	"slices"
+	"sort"
+	"time"
+)
+
+// ! rankingCacheTTL durée de vie maximale d'un classement en cache (revalidé à chaque lecture)
+const rankingCacheTTL = 10 * time.Minute
+
+// ! RankingUseCase rangs par matière et général, statistiques de classe par période
+type RankingUseCase interface {
+	ClassRanking(ctx context.Context, requesterID, classID, termID int) (*dto.ClassRankingResponse, error)
+	StudentRanking(ctx context.Context, studentID, termID int) (*dto.StudentRankingResponse, error)
+
+	GetSettings(ctx context.Context, adminID int) (*domain.RankingSettings, error)
+	UpdateSettings(ctx context.Context, adminID int, req *dto.RankingSettingsRequest) (*domain.RankingSettings, error)
+}
+
+type rankingCacheKey struct {
+	classID int
+	termID  int
+}
+
+// ! rankingCacheEntry classement calculé pour un roster et une empreinte des notes donnés
+type rankingCacheEntry struct {
+	version domain.GradeSetVersion
+	roster  []int
+	ranking *domain.ClassRanking
+}
+
+type rankingUseCase struct {
+	userRepo         repository.UserRepository
+	classRepo        repository.ClassRepository
+	subjectRepo      repository.SubjectRepository
+	studentClassRepo repository.StudentClassRepository
+	gradeRepo        repository.GradeRepository
+	rankingRepo      repository.RankingRepository
+	cache            *cache.TTL[rankingCacheKey, *rankingCacheEntry]
+}
+
+func NewRankingUseCase(
+	userRepo repository.UserRepository,
+	classRepo repository.ClassRepository,
+	subjectRepo repository.SubjectRepository,
+	studentClassRepo repository.StudentClassRepository,
+	gradeRepo repository.GradeRepository,
+	rankingRepo repository.RankingRepository,
+) RankingUseCase {
+	return &rankingUseCase{
+		userRepo:         userRepo,
+		classRepo:        classRepo,
+		subjectRepo:      subjectRepo,
+		studentClassRepo: studentClassRepo,
+		gradeRepo:        gradeRepo,
+		rankingRepo:      rankingRepo,
+		cache:            cache.NewTTL[rankingCacheKey, *rankingCacheEntry](rankingCacheTTL),
+	}
+}
+
+// ! ClassRanking enseignant ou admin de l'école : classement complet et statistiques
+func (uc *rankingUseCase) ClassRanking(ctx context.Context, requesterID, classID, termID int) (*dto.ClassRankingResponse, error) {
+	requester, err := uc.userRepo.FindByID(ctx, requesterID)
+	if err != nil {
+		return nil, err
+	}
+	if !requester.IsAdmin() && !requester.IsTeacher() {
+		return nil, domain.ErrForbidden
+	}
+
+	class, err := uc.classRepo.FindByID(ctx, classID)
+	if errors.Is(err, domain.ErrClassNotFound) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if class.SchoolID != requester.SchoolID {
+		return nil, domain.ErrNotFound
+	}
+	term, err := uc.findTerm(ctx, requester.SchoolID, termID)
+	if err != nil {
+		return nil, err
+	}
+	if term.AcademicYear != class.AcademicYear {
+		return nil, domain.ErrTermClassYearMismatch
+	}
+
+	ranking, err := uc.ranking(ctx, class, term)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.ClassRankingResponse{ClassName: class.Name, TermName: term.Name, ClassRanking: ranking}, nil
+}
+
+// ! StudentRanking moyennes de l'élève dans sa classe de l'année ; rangs seulement si
+// ! l'école l'autorise
+func (uc *rankingUseCase) StudentRanking(ctx context.Context, studentID, termID int) (*dto.StudentRankingResponse, error) {
+	student, err := uc.userRepo.FindByID(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	if !student.IsStudent() {
+		return nil, domain.ErrForbidden
+	}
+	term, err := uc.findTerm(ctx, student.SchoolID, termID)
+	if err != nil {
+		return nil, err
+	}
+
+	//! Inscription active pour l'année de la période (un élève parti n'est plus classé)
+	history, err := uc.studentClassRepo.FindHistory(ctx, student.ID)
+	if err != nil {
+		return nil, err
+	}
+	classID := 0
+	for _, enrollment := range history {
+		if enrollment.IsActive && enrollment.AcademicYear == term.AcademicYear {
+			classID = enrollment.ClassID
+			break
+		}
+	}
+	if classID == 0 {
+		return nil, domain.ErrStudentClassNotFound
+	}
+	class, err := uc.classRepo.FindByID(ctx, classID)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := uc.rankingRepo.FindSettings(ctx, student.SchoolID)
+	if err != nil {
+		return nil, err
+	}
+	ranking, err := uc.ranking(ctx, class, term)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.StudentRankingResponse{
+		ClassName:      class.Name,
+		TermName:       term.Name,
+		StudentRanking: ranking.ForStudent(student.ID, settings.StudentRankVisible),
+	}, nil
+}
+
+// ! ==================== SETTINGS ====================
+
+func (uc *rankingUseCase) GetSettings(ctx context.Context, adminID int) (*domain.RankingSettings, error) {
+	admin, err := uc.verifyAdmin(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.rankingRepo.FindSettings(ctx, admin.SchoolID)
+}
+
+func (uc *rankingUseCase) UpdateSettings(ctx context.Context, adminID int, req *dto.RankingSettingsRequest) (*domain.RankingSettings, error) {
+	admin, err := uc.verifyAdmin(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	settings := &domain.RankingSettings{
+		SchoolID:           admin.SchoolID,
+		StudentRankVisible: req.StudentRankVisible,
+		UpdatedBy:          admin.ID,
+	}
+	if err := uc.rankingRepo.SaveSettings(ctx, settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// ! ==================== HELPERS ====================
+
+// ! ranking classement en cache tant que le roster et l'empreinte des notes sont inchangés ;
+// ! l'empreinte est lue avant les notes : une écriture concurrente provoque au pire un recalcul
+func (uc *rankingUseCase) ranking(ctx context.Context, class *domain.Class, term *domain.Term) (*domain.ClassRanking, error) {
+	students, err := uc.studentClassRepo.FindByClass(ctx, class.ID)
+	if err != nil {
+		return nil, err
+	}
+	roster := make([]int, len(students))
+	for i, s := range students {
+		roster[i] = s.ID
+	}
+	sort.Ints(roster)
+
+	version, err := uc.rankingRepo.GradeSetVersion(ctx, class.ID, term.ID)
+	if err != nil {
+		return nil, err
+	}
+	key := rankingCacheKey{classID: class.ID, termID: term.ID}
+	if entry, ok := uc.cache.Get(key); ok && entry.version == version && slices.Equal(entry.roster, roster) {
+		return entry.ranking, nil
+	}
+
+	subjects, err := uc.subjectRepo.FindBySchoolID(ctx, class.SchoolID)
+	if err != nil {
+		return nil, err
+	}
+	grades, err := uc.gradeRepo.FindByClassTerm(ctx, class.ID, term.ID)
+	if err != nil {
+		return nil, err
+	}
+	ranking := domain.BuildClassRanking(class.ID, term.ID, students, subjects, grades, time.Now())
+	uc.cache.Set(key, &rankingCacheEntry{version: version, roster: roster, ranking: ranking})
+	return ranking, nil
+}
+
+func (uc *rankingUseCase) findTerm(ctx context.Context, schoolID, termID int) (*domain.Term, error) {
+	term, err := uc.gradeRepo.FindTermByID(ctx, termID)
+	if err != nil {
+		return nil, err
+	}
+	if term.SchoolID != schoolID {
+		return nil, domain.ErrTermNotFound
+	}
+	return term, nil
+}
+
+func (uc *rankingUseCase) verifyAdmin(ctx context.Context, adminID int) (*domain.User, error) {
+	admin, err := uc.userRepo.FindByID(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	if !admin.IsAdmin() {
+		return nil, domain.ErrForbidden
+	}
+	return admin, nil
+}
@@ -0,0 +1,271 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"educnet/internal/domain"
+	"educnet/internal/handler/dto"
+	"educnet/internal/reportcard"
+	"educnet/internal/repository"
+	"educnet/internal/utils"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// ! ReportCardUseCase bulletins PDF générés localement (aucun service externe)
+type ReportCardUseCase interface {
+	StudentReportCard(ctx context.Context, requesterID, studentID, termID int) (*dto.FileResponse, error)
+	ClassReportCards(ctx context.Context, adminUserID, classID, termID int) (*dto.FileResponse, error)
+}
+
+type reportCardUseCase struct {
+	userRepo          repository.UserRepository
+	schoolRepo        repository.SchoolRepository
+	classRepo         repository.ClassRepository
+	subjectRepo       repository.SubjectRepository
+	studentClassRepo  repository.StudentClassRepository
+	gradeRepo         repository.GradeRepository
+	attendanceRepo    repository.AttendanceRepository
+	studentNumberRepo repository.StudentNumberRepository
+}
+
+func NewReportCardUseCase(
+	userRepo repository.UserRepository,
+	schoolRepo repository.SchoolRepository,
+	classRepo repository.ClassRepository,
+	subjectRepo repository.SubjectRepository,
+	studentClassRepo repository.StudentClassRepository,
+	gradeRepo repository.GradeRepository,
+	attendanceRepo repository.AttendanceRepository,
+	studentNumberRepo repository.StudentNumberRepository,
+) ReportCardUseCase {
+	return &reportCardUseCase{
+		userRepo:          userRepo,
+		schoolRepo:        schoolRepo,
+		classRepo:         classRepo,
+		subjectRepo:       subjectRepo,
+		studentClassRepo:  studentClassRepo,
+		gradeRepo:         gradeRepo,
+		attendanceRepo:    attendanceRepo,
+		studentNumberRepo: studentNumberRepo,
+	}
+}
+
+// ! StudentReportCard admin : tout élève de l'école ; élève : son propre bulletin
+func (uc *reportCardUseCase) StudentReportCard(ctx context.Context, requesterID, studentID, termID int) (*dto.FileResponse, error) {
+	//! 1. Verify requester
+	requester, err := uc.userRepo.FindByID(ctx, requesterID)
+	if err != nil {
+		return nil, err
+	}
+	if !requester.IsAdmin() && requester.ID != studentID {
+		return nil, domain.ErrForbidden
+	}
+
+	student, err := uc.userRepo.FindByID(ctx, studentID)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if student.SchoolID != requester.SchoolID {
+		return nil, domain.ErrNotFound
+	}
+	if !student.IsStudent() {
+		return nil, domain.ErrUserNotStudent
+	}
+
+	//! 2. Classe de l'élève pour l'année de la période (la plus récente si transfert)
+	term, err := uc.findTerm(ctx, student.SchoolID, termID)
+	if err != nil {
+		return nil, err
+	}
+	history, err := uc.studentClassRepo.FindHistory(ctx, student.ID)
+	if err != nil {
+		return nil, err
+	}
+	classID := 0
+	for _, enrollment := range history {
+		if enrollment.AcademicYear == term.AcademicYear {
+			classID = enrollment.ClassID
+			break
+		}
+	}
+	if classID == 0 {
+		return nil, domain.ErrStudentClassNotFound
+	}
+
+	//! 3. Le rang dépend de toute la classe : calcul pour la classe, extraction de l'élève
+	cards, school, err := uc.buildCards(ctx, classID, term, student)
+	if err != nil {
+		return nil, err
+	}
+	for _, card := range cards {
+		if card.Student.ID != student.ID {
+			continue
+		}
+		data, err := reportcard.Render(card, loadLogo(school))
+		if err != nil {
+			return nil, err
+		}
+		return &dto.FileResponse{FileName: reportcard.FileName(card), ContentType: "application/pdf", Data: data}, nil
+	}
+	return nil, domain.ErrStudentClassNotFound
+}
+
+// ! ClassReportCards archive ZIP des bulletins de toute la classe (admin)
+func (uc *reportCardUseCase) ClassReportCards(ctx context.Context, adminUserID, classID, termID int) (*dto.FileResponse, error) {
+	admin, err := uc.userRepo.FindByID(ctx, adminUserID)
+	if err != nil {
+		return nil, err
+	}
+	if !admin.IsAdmin() {
+		return nil, domain.ErrForbidden
+	}
+	term, err := uc.findTerm(ctx, admin.SchoolID, termID)
+	if err != nil {
+		return nil, err
+	}
+
+	cards, school, err := uc.buildCards(ctx, classID, term, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := reportcard.WriteZip(&buf, cards, loadLogo(school)); err != nil {
+		return nil, err
+	}
+	return &dto.FileResponse{
+		FileName:    fmt.Sprintf("bulletins-%s-%s.zip", utils.CreateSlug(cards[0].Class.Name), utils.CreateSlug(term.Name)),
+		ContentType: "application/zip",
+		Data:        buf.Bytes(),
+	}, nil
+}
+
+// ! ==================== HELPERS ====================
+
+// ! buildCards charge les données de la classe pour la période ; extra = élève
+// ! transféré hors de la classe en cours d'année (ajouté aux élèves actifs)
+func (uc *reportCardUseCase) buildCards(ctx context.Context, classID int, term *domain.Term, extra *domain.User) ([]*domain.ReportCard, *domain.School, error) {
+	//! 1. Classe + école
+	class, err := uc.classRepo.FindByID(ctx, classID)
+	if errors.Is(err, domain.ErrClassNotFound) {
+		return nil, nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if class.SchoolID != term.SchoolID {
+		return nil, nil, domain.ErrNotFound
+	}
+	if class.AcademicYear != term.AcademicYear {
+		return nil, nil, domain.ErrTermClassYearMismatch
+	}
+	school, err := uc.schoolRepo.FindByID(ctx, class.SchoolID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	//! 2. Élèves
+	students, err := uc.studentClassRepo.FindByClass(ctx, class.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if extra != nil && !containsUser(students, extra.ID) {
+		students = append(students, extra)
+	}
+	if len(students) == 0 {
+		return nil, nil, domain.ErrStudentClassNotFound
+	}
+	studentIDs := make([]int, len(students))
+	for i, s := range students {
+		studentIDs[i] = s.ID
+	}
+
+	//! 3. Notes, appréciations, absences
+	subjects, err := uc.subjectRepo.FindBySchoolID(ctx, class.SchoolID)
+	if err != nil {
+		return nil, nil, err
+	}
+	grades, err := uc.gradeRepo.FindByClassTerm(ctx, class.ID, term.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	comments, err := uc.gradeRepo.FindComments(ctx, term.ID, studentIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	attendance, err := uc.attendanceRepo.Summaries(ctx, studentIDs, term.StartDate, term.EndDate)
+	if err != nil {
+		return nil, nil, err
+	}
+	studentNumbers, err := uc.studentNumberRepo.FindBySchool(ctx, class.SchoolID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	//! 4. Noms des enseignants
+	teacherNames := map[int]string{}
+	for _, g := range grades {
+		if _, ok := teacherNames[g.TeacherID]; ok || g.TeacherID == 0 {
+			continue
+		}
+		teacherNames[g.TeacherID] = ""
+		if teacher, err := uc.userRepo.FindByID(ctx, g.TeacherID); err == nil {
+			teacherNames[g.TeacherID] = teacher.GetFullName()
+		}
+	}
+
+	cards := domain.BuildReportCards(domain.ReportCardInput{
+		School:         school,
+		Class:          class,
+		Term:           term,
+		Students:       students,
+		Subjects:       subjects,
+		Grades:         grades,
+		Comments:       comments,
+		Attendance:     attendance,
+		TeacherNames:   teacherNames,
+		StudentNumbers: studentNumbers,
+		GeneratedAt:    time.Now(),
+	})
+	return cards, school, nil
+}
+
+// ! findTerm période de l'école (TermNotFound sinon)
+func (uc *reportCardUseCase) findTerm(ctx context.Context, schoolID, termID int) (*domain.Term, error) {
+	term, err := uc.gradeRepo.FindTermByID(ctx, termID)
+	if err != nil {
+		return nil, err
+	}
+	if term.SchoolID != schoolID {
+		return nil, domain.ErrTermNotFound
+	}
+	return term, nil
+}
+
+func containsUser(users []*domain.User, id int) bool {
+	for _, u := range users {
+		if u.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// ! loadLogo lit le logo téléversé (/uploads/...) ; absent ou illisible => bulletin sans logo
+func loadLogo(school *domain.School) []byte {
+	path, ok := utils.UploadPath(school.LogoURL)
+	if !ok {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	return data
+}
@@ -0,0 +1,553 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"educnet/internal/db"
+	"educnet/internal/domain"
+	"educnet/internal/handler/dto"
+	"educnet/internal/repository"
+	"educnet/internal/utils"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// ! ResourceUseCase bibliothèque de cours : les enseignants d'une matière la partagent,
+// ! les élèves voient les ressources de leurs classes (ou de leur niveau)
+type ResourceUseCase interface {
+	CreateFolder(ctx context.Context, teacherID int, req *dto.CreateFolderRequest) (*dto.ResourceFolderResponse, error)
+	ListFolders(ctx context.Context, userID, subjectID int) ([]dto.ResourceFolderResponse, error)
+	DeleteFolder(ctx context.Context, teacherID, folderID int) error
+
+	CreateResource(ctx context.Context, teacherID int, req *dto.ResourceRequest, file *dto.UploadedFile) (*dto.ResourceResponse, error)
+	UpdateResource(ctx context.Context, teacherID, resourceID int, req *dto.ResourceRequest) (*dto.ResourceResponse, error)
+	DeleteResource(ctx context.Context, teacherID, resourceID int) error
+	ListResources(ctx context.Context, teacherID int, query dto.ResourceQuery) ([]dto.ResourceResponse, error)
+	AddVersion(ctx context.Context, teacherID, resourceID int, file *dto.UploadedFile) (*dto.ResourceResponse, error)
+	ListVersions(ctx context.Context, teacherID, resourceID int) ([]dto.ResourceVersionResponse, error)
+
+	ListMyResources(ctx context.Context, studentID int, query dto.ResourceQuery) ([]dto.ResourceResponse, error)
+	Download(ctx context.Context, userID, resourceID, version int) (*dto.ResourceDownload, error)
+}
+
+type resourceUseCase struct {
+	db                 *sql.DB
+	userRepo           repository.UserRepository
+	classRepo          repository.ClassRepository
+	subjectRepo        repository.SubjectRepository
+	teacherSubjectRepo repository.TeacherSubjectRepository
+	studentClassRepo   repository.StudentClassRepository
+	resourceRepo       repository.ResourceRepository
+}
+
+func NewResourceUseCase(
+	db *sql.DB,
+	userRepo repository.UserRepository,
+	classRepo repository.ClassRepository,
+	subjectRepo repository.SubjectRepository,
+	teacherSubjectRepo repository.TeacherSubjectRepository,
+	studentClassRepo repository.StudentClassRepository,
+	resourceRepo repository.ResourceRepository,
+) ResourceUseCase {
+	return &resourceUseCase{
+		db:                 db,
+		userRepo:           userRepo,
+		classRepo:          classRepo,
+		subjectRepo:        subjectRepo,
+		teacherSubjectRepo: teacherSubjectRepo,
+		studentClassRepo:   studentClassRepo,
+		resourceRepo:       resourceRepo,
+	}
+}
+
+// ! ==================== FOLDERS ====================
+
+func (uc *resourceUseCase) CreateFolder(ctx context.Context, teacherID int, req *dto.CreateFolderRequest) (*dto.ResourceFolderResponse, error) {
+	teacher, err := uc.verifySubjectTeacher(ctx, teacherID, req.SubjectID)
+	if err != nil {
+		return nil, err
+	}
+	if req.ParentID != nil {
+		if _, err := uc.findFolder(ctx, teacher.SchoolID, req.SubjectID, *req.ParentID); err != nil {
+			return nil, err
+		}
+	}
+
+	folder, err := domain.NewResourceFolder(teacher.SchoolID, req.SubjectID, req.ParentID, req.Name, teacher.ID)
+	if err != nil {
+		return nil, err
+	}
+	exists, err := uc.resourceRepo.FolderExists(ctx, folder.SubjectID, folder.ParentID, folder.Name)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, domain.ErrResourceFolderExists
+	}
+
+	if err := uc.resourceRepo.CreateFolder(ctx, folder); err != nil {
+		return nil, err
+	}
+	return &dto.ResourceFolderResponsesFromDomain([]*domain.ResourceFolder{folder})[0], nil
+}
+
+// ! ListFolders enseignant de la matière, ou élève de l'école (les noms de dossiers ne sont pas filtrés)
+func (uc *resourceUseCase) ListFolders(ctx context.Context, userID, subjectID int) ([]dto.ResourceFolderResponse, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case user.IsTeacher():
+		if _, err := uc.verifySubjectTeacher(ctx, user.ID, subjectID); err != nil {
+			return nil, err
+		}
+	case user.IsStudent():
+		subject, err := uc.subjectRepo.FindByID(ctx, subjectID)
+		if err != nil {
+			return nil, err
+		}
+		if subject.SchoolID != user.SchoolID {
+			return nil, domain.ErrSubjectNotFound
+		}
+	default:
+		return nil, domain.ErrForbidden
+	}
+
+	folders, err := uc.resourceRepo.FindFolders(ctx, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ResourceFolderResponsesFromDomain(folders), nil
+}
+
+// ! DeleteFolder uniquement vide (ni sous-dossier ni ressource)
+func (uc *resourceUseCase) DeleteFolder(ctx context.Context, teacherID, folderID int) error {
+	teacher, err := uc.verifyTeacher(ctx, teacherID)
+	if err != nil {
+		return err
+	}
+	folder, err := uc.resourceRepo.FindFolderByID(ctx, folderID)
+	if err != nil {
+		return err
+	}
+	if folder.SchoolID != teacher.SchoolID {
+		return domain.ErrResourceFolderNotFound
+	}
+	if _, err := uc.verifySubjectTeacher(ctx, teacher.ID, folder.SubjectID); err != nil {
+		return err
+	}
+
+	empty, err := uc.resourceRepo.FolderIsEmpty(ctx, folder.ID)
+	if err != nil {
+		return err
+	}
+	if !empty {
+		return domain.ErrResourceFolderNotEmpty
+	}
+	return uc.resourceRepo.DeleteFolder(ctx, folder.ID)
+}
+
+// ! ==================== RESOURCES (TEACHER) ====================
+
+func (uc *resourceUseCase) CreateResource(ctx context.Context, teacherID int, req *dto.ResourceRequest, file *dto.UploadedFile) (*dto.ResourceResponse, error) {
+	//! 1. Enseignant de la matière, classe et dossier cohérents
+	teacher, err := uc.verifySubjectTeacher(ctx, teacherID, req.SubjectID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.verifyTarget(ctx, teacher.SchoolID, req.SubjectID, req); err != nil {
+		return nil, err
+	}
+
+	resource, err := domain.NewResource(teacher.SchoolID, req.SubjectID, req.ClassID, req.Level, req.Chapter,
+		req.FolderID, req.Title, req.Description, req.Kind, req.URL, req.Tags, teacher.ID)
+	if err != nil {
+		return nil, err
+	}
+	if resource.Kind == domain.ResourceFile && file == nil {
+		return nil, domain.ErrResourceFileRequired
+	}
+
+	//! 2. Ressource + première version du fichier dans la même transaction
+	var version *domain.ResourceVersion
+	err = db.RunInTx(ctx, uc.db, func(ctx context.Context) error {
+		if err := uc.resourceRepo.Create(ctx, resource); err != nil {
+			return err
+		}
+		if resource.Kind != domain.ResourceFile {
+			return nil
+		}
+		version, err = uc.storeVersion(ctx, resource, 1, teacher.ID, file)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resourceResponse(resource, version), nil
+}
+
+// ! UpdateResource métadonnées seulement ; le type ne change pas, le fichier passe par AddVersion
+func (uc *resourceUseCase) UpdateResource(ctx context.Context, teacherID, resourceID int, req *dto.ResourceRequest) (*dto.ResourceResponse, error) {
+	teacher, resource, err := uc.findTeacherResource(ctx, teacherID, resourceID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.verifyTarget(ctx, teacher.SchoolID, resource.SubjectID, req); err != nil {
+		return nil, err
+	}
+
+	updated, err := domain.NewResource(resource.SchoolID, resource.SubjectID, req.ClassID, req.Level, req.Chapter,
+		req.FolderID, req.Title, req.Description, resource.Kind, req.URL, req.Tags, resource.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+	updated.ID = resource.ID
+	updated.CurrentVersion = resource.CurrentVersion
+	updated.DownloadCount = resource.DownloadCount
+	updated.CreatedAt = resource.CreatedAt
+
+	if err := uc.resourceRepo.Update(ctx, updated); err != nil {
+		return nil, err
+	}
+	return resourceResponse(updated, nil), nil
+}
+
+// ! DeleteResource les fichiers de toutes les versions sont supprimés après validation
+func (uc *resourceUseCase) DeleteResource(ctx context.Context, teacherID, resourceID int) error {
+	_, resource, err := uc.findTeacherResource(ctx, teacherID, resourceID)
+	if err != nil {
+		return err
+	}
+	versions, err := uc.resourceRepo.FindVersions(ctx, resource.ID)
+	if err != nil {
+		return err
+	}
+	if err := uc.resourceRepo.Delete(ctx, resource.ID); err != nil {
+		return err
+	}
+
+	db.AfterCommit(ctx, func() {
+		for _, v := range versions {
+			os.Remove(v.StoragePath)
+		}
+	})
+	return nil
+}
+
+// ! ListResources toutes les ressources des matières enseignées
+func (uc *resourceUseCase) ListResources(ctx context.Context, teacherID int, query dto.ResourceQuery) ([]dto.ResourceResponse, error) {
+	teacher, err := uc.verifyTeacher(ctx, teacherID)
+	if err != nil {
+		return nil, err
+	}
+	subjects, err := uc.teacherSubjectRepo.FindByTeacher(ctx, teacher.ID)
+	if err != nil {
+		return nil, err
+	}
+	subjectIDs := make([]int, len(subjects))
+	for i, subject := range subjects {
+		subjectIDs[i] = subject.ID
+	}
+
+	filter := resourceFilter(teacher.SchoolID, query)
+	filter.SubjectIDs = subjectIDs
+	resources, err := uc.resourceRepo.Search(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ResourceResponsesFromDomain(resources), nil
+}
+
+// ! AddVersion remplace le fichier : nouvelle version courante, les précédentes sont conservées
+func (uc *resourceUseCase) AddVersion(ctx context.Context, teacherID, resourceID int, file *dto.UploadedFile) (*dto.ResourceResponse, error) {
+	teacher, resource, err := uc.findTeacherResource(ctx, teacherID, resourceID)
+	if err != nil {
+		return nil, err
+	}
+	if resource.Kind != domain.ResourceFile {
+		return nil, domain.ErrResourceNotFile
+	}
+	if file == nil {
+		return nil, domain.ErrResourceFileRequired
+	}
+
+	version, err := uc.storeVersion(ctx, resource, resource.CurrentVersion+1, teacher.ID, file)
+	if err != nil {
+		return nil, err
+	}
+	resource.CurrentVersion = version.Version
+	return resourceResponse(resource, version), nil
+}
+
+func (uc *resourceUseCase) ListVersions(ctx context.Context, teacherID, resourceID int) ([]dto.ResourceVersionResponse, error) {
+	_, resource, err := uc.findTeacherResource(ctx, teacherID, resourceID)
+	if err != nil {
+		return nil, err
+	}
+	versions, err := uc.resourceRepo.FindVersions(ctx, resource.ID)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ResourceVersionResponsesFromDomain(versions), nil
+}
+
+// ! ==================== RESOURCES (STUDENT) ====================
+
+// ! ListMyResources ressources des classes de l'élève et des niveaux de ces classes
+func (uc *resourceUseCase) ListMyResources(ctx context.Context, studentID int, query dto.ResourceQuery) ([]dto.ResourceResponse, error) {
+	student, classes, err := uc.findStudentClasses(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := resourceFilter(student.SchoolID, query)
+	filter.ClassIDs = []int{}
+	filter.Levels = []string{}
+	for _, class := range classes {
+		filter.ClassIDs = append(filter.ClassIDs, class.ID)
+		filter.Levels = append(filter.Levels, class.Level)
+	}
+	resources, err := uc.resourceRepo.Search(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ResourceResponsesFromDomain(resources), nil
+}
+
+// ! Download compte le téléchargement ; version 0 = version courante.
+// ! Seuls les enseignants de la matière accèdent aux anciennes versions.
+func (uc *resourceUseCase) Download(ctx context.Context, userID, resourceID, version int) (*dto.ResourceDownload, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var resource *domain.Resource
+	switch {
+	case user.IsTeacher():
+		if _, resource, err = uc.findTeacherResource(ctx, user.ID, resourceID); err != nil {
+			return nil, err
+		}
+	case user.IsStudent():
+		if resource, err = uc.findStudentResource(ctx, user.ID, resourceID); err != nil {
+			return nil, err
+		}
+		if version != 0 && version != resource.CurrentVersion {
+			return nil, domain.ErrResourceVersionNotFound
+		}
+	default:
+		return nil, domain.ErrForbidden
+	}
+
+	download := &dto.ResourceDownload{}
+	if resource.Kind == domain.ResourceLink {
+		download.URL = resource.URL
+	} else {
+		if version == 0 {
+			version = resource.CurrentVersion
+		}
+		v, err := uc.resourceRepo.FindVersion(ctx, resource.ID, version)
+		if err != nil {
+			return nil, err
+		}
+		data, err := os.ReadFile(v.StoragePath)
+		if err != nil {
+			return nil, fmt.Errorf("read resource file: %w", err)
+		}
+		download.File = &dto.FileResponse{FileName: v.FileName, ContentType: v.ContentType, Data: data}
+	}
+
+	if err := uc.resourceRepo.IncrementDownloads(ctx, resource.ID); err != nil {
+		return nil, err
+	}
+	return download, nil
+}
+
+// ! ==================== HELPERS ====================
+
+// ! storeVersion écrit le fichier puis l'enregistre ; le fichier est retiré si l'insertion échoue
+func (uc *resourceUseCase) storeVersion(ctx context.Context, resource *domain.Resource, number, uploadedBy int, file *dto.UploadedFile) (*domain.ResourceVersion, error) {
+	version, err := domain.NewResourceVersion(resource.ID, number, file.FileName, file.ContentType, file.Size, uploadedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join(utils.ResourceDir, strconv.Itoa(resource.SchoolID))
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("create resource directory: %w", err)
+	}
+	version.StoragePath = filepath.Join(dir, fmt.Sprintf("%d_v%d%s", resource.ID, number, version.Ext()))
+
+	if err := writeLimitedFile(version.StoragePath, file.Content, domain.ResourceMaxFileSize, domain.ErrResourceFileTooLarge); err != nil {
+		return nil, err
+	}
+	if err := uc.resourceRepo.AddVersion(ctx, version); err != nil {
+		os.Remove(version.StoragePath)
+		return nil, err
+	}
+	return version, nil
+}
+
+// ! writeLimitedFile copie au plus maxSize octets (la taille annoncée n'est pas fiable) ;
+// ! le fichier est retiré et errTooLarge renvoyée au-delà
+func writeLimitedFile(path string, content io.Reader, maxSize int64, errTooLarge error) error {
+	dst, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o640)
+	if err != nil {
+		return fmt.Errorf("create file: %w", err)
+	}
+
+	n, err := io.Copy(dst, io.LimitReader(content, maxSize+1))
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && n > maxSize {
+		err = errTooLarge
+	}
+	if err != nil {
+		os.Remove(path)
+		if errors.Is(err, errTooLarge) {
+			return err
+		}
+		return fmt.Errorf("write file: %w", err)
+	}
+	return nil
+}
+
+func resourceResponse(resource *domain.Resource, version *domain.ResourceVersion) *dto.ResourceResponse {
+	response := dto.ResourceResponseFromDomain(resource)
+	if version != nil {
+		response.File = &dto.ResourceVersionResponsesFromDomain([]*domain.ResourceVersion{version})[0]
+	}
+	return &response
+}
+
+func resourceFilter(schoolID int, query dto.ResourceQuery) domain.ResourceFilter {
+	return domain.ResourceFilter{
+		SchoolID:  schoolID,
+		SubjectID: query.SubjectID,
+		ClassID:   query.ClassID,
+		Level:     query.Level,
+		Chapter:   query.Chapter,
+		FolderID:  query.FolderID,
+		Tag:       query.Tag,
+		Query:     query.Query,
+	}
+}
+
+// ! verifyTarget classe de l'école, dossier de la même matière
+func (uc *resourceUseCase) verifyTarget(ctx context.Context, schoolID, subjectID int, req *dto.ResourceRequest) error {
+	if req.ClassID != nil {
+		class, err := uc.classRepo.FindByID(ctx, *req.ClassID)
+		if errors.Is(err, domain.ErrClassNotFound) {
+			return domain.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if class.SchoolID != schoolID {
+			return domain.ErrNotFound
+		}
+		if req.Level == "" {
+			req.Level = class.Level
+		}
+	}
+	if req.FolderID != nil {
+		if _, err := uc.findFolder(ctx, schoolID, subjectID, *req.FolderID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (uc *resourceUseCase) findFolder(ctx context.Context, schoolID, subjectID, folderID int) (*domain.ResourceFolder, error) {
+	folder, err := uc.resourceRepo.FindFolderByID(ctx, folderID)
+	if err != nil {
+		return nil, err
+	}
+	if folder.SchoolID != schoolID || folder.SubjectID != subjectID {
+		return nil, domain.ErrResourceFolderNotFound
+	}
+	return folder, nil
+}
+
+func (uc *resourceUseCase) verifyTeacher(ctx context.Context, teacherID int) (*domain.User, error) {
+	teacher, err := uc.userRepo.FindByID(ctx, teacherID)
+	if err != nil {
+		return nil, err
+	}
+	if !teacher.IsTeacher() {
+		return nil, domain.ErrForbidden
+	}
+	return teacher, nil
+}
+
+// ! verifySubjectTeacher la bibliothèque d'une matière est partagée par ses enseignants
+func (uc *resourceUseCase) verifySubjectTeacher(ctx context.Context, teacherID, subjectID int) (*domain.User, error) {
+	teacher, err := uc.verifyTeacher(ctx, teacherID)
+	if err != nil {
+		return nil, err
+	}
+	teaches, err := uc.teacherSubjectRepo.Exists(ctx, teacher.ID, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	if !teaches {
+		return nil, domain.ErrForbidden
+	}
+	return teacher, nil
+}
+
+func (uc *resourceUseCase) findTeacherResource(ctx context.Context, teacherID, resourceID int) (*domain.User, *domain.Resource, error) {
+	teacher, err := uc.verifyTeacher(ctx, teacherID)
+	if err != nil {
+		return nil, nil, err
+	}
+	resource, err := uc.resourceRepo.FindByID(ctx, resourceID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resource.SchoolID != teacher.SchoolID {
+		return nil, nil, domain.ErrResourceNotFound
+	}
+	if _, err := uc.verifySubjectTeacher(ctx, teacher.ID, resource.SubjectID); err != nil {
+		return nil, nil, err
+	}
+	return teacher, resource, nil
+}
+
+func (uc *resourceUseCase) findStudentClasses(ctx context.Context, studentID int) (*domain.User, []*domain.Class, error) {
+	student, err := uc.userRepo.FindByID(ctx, studentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !student.IsStudent() {
+		return nil, nil, domain.ErrForbidden
+	}
+	classes, err := uc.studentClassRepo.FindByStudent(ctx, student.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return student, classes, nil
+}
+
+// ! findStudentResource ressource visible par l'élève (NotFound sinon, sans divulguer son existence)
+func (uc *resourceUseCase) findStudentResource(ctx context.Context, studentID, resourceID int) (*domain.Resource, error) {
+	_, classes, err := uc.findStudentClasses(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	resource, err := uc.resourceRepo.FindByID(ctx, resourceID)
+	if err != nil {
+		return nil, err
+	}
+	if !resource.VisibleTo(classes) {
+		return nil, domain.ErrResourceNotFound
+	}
+	return resource, nil
+}
@@ -0,0 +1,539 @@
+package usecase
+
+import (
+	"context"
+	"educnet/internal/domain"
+	"educnet/internal/handler/dto"
+	"educnet/internal/repository"
+	"time"
+)
+
+// ! RoomUseCase salles et équipements de l'école (gérés par l'admin), réservations des
+// ! enseignants avec validation pour les ressources spéciales, recherche de disponibilités
+type RoomUseCase interface {
+	//! Administration
+	CreateRoom(ctx context.Context, adminID int, req *dto.RoomRequest) (*dto.RoomResponse, error)
+	UpdateRoom(ctx context.Context, adminID, roomID int, req *dto.RoomRequest) (*dto.RoomResponse, error)
+	DeleteRoom(ctx context.Context, adminID, roomID int) error
+	CreateEquipment(ctx context.Context, adminID int, req *dto.EquipmentRequest) (*dto.EquipmentResponse, error)
+	UpdateEquipment(ctx context.Context, adminID, equipmentID int, req *dto.EquipmentRequest) (*dto.EquipmentResponse, error)
+	DeleteEquipment(ctx context.Context, adminID, equipmentID int) error
+	ListBookings(ctx context.Context, adminID int, query dto.BookingQuery) ([]dto.BookingResponse, error)
+	ReviewBooking(ctx context.Context, adminID, bookingID int, req *dto.BookingReviewRequest) (*dto.BookingResponse, error)
+
+	//! Enseignants et admins
+	ListRooms(ctx context.Context, userID int) ([]dto.RoomResponse, error)
+	ListEquipment(ctx context.Context, userID int) ([]dto.EquipmentResponse, error)
+	SearchAvailability(ctx context.Context, userID int, query dto.AvailabilityQuery) (*dto.AvailabilityResponse, error)
+	CreateBooking(ctx context.Context, userID int, req *dto.BookingRequest) (*dto.BookingResponse, error)
+	ListMyBookings(ctx context.Context, userID int) ([]dto.BookingResponse, error)
+	CancelBooking(ctx context.Context, userID, bookingID int) (*dto.BookingResponse, error)
+}
+
+type roomUseCase struct {
+	userRepo     repository.UserRepository
+	roomRepo     repository.RoomRepository
+	calendarRepo repository.CalendarRepository
+}
+
+func NewRoomUseCase(
+	userRepo repository.UserRepository,
+	roomRepo repository.RoomRepository,
+	calendarRepo repository.CalendarRepository,
+) RoomUseCase {
+	return &roomUseCase{
+		userRepo:     userRepo,
+		roomRepo:     roomRepo,
+		calendarRepo: calendarRepo,
+	}
+}
+
+// ! ==================== ROOMS ====================
+func (uc *roomUseCase) CreateRoom(ctx context.Context, adminID int, req *dto.RoomRequest) (*dto.RoomResponse, error) {
+	admin, err := uc.verifyAdmin(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	room, err := domain.NewRoom(admin.SchoolID, req.Name, req.Type, req.Capacity, req.Equipment, req.RequiresApproval)
+	if err != nil {
+		return nil, err
+	}
+	if req.IsActive != nil {
+		room.IsActive = *req.IsActive
+	}
+	if err := uc.checkRoomName(ctx, room, 0); err != nil {
+		return nil, err
+	}
+
+	if err := uc.roomRepo.CreateRoom(ctx, room); err != nil {
+		return nil, err
+	}
+	response := dto.RoomResponseFromDomain(room)
+	return &response, nil
+}
+
+func (uc *roomUseCase) UpdateRoom(ctx context.Context, adminID, roomID int, req *dto.RoomRequest) (*dto.RoomResponse, error) {
+	admin, err := uc.verifyAdmin(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	room, err := uc.findRoom(ctx, admin.SchoolID, roomID)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := domain.NewRoom(admin.SchoolID, req.Name, req.Type, req.Capacity, req.Equipment, req.RequiresApproval)
+	if err != nil {
+		return nil, err
+	}
+	updated.ID = room.ID
+	updated.IsActive = room.IsActive
+	updated.CreatedAt = room.CreatedAt
+	if req.IsActive != nil {
+		updated.IsActive = *req.IsActive
+	}
+	if err := uc.checkRoomName(ctx, updated, room.ID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.roomRepo.UpdateRoom(ctx, updated); err != nil {
+		return nil, err
+	}
+	response := dto.RoomResponseFromDomain(updated)
+	return &response, nil
+}
+
+// ! DeleteRoom supprime aussi ses réservations ; pour garder l'historique, désactiver la salle
+func (uc *roomUseCase) DeleteRoom(ctx context.Context, adminID, roomID int) error {
+	admin, err := uc.verifyAdmin(ctx, adminID)
+	if err != nil {
+		return err
+	}
+	if _, err := uc.findRoom(ctx, admin.SchoolID, roomID); err != nil {
+		return err
+	}
+	return uc.roomRepo.DeleteRoom(ctx, roomID)
+}
+
+// ! ListRooms admin : salles désactivées incluses
+func (uc *roomUseCase) ListRooms(ctx context.Context, userID int) ([]dto.RoomResponse, error) {
+	user, err := uc.verifyMember(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	rooms, err := uc.roomRepo.FindRooms(ctx, domain.RoomFilter{SchoolID: user.SchoolID, IncludeInactive: user.IsAdmin()})
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.RoomResponse, len(rooms))
+	for i, room := range rooms {
+		responses[i] = dto.RoomResponseFromDomain(room)
+	}
+	return responses, nil
+}
+
+// ! ==================== EQUIPMENT ====================
+func (uc *roomUseCase) CreateEquipment(ctx context.Context, adminID int, req *dto.EquipmentRequest) (*dto.EquipmentResponse, error) {
+	admin, err := uc.verifyAdmin(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	equipment, err := domain.NewEquipment(admin.SchoolID, req.Name, req.Description, req.RequiresApproval)
+	if err != nil {
+		return nil, err
+	}
+	if req.IsActive != nil {
+		equipment.IsActive = *req.IsActive
+	}
+	if err := uc.checkEquipmentName(ctx, equipment, 0); err != nil {
+		return nil, err
+	}
+
+	if err := uc.roomRepo.CreateEquipment(ctx, equipment); err != nil {
+		return nil, err
+	}
+	response := dto.EquipmentResponseFromDomain(equipment)
+	return &response, nil
+}
+
+func (uc *roomUseCase) UpdateEquipment(ctx context.Context, adminID, equipmentID int, req *dto.EquipmentRequest) (*dto.EquipmentResponse, error) {
+	admin, err := uc.verifyAdmin(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	equipment, err := uc.findEquipment(ctx, admin.SchoolID, equipmentID)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := domain.NewEquipment(admin.SchoolID, req.Name, req.Description, req.RequiresApproval)
+	if err != nil {
+		return nil, err
+	}
+	updated.ID = equipment.ID
+	updated.IsActive = equipment.IsActive
+	updated.CreatedAt = equipment.CreatedAt
+	if req.IsActive != nil {
+		updated.IsActive = *req.IsActive
+	}
+	if err := uc.checkEquipmentName(ctx, updated, equipment.ID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.roomRepo.UpdateEquipment(ctx, updated); err != nil {
+		return nil, err
+	}
+	response := dto.EquipmentResponseFromDomain(updated)
+	return &response, nil
+}
+
+func (uc *roomUseCase) DeleteEquipment(ctx context.Context, adminID, equipmentID int) error {
+	admin, err := uc.verifyAdmin(ctx, adminID)
+	if err != nil {
+		return err
+	}
+	if _, err := uc.findEquipment(ctx, admin.SchoolID, equipmentID); err != nil {
+		return err
+	}
+	return uc.roomRepo.DeleteEquipment(ctx, equipmentID)
+}
+
+func (uc *roomUseCase) ListEquipment(ctx context.Context, userID int) ([]dto.EquipmentResponse, error) {
+	user, err := uc.verifyMember(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := uc.roomRepo.FindEquipment(ctx, user.SchoolID, user.IsAdmin())
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.EquipmentResponse, len(items))
+	for i, item := range items {
+		responses[i] = dto.EquipmentResponseFromDomain(item)
+	}
+	return responses, nil
+}
+
+// ! ==================== BOOKINGS ====================
+
+// ! CreateBooking en attente pour une ressource soumise à validation, approuvée sinon.
+// ! Une salle occupée par un cours de l'emploi du temps n'est pas réservable ;
+// ! le chevauchement entre réservations est garanti par la base (ErrBookingConflict)
+func (uc *roomUseCase) CreateBooking(ctx context.Context, userID int, req *dto.BookingRequest) (*dto.BookingResponse, error) {
+	user, err := uc.verifyMember(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	startsAt, err := parseTimestamp(req.StartsAt)
+	if err != nil {
+		return nil, domain.ErrBookingInvalidDates
+	}
+	endsAt, err := parseTimestamp(req.EndsAt)
+	if err != nil {
+		return nil, domain.ErrBookingInvalidDates
+	}
+
+	requiresApproval := false
+	switch {
+	case req.RoomID != nil && req.EquipmentID == nil:
+		room, err := uc.findRoom(ctx, user.SchoolID, *req.RoomID)
+		if err != nil {
+			return nil, err
+		}
+		if !room.IsActive {
+			return nil, domain.ErrBookingUnavailable
+		}
+		requiresApproval = room.RequiresApproval
+	case req.EquipmentID != nil && req.RoomID == nil:
+		equipment, err := uc.findEquipment(ctx, user.SchoolID, *req.EquipmentID)
+		if err != nil {
+			return nil, err
+		}
+		if !equipment.IsActive {
+			return nil, domain.ErrBookingUnavailable
+		}
+		requiresApproval = equipment.RequiresApproval
+	}
+
+	booking, err := domain.NewBooking(user.SchoolID, req.RoomID, req.EquipmentID, user.ID, req.Purpose,
+		startsAt, endsAt, requiresApproval && !user.IsAdmin(), time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	if booking.RoomID != nil {
+		busy, err := uc.roomsInLessons(ctx, user.SchoolID, booking.StartsAt, booking.EndsAt)
+		if err != nil {
+			return nil, err
+		}
+		if busy[*booking.RoomID] {
+			return nil, domain.ErrBookingConflict
+		}
+	}
+
+	if err := uc.roomRepo.CreateBooking(ctx, booking); err != nil {
+		return nil, err
+	}
+	response := dto.BookingResponseFromDomain(booking)
+	return &response, nil
+}
+
+// ! ListMyBookings réservations à venir ou en cours
+func (uc *roomUseCase) ListMyBookings(ctx context.Context, userID int) ([]dto.BookingResponse, error) {
+	user, err := uc.verifyMember(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	bookings, err := uc.roomRepo.FindBookings(ctx, domain.BookingFilter{
+		SchoolID: user.SchoolID,
+		UserID:   user.ID,
+		From:     time.Now().UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bookingResponses(bookings), nil
+}
+
+// ! CancelBooking l'auteur de la réservation ou un admin
+func (uc *roomUseCase) CancelBooking(ctx context.Context, userID, bookingID int) (*dto.BookingResponse, error) {
+	user, err := uc.verifyMember(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	booking, err := uc.findBooking(ctx, user.SchoolID, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if booking.UserID != user.ID && !user.IsAdmin() {
+		return nil, domain.ErrForbidden
+	}
+	if err := booking.Cancel(time.Now().UTC()); err != nil {
+		return nil, err
+	}
+
+	if err := uc.roomRepo.UpdateBookingStatus(ctx, booking); err != nil {
+		return nil, err
+	}
+	response := dto.BookingResponseFromDomain(booking)
+	return &response, nil
+}
+
+// ! ListBookings réservations de l'école (status=pending : demandes à valider)
+func (uc *roomUseCase) ListBookings(ctx context.Context, adminID int, query dto.BookingQuery) ([]dto.BookingResponse, error) {
+	admin, err := uc.verifyAdmin(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	from, err := parseOptionalDay(query.From)
+	if err != nil {
+		return nil, domain.ErrBookingInvalidDates
+	}
+	to, err := parseOptionalDay(query.To)
+	if err != nil {
+		return nil, domain.ErrBookingInvalidDates
+	}
+
+	bookings, err := uc.roomRepo.FindBookings(ctx, domain.BookingFilter{
+		SchoolID: admin.SchoolID,
+		RoomID:   query.RoomID,
+		Status:   query.Status,
+		From:     from,
+		To:       to,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bookingResponses(bookings), nil
+}
+
+func (uc *roomUseCase) ReviewBooking(ctx context.Context, adminID, bookingID int, req *dto.BookingReviewRequest) (*dto.BookingResponse, error) {
+	admin, err := uc.verifyAdmin(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	booking, err := uc.findBooking(ctx, admin.SchoolID, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if err := booking.Review(req.Approve, admin.ID, req.Note, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+
+	if err := uc.roomRepo.UpdateBookingStatus(ctx, booking); err != nil {
+		return nil, err
+	}
+	response := dto.BookingResponseFromDomain(booking)
+	return &response, nil
+}
+
+// ! ==================== AVAILABILITY ====================
+
+// ! SearchAvailability salles actives correspondant aux critères et équipements libres
+// ! sur tout le créneau : ni réservation en attente / approuvée, ni cours dans la salle
+func (uc *roomUseCase) SearchAvailability(ctx context.Context, userID int, query dto.AvailabilityQuery) (*dto.AvailabilityResponse, error) {
+	user, err := uc.verifyMember(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	from, err := parseTimestamp(query.From)
+	if err != nil {
+		return nil, domain.ErrBookingInvalidDates
+	}
+	to, err := parseTimestamp(query.To)
+	if err != nil {
+		return nil, domain.ErrBookingInvalidDates
+	}
+	if !to.After(from) || to.Sub(from) > domain.BookingMaxDuration {
+		return nil, domain.ErrBookingInvalidDates
+	}
+
+	rooms, err := uc.roomRepo.FindRooms(ctx, domain.RoomFilter{
+		SchoolID:    user.SchoolID,
+		Type:        query.Type,
+		MinCapacity: query.MinCapacity,
+		Equipment:   query.Equipment,
+	})
+	if err != nil {
+		return nil, err
+	}
+	items, err := uc.roomRepo.FindEquipment(ctx, user.SchoolID, false)
+	if err != nil {
+		return nil, err
+	}
+	bookedRooms, bookedEquipment, err := uc.roomRepo.FindBusy(ctx, user.SchoolID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	busy, err := uc.roomsInLessons(ctx, user.SchoolID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range bookedRooms {
+		busy[id] = true
+	}
+	busyEquipment := map[int]bool{}
+	for _, id := range bookedEquipment {
+		busyEquipment[id] = true
+	}
+
+	response := &dto.AvailabilityResponse{
+		From:      from,
+		To:        to,
+		Rooms:     []dto.RoomResponse{},
+		Equipment: []dto.EquipmentResponse{},
+	}
+	for _, room := range rooms {
+		if !busy[room.ID] {
+			response.Rooms = append(response.Rooms, dto.RoomResponseFromDomain(room))
+		}
+	}
+	for _, item := range items {
+		if !busyEquipment[item.ID] {
+			response.Equipment = append(response.Equipment, dto.EquipmentResponseFromDomain(item))
+		}
+	}
+	return response, nil
+}
+
+// ! ==================== HELPERS ====================
+
+// ! roomsInLessons salles occupées sur [from, to) par un événement du calendrier (récurrences dépliées)
+func (uc *roomUseCase) roomsInLessons(ctx context.Context, schoolID int, from, to time.Time) (map[int]bool, error) {
+	events, err := uc.calendarRepo.FindEvents(ctx, domain.EventFilter{SchoolID: schoolID, From: from, To: to, WithRoom: true})
+	if err != nil {
+		return nil, err
+	}
+	busy := map[int]bool{}
+	for _, event := range events {
+		if event.RoomID != nil && len(event.Occurrences(from, to)) > 0 {
+			busy[*event.RoomID] = true
+		}
+	}
+	return busy, nil
+}
+
+func (uc *roomUseCase) checkRoomName(ctx context.Context, room *domain.Room, excludeID int) error {
+	exists, err := uc.roomRepo.RoomNameExists(ctx, room.SchoolID, room.Name, excludeID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return domain.ErrRoomExists
+	}
+	return nil
+}
+
+func (uc *roomUseCase) checkEquipmentName(ctx context.Context, equipment *domain.Equipment, excludeID int) error {
+	exists, err := uc.roomRepo.EquipmentNameExists(ctx, equipment.SchoolID, equipment.Name, excludeID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return domain.ErrEquipmentExists
+	}
+	return nil
+}
+
+func (uc *roomUseCase) findRoom(ctx context.Context, schoolID, roomID int) (*domain.Room, error) {
+	room, err := uc.roomRepo.FindRoomByID(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	if room.SchoolID != schoolID {
+		return nil, domain.ErrRoomNotFound
+	}
+	return room, nil
+}
+
+func (uc *roomUseCase) findEquipment(ctx context.Context, schoolID, equipmentID int) (*domain.Equipment, error) {
+	equipment, err := uc.roomRepo.FindEquipmentByID(ctx, equipmentID)
+	if err != nil {
+		return nil, err
+	}
+	if equipment.SchoolID != schoolID {
+		return nil, domain.ErrEquipmentNotFound
+	}
+	return equipment, nil
+}
+
+func (uc *roomUseCase) findBooking(ctx context.Context, schoolID, bookingID int) (*domain.Booking, error) {
+	booking, err := uc.roomRepo.FindBookingByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if booking.SchoolID != schoolID {
+		return nil, domain.ErrBookingNotFound
+	}
+	return booking, nil
+}
+
+func (uc *roomUseCase) verifyAdmin(ctx context.Context, adminID int) (*domain.User, error) {
+	admin, err := uc.userRepo.FindByID(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	if !admin.IsAdmin() {
+		return nil, domain.ErrForbidden
+	}
+	return admin, nil
+}
+
+// ! verifyMember enseignants et admins de l'école
+func (uc *roomUseCase) verifyMember(ctx context.Context, userID int) (*domain.User, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if (!user.IsAdmin() && !user.IsTeacher()) || user.SchoolID == 0 {
+		return nil, domain.ErrForbidden
+	}
+	return user, nil
+}
+
+func bookingResponses(bookings []*domain.Booking) []dto.BookingResponse {
+	responses := make([]dto.BookingResponse, len(bookings))
+	for i, booking := range bookings {
+		responses[i] = dto.BookingResponseFromDomain(booking)
+	}
+	return responses
+}
@@ -1,12 +1,14 @@
 package usecase
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 
+	"educnet/internal/db"
 	"educnet/internal/domain"
 	"educnet/internal/handler/dto"
 	"educnet/internal/repository"
@@ -32,8 +34,8 @@ type CreateSchoolOutput struct {
 
 // ! SchoolUseCase interface
 type SchoolUseCase interface {
-	CreateSchool(input CreateSchoolInput) (*CreateSchoolOutput, error)
-	GetAllSchool() ([]*dto.SchoolDTO, error)
+	CreateSchool(ctx context.Context, input CreateSchoolInput) (*CreateSchoolOutput, error)
+	GetAllSchool(ctx context.Context) ([]*dto.SchoolDTO, error)
 }
 
 // ! schoolUseCase implémentation
@@ -60,7 +62,7 @@ func NewSchoolUseCase(
 }
 
 // ! CreateSchool crée une nouvelle école avec son admin
-func (uc *schoolUseCase) CreateSchool(input CreateSchoolInput) (*CreateSchoolOutput, error) {
+func (uc *schoolUseCase) CreateSchool(ctx context.Context, input CreateSchoolInput) (*CreateSchoolOutput, error) {
 	//! 1. Validation basique
 	if err := uc.validateInput(input); err != nil {
 		return nil, err
@@ -70,7 +72,7 @@ func (uc *schoolUseCase) CreateSchool(input CreateSchoolInput) (*CreateSchoolOut
 	slug := utils.CreateSlug(input.SchoolName)
 
 	//! 3. Vérifier si slug existe
-	exists, err := uc.schoolRepo.ExistsBySlug(slug)
+	exists, err := uc.schoolRepo.ExistsBySlug(ctx, slug)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check school slug: %w", err)
 	}
@@ -79,7 +81,7 @@ func (uc *schoolUseCase) CreateSchool(input CreateSchoolInput) (*CreateSchoolOut
 	}
 
 	//! 4. Vérifier si email existe
-	exists, err = uc.userRepo.ExistsByEmail(input.AdminEmail)
+	exists, err = uc.userRepo.ExistsByEmail(ctx, input.AdminEmail)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check email: %w", err)
 	}
@@ -89,7 +91,6 @@ func (uc *schoolUseCase) CreateSchool(input CreateSchoolInput) (*CreateSchoolOut
 
 	//! 5. Séparer prénom et nom
 	firstName, lastName := utils.SplitFullName(input.AdminName)
-	fmt.Println("SCHOOL_NAME: ", input)
 	//! 6. Créer entités domain (avec validation métier)
 	school, err := domain.NewSchool(
 		input.SchoolName,
@@ -114,33 +115,29 @@ func (uc *schoolUseCase) CreateSchool(input CreateSchoolInput) (*CreateSchoolOut
 		return nil, err
 	}
 
-	//! 7. Transaction database
-	tx, err := uc.db.Begin()
-	if err != nil {
-		return nil, fmt.Errorf("failed to begin transaction: %w", err)
-	}
-	defer tx.Rollback()
-
-	//! 8. Créer école
-	if err := uc.schoolRepo.Create(school); err != nil {
-		return nil, fmt.Errorf("failed to create school: %w", err)
-	}
-
-	//! 9. Créer admin
-	admin.SchoolID = school.ID
-	if err := uc.userRepo.Create(admin); err != nil {
-		return nil, fmt.Errorf("failed to create admin: %w", err)
-	}
-
-	//! 10. Mettre à jour école avec admin_user_id
-	school.SetAdmin(admin.ID)
-	if err := uc.schoolRepo.Update(school); err != nil {
-		return nil, fmt.Errorf("failed to update school: %w", err)
-	}
-
+	//! 7. Transaction database (les repositories utilisent la tx du context)
+	err = db.RunInTx(ctx, uc.db, func(ctx context.Context) error {
+		//! 8. Créer école
+		if err := uc.schoolRepo.Create(ctx, school); err != nil {
+			return fmt.Errorf("failed to create school: %w", err)
+		}
+
+		//! 9. Créer admin
+		admin.SchoolID = school.ID
+		if err := uc.userRepo.Create(ctx, admin); err != nil {
+			return fmt.Errorf("failed to create admin: %w", err)
+		}
+
+		//! 10. Mettre à jour école avec admin_user_id
+		school.SetAdmin(admin.ID)
+		if err := uc.schoolRepo.Update(ctx, school); err != nil {
+			return fmt.Errorf("failed to update school: %w", err)
+		}
+		return nil
+	})
 	//! 11. Commit transaction
-	if err := tx.Commit(); err != nil {
-		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	if err != nil {
+		return nil, err
 	}
 
 	//! 12. Générer JWT token
@@ -158,8 +155,8 @@ func (uc *schoolUseCase) CreateSchool(input CreateSchoolInput) (*CreateSchoolOut
 }
 
 // ! GetAllSchool retourne toute les écoles
-func (uc *schoolUseCase) GetAllSchool() ([]*dto.SchoolDTO, error) {
-	schools, err := uc.schoolRepo.GetAll()
+func (uc *schoolUseCase) GetAllSchool(ctx context.Context) ([]*dto.SchoolDTO, error) {
+	schools, err := uc.schoolRepo.GetAll(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("[ERROR_GETTING_ALL_SCHOOL]: %w", err)
 	}
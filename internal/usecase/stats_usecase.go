@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"educnet/internal/cache"
+	"educnet/internal/domain"
+	"educnet/internal/handler/dto"
+	"educnet/internal/repository"
+	"time"
+)
+
+// ! statsCacheTTL durée de vie des statistiques en cache (par école et par période)
+const statsCacheTTL = 5 * time.Minute
+
+type StatsUseCase interface {
+	GetStats(ctx context.Context, adminUserID int, from, to time.Time, granularity string) (*dto.StatsResponse, error)
+}
+
+type statsCacheKey struct {
+	schoolID int
+	period   domain.StatsPeriod
+}
+
+type statsUseCase struct {
+	userRepo  repository.UserRepository
+	statsRepo repository.StatsRepository
+	cache     *cache.TTL[statsCacheKey, *dto.StatsResponse]
+}
+
+func NewStatsUseCase(userRepo repository.UserRepository, statsRepo repository.StatsRepository) StatsUseCase {
+	return &statsUseCase{
+		userRepo:  userRepo,
+		statsRepo: statsRepo,
+		cache:     cache.NewTTL[statsCacheKey, *dto.StatsResponse](statsCacheTTL),
+	}
+}
+
+func (uc *statsUseCase) GetStats(ctx context.Context, adminUserID int, from, to time.Time, granularity string) (*dto.StatsResponse, error) {
+	//! 1. Verify admin
+	admin, err := uc.userRepo.FindByID(ctx, adminUserID)
+	if err != nil {
+		return nil, err
+	}
+	if !admin.IsAdmin() {
+		return nil, domain.ErrForbidden
+	}
+
+	//! 2. Validate period
+	period, err := domain.NewStatsPeriod(from, to, granularity, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	//! 3. Cache par école + période
+	key := statsCacheKey{schoolID: admin.SchoolID, period: period}
+	if stats, ok := uc.cache.Get(key); ok {
+		return stats, nil
+	}
+
+	//! 4. Aggregate in SQL
+	stats := &dto.StatsResponse{Period: period, GeneratedAt: time.Now()}
+
+	users, err := uc.statsRepo.CountUsers(ctx, admin.SchoolID)
+	if err != nil {
+		return nil, err
+	}
+	stats.Users = *users
+
+	if stats.Registrations, err = uc.statsRepo.Registrations(ctx, admin.SchoolID, period); err != nil {
+		return nil, err
+	}
+
+	latency, err := uc.statsRepo.ApprovalLatency(ctx, admin.SchoolID, period)
+	if err != nil {
+		return nil, err
+	}
+	stats.ApprovalLatency = *latency
+
+	if stats.ClassFill, err = uc.statsRepo.ClassFillRates(ctx, admin.SchoolID); err != nil {
+		return nil, err
+	}
+	if stats.TeachersPerSubject, err = uc.statsRepo.TeachersPerSubject(ctx, admin.SchoolID); err != nil {
+		return nil, err
+	}
+	if stats.ChatActivity, err = uc.statsRepo.ChatActivity(ctx, admin.SchoolID, period); err != nil {
+		return nil, err
+	}
+
+	uc.cache.Set(key, stats)
+	return stats, nil
+}
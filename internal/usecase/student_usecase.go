@@ -1,7 +1,9 @@
 package usecase
 
 import (
+	"context"
 	"database/sql"
+	"educnet/internal/db"
 	"educnet/internal/domain"
 	"educnet/internal/handler/dto"
 	"educnet/internal/repository"
@@ -10,17 +12,18 @@ import (
 )
 
 type StudentUseCase interface {
-	RegisterStudent(req *dto.StudentRegistrationRequest) (*dto.StudentRegistrationResponse, error)
+	RegisterStudent(ctx context.Context, req *dto.StudentRegistrationRequest) (*dto.StudentRegistrationResponse, error)
 
-	GetStudentClasses(studentID int) ([]dto.ClassResponse, error)
+	GetStudentClasses(ctx context.Context, studentID int) ([]dto.ClassResponse, error)
 }
 
 type studentUseCase struct {
-	db               *sql.DB
-	userRepo         repository.UserRepository
-	schoolRepo       repository.SchoolRepository
-	classRepo        repository.ClassRepository
-	studentClassRepo repository.StudentClassRepository
+	db                *sql.DB
+	userRepo          repository.UserRepository
+	schoolRepo        repository.SchoolRepository
+	classRepo         repository.ClassRepository
+	studentClassRepo  repository.StudentClassRepository
+	studentNumberRepo repository.StudentNumberRepository
 }
 
 func NewStudentUseCase(
@@ -29,28 +32,33 @@ func NewStudentUseCase(
 	schoolRepo repository.SchoolRepository,
 	classRepo repository.ClassRepository,
 	studentClassRepo repository.StudentClassRepository,
+	studentNumberRepo repository.StudentNumberRepository,
 ) StudentUseCase {
 	return &studentUseCase{
-		db:               db,
-		userRepo:         userRepo,
-		schoolRepo:       schoolRepo,
-		classRepo:        classRepo,
-		studentClassRepo: studentClassRepo,
+		db:                db,
+		userRepo:          userRepo,
+		schoolRepo:        schoolRepo,
+		classRepo:         classRepo,
+		studentClassRepo:  studentClassRepo,
+		studentNumberRepo: studentNumberRepo,
 	}
 }
 
-func (uc *studentUseCase) RegisterStudent(req *dto.StudentRegistrationRequest) (*dto.StudentRegistrationResponse, error) {
+func (uc *studentUseCase) RegisterStudent(ctx context.Context, req *dto.StudentRegistrationRequest) (*dto.StudentRegistrationResponse, error) {
 	//! 1. Validate school exists
-	school, err := uc.schoolRepo.FindBySlug(req.SchoolSlug)
+	school, err := uc.schoolRepo.FindBySlug(ctx, req.SchoolSlug)
 	if errors.Is(err, domain.ErrSchoolNotFound) {
 		return nil, domain.ErrNotFound
 	}
 	if err != nil {
 		return nil, domain.ErrInternal
 	}
+	if !school.AllowsSelfRegistration() {
+		return nil, domain.ErrSelfRegistrationClosed
+	}
 
 	//! 2. Check if email already exists
-	exists, err := uc.userRepo.ExistsByEmail(req.Email)
+	exists, err := uc.userRepo.ExistsByEmail(ctx, req.Email)
 	if err != nil {
 		return nil, domain.ErrInternal
 	}
@@ -59,7 +67,7 @@ func (uc *studentUseCase) RegisterStudent(req *dto.StudentRegistrationRequest) (
 	}
 
 	//! 3. Validate class exists and belongs to school
-	class, err := uc.classRepo.FindByID(req.ClassID)
+	class, err := uc.classRepo.FindByID(ctx, req.ClassID)
 	if errors.Is(err, domain.ErrClassNotFound) {
 		return nil, domain.ErrNotFound
 	}
@@ -70,50 +78,64 @@ func (uc *studentUseCase) RegisterStudent(req *dto.StudentRegistrationRequest) (
 		return nil, domain.ErrForbidden
 	}
 
-	//! 4. Start transaction
-	tx, err := uc.db.Begin()
-	if err != nil {
-		return nil, domain.ErrInternal
-	}
-	defer tx.Rollback()
-
-	//! 5. Create student user (status = PENDING)
+	//! 4. Create student user (status selon le mode d'inscription de l'école)
 	user, err := domain.NewUser(
 		school.ID, req.Email, req.Password, req.FirstName, req.LastName, req.Phone, domain.RoleStudent,
 	)
 	if err != nil {
 		return nil, err
 	}
-	user.Status = domain.UserStatusPending
-
-	if err := uc.userRepo.Create(user); err != nil {
-		return nil, fmt.Errorf("failed to create student: %w", err)
-	}
-
-	//! 6. Enroll in class
-	if err := uc.studentClassRepo.Create(user.ID, class.ID); err != nil {
-		return nil, fmt.Errorf("failed to enroll student: %w", err)
+	user.Status = school.SelfRegistrationStatus()
+
+	//! 5. Transaction : user + enrollment (les repositories utilisent la tx du context)
+	waitlisted := false
+	err = db.RunInTx(ctx, uc.db, func(ctx context.Context) error {
+		if err := uc.userRepo.Create(ctx, user); err != nil {
+			return fmt.Errorf("failed to create student: %w", err)
+		}
+
+		//! 6. Enroll in class (compte en attente : place vérifiée à l'approbation)
+		if !user.IsApproved() {
+			if err := uc.studentClassRepo.Create(ctx, user.ID, class.ID); err != nil {
+				return fmt.Errorf("failed to enroll student: %w", err)
+			}
+			return nil
+		}
+		var err error
+		if waitlisted, err = uc.studentClassRepo.EnrollOrWaitlist(ctx, user.ID, class.ID); err != nil {
+			return fmt.Errorf("failed to enroll student: %w", err)
+		}
+
+		//! 7. Inscription ouverte : compte déjà approuvé, matricule attribué tout de suite
+		if _, err := assignStudentNumber(ctx, uc.studentNumberRepo, uc.studentClassRepo, user); err != nil {
+			return fmt.Errorf("failed to assign student number: %w", err)
+		}
+		return nil
+	})
+	//! 8. Commit transaction
+	if err != nil {
+		return nil, err
 	}
 
-	//! 7. Commit transaction
-	if err := tx.Commit(); err != nil {
-		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	//! 9. Return response
+	message := registrationMessage("Student", user)
+	if waitlisted {
+		message = "Student registration successful. The class is full: you are on its waitlist."
 	}
-
-	//! 8. Return response
 	return &dto.StudentRegistrationResponse{
-		UserID:    user.ID,
-		Email:     user.Email,
-		FullName:  user.GetFullName(),
-		SchoolID:  school.ID,
-		Status:    user.Status,
-		ClassName: class.Name,
-		Message:   "Student registration successful. Pending admin approval.",
+		UserID:     user.ID,
+		Email:      user.Email,
+		FullName:   user.GetFullName(),
+		SchoolID:   school.ID,
+		Status:     user.Status,
+		ClassName:  class.Name,
+		Waitlisted: waitlisted,
+		Message:    message,
 	}, nil
 }
 
-func (uc *studentUseCase) GetStudentClasses(studentID int) ([]dto.ClassResponse, error) {
-	user, err := uc.userRepo.FindByID(studentID)
+func (uc *studentUseCase) GetStudentClasses(ctx context.Context, studentID int) ([]dto.ClassResponse, error) {
+	user, err := uc.userRepo.FindByID(ctx, studentID)
 	if err != nil {
 		return nil, domain.ErrUserNotFound
 	}
@@ -121,7 +143,7 @@ func (uc *studentUseCase) GetStudentClasses(studentID int) ([]dto.ClassResponse,
 		return nil, domain.ErrForbidden
 	}
 
-	classes, err := uc.studentClassRepo.FindByStudent(studentID)
+	classes, err := uc.studentClassRepo.FindByStudent(ctx, studentID)
 	if err != nil {
 		return nil, domain.ErrInternal
 	}
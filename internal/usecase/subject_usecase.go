@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"educnet/internal/handler/dto"
 	"educnet/internal/repository"
 	"fmt"
@@ -8,7 +9,7 @@ import (
 
 // ! SubjectUseCase interface
 type SubjectUseCase interface {
-	GetAllBySchoolID(schoolID int) ([]*dto.SubjectInfo, error)
+	GetAllBySchoolID(ctx context.Context, schoolID int) ([]*dto.SubjectInfo, error)
 }
 
 // ! SubjectUseCase implémentation
@@ -22,8 +23,8 @@ func NewSubjectUsecase(subjectRepo repository.SubjectRepository) SubjectUseCase
 	}
 }
 
-func (uc *subjectUseCase) GetAllBySchoolID(schoolID int) ([]*dto.SubjectInfo, error) {
-	subjects, err := uc.subjectRepo.FindBySchoolID(schoolID)
+func (uc *subjectUseCase) GetAllBySchoolID(ctx context.Context, schoolID int) ([]*dto.SubjectInfo, error) {
+	subjects, err := uc.subjectRepo.FindBySchoolID(ctx, schoolID)
 	if err != nil {
 		return nil, fmt.Errorf("[ERROR_GET_Subjects_BY_SCHOOL_ID]: %w", err)
 	}
@@ -0,0 +1,350 @@
+package usecase
+
+import (
+	"context"
+	"educnet/internal/auth"
+	"educnet/internal/domain"
+	"educnet/internal/handler/dto"
+	"educnet/internal/repository"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ! impersonationTTL durée de vie d'un token d'impersonation
+const impersonationTTL = time.Hour
+
+// ! auditLogLimit nombre d'entrées d'audit retournées par défaut
+const auditLogLimit = 100
+
+// ! SuperAdminUseCase console plateforme (toutes les écoles)
+type SuperAdminUseCase interface {
+	EnsureSuperAdmin(ctx context.Context, email, password, firstName, lastName string) error
+
+	ListSchools(ctx context.Context, superAdminID int) ([]dto.SchoolStatsResponse, error)
+	GetSchool(ctx context.Context, superAdminID, schoolID int) (*dto.SchoolDetailResponse, error)
+	SuspendSchool(ctx context.Context, superAdminID, schoolID int, req *dto.SuspendSchoolRequest, ipAddress string) (*dto.SchoolStatsResponse, error)
+	ReactivateSchool(ctx context.Context, superAdminID, schoolID int, ipAddress string) (*dto.SchoolStatsResponse, error)
+	ImpersonateSchoolAdmin(ctx context.Context, superAdminID, schoolID int, req *dto.ImpersonateRequest, ipAddress string) (*dto.ImpersonationResponse, error)
+	TransferOwnership(ctx context.Context, superAdminID, schoolID int, req *dto.TransferOwnershipRequest, ipAddress string) (*dto.SchoolStatsResponse, error)
+
+	GetAuditLogs(ctx context.Context, superAdminID int) ([]*domain.AuditLog, error)
+}
+
+type superAdminUseCase struct {
+	userRepo     repository.UserRepository
+	schoolRepo   repository.SchoolRepository
+	auditLogRepo repository.AuditLogRepository
+	jwtService   *auth.JWTService
+}
+
+func NewSuperAdminUseCase(
+	userRepo repository.UserRepository,
+	schoolRepo repository.SchoolRepository,
+	auditLogRepo repository.AuditLogRepository,
+	jwtService *auth.JWTService,
+) SuperAdminUseCase {
+	return &superAdminUseCase{
+		userRepo:     userRepo,
+		schoolRepo:   schoolRepo,
+		auditLogRepo: auditLogRepo,
+		jwtService:   jwtService,
+	}
+}
+
+// ! EnsureSuperAdmin crée le compte super-admin configuré s'il n'existe pas encore
+func (uc *superAdminUseCase) EnsureSuperAdmin(ctx context.Context, email, password, firstName, lastName string) error {
+	existing, err := uc.userRepo.FindByEmail(ctx, email)
+	if err == nil {
+		if !existing.IsSuperAdmin() {
+			return fmt.Errorf("email %s already belongs to a school user", email)
+		}
+		return nil
+	}
+	if !errors.Is(err, domain.ErrUserNotFound) {
+		return err
+	}
+
+	superAdmin, err := domain.NewSuperAdminUser(email, password, firstName, lastName)
+	if err != nil {
+		return err
+	}
+	if err := uc.userRepo.Create(ctx, superAdmin); err != nil {
+		return err
+	}
+
+	return uc.audit(ctx, superAdmin.ID, domain.AuditActionSuperAdminBootstrap, 0, superAdmin.ID, "created from configuration", "")
+}
+
+func (uc *superAdminUseCase) ListSchools(ctx context.Context, superAdminID int) ([]dto.SchoolStatsResponse, error) {
+	if _, err := uc.verifySuperAdmin(ctx, superAdminID); err != nil {
+		return nil, err
+	}
+
+	all, err := uc.schoolRepo.GetAllWithStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]dto.SchoolStatsResponse, len(all))
+	for i, stats := range all {
+		resp[i] = dto.SchoolStatsResponseFromDomain(stats)
+	}
+	return resp, nil
+}
+
+func (uc *superAdminUseCase) GetSchool(ctx context.Context, superAdminID, schoolID int) (*dto.SchoolDetailResponse, error) {
+	if _, err := uc.verifySuperAdmin(ctx, superAdminID); err != nil {
+		return nil, err
+	}
+
+	stats, err := uc.findSchoolStats(ctx, schoolID)
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := uc.auditLogRepo.FindBySchool(ctx, schoolID, auditLogLimit)
+	if err != nil {
+		return nil, err
+	}
+	if logs == nil {
+		logs = []*domain.AuditLog{}
+	}
+
+	detail := &dto.SchoolDetailResponse{
+		SchoolStatsResponse: dto.SchoolStatsResponseFromDomain(stats),
+		AuditLogs:           logs,
+	}
+	if stats.School.AdminUserID != nil {
+		if admin, err := uc.userRepo.FindByID(ctx, *stats.School.AdminUserID); err == nil {
+			detail.Admin = dto.UserDTOFromDomain(admin)
+		}
+	}
+	return detail, nil
+}
+
+func (uc *superAdminUseCase) SuspendSchool(ctx context.Context, superAdminID, schoolID int, req *dto.SuspendSchoolRequest, ipAddress string) (*dto.SchoolStatsResponse, error) {
+	//! 1. Verify super-admin
+	if _, err := uc.verifySuperAdmin(ctx, superAdminID); err != nil {
+		return nil, err
+	}
+
+	//! 2. Get school
+	school, err := uc.findSchool(ctx, schoolID)
+	if err != nil {
+		return nil, err
+	}
+
+	//! 3. Suspend (domain rule: not already suspended)
+	if err := school.Suspend(); err != nil {
+		return nil, err
+	}
+	if err := uc.schoolRepo.UpdateStatus(ctx, school.ID, school.Status); err != nil {
+		return nil, err
+	}
+
+	//! 4. Audit
+	if err := uc.audit(ctx, superAdminID, domain.AuditActionSchoolSuspended, school.ID, 0, req.Reason, ipAddress); err != nil {
+		return nil, err
+	}
+
+	return uc.statsResponse(ctx, school.ID)
+}
+
+func (uc *superAdminUseCase) ReactivateSchool(ctx context.Context, superAdminID, schoolID int, ipAddress string) (*dto.SchoolStatsResponse, error) {
+	if _, err := uc.verifySuperAdmin(ctx, superAdminID); err != nil {
+		return nil, err
+	}
+
+	school, err := uc.findSchool(ctx, schoolID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := school.Reactivate(); err != nil {
+		return nil, err
+	}
+	if err := uc.schoolRepo.UpdateStatus(ctx, school.ID, school.Status); err != nil {
+		return nil, err
+	}
+
+	if err := uc.audit(ctx, superAdminID, domain.AuditActionSchoolReactivated, school.ID, 0, "", ipAddress); err != nil {
+		return nil, err
+	}
+
+	return uc.statsResponse(ctx, school.ID)
+}
+
+func (uc *superAdminUseCase) ImpersonateSchoolAdmin(ctx context.Context, superAdminID, schoolID int, req *dto.ImpersonateRequest, ipAddress string) (*dto.ImpersonationResponse, error) {
+	//! 1. Verify super-admin
+	if _, err := uc.verifySuperAdmin(ctx, superAdminID); err != nil {
+		return nil, err
+	}
+
+	//! 2. A reason is mandatory for the audit trail
+	if req.Reason == "" {
+		return nil, domain.ErrValidation
+	}
+
+	//! 3. Get school admin
+	school, err := uc.findSchool(ctx, schoolID)
+	if err != nil {
+		return nil, err
+	}
+	if school.AdminUserID == nil {
+		return nil, domain.ErrSchoolHasNoAdmin
+	}
+	admin, err := uc.userRepo.FindByID(ctx, *school.AdminUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	//! 4. Audit BEFORE issuing the token
+	if err := uc.audit(ctx, superAdminID, domain.AuditActionImpersonation, school.ID, admin.ID, req.Reason, ipAddress); err != nil {
+		return nil, err
+	}
+
+	//! 5. Generate short-lived token carrying impersonator_id
+	token, err := uc.jwtService.GenerateImpersonationToken(
+		admin.ID, admin.Email, admin.Role, admin.SchoolID, superAdminID, impersonationTTL,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.ImpersonationResponse{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresIn:   int(impersonationTTL.Seconds()),
+		User: dto.UserInfo{
+			ID:        admin.ID,
+			Email:     admin.Email,
+			FullName:  admin.GetFullName(),
+			Role:      admin.Role,
+			Status:    admin.Status,
+			SchoolID:  admin.SchoolID,
+			AvatarURL: admin.AvatarURL,
+		},
+		ImpersonatorID: superAdminID,
+	}, nil
+}
+
+func (uc *superAdminUseCase) TransferOwnership(ctx context.Context, superAdminID, schoolID int, req *dto.TransferOwnershipRequest, ipAddress string) (*dto.SchoolStatsResponse, error) {
+	//! 1. Verify super-admin
+	if _, err := uc.verifySuperAdmin(ctx, superAdminID); err != nil {
+		return nil, err
+	}
+
+	//! 2. Get school and new owner
+	school, err := uc.findSchool(ctx, schoolID)
+	if err != nil {
+		return nil, err
+	}
+	newOwner, err := uc.userRepo.FindByID(ctx, req.NewAdminUserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	//! 3. New owner must be an approved member of the school
+	if newOwner.SchoolID != school.ID {
+		return nil, domain.ErrForbidden
+	}
+	if !newOwner.IsApproved() {
+		return nil, domain.ErrUserNotApproved
+	}
+
+	//! 4. Promote if needed
+	if !newOwner.IsAdmin() {
+		newOwner.PromoteToAdmin()
+		if err := uc.userRepo.UpdateRole(ctx, newOwner.ID, newOwner.Role); err != nil {
+			return nil, err
+		}
+	}
+
+	//! 5. Set school owner
+	previous := 0
+	if school.AdminUserID != nil {
+		previous = *school.AdminUserID
+	}
+	school.SetAdmin(newOwner.ID)
+	if err := uc.schoolRepo.Update(ctx, school); err != nil {
+		return nil, err
+	}
+
+	//! 6. Audit
+	details := fmt.Sprintf("previous_admin_user_id=%d", previous)
+	if req.Reason != "" {
+		details += "; reason=" + req.Reason
+	}
+	if err := uc.audit(ctx, superAdminID, domain.AuditActionOwnershipTransfer, school.ID, newOwner.ID, details, ipAddress); err != nil {
+		return nil, err
+	}
+
+	return uc.statsResponse(ctx, school.ID)
+}
+
+func (uc *superAdminUseCase) GetAuditLogs(ctx context.Context, superAdminID int) ([]*domain.AuditLog, error) {
+	if _, err := uc.verifySuperAdmin(ctx, superAdminID); err != nil {
+		return nil, err
+	}
+
+	logs, err := uc.auditLogRepo.FindRecent(ctx, auditLogLimit)
+	if err != nil {
+		return nil, err
+	}
+	if logs == nil {
+		logs = []*domain.AuditLog{}
+	}
+	return logs, nil
+}
+
+// ! ==================== HELPERS ====================
+func (uc *superAdminUseCase) verifySuperAdmin(ctx context.Context, userID int) (*domain.User, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsSuperAdmin() {
+		return nil, domain.ErrForbidden
+	}
+	return user, nil
+}
+
+func (uc *superAdminUseCase) findSchool(ctx context.Context, schoolID int) (*domain.School, error) {
+	school, err := uc.schoolRepo.FindByID(ctx, schoolID)
+	if errors.Is(err, domain.ErrSchoolNotFound) {
+		return nil, domain.ErrNotFound
+	}
+	return school, err
+}
+
+func (uc *superAdminUseCase) findSchoolStats(ctx context.Context, schoolID int) (*domain.SchoolStats, error) {
+	stats, err := uc.schoolRepo.FindStatsByID(ctx, schoolID)
+	if errors.Is(err, domain.ErrSchoolNotFound) {
+		return nil, domain.ErrNotFound
+	}
+	return stats, err
+}
+
+func (uc *superAdminUseCase) statsResponse(ctx context.Context, schoolID int) (*dto.SchoolStatsResponse, error) {
+	stats, err := uc.findSchoolStats(ctx, schoolID)
+	if err != nil {
+		return nil, err
+	}
+	resp := dto.SchoolStatsResponseFromDomain(stats)
+	return &resp, nil
+}
+
+func (uc *superAdminUseCase) audit(ctx context.Context, actorID int, action string, schoolID, targetUserID int, details, ipAddress string) error {
+	log, err := domain.NewAuditLog(actorID, action, schoolID, targetUserID, details, ipAddress)
+	if err != nil {
+		return err
+	}
+	if impersonatorID := auth.ImpersonatorFromContext(ctx); impersonatorID > 0 {
+		log.ImpersonatorID = &impersonatorID
+	}
+	return uc.auditLogRepo.Create(ctx, log)
+}
@@ -1,7 +1,9 @@
 package usecase
 
 import (
+	"context"
 	"database/sql"
+	"educnet/internal/db"
 	"educnet/internal/domain"
 	"educnet/internal/handler/dto"
 	"educnet/internal/repository"
@@ -10,9 +12,9 @@ import (
 )
 
 type TeacherUseCase interface {
-	RegisterTeacher(req *dto.TeacherRegistrationRequest) (*dto.TeacherRegistrationResponse, error)
+	RegisterTeacher(ctx context.Context, req *dto.TeacherRegistrationRequest) (*dto.TeacherRegistrationResponse, error)
 
-	GetTeacherSubjects(teacherID int) ([]dto.SubjectResponse, error)
+	GetTeacherSubjects(ctx context.Context, teacherID int) ([]dto.SubjectResponse, error)
 }
 
 type teacherUseCase struct {
@@ -39,18 +41,21 @@ func NewTeacherUseCase(
 	}
 }
 
-func (uc *teacherUseCase) RegisterTeacher(req *dto.TeacherRegistrationRequest) (*dto.TeacherRegistrationResponse, error) {
+func (uc *teacherUseCase) RegisterTeacher(ctx context.Context, req *dto.TeacherRegistrationRequest) (*dto.TeacherRegistrationResponse, error) {
 	//! 1. Validate school exists
-	school, err := uc.schoolRepo.FindBySlug(req.SchoolSlug)
+	school, err := uc.schoolRepo.FindBySlug(ctx, req.SchoolSlug)
 	if errors.Is(err, domain.ErrSchoolNotFound) {
 		return nil, domain.ErrNotFound
 	}
 	if err != nil {
 		return nil, domain.ErrInternal
 	}
+	if !school.AllowsSelfRegistration() {
+		return nil, domain.ErrSelfRegistrationClosed
+	}
 
 	//! 2. Check if email already exists
-	exists, err := uc.userRepo.ExistsByEmail(req.Email)
+	exists, err := uc.userRepo.ExistsByEmail(ctx, req.Email)
 	if err != nil {
 		return nil, domain.ErrInternal
 	}
@@ -65,7 +70,7 @@ func (uc *teacherUseCase) RegisterTeacher(req *dto.TeacherRegistrationRequest) (
 
 	subjectNames := []string{}
 	for _, subjectID := range req.SubjectIDs {
-		subject, err := uc.subjectRepo.FindByID(subjectID)
+		subject, err := uc.subjectRepo.FindByID(ctx, subjectID)
 		if errors.Is(err, domain.ErrSubjectNotFound) {
 			return nil, domain.ErrNotFound
 		}
@@ -78,36 +83,32 @@ func (uc *teacherUseCase) RegisterTeacher(req *dto.TeacherRegistrationRequest) (
 		subjectNames = append(subjectNames, subject.Name)
 	}
 
-	//! 4. Start transaction
-	tx, err := uc.db.Begin()
-	if err != nil {
-		return nil, domain.ErrInternal
-	}
-	defer tx.Rollback()
-
-	//! 5. Create teacher user (status = PENDING)
+	//! 4. Create teacher user (status selon le mode d'inscription de l'école)
 	user, err := domain.NewUser(
 		school.ID, req.Email, req.Password, req.FirstName, req.LastName, req.Phone, domain.RoleTeacher,
 	)
 	if err != nil {
 		return nil, err
 	}
-	user.Status = domain.UserStatusPending
-
-	if err := uc.userRepo.Create(user); err != nil {
-		return nil, fmt.Errorf("failed to create teacher: %w", err)
-	}
+	user.Status = school.SelfRegistrationStatus()
 
-	//! 6. Assign subjects (utilise repo injecté)
-	for _, subjectID := range req.SubjectIDs {
-		if err := uc.teacherSubjectRepo.Create(user.ID, subjectID); err != nil {
-			return nil, fmt.Errorf("failed to assign subject %d: %w", subjectID, err)
+	//! 5. Transaction : user + subjects (les repositories utilisent la tx du context)
+	err = db.RunInTx(ctx, uc.db, func(ctx context.Context) error {
+		if err := uc.userRepo.Create(ctx, user); err != nil {
+			return fmt.Errorf("failed to create teacher: %w", err)
 		}
-	}
 
+		//! 6. Assign subjects (utilise repo injecté)
+		for _, subjectID := range req.SubjectIDs {
+			if err := uc.teacherSubjectRepo.Create(ctx, user.ID, subjectID); err != nil {
+				return fmt.Errorf("failed to assign subject %d: %w", subjectID, err)
+			}
+		}
+		return nil
+	})
 	//! 7. Commit transaction
-	if err := tx.Commit(); err != nil {
-		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	if err != nil {
+		return nil, err
 	}
 
 	//! 8. Return response
@@ -118,12 +119,12 @@ func (uc *teacherUseCase) RegisterTeacher(req *dto.TeacherRegistrationRequest) (
 		SchoolID: school.ID,
 		Status:   user.Status,
 		Subjects: subjectNames,
-		Message:  "Teacher registration successful. Pending admin approval.",
+		Message:  registrationMessage("Teacher", user),
 	}, nil
 }
 
-func (uc *teacherUseCase) GetTeacherSubjects(teacherID int) ([]dto.SubjectResponse, error) {
-	user, err := uc.userRepo.FindByID(teacherID)
+func (uc *teacherUseCase) GetTeacherSubjects(ctx context.Context, teacherID int) ([]dto.SubjectResponse, error) {
+	user, err := uc.userRepo.FindByID(ctx, teacherID)
 	if err != nil {
 		return nil, domain.ErrUserNotFound
 	}
@@ -131,10 +132,18 @@ func (uc *teacherUseCase) GetTeacherSubjects(teacherID int) ([]dto.SubjectRespon
 		return nil, domain.ErrForbidden
 	}
 
-	subjects, err := uc.teacherSubjectRepo.FindByTeacher(teacherID)
+	subjects, err := uc.teacherSubjectRepo.FindByTeacher(ctx, teacherID)
 	if err != nil {
 		return nil, domain.ErrInternal
 	}
 
 	return dto.SubjectResponsesFromDomain(subjects), nil
 }
+
+// ! registrationMessage message de confirmation selon le statut initial du compte
+func registrationMessage(who string, user *domain.User) string {
+	if user.IsApproved() {
+		return who + " registration successful. You can now log in."
+	}
+	return who + " registration successful. Pending admin approval."
+}
@@ -0,0 +1,399 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"educnet/internal/db"
+	"educnet/internal/domain"
+	"educnet/internal/handler/dto"
+	"educnet/internal/importer"
+	"educnet/internal/mailer"
+	"educnet/internal/repository"
+	"educnet/internal/utils"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// ! importPasswordLength longueur des mots de passe initiaux générés
+const importPasswordLength = 10
+
+// ! Champs reconnus dans un fichier d'import (mapping champ → colonne)
+var (
+	importFields         = []string{"email", "first_name", "last_name", "phone", "role", "class", "subjects", "password"}
+	importRequiredFields = []string{"email", "first_name"}
+)
+
+// ! UserImportUseCase onboarding d'une école : import en masse enseignants/élèves
+type UserImportUseCase interface {
+	ImportUsers(ctx context.Context, adminUserID int, rows [][]string, req *dto.ImportUsersRequest) (*dto.ImportReport, error)
+}
+
+type userImportUseCase struct {
+	db                 *sql.DB
+	userRepo           repository.UserRepository
+	schoolRepo         repository.SchoolRepository
+	classRepo          repository.ClassRepository
+	subjectRepo        repository.SubjectRepository
+	studentClassRepo   repository.StudentClassRepository
+	teacherSubjectRepo repository.TeacherSubjectRepository
+	studentNumberRepo  repository.StudentNumberRepository
+	mailer             mailer.Mailer
+}
+
+func NewUserImportUseCase(
+	db *sql.DB,
+	userRepo repository.UserRepository,
+	schoolRepo repository.SchoolRepository,
+	classRepo repository.ClassRepository,
+	subjectRepo repository.SubjectRepository,
+	studentClassRepo repository.StudentClassRepository,
+	teacherSubjectRepo repository.TeacherSubjectRepository,
+	studentNumberRepo repository.StudentNumberRepository,
+	mailer mailer.Mailer,
+) UserImportUseCase {
+	return &userImportUseCase{
+		db:                 db,
+		userRepo:           userRepo,
+		schoolRepo:         schoolRepo,
+		classRepo:          classRepo,
+		subjectRepo:        subjectRepo,
+		studentClassRepo:   studentClassRepo,
+		teacherSubjectRepo: teacherSubjectRepo,
+		studentNumberRepo:  studentNumberRepo,
+		mailer:             mailer,
+	}
+}
+
+// ! importPlan ligne validée, prête à être créée
+type importPlan struct {
+	result   *dto.ImportRowResult
+	record   importer.Record
+	password string
+	class    *domain.Class
+	subjects []*domain.Subject
+}
+
+func (uc *userImportUseCase) ImportUsers(ctx context.Context, adminUserID int, rows [][]string, req *dto.ImportUsersRequest) (*dto.ImportReport, error) {
+	//! 1. Verify admin
+	admin, err := uc.userRepo.FindByID(ctx, adminUserID)
+	if err != nil {
+		return nil, err
+	}
+	if !admin.IsAdmin() {
+		return nil, domain.ErrForbidden
+	}
+
+	//! 2. Options
+	if req.Role == "" {
+		req.Role = domain.RoleStudent
+	}
+	if req.Role != domain.RoleStudent && req.Role != domain.RoleTeacher {
+		return nil, domain.ErrImportInvalidRole
+	}
+	if req.PasswordDelivery == "" {
+		req.PasswordDelivery = dto.PasswordDeliverySheet
+	}
+	if req.PasswordDelivery != dto.PasswordDeliverySheet && req.PasswordDelivery != dto.PasswordDeliveryEmail {
+		return nil, domain.ErrImportInvalidDelivery
+	}
+
+	//! 3. Column mapping
+	records, err := importer.MapRecords(rows, req.Mapping, importFields, importRequiredFields)
+	if err != nil {
+		return nil, err
+	}
+
+	//! 4. Validate every row (dry-run report)
+	plans, err := uc.validate(ctx, admin.SchoolID, records, req.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &dto.ImportReport{
+		DryRun:    req.DryRun,
+		TotalRows: len(plans),
+		Rows:      make([]dto.ImportRowResult, len(plans)),
+	}
+	for i, plan := range plans {
+		report.Rows[i] = *plan.result
+		if len(plan.result.Errors) > 0 {
+			report.InvalidRows++
+		} else {
+			report.ValidRows++
+		}
+	}
+	if req.DryRun || report.InvalidRows > 0 {
+		return report, nil
+	}
+
+	//! 5. Build users (bcrypt en parallèle, hors transaction)
+	users, err := uc.buildUsers(admin.SchoolID, plans, req.PreApprove)
+	if err != nil {
+		return nil, err
+	}
+
+	//! 6. Commit atomically
+	conflict := -1
+	err = db.RunInTx(ctx, uc.db, func(ctx context.Context) error {
+		for i, plan := range plans {
+			user := users[i]
+			if err := uc.userRepo.Create(ctx, user); err != nil {
+				if errors.Is(err, domain.ErrEmailAlreadyExists) {
+					conflict = i
+				}
+				return fmt.Errorf("line %d: failed to create user: %w", plan.record.Line, err)
+			}
+			if plan.class != nil {
+				if err := uc.enroll(ctx, user, plan.class.ID); err != nil {
+					return fmt.Errorf("line %d: failed to enroll student: %w", plan.record.Line, err)
+				}
+			}
+			//! Élève pré-approuvé : matricule attribué dans la même transaction
+			if user.IsStudent() && user.IsApproved() {
+				if _, err := assignStudentNumber(ctx, uc.studentNumberRepo, uc.studentClassRepo, user); err != nil {
+					return fmt.Errorf("line %d: failed to assign student number: %w", plan.record.Line, err)
+				}
+			}
+			for _, subject := range plan.subjects {
+				if err := uc.teacherSubjectRepo.Create(ctx, user.ID, subject.ID); err != nil {
+					return fmt.Errorf("line %d: failed to assign subject: %w", plan.record.Line, err)
+				}
+			}
+		}
+		return nil
+	})
+	//! Email pris depuis la validation : rien n'est importé, la ligne est signalée
+	if conflict >= 0 {
+		report.Rows[conflict].Errors = append(report.Rows[conflict].Errors, "email already exists")
+		report.ValidRows--
+		report.InvalidRows++
+		return report, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	report.Committed = true
+	report.Created = len(users)
+
+	//! 7. Deliver initial passwords
+	credentials := make([]dto.ImportCredential, len(plans))
+	for i, plan := range plans {
+		credentials[i] = dto.ImportCredential{
+			Email:    plan.result.Email,
+			FullName: plan.result.FullName,
+			Role:     plan.result.Role,
+			Class:    plan.result.Class,
+			Password: plan.password,
+		}
+	}
+	if req.PasswordDelivery == dto.PasswordDeliverySheet {
+		report.Credentials = credentials
+		return report, nil
+	}
+
+	school, err := uc.schoolRepo.FindByID(ctx, admin.SchoolID)
+	if err != nil {
+		return nil, err
+	}
+	db.AfterCommit(ctx, func() {
+		go uc.sendCredentials(school.Name, credentials)
+	})
+	return report, nil
+}
+
+// ! ==================== HELPERS ====================
+func (uc *userImportUseCase) validate(ctx context.Context, schoolID int, records []importer.Record, defaultRole string) ([]*importPlan, error) {
+	classes, err := uc.classRepo.FindBySchoolID(ctx, schoolID)
+	if err != nil {
+		return nil, err
+	}
+	classByName := map[string]*domain.Class{}
+	for _, class := range classes {
+		classByName[strings.ToLower(class.Name)] = class
+	}
+
+	subjects, err := uc.subjectRepo.FindBySchoolID(ctx, schoolID)
+	if err != nil {
+		return nil, err
+	}
+	subjectByKey := map[string]*domain.Subject{}
+	for _, subject := range subjects {
+		subjectByKey[strings.ToLower(subject.Code)] = subject
+		subjectByKey[strings.ToLower(subject.Name)] = subject
+	}
+
+	seen := map[string]int{}
+	plans := make([]*importPlan, len(records))
+	for i, record := range records {
+		email := strings.ToLower(record.Get("email"))
+		role := strings.ToLower(record.Get("role"))
+		if role == "" {
+			role = defaultRole
+		}
+
+		plan := &importPlan{
+			record:   record,
+			password: record.Get("password"),
+			result: &dto.ImportRowResult{
+				Line:     record.Line,
+				Email:    email,
+				FullName: strings.TrimSpace(record.Get("first_name") + " " + record.Get("last_name")),
+				Role:     role,
+				Class:    record.Get("class"),
+			},
+		}
+		plans[i] = plan
+		addError := func(format string, args ...any) {
+			plan.result.Errors = append(plan.result.Errors, fmt.Sprintf(format, args...))
+		}
+
+		if plan.password == "" {
+			if plan.password, err = utils.GeneratePassword(importPasswordLength); err != nil {
+				return nil, err
+			}
+		}
+
+		//! Domain rules (email, password, name, role)
+		if role != domain.RoleStudent && role != domain.RoleTeacher {
+			addError("role must be teacher or student")
+		} else if err := domain.ValidateUserInput(email, plan.password, record.Get("first_name"), role); err != nil {
+			addError("%s", domainMessage(err))
+		}
+
+		//! Duplicate emails (file + database)
+		if email != "" {
+			if line, ok := seen[email]; ok {
+				addError("duplicate email (line %d)", line)
+			} else {
+				seen[email] = record.Line
+				exists, err := uc.userRepo.ExistsByEmail(ctx, email)
+				if err != nil {
+					return nil, err
+				}
+				if exists {
+					addError("email already exists")
+				}
+			}
+		}
+
+		//! Class (students) / subjects (teachers)
+		switch role {
+		case domain.RoleStudent:
+			if plan.result.Class == "" {
+				addError("class is required for students")
+			} else if class, ok := classByName[strings.ToLower(plan.result.Class)]; ok {
+				plan.class = class
+				plan.result.Class = class.Name
+			} else {
+				addError("unknown class %q", plan.result.Class)
+			}
+		case domain.RoleTeacher:
+			for _, key := range splitList(record.Get("subjects")) {
+				if subject, ok := subjectByKey[strings.ToLower(key)]; ok {
+					plan.subjects = append(plan.subjects, subject)
+					plan.result.Subjects = append(plan.result.Subjects, subject.Name)
+				} else {
+					addError("unknown subject %q", key)
+				}
+			}
+			if record.Get("subjects") == "" {
+				addError("at least one subject is required for teachers")
+			}
+		}
+	}
+	return plans, nil
+}
+
+// ! buildUsers crée les entités (hash bcrypt coûteux → workers parallèles)
+func (uc *userImportUseCase) buildUsers(schoolID int, plans []*importPlan, preApprove bool) ([]*domain.User, error) {
+	users := make([]*domain.User, len(plans))
+	errs := make([]error, len(plans))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < runtime.NumCPU(); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				plan := plans[i]
+				user, err := domain.NewUser(
+					schoolID, plan.result.Email, plan.password,
+					plan.record.Get("first_name"), plan.record.Get("last_name"), plan.record.Get("phone"),
+					plan.result.Role,
+				)
+				if err != nil {
+					errs[i] = fmt.Errorf("line %d: %w", plan.record.Line, err)
+					continue
+				}
+				if preApprove {
+					user.Approve()
+				}
+				//! Mot de passe généré : à changer à la première connexion
+				user.MustChangePassword = plan.record.Get("password") == ""
+				users[i] = user
+			}
+		}()
+	}
+	for i := range plans {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return users, nil
+}
+
+func (uc *userImportUseCase) sendCredentials(schoolName string, credentials []dto.ImportCredential) {
+	for _, cred := range credentials {
+		body := fmt.Sprintf(
+			"Bonjour %s,\n\nVotre compte %s a été créé sur EducNet (%s).\n\nEmail : %s\nMot de passe initial : %s\n\nMerci de le changer dès votre première connexion.\n",
+			cred.FullName, cred.Role, schoolName, cred.Email, cred.Password,
+		)
+		if err := uc.mailer.Send(cred.Email, "Vos identifiants EducNet", body); err != nil {
+			slog.Error("failed to send import credentials", "email", cred.Email, "error", err)
+		}
+	}
+}
+
+// ! splitList "MATH, PHY;SVT" → [MATH PHY SVT]
+func splitList(value string) []string {
+	parts := strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ';' || r == '|'
+	})
+	var out []string
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+// ! domainMessage message lisible d'une erreur métier ; les erreurs techniques
+// ! (SQL, réseau...) restent génériques pour ne rien exposer au client
+func domainMessage(err error) string {
+	var domainErr *domain.DomainError
+	if errors.As(err, &domainErr) {
+		return domainErr.Message
+	}
+	return "internal error"
+}
+
+// ! enroll inscrit l'élève importé : capacité contrôlée tout de suite s'il est
+// ! pré-approuvé (classe complète = import refusé), sinon à l'approbation
+func (uc *userImportUseCase) enroll(ctx context.Context, user *domain.User, classID int) error {
+	if user.IsApproved() {
+		return uc.studentClassRepo.Enroll(ctx, user.ID, classID)
+	}
+	return uc.studentClassRepo.Create(ctx, user.ID, classID)
+}
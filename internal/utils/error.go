@@ -3,13 +3,14 @@ package utils
 import (
 	"educnet/internal/domain"
 	"errors"
-	"log"
+	"log/slog"
 	"net/http"
 )
 
 func HandleUseCaseError(w http.ResponseWriter, err error) {
 	w.Header().Set("Content-Type", "application/json")
 
+	var domainErr *domain.DomainError
 	switch {
 	case errors.Is(err, domain.ErrNotFound):
 		http.Error(w, `{"error":"Resource not found"}`, http.StatusNotFound)
@@ -19,8 +20,103 @@ func HandleUseCaseError(w http.ResponseWriter, err error) {
 		http.Error(w, `{"error":"Unauthorized"}`, http.StatusUnauthorized)
 	case errors.Is(err, domain.ErrValidation):
 		http.Error(w, `{"error":"Validation failed"}`, http.StatusUnprocessableEntity)
+	case errors.Is(err, domain.ErrSchoolSuspended):
+		http.Error(w, `{"error":"School is suspended"}`, http.StatusForbidden)
+	case errors.Is(err, domain.ErrUserSuspended):
+		http.Error(w, `{"error":"User account is suspended"}`, http.StatusForbidden)
+	case errors.Is(err, domain.ErrSelfRegistrationClosed):
+		Error(w, http.StatusForbidden, domain.ErrSelfRegistrationClosed.Message)
+	case errors.Is(err, domain.ErrInvitationNotFound):
+		Error(w, http.StatusNotFound, domain.ErrInvitationNotFound.Message)
+	case errors.Is(err, domain.ErrClassFull):
+		Error(w, http.StatusConflict, domain.ErrClassFull.Message)
+	case errors.Is(err, domain.ErrTermNotFound):
+		Error(w, http.StatusNotFound, domain.ErrTermNotFound.Message)
+	case errors.Is(err, domain.ErrGradeNotFound):
+		Error(w, http.StatusNotFound, domain.ErrGradeNotFound.Message)
+	case errors.Is(err, domain.ErrQuizNotFound):
+		Error(w, http.StatusNotFound, domain.ErrQuizNotFound.Message)
+	case errors.Is(err, domain.ErrQuestionNotFound):
+		Error(w, http.StatusNotFound, domain.ErrQuestionNotFound.Message)
+	case errors.Is(err, domain.ErrQuizAttemptNotFound):
+		Error(w, http.StatusNotFound, domain.ErrQuizAttemptNotFound.Message)
+	case errors.Is(err, domain.ErrQuestionInUse), errors.Is(err, domain.ErrQuizAlreadyOpened),
+		errors.Is(err, domain.ErrQuizAttemptSubmitted), errors.Is(err, domain.ErrQuizNoAttemptsLeft):
+		errors.As(err, &domainErr)
+		Error(w, http.StatusConflict, domainErr.Message)
+	case errors.Is(err, domain.ErrResourceNotFound), errors.Is(err, domain.ErrResourceFolderNotFound),
+		errors.Is(err, domain.ErrResourceVersionNotFound):
+		errors.As(err, &domainErr)
+		Error(w, http.StatusNotFound, domainErr.Message)
+	case errors.Is(err, domain.ErrResourceFolderExists), errors.Is(err, domain.ErrResourceFolderNotEmpty):
+		errors.As(err, &domainErr)
+		Error(w, http.StatusConflict, domainErr.Message)
+	case errors.Is(err, domain.ErrResourceFileTooLarge):
+		Error(w, http.StatusRequestEntityTooLarge, domain.ErrResourceFileTooLarge.Message)
+	case errors.Is(err, domain.ErrEventNotFound), errors.Is(err, domain.ErrCalendarFeedNotFound):
+		errors.As(err, &domainErr)
+		Error(w, http.StatusNotFound, domainErr.Message)
+	case errors.Is(err, domain.ErrEventSchoolWide):
+		Error(w, http.StatusForbidden, domain.ErrEventSchoolWide.Message)
+	case errors.Is(err, domain.ErrAnnouncementNotFound), errors.Is(err, domain.ErrAnnouncementAttachmentNotFound):
+		errors.As(err, &domainErr)
+		Error(w, http.StatusNotFound, domainErr.Message)
+	case errors.Is(err, domain.ErrAnnouncementFileTooLarge):
+		Error(w, http.StatusRequestEntityTooLarge, domain.ErrAnnouncementFileTooLarge.Message)
+	case errors.Is(err, domain.ErrAnnouncementTooManyFiles):
+		Error(w, http.StatusConflict, domain.ErrAnnouncementTooManyFiles.Message)
+	case errors.Is(err, domain.ErrRoomNotFound), errors.Is(err, domain.ErrEquipmentNotFound),
+		errors.Is(err, domain.ErrBookingNotFound):
+		errors.As(err, &domainErr)
+		Error(w, http.StatusNotFound, domainErr.Message)
+	case errors.Is(err, domain.ErrRoomExists), errors.Is(err, domain.ErrEquipmentExists),
+		errors.Is(err, domain.ErrBookingConflict), errors.Is(err, domain.ErrBookingNotPending),
+		errors.Is(err, domain.ErrBookingNotCancellable):
+		errors.As(err, &domainErr)
+		Error(w, http.StatusConflict, domainErr.Message)
+	case errors.Is(err, domain.ErrProfileDocumentNotFound):
+		Error(w, http.StatusNotFound, domain.ErrProfileDocumentNotFound.Message)
+	case errors.Is(err, domain.ErrProfileFieldReadOnly):
+		Error(w, http.StatusForbidden, domain.ErrProfileFieldReadOnly.Message)
+	case errors.Is(err, domain.ErrStudentNumberTaken), errors.Is(err, domain.ErrProfileTooManyDocuments):
+		errors.As(err, &domainErr)
+		Error(w, http.StatusConflict, domainErr.Message)
+	case errors.Is(err, domain.ErrStudentNumberNotFound):
+		Error(w, http.StatusNotFound, domain.ErrStudentNumberNotFound.Message)
+	case errors.Is(err, domain.ErrStudentNumberUnavailable):
+		Error(w, http.StatusConflict, domain.ErrStudentNumberUnavailable.Message)
+	case errors.Is(err, domain.ErrIncidentNotFound), errors.Is(err, domain.ErrSanctionNotFound):
+		errors.As(err, &domainErr)
+		Error(w, http.StatusNotFound, domainErr.Message)
+	case errors.Is(err, domain.ErrIncidentNotOpen), errors.Is(err, domain.ErrIncidentClosed):
+		errors.As(err, &domainErr)
+		Error(w, http.StatusConflict, domainErr.Message)
+	case errors.Is(err, domain.ErrSanctionAdminOnly):
+		Error(w, http.StatusForbidden, domain.ErrSanctionAdminOnly.Message)
+	case errors.Is(err, domain.ErrEvaluationNotFound):
+		Error(w, http.StatusNotFound, domain.ErrEvaluationNotFound.Message)
+	case errors.Is(err, domain.ErrEvaluationExists), errors.Is(err, domain.ErrEvaluationScoreAboveMax),
+		errors.Is(err, domain.ErrGradebookConflict), errors.Is(err, domain.ErrGradeInGradebook):
+		errors.As(err, &domainErr)
+		Error(w, http.StatusConflict, domainErr.Message)
+	case errors.Is(err, domain.ErrGradebookTooManyChanges):
+		Error(w, http.StatusRequestEntityTooLarge, domain.ErrGradebookTooManyChanges.Message)
+	case errors.Is(err, domain.ErrProfileDocumentTooLarge):
+		Error(w, http.StatusRequestEntityTooLarge, domain.ErrProfileDocumentTooLarge.Message)
+	case errors.Is(err, domain.ErrQuizNotOpen):
+		Error(w, http.StatusForbidden, domain.ErrQuizNotOpen.Message)
+	case errors.Is(err, domain.ErrUserNotPending):
+		Error(w, http.StatusConflict, domain.ErrUserNotPending.Message)
+	case errors.Is(err, domain.ErrTermAlreadyExists):
+		Error(w, http.StatusConflict, domain.ErrTermAlreadyExists.Message)
+	case domain.IsNotFound(err):
+		errors.As(err, &domainErr)
+		Error(w, http.StatusNotFound, domainErr.Message)
+	case errors.As(err, &domainErr):
+		//! Autres erreurs métier : validation de la requête
+		Error(w, http.StatusBadRequest, domainErr.Message)
 	default:
-		log.Printf("Internal error: %v", err)
+		slog.Error("internal error", "error", err)
 		http.Error(w, `{"error":"Internal server error"}`, http.StatusInternalServerError)
 	}
 }
@@ -0,0 +1,23 @@
+package utils
+
+import (
+	"crypto/rand"
+	"math/big"
+)
+
+// passwordAlphabet exclut les caractères ambigus à l'impression (0/O, 1/l/I)
+const passwordAlphabet = "abcdefghijkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// GeneratePassword génère un mot de passe initial aléatoire (crypto/rand)
+func GeneratePassword(length int) (string, error) {
+	max := big.NewInt(int64(len(passwordAlphabet)))
+	password := make([]byte, length)
+	for i := range password {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		password[i] = passwordAlphabet[n.Int64()]
+	}
+	return string(password), nil
+}
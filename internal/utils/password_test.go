@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGeneratePassword(t *testing.T) {
+	password, err := GeneratePassword(10)
+	if err != nil {
+		t.Fatalf("GeneratePassword() error = %v", err)
+	}
+	if len(password) != 10 {
+		t.Errorf("GeneratePassword() length = %d, want 10", len(password))
+	}
+	for _, c := range password {
+		if !strings.ContainsRune(passwordAlphabet, c) {
+			t.Errorf("GeneratePassword() unexpected character %q", c)
+		}
+	}
+
+	other, _ := GeneratePassword(10)
+	if other == password {
+		t.Error("GeneratePassword() returned the same password twice")
+	}
+}
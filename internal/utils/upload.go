@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// ! UploadDir dossier des fichiers téléversés, servi sous /uploads/
+const UploadDir = "./uploads"
+
+// ! ResourceDir fichiers de la bibliothèque de cours, hors de /uploads/ :
+// ! ils ne sont servis qu'après contrôle d'accès (téléchargement)
+const ResourceDir = "./storage/resources"
+
+// ! AnnouncementDir pièces jointes des annonces (même contrôle d'accès)
+const AnnouncementDir = "./storage/announcements"
+
+// ! ProfileDir documents des profils (certificats, CV), même contrôle d'accès
+const ProfileDir = "./storage/profiles"
+
+// ! UploadPath chemin local d'une URL /uploads/... ; false si l'URL sort du dossier
+func UploadPath(url string) (string, bool) {
+	if !strings.HasPrefix(url, "/uploads/") {
+		return "", false
+	}
+	path := filepath.Clean("." + url)
+	if !strings.HasPrefix(path, "uploads"+string(filepath.Separator)) {
+		return "", false
+	}
+	return path, true
+}
@@ -0,0 +1,24 @@
+package utils
+
+import "testing"
+
+func TestUploadPath(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+		ok   bool
+	}{
+		{"/uploads/avatars/avatar_1_10.png", "uploads/avatars/avatar_1_10.png", true},
+		{"/uploads/logos/../avatars/a.png", "uploads/avatars/a.png", true},
+		{"/uploads/../config.env", "", false},
+		{"https://cdn.example.com/a.png", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := UploadPath(tt.url)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("UploadPath(%q) = %q, %v; want %q, %v", tt.url, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -1,8 +1,11 @@
 package websocket
 
 import (
-	"log"
+	"context"
+	"log/slog"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/gorilla/websocket"
 )
@@ -16,6 +19,7 @@ type Client struct {
 	ID   int
 	Conn *websocket.Conn
 	Send chan Message
+	Ctx  context.Context //! context de session (request_id, user_id) pour les logs
 }
 
 type Room struct {
@@ -25,11 +29,58 @@ type Room struct {
 	Broadcast  chan Message
 	Register   chan *Client
 	Unregister chan *Client
+	done       chan struct{} //! fermé quand Run s'arrête (salon vide ou arrêt du serveur)
 }
 
-var rooms = make(map[int]*Room)
+var (
+	rooms   = make(map[int]*Room)
+	roomsMu sync.Mutex
+)
+
+// ! Arrêt du serveur : fermé par Shutdown (sous roomsMu), chaque Room.Run déconnecte ses
+// ! clients puis s'arrête
+var (
+	shutdown     = make(chan struct{})
+	shutdownOnce sync.Once
+	roomsRunning sync.WaitGroup
+)
+
+// ! restartCloseFrame code 1012 (service restart) : les clients doivent se reconnecter
+var restartCloseFrame = websocket.FormatCloseMessage(websocket.CloseServiceRestart, "server restarting")
+
+// ! Compteurs exposés par CurrentStats (métriques)
+var (
+	activeClients   atomic.Int64
+	activeRooms     atomic.Int64
+	droppedMessages atomic.Int64
+)
+
+// ! Stats état des salons de chat
+type Stats struct {
+	Rooms           int64 //! salons avec au moins un client
+	Clients         int64 //! connexions WebSocket enregistrées
+	DroppedMessages int64 //! messages non remis à un client trop lent (cumul)
+}
 
+func CurrentStats() Stats {
+	return Stats{
+		Rooms:           activeRooms.Load(),
+		Clients:         activeClients.Load(),
+		DroppedMessages: droppedMessages.Load(),
+	}
+}
+
+// ! GetRoom salon de la classe, créé au besoin ; nil une fois l'arrêt commencé
 func GetRoom(classID int) *Room {
+	roomsMu.Lock()
+	defer roomsMu.Unlock()
+
+	select {
+	case <-shutdown:
+		return nil
+	default:
+	}
+
 	room, exists := rooms[classID]
 	if !exists {
 		room = &Room{
@@ -38,8 +89,10 @@ func GetRoom(classID int) *Room {
 			Broadcast:  make(chan Message),
 			Register:   make(chan *Client),
 			Unregister: make(chan *Client),
+			done:       make(chan struct{}),
 		}
 		rooms[classID] = room
+		roomsRunning.Add(1)
 		go room.Run()
 	}
 	return room
@@ -49,44 +102,181 @@ func NewRoom(classID int) *Room {
 	return GetRoom(classID)
 }
 
+// ! Join inscrit le client dans le salon de la classe et retourne ce salon. Un salon
+// ! fermé entre-temps (dernier client parti) est recréé. Pendant l'arrêt, le client
+// ! reçoit la trame de redémarrage et Join retourne nil.
+func Join(classID int, client *Client) *Room {
+	for {
+		room := GetRoom(classID)
+		if room == nil {
+			sendRestart(client)
+			close(client.Send)
+			return nil
+		}
+		select {
+		case room.Register <- client:
+			return room
+		case <-room.done:
+			//! Salon vidé et retiré de rooms : le GetRoom suivant en crée un nouveau
+		}
+	}
+}
+
 func (r *Room) Run() {
+	defer roomsRunning.Done()
+	defer close(r.done)
+
 	for {
 		select {
+		case <-shutdown:
+			r.mu.RLock()
+			clients := make([]*Client, 0, len(r.Clients))
+			for client := range r.Clients {
+				clients = append(clients, client)
+			}
+			r.mu.RUnlock()
+
+			for _, client := range clients {
+				sendRestart(client)
+				r.removeClient(client)
+			}
+			return
+
 		case client := <-r.Register:
 			r.mu.Lock()
 			r.Clients[client] = true
+			count := len(r.Clients)
 			r.mu.Unlock()
-			log.Printf("Client %d joined room %d (%d total)", client.ID, r.ID, len(r.Clients))
+			activeClients.Add(1)
+			if count == 1 {
+				activeRooms.Add(1)
+			}
+			slog.DebugContext(client.Ctx, "client joined room", "room_id", r.ID, "clients", count)
 
 		case client := <-r.Unregister:
-			r.mu.Lock()
-			if _, ok := r.Clients[client]; ok {
-				delete(r.Clients, client)
-				close(client.Send)
+			if r.removeClient(client) == 0 {
+				r.close()
+				return
 			}
-			r.mu.Unlock()
-			log.Printf("Client %d left room %d (%d total)", client.ID, r.ID, len(r.Clients))
 
 		case message := <-r.Broadcast:
+			var slow []*Client
 			r.mu.RLock()
 			for client := range r.Clients {
 				select {
 				case client.Send <- message:
 				default:
-					r.mu.RUnlock()
-					r.Unregister <- client
-					r.mu.RLock()
+					slow = append(slow, client)
 				}
 			}
 			r.mu.RUnlock()
+
+			//! Client trop lent (buffer plein) : message perdu et client déconnecté.
+			//! Retiré ici directement : Run est le seul lecteur de Unregister.
+			for _, client := range slow {
+				droppedMessages.Add(1)
+				slog.WarnContext(client.Ctx, "websocket client too slow, disconnected", "room_id", r.ID)
+				r.removeClient(client)
+			}
+			if len(slow) > 0 && r.clientCount() == 0 {
+				r.close()
+				return
+			}
 		}
 	}
 }
 
+// ! close retire le salon vide de rooms ; les clients qui le rejoignent encore voient
+// ! done fermé et passent par un nouveau salon (Join)
+func (r *Room) close() {
+	roomsMu.Lock()
+	if rooms[r.ID] == r {
+		delete(rooms, r.ID)
+	}
+	roomsMu.Unlock()
+	slog.Debug("room closed", "room_id", r.ID)
+}
+
+func (r *Room) clientCount() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.Clients)
+}
+
+// ! removeClient retire le client et ferme son canal (sans effet s'il est déjà parti).
+// ! Retourne le nombre de clients restants.
+func (r *Room) removeClient(client *Client) int {
+	r.mu.Lock()
+	_, ok := r.Clients[client]
+	if ok {
+		delete(r.Clients, client)
+		close(client.Send)
+	}
+	count := len(r.Clients)
+	r.mu.Unlock()
+
+	if !ok {
+		return count
+	}
+	activeClients.Add(-1)
+	if count == 0 {
+		activeRooms.Add(-1)
+	}
+	slog.DebugContext(client.Ctx, "client left room", "room_id", r.ID, "clients", count)
+	return count
+}
+
+// ! RegisterClient pendant l'arrêt : le client reçoit directement la trame de redémarrage.
+// ! Préférer Join, qui gère aussi un salon fermé entre GetRoom et l'inscription.
 func (r *Room) RegisterClient(client *Client) {
-	r.Register <- client
+	select {
+	case r.Register <- client:
+	case <-r.done:
+		sendRestart(client)
+		close(client.Send)
+	}
 }
 
 func (r *Room) UnregisterClient(client *Client) {
-	r.Unregister <- client
+	select {
+	case r.Unregister <- client:
+	case <-r.done: //! Run arrêté : client déjà retiré
+	}
+}
+
+// ! Publish diffuse message aux clients du salon (ignoré une fois le salon arrêté)
+func (r *Room) Publish(message Message) {
+	select {
+	case r.Broadcast <- message:
+	case <-r.done:
+	}
+}
+
+// ! Shutdown envoie la trame 1012 "server restarting" à tous les clients et arrête
+// ! les salons. Attend leur fin ou l'expiration de ctx. Fermé sous roomsMu : aucun
+// ! salon n'est plus créé (roomsRunning.Add) une fois l'attente commencée.
+func Shutdown(ctx context.Context) error {
+	shutdownOnce.Do(func() {
+		roomsMu.Lock()
+		close(shutdown)
+		roomsMu.Unlock()
+	})
+
+	done := make(chan struct{})
+	go func() {
+		roomsRunning.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ! sendRestart WriteControl peut être appelé en parallèle du writePump du client
+func sendRestart(client *Client) {
+	client.Conn.WriteControl(websocket.CloseMessage, restartCloseFrame, time.Now().Add(time.Second))
 }
@@ -0,0 +1,4 @@
+--! Annule 001_init
+DROP TABLE IF EXISTS users CASCADE;
+DROP TABLE IF EXISTS schools CASCADE;
+DROP FUNCTION IF EXISTS update_updated_at_column();
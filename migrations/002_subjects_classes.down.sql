@@ -0,0 +1,5 @@
+--! Annule 002_subjects_classes
+DROP TABLE IF EXISTS student_classes;
+DROP TABLE IF EXISTS teacher_subjects;
+DROP TABLE IF EXISTS classes;
+DROP TABLE IF EXISTS subjects;
@@ -0,0 +1,4 @@
+--! Annule 004_messages
+DROP FUNCTION IF EXISTS get_recent_messages(INTEGER, INTEGER);
+DROP VIEW IF EXISTS messages_view;
+DROP TABLE IF EXISTS messages;
@@ -1,8 +1,6 @@
 --! Messages système pour classes - EducNet Realtime Chat
 --! Date: 2026-02-05
 
-BEGIN;
-
 --! Table principale des messages
 CREATE TABLE IF NOT EXISTS messages (
     id BIGSERIAL PRIMARY KEY,
@@ -22,15 +20,7 @@ CREATE INDEX idx_messages_user_id ON messages(user_id);
 CREATE INDEX idx_messages_created_at ON messages(created_at);
 CREATE INDEX idx_messages_class_created ON messages(class_id, created_at DESC);
 
---! Trigger updated_at
-CREATE OR REPLACE FUNCTION update_updated_at_column()
-RETURNS TRIGGER AS $$
-BEGIN
-    NEW.updated_at = NOW();
-    RETURN NEW;
-END;
-$$ language 'plpgsql';
-
+--! Trigger updated_at (update_updated_at_column() définie dans 001)
 CREATE TRIGGER update_messages_updated_at 
     BEFORE UPDATE ON messages 
     FOR EACH ROW 
@@ -86,5 +76,3 @@ BEGIN
     LIMIT p_limit;
 END;
 $$ LANGUAGE plpgsql;
-
-COMMIT;
@@ -0,0 +1,12 @@
+--! Annule 005_superadmin
+--! ATTENTION: supprime les comptes superadmin (school_id redevient obligatoire)
+DROP TABLE IF EXISTS audit_logs;
+
+ALTER TABLE schools DROP CONSTRAINT IF EXISTS schools_status_check;
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_school_required;
+DELETE FROM users WHERE role = 'superadmin';
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users ADD CONSTRAINT users_role_check
+    CHECK (role IN ('admin', 'teacher', 'student', 'parent'));
+ALTER TABLE users ALTER COLUMN school_id SET NOT NULL;
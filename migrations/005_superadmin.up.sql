@@ -0,0 +1,34 @@
+--! Super-admin plateforme + audit des actions cross-écoles
+--! Date: 2026-10-19
+
+--! Un superadmin n'appartient à aucune école
+ALTER TABLE users ALTER COLUMN school_id DROP NOT NULL;
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users ADD CONSTRAINT users_role_check
+    CHECK (role IN ('superadmin', 'admin', 'teacher', 'student', 'parent'));
+
+ALTER TABLE users ADD CONSTRAINT users_school_required
+    CHECK (role = 'superadmin' OR school_id IS NOT NULL);
+
+--! Statuts école: active, inactive, suspended
+ALTER TABLE schools ADD CONSTRAINT schools_status_check
+    CHECK (status IN ('active', 'inactive', 'suspended'));
+
+--! Journal d'audit (suspension, impersonation, transfert de propriété...)
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id BIGSERIAL PRIMARY KEY,
+    actor_user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    action VARCHAR(50) NOT NULL,
+    school_id INTEGER REFERENCES schools(id) ON DELETE CASCADE,
+    target_user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    details TEXT,
+    ip_address VARCHAR(64),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_audit_logs_school ON audit_logs(school_id);
+CREATE INDEX idx_audit_logs_actor ON audit_logs(actor_user_id);
+CREATE INDEX idx_audit_logs_created_at ON audit_logs(created_at DESC);
+
+COMMENT ON TABLE audit_logs IS 'Actions sensibles effectuées par les super-admins';
@@ -0,0 +1,28 @@
+--! Annule 006_row_level_security
+DROP POLICY IF EXISTS tenant_isolation ON messages;
+DROP POLICY IF EXISTS tenant_isolation ON student_classes;
+DROP POLICY IF EXISTS tenant_isolation ON teacher_subjects;
+DROP POLICY IF EXISTS tenant_isolation ON audit_logs;
+DROP POLICY IF EXISTS tenant_isolation ON classes;
+DROP POLICY IF EXISTS tenant_isolation ON subjects;
+DROP POLICY IF EXISTS tenant_isolation ON users;
+DROP POLICY IF EXISTS tenant_isolation ON schools;
+
+ALTER TABLE messages NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE messages DISABLE ROW LEVEL SECURITY;
+ALTER TABLE student_classes NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE student_classes DISABLE ROW LEVEL SECURITY;
+ALTER TABLE teacher_subjects NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE teacher_subjects DISABLE ROW LEVEL SECURITY;
+ALTER TABLE audit_logs NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE audit_logs DISABLE ROW LEVEL SECURITY;
+ALTER TABLE classes NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE classes DISABLE ROW LEVEL SECURITY;
+ALTER TABLE subjects NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE subjects DISABLE ROW LEVEL SECURITY;
+ALTER TABLE users NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE users DISABLE ROW LEVEL SECURITY;
+ALTER TABLE schools NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE schools DISABLE ROW LEVEL SECURITY;
+
+DROP FUNCTION IF EXISTS app_current_school();
@@ -0,0 +1,68 @@
+--! Isolation multi-écoles via Row-Level Security
+--! Date: 2026-10-19
+--!
+--! L'API fixe app.school_id (SET LOCAL, transaction par requête) à partir du JWT.
+--! Sans app.school_id (routes publiques, login, superadmin) l'accès n'est pas filtré.
+--! ATTENTION: un rôle SUPERUSER ou BYPASSRLS ignore ces policies,
+--! l'API doit se connecter avec un rôle applicatif ordinaire.
+
+--! École courante de la transaction (NULL si non scopée)
+CREATE OR REPLACE FUNCTION app_current_school() RETURNS INTEGER AS $$
+    SELECT NULLIF(current_setting('app.school_id', true), '')::INTEGER;
+$$ LANGUAGE sql STABLE;
+
+--! ========== TABLES AVEC school_id ==========
+ALTER TABLE schools ENABLE ROW LEVEL SECURITY;
+ALTER TABLE schools FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON schools
+    USING (app_current_school() IS NULL OR id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR id = app_current_school());
+
+ALTER TABLE users ENABLE ROW LEVEL SECURITY;
+ALTER TABLE users FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON users
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER TABLE subjects ENABLE ROW LEVEL SECURITY;
+ALTER TABLE subjects FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON subjects
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER TABLE classes ENABLE ROW LEVEL SECURITY;
+ALTER TABLE classes FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON classes
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER TABLE audit_logs ENABLE ROW LEVEL SECURITY;
+ALTER TABLE audit_logs FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON audit_logs
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+--! ========== TABLES DE LIAISON (école via la table parente) ==========
+ALTER TABLE teacher_subjects ENABLE ROW LEVEL SECURITY;
+ALTER TABLE teacher_subjects FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON teacher_subjects
+    USING (app_current_school() IS NULL OR EXISTS (
+        SELECT 1 FROM subjects s WHERE s.id = subject_id AND s.school_id = app_current_school()))
+    WITH CHECK (app_current_school() IS NULL OR EXISTS (
+        SELECT 1 FROM subjects s WHERE s.id = subject_id AND s.school_id = app_current_school()));
+
+ALTER TABLE student_classes ENABLE ROW LEVEL SECURITY;
+ALTER TABLE student_classes FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON student_classes
+    USING (app_current_school() IS NULL OR EXISTS (
+        SELECT 1 FROM classes c WHERE c.id = class_id AND c.school_id = app_current_school()))
+    WITH CHECK (app_current_school() IS NULL OR EXISTS (
+        SELECT 1 FROM classes c WHERE c.id = class_id AND c.school_id = app_current_school()));
+
+ALTER TABLE messages ENABLE ROW LEVEL SECURITY;
+ALTER TABLE messages FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON messages
+    USING (app_current_school() IS NULL OR EXISTS (
+        SELECT 1 FROM classes c WHERE c.id = class_id AND c.school_id = app_current_school()))
+    WITH CHECK (app_current_school() IS NULL OR EXISTS (
+        SELECT 1 FROM classes c WHERE c.id = class_id AND c.school_id = app_current_school()));
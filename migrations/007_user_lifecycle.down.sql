@@ -0,0 +1,7 @@
+--! Annule 007_user_lifecycle (les comptes suspendus repassent inactifs)
+ALTER TABLE users DROP COLUMN IF EXISTS must_change_password;
+
+UPDATE users SET status = 'inactive' WHERE status = 'suspended';
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_status_check;
+ALTER TABLE users ADD CONSTRAINT users_status_check
+    CHECK (status IN ('pending', 'approved', 'rejected', 'inactive'));
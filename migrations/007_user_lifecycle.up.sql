@@ -0,0 +1,9 @@
+--! Cycle de vie des comptes: suspension + réinitialisation forcée du mot de passe
+--! Date: 2026-10-19
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_status_check;
+ALTER TABLE users ADD CONSTRAINT users_status_check
+    CHECK (status IN ('pending', 'approved', 'rejected', 'inactive', 'suspended'));
+
+--! Vrai après une réinitialisation par l'admin (ou un mot de passe généré à l'import)
+ALTER TABLE users ADD COLUMN IF NOT EXISTS must_change_password BOOLEAN NOT NULL DEFAULT FALSE;
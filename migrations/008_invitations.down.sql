@@ -0,0 +1,5 @@
+--! Annule 008_invitations
+DROP TABLE IF EXISTS invitations;
+
+ALTER TABLE schools DROP CONSTRAINT IF EXISTS schools_registration_mode_check;
+ALTER TABLE schools DROP COLUMN IF EXISTS registration_mode;
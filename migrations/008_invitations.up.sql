@@ -0,0 +1,34 @@
+--! Invitations + politique d'inscription libre par école
+--! Date: 2026-10-19
+
+--! open: comptes approuvés directement | invite_only: invitation obligatoire
+--! open_with_approval: comptes en attente de validation (comportement historique)
+ALTER TABLE schools ADD COLUMN IF NOT EXISTS registration_mode VARCHAR(20) NOT NULL DEFAULT 'open_with_approval';
+ALTER TABLE schools DROP CONSTRAINT IF EXISTS schools_registration_mode_check;
+ALTER TABLE schools ADD CONSTRAINT schools_registration_mode_check
+    CHECK (registration_mode IN ('open', 'invite_only', 'open_with_approval'));
+
+CREATE TABLE IF NOT EXISTS invitations (
+    id SERIAL PRIMARY KEY,
+    school_id INTEGER NOT NULL REFERENCES schools(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('teacher', 'student')),
+    class_id INTEGER REFERENCES classes(id) ON DELETE SET NULL,
+    subject_ids INTEGER[] NOT NULL DEFAULT '{}',
+    invited_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    expires_at TIMESTAMP NOT NULL,
+    accepted_at TIMESTAMP,
+    accepted_user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_invitations_school ON invitations(school_id);
+CREATE INDEX IF NOT EXISTS idx_invitations_email ON invitations(LOWER(email));
+
+--! Isolation multi-écoles (cf. 006)
+ALTER TABLE invitations ENABLE ROW LEVEL SECURITY;
+ALTER TABLE invitations FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON invitations
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
@@ -0,0 +1,6 @@
+--! Annule 009_user_approved_at
+DROP INDEX IF EXISTS idx_users_school_approved;
+DROP INDEX IF EXISTS idx_users_school_created;
+DROP TRIGGER IF EXISTS set_users_approved_at ON users;
+DROP FUNCTION IF EXISTS set_user_approved_at();
+ALTER TABLE users DROP COLUMN IF EXISTS approved_at;
@@ -0,0 +1,24 @@
+--! Date d'approbation des comptes (statistiques: délai de validation)
+--! Date: 2026-10-19
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS approved_at TIMESTAMP;
+
+--! Renseignée uniquement au passage pending -> approved : les comptes créés
+--! déjà approuvés (admin, invitation, import) restent hors du délai moyen.
+--! Pas de rattrapage: updated_at n'indique pas la date de validation.
+CREATE OR REPLACE FUNCTION set_user_approved_at()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.status = 'approved' AND OLD.status = 'pending' THEN
+        NEW.approved_at = NOW();
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER set_users_approved_at
+    BEFORE UPDATE OF status ON users
+    FOR EACH ROW EXECUTE FUNCTION set_user_approved_at();
+
+CREATE INDEX IF NOT EXISTS idx_users_school_created ON users(school_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_users_school_approved ON users(school_id, approved_at) WHERE approved_at IS NOT NULL;
@@ -0,0 +1,2 @@
+--! Annule 010_class_waitlist
+DROP TABLE IF EXISTS class_waitlist;
@@ -0,0 +1,26 @@
+--! Capacité des classes : liste d'attente ordonnée
+--! Date: 2026-10-19
+
+--! Élèves approuvés sans place dans la classe demandée, promus dans l'ordre
+--! d'arrivée (created_at, id) dès qu'une place se libère.
+CREATE TABLE IF NOT EXISTS class_waitlist (
+    id SERIAL PRIMARY KEY,
+    class_id INTEGER NOT NULL REFERENCES classes(id) ON DELETE CASCADE,
+    student_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE(class_id, student_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_class_waitlist_order ON class_waitlist(class_id, created_at, id);
+CREATE INDEX IF NOT EXISTS idx_class_waitlist_student ON class_waitlist(student_id);
+
+COMMENT ON TABLE class_waitlist IS 'Liste d''attente des classes complètes';
+
+--! Isolation multi-écoles (cf. 006, même règle que student_classes)
+ALTER TABLE class_waitlist ENABLE ROW LEVEL SECURITY;
+ALTER TABLE class_waitlist FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON class_waitlist
+    USING (app_current_school() IS NULL OR EXISTS (
+        SELECT 1 FROM classes c WHERE c.id = class_id AND c.school_id = app_current_school()))
+    WITH CHECK (app_current_school() IS NULL OR EXISTS (
+        SELECT 1 FROM classes c WHERE c.id = class_id AND c.school_id = app_current_school()));
@@ -0,0 +1,14 @@
+--! Annule 011_enrollment_history (l'historique des inscriptions fermées est perdu)
+DROP INDEX IF EXISTS uniq_student_classes_active_class;
+DROP INDEX IF EXISTS uniq_student_classes_active_year;
+
+DELETE FROM student_classes WHERE NOT is_active;
+ALTER TABLE student_classes ADD CONSTRAINT student_classes_student_id_class_id_key UNIQUE (student_id, class_id);
+
+DROP TRIGGER IF EXISTS set_student_classes_academic_year ON student_classes;
+DROP FUNCTION IF EXISTS set_student_class_academic_year();
+
+ALTER TABLE student_classes ALTER COLUMN is_active DROP NOT NULL;
+ALTER TABLE student_classes DROP COLUMN IF EXISTS end_reason;
+ALTER TABLE student_classes DROP COLUMN IF EXISTS ended_at;
+ALTER TABLE student_classes DROP COLUMN IF EXISTS academic_year;
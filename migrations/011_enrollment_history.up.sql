@@ -0,0 +1,49 @@
+--! Historique des inscriptions : une inscription fermée n'est plus supprimée
+--! Date: 2026-10-19
+
+ALTER TABLE student_classes ADD COLUMN IF NOT EXISTS academic_year VARCHAR(20);
+ALTER TABLE student_classes ADD COLUMN IF NOT EXISTS ended_at TIMESTAMP;
+ALTER TABLE student_classes ADD COLUMN IF NOT EXISTS end_reason VARCHAR(255);
+
+--! Année scolaire recopiée de la classe (nécessaire à l'index partiel ci-dessous)
+UPDATE student_classes sc SET academic_year = c.academic_year
+FROM classes c WHERE c.id = sc.class_id AND sc.academic_year IS NULL;
+UPDATE student_classes SET is_active = TRUE WHERE is_active IS NULL;
+
+ALTER TABLE student_classes ALTER COLUMN academic_year SET NOT NULL;
+ALTER TABLE student_classes ALTER COLUMN is_active SET NOT NULL;
+
+CREATE OR REPLACE FUNCTION set_student_class_academic_year()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.academic_year IS NULL THEN
+        SELECT academic_year INTO NEW.academic_year FROM classes WHERE id = NEW.class_id;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER set_student_classes_academic_year
+    BEFORE INSERT ON student_classes
+    FOR EACH ROW EXECUTE FUNCTION set_student_class_academic_year();
+
+--! Doublons existants (plusieurs classes actives la même année) :
+--! on garde la dernière inscription créée, les autres sont fermées
+UPDATE student_classes sc SET is_active = FALSE, ended_at = NOW(),
+    end_reason = 'Closed by migration: several active classes in the same year'
+WHERE sc.is_active AND EXISTS (
+    SELECT 1 FROM student_classes newer
+    WHERE newer.student_id = sc.student_id
+        AND newer.academic_year = sc.academic_year
+        AND newer.is_active
+        AND newer.id > sc.id);
+
+--! Une seule classe active par élève et par année ; une même classe peut
+--! réapparaître dans l'historique (retour après transfert)
+ALTER TABLE student_classes DROP CONSTRAINT IF EXISTS student_classes_student_id_class_id_key;
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_student_classes_active_year
+    ON student_classes(student_id, academic_year) WHERE is_active;
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_student_classes_active_class
+    ON student_classes(student_id, class_id) WHERE is_active;
+
+COMMENT ON COLUMN student_classes.end_reason IS 'Motif de fin d''inscription (transfert, départ...)';
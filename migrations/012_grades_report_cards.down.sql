@@ -0,0 +1,5 @@
+--! Annule 012_grades_report_cards
+DROP TABLE IF EXISTS attendance_records;
+DROP TABLE IF EXISTS report_comments;
+DROP TABLE IF EXISTS grades;
+DROP TABLE IF EXISTS terms;
@@ -0,0 +1,100 @@
+--! Notes, appréciations et absences (bulletins trimestriels)
+--! Date: 2026-10-19
+
+--! Périodes de notation (trimestres / semestres) par école et année scolaire
+CREATE TABLE IF NOT EXISTS terms (
+    id SERIAL PRIMARY KEY,
+    school_id INTEGER NOT NULL REFERENCES schools(id) ON DELETE CASCADE,
+    academic_year VARCHAR(20) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    position INTEGER NOT NULL DEFAULT 1,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (end_date >= start_date),
+    UNIQUE(school_id, academic_year, name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_terms_school_year ON terms(school_id, academic_year, position);
+
+--! Une note = une évaluation (devoir, interrogation, examen)
+CREATE TABLE IF NOT EXISTS grades (
+    id SERIAL PRIMARY KEY,
+    school_id INTEGER NOT NULL REFERENCES schools(id) ON DELETE CASCADE,
+    student_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    subject_id INTEGER NOT NULL REFERENCES subjects(id) ON DELETE CASCADE,
+    class_id INTEGER NOT NULL REFERENCES classes(id) ON DELETE CASCADE,
+    term_id INTEGER NOT NULL REFERENCES terms(id) ON DELETE CASCADE,
+    teacher_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    label VARCHAR(255) NOT NULL DEFAULT '',
+    score NUMERIC(5,2) NOT NULL CHECK (score >= 0),
+    max_score NUMERIC(5,2) NOT NULL DEFAULT 20 CHECK (max_score > 0),
+    coefficient NUMERIC(4,2) NOT NULL DEFAULT 1 CHECK (coefficient > 0),
+    graded_on DATE NOT NULL DEFAULT CURRENT_DATE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (score <= max_score)
+);
+
+CREATE INDEX IF NOT EXISTS idx_grades_class_term ON grades(class_id, term_id);
+CREATE INDEX IF NOT EXISTS idx_grades_student_term ON grades(student_id, term_id);
+
+CREATE TRIGGER update_grades_updated_at BEFORE UPDATE ON grades
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+--! Appréciations du bulletin : par matière (enseignant) ou générale (subject_id NULL)
+CREATE TABLE IF NOT EXISTS report_comments (
+    id SERIAL PRIMARY KEY,
+    school_id INTEGER NOT NULL REFERENCES schools(id) ON DELETE CASCADE,
+    student_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    term_id INTEGER NOT NULL REFERENCES terms(id) ON DELETE CASCADE,
+    subject_id INTEGER REFERENCES subjects(id) ON DELETE CASCADE,
+    author_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    comment TEXT NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_report_comments
+    ON report_comments(student_id, term_id, COALESCE(subject_id, 0));
+
+--! Absences et retards (les présences ne sont pas stockées)
+CREATE TABLE IF NOT EXISTS attendance_records (
+    id SERIAL PRIMARY KEY,
+    school_id INTEGER NOT NULL REFERENCES schools(id) ON DELETE CASCADE,
+    student_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    class_id INTEGER NOT NULL REFERENCES classes(id) ON DELETE CASCADE,
+    recorded_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    date DATE NOT NULL,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('absent', 'late')),
+    justified BOOLEAN NOT NULL DEFAULT FALSE,
+    note VARCHAR(255),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE(student_id, date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_attendance_class_date ON attendance_records(class_id, date);
+
+--! Isolation multi-écoles (cf. 006)
+ALTER TABLE terms ENABLE ROW LEVEL SECURITY;
+ALTER TABLE terms FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON terms
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER TABLE grades ENABLE ROW LEVEL SECURITY;
+ALTER TABLE grades FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON grades
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER TABLE report_comments ENABLE ROW LEVEL SECURITY;
+ALTER TABLE report_comments FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON report_comments
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER TABLE attendance_records ENABLE ROW LEVEL SECURITY;
+ALTER TABLE attendance_records FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON attendance_records
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
@@ -0,0 +1,2 @@
+--! Annule 013_retention_policies
+DROP TABLE IF EXISTS retention_policies;
@@ -0,0 +1,20 @@
+--! RGPD : politiques de rétention des données par école
+--! Date: 2026-10-19
+
+--! message_retention_days NULL = messages conservés indéfiniment.
+--! La purge périodique supprime les messages plus anciens que la durée fixée.
+CREATE TABLE IF NOT EXISTS retention_policies (
+    school_id INTEGER PRIMARY KEY REFERENCES schools(id) ON DELETE CASCADE,
+    message_retention_days INTEGER CHECK (message_retention_days IS NULL OR message_retention_days > 0),
+    updated_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE retention_policies IS 'Durées de conservation des données personnelles par école';
+
+--! Isolation multi-écoles (cf. 006)
+ALTER TABLE retention_policies ENABLE ROW LEVEL SECURITY;
+ALTER TABLE retention_policies FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON retention_policies
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
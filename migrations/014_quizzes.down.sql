@@ -0,0 +1,9 @@
+--! Annule 014_quizzes (les notes issues des quiz sont supprimées)
+DELETE FROM grades WHERE quiz_id IS NOT NULL;
+DROP INDEX IF EXISTS uniq_grades_quiz_student;
+ALTER TABLE grades DROP COLUMN IF EXISTS quiz_id;
+
+DROP TABLE IF EXISTS quiz_attempts;
+DROP TABLE IF EXISTS quiz_questions;
+DROP TABLE IF EXISTS quizzes;
+DROP TABLE IF EXISTS questions;
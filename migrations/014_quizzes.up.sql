@@ -0,0 +1,110 @@
+--! QCM en ligne : banque de questions par matière, quiz minutés, tentatives
+--! Date: 2026-10-19
+
+--! Banque de questions d'une matière. choices : propositions (choix unique / multiple),
+--! answer_key : réponse attendue, jamais renvoyée aux élèves avant la clôture du quiz
+CREATE TABLE IF NOT EXISTS questions (
+    id SERIAL PRIMARY KEY,
+    school_id INTEGER NOT NULL REFERENCES schools(id) ON DELETE CASCADE,
+    subject_id INTEGER NOT NULL REFERENCES subjects(id) ON DELETE CASCADE,
+    author_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    type VARCHAR(20) NOT NULL
+        CHECK (type IN ('single_choice', 'multiple_choice', 'true_false', 'numeric', 'short_text')),
+    prompt TEXT NOT NULL,
+    choices JSONB NOT NULL DEFAULT '[]',
+    answer_key JSONB NOT NULL,
+    points NUMERIC(5,2) NOT NULL DEFAULT 1 CHECK (points > 0),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_questions_subject ON questions(school_id, subject_id);
+
+CREATE TRIGGER update_questions_updated_at BEFORE UPDATE ON questions
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+--! Quiz d'une classe : fenêtre d'ouverture, durée par tentative, nombre de tentatives.
+--! Le résultat (meilleure tentative) est reporté dans grades pour la période term_id.
+CREATE TABLE IF NOT EXISTS quizzes (
+    id SERIAL PRIMARY KEY,
+    school_id INTEGER NOT NULL REFERENCES schools(id) ON DELETE CASCADE,
+    subject_id INTEGER NOT NULL REFERENCES subjects(id) ON DELETE CASCADE,
+    class_id INTEGER NOT NULL REFERENCES classes(id) ON DELETE CASCADE,
+    term_id INTEGER NOT NULL REFERENCES terms(id) ON DELETE CASCADE,
+    teacher_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    duration_minutes INTEGER NOT NULL CHECK (duration_minutes > 0),
+    opens_at TIMESTAMP NOT NULL,
+    closes_at TIMESTAMP NOT NULL,
+    max_attempts INTEGER NOT NULL DEFAULT 1 CHECK (max_attempts > 0),
+    coefficient NUMERIC(4,2) NOT NULL DEFAULT 1 CHECK (coefficient > 0),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (closes_at > opens_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_quizzes_class ON quizzes(class_id, opens_at);
+
+CREATE TRIGGER update_quizzes_updated_at BEFORE UPDATE ON quizzes
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+--! Questions d'un quiz (une question utilisée n'est plus modifiable ni supprimable)
+CREATE TABLE IF NOT EXISTS quiz_questions (
+    quiz_id INTEGER NOT NULL REFERENCES quizzes(id) ON DELETE CASCADE,
+    question_id INTEGER NOT NULL REFERENCES questions(id) ON DELETE RESTRICT,
+    position INTEGER NOT NULL,
+    PRIMARY KEY (quiz_id, question_id)
+);
+
+--! Tentatives : deadline_at calculée par le serveur au démarrage
+--! (min(début + durée, clôture)), submitted_at NULL = en cours
+CREATE TABLE IF NOT EXISTS quiz_attempts (
+    id SERIAL PRIMARY KEY,
+    school_id INTEGER NOT NULL REFERENCES schools(id) ON DELETE CASCADE,
+    quiz_id INTEGER NOT NULL REFERENCES quizzes(id) ON DELETE CASCADE,
+    student_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    number INTEGER NOT NULL,
+    started_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    deadline_at TIMESTAMP NOT NULL,
+    submitted_at TIMESTAMP,
+    answers JSONB NOT NULL DEFAULT '[]',
+    score NUMERIC(6,2) NOT NULL DEFAULT 0,
+    max_score NUMERIC(6,2) NOT NULL DEFAULT 0,
+    UNIQUE(quiz_id, student_id, number)
+);
+
+CREATE INDEX IF NOT EXISTS idx_quiz_attempts_student ON quiz_attempts(student_id, quiz_id);
+
+--! Note issue d'un quiz : une seule par élève, mise à jour à chaque tentative
+ALTER TABLE grades ADD COLUMN IF NOT EXISTS quiz_id INTEGER REFERENCES quizzes(id) ON DELETE CASCADE;
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_grades_quiz_student
+    ON grades(quiz_id, student_id) WHERE quiz_id IS NOT NULL;
+
+--! Isolation multi-écoles (cf. 006)
+ALTER TABLE questions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE questions FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON questions
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER TABLE quizzes ENABLE ROW LEVEL SECURITY;
+ALTER TABLE quizzes FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON quizzes
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER TABLE quiz_attempts ENABLE ROW LEVEL SECURITY;
+ALTER TABLE quiz_attempts FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON quiz_attempts
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+--! Table de liaison : école via le quiz parent
+ALTER TABLE quiz_questions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE quiz_questions FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON quiz_questions
+    USING (app_current_school() IS NULL OR EXISTS (
+        SELECT 1 FROM quizzes q WHERE q.id = quiz_id AND q.school_id = app_current_school()))
+    WITH CHECK (app_current_school() IS NULL OR EXISTS (
+        SELECT 1 FROM quizzes q WHERE q.id = quiz_id AND q.school_id = app_current_school()));
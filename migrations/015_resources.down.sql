@@ -0,0 +1,4 @@
+--! Annule 015_resources (les fichiers restent dans le dossier de stockage)
+DROP TABLE IF EXISTS resource_versions;
+DROP TABLE IF EXISTS resources;
+DROP TABLE IF EXISTS resource_folders;
@@ -0,0 +1,79 @@
+--! Bibliothèque de ressources pédagogiques (fichiers versionnés, liens)
+--! Date: 2026-10-19
+
+--! Dossiers d'une matière (arborescence via parent_id)
+CREATE TABLE IF NOT EXISTS resource_folders (
+    id SERIAL PRIMARY KEY,
+    school_id INTEGER NOT NULL REFERENCES schools(id) ON DELETE CASCADE,
+    subject_id INTEGER NOT NULL REFERENCES subjects(id) ON DELETE CASCADE,
+    parent_id INTEGER REFERENCES resource_folders(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_resource_folders_name
+    ON resource_folders(subject_id, COALESCE(parent_id, 0), LOWER(name));
+
+--! Ressource d'une matière destinée à une classe (class_id) ou à tout un niveau (level).
+--! kind = 'file' : contenu dans resource_versions ; kind = 'link' : url
+CREATE TABLE IF NOT EXISTS resources (
+    id SERIAL PRIMARY KEY,
+    school_id INTEGER NOT NULL REFERENCES schools(id) ON DELETE CASCADE,
+    subject_id INTEGER NOT NULL REFERENCES subjects(id) ON DELETE CASCADE,
+    class_id INTEGER REFERENCES classes(id) ON DELETE CASCADE,
+    level VARCHAR(50) NOT NULL DEFAULT '',
+    chapter VARCHAR(255) NOT NULL DEFAULT '',
+    folder_id INTEGER REFERENCES resource_folders(id) ON DELETE SET NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    kind VARCHAR(10) NOT NULL CHECK (kind IN ('file', 'link')),
+    url TEXT NOT NULL DEFAULT '',
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    current_version INTEGER NOT NULL DEFAULT 0,
+    download_count INTEGER NOT NULL DEFAULT 0,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(), --! métadonnées / fichier uniquement (pas les téléchargements)
+    CHECK (class_id IS NOT NULL OR level <> '')
+);
+
+CREATE INDEX IF NOT EXISTS idx_resources_subject ON resources(subject_id, chapter);
+CREATE INDEX IF NOT EXISTS idx_resources_class ON resources(class_id);
+CREATE INDEX IF NOT EXISTS idx_resources_tags ON resources USING GIN (tags);
+
+--! Versions d'un fichier : remplacer le fichier ajoute une version, les anciennes restent téléchargeables
+CREATE TABLE IF NOT EXISTS resource_versions (
+    id SERIAL PRIMARY KEY,
+    resource_id INTEGER NOT NULL REFERENCES resources(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL CHECK (size_bytes > 0),
+    storage_path TEXT NOT NULL,
+    uploaded_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE(resource_id, version)
+);
+
+--! Isolation multi-écoles (cf. 006)
+ALTER TABLE resource_folders ENABLE ROW LEVEL SECURITY;
+ALTER TABLE resource_folders FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON resource_folders
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER TABLE resources ENABLE ROW LEVEL SECURITY;
+ALTER TABLE resources FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON resources
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+--! Table de liaison : école via la ressource parente
+ALTER TABLE resource_versions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE resource_versions FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON resource_versions
+    USING (app_current_school() IS NULL OR EXISTS (
+        SELECT 1 FROM resources r WHERE r.id = resource_id AND r.school_id = app_current_school()))
+    WITH CHECK (app_current_school() IS NULL OR EXISTS (
+        SELECT 1 FROM resources r WHERE r.id = resource_id AND r.school_id = app_current_school()));
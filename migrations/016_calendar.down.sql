@@ -0,0 +1,3 @@
+--! Annule 016_calendar
+DROP TABLE IF EXISTS calendar_feeds;
+DROP TABLE IF EXISTS events;
@@ -0,0 +1,52 @@
+--! Calendrier de l'école : événements (récurrents ou non) et flux iCalendar personnels
+--! Date: 2026-10-19
+
+--! Événement de l'école, ou d'une classe si class_id est renseigné (emploi du temps, sorties).
+--! Dates en UTC ; all_day : ends_at exclusif. rrule : sous-ensemble de RFC 5545 (sans "RRULE:")
+CREATE TABLE IF NOT EXISTS events (
+    id SERIAL PRIMARY KEY,
+    school_id INTEGER NOT NULL REFERENCES schools(id) ON DELETE CASCADE,
+    class_id INTEGER REFERENCES classes(id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    location VARCHAR(255) NOT NULL DEFAULT '',
+    category VARCHAR(20) NOT NULL DEFAULT 'other'
+        CHECK (category IN ('holiday', 'exam', 'meeting', 'lesson', 'other')),
+    audience VARCHAR(20) NOT NULL DEFAULT 'all'
+        CHECK (audience IN ('all', 'staff', 'students', 'parents')),
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    all_day BOOLEAN NOT NULL DEFAULT FALSE,
+    rrule VARCHAR(255) NOT NULL DEFAULT '',
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_events_school_starts ON events(school_id, starts_at);
+CREATE INDEX IF NOT EXISTS idx_events_class ON events(class_id);
+
+CREATE TRIGGER update_events_updated_at BEFORE UPDATE ON events
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+--! Flux .ics personnel : le token signé porte la version, l'incrémenter révoque l'ancien lien
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    school_id INTEGER NOT NULL REFERENCES schools(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL DEFAULT 1,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+--! Isolation multi-écoles (cf. 006)
+ALTER TABLE events ENABLE ROW LEVEL SECURITY;
+ALTER TABLE events FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON events
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER TABLE calendar_feeds ENABLE ROW LEVEL SECURITY;
+ALTER TABLE calendar_feeds FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON calendar_feeds
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
@@ -0,0 +1,4 @@
+--! Annule 017_announcements
+DROP TABLE IF EXISTS announcement_receipts;
+DROP TABLE IF EXISTS announcement_attachments;
+DROP TABLE IF EXISTS announcements;
@@ -0,0 +1,72 @@
+--! Tableau d'annonces : ciblage, publication programmée, pièces jointes, accusés de réception
+--! Date: 2026-10-19
+
+--! target : school | role (target_role) | classes (class_ids) | levels (levels) | parents.
+--! notified_at : emails envoyés (à la publication, ou par la tâche périodique si programmée)
+CREATE TABLE IF NOT EXISTS announcements (
+    id SERIAL PRIMARY KEY,
+    school_id INTEGER NOT NULL REFERENCES schools(id) ON DELETE CASCADE,
+    author_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    title VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    target VARCHAR(20) NOT NULL
+        CHECK (target IN ('school', 'role', 'classes', 'levels', 'parents')),
+    target_role VARCHAR(20) NOT NULL DEFAULT '',
+    class_ids INTEGER[] NOT NULL DEFAULT '{}',
+    levels TEXT[] NOT NULL DEFAULT '{}',
+    publish_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP,
+    requires_ack BOOLEAN NOT NULL DEFAULT FALSE,
+    notified_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (expires_at IS NULL OR expires_at > publish_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_announcements_school_publish ON announcements(school_id, publish_at DESC);
+CREATE INDEX IF NOT EXISTS idx_announcements_pending ON announcements(publish_at) WHERE notified_at IS NULL;
+
+--! Fichiers stockés hors de /uploads/ (servis après contrôle d'accès)
+CREATE TABLE IF NOT EXISTS announcement_attachments (
+    id SERIAL PRIMARY KEY,
+    announcement_id INTEGER NOT NULL REFERENCES announcements(id) ON DELETE CASCADE,
+    school_id INTEGER NOT NULL REFERENCES schools(id) ON DELETE CASCADE,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    storage_path VARCHAR(500) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_announcement_attachments_announcement ON announcement_attachments(announcement_id);
+
+--! Première lecture et accusé de réception par destinataire
+CREATE TABLE IF NOT EXISTS announcement_receipts (
+    announcement_id INTEGER NOT NULL REFERENCES announcements(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    school_id INTEGER NOT NULL REFERENCES schools(id) ON DELETE CASCADE,
+    read_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    acknowledged_at TIMESTAMP,
+    PRIMARY KEY (announcement_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_announcement_receipts_user ON announcement_receipts(user_id);
+
+--! Isolation multi-écoles (cf. 006)
+ALTER TABLE announcements ENABLE ROW LEVEL SECURITY;
+ALTER TABLE announcements FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON announcements
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER TABLE announcement_attachments ENABLE ROW LEVEL SECURITY;
+ALTER TABLE announcement_attachments FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON announcement_attachments
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER TABLE announcement_receipts ENABLE ROW LEVEL SECURITY;
+ALTER TABLE announcement_receipts FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON announcement_receipts
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
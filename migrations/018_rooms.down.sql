@@ -0,0 +1,6 @@
+--! Annule 018_rooms (l'extension btree_gist est conservée)
+DROP INDEX IF EXISTS idx_events_room;
+ALTER TABLE events DROP COLUMN IF EXISTS room_id;
+DROP TABLE IF EXISTS bookings;
+DROP TABLE IF EXISTS equipment;
+DROP TABLE IF EXISTS rooms;
@@ -0,0 +1,97 @@
+--! Salles, équipements réservables et réservations sans chevauchement
+--! Date: 2026-10-19
+
+--! btree_gist : égalité sur room_id / equipment_id dans une contrainte d'exclusion GiST
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+CREATE TABLE IF NOT EXISTS rooms (
+    id SERIAL PRIMARY KEY,
+    school_id INTEGER NOT NULL REFERENCES schools(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(20) NOT NULL DEFAULT 'classroom'
+        CHECK (type IN ('classroom', 'lab', 'gym', 'library', 'computer', 'auditorium', 'other')),
+    capacity INTEGER NOT NULL DEFAULT 0 CHECK (capacity >= 0),
+    equipment TEXT[] NOT NULL DEFAULT '{}',
+    requires_approval BOOLEAN NOT NULL DEFAULT FALSE,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_rooms_school_name ON rooms(school_id, LOWER(name));
+
+--! Une ligne par unité réservable (ex: "Vidéoprojecteur 2")
+CREATE TABLE IF NOT EXISTS equipment (
+    id SERIAL PRIMARY KEY,
+    school_id INTEGER NOT NULL REFERENCES schools(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    requires_approval BOOLEAN NOT NULL DEFAULT FALSE,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_equipment_school_name ON equipment(school_id, LOWER(name));
+
+CREATE TRIGGER update_rooms_updated_at BEFORE UPDATE ON rooms
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_equipment_updated_at BEFORE UPDATE ON equipment
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+--! Réservation d'une salle OU d'un équipement. Les réservations en attente ou approuvées
+--! d'une même ressource ne peuvent pas se chevaucher (intervalles [début, fin) : bout à bout autorisé)
+CREATE TABLE IF NOT EXISTS bookings (
+    id SERIAL PRIMARY KEY,
+    school_id INTEGER NOT NULL REFERENCES schools(id) ON DELETE CASCADE,
+    room_id INTEGER REFERENCES rooms(id) ON DELETE CASCADE,
+    equipment_id INTEGER REFERENCES equipment(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    purpose VARCHAR(255) NOT NULL DEFAULT '',
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'approved'
+        CHECK (status IN ('pending', 'approved', 'rejected', 'cancelled')),
+    reviewed_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMP,
+    review_note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK ((room_id IS NULL) <> (equipment_id IS NULL)),
+    CHECK (ends_at > starts_at),
+    CONSTRAINT bookings_room_no_overlap EXCLUDE USING gist (
+        room_id WITH =, tsrange(starts_at, ends_at) WITH &&
+    ) WHERE (room_id IS NOT NULL AND status IN ('pending', 'approved')),
+    CONSTRAINT bookings_equipment_no_overlap EXCLUDE USING gist (
+        equipment_id WITH =, tsrange(starts_at, ends_at) WITH &&
+    ) WHERE (equipment_id IS NOT NULL AND status IN ('pending', 'approved'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_bookings_school_starts ON bookings(school_id, starts_at);
+CREATE INDEX IF NOT EXISTS idx_bookings_user ON bookings(user_id);
+
+CREATE TRIGGER update_bookings_updated_at BEFORE UPDATE ON bookings
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+--! Emploi du temps : un événement (cours) peut occuper une salle
+ALTER TABLE events ADD COLUMN IF NOT EXISTS room_id INTEGER REFERENCES rooms(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_events_room ON events(room_id) WHERE room_id IS NOT NULL;
+
+--! Isolation multi-écoles (cf. 006)
+ALTER TABLE rooms ENABLE ROW LEVEL SECURITY;
+ALTER TABLE rooms FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON rooms
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER TABLE equipment ENABLE ROW LEVEL SECURITY;
+ALTER TABLE equipment FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON equipment
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER TABLE bookings ENABLE ROW LEVEL SECURITY;
+ALTER TABLE bookings FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON bookings
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
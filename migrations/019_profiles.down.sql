@@ -0,0 +1,5 @@
+--! Annule 019_profiles (les fichiers de storage/profiles ne sont pas supprimés)
+DROP TABLE IF EXISTS profile_documents;
+DROP TABLE IF EXISTS teacher_profiles;
+DROP TABLE IF EXISTS student_guardians;
+DROP TABLE IF EXISTS student_profiles;
@@ -0,0 +1,90 @@
+--! Profils étendus : élèves (état civil, matricule, responsables, médical), enseignants, documents
+--! Date: 2026-10-19
+
+CREATE TABLE IF NOT EXISTS student_profiles (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    school_id INTEGER NOT NULL REFERENCES schools(id) ON DELETE CASCADE,
+    date_of_birth DATE,
+    address TEXT NOT NULL DEFAULT '',
+    student_number VARCHAR(50),
+    medical_notes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+--! Matricule unique dans l'école
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_student_profiles_number
+    ON student_profiles(school_id, student_number) WHERE student_number IS NOT NULL;
+
+--! Responsables légaux et contacts d'urgence (sans compte), dans l'ordre de saisie
+CREATE TABLE IF NOT EXISTS student_guardians (
+    id SERIAL PRIMARY KEY,
+    school_id INTEGER NOT NULL REFERENCES schools(id) ON DELETE CASCADE,
+    student_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    full_name VARCHAR(200) NOT NULL,
+    relationship VARCHAR(50) NOT NULL DEFAULT '',
+    phone VARCHAR(30) NOT NULL DEFAULT '',
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    is_legal_guardian BOOLEAN NOT NULL DEFAULT FALSE,
+    is_emergency_contact BOOLEAN NOT NULL DEFAULT FALSE,
+    position INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_student_guardians_student ON student_guardians(student_id, position);
+
+CREATE TABLE IF NOT EXISTS teacher_profiles (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    school_id INTEGER NOT NULL REFERENCES schools(id) ON DELETE CASCADE,
+    qualifications TEXT[] NOT NULL DEFAULT '{}',
+    hire_date DATE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER update_student_profiles_updated_at BEFORE UPDATE ON student_profiles
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_teacher_profiles_updated_at BEFORE UPDATE ON teacher_profiles
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+--! Pièces du dossier (certificats, CV...), stockées hors de /uploads/
+CREATE TABLE IF NOT EXISTS profile_documents (
+    id SERIAL PRIMARY KEY,
+    school_id INTEGER NOT NULL REFERENCES schools(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL DEFAULT 'other'
+        CHECK (kind IN ('certificate', 'medical', 'identity', 'diploma', 'cv', 'other')),
+    title VARCHAR(255) NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    storage_path TEXT NOT NULL,
+    uploaded_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_profile_documents_user ON profile_documents(user_id, created_at);
+
+--! Isolation multi-écoles (cf. 006)
+ALTER TABLE student_profiles ENABLE ROW LEVEL SECURITY;
+ALTER TABLE student_profiles FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON student_profiles
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER TABLE student_guardians ENABLE ROW LEVEL SECURITY;
+ALTER TABLE student_guardians FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON student_guardians
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER TABLE teacher_profiles ENABLE ROW LEVEL SECURITY;
+ALTER TABLE teacher_profiles FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON teacher_profiles
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER TABLE profile_documents ENABLE ROW LEVEL SECURITY;
+ALTER TABLE profile_documents FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON profile_documents
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
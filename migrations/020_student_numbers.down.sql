@@ -0,0 +1,3 @@
+--! Annule 020_student_numbers (les matricules déjà attribués restent dans student_profiles)
+DROP TABLE IF EXISTS student_number_sequences;
+DROP TABLE IF EXISTS student_number_formats;
@@ -0,0 +1,35 @@
+--! Matricules : format par école et compteurs
+--! Date: 2026-10-19
+
+--! Aucune ligne = format par défaut ({YEAR}-{SEQ:5})
+CREATE TABLE IF NOT EXISTS student_number_formats (
+    school_id INTEGER PRIMARY KEY REFERENCES schools(id) ON DELETE CASCADE,
+    format VARCHAR(60) NOT NULL,
+    updated_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+--! Un compteur par portée (matricule sans le compteur, ex. "2026-6EME-{SEQ}").
+--! Il ne recule jamais : un matricule libéré (effacement RGPD) n'est pas réattribué.
+--! L'incrément par INSERT ... ON CONFLICT DO UPDATE verrouille la ligne jusqu'au
+--! commit, ce qui sérialise les validations concurrentes d'une même portée.
+CREATE TABLE IF NOT EXISTS student_number_sequences (
+    school_id INTEGER NOT NULL REFERENCES schools(id) ON DELETE CASCADE,
+    scope VARCHAR(100) NOT NULL,
+    last_value INTEGER NOT NULL CHECK (last_value > 0),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (school_id, scope)
+);
+
+--! Isolation multi-écoles (cf. 006)
+ALTER TABLE student_number_formats ENABLE ROW LEVEL SECURITY;
+ALTER TABLE student_number_formats FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON student_number_formats
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER TABLE student_number_sequences ENABLE ROW LEVEL SECURITY;
+ALTER TABLE student_number_sequences FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON student_number_sequences
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
@@ -0,0 +1,4 @@
+--! Annule 021_discipline
+DROP TABLE IF EXISTS sanctions;
+DROP TABLE IF EXISTS incidents;
+DROP TABLE IF EXISTS discipline_settings;
@@ -0,0 +1,81 @@
+--! Vie scolaire : incidents disciplinaires, sanctions et réglages par école
+--! Date: 2026-10-19
+
+--! Aucune ligne = réglages par défaut (cf. domain.DefaultDisciplineSettings)
+CREATE TABLE IF NOT EXISTS discipline_settings (
+    school_id INTEGER PRIMARY KEY REFERENCES schools(id) ON DELETE CASCADE,
+    student_visibility VARCHAR(20) NOT NULL CHECK (student_visibility IN ('none', 'sanctioned', 'all')),
+    parent_visibility VARCHAR(20) NOT NULL CHECK (parent_visibility IN ('none', 'sanctioned', 'all')),
+    escalate_severity VARCHAR(20) NOT NULL CHECK (escalate_severity IN ('minor', 'moderate', 'serious', 'critical')),
+    escalate_threshold INTEGER NOT NULL CHECK (escalate_threshold BETWEEN 0 AND 50),
+    escalate_window_days INTEGER NOT NULL CHECK (escalate_window_days BETWEEN 1 AND 365),
+    updated_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+--! L'incident est rattaché à la classe où il a eu lieu ; escalated_by NULL avec
+--! escalated_at renseigné = escalade automatique (gravité ou récidive)
+CREATE TABLE IF NOT EXISTS incidents (
+    id SERIAL PRIMARY KEY,
+    school_id INTEGER NOT NULL REFERENCES schools(id) ON DELETE CASCADE,
+    student_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    class_id INTEGER NOT NULL REFERENCES classes(id) ON DELETE CASCADE,
+    reported_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    occurred_at TIMESTAMP NOT NULL,
+    location VARCHAR(200) NOT NULL DEFAULT '',
+    description TEXT NOT NULL,
+    severity VARCHAR(20) NOT NULL CHECK (severity IN ('minor', 'moderate', 'serious', 'critical')),
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'escalated', 'resolved', 'dismissed')),
+    confidential BOOLEAN NOT NULL DEFAULT FALSE,
+    escalated_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    escalated_at TIMESTAMP,
+    escalation_reason TEXT NOT NULL DEFAULT '',
+    resolved_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    resolved_at TIMESTAMP,
+    resolution TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_incidents_student ON incidents(student_id, occurred_at);
+CREATE INDEX IF NOT EXISTS idx_incidents_class ON incidents(class_id, occurred_at);
+CREATE INDEX IF NOT EXISTS idx_incidents_school_status ON incidents(school_id, status);
+
+CREATE TRIGGER update_incidents_updated_at BEFORE UPDATE ON incidents
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+--! starts_on / ends_on : jour de retenue ou période d'exclusion (bornes incluses)
+CREATE TABLE IF NOT EXISTS sanctions (
+    id SERIAL PRIMARY KEY,
+    school_id INTEGER NOT NULL REFERENCES schools(id) ON DELETE CASCADE,
+    incident_id INTEGER NOT NULL REFERENCES incidents(id) ON DELETE CASCADE,
+    student_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('warning', 'detention', 'exclusion')),
+    starts_on DATE,
+    ends_on DATE,
+    notes TEXT NOT NULL DEFAULT '',
+    issued_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (ends_on IS NULL OR starts_on <= ends_on)
+);
+
+CREATE INDEX IF NOT EXISTS idx_sanctions_incident ON sanctions(incident_id);
+
+--! Isolation multi-écoles (cf. 006)
+ALTER TABLE discipline_settings ENABLE ROW LEVEL SECURITY;
+ALTER TABLE discipline_settings FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON discipline_settings
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER TABLE incidents ENABLE ROW LEVEL SECURITY;
+ALTER TABLE incidents FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON incidents
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER TABLE sanctions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE sanctions FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON sanctions
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
@@ -0,0 +1,7 @@
+--! Annule 022_gradebook (les notes saisies au carnet sont supprimées avec leurs évaluations)
+DELETE FROM grades WHERE evaluation_id IS NOT NULL;
+DROP INDEX IF EXISTS uniq_grades_evaluation_student;
+ALTER TABLE grades DROP COLUMN IF EXISTS version;
+ALTER TABLE grades DROP COLUMN IF EXISTS evaluation_id;
+
+DROP TABLE IF EXISTS evaluations;
@@ -0,0 +1,38 @@
+--! Carnet de notes : évaluations (colonnes) et version des notes (verrou optimiste)
+--! Date: 2026-10-19
+
+--! Une évaluation par libellé pour une classe, une matière et une période ; ses notes
+--! reprennent libellé, barème, coefficient et date (répercutés à la modification)
+CREATE TABLE IF NOT EXISTS evaluations (
+    id SERIAL PRIMARY KEY,
+    school_id INTEGER NOT NULL REFERENCES schools(id) ON DELETE CASCADE,
+    class_id INTEGER NOT NULL REFERENCES classes(id) ON DELETE CASCADE,
+    subject_id INTEGER NOT NULL REFERENCES subjects(id) ON DELETE CASCADE,
+    term_id INTEGER NOT NULL REFERENCES terms(id) ON DELETE CASCADE,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    label VARCHAR(255) NOT NULL,
+    max_score NUMERIC(5,2) NOT NULL DEFAULT 20 CHECK (max_score > 0),
+    coefficient NUMERIC(4,2) NOT NULL DEFAULT 1 CHECK (coefficient > 0),
+    graded_on DATE NOT NULL DEFAULT CURRENT_DATE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_evaluations_label
+    ON evaluations(class_id, subject_id, term_id, LOWER(label));
+
+CREATE TRIGGER update_evaluations_updated_at BEFORE UPDATE ON evaluations
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+--! Une note par élève et par évaluation ; version incrémentée à chaque modification
+ALTER TABLE grades ADD COLUMN IF NOT EXISTS evaluation_id INTEGER REFERENCES evaluations(id) ON DELETE CASCADE;
+ALTER TABLE grades ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_grades_evaluation_student
+    ON grades(evaluation_id, student_id) WHERE evaluation_id IS NOT NULL;
+
+--! Isolation multi-écoles (cf. 006)
+ALTER TABLE evaluations ENABLE ROW LEVEL SECURITY;
+ALTER TABLE evaluations FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON evaluations
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
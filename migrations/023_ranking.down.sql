@@ -0,0 +1,2 @@
+--! Annule 023_ranking
+DROP TABLE IF EXISTS ranking_settings;
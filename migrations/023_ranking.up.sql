@@ -0,0 +1,17 @@
+--! Classements et statistiques de classe : réglage de visibilité du rang pour les élèves
+--! Date: 2026-10-19
+
+--! Les classements sont calculés à la demande (cache mémoire invalidé par l'empreinte
+--! des notes, cf. idx_grades_class_term) ; seul le réglage de l'école est stocké
+CREATE TABLE IF NOT EXISTS ranking_settings (
+    school_id INTEGER PRIMARY KEY REFERENCES schools(id) ON DELETE CASCADE,
+    student_rank_visible BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE ranking_settings ENABLE ROW LEVEL SECURITY;
+ALTER TABLE ranking_settings FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON ranking_settings
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
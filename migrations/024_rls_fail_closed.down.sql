@@ -0,0 +1,179 @@
+--! Annule 024_rls_fail_closed (retour aux policies ouvertes sans école)
+
+ALTER POLICY tenant_isolation ON schools
+    USING (app_current_school() IS NULL OR id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR id = app_current_school());
+
+ALTER POLICY tenant_isolation ON users
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON subjects
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON classes
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON audit_logs
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON teacher_subjects
+    USING (app_current_school() IS NULL OR EXISTS (
+        SELECT 1 FROM subjects s WHERE s.id = subject_id AND s.school_id = app_current_school()))
+    WITH CHECK (app_current_school() IS NULL OR EXISTS (
+        SELECT 1 FROM subjects s WHERE s.id = subject_id AND s.school_id = app_current_school()));
+
+ALTER POLICY tenant_isolation ON student_classes
+    USING (app_current_school() IS NULL OR EXISTS (
+        SELECT 1 FROM classes c WHERE c.id = class_id AND c.school_id = app_current_school()))
+    WITH CHECK (app_current_school() IS NULL OR EXISTS (
+        SELECT 1 FROM classes c WHERE c.id = class_id AND c.school_id = app_current_school()));
+
+ALTER POLICY tenant_isolation ON messages
+    USING (app_current_school() IS NULL OR EXISTS (
+        SELECT 1 FROM classes c WHERE c.id = class_id AND c.school_id = app_current_school()))
+    WITH CHECK (app_current_school() IS NULL OR EXISTS (
+        SELECT 1 FROM classes c WHERE c.id = class_id AND c.school_id = app_current_school()));
+
+ALTER POLICY tenant_isolation ON invitations
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON class_waitlist
+    USING (app_current_school() IS NULL OR EXISTS (
+        SELECT 1 FROM classes c WHERE c.id = class_id AND c.school_id = app_current_school()))
+    WITH CHECK (app_current_school() IS NULL OR EXISTS (
+        SELECT 1 FROM classes c WHERE c.id = class_id AND c.school_id = app_current_school()));
+
+ALTER POLICY tenant_isolation ON terms
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON grades
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON report_comments
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON attendance_records
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON retention_policies
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON questions
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON quizzes
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON quiz_attempts
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON quiz_questions
+    USING (app_current_school() IS NULL OR EXISTS (
+        SELECT 1 FROM quizzes q WHERE q.id = quiz_id AND q.school_id = app_current_school()))
+    WITH CHECK (app_current_school() IS NULL OR EXISTS (
+        SELECT 1 FROM quizzes q WHERE q.id = quiz_id AND q.school_id = app_current_school()));
+
+ALTER POLICY tenant_isolation ON resource_folders
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON resources
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON resource_versions
+    USING (app_current_school() IS NULL OR EXISTS (
+        SELECT 1 FROM resources r WHERE r.id = resource_id AND r.school_id = app_current_school()))
+    WITH CHECK (app_current_school() IS NULL OR EXISTS (
+        SELECT 1 FROM resources r WHERE r.id = resource_id AND r.school_id = app_current_school()));
+
+ALTER POLICY tenant_isolation ON events
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON calendar_feeds
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON announcements
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON announcement_attachments
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON announcement_receipts
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON rooms
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON equipment
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON bookings
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON student_profiles
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON student_guardians
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON teacher_profiles
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON profile_documents
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON student_number_formats
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON student_number_sequences
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON discipline_settings
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON incidents
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON sanctions
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON evaluations
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON ranking_settings
+    USING (app_current_school() IS NULL OR school_id = app_current_school())
+    WITH CHECK (app_current_school() IS NULL OR school_id = app_current_school());
+
+DROP FUNCTION IF EXISTS app_rls_bypass();
@@ -0,0 +1,190 @@
+--! RLS fermée par défaut : une transaction sans école ne voit plus aucune ligne
+--! Date: 2026-10-19
+--!
+--! Jusqu'ici (006) une transaction sans app.school_id voyait et écrivait toutes les
+--! écoles : routes publiques, WebSocket, tâches de fond... L'accès plateforme est
+--! désormais explicite : app.bypass_rls = on (SET LOCAL, db.BeginPlatformTx), réservé
+--! au superadmin et aux chemins cross-tenant (login, inscription, tâches de fond).
+--! Toute nouvelle policy utilise app_rls_bypass(), jamais app_current_school() IS NULL.
+--! ATTENTION: un rôle SUPERUSER ou BYPASSRLS ignore toujours ces policies (cf. .env.example).
+
+--! Accès plateforme explicite de la transaction
+CREATE OR REPLACE FUNCTION app_rls_bypass() RETURNS BOOLEAN AS $$
+    SELECT COALESCE(current_setting('app.bypass_rls', true), '') = 'on';
+$$ LANGUAGE sql STABLE;
+
+ALTER POLICY tenant_isolation ON schools
+    USING (app_rls_bypass() OR id = app_current_school())
+    WITH CHECK (app_rls_bypass() OR id = app_current_school());
+
+ALTER POLICY tenant_isolation ON users
+    USING (app_rls_bypass() OR school_id = app_current_school())
+    WITH CHECK (app_rls_bypass() OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON subjects
+    USING (app_rls_bypass() OR school_id = app_current_school())
+    WITH CHECK (app_rls_bypass() OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON classes
+    USING (app_rls_bypass() OR school_id = app_current_school())
+    WITH CHECK (app_rls_bypass() OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON audit_logs
+    USING (app_rls_bypass() OR school_id = app_current_school())
+    WITH CHECK (app_rls_bypass() OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON teacher_subjects
+    USING (app_rls_bypass() OR EXISTS (
+        SELECT 1 FROM subjects s WHERE s.id = subject_id AND s.school_id = app_current_school()))
+    WITH CHECK (app_rls_bypass() OR EXISTS (
+        SELECT 1 FROM subjects s WHERE s.id = subject_id AND s.school_id = app_current_school()));
+
+ALTER POLICY tenant_isolation ON student_classes
+    USING (app_rls_bypass() OR EXISTS (
+        SELECT 1 FROM classes c WHERE c.id = class_id AND c.school_id = app_current_school()))
+    WITH CHECK (app_rls_bypass() OR EXISTS (
+        SELECT 1 FROM classes c WHERE c.id = class_id AND c.school_id = app_current_school()));
+
+ALTER POLICY tenant_isolation ON messages
+    USING (app_rls_bypass() OR EXISTS (
+        SELECT 1 FROM classes c WHERE c.id = class_id AND c.school_id = app_current_school()))
+    WITH CHECK (app_rls_bypass() OR EXISTS (
+        SELECT 1 FROM classes c WHERE c.id = class_id AND c.school_id = app_current_school()));
+
+ALTER POLICY tenant_isolation ON invitations
+    USING (app_rls_bypass() OR school_id = app_current_school())
+    WITH CHECK (app_rls_bypass() OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON class_waitlist
+    USING (app_rls_bypass() OR EXISTS (
+        SELECT 1 FROM classes c WHERE c.id = class_id AND c.school_id = app_current_school()))
+    WITH CHECK (app_rls_bypass() OR EXISTS (
+        SELECT 1 FROM classes c WHERE c.id = class_id AND c.school_id = app_current_school()));
+
+ALTER POLICY tenant_isolation ON terms
+    USING (app_rls_bypass() OR school_id = app_current_school())
+    WITH CHECK (app_rls_bypass() OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON grades
+    USING (app_rls_bypass() OR school_id = app_current_school())
+    WITH CHECK (app_rls_bypass() OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON report_comments
+    USING (app_rls_bypass() OR school_id = app_current_school())
+    WITH CHECK (app_rls_bypass() OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON attendance_records
+    USING (app_rls_bypass() OR school_id = app_current_school())
+    WITH CHECK (app_rls_bypass() OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON retention_policies
+    USING (app_rls_bypass() OR school_id = app_current_school())
+    WITH CHECK (app_rls_bypass() OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON questions
+    USING (app_rls_bypass() OR school_id = app_current_school())
+    WITH CHECK (app_rls_bypass() OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON quizzes
+    USING (app_rls_bypass() OR school_id = app_current_school())
+    WITH CHECK (app_rls_bypass() OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON quiz_attempts
+    USING (app_rls_bypass() OR school_id = app_current_school())
+    WITH CHECK (app_rls_bypass() OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON quiz_questions
+    USING (app_rls_bypass() OR EXISTS (
+        SELECT 1 FROM quizzes q WHERE q.id = quiz_id AND q.school_id = app_current_school()))
+    WITH CHECK (app_rls_bypass() OR EXISTS (
+        SELECT 1 FROM quizzes q WHERE q.id = quiz_id AND q.school_id = app_current_school()));
+
+ALTER POLICY tenant_isolation ON resource_folders
+    USING (app_rls_bypass() OR school_id = app_current_school())
+    WITH CHECK (app_rls_bypass() OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON resources
+    USING (app_rls_bypass() OR school_id = app_current_school())
+    WITH CHECK (app_rls_bypass() OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON resource_versions
+    USING (app_rls_bypass() OR EXISTS (
+        SELECT 1 FROM resources r WHERE r.id = resource_id AND r.school_id = app_current_school()))
+    WITH CHECK (app_rls_bypass() OR EXISTS (
+        SELECT 1 FROM resources r WHERE r.id = resource_id AND r.school_id = app_current_school()));
+
+ALTER POLICY tenant_isolation ON events
+    USING (app_rls_bypass() OR school_id = app_current_school())
+    WITH CHECK (app_rls_bypass() OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON calendar_feeds
+    USING (app_rls_bypass() OR school_id = app_current_school())
+    WITH CHECK (app_rls_bypass() OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON announcements
+    USING (app_rls_bypass() OR school_id = app_current_school())
+    WITH CHECK (app_rls_bypass() OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON announcement_attachments
+    USING (app_rls_bypass() OR school_id = app_current_school())
+    WITH CHECK (app_rls_bypass() OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON announcement_receipts
+    USING (app_rls_bypass() OR school_id = app_current_school())
+    WITH CHECK (app_rls_bypass() OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON rooms
+    USING (app_rls_bypass() OR school_id = app_current_school())
+    WITH CHECK (app_rls_bypass() OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON equipment
+    USING (app_rls_bypass() OR school_id = app_current_school())
+    WITH CHECK (app_rls_bypass() OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON bookings
+    USING (app_rls_bypass() OR school_id = app_current_school())
+    WITH CHECK (app_rls_bypass() OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON student_profiles
+    USING (app_rls_bypass() OR school_id = app_current_school())
+    WITH CHECK (app_rls_bypass() OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON student_guardians
+    USING (app_rls_bypass() OR school_id = app_current_school())
+    WITH CHECK (app_rls_bypass() OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON teacher_profiles
+    USING (app_rls_bypass() OR school_id = app_current_school())
+    WITH CHECK (app_rls_bypass() OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON profile_documents
+    USING (app_rls_bypass() OR school_id = app_current_school())
+    WITH CHECK (app_rls_bypass() OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON student_number_formats
+    USING (app_rls_bypass() OR school_id = app_current_school())
+    WITH CHECK (app_rls_bypass() OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON student_number_sequences
+    USING (app_rls_bypass() OR school_id = app_current_school())
+    WITH CHECK (app_rls_bypass() OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON discipline_settings
+    USING (app_rls_bypass() OR school_id = app_current_school())
+    WITH CHECK (app_rls_bypass() OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON incidents
+    USING (app_rls_bypass() OR school_id = app_current_school())
+    WITH CHECK (app_rls_bypass() OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON sanctions
+    USING (app_rls_bypass() OR school_id = app_current_school())
+    WITH CHECK (app_rls_bypass() OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON evaluations
+    USING (app_rls_bypass() OR school_id = app_current_school())
+    WITH CHECK (app_rls_bypass() OR school_id = app_current_school());
+
+ALTER POLICY tenant_isolation ON ranking_settings
+    USING (app_rls_bypass() OR school_id = app_current_school())
+    WITH CHECK (app_rls_bypass() OR school_id = app_current_school());
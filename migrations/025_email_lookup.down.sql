@@ -0,0 +1,2 @@
+--! Annule 025_email_lookup
+DROP FUNCTION IF EXISTS app_email_exists(TEXT);
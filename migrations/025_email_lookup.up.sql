@@ -0,0 +1,12 @@
+--! Unicité globale des emails vérifiable depuis une transaction scopée sur une école
+--! Date: 2026-10-19
+--!
+--! users.email est UNIQUE pour toute la plateforme, mais la RLS (024) limite une
+--! transaction tenant aux comptes de son école : un email pris ailleurs paraissait libre
+--! (import, invitation) puis l'INSERT échouait sur l'index unique.
+--! La fonction active l'accès plateforme pour sa seule exécution (clause SET) et ne
+--! révèle que l'existence de l'adresse, comme le ferait l'index unique.
+CREATE OR REPLACE FUNCTION app_email_exists(p_email TEXT) RETURNS BOOLEAN AS $$
+    SELECT EXISTS(SELECT 1 FROM users WHERE email = p_email);
+$$ LANGUAGE sql STABLE
+SET app.bypass_rls = 'on';
@@ -0,0 +1,2 @@
+--! Annule 026_audit_impersonator
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS impersonator_id;
@@ -0,0 +1,8 @@
+--! Auteur réel des actions effectuées pendant une impersonation
+--! Date: 2026-10-19
+--!
+--! actor_user_id reste l'utilisateur du token (admin impersoné) ; impersonator_id
+--! garde le superadmin qui agissait réellement à sa place.
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS impersonator_id INTEGER REFERENCES users(id) ON DELETE SET NULL;
+
+COMMENT ON COLUMN audit_logs.impersonator_id IS 'Superadmin ayant agi via impersonation';
@@ -0,0 +1,10 @@
+// Package migrations embarque les migrations SQL versionnées dans le binaire.
+// Convention : NNN_nom.up.sql (obligatoire) + NNN_nom.down.sql (rollback).
+// Les fichiers ne contiennent pas de BEGIN/COMMIT : le runner (internal/migrate)
+// exécute chaque migration dans sa propre transaction.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -1,3 +1,8 @@
+--! Données de démo (développement uniquement, hors migrations versionnées)
+--! Suppose une école existante d'id 5 : psql -f migrations/seeds/dev_seed.sql
+
+--! Accès plateforme (RLS, cf. 024) : le seed écrit sans école de session
+SET app.bypass_rls = on;
 
 --!Insert subjects
 INSERT INTO subjects (school_id, name, code) VALUES